@@ -0,0 +1,95 @@
+/*
+Copyright © 2025 Juan David Cabrera Duran juandavid.juandis@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"user-service/internal/adapters/anonymize"
+	"user-service/internal/adapters/backup"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/spf13/cobra"
+)
+
+var anonymizeTargetSchema string
+
+// anonymizeDumpCmd copies user data into a staging schema with PII replaced
+var anonymizeDumpCmd = &cobra.Command{
+	Use:   "anonymize-dump",
+	Short: "Copy user data into a staging schema with personal data replaced",
+	Long: `Copy the users, user_tags and user_notes tables into another schema of the
+same database, replacing personal data with deterministic fakes.
+
+The same email always becomes the same fake email for a given
+anonymize.salt, so uniqueness and relationships are preserved. Passwords
+are made unusable and note texts redacted. Existing tables in the target
+schema are replaced; nothing is written to the source schema.
+
+Examples:
+  # Refresh the staging schema
+  user-service anonymize-dump --env production
+
+  # Write into another schema
+  user-service anonymize-dump --target-schema load_test`,
+	RunE: runAnonymizeDump,
+}
+
+func init() {
+	anonymizeDumpCmd.Flags().StringVar(&anonymizeTargetSchema, "target-schema", "", "schema to write to (defaults to anonymize.target_schema)")
+	rootCmd.AddCommand(anonymizeDumpCmd)
+}
+
+func runAnonymizeDump(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	log, cfg, err := loadCommandConfig()
+	if err != nil {
+		return err
+	}
+
+	anonymizer, err := anonymize.New(cfg.Anonymize.Salt)
+	if err != nil {
+		return fmt.Errorf("anonymize.salt: %w", err)
+	}
+
+	schema := cfg.Anonymize.TargetSchema
+	if anonymizeTargetSchema != "" {
+		schema = anonymizeTargetSchema
+	}
+
+	connections, err := connectForBackup(cfg, log)
+	if err != nil {
+		return err
+	}
+	defer connections.Close()
+
+	sqlDB, err := connections.GetGormDB().DB()
+	if err != nil {
+		return err
+	}
+
+	log.Info("Starting anonymized dump...", "target_schema", schema)
+
+	var results []anonymize.TableResult
+	readOnly := pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}
+	err = backup.WithTransaction(ctx, sqlDB, readOnly, func(source *backup.PostgresDatabase) error {
+		return backup.WithTransaction(ctx, sqlDB, pgx.TxOptions{}, func(target *backup.PostgresDatabase) error {
+			results, err = anonymize.Dump(ctx, source, target, schema, anonymizer)
+			return err
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("anonymized dump failed, target schema left unchanged: %w", err)
+	}
+
+	for _, result := range results {
+		log.Info("Table copied",
+			"table", result.Table,
+			"rows", result.Rows,
+			"anonymized_columns", result.Anonymized)
+	}
+	log.Info("Anonymized dump completed", "target_schema", schema)
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2025 Juan David Cabrera Duran juandavid.juandis@gmail.com
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"user-service/internal/adapters/bench"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	benchOptions    bench.Options
+	benchThresholds bench.Thresholds
+	benchOutput     string
+)
+
+// benchCmd load-tests a running instance
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Load-test a running instance and report latency and error rates",
+	Long: `Load-test a running instance through its public user API at a fixed
+request rate and report latency percentiles and error rates per operation.
+
+Scenarios:
+  create  sign up new users
+  read    fetch users created before the run
+  mixed   one sign-up for every four reads
+
+Users created by a run are not removed, so run it against disposable
+environments. Lift security.rate_limit_rps on the target, or disable
+rate_limit for /api/v1/users, when offering more than it allows per client.
+
+Thresholds make the command fail when exceeded, for regression checks in CI.
+
+Examples:
+  user-service bench --target http://localhost:8080 --scenario read --rps 200
+
+  # Fail the pipeline when p99 exceeds 250ms or more than 1% of requests fail
+  user-service bench --target http://user-service:8080 --scenario mixed --rps 100 \
+    --duration 2m --max-p99 250ms --max-error-rate 0.01 --output json`,
+	RunE: runBench,
+}
+
+func init() {
+	flags := benchCmd.Flags()
+	flags.StringVar(&benchOptions.Target, "target", "", "base URL of the instance, e.g. http://localhost:8080")
+	flags.StringVar(&benchOptions.Scenario, "scenario", bench.ScenarioMixed, "create, read or mixed")
+	flags.IntVar(&benchOptions.RPS, "rps", 50, "requests per second to offer")
+	flags.DurationVar(&benchOptions.Duration, "duration", 30*time.Second, "how long to offer requests")
+	flags.IntVar(&benchOptions.Concurrency, "concurrency", 64, "maximum requests in flight")
+	flags.DurationVar(&benchOptions.Timeout, "timeout", 10*time.Second, "timeout per request")
+	flags.StringVar(&benchOptions.APIKey, "api-key", "", "API key sent with every request")
+	flags.Float64Var(&benchThresholds.MaxErrorRate, "max-error-rate", 0, "fail when an operation's error rate exceeds this fraction")
+	flags.DurationVar(&benchThresholds.MaxP99, "max-p99", 0, "fail when an operation's p99 latency exceeds this")
+	flags.Float64Var(&benchThresholds.MaxDroppedRate, "max-dropped-rate", 0, "fail when more than this fraction of requests is dropped")
+	flags.StringVar(&benchOutput, "output", "text", "report format: text or json")
+	_ = benchCmd.MarkFlagRequired("target")
+
+	rootCmd.AddCommand(benchCmd)
+}
+
+func runBench(cmd *cobra.Command, args []string) error {
+	if benchOutput != "text" && benchOutput != "json" {
+		return fmt.Errorf("output %q must be text or json", benchOutput)
+	}
+
+	runner, err := bench.NewRunner(benchOptions)
+	if err != nil {
+		return err
+	}
+	report, err := runner.Run(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if err := writeBenchReport(cmd, report); err != nil {
+		return err
+	}
+
+	if violations := report.Check(benchThresholds); len(violations) > 0 {
+		return fmt.Errorf("thresholds exceeded: %s", strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+func writeBenchReport(cmd *cobra.Command, report *bench.Report) error {
+	if benchOutput == "json" {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	out := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+	fmt.Fprintf(out, "%s scenario against %s: %.1f of %d rps for %.1fs, %d dropped\n\n",
+		report.Scenario, report.Target, report.AchievedRPS, report.TargetRPS, report.DurationSeconds, report.Dropped)
+	fmt.Fprintln(out, "OPERATION\tREQUESTS\tERRORS\tERROR RATE\tP50\tP90\tP95\tP99\tMAX\tSTATUSES")
+	for _, operation := range slices.Sorted(maps.Keys(report.Operations)) {
+		o := report.Operations[operation]
+		statuses := make([]string, 0, len(o.Statuses))
+		for _, status := range slices.Sorted(maps.Keys(o.Statuses)) {
+			statuses = append(statuses, fmt.Sprintf("%s=%d", status, o.Statuses[status]))
+		}
+		fmt.Fprintf(out, "%s\t%d\t%d\t%.2f%%\t%.1fms\t%.1fms\t%.1fms\t%.1fms\t%.1fms\t%s\n",
+			operation, o.Requests, o.Errors, o.ErrorRate*100,
+			o.Latency.P50, o.Latency.P90, o.Latency.P95, o.Latency.P99, o.Latency.Max,
+			strings.Join(statuses, " "))
+	}
+	return out.Flush()
+}
@@ -0,0 +1,24 @@
+/*
+Copyright © 2025 Juan David Cabrera Duran juandavid.juandis@gmail.com
+*/
+package cmd
+
+import (
+	"user-service/internal/config"
+	"user-service/pkg/logger"
+)
+
+// configureLogger rebuilds the bootstrap logger from the logging configuration,
+// keeping the bootstrap logger if the configuration is invalid
+func configureLogger(bootstrap logger.Logger, cfg *config.Config) logger.Logger {
+	log, err := logger.NewWithOptions(env, logger.Options{
+		Level:      cfg.Logging.Level,
+		Format:     cfg.Logging.Format,
+		Components: cfg.Logging.Components,
+	})
+	if err != nil {
+		bootstrap.Warn("Invalid logging configuration, keeping defaults", "error", err)
+		return bootstrap
+	}
+	return log
+}
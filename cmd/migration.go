@@ -4,14 +4,19 @@ Copyright © 2025 Juan David Cabrera Duran juandavid.juandis@gmail.com
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"user-service/internal/adapters/persistence/schema"
 	"user-service/internal/adapters/persistence/user_repository"
+	"user-service/internal/adapters/searchtoken"
 
 	"user-service/internal/config"
 	"user-service/internal/infrastructure"
 	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
 
 	"github.com/spf13/cobra"
+	"gorm.io/gorm"
 )
 
 var (
@@ -29,6 +34,7 @@ This command will:
 - Add new columns to existing tables  
 - Update column types if needed
 - Create indexes
+- Recompute the phone search tokens, e.g. after search_tokens.key changed
 
 Examples:
   # Run migrations
@@ -50,19 +56,25 @@ func runMigration(cmd *cobra.Command, args []string) error {
 	log.Info("Starting database migration...")
 
 	// Load configuration
-	cfg, err := config.Load(configFile, env)
+	cfg, err := config.Load(configFile, env, overrides)
 	if err != nil {
 		log.Fatal("Failed to load configuration", "error", err)
 		return err
 	}
 
+	log = configureLogger(log, cfg)
+
 	log.Info("Configuration loaded",
 		"env", cfg.Environment,
 		"database", cfg.Database.Database)
 
+	// Migrations only touch PostgreSQL; don't require the broker or cache to be up
+	cfg.Messaging.Enabled = false
+	cfg.Cache.Enabled = false
+
 	// Initialize database connections
 	log.Info("Initializing database connections...")
-	connections, err := infrastructure.NewDatabaseConnections(cfg, log)
+	connections, err := infrastructure.NewDatabaseConnections(cfg, log, metrics.NewRegistry())
 	if err != nil {
 		log.Fatal("Failed to initialize database connections", "error", err)
 		return err
@@ -77,7 +89,12 @@ func runMigration(cmd *cobra.Command, args []string) error {
 
 	log.Info("Database connection established successfully")
 
-	if err := runDatabaseMigrations(connections, log); err != nil {
+	tokens, err := searchtoken.FromConfig(cfg.SearchTokens, log)
+	if err != nil {
+		return err
+	}
+
+	if err := runDatabaseMigrations(cmd.Context(), connections, cfg.Version, tokens, log); err != nil {
 		log.Error("Migration failed", "error", err)
 		return err
 	}
@@ -86,25 +103,59 @@ func runMigration(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func runDatabaseMigrations(connections *infrastructure.DatabaseConnections, log logger.Logger) error {
-	// Get the GORM database instance
-	db := connections.GetGormDB()
-
-	models := getAllModels()
+func runDatabaseMigrations(ctx context.Context, connections *infrastructure.DatabaseConnections, serviceVersion string, tokens *searchtoken.Tokenizer, log logger.Logger) error {
+	models := schema.Models()
 
 	log.Info("Running AutoMigrate", "models_count", len(models))
 
-	if err := db.AutoMigrate(models...); err != nil {
+	version, err := schema.Migrate(ctx, connections.GetGormDB(), models, serviceVersion)
+	if err != nil {
 		return fmt.Errorf("failed to run AutoMigrate: %w", err)
 	}
+	log.Info("Schema version recorded", "checksum", version.Checksum, "service_version", version.ServiceVersion)
+
+	if err := backfillPhoneTokens(ctx, connections.GetGormDB(), tokens, log); err != nil {
+		return err
+	}
+	if err := backfillReferralCodes(ctx, connections.GetGormDB(), log); err != nil {
+		return err
+	}
+
+	// Regional databases only hold the users resident there
+	for region, regionalDB := range connections.GetRegionalGormDBs() {
+		log.Info("Running AutoMigrate for region", "region", region)
+		if _, err := schema.Migrate(ctx, regionalDB, schema.RegionalModels(), serviceVersion); err != nil {
+			return fmt.Errorf("failed to run AutoMigrate for region %s: %w", region, err)
+		}
+		if err := backfillPhoneTokens(ctx, regionalDB, tokens, log.With("region", string(region))); err != nil {
+			return err
+		}
+		if err := backfillReferralCodes(ctx, regionalDB, log.With("region", string(region))); err != nil {
+			return err
+		}
+	}
 
 	log.Info("All migrations completed successfully")
 	return nil
 }
 
-// getAllModels returns all database models that need migration
-func getAllModels() []interface{} {
-	return []interface{}{
-		&user_repository.UserModel{},
+// backfillPhoneTokens recomputes the phone search tokens, which is needed once
+// after they are introduced and after every key change
+func backfillPhoneTokens(ctx context.Context, db *gorm.DB, tokens *searchtoken.Tokenizer, log logger.Logger) error {
+	updated, err := user_repository.BackfillPhoneTokens(ctx, db, tokens)
+	if err != nil {
+		return fmt.Errorf("failed to backfill phone tokens: %w", err)
+	}
+	log.Info("Phone search tokens backfilled", "updated", updated)
+	return nil
+}
+
+// backfillReferralCodes gives users stored before referral codes existed one
+func backfillReferralCodes(ctx context.Context, db *gorm.DB, log logger.Logger) error {
+	updated, err := user_repository.BackfillReferralCodes(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to backfill referral codes: %w", err)
 	}
+	log.Info("Referral codes backfilled", "updated", updated)
+	return nil
 }
@@ -20,6 +20,7 @@ var (
 	configFile string
 	port       string
 	env        string
+	overrides  []string
 )
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -35,4 +36,5 @@ func init() {
 	// Add persistent flags
 	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "config file path")
 	rootCmd.PersistentFlags().StringVar(&env, "env", "development", "environment (development, staging, production)")
+	rootCmd.PersistentFlags().StringArrayVar(&overrides, "set", nil, "override a config value, e.g. --set database.max_open_conns=50 (repeatable, wins over files and environment)")
 }
@@ -0,0 +1,58 @@
+/*
+Copyright © 2025 Juan David Cabrera Duran juandavid.juandis@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"user-service/internal/adapters/http"
+	"user-service/internal/config"
+	"user-service/internal/infrastructure"
+	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
+
+	"github.com/spf13/cobra"
+)
+
+// routesCmd prints the routes the server would register
+var routesCmd = &cobra.Command{
+	Use:   "routes",
+	Short: "Print every route and its effective middleware chain",
+	Long: `Print every route the server registers with the middlewares it runs,
+outermost first, after the middleware configuration is applied.
+
+The server is built without connecting to any database, so the command
+also checks the configuration offline. Logs go to stderr.
+
+Example:
+  user-service routes --env production`,
+	RunE: runRoutes,
+}
+
+func init() {
+	rootCmd.AddCommand(routesCmd)
+}
+
+func runRoutes(cmd *cobra.Command, args []string) error {
+	log := logger.New(env)
+	cfg, err := config.Load(configFile, env, overrides)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	log = configureLogger(log, cfg)
+
+	server, err := http.NewServer(cfg, log, infrastructure.NewDisconnectedConnections(log), metrics.NewRegistry())
+	if err != nil {
+		return fmt.Errorf("failed to create server: %w", err)
+	}
+
+	out := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+	fmt.Fprintln(out, "METHOD\tPATH\tMIDDLEWARE")
+	for _, route := range server.Routes() {
+		fmt.Fprintf(out, "%s\t%s\t%s\n", route.Method, route.Path, strings.Join(route.Middleware, " > "))
+	}
+	return out.Flush()
+}
@@ -0,0 +1,114 @@
+/*
+Copyright © 2025 Juan David Cabrera Duran juandavid.juandis@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"user-service/internal/scaffold"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	scaffoldDir    string
+	scaffoldPlural string
+	scaffoldDryRun bool
+)
+
+// scaffoldCmd groups the code generators
+var scaffoldCmd = &cobra.Command{
+	Use:   "scaffold",
+	Short: "Generate code for new parts of the service",
+}
+
+// scaffoldResourceCmd generates a user sub-resource
+var scaffoldResourceCmd = &cobra.Command{
+	Use:   "resource <name>",
+	Short: "Generate a user sub-resource across every layer",
+	Long: `Generate a sub-resource of users, such as addresses, with its entity, domain
+errors, repository port, GORM model and repository, DTOs, use cases, use case
+tests and handler, and wire it into the server, the schema, the error mapping
+and the error contract tests.
+
+The generated resource has a single name field and CRUD routes under
+/api/v1/users/:id/<plural>; extend the entity and its layers from there.
+Nothing is written when any of the files already exists.
+
+Examples:
+  # Generate addresses from the repository root
+  user-service scaffold resource address
+
+  # Give an irregular plural and only list the files
+  user-service scaffold resource person --plural people --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runScaffoldResource,
+}
+
+// scaffoldErrorCatalogCmd regenerates the domain error catalog
+var scaffoldErrorCatalogCmd = &cobra.Command{
+	Use:   "error-catalog",
+	Short: "Regenerate the catalog of domain errors",
+	Long: `Regenerate internal/domain/errors/catalog.go, which lists every domain error
+for the GET /api/v1/errors endpoint. Run it after adding or removing a domain
+error; "go generate ./internal/domain/errors" runs it too. Resources generated
+by "scaffold resource" are added to the catalog already.`,
+	Args: cobra.NoArgs,
+	RunE: runScaffoldErrorCatalog,
+}
+
+func init() {
+	scaffoldErrorCatalogCmd.Flags().StringVar(&scaffoldDir, "dir", ".", "root of the repository to generate into")
+	scaffoldCmd.AddCommand(scaffoldErrorCatalogCmd)
+	scaffoldResourceCmd.Flags().StringVar(&scaffoldDir, "dir", ".", "root of the repository to generate into")
+	scaffoldResourceCmd.Flags().StringVar(&scaffoldPlural, "plural", "", "plural of the name, when not the regular English one")
+	scaffoldResourceCmd.Flags().BoolVar(&scaffoldDryRun, "dry-run", false, "list the files that would be written without writing them")
+	scaffoldCmd.AddCommand(scaffoldResourceCmd)
+	rootCmd.AddCommand(scaffoldCmd)
+}
+
+func runScaffoldResource(cmd *cobra.Command, args []string) error {
+	names, err := scaffold.NewNames(args[0], scaffoldPlural)
+	if err != nil {
+		return err
+	}
+
+	var paths []string
+	if scaffoldDryRun {
+		outputs, err := scaffold.Plan(scaffoldDir, names)
+		if err != nil {
+			return err
+		}
+		for path := range outputs {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+	} else if paths, err = scaffold.Generate(scaffoldDir, names); err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		fmt.Fprintln(cmd.OutOrStdout(), path)
+	}
+	if !scaffoldDryRun {
+		fmt.Fprintf(cmd.OutOrStdout(), "\nGenerated %s; run the migrations to create the user_%s table\n", names.Human, names.PluralSnake)
+		fmt.Fprintln(cmd.OutOrStdout(), "Translate its error messages in internal/domain/errors/messages")
+	}
+	return nil
+}
+
+func runScaffoldErrorCatalog(cmd *cobra.Command, args []string) error {
+	catalog, err := scaffold.ErrorCatalog(scaffoldDir, nil)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(scaffoldDir, scaffold.ErrorCatalogPath), catalog, 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), scaffold.ErrorCatalogPath)
+	return nil
+}
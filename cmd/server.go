@@ -13,6 +13,7 @@ import (
 	"user-service/internal/config"
 	"user-service/internal/infrastructure"
 	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
 
 	"github.com/spf13/cobra"
 )
@@ -39,12 +40,14 @@ func runServer(cmd *cobra.Command, args []string) error {
 	log.Info("Starting Identity Service...")
 
 	// Load configuration
-	cfg, err := config.Load(configFile, env)
+	cfg, err := config.Load(configFile, env, overrides)
 	if err != nil {
 		log.Fatal("Failed to load configuration", "error", err)
 		return err
 	}
 
+	log = configureLogger(log, cfg)
+
 	// Override port if provided via flag
 	if cmd.Flags().Changed("port") {
 		cfg.Server.Port = port
@@ -56,9 +59,11 @@ func runServer(cmd *cobra.Command, args []string) error {
 		"port", cfg.Server.Port,
 		"log_level", cfg.Logging.Level)
 
+	registry := metrics.NewRegistry()
+
 	// Initialize database connections
 	log.Info("Initializing database connections...")
-	connections, err := infrastructure.NewDatabaseConnections(cfg, log)
+	connections, err := infrastructure.NewDatabaseConnections(cfg, log, registry)
 	if err != nil {
 		log.Fatal("Failed to initialize database connections", "error", err)
 		return err
@@ -72,7 +77,7 @@ func runServer(cmd *cobra.Command, args []string) error {
 	}()
 
 	// Create HTTP server with database connections
-	server, err := http.NewServer(cfg, log, connections) // Updated
+	server, err := http.NewServer(cfg, log, connections, registry) // Updated
 	if err != nil {
 		log.Fatal("Failed to create server", "error", err)
 		return err
@@ -85,7 +90,9 @@ func runServer(cmd *cobra.Command, args []string) error {
 		}
 	}()
 
-	log.Info("Server started successfully", "port", cfg.Server.Port)
+	reportCtx, cancelReport := context.WithTimeout(context.Background(), 5*time.Second)
+	infrastructure.NewStartupReport(reportCtx, cfg, connections).Log(log)
+	cancelReport()
 
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
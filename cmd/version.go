@@ -16,10 +16,11 @@ var versionCmd = &cobra.Command{
 	Short: "Print version information",
 	Run: func(cmd *cobra.Command, args []string) {
 		log := logger.New(env)
-		cfg, err := config.Load(configFile, env)
+		cfg, err := config.Load(configFile, env, overrides)
 		if err != nil {
 			log.Fatal("Failed to load configuration", "error", err)
 		}
+		log = configureLogger(log, cfg)
 		log.Info(fmt.Sprintf("version: %s", cfg.Version))
 	},
 }
@@ -0,0 +1,130 @@
+package anonymize
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// UnusablePassword replaces password hashes; it never matches a bcrypt check
+const UnusablePassword = "!anonymized"
+
+var firstNames = []string{
+	"Alex", "Bailey", "Casey", "Dana", "Eden", "Finley", "Gray", "Harper",
+	"Indigo", "Jordan", "Kai", "Logan", "Morgan", "Noel", "Oakley", "Parker",
+	"Quinn", "Riley", "Sage", "Taylor", "Umber", "Val", "Winter", "Yael",
+}
+
+var lastNames = []string{
+	"Abbott", "Barker", "Carver", "Dalton", "Ellis", "Fletcher", "Garner", "Hayes",
+	"Irwin", "Jensen", "Keller", "Lowell", "Mercer", "Nolan", "Osborne", "Pryce",
+	"Quill", "Rowe", "Sutton", "Thorne", "Upton", "Vance", "Walsh", "Young",
+}
+
+// Anonymizer derives fake values from real ones. The same input always maps
+// to the same fake, so relationships and uniqueness survive, while the keyed
+// hash keeps fakes from being reversed by hashing candidate values.
+type Anonymizer struct {
+	key []byte
+}
+
+// New creates an anonymizer; fakes are only stable across runs using the same salt
+func New(salt string) (*Anonymizer, error) {
+	if len(salt) < 16 {
+		return nil, fmt.Errorf("anonymization salt must be at least 16 characters")
+	}
+	return &Anonymizer{key: []byte(salt)}, nil
+}
+
+func (a *Anonymizer) digest(kind, value string) []byte {
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(kind + ":" + value))
+	return mac.Sum(nil)
+}
+
+func (a *Anonymizer) pick(kind, value string, options []string) string {
+	sum := a.digest(kind, value)
+	return options[binary.BigEndian.Uint64(sum[:8])%uint64(len(options))]
+}
+
+// Email returns a fake address on a reserved domain; emails differing only
+// in case or surrounding spaces map to the same fake
+func (a *Anonymizer) Email(email string) string {
+	normalized := strings.ToLower(strings.TrimSpace(email))
+	return "user-" + hex.EncodeToString(a.digest("email", normalized)[:8]) + "@example.test"
+}
+
+// FirstName returns a fake first name
+func (a *Anonymizer) FirstName(name string) string {
+	return a.pick("first_name", name, firstNames)
+}
+
+// LastName returns a fake last name
+func (a *Anonymizer) LastName(name string) string {
+	return a.pick("last_name", name, lastNames)
+}
+
+// Phone returns a fake number in the 555 fictional range, keeping empty values empty
+func (a *Anonymizer) Phone(phone string) string {
+	if phone == "" {
+		return ""
+	}
+	sum := a.digest("phone", phone)
+	return fmt.Sprintf("+1555%07d", binary.BigEndian.Uint32(sum[:4])%10_000_000)
+}
+
+// DisplayName returns a fake first name, keeping empty values empty
+func (a *Anonymizer) DisplayName(name string) string {
+	if name == "" {
+		return ""
+	}
+	return a.pick("display_name", name, firstNames)
+}
+
+// DateOfBirth moves a date to the first of January of its year, keeping the
+// age brackets age gating depends on roughly intact
+func (a *Anonymizer) DateOfBirth(date string) string {
+	if len(date) < len("2006-01-02") {
+		return date
+	}
+	return date[:4] + "-01-01"
+}
+
+// Rule rewrites one column value
+type Rule func(value string) string
+
+// Rules returns the rewrites applied per table and column. Tables without
+// rules are copied unchanged; columns without a rule keep their value.
+func (a *Anonymizer) Rules() map[string]map[string]Rule {
+	return map[string]map[string]Rule{
+		"users": {
+			"email":      a.Email,
+			"first_name": a.FirstName,
+			"last_name":  a.LastName,
+			"phone":      a.Phone,
+			// Phone tokens are keyed hashes of the real number; the migrate
+			// command derives them again from the fake one
+			"phone_token": func(string) string { return "" },
+			"password":    func(string) string { return UnusablePassword },
+			// Display names are often real names; pronouns are dropped
+			"display_name":  a.DisplayName,
+			"pronouns":      func(string) string { return "" },
+			"date_of_birth": a.DateOfBirth,
+			// Free text written by staff about the user, and who placed a hold
+			"legal_hold_reason":    func(string) string { return "[redacted]" },
+			"legal_hold_placed_by": func(author string) string { return a.FirstName(author) },
+			"suspension_note":      func(string) string { return "" },
+		},
+		"user_notes": {
+			"author": func(author string) string { return a.FirstName(author) },
+			"text":   func(string) string { return "[redacted]" },
+		},
+		"email_suppressions": {
+			"email": a.Email,
+			"note":  func(string) string { return "[redacted]" },
+		},
+	}
+}
@@ -0,0 +1,97 @@
+package anonymize
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSalt = "0123456789abcdef-test"
+
+func TestNew_RejectsShortSalt(t *testing.T) {
+	_, err := New("short")
+
+	assert.Error(t, err)
+}
+
+func TestAnonymizer_EmailIsDeterministic(t *testing.T) {
+	// Given
+	anonymizer, err := New(testSalt)
+	require.NoError(t, err)
+
+	// When
+	first := anonymizer.Email("Jane.Doe@Example.com")
+	second := anonymizer.Email("  jane.doe@example.com ")
+
+	// Then
+	assert.Equal(t, first, second)
+	assert.Regexp(t, regexp.MustCompile(`^user-[0-9a-f]{16}@example\.test$`), first)
+	assert.NotEqual(t, first, anonymizer.Email("john@example.com"))
+}
+
+func TestAnonymizer_FakesDependOnSalt(t *testing.T) {
+	// Given
+	one, err := New(testSalt)
+	require.NoError(t, err)
+	other, err := New(testSalt + "-other")
+	require.NoError(t, err)
+
+	// Then
+	assert.NotEqual(t, one.Email("jane@example.com"), other.Email("jane@example.com"))
+	assert.NotEqual(t, one.Phone("+34600111222"), other.Phone("+34600111222"))
+}
+
+func TestAnonymizer_Phone(t *testing.T) {
+	anonymizer, err := New(testSalt)
+	require.NoError(t, err)
+
+	assert.Empty(t, anonymizer.Phone(""))
+	assert.Regexp(t, regexp.MustCompile(`^\+1555\d{7}$`), anonymizer.Phone("+34600111222"))
+	assert.Equal(t, anonymizer.Phone("+34600111222"), anonymizer.Phone("+34600111222"))
+}
+
+func TestAnonymizer_Names(t *testing.T) {
+	anonymizer, err := New(testSalt)
+	require.NoError(t, err)
+
+	assert.Contains(t, firstNames, anonymizer.FirstName("Jane"))
+	assert.Contains(t, lastNames, anonymizer.LastName("Doe"))
+	assert.Equal(t, anonymizer.FirstName("Jane"), anonymizer.FirstName("Jane"))
+	assert.Contains(t, firstNames, anonymizer.DisplayName("JJ"))
+	assert.Empty(t, anonymizer.DisplayName(""))
+}
+
+func TestAnonymizer_DateOfBirth(t *testing.T) {
+	anonymizer, err := New(testSalt)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2009-01-01", anonymizer.DateOfBirth("2009-07-23"))
+}
+
+func TestAnonymizer_RulesClearPhoneTokens(t *testing.T) {
+	anonymizer, err := New(testSalt)
+	require.NoError(t, err)
+
+	rule, ok := anonymizer.Rules()["users"]["phone_token"]
+
+	require.True(t, ok, "tokens of real numbers must not survive")
+	assert.Empty(t, rule("3f9a6c1e0b7d2a48"))
+}
+
+func TestAnonymizer_RulesRedactStaffNotes(t *testing.T) {
+	anonymizer, err := New(testSalt)
+	require.NoError(t, err)
+	rules := anonymizer.Rules()["users"]
+
+	for column, value := range map[string]string{
+		"legal_hold_reason":    "Subpoena in Doe v. Roe",
+		"legal_hold_placed_by": "jane.counsel",
+		"suspension_note":      "Chargeback on card ending 4242",
+	} {
+		rule, ok := rules[column]
+		require.True(t, ok, column)
+		assert.NotContains(t, rule(value), value, column)
+	}
+}
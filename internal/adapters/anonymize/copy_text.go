@@ -0,0 +1,63 @@
+package anonymize
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// nullField is how COPY text format writes NULL
+const nullField = `\N`
+
+var (
+	copyUnescaper = strings.NewReplacer(`\\`, `\`, `\t`, "\t", `\n`, "\n", `\r`, "\r", `\b`, "\b", `\f`, "\f", `\v`, "\v")
+	copyEscaper   = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`, "\b", `\b`, "\f", `\f`, "\v", `\v`)
+)
+
+// rowRewriter applies rules to a stream in COPY text format: one row per
+// line, tab separated, with backslash escapes
+type rowRewriter struct {
+	w       io.Writer
+	rules   []Rule
+	pending []byte
+	rows    int64
+}
+
+func newRowRewriter(w io.Writer, columns []string, rules map[string]Rule) *rowRewriter {
+	byIndex := make([]Rule, len(columns))
+	for i, column := range columns {
+		byIndex[i] = rules[column]
+	}
+	return &rowRewriter{w: w, rules: byIndex}
+}
+
+func (r *rowRewriter) Write(p []byte) (int, error) {
+	r.pending = append(r.pending, p...)
+
+	for {
+		end := bytes.IndexByte(r.pending, '\n')
+		if end < 0 {
+			break
+		}
+		if _, err := io.WriteString(r.w, r.rewrite(string(r.pending[:end]))+"\n"); err != nil {
+			return len(p), err
+		}
+		r.pending = r.pending[end+1:]
+		r.rows++
+	}
+
+	// Compact so long streams don't keep every consumed row alive
+	r.pending = append([]byte(nil), r.pending...)
+	return len(p), nil
+}
+
+func (r *rowRewriter) rewrite(line string) string {
+	fields := strings.Split(line, "\t")
+	for i, field := range fields {
+		if i >= len(r.rules) || r.rules[i] == nil || field == nullField {
+			continue
+		}
+		fields[i] = copyEscaper.Replace(r.rules[i](copyUnescaper.Replace(field)))
+	}
+	return strings.Join(fields, "\t")
+}
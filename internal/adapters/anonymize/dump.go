@@ -0,0 +1,125 @@
+package anonymize
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"user-service/internal/adapters/backup"
+)
+
+// Tables lists what is copied to staging. Event history and handler receipts
+// are left out: event payloads are free-form and may hold personal data. So
+// are external IDs, which would tie staging users to real customer records,
+// and OIDC clients, whose secrets and redirect URIs are production ones;
+// staging registers its own. Suppressions are copied with their addresses
+// anonymized like user emails, so suppressed staging users stay suppressed.
+var Tables = slices.DeleteFunc(slices.Clone(backup.Tables), func(table backup.Table) bool {
+	switch table.Name {
+	case "domain_events", "event_handler_receipts", "user_external_ids", "oidc_clients":
+		return true
+	}
+	return false
+})
+
+// Source is the database data is read from
+type Source interface {
+	Columns(ctx context.Context, table string) ([]string, error)
+	CopyOut(ctx context.Context, table string, columns []string, w io.Writer) (int64, error)
+	CurrentSchema(ctx context.Context) (string, error)
+}
+
+// Target is the database the anonymized copy is written to
+type Target interface {
+	CreateSchema(ctx context.Context, schema string) error
+	CloneTable(ctx context.Context, source, target, serialColumn string) error
+	CopyIn(ctx context.Context, table string, columns []string, r io.Reader) (int64, error)
+	ResetSequence(ctx context.Context, table, column string) error
+}
+
+// TableResult reports what was copied for one table
+type TableResult struct {
+	Table      string
+	Rows       int64
+	Anonymized []string
+}
+
+// Dump recreates every table of Tables in schema and streams the source rows
+// into it, rewriting personal data with anonymizer on the way
+func Dump(ctx context.Context, source Source, target Target, schema string, anonymizer *Anonymizer) ([]TableResult, error) {
+	current, err := source.CurrentSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if schema == "" || schema == current {
+		return nil, fmt.Errorf("target schema must differ from the source schema %q", current)
+	}
+
+	if err := target.CreateSchema(ctx, schema); err != nil {
+		return nil, fmt.Errorf("failed to create schema %s: %w", schema, err)
+	}
+
+	rules := anonymizer.Rules()
+	results := make([]TableResult, 0, len(Tables))
+
+	for _, table := range Tables {
+		destination := schema + "." + table.Name
+
+		if err := target.CloneTable(ctx, table.Name, destination, table.SerialColumn); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", destination, err)
+		}
+
+		columns, err := source.Columns(ctx, table.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read columns of %s: %w", table.Name, err)
+		}
+
+		rows, err := copyTable(ctx, source, target, table.Name, destination, columns, rules[table.Name])
+		if err != nil {
+			return nil, err
+		}
+
+		if table.SerialColumn != "" {
+			if err := target.ResetSequence(ctx, destination, table.SerialColumn); err != nil {
+				return nil, fmt.Errorf("failed to reset sequence of %s: %w", destination, err)
+			}
+		}
+
+		result := TableResult{Table: table.Name, Rows: rows}
+		for _, column := range columns {
+			if rules[table.Name][column] != nil {
+				result.Anonymized = append(result.Anonymized, column)
+			}
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// copyTable streams one table from source to target through the rewriter
+func copyTable(ctx context.Context, source Source, target Target, table, destination string, columns []string, rules map[string]Rule) (int64, error) {
+	reader, writer := io.Pipe()
+	rewriter := newRowRewriter(writer, columns, rules)
+
+	copiedOut := make(chan error, 1)
+	go func() {
+		_, err := source.CopyOut(ctx, table, columns, rewriter)
+		writer.CloseWithError(err)
+		copiedOut <- err
+	}()
+
+	rows, err := target.CopyIn(ctx, destination, columns, reader)
+	// Unblock the reader side if COPY IN stopped early
+	reader.CloseWithError(fmt.Errorf("copy into %s stopped", destination))
+
+	if outErr := <-copiedOut; outErr != nil || err != nil {
+		return 0, fmt.Errorf("failed to copy %s into %s: %w", table, destination, errors.Join(err, outErr))
+	}
+	if rows != rewriter.rows {
+		return 0, fmt.Errorf("wrote %d rows into %s, read %d", rows, destination, rewriter.rows)
+	}
+	return rows, nil
+}
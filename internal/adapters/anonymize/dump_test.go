@@ -0,0 +1,152 @@
+package anonymize
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSource serves COPY text per table from memory
+type fakeSource struct {
+	schema  string
+	columns map[string][]string
+	data    map[string]string
+}
+
+func (f *fakeSource) Columns(ctx context.Context, table string) ([]string, error) {
+	return f.columns[table], nil
+}
+
+func (f *fakeSource) CopyOut(ctx context.Context, table string, columns []string, w io.Writer) (int64, error) {
+	data := f.data[table]
+	_, err := io.WriteString(w, data)
+	return int64(strings.Count(data, "\n")), err
+}
+
+func (f *fakeSource) CurrentSchema(ctx context.Context) (string, error) {
+	return f.schema, nil
+}
+
+// fakeTarget records what was created and written
+type fakeTarget struct {
+	schemas   []string
+	cloned    []string
+	data      map[string]string
+	sequences []string
+}
+
+func (f *fakeTarget) CreateSchema(ctx context.Context, schema string) error {
+	f.schemas = append(f.schemas, schema)
+	return nil
+}
+
+func (f *fakeTarget) CloneTable(ctx context.Context, source, target, serialColumn string) error {
+	f.cloned = append(f.cloned, target)
+	return nil
+}
+
+func (f *fakeTarget) CopyIn(ctx context.Context, table string, columns []string, r io.Reader) (int64, error) {
+	data, err := io.ReadAll(r)
+	f.data[table] = string(data)
+	return int64(bytes.Count(data, []byte{'\n'})), err
+}
+
+func (f *fakeTarget) ResetSequence(ctx context.Context, table, column string) error {
+	f.sequences = append(f.sequences, table+"."+column)
+	return nil
+}
+
+func newFakes() (*fakeSource, *fakeTarget) {
+	source := &fakeSource{
+		schema: "public",
+		columns: map[string][]string{
+			"users":              {"id", "email", "first_name", "last_name", "phone", "password"},
+			"user_tags":          {"user_id", "tag"},
+			"user_notes":         {"id", "user_id", "author", "text"},
+			"email_suppressions": {"email", "reason", "source", "note"},
+		},
+		data: map[string]string{
+			"users": "1\tjane@example.com\tJane\tDoe\t\\N\t$2a$10$hash\n" +
+				"2\tJOHN@example.com\tJohn\tSmith\t+34600111222\t$2a$10$other\n",
+			"user_tags":          "1\tvip\n",
+			"user_notes":         "7\t1\tsupport\tcalled about\\tinvoice\\n123\n",
+			"email_suppressions": "jane@example.com\tunsubscribed\tsupport\tAsked by phone\n",
+			"oidc_clients":       "1\tbilling-portal\t$2a$10$secret\n",
+		},
+	}
+	return source, &fakeTarget{data: map[string]string{}}
+}
+
+func TestDump_AnonymizesPersonalData(t *testing.T) {
+	// Given
+	source, target := newFakes()
+	anonymizer, err := New(testSalt)
+	require.NoError(t, err)
+
+	// When
+	results, err := Dump(context.Background(), source, target, "staging", anonymizer)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"staging"}, target.schemas)
+	assert.Equal(t, []string{"staging.users", "staging.user_tags", "staging.user_notes", "staging.email_suppressions"}, target.cloned)
+	assert.Equal(t, []string{"staging.users.id", "staging.user_notes.id"}, target.sequences)
+
+	users := strings.Split(strings.TrimSuffix(target.data["staging.users"], "\n"), "\n")
+	require.Len(t, users, 2)
+	jane := strings.Split(users[0], "\t")
+	assert.Equal(t, "1", jane[0])
+	assert.Equal(t, anonymizer.Email("jane@example.com"), jane[1])
+	assert.Equal(t, `\N`, jane[4], "NULL phones stay NULL")
+	assert.Equal(t, UnusablePassword, jane[5])
+	assert.NotContains(t, target.data["staging.users"], "Doe")
+
+	assert.Equal(t, "1\tvip\n", target.data["staging.user_tags"])
+	assert.Equal(t, "7\t1\t"+anonymizer.FirstName("support")+"\t[redacted]\n", target.data["staging.user_notes"])
+
+	assert.Equal(t, anonymizer.Email("jane@example.com")+"\tunsubscribed\tsupport\t[redacted]\n", target.data["staging.email_suppressions"],
+		"suppressions follow the anonymized user emails")
+	assert.NotContains(t, target.data, "staging.oidc_clients")
+
+	require.Len(t, results, 4)
+	assert.Equal(t, int64(2), results[0].Rows)
+	assert.Equal(t, []string{"email", "first_name", "last_name", "phone", "password"}, results[0].Anonymized)
+	assert.Empty(t, results[1].Anonymized)
+}
+
+func TestDump_RejectsSourceSchema(t *testing.T) {
+	// Given
+	source, target := newFakes()
+	anonymizer, err := New(testSalt)
+	require.NoError(t, err)
+
+	for _, schema := range []string{"", "public"} {
+		// When
+		_, err := Dump(context.Background(), source, target, schema, anonymizer)
+
+		// Then
+		assert.Error(t, err, schema)
+	}
+	assert.Empty(t, target.schemas)
+}
+
+func TestRowRewriter_HandlesEscapesAndSplitWrites(t *testing.T) {
+	// Given
+	var out bytes.Buffer
+	rewriter := newRowRewriter(&out, []string{"id", "text"}, map[string]Rule{
+		"text": strings.ToUpper,
+	})
+
+	// When: a row arrives across two writes
+	_, _ = rewriter.Write([]byte("1\tline\\none\\\\"))
+	_, _ = rewriter.Write([]byte("x\ttrailing\n2\t\\N\n"))
+
+	// Then
+	assert.Equal(t, "1\tLINE\\nONE\\\\X\ttrailing\n2\t\\N\n", out.String())
+	assert.Equal(t, int64(2), rewriter.rows)
+}
@@ -0,0 +1,39 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	"user-service/pkg/logger"
+)
+
+// LogAuditLogger writes audit events to the structured application log
+type LogAuditLogger struct {
+	logger logger.Logger
+}
+
+// NewLogAuditLogger creates an audit logger backed by the application logger
+func NewLogAuditLogger(log logger.Logger) ports.AuditLogger {
+	return &LogAuditLogger{
+		logger: log.With("component", "audit"),
+	}
+}
+
+// Record implements ports.AuditLogger
+func (a *LogAuditLogger) Record(ctx context.Context, event *entities.AuditEvent) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+
+	a.logger.Info("Audit event",
+		"action", event.Action,
+		"actor_id", event.ActorID,
+		"resource_type", event.ResourceType,
+		"resource_id", event.ResourceID,
+		"request_id", event.RequestID,
+		"remote_ip", event.RemoteIP,
+		"metadata", event.Metadata,
+		"occurred_at", event.OccurredAt)
+}
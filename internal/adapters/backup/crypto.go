@@ -0,0 +1,254 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Snapshot files start with an unencrypted header followed by the encrypted
+// body, split into chunks sealed with AES-256-GCM:
+//
+//	header: "USBK" | format version (1) | key ID (8) | base nonce (12)
+//	chunk:  ciphertext length (4) | final flag (1) | ciphertext
+//
+// Each chunk's nonce is the base nonce XOR its index, and the header and final
+// flag are authenticated with it, so reordered, altered or truncated files are
+// rejected.
+const (
+	FormatVersion byte = 1
+
+	keySize       = 32
+	keyIDSize     = 8
+	nonceSize     = 12
+	chunkSize     = 64 * 1024
+	headerSize    = 4 + 1 + keyIDSize + nonceSize
+	maxCipherSize = chunkSize + 16
+)
+
+var magic = []byte("USBK")
+
+var (
+	ErrWrongKey       = errors.New("snapshot was encrypted with a different key")
+	ErrTruncated      = errors.New("snapshot is truncated")
+	ErrCorrupted      = errors.New("snapshot is corrupted or was tampered with")
+	ErrUnknownVersion = errors.New("snapshot format version is not supported")
+)
+
+// ParseKey decodes a base64 encoded 256-bit encryption key
+func ParseKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("encryption key is not valid base64: %w", err)
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes, got %d", keySize, len(key))
+	}
+	return key, nil
+}
+
+// keyID identifies a key without revealing it, so restores can tell a wrong
+// key apart from a corrupted file
+func keyID(key []byte) []byte {
+	sum := sha256.Sum256(append([]byte("user-service-backup:"), key...))
+	return sum[:keyIDSize]
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func chunkNonce(base []byte, index uint64) []byte {
+	nonce := bytes.Clone(base)
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], index)
+	for i := range counter {
+		nonce[nonceSize-8+i] ^= counter[i]
+	}
+	return nonce
+}
+
+func chunkAAD(header []byte, final bool) []byte {
+	flag := byte(0)
+	if final {
+		flag = 1
+	}
+	return append(bytes.Clone(header), flag)
+}
+
+// encryptWriter encrypts everything written to it; Close must be called to
+// seal the final chunk
+type encryptWriter struct {
+	w      io.Writer
+	aead   cipher.AEAD
+	header []byte
+	nonce  []byte
+	index  uint64
+	buf    []byte
+	closed bool
+}
+
+func newEncryptWriter(w io.Writer, key []byte) (*encryptWriter, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, headerSize)
+	header = append(header, magic...)
+	header = append(header, FormatVersion)
+	header = append(header, keyID(key)...)
+	header = append(header, nonce...)
+
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	return &encryptWriter{w: w, aead: aead, header: header, nonce: nonce, buf: make([]byte, 0, chunkSize)}, nil
+}
+
+func (e *encryptWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		// A full buffer is only sealed once more data arrives, so the last
+		// chunk is always the one Close seals as final
+		if len(e.buf) == chunkSize {
+			if err := e.seal(false); err != nil {
+				return written, err
+			}
+		}
+
+		n := min(chunkSize-len(e.buf), len(p))
+		e.buf = append(e.buf, p[:n]...)
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+func (e *encryptWriter) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	return e.seal(true)
+}
+
+func (e *encryptWriter) seal(final bool) error {
+	ciphertext := e.aead.Seal(nil, chunkNonce(e.nonce, e.index), e.buf, chunkAAD(e.header, final))
+	e.index++
+	e.buf = e.buf[:0]
+
+	var prefix [5]byte
+	binary.BigEndian.PutUint32(prefix[:4], uint32(len(ciphertext)))
+	if final {
+		prefix[4] = 1
+	}
+
+	if _, err := e.w.Write(prefix[:]); err != nil {
+		return err
+	}
+	_, err := e.w.Write(ciphertext)
+	return err
+}
+
+// decryptReader authenticates and decrypts a snapshot chunk by chunk
+type decryptReader struct {
+	r      io.Reader
+	aead   cipher.AEAD
+	header []byte
+	nonce  []byte
+	index  uint64
+	buf    []byte
+	final  bool
+}
+
+func newDecryptReader(r io.Reader, key []byte) (*decryptReader, error) {
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, ErrTruncated
+	}
+	if !bytes.Equal(header[:4], magic) {
+		return nil, fmt.Errorf("not a user-service snapshot")
+	}
+	if header[4] != FormatVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownVersion, header[4])
+	}
+	if !bytes.Equal(header[5:5+keyIDSize], keyID(key)) {
+		return nil, ErrWrongKey
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &decryptReader{r: r, aead: aead, header: header, nonce: header[5+keyIDSize:]}, nil
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.buf) == 0 {
+		if d.final {
+			return 0, d.expectEOF()
+		}
+		if err := d.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, d.buf)
+	d.buf = d.buf[n:]
+	return n, nil
+}
+
+func (d *decryptReader) open() error {
+	var prefix [5]byte
+	if _, err := io.ReadFull(d.r, prefix[:]); err != nil {
+		return ErrTruncated
+	}
+
+	size := binary.BigEndian.Uint32(prefix[:4])
+	if size > maxCipherSize || prefix[4] > 1 {
+		return ErrCorrupted
+	}
+
+	ciphertext := make([]byte, size)
+	if _, err := io.ReadFull(d.r, ciphertext); err != nil {
+		return ErrTruncated
+	}
+
+	final := prefix[4] == 1
+	plaintext, err := d.aead.Open(nil, chunkNonce(d.nonce, d.index), ciphertext, chunkAAD(d.header, final))
+	if err != nil {
+		return ErrCorrupted
+	}
+
+	d.index++
+	d.buf = plaintext
+	d.final = final
+	return nil
+}
+
+func (d *decryptReader) expectEOF() error {
+	var extra [1]byte
+	if _, err := io.ReadFull(d.r, extra[:]); err == nil {
+		return ErrCorrupted
+	}
+	return io.EOF
+}
@@ -0,0 +1,104 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(t *testing.T) []byte {
+	key := make([]byte, keySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return key
+}
+
+func encrypt(t *testing.T, key, plaintext []byte) []byte {
+	var out bytes.Buffer
+	w, err := newEncryptWriter(&out, key)
+	require.NoError(t, err)
+	_, err = w.Write(plaintext)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return out.Bytes()
+}
+
+func decrypt(key, ciphertext []byte) ([]byte, error) {
+	r, err := newDecryptReader(bytes.NewReader(ciphertext), key)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+func TestEncryption_RoundTrip(t *testing.T) {
+	key := testKey(t)
+
+	for _, size := range []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 3*chunkSize + 17} {
+		plaintext := bytes.Repeat([]byte("x"), size)
+
+		decrypted, err := decrypt(key, encrypt(t, key, plaintext))
+
+		require.NoError(t, err, "size %d", size)
+		assert.Equal(t, plaintext, decrypted, "size %d", size)
+	}
+}
+
+func TestEncryption_RejectsWrongKey(t *testing.T) {
+	ciphertext := encrypt(t, testKey(t), []byte("users"))
+
+	_, err := decrypt(testKey(t), ciphertext)
+
+	assert.ErrorIs(t, err, ErrWrongKey)
+}
+
+func TestEncryption_RejectsTampering(t *testing.T) {
+	key := testKey(t)
+	ciphertext := encrypt(t, key, []byte("users and their tags"))
+	ciphertext[len(ciphertext)-1] ^= 0xff
+
+	_, err := decrypt(key, ciphertext)
+
+	assert.ErrorIs(t, err, ErrCorrupted)
+}
+
+func TestEncryption_RejectsTruncation(t *testing.T) {
+	// Given a snapshot cut at a chunk boundary, so every remaining chunk is intact
+	key := testKey(t)
+	ciphertext := encrypt(t, key, bytes.Repeat([]byte("x"), 2*chunkSize+10))
+	firstChunkEnd := headerSize + 5 + chunkSize + 16
+
+	// When
+	_, err := decrypt(key, ciphertext[:firstChunkEnd])
+
+	// Then
+	assert.ErrorIs(t, err, ErrTruncated)
+}
+
+func TestEncryption_RejectsTrailingData(t *testing.T) {
+	key := testKey(t)
+	ciphertext := append(encrypt(t, key, []byte("users")), 0x00)
+
+	_, err := decrypt(key, ciphertext)
+
+	assert.ErrorIs(t, err, ErrCorrupted)
+}
+
+func TestParseKey(t *testing.T) {
+	key := testKey(t)
+
+	parsed, err := ParseKey(base64.StdEncoding.EncodeToString(key))
+	require.NoError(t, err)
+	assert.Equal(t, key, parsed)
+
+	_, err = ParseKey(base64.StdEncoding.EncodeToString(key[:16]))
+	assert.Error(t, err)
+
+	_, err = ParseKey("not base64!")
+	assert.Error(t, err)
+}
@@ -0,0 +1,91 @@
+package backup
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// The decrypted body is a sequence of frames: kind (1) | length (4) | payload.
+// A snapshot holds one manifest frame, then for each table a start frame, its
+// COPY data split in data frames, and an end frame with the table checksum.
+const (
+	frameManifest   byte = 'M'
+	frameTableStart byte = 'T'
+	frameData       byte = 'D'
+	frameTableEnd   byte = 'E'
+
+	maxFrameSize = 16 * 1024 * 1024
+)
+
+func writeFrame(w io.Writer, kind byte, payload []byte) error {
+	var prefix [5]byte
+	prefix[0] = kind
+	binary.BigEndian.PutUint32(prefix[1:], uint32(len(payload)))
+
+	if _, err := w.Write(prefix[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+func writeJSONFrame(w io.Writer, kind byte, value interface{}) error {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return writeFrame(w, kind, payload)
+}
+
+// readFrame returns io.EOF only when the stream ends cleanly between frames
+func readFrame(r io.Reader) (byte, []byte, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		if err == io.EOF {
+			return 0, nil, io.EOF
+		}
+		return 0, nil, ErrTruncated
+	}
+
+	size := binary.BigEndian.Uint32(prefix[1:])
+	if size > maxFrameSize {
+		return 0, nil, ErrCorrupted
+	}
+
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, ErrTruncated
+	}
+
+	return prefix[0], payload, nil
+}
+
+func decodeFrame(kind byte, payload []byte, want byte, value interface{}) error {
+	if kind != want {
+		return fmt.Errorf("%w: unexpected frame %q, expected %q", ErrCorrupted, kind, want)
+	}
+	if err := json.Unmarshal(payload, value); err != nil {
+		return fmt.Errorf("%w: %v", ErrCorrupted, err)
+	}
+	return nil
+}
+
+// frameWriter turns a byte stream, such as COPY output, into data frames
+type frameWriter struct {
+	w io.Writer
+}
+
+func (f frameWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := min(len(p), chunkSize)
+		if err := writeFrame(f.w, frameData, p[:n]); err != nil {
+			return written, err
+		}
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
@@ -0,0 +1,99 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SnapshotName is the versioned file name given to snapshots exported to a
+// directory or S3 prefix
+func SnapshotName(t time.Time) string {
+	return "user-service-" + t.UTC().Format("20060102T150405Z") + ".usbk"
+}
+
+// Location is where a snapshot is stored: an S3 object or a local file
+type Location struct {
+	Bucket string
+	Key    string
+	Path   string
+}
+
+// ParseLocation reads an s3://bucket/key URI or a local path
+func ParseLocation(uri string) (Location, error) {
+	if strings.HasPrefix(uri, "s3://") {
+		bucket, key, err := ParseS3URI(uri)
+		if err != nil {
+			return Location{}, err
+		}
+		return Location{Bucket: bucket, Key: key}, nil
+	}
+	if uri == "" {
+		return Location{}, fmt.Errorf("snapshot location is required")
+	}
+	return Location{Path: uri}, nil
+}
+
+// IsS3 reports whether the snapshot lives in S3
+func (l Location) IsS3() bool {
+	return l.Bucket != ""
+}
+
+// Versioned names the snapshot with its creation time when the location is a
+// prefix or directory rather than a full object or file name
+func (l Location) Versioned(now time.Time) Location {
+	if l.IsS3() {
+		if l.Key == "" || strings.HasSuffix(l.Key, "/") {
+			l.Key += SnapshotName(now)
+		}
+		return l
+	}
+	if info, err := os.Stat(l.Path); (err == nil && info.IsDir()) || strings.HasSuffix(l.Path, string(os.PathSeparator)) {
+		l.Path = filepath.Join(l.Path, SnapshotName(now))
+	}
+	return l
+}
+
+func (l Location) String() string {
+	if l.IsS3() {
+		return "s3://" + l.Bucket + "/" + l.Key
+	}
+	return l.Path
+}
+
+// Save moves a finished snapshot file to the location
+func (l Location) Save(ctx context.Context, s3 *S3Client, snapshot *os.File) error {
+	if !l.IsS3() {
+		return os.Rename(snapshot.Name(), l.Path)
+	}
+
+	info, err := snapshot.Stat()
+	if err != nil {
+		return err
+	}
+	if _, err := snapshot.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return s3.PutObject(ctx, l.Bucket, l.Key, snapshot, info.Size())
+}
+
+// Open streams the snapshot stored at the location
+func (l Location) Open(ctx context.Context, s3 *S3Client) (io.ReadCloser, error) {
+	if !l.IsS3() {
+		return os.Open(l.Path)
+	}
+	return s3.GetObject(ctx, l.Bucket, l.Key)
+}
+
+// TempDir is where a snapshot is written before it is saved; for local
+// destinations it is the target directory so the final rename is atomic
+func (l Location) TempDir() string {
+	if l.IsS3() {
+		return ""
+	}
+	return filepath.Dir(l.Path)
+}
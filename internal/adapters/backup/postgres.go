@@ -0,0 +1,141 @@
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// PostgresDatabase implements Database within a pgx transaction
+type PostgresDatabase struct {
+	tx pgx.Tx
+}
+
+// WithTransaction runs fn against a dedicated connection of db inside a
+// transaction with opts, committing only when fn succeeds
+func WithTransaction(ctx context.Context, db *sql.DB, opts pgx.TxOptions, fn func(*PostgresDatabase) error) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		stdConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("unsupported database driver %T", driverConn)
+		}
+
+		tx, err := stdConn.Conn().BeginTx(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(&PostgresDatabase{tx: tx}); err != nil {
+			_ = tx.Rollback(context.WithoutCancel(ctx))
+			return err
+		}
+		return tx.Commit(ctx)
+	})
+}
+
+// Columns implements Database
+func (p *PostgresDatabase) Columns(ctx context.Context, table string) ([]string, error) {
+	rows, err := p.tx.Query(ctx, `SELECT column_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1
+		ORDER BY ordinal_position`, table)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table %s does not exist; run migrations first", table)
+	}
+	return columns, nil
+}
+
+// CopyOut implements Database
+func (p *PostgresDatabase) CopyOut(ctx context.Context, table string, columns []string, w io.Writer) (int64, error) {
+	tag, err := p.tx.Conn().PgConn().CopyTo(ctx, w, fmt.Sprintf("COPY %s (%s) TO STDOUT", quote(table), quoteAll(columns)))
+	return tag.RowsAffected(), err
+}
+
+// CopyIn implements Database
+func (p *PostgresDatabase) CopyIn(ctx context.Context, table string, columns []string, r io.Reader) (int64, error) {
+	tag, err := p.tx.Conn().PgConn().CopyFrom(ctx, r, fmt.Sprintf("COPY %s (%s) FROM STDIN", quote(table), quoteAll(columns)))
+	return tag.RowsAffected(), err
+}
+
+// Truncate implements Database
+func (p *PostgresDatabase) Truncate(ctx context.Context, tables []string) error {
+	_, err := p.tx.Exec(ctx, "TRUNCATE "+quoteAll(tables))
+	return err
+}
+
+// ResetSequence implements Database
+func (p *PostgresDatabase) ResetSequence(ctx context.Context, table, column string) error {
+	_, err := p.tx.Exec(ctx, fmt.Sprintf(
+		"SELECT setval(pg_get_serial_sequence($1, $2), COALESCE((SELECT MAX(%s) FROM %s), 0) + 1, false)",
+		quote(column), quote(table)), table, column)
+	return err
+}
+
+// quote quotes an identifier, keeping schema-qualified names such as
+// staging.users as two parts
+func quote(identifier string) string {
+	return pgx.Identifier(strings.Split(identifier, ".")).Sanitize()
+}
+
+// CloneTable recreates target with the columns, defaults, constraints and
+// indexes of source but no rows. A serial column gets a sequence of its own so
+// inserts into the clone never advance the source sequence.
+func (p *PostgresDatabase) CloneTable(ctx context.Context, source, target, serialColumn string) error {
+	statements := []string{
+		fmt.Sprintf("DROP TABLE IF EXISTS %s CASCADE", quote(target)),
+		fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING ALL)", quote(target), quote(source)),
+	}
+	if serialColumn != "" {
+		sequence := target + "_" + serialColumn + "_seq"
+		statements = append(statements,
+			fmt.Sprintf("CREATE SEQUENCE %s OWNED BY %s.%s", quote(sequence), quote(target), quote(serialColumn)),
+			fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET DEFAULT nextval('%s')", quote(target), quote(serialColumn), quote(sequence)),
+		)
+	}
+
+	for _, statement := range statements {
+		if _, err := p.tx.Exec(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateSchema creates schema when it does not exist yet
+func (p *PostgresDatabase) CreateSchema(ctx context.Context, schema string) error {
+	_, err := p.tx.Exec(ctx, "CREATE SCHEMA IF NOT EXISTS "+quote(schema))
+	return err
+}
+
+// CurrentSchema returns the schema unqualified table names resolve to
+func (p *PostgresDatabase) CurrentSchema(ctx context.Context) (string, error) {
+	var schema string
+	err := p.tx.QueryRow(ctx, "SELECT current_schema()").Scan(&schema)
+	return schema, err
+}
+
+func quoteAll(identifiers []string) string {
+	quoted := make([]string, len(identifiers))
+	for i, identifier := range identifiers {
+		quoted[i] = quote(identifier)
+	}
+	return strings.Join(quoted, ", ")
+}
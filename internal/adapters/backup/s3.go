@@ -0,0 +1,190 @@
+package backup
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	unsignedPayload  = "UNSIGNED-PAYLOAD"
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// S3Config locates the bucket service and the credentials to sign with
+type S3Config struct {
+	Region string
+	// Endpoint overrides the AWS endpoint, e.g. for MinIO; it implies path-style URLs
+	Endpoint        string
+	PathStyle       bool
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// S3Client uploads and downloads snapshots with Signature Version 4 signed
+// requests. It only implements the two object operations snapshots need.
+type S3Client struct {
+	config S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewS3Client creates an S3 client using client for requests
+func NewS3Client(config S3Config, client *http.Client) *S3Client {
+	return &S3Client{config: config, client: client, now: time.Now}
+}
+
+// ParseS3URI splits s3://bucket/key into its bucket and key
+func ParseS3URI(uri string) (string, string, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != "s3" || parsed.Host == "" {
+		return "", "", fmt.Errorf("invalid S3 URI %q: expected s3://bucket/key", uri)
+	}
+	return parsed.Host, strings.TrimPrefix(parsed.Path, "/"), nil
+}
+
+// PutObject uploads size bytes from body to bucket/key
+func (c *S3Client) PutObject(ctx context.Context, bucket, key string, body io.Reader, size int64) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.objectURL(bucket, key), body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	c.sign(req, unsignedPayload)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return s3Error(resp)
+	}
+	return nil
+}
+
+// GetObject streams bucket/key; the caller must close the returned body
+func (c *S3Client) GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.objectURL(bucket, key), nil)
+	if err != nil {
+		return nil, err
+	}
+	c.sign(req, emptyPayloadHash)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, s3Error(resp)
+	}
+	return resp.Body, nil
+}
+
+func (c *S3Client) objectURL(bucket, key string) string {
+	escapedKey := escapeS3Path(key)
+
+	if c.config.Endpoint != "" {
+		return strings.TrimSuffix(c.config.Endpoint, "/") + "/" + bucket + "/" + escapedKey
+	}
+	if c.config.PathStyle {
+		return fmt.Sprintf("https://s3.%s.amazonaws.com/%s/%s", c.config.Region, bucket, escapedKey)
+	}
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", bucket, c.config.Region, escapedKey)
+}
+
+// sign adds a Signature Version 4 Authorization header to req. The host, the
+// Range header and every x-amz-* header are signed.
+func (c *S3Client) sign(req *http.Request, payloadHash string) {
+	now := c.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if c.config.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", c.config.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "x-amz-") || lower == "range" {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + c.config.Region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	signingKey := hmacSHA256([]byte("AWS4"+c.config.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, c.config.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.config.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// escapeS3Path URI-encodes each segment of an object key as SigV4 requires
+func escapeS3Path(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		var escaped strings.Builder
+		for _, b := range []byte(segment) {
+			if ('A' <= b && b <= 'Z') || ('a' <= b && b <= 'z') || ('0' <= b && b <= '9') || strings.IndexByte("-._~", b) >= 0 {
+				escaped.WriteByte(b)
+			} else {
+				fmt.Fprintf(&escaped, "%%%02X", b)
+			}
+		}
+		segments[i] = escaped.String()
+	}
+	return strings.Join(segments, "/")
+}
+
+func s3Error(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("s3 request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
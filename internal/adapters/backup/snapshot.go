@@ -0,0 +1,296 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"slices"
+	"time"
+)
+
+// Table is a table included in snapshots
+type Table struct {
+	Name string
+	// SerialColumn is the auto-incremented column whose sequence is moved past
+	// the restored rows, if any
+	SerialColumn string
+}
+
+// Tables lists what a snapshot contains, parents before the tables that reference them
+var Tables = []Table{
+	{Name: "users", SerialColumn: "id"},
+	{Name: "user_tags"},
+	{Name: "user_external_ids"},
+	{Name: "user_notes", SerialColumn: "id"},
+	{Name: "domain_events", SerialColumn: "sequence"},
+	{Name: "event_handler_receipts"},
+	{Name: "email_suppressions"},
+	{Name: "oidc_clients", SerialColumn: "id"},
+}
+
+// Database is the access to PostgreSQL needed to take and restore snapshots.
+// Data is streamed in COPY text format.
+type Database interface {
+	Columns(ctx context.Context, table string) ([]string, error)
+	CopyOut(ctx context.Context, table string, columns []string, w io.Writer) (int64, error)
+	CopyIn(ctx context.Context, table string, columns []string, r io.Reader) (int64, error)
+	Truncate(ctx context.Context, tables []string) error
+	ResetSequence(ctx context.Context, table, column string) error
+}
+
+// Manifest describes a snapshot
+type Manifest struct {
+	FormatVersion  int       `json:"format_version"`
+	CreatedAt      time.Time `json:"created_at"`
+	ServiceVersion string    `json:"service_version"`
+	Tables         []string  `json:"tables"`
+}
+
+// TableSummary is what a snapshot holds for one table
+type TableSummary struct {
+	Table  string `json:"table"`
+	Rows   int64  `json:"rows"`
+	Bytes  int64  `json:"bytes"`
+	SHA256 string `json:"sha256"`
+}
+
+// Summary describes an exported or restored snapshot
+type Summary struct {
+	Manifest Manifest
+	Tables   []TableSummary
+}
+
+var errRestoreAborted = errors.New("restore aborted")
+
+type tableStart struct {
+	Table   string   `json:"table"`
+	Columns []string `json:"columns"`
+}
+
+// tableDigest hashes and measures a table's COPY stream. In text format every
+// row ends with a newline and embedded newlines are escaped, so rows can be
+// counted without parsing.
+type tableDigest struct {
+	hash  hash.Hash
+	rows  int64
+	bytes int64
+}
+
+func newTableDigest() *tableDigest {
+	return &tableDigest{hash: sha256.New()}
+}
+
+func (d *tableDigest) Write(p []byte) (int, error) {
+	d.hash.Write(p)
+	d.rows += int64(bytes.Count(p, []byte{'\n'}))
+	d.bytes += int64(len(p))
+	return len(p), nil
+}
+
+func (d *tableDigest) summary(table string) TableSummary {
+	return TableSummary{Table: table, Rows: d.rows, Bytes: d.bytes, SHA256: hex.EncodeToString(d.hash.Sum(nil))}
+}
+
+// Export streams every snapshot table from db into w, encrypted with key
+func Export(ctx context.Context, db Database, w io.Writer, key []byte, serviceVersion string) (*Summary, error) {
+	encrypted, err := newEncryptWriter(w, key)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{Manifest: Manifest{
+		FormatVersion:  int(FormatVersion),
+		CreatedAt:      time.Now().UTC(),
+		ServiceVersion: serviceVersion,
+	}}
+	for _, table := range Tables {
+		summary.Manifest.Tables = append(summary.Manifest.Tables, table.Name)
+	}
+
+	if err := writeJSONFrame(encrypted, frameManifest, summary.Manifest); err != nil {
+		return nil, err
+	}
+
+	for _, table := range Tables {
+		columns, err := db.Columns(ctx, table.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read columns of %s: %w", table.Name, err)
+		}
+
+		if err := writeJSONFrame(encrypted, frameTableStart, tableStart{Table: table.Name, Columns: columns}); err != nil {
+			return nil, err
+		}
+
+		digest := newTableDigest()
+		if _, err := db.CopyOut(ctx, table.Name, columns, io.MultiWriter(digest, frameWriter{w: encrypted})); err != nil {
+			return nil, fmt.Errorf("failed to export %s: %w", table.Name, err)
+		}
+
+		tableSummary := digest.summary(table.Name)
+		if err := writeJSONFrame(encrypted, frameTableEnd, tableSummary); err != nil {
+			return nil, err
+		}
+		summary.Tables = append(summary.Tables, tableSummary)
+	}
+
+	if err := encrypted.Close(); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// Restore replaces the snapshot tables in db with the contents of r. Every
+// table checksum is verified; callers must run it in a transaction and roll
+// back on error. With dryRun, r is fully read and verified but db is not used.
+func Restore(ctx context.Context, db Database, r io.Reader, key []byte, dryRun bool) (*Summary, error) {
+	decrypted, err := newDecryptReader(r, key)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{}
+	kind, payload, err := readFrame(decrypted)
+	if err != nil {
+		return nil, err
+	}
+	if err := decodeFrame(kind, payload, frameManifest, &summary.Manifest); err != nil {
+		return nil, err
+	}
+	if err := checkTables(summary.Manifest.Tables); err != nil {
+		return nil, err
+	}
+
+	if !dryRun {
+		if err := db.Truncate(ctx, summary.Manifest.Tables); err != nil {
+			return nil, fmt.Errorf("failed to clear tables: %w", err)
+		}
+	}
+
+	for _, expected := range summary.Manifest.Tables {
+		tableSummary, err := restoreTable(ctx, db, decrypted, expected, dryRun)
+		if err != nil {
+			return nil, err
+		}
+		summary.Tables = append(summary.Tables, *tableSummary)
+	}
+
+	if _, _, err := readFrame(decrypted); !errors.Is(err, io.EOF) {
+		if err == nil {
+			return nil, fmt.Errorf("%w: unexpected data after the last table", ErrCorrupted)
+		}
+		return nil, err
+	}
+
+	if !dryRun {
+		for _, table := range Tables {
+			if table.SerialColumn == "" || !slices.Contains(summary.Manifest.Tables, table.Name) {
+				continue
+			}
+			if err := db.ResetSequence(ctx, table.Name, table.SerialColumn); err != nil {
+				return nil, fmt.Errorf("failed to reset sequence of %s: %w", table.Name, err)
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+func checkTables(tables []string) error {
+	for _, name := range tables {
+		if !slices.ContainsFunc(Tables, func(table Table) bool { return table.Name == name }) {
+			return fmt.Errorf("snapshot contains unknown table %q", name)
+		}
+	}
+	return nil
+}
+
+func restoreTable(ctx context.Context, db Database, r io.Reader, expected string, dryRun bool) (*TableSummary, error) {
+	kind, payload, err := readFrame(r)
+	if errors.Is(err, io.EOF) {
+		return nil, ErrTruncated
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var start tableStart
+	if err := decodeFrame(kind, payload, frameTableStart, &start); err != nil {
+		return nil, err
+	}
+	if start.Table != expected {
+		return nil, fmt.Errorf("%w: found table %s, expected %s", ErrCorrupted, start.Table, expected)
+	}
+
+	digest := newTableDigest()
+	sink := io.Writer(digest)
+
+	var pipe *io.PipeWriter
+	copied := make(chan error, 1)
+	var copiedRows int64
+	if !dryRun {
+		var source *io.PipeReader
+		source, pipe = io.Pipe()
+		sink = io.MultiWriter(digest, pipe)
+
+		// Never return while COPY still uses the connection
+		defer func() {
+			if pipe != nil {
+				pipe.CloseWithError(errRestoreAborted)
+				<-copied
+			}
+		}()
+
+		go func() {
+			rows, err := db.CopyIn(ctx, start.Table, start.Columns, source)
+			copiedRows = rows
+			source.CloseWithError(err)
+			copied <- err
+		}()
+	}
+
+	for {
+		kind, payload, err := readFrame(r)
+		if errors.Is(err, io.EOF) {
+			return nil, ErrTruncated
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if kind == frameData {
+			if _, err := sink.Write(payload); err != nil {
+				return nil, fmt.Errorf("failed to restore %s: %w", start.Table, err)
+			}
+			continue
+		}
+
+		var end TableSummary
+		if err := decodeFrame(kind, payload, frameTableEnd, &end); err != nil {
+			return nil, err
+		}
+
+		actual := digest.summary(start.Table)
+		if actual != end {
+			return nil, fmt.Errorf("%w: checksum mismatch for %s", ErrCorrupted, start.Table)
+		}
+
+		if pipe != nil {
+			pipe.Close()
+			err := <-copied
+			pipe = nil
+			if err != nil {
+				return nil, fmt.Errorf("failed to restore %s: %w", start.Table, err)
+			}
+			if copiedRows != actual.Rows {
+				return nil, fmt.Errorf("restored %d rows into %s, snapshot has %d", copiedRows, start.Table, actual.Rows)
+			}
+		}
+
+		return &actual, nil
+	}
+}
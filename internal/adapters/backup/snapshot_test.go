@@ -0,0 +1,140 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDatabase keeps COPY text per table in memory
+type fakeDatabase struct {
+	columns   map[string][]string
+	data      map[string][]byte
+	truncated []string
+	sequences []string
+}
+
+func newFakeDatabase() *fakeDatabase {
+	db := &fakeDatabase{columns: map[string][]string{}, data: map[string][]byte{}}
+	for _, table := range Tables {
+		db.columns[table.Name] = []string{"id", "value"}
+	}
+	return db
+}
+
+func (f *fakeDatabase) Columns(ctx context.Context, table string) ([]string, error) {
+	return f.columns[table], nil
+}
+
+func (f *fakeDatabase) CopyOut(ctx context.Context, table string, columns []string, w io.Writer) (int64, error) {
+	data := f.data[table]
+	_, err := w.Write(data)
+	return int64(bytes.Count(data, []byte{'\n'})), err
+}
+
+func (f *fakeDatabase) CopyIn(ctx context.Context, table string, columns []string, r io.Reader) (int64, error) {
+	data, err := io.ReadAll(r)
+	f.data[table] = append(f.data[table], data...)
+	return int64(bytes.Count(data, []byte{'\n'})), err
+}
+
+func (f *fakeDatabase) Truncate(ctx context.Context, tables []string) error {
+	f.truncated = tables
+	for _, table := range tables {
+		delete(f.data, table)
+	}
+	return nil
+}
+
+func (f *fakeDatabase) ResetSequence(ctx context.Context, table, column string) error {
+	f.sequences = append(f.sequences, table+"."+column)
+	return nil
+}
+
+func exportSnapshot(t *testing.T, source *fakeDatabase, key []byte) []byte {
+	var snapshot bytes.Buffer
+	summary, err := Export(context.Background(), source, &snapshot, key, "1.2.3")
+	require.NoError(t, err)
+	assert.Len(t, summary.Tables, len(Tables))
+	return snapshot.Bytes()
+}
+
+func TestSnapshot_ExportAndRestore(t *testing.T) {
+	// Given
+	key := testKey(t)
+	source := newFakeDatabase()
+	source.data["users"] = []byte("1\tjohn@example.com\n2\tjane\\nwith newline@example.com\n")
+	source.data["user_tags"] = bytes.Repeat([]byte("1\tvip\n"), 50000)
+	source.data["email_suppressions"] = []byte("bounced@example.com\tbounced\n")
+	source.data["oidc_clients"] = []byte("1\tbilling-portal\n")
+	snapshot := exportSnapshot(t, source, key)
+
+	target := newFakeDatabase()
+	target.data["users"] = []byte("99\tstale@example.com\n")
+
+	// When
+	summary, err := Restore(context.Background(), target, bytes.NewReader(snapshot), key, false)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", summary.Manifest.ServiceVersion)
+	assert.Equal(t, int64(2), summary.Tables[0].Rows)
+	assert.Equal(t, int64(50000), summary.Tables[1].Rows)
+	assert.Equal(t, source.data["users"], target.data["users"])
+	assert.Equal(t, source.data["user_tags"], target.data["user_tags"])
+	assert.Equal(t, source.data["email_suppressions"], target.data["email_suppressions"])
+	assert.Equal(t, source.data["oidc_clients"], target.data["oidc_clients"])
+	assert.Len(t, target.truncated, len(Tables))
+	assert.Equal(t, []string{"users.id", "user_notes.id", "domain_events.sequence", "oidc_clients.id"}, target.sequences)
+}
+
+func TestSnapshot_DryRunDoesNotTouchDatabase(t *testing.T) {
+	// Given
+	key := testKey(t)
+	source := newFakeDatabase()
+	source.data["users"] = []byte("1\tjohn@example.com\n")
+	snapshot := exportSnapshot(t, source, key)
+
+	// When
+	summary, err := Restore(context.Background(), nil, bytes.NewReader(snapshot), key, true)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), summary.Tables[0].Rows)
+}
+
+func TestSnapshot_RestoreRejectsChecksumMismatch(t *testing.T) {
+	// Given a snapshot whose table end frame disagrees with its data
+	key := testKey(t)
+	var snapshot bytes.Buffer
+	w, err := newEncryptWriter(&snapshot, key)
+	require.NoError(t, err)
+	require.NoError(t, writeJSONFrame(w, frameManifest, Manifest{FormatVersion: 1, Tables: []string{"users"}}))
+	require.NoError(t, writeJSONFrame(w, frameTableStart, tableStart{Table: "users", Columns: []string{"id"}}))
+	require.NoError(t, writeFrame(w, frameData, []byte("1\n")))
+	require.NoError(t, writeJSONFrame(w, frameTableEnd, TableSummary{Table: "users", Rows: 1, Bytes: 2, SHA256: "deadbeef"}))
+	require.NoError(t, w.Close())
+
+	// When
+	_, err = Restore(context.Background(), newFakeDatabase(), bytes.NewReader(snapshot.Bytes()), key, false)
+
+	// Then
+	assert.ErrorIs(t, err, ErrCorrupted)
+}
+
+func TestSnapshot_RestoreRejectsUnknownTables(t *testing.T) {
+	key := testKey(t)
+	var snapshot bytes.Buffer
+	w, err := newEncryptWriter(&snapshot, key)
+	require.NoError(t, err)
+	require.NoError(t, writeJSONFrame(w, frameManifest, Manifest{FormatVersion: 1, Tables: []string{"pg_authid"}}))
+	require.NoError(t, w.Close())
+
+	_, err = Restore(context.Background(), newFakeDatabase(), bytes.NewReader(snapshot.Bytes()), key, true)
+
+	assert.ErrorContains(t, err, "unknown table")
+}
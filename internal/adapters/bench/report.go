@@ -0,0 +1,159 @@
+package bench
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Report summarizes a benchmark run
+type Report struct {
+	Target    string `json:"target"`
+	Scenario  string `json:"scenario"`
+	TargetRPS int    `json:"target_rps"`
+	// AchievedRPS is the rate of requests sent, dropped ones left out
+	AchievedRPS     float64 `json:"achieved_rps"`
+	DurationSeconds float64 `json:"duration_seconds"`
+	// Dropped counts requests that were due while every worker was busy
+	Dropped    int                         `json:"dropped"`
+	Operations map[string]*OperationReport `json:"operations"`
+}
+
+// OperationReport summarizes the requests of one operation
+type OperationReport struct {
+	Requests  int     `json:"requests"`
+	Errors    int     `json:"errors"`
+	ErrorRate float64 `json:"error_rate"`
+	// Statuses counts responses by status; "none" counts requests that got no
+	// response
+	Statuses map[string]int `json:"statuses"`
+	Latency  Latency        `json:"latency"`
+}
+
+// Latency holds latency percentiles in milliseconds
+type Latency struct {
+	P50 float64 `json:"p50_ms"`
+	P90 float64 `json:"p90_ms"`
+	P95 float64 `json:"p95_ms"`
+	P99 float64 `json:"p99_ms"`
+	Max float64 `json:"max_ms"`
+}
+
+// Thresholds fail a run that regressed; zero values are not checked
+type Thresholds struct {
+	MaxErrorRate float64
+	MaxP99       time.Duration
+	// MaxDroppedRate is the share of offered requests that may be dropped
+	MaxDroppedRate float64
+}
+
+// Check returns the thresholds the run exceeded
+func (r *Report) Check(thresholds Thresholds) []string {
+	var violations []string
+	for _, operation := range slices.Sorted(maps.Keys(r.Operations)) {
+		report := r.Operations[operation]
+		if thresholds.MaxErrorRate > 0 && report.ErrorRate > thresholds.MaxErrorRate {
+			violations = append(violations, fmt.Sprintf("%s error rate %.4f exceeds %.4f", operation, report.ErrorRate, thresholds.MaxErrorRate))
+		}
+		if maxP99 := milliseconds(thresholds.MaxP99); maxP99 > 0 && report.Latency.P99 > maxP99 {
+			violations = append(violations, fmt.Sprintf("%s p99 %.1fms exceeds %.1fms", operation, report.Latency.P99, maxP99))
+		}
+	}
+
+	offered := r.Dropped
+	for _, report := range r.Operations {
+		offered += report.Requests
+	}
+	if thresholds.MaxDroppedRate > 0 && offered > 0 {
+		if rate := float64(r.Dropped) / float64(offered); rate > thresholds.MaxDroppedRate {
+			violations = append(violations, fmt.Sprintf("dropped rate %.4f exceeds %.4f", rate, thresholds.MaxDroppedRate))
+		}
+	}
+	return violations
+}
+
+// recorder collects request outcomes from concurrent workers
+type recorder struct {
+	mu        sync.Mutex
+	latencies map[string][]time.Duration
+	errors    map[string]int
+	statuses  map[string]map[string]int
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		latencies: make(map[string][]time.Duration),
+		errors:    make(map[string]int),
+		statuses:  make(map[string]map[string]int),
+	}
+}
+
+func (r *recorder) record(operation string, res result) {
+	status := "none"
+	if res.status != 0 {
+		status = strconv.Itoa(res.status)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.latencies[operation] = append(r.latencies[operation], res.latency)
+	if res.err != nil {
+		r.errors[operation]++
+	}
+	if r.statuses[operation] == nil {
+		r.statuses[operation] = make(map[string]int)
+	}
+	r.statuses[operation][status]++
+}
+
+func (r *recorder) report(options Options, offered, dropped int, elapsed time.Duration) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := &Report{
+		Target:          options.Target,
+		Scenario:        options.Scenario,
+		TargetRPS:       options.RPS,
+		DurationSeconds: elapsed.Seconds(),
+		Dropped:         dropped,
+		Operations:      make(map[string]*OperationReport, len(r.latencies)),
+	}
+	if elapsed > 0 {
+		report.AchievedRPS = float64(offered-dropped) / elapsed.Seconds()
+	}
+
+	for operation, latencies := range r.latencies {
+		slices.Sort(latencies)
+		report.Operations[operation] = &OperationReport{
+			Requests:  len(latencies),
+			Errors:    r.errors[operation],
+			ErrorRate: float64(r.errors[operation]) / float64(len(latencies)),
+			Statuses:  r.statuses[operation],
+			Latency: Latency{
+				P50: milliseconds(percentile(latencies, 50)),
+				P90: milliseconds(percentile(latencies, 90)),
+				P95: milliseconds(percentile(latencies, 95)),
+				P99: milliseconds(percentile(latencies, 99)),
+				Max: milliseconds(latencies[len(latencies)-1]),
+			},
+		}
+	}
+	return report
+}
+
+// percentile returns the nearest-rank percentile of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,243 @@
+package bench
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"user-service/internal/adapters/http/middlewares/auth"
+	"user-service/internal/application/dto"
+)
+
+// Scenarios
+const (
+	// ScenarioCreate signs up new users
+	ScenarioCreate = "create"
+	// ScenarioRead fetches users created before the run
+	ScenarioRead = "read"
+	// ScenarioMixed reads four times as often as it creates
+	ScenarioMixed = "mixed"
+)
+
+// Operations
+const (
+	OperationCreate = "create"
+	OperationRead   = "read"
+)
+
+// seedUsers is the number of users read scenarios create before measuring
+const seedUsers = 20
+
+// Options describes a benchmark run
+type Options struct {
+	// Target is the base URL of the instance, e.g. http://localhost:8080
+	Target   string
+	Scenario string
+	// RPS is the request rate offered, whether or not the target keeps up
+	RPS      int
+	Duration time.Duration
+	// Concurrency caps requests in flight; requests due while all are busy
+	// are dropped and reported
+	Concurrency int
+	// Timeout bounds each request
+	Timeout time.Duration
+	// APIKey is sent with every request when set
+	APIKey string
+}
+
+// Validate rejects unknown scenarios and non-positive limits
+func (o Options) Validate() error {
+	switch o.Scenario {
+	case ScenarioCreate, ScenarioRead, ScenarioMixed:
+	default:
+		return fmt.Errorf("scenario %q must be create, read or mixed", o.Scenario)
+	}
+	if !strings.HasPrefix(o.Target, "http://") && !strings.HasPrefix(o.Target, "https://") {
+		return fmt.Errorf("target %q must be an http or https URL", o.Target)
+	}
+	if o.RPS <= 0 || o.Duration <= 0 || o.Concurrency <= 0 || o.Timeout <= 0 {
+		return fmt.Errorf("rps, duration, concurrency and timeout must be positive")
+	}
+	return nil
+}
+
+// Runner load-tests a running instance through its public user API at a
+// fixed request rate. Users it creates are left in place, so it is meant for
+// disposable environments.
+type Runner struct {
+	options Options
+	client  *http.Client
+	// run tells apart the users of different runs against one instance
+	run string
+	// sequence numbers the users created in a run
+	sequence atomic.Uint64
+	// seeded are the users read scenarios fetch
+	seeded []uint
+}
+
+// NewRunner creates a runner for validated options
+func NewRunner(options Options) (*Runner, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	return &Runner{
+		options: options,
+		client:  &http.Client{Timeout: options.Timeout},
+		run:     strconv.FormatInt(time.Now().UnixNano(), 36),
+	}, nil
+}
+
+// Run seeds the users read scenarios need, then offers requests at the
+// configured rate for the configured duration and reports on them
+func (r *Runner) Run(ctx context.Context) (*Report, error) {
+	if r.options.Scenario != ScenarioCreate {
+		if err := r.seed(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	recorder := newRecorder()
+	work := make(chan string, r.options.Concurrency)
+	var workers sync.WaitGroup
+	for range r.options.Concurrency {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for operation := range work {
+				recorder.record(operation, r.perform(ctx, operation))
+			}
+		}()
+	}
+
+	start := time.Now()
+	ticker := time.NewTicker(time.Second / time.Duration(r.options.RPS))
+	deadline := time.NewTimer(r.options.Duration)
+	offered, dropped := 0, 0
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-deadline.C:
+			break loop
+		case <-ticker.C:
+			offered++
+			select {
+			case work <- r.nextOperation():
+			default:
+				dropped++
+			}
+		}
+	}
+	ticker.Stop()
+	deadline.Stop()
+	close(work)
+	workers.Wait()
+
+	return recorder.report(r.options, offered, dropped, time.Since(start)), nil
+}
+
+// seed creates the users read scenarios fetch
+func (r *Runner) seed(ctx context.Context) error {
+	for range seedUsers {
+		result := r.create(ctx)
+		if result.err != nil {
+			return fmt.Errorf("failed to create seed user: %w", result.err)
+		}
+		r.seeded = append(r.seeded, result.userID)
+	}
+	return nil
+}
+
+func (r *Runner) nextOperation() string {
+	switch r.options.Scenario {
+	case ScenarioCreate:
+		return OperationCreate
+	case ScenarioRead:
+		return OperationRead
+	default:
+		if rand.IntN(5) == 0 {
+			return OperationCreate
+		}
+		return OperationRead
+	}
+}
+
+func (r *Runner) perform(ctx context.Context, operation string) result {
+	if operation == OperationCreate {
+		return r.create(ctx)
+	}
+	return r.read(ctx, r.seeded[rand.IntN(len(r.seeded))])
+}
+
+// result is the outcome of one request
+type result struct {
+	latency time.Duration
+	// status is the response status, 0 when none was received
+	status int
+	err    error
+	userID uint
+}
+
+func (r *Runner) create(ctx context.Context) result {
+	n := r.sequence.Add(1)
+	body, _ := json.Marshal(dto.CreateUserRequestDTO{
+		Email:     fmt.Sprintf("bench-%s-%d@example.com", r.run, n),
+		Password:  "bench-password",
+		FirstName: "Bench",
+		LastName:  "User",
+		Phone:     fmt.Sprintf("+1555%07d", n%10_000_000),
+	})
+
+	var user dto.UserResponseDTO
+	res := r.do(ctx, http.MethodPost, "/api/v1/users", body, http.StatusCreated, &user)
+	res.userID = user.ID
+	return res
+}
+
+func (r *Runner) read(ctx context.Context, id uint) result {
+	return r.do(ctx, http.MethodGet, "/api/v1/users/"+strconv.FormatUint(uint64(id), 10), nil, http.StatusOK, nil)
+}
+
+// do sends a request and decodes the response into out when given; any
+// status but expected is an error
+func (r *Runner) do(ctx context.Context, method, path string, body []byte, expected int, out interface{}) result {
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(r.options.Target, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return result{err: err}
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if r.options.APIKey != "" {
+		req.Header.Set(auth.HeaderAPIKey, r.options.APIKey)
+	}
+
+	start := time.Now()
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return result{latency: time.Since(start), err: err}
+	}
+	defer resp.Body.Close()
+
+	res := result{status: resp.StatusCode}
+	if resp.StatusCode != expected {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		res.err = fmt.Errorf("%s %s: unexpected status %d", method, path, resp.StatusCode)
+	} else if out != nil {
+		res.err = json.NewDecoder(resp.Body).Decode(out)
+	} else {
+		_, _ = io.Copy(io.Discard, resp.Body)
+	}
+	res.latency = time.Since(start)
+	return res
+}
@@ -0,0 +1,95 @@
+package bench
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"user-service/internal/application/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestTarget serves the user API; every fifth read fails
+func newTestTarget(t *testing.T) *httptest.Server {
+	var users, reads atomic.Uint64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/users":
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(dto.UserResponseDTO{ID: uint(users.Add(1))})
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/v1/users/"):
+			if reads.Add(1)%5 == 0 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRunner_ReportsPerOperation(t *testing.T) {
+	// Given a target whose reads fail one time in five
+	target := newTestTarget(t)
+	runner, err := NewRunner(Options{
+		Target:      target.URL,
+		Scenario:    ScenarioRead,
+		RPS:         200,
+		Duration:    250 * time.Millisecond,
+		Concurrency: 8,
+		Timeout:     time.Second,
+	})
+	require.NoError(t, err)
+
+	// When
+	report, err := runner.Run(context.Background())
+
+	// Then the seeding is left out and the failures are counted
+	require.NoError(t, err)
+	require.Contains(t, report.Operations, OperationRead)
+	assert.NotContains(t, report.Operations, OperationCreate)
+	reads := report.Operations[OperationRead]
+	assert.Positive(t, reads.Requests)
+	assert.Equal(t, reads.Requests, reads.Statuses["200"]+reads.Statuses["503"])
+	assert.Equal(t, reads.Statuses["503"], reads.Errors)
+	assert.InDelta(t, 0.2, reads.ErrorRate, 0.1)
+	assert.LessOrEqual(t, reads.Latency.P50, reads.Latency.P99)
+
+	// And the error rate fails a strict threshold
+	assert.Len(t, report.Check(Thresholds{MaxErrorRate: 0.05}), 1)
+	assert.Empty(t, report.Check(Thresholds{MaxErrorRate: 0.5}))
+}
+
+func TestOptions_Validate(t *testing.T) {
+	valid := Options{Target: "http://localhost:8080", Scenario: ScenarioMixed, RPS: 1, Duration: time.Second, Concurrency: 1, Timeout: time.Second}
+	assert.NoError(t, valid.Validate())
+
+	unknown := valid
+	unknown.Scenario = "delete"
+	assert.ErrorContains(t, unknown.Validate(), "scenario")
+
+	relative := valid
+	relative.Target = "localhost:8080"
+	assert.ErrorContains(t, relative.Validate(), "target")
+}
+
+func TestPercentile(t *testing.T) {
+	latencies := make([]time.Duration, 100)
+	for i := range latencies {
+		latencies[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	assert.Equal(t, 50*time.Millisecond, percentile(latencies, 50))
+	assert.Equal(t, 99*time.Millisecond, percentile(latencies, 99))
+	assert.Equal(t, time.Duration(0), percentile(nil, 99))
+}
@@ -0,0 +1,69 @@
+package billing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"user-service/internal/adapters/backup"
+	"user-service/internal/application/ports"
+)
+
+// UsageExporter writes usage exports as files under an S3 prefix or into a
+// local directory, where the billing system picks them up
+type UsageExporter struct {
+	location backup.Location
+	// s3 is nil for local directories
+	s3 *backup.S3Client
+}
+
+var _ ports.UsageExporter = (*UsageExporter)(nil)
+
+// NewUsageExporter creates an exporter for destination, an s3://bucket/prefix/
+// URI or a local directory, which is created when missing
+func NewUsageExporter(destination string, s3 *backup.S3Client) (*UsageExporter, error) {
+	location, err := backup.ParseLocation(destination)
+	if err != nil {
+		return nil, err
+	}
+
+	if !location.IsS3() {
+		if err := os.MkdirAll(location.Path, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create usage export directory: %w", err)
+		}
+	}
+
+	return &UsageExporter{location: location, s3: s3}, nil
+}
+
+// Export implements ports.UsageExporter. Local files are written to a
+// temporary file first and renamed, so billing never reads a partial file.
+func (e *UsageExporter) Export(ctx context.Context, name string, content []byte) error {
+	if e.location.IsS3() {
+		key := path.Join(e.location.Key, name)
+		return e.s3.PutObject(ctx, e.location.Bucket, key, bytes.NewReader(content), int64(len(content)))
+	}
+
+	file, err := os.CreateTemp(e.location.Path, "."+name+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), filepath.Join(e.location.Path, name))
+}
+
+// String describes the destination for logs
+func (e *UsageExporter) String() string {
+	return e.location.String()
+}
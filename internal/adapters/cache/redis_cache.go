@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"user-service/internal/application/ports"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCache implements the SharedCache interface using Redis
+type RedisCache struct {
+	client *redis.Client
+}
+
+var _ ports.SharedCache = (*RedisCache)(nil)
+
+// NewRedisCache creates a cache backed by the Redis server at address
+// ("host:port"). Connections are opened lazily, on first use.
+func NewRedisCache(address string, timeout time.Duration) *RedisCache {
+	return &RedisCache{client: redis.NewClient(&redis.Options{
+		Addr:         address,
+		DialTimeout:  timeout,
+		ReadTimeout:  timeout,
+		WriteTimeout: timeout,
+	})}
+}
+
+// Get implements ports.SharedCache
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Set implements ports.SharedCache
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+// Delete implements ports.SharedCache
+func (c *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.client.Del(ctx, keys...).Err()
+}
+
+// Close releases the client's connections
+func (c *RedisCache) Close() error {
+	return c.client.Close()
+}
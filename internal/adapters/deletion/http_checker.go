@@ -0,0 +1,80 @@
+package deletion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	"user-service/pkg/deadline"
+)
+
+// maxResponseBytes bounds how much of a checker response is read
+const maxResponseBytes = 64 << 10
+
+type checkRequest struct {
+	UserID uint   `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+type checkResponse struct {
+	Blockers []string `json:"blockers"`
+}
+
+// HTTPChecker asks a remote service whether a user may be deleted. The service
+// receives {"user_id", "email"} and answers 200 with {"blockers": [...]},
+// an empty list allowing the deletion.
+type HTTPChecker struct {
+	name   string
+	url    string
+	client *http.Client
+}
+
+// NewHTTPChecker creates a deletion checker calling url
+func NewHTTPChecker(name, url string, client *http.Client) ports.DeletionChecker {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPChecker{name: name, url: url, client: client}
+}
+
+// Name implements ports.DeletionChecker
+func (c *HTTPChecker) Name() string {
+	return c.name
+}
+
+// CheckDeletion implements ports.DeletionChecker
+func (c *HTTPChecker) CheckDeletion(ctx context.Context, user *entities.User) ([]string, error) {
+	body, err := json.Marshal(checkRequest{UserID: user.ID, Email: user.Email})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	deadline.Propagate(req)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("deletion checker %s: %w", c.name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("deletion checker %s: unexpected status %d", c.name, resp.StatusCode)
+	}
+
+	var result checkResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&result); err != nil {
+		return nil, fmt.Errorf("deletion checker %s: invalid response: %w", c.name, err)
+	}
+
+	return result.Blockers, nil
+}
@@ -0,0 +1,79 @@
+package deletion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"user-service/internal/domain/entities"
+	"user-service/pkg/deadline"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPChecker_ReturnsBlockers(t *testing.T) {
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request checkRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, uint(42), request.UserID)
+
+		_ = json.NewEncoder(w).Encode(checkResponse{Blockers: []string{"1 open invoice"}})
+	}))
+	defer server.Close()
+
+	checker := NewHTTPChecker("billing", server.URL, server.Client())
+
+	// When
+	reasons, err := checker.CheckDeletion(context.Background(), &entities.User{ID: 42, Email: "john@example.com"})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1 open invoice"}, reasons)
+	assert.Equal(t, "billing", checker.Name())
+}
+
+func TestHTTPChecker_UnexpectedStatusIsAnError(t *testing.T) {
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	checker := NewHTTPChecker("billing", server.URL, server.Client())
+
+	// When
+	reasons, err := checker.CheckDeletion(context.Background(), &entities.User{ID: 42})
+
+	// Then
+	assert.Error(t, err)
+	assert.Nil(t, reasons)
+}
+
+func TestHTTPChecker_PropagatesRemainingBudget(t *testing.T) {
+	// Given
+	var budget string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		budget = r.Header.Get(deadline.HeaderTimeout)
+		_ = json.NewEncoder(w).Encode(checkResponse{})
+	}))
+	defer server.Close()
+
+	checker := NewHTTPChecker("billing", server.URL, server.Client())
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// When
+	_, err := checker.CheckDeletion(ctx, &entities.User{ID: 42})
+
+	// Then
+	require.NoError(t, err)
+	remaining, err := deadline.ParseTimeout(budget)
+	require.NoError(t, err)
+	assert.Greater(t, remaining, time.Second)
+	assert.LessOrEqual(t, remaining, 2*time.Second)
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+
+	"user-service/internal/adapters/http/middlewares/auth"
+	"user-service/internal/application/usecases"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ActionLimitHandler struct {
+	limiter usecases.SensitiveActionLimiter
+	logger  logger.Logger
+}
+
+func NewActionLimitHandler(limiter usecases.SensitiveActionLimiter, log logger.Logger) *ActionLimitHandler {
+	return &ActionLimitHandler{
+		limiter: limiter,
+		logger:  log.With("component", "action_limit_handler"),
+	}
+}
+
+// ResetLimits handles DELETE /api/v1/admin/users/:id/action-limits
+func (h *ActionLimitHandler) ResetLimits(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	userID, err := parseUserID(c)
+	if err != nil {
+		return renderError(c, domainErrors.ErrInvalidID)
+	}
+
+	actor := auth.PrincipalFrom(c).Name
+
+	if err := h.limiter.Reset(c.Request().Context(), userID, actor); err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to reset action limits")
+	}
+
+	h.logger.Info("Action limits reset",
+		"request_id", requestID,
+		"user_id", userID,
+		"actor", actor)
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,290 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"user-service/internal/application/dto"
+	"user-service/pkg/textdistance"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+// bodyField is the detail key used for problems with the body as a whole
+const bodyField = "body"
+
+// BindingError reports a request body that could not be decoded into the
+// request DTO. Field is the JSON path of the offending value, or "body" when
+// the body itself is malformed.
+type BindingError struct {
+	Field   string
+	Message string
+	cause   error
+}
+
+func (e *BindingError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+func (e *BindingError) Unwrap() error {
+	return e.cause
+}
+
+// BindingPolicy decides which routes reject unknown JSON fields
+type BindingPolicy struct {
+	RejectUnknownFields bool
+	// LenientRoutes ignore unknown fields regardless of RejectUnknownFields,
+	// keyed by "METHOD /route"
+	LenientRoutes map[string]bool
+}
+
+// NewBindingPolicy builds a policy from "METHOD /route" entries
+func NewBindingPolicy(rejectUnknownFields bool, lenientRoutes []string) BindingPolicy {
+	policy := BindingPolicy{RejectUnknownFields: rejectUnknownFields, LenientRoutes: make(map[string]bool, len(lenientRoutes))}
+	for _, route := range lenientRoutes {
+		method, path, _ := strings.Cut(route, " ")
+		policy.LenientRoutes[strings.ToUpper(method)+" "+strings.TrimSpace(path)] = true
+	}
+	return policy
+}
+
+// rejectsUnknownFields reports whether the matched route is strict
+func (p BindingPolicy) rejectsUnknownFields(c echo.Context) bool {
+	return p.RejectUnknownFields && !p.LenientRoutes[c.Request().Method+" "+c.Path()]
+}
+
+// RegisterRequestBinding installs the binder and validator used by every
+// handler, so c.Bind and c.Validate report problems as VALIDATION_ERRORs.
+// requiredProfileFields are the optional profile fields this deployment
+// requires on user creation.
+func RegisterRequestBinding(e *echo.Echo, policy BindingPolicy, requiredProfileFields []string) error {
+	requestValidator, err := NewRequestValidator(requiredProfileFields)
+	if err != nil {
+		return err
+	}
+
+	e.Binder = &RequestBinder{policy: policy}
+	e.Validator = requestValidator
+	return nil
+}
+
+// RequestBinder binds path and query parameters like Echo's default binder
+// but decodes JSON bodies strictly, rejecting trailing data and, unless the
+// route is lenient, unknown fields
+type RequestBinder struct {
+	echo.DefaultBinder
+	policy BindingPolicy
+}
+
+// Bind implements echo.Binder
+func (b *RequestBinder) Bind(i interface{}, c echo.Context) error {
+	if err := b.BindPathParams(c, i); err != nil {
+		return err
+	}
+
+	method := c.Request().Method
+	if method == http.MethodGet || method == http.MethodDelete || method == http.MethodHead {
+		if err := b.BindQueryParams(c, i); err != nil {
+			return err
+		}
+	}
+
+	req := c.Request()
+	if req.ContentLength == 0 {
+		return nil
+	}
+	if !strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		return b.BindBody(c, i)
+	}
+
+	return decodeJSON(req.Body, i, b.policy.rejectsUnknownFields(c))
+}
+
+// decodeJSON decodes exactly one JSON value from body into i
+func decodeJSON(body io.Reader, i interface{}, rejectUnknownFields bool) error {
+	decoder := json.NewDecoder(body)
+	if rejectUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+
+	if err := decoder.Decode(i); err != nil {
+		return bindingError(err, i)
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return &BindingError{Field: bodyField, Message: "Request body must contain a single JSON value"}
+	}
+	return nil
+}
+
+// bindingError translates a JSON decoding error into a BindingError; errors
+// unrelated to the payload, such as exceeding the body limit, are returned as is
+func bindingError(err error, target interface{}) error {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return err
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return &BindingError{
+			Field:   bodyField,
+			Message: fmt.Sprintf("Malformed JSON at offset %d", syntaxErr.Offset),
+			cause:   err,
+		}
+	case errors.As(err, &typeErr):
+		field := typeErr.Field
+		if field == "" {
+			field = bodyField
+		}
+		return &BindingError{
+			Field:   field,
+			Message: fmt.Sprintf("Expected %s, got %s", jsonTypeName(typeErr.Type), typeErr.Value),
+			cause:   err,
+		}
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return &BindingError{Field: bodyField, Message: "Request body is incomplete", cause: err}
+	}
+
+	// encoding/json has no typed error for unknown fields
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		field = strings.Trim(field, `"`)
+		message := "Unknown field"
+		if suggestion := closestField(field, target); suggestion != "" {
+			message += fmt.Sprintf("; did you mean %q?", suggestion)
+		}
+		return &BindingError{Field: field, Message: message, cause: err}
+	}
+
+	return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body format").SetInternal(err)
+}
+
+// closestField returns the JSON field of target that name most likely
+// misspells, or "" when none is close. Spelling is compared ignoring case,
+// underscores and dashes, allowing up to two edits.
+func closestField(name string, target interface{}) string {
+	t := reflect.TypeOf(target)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return ""
+	}
+
+	normalize := strings.NewReplacer("_", "", "-", "").Replace
+	wanted := normalize(strings.ToLower(name))
+
+	best, bestDistance := "", 3
+	for i := 0; i < t.NumField(); i++ {
+		field, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if field == "" || field == "-" {
+			continue
+		}
+		if distance := textdistance.Levenshtein(wanted, normalize(field)); distance < bestDistance {
+			best, bestDistance = field, distance
+		}
+	}
+	return best
+}
+
+// jsonTypeName describes a Go type in JSON terms
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// RequestValidator validates request DTOs with their validate tags. Field
+// errors are named after the JSON fields clients send.
+type RequestValidator struct {
+	validate *validator.Validate
+}
+
+// NewRequestValidator creates a validator naming fields by their JSON tags.
+// The rules of user creation requests are built from the profile fields the
+// deployment requires, for single and bulk creation alike.
+func NewRequestValidator(requiredProfileFields []string) (*RequestValidator, error) {
+	rules, err := dto.RequiredProfileFieldRules(requiredProfileFields)
+	if err != nil {
+		return nil, err
+	}
+
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			return ""
+		case "":
+			return field.Name
+		}
+		return name
+	})
+
+	if len(rules) > 0 {
+		validate.RegisterStructValidationMapRules(rules, dto.CreateUserRequestDTO{})
+	}
+
+	return &RequestValidator{validate: validate}, nil
+}
+
+// Validate implements echo.Validator
+func (v *RequestValidator) Validate(i interface{}) error {
+	return v.validate.Struct(i)
+}
+
+// validationDetails maps each invalid field path to a message, or returns
+// false when err is not a validation or binding error
+func validationDetails(err error) (map[string]interface{}, bool) {
+	var bindingErr *BindingError
+	if errors.As(err, &bindingErr) {
+		return map[string]interface{}{bindingErr.Field: bindingErr.Message}, true
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil, false
+	}
+
+	details := make(map[string]interface{}, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		details[fieldPath(fieldError)] = getValidationErrorMessage(fieldError)
+	}
+	return details, true
+}
+
+// fieldPath strips the DTO name from a field error's namespace, turning
+// "BulkCreateUsersRequestDTO.users[1].email" into "users[1].email"
+func fieldPath(fieldError validator.FieldError) string {
+	if _, path, ok := strings.Cut(fieldError.Namespace(), "."); ok {
+		return path
+	}
+	return fieldError.Field()
+}
+
+// bindRequest binds the request into request and validates it
+func bindRequest(c echo.Context, request interface{}) error {
+	if err := c.Bind(request); err != nil {
+		return err
+	}
+	return c.Validate(request)
+}
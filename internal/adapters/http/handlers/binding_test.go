@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"user-service/internal/application/dto"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestEcho returns an Echo instance with the service's binder and validator
+func newTestEcho() *echo.Echo {
+	e := echo.New()
+	if err := RegisterRequestBinding(e, BindingPolicy{RejectUnknownFields: true}, []string{"last_name"}); err != nil {
+		panic(err)
+	}
+	return e
+}
+
+func performCreateUser(t *testing.T, body string) (*httptest.ResponseRecorder, ErrorResponse) {
+	handler := NewUserHandler(new(MockUserUseCases), nil, logger.New("test"))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := newTestEcho().NewContext(req, rec)
+
+	require.NoError(t, handler.CreateUser(c))
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	return rec, response
+}
+
+func TestBinding_MalformedJSONIsValidationError(t *testing.T) {
+	// When
+	rec, response := performCreateUser(t, `{"email": "john@example.com",`)
+
+	// Then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "VALIDATION_ERROR", response.Error)
+	assert.Contains(t, response.Details, "body")
+}
+
+func TestBinding_WrongTypeNamesTheField(t *testing.T) {
+	// When a string is expected but a number is sent
+	rec, response := performCreateUser(t, `{"email": "john@example.com", "first_name": 42}`)
+
+	// Then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "VALIDATION_ERROR", response.Error)
+	assert.Equal(t, "Expected string, got number", response.Details["first_name"])
+}
+
+func TestBinding_UnknownFieldIsRejected(t *testing.T) {
+	// When a field is misspelled
+	rec, response := performCreateUser(t, `{"email": "john@example.com", "firstname": "John"}`)
+
+	// Then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "VALIDATION_ERROR", response.Error)
+	assert.Equal(t, `Unknown field; did you mean "first_name"?`, response.Details["firstname"])
+}
+
+func TestBinding_UnknownFieldWithoutCloseMatch(t *testing.T) {
+	_, response := performCreateUser(t, `{"email": "john@example.com", "nickname": "Johnny"}`)
+
+	assert.Equal(t, "Unknown field", response.Details["nickname"])
+}
+
+func TestBinding_LenientRouteIgnoresUnknownFields(t *testing.T) {
+	// Given a policy that lets the sync endpoint accept unknown fields
+	e := echo.New()
+	require.NoError(t, RegisterRequestBinding(e, NewBindingPolicy(true, []string{"PUT /api/v1/internal/users/sync"}), nil))
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/internal/users/sync", strings.NewReader(`{"email": "john@example.com", "crm_id": 7}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c := e.NewContext(req, httptest.NewRecorder())
+	c.SetPath("/api/v1/internal/users/sync")
+
+	// When
+	var request dto.SyncUserRequestDTO
+	err := c.Bind(&request)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "john@example.com", request.Email)
+}
+
+func TestBinding_TrailingDataIsRejected(t *testing.T) {
+	rec, response := performCreateUser(t, `{"email": "john@example.com"} {"email": "jane@example.com"}`)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "VALIDATION_ERROR", response.Error)
+	assert.Contains(t, response.Details, "body")
+}
+
+func TestBinding_ValidationErrorsUseJSONFieldPaths(t *testing.T) {
+	// Given
+	e := newTestEcho()
+	request := dto.UserTagsRequestDTO{Tags: []string{"vip", strings.Repeat("x", 51)}}
+
+	// When
+	err := e.Validator.Validate(request)
+	details, ok := validationDetails(err)
+
+	// Then
+	require.True(t, ok)
+	assert.Equal(t, map[string]interface{}{"tags[1]": "Maximum length is 50 characters"}, details)
+}
+
+func TestRequestValidator_RequiredProfileFields(t *testing.T) {
+	request := dto.CreateUserRequestDTO{Email: "john@example.com", Password: "SecurePass123", FirstName: "John"}
+
+	// Given a deployment that requires phones but not last names
+	requestValidator, err := NewRequestValidator([]string{"phone"})
+	require.NoError(t, err)
+
+	// When
+	err = requestValidator.Validate(&request)
+
+	// Then
+	details, ok := validationDetails(err)
+	require.True(t, ok)
+	assert.Equal(t, map[string]interface{}{"phone": "This field is required"}, details)
+
+	// And last names stay optional
+	request.Phone = "1234567890"
+	assert.NoError(t, requestValidator.Validate(&request))
+}
+
+func TestRequestValidator_RejectsUnknownProfileField(t *testing.T) {
+	_, err := NewRequestValidator([]string{"nickname"})
+
+	assert.ErrorContains(t, err, `profile.required_fields: "nickname"`)
+}
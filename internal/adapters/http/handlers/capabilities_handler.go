@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	"user-service/internal/application/dto"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CapabilitiesHandler struct {
+	capabilities dto.CapabilitiesResponseDTO
+	// maxPageSize returns the caller's page size cap
+	maxPageSize func(echo.Context) int
+	logger      logger.Logger
+}
+
+func NewCapabilitiesHandler(capabilities dto.CapabilitiesResponseDTO, maxPageSize func(echo.Context) int, log logger.Logger) *CapabilitiesHandler {
+	return &CapabilitiesHandler{
+		capabilities: capabilities,
+		maxPageSize:  maxPageSize,
+		logger:       log.With("component", "capabilities_handler"),
+	}
+}
+
+// Capabilities handles GET /api/v1/internal/capabilities
+func (h *CapabilitiesHandler) Capabilities(c echo.Context) error {
+	h.logger.Debug("Capabilities requested",
+		"request_id", c.Response().Header().Get(echo.HeaderXRequestID))
+
+	response := h.capabilities
+	response.Limits.MaxPageSize = h.maxPageSize(c)
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"user-service/internal/application/usecases"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type DuplicateHandler struct {
+	duplicateUseCases usecases.DuplicateUseCases
+	logger            logger.Logger
+}
+
+func NewDuplicateHandler(duplicateUseCases usecases.DuplicateUseCases, log logger.Logger) *DuplicateHandler {
+	return &DuplicateHandler{
+		duplicateUseCases: duplicateUseCases,
+		logger:            log.With("component", "duplicate_handler"),
+	}
+}
+
+// ListSuggestions handles GET /api/v1/admin/duplicates. Suggestions come from
+// the last duplicate_detection run; trigger the job to refresh them.
+func (h *DuplicateHandler) ListSuggestions(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	page := 1
+	pageSize := 20
+
+	if pageParam := c.QueryParam("page"); pageParam != "" {
+		if p, err := strconv.Atoi(pageParam); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	if sizeParam := c.QueryParam("page_size"); sizeParam != "" {
+		if ps, err := strconv.Atoi(sizeParam); err == nil && ps > 0 {
+			pageSize = ps
+		}
+	}
+
+	response, err := h.duplicateUseCases.ListSuggestions(c.Request().Context(), page, pageSize)
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to list duplicate suggestions")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"user-service/internal/application/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Envelope is the uniform shape of JSON responses for clients that ask for
+// it. Successful responses carry data, failed ones errors.
+type Envelope struct {
+	Data   interface{}     `json:"data"`
+	Meta   EnvelopeMeta    `json:"meta"`
+	Errors []ErrorResponse `json:"errors,omitempty"`
+}
+
+// EnvelopeMeta describes the response around its data
+type EnvelopeMeta struct {
+	// Pagination is set for paginated responses, whose data is the page's items
+	Pagination *dto.PageMeta `json:"pagination,omitempty"`
+}
+
+// protocolPrefixes are the paths of endpoints whose response shape a protocol
+// fixes, such as OAuth 2.0 and OpenID Connect; they are never wrapped
+var protocolPrefixes = []string{"/.well-known/", "/oauth/"}
+
+// EnvelopePolicy decides which responses are wrapped in an Envelope
+type EnvelopePolicy struct {
+	// Header lets clients choose, "true" or "false"
+	Header string
+	// Default applies to clients that do not choose
+	Default bool
+}
+
+// Wraps reports whether the response to a request is wrapped
+func (p EnvelopePolicy) Wraps(c echo.Context) bool {
+	if isProtocolPath(c.Request().URL.Path) {
+		return false
+	}
+	wrap, err := strconv.ParseBool(c.Request().Header.Get(p.Header))
+	if err != nil {
+		return p.Default
+	}
+	return wrap
+}
+
+// isProtocolPath reports whether path belongs to a protocol endpoint
+func isProtocolPath(path string) bool {
+	for _, prefix := range protocolPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Variant returns the response cache variant of a request, see
+// responsecache.Variation, splitting the variants of of by envelope
+func (p EnvelopePolicy) Variant(of func(echo.Context) string) func(echo.Context) string {
+	return func(c echo.Context) string {
+		if p.Wraps(c) {
+			return of(c) + "+envelope"
+		}
+		return of(c)
+	}
+}
+
+// Variants lists the variants Variant returns for variants
+func (p EnvelopePolicy) Variants(variants []string) []string {
+	all := make([]string, 0, 2*len(variants))
+	for _, variant := range variants {
+		all = append(all, variant, variant+"+envelope")
+	}
+	return all
+}
+
+// RegisterResponseEnvelope installs the JSON serializer that wraps responses
+// as the policy decides, so handlers keep rendering their DTOs and
+// ErrorResponses as they are
+func RegisterResponseEnvelope(e *echo.Echo, policy EnvelopePolicy) {
+	e.JSONSerializer = &EnvelopeSerializer{policy: policy}
+}
+
+// EnvelopeSerializer serializes like Echo's default JSON serializer, wrapping
+// responses in an Envelope when the policy says so
+type EnvelopeSerializer struct {
+	echo.DefaultJSONSerializer
+	policy EnvelopePolicy
+}
+
+// Serialize implements echo.JSONSerializer
+func (s *EnvelopeSerializer) Serialize(c echo.Context, i interface{}, indent string) error {
+	if isProtocolPath(c.Request().URL.Path) {
+		return s.DefaultJSONSerializer.Serialize(c, i, indent)
+	}
+	c.Response().Header().Add(echo.HeaderVary, s.policy.Header)
+	if !s.policy.Wraps(c) {
+		return s.DefaultJSONSerializer.Serialize(c, i, indent)
+	}
+	return s.DefaultJSONSerializer.Serialize(c, wrap(i), indent)
+}
+
+// wrap puts a response in an Envelope
+func wrap(i interface{}) *Envelope {
+	switch response := i.(type) {
+	case ErrorResponse:
+		return &Envelope{Errors: []ErrorResponse{response}}
+	case *ErrorResponse:
+		return &Envelope{Errors: []ErrorResponse{*response}}
+	case dto.Paginated:
+		meta := response.PageMeta()
+		return &Envelope{Data: response.PageItems(), Meta: EnvelopeMeta{Pagination: &meta}}
+	default:
+		return &Envelope{Data: i}
+	}
+}
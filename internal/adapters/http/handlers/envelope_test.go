@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"user-service/internal/application/dto"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveEnveloped(t *testing.T, policy EnvelopePolicy, header string, handler echo.HandlerFunc) *httptest.ResponseRecorder {
+	return serveEnvelopedPath(t, policy, "/api/v1/users", header, handler)
+}
+
+func serveEnvelopedPath(t *testing.T, policy EnvelopePolicy, path, header string, handler echo.HandlerFunc) *httptest.ResponseRecorder {
+	e := echo.New()
+	e.HTTPErrorHandler = NewHTTPErrorHandler(logger.New("test"))
+	RegisterResponseEnvelope(e, policy)
+	e.GET(path, handler)
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if header != "" {
+		req.Header.Set("X-Response-Envelope", header)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func listUsers(c echo.Context) error {
+	return c.JSON(http.StatusOK, &dto.UserListResponseDTO{
+		Users:    []*dto.UserResponseDTO{{ID: 1, Email: "ada@example.com"}},
+		Total:    1,
+		Page:     2,
+		PageSize: 10,
+	})
+}
+
+func TestEnvelope_MovesPaginationIntoMeta(t *testing.T) {
+	// Given a client asking for the envelope
+	policy := EnvelopePolicy{Header: "X-Response-Envelope"}
+
+	// When
+	rec := serveEnveloped(t, policy, "true", listUsers)
+
+	// Then
+	require.Equal(t, http.StatusOK, rec.Code)
+	var envelope struct {
+		Data []dto.UserResponseDTO `json:"data"`
+		Meta EnvelopeMeta          `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+	require.Len(t, envelope.Data, 1)
+	assert.Equal(t, "ada@example.com", envelope.Data[0].Email)
+	require.NotNil(t, envelope.Meta.Pagination)
+	assert.Equal(t, 2, envelope.Meta.Pagination.Page)
+	assert.Equal(t, 10, envelope.Meta.Pagination.PageSize)
+	assert.Equal(t, int64(1), *envelope.Meta.Pagination.Total)
+	assert.NotContains(t, rec.Body.String(), `"users"`)
+	assert.Equal(t, "X-Response-Envelope", rec.Header().Get(echo.HeaderVary))
+}
+
+func TestEnvelope_WrapsErrors(t *testing.T) {
+	// Given envelopes by default
+	policy := EnvelopePolicy{Header: "X-Response-Envelope", Default: true}
+
+	// When
+	rec := serveEnveloped(t, policy, "", func(c echo.Context) error {
+		return domainErrors.ErrUserNotFound
+	})
+
+	// Then
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{
+		"data": null,
+		"meta": {},
+		"errors": [{"error": "USER_NOT_FOUND", "message": "User not found", "retryable": false}]
+	}`, rec.Body.String())
+}
+
+func TestEnvelope_ClientsMayOptOut(t *testing.T) {
+	// Given envelopes by default
+	policy := EnvelopePolicy{Header: "X-Response-Envelope", Default: true}
+
+	// When the client opts out
+	rec := serveEnveloped(t, policy, "false", listUsers)
+
+	// Then the response keeps its own shape
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"page_size":10`)
+	assert.NotContains(t, rec.Body.String(), `"data"`)
+}
+
+func TestEnvelope_LeavesProtocolEndpointsAlone(t *testing.T) {
+	// Given envelopes by default
+	policy := EnvelopePolicy{Header: "X-Response-Envelope", Default: true}
+
+	for _, path := range []string{"/.well-known/openid-configuration", "/oauth/jwks"} {
+		t.Run(path, func(t *testing.T) {
+			// When a protocol endpoint responds, even to a client asking for the envelope
+			rec := serveEnvelopedPath(t, policy, path, "true", func(c echo.Context) error {
+				return c.JSON(http.StatusOK, map[string]string{"issuer": "https://id.example.com"})
+			})
+
+			// Then the response keeps the shape the protocol defines
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.JSONEq(t, `{"issuer": "https://id.example.com"}`, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+
+	"user-service/internal/application/dto"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+)
+
+type ErrorCatalogHandler struct {
+	// catalogs holds the catalog in every message locale
+	catalogs map[language.Tag]dto.ErrorCatalogResponseDTO
+	logger   logger.Logger
+}
+
+// NewErrorCatalogHandler builds the catalog of domain errors in every
+// message locale
+func NewErrorCatalogHandler(log logger.Logger) *ErrorCatalogHandler {
+	catalogs := make(map[language.Tag]dto.ErrorCatalogResponseDTO, len(domainErrors.MessageLocales))
+	for _, locale := range domainErrors.MessageLocales {
+		catalog := dto.ErrorCatalogResponseDTO{
+			Locale: locale.String(),
+			Errors: make([]dto.ErrorCatalogEntryDTO, 0, len(domainErrors.Catalog)),
+		}
+		for _, err := range domainErrors.Catalog {
+			spec := domainErrorSpec(err.Code)
+			catalog.Errors = append(catalog.Errors, dto.ErrorCatalogEntryDTO{
+				Code:         err.Code,
+				Status:       spec.Status,
+				Message:      err.LocalizedMessage(locale),
+				Field:        err.Field,
+				Retryable:    spec.Retryable,
+				RetryAfterMS: spec.RetryAfter.Milliseconds(),
+			})
+		}
+		catalogs[locale] = catalog
+	}
+
+	return &ErrorCatalogHandler{
+		catalogs: catalogs,
+		logger:   log.With("component", "error_catalog_handler"),
+	}
+}
+
+// ErrorCatalog handles GET /api/v1/errors. Messages are in the locale of the
+// locale query parameter or, failing that, of the Accept-Language header,
+// and in English when neither is available.
+func (h *ErrorCatalogHandler) ErrorCatalog(c echo.Context) error {
+	locale := domainErrors.MatchMessageLocale(c.QueryParam("locale"), c.Request().Header.Get("Accept-Language"))
+
+	h.logger.Debug("Error catalog requested",
+		"request_id", c.Response().Header().Get(echo.HeaderXRequestID),
+		"locale", locale.String())
+
+	c.Response().Header().Add(echo.HeaderVary, "Accept-Language")
+	c.Response().Header().Set("Content-Language", locale.String())
+	return c.JSON(http.StatusOK, h.catalogs[locale])
+}
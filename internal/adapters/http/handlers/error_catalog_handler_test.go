@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"user-service/internal/application/dto"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func performErrorCatalogRequest(t *testing.T, target, acceptLanguage string) (*httptest.ResponseRecorder, dto.ErrorCatalogResponseDTO) {
+	t.Helper()
+	handler := NewErrorCatalogHandler(logger.New("test"))
+
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if acceptLanguage != "" {
+		req.Header.Set("Accept-Language", acceptLanguage)
+	}
+	rec := httptest.NewRecorder()
+	require.NoError(t, handler.ErrorCatalog(echo.New().NewContext(req, rec)))
+
+	var response dto.ErrorCatalogResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	return rec, response
+}
+
+// catalogEntry returns the entry of the catalog with code
+func catalogEntry(t *testing.T, response dto.ErrorCatalogResponseDTO, code string) dto.ErrorCatalogEntryDTO {
+	t.Helper()
+	for _, entry := range response.Errors {
+		if entry.Code == code {
+			return entry
+		}
+	}
+	t.Fatalf("%s is not in the catalog", code)
+	return dto.ErrorCatalogEntryDTO{}
+}
+
+func TestErrorCatalogHandler_ListsEveryDomainError(t *testing.T) {
+	// When
+	rec, response := performErrorCatalogRequest(t, "/api/v1/errors", "")
+
+	// Then every error is listed in English with the response it gets
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "en", response.Locale)
+	assert.Len(t, response.Errors, len(errorContract))
+	for _, contract := range errorContract {
+		entry := catalogEntry(t, response, contract.err.Code)
+		assert.Equal(t, contract.status, entry.Status, contract.err.Code)
+		assert.Equal(t, contract.retryable, entry.Retryable, contract.err.Code)
+		assert.Equal(t, contract.err.Message, entry.Message, contract.err.Code)
+		assert.Equal(t, contract.err.Field, entry.Field, contract.err.Code)
+	}
+
+	rateLimited := catalogEntry(t, response, domainErrors.ErrBulkCapacityExceeded.Code)
+	assert.Equal(t, int64(5000), rateLimited.RetryAfterMS)
+}
+
+func TestErrorCatalogHandler_LocalizesMessages(t *testing.T) {
+	tests := []struct {
+		name, target, acceptLanguage, locale string
+	}{
+		{"accept language", "/api/v1/errors", "fr-FR, es-CO;q=0.8", "es"},
+		{"query parameter first", "/api/v1/errors?locale=en", "es", "en"},
+		{"unsupported query parameter", "/api/v1/errors?locale=fr", "es", "es"},
+		{"unsupported", "/api/v1/errors", "de", "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When
+			rec, response := performErrorCatalogRequest(t, tt.target, tt.acceptLanguage)
+
+			// Then
+			assert.Equal(t, tt.locale, response.Locale)
+			assert.Equal(t, tt.locale, rec.Header().Get("Content-Language"))
+			assert.Equal(t, "Accept-Language", rec.Header().Get(echo.HeaderVary))
+			userNotFound := catalogEntry(t, response, domainErrors.ErrUserNotFound.Code)
+			if tt.locale == "es" {
+				assert.Equal(t, "Usuario no encontrado", userNotFound.Message)
+			} else {
+				assert.Equal(t, domainErrors.ErrUserNotFound.Message, userNotFound.Message)
+			}
+		})
+	}
+}
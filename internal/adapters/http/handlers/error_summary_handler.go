@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"user-service/internal/application/dto"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ErrorSummaryHandler struct {
+	// summary returns the rolling summary of this instance's server errors
+	summary func() dto.ErrorSummaryResponseDTO
+	logger  logger.Logger
+}
+
+func NewErrorSummaryHandler(summary func() dto.ErrorSummaryResponseDTO, log logger.Logger) *ErrorSummaryHandler {
+	return &ErrorSummaryHandler{
+		summary: summary,
+		logger:  log.With("component", "error_summary_handler"),
+	}
+}
+
+// RecentErrors handles GET /api/v1/admin/errors/recent. The summary covers
+// the instance that answers, as each keeps its own in memory.
+func (h *ErrorSummaryHandler) RecentErrors(c echo.Context) error {
+	h.logger.Debug("Recent errors requested",
+		"request_id", c.Response().Header().Get(echo.HeaderXRequestID))
+
+	return c.JSON(http.StatusOK, h.summary())
+}
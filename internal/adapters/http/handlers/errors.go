@@ -0,0 +1,314 @@
+package handlers
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string                 `json:"error"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details,omitempty"`
+	// Retryable tells clients whether repeating the same request may succeed
+	Retryable bool `json:"retryable"`
+	// RetryAfterMS is the suggested minimum wait before retrying
+	RetryAfterMS int64 `json:"retry_after_ms,omitempty"`
+	// RequestID identifies the request in logs; clients should quote it to support
+	RequestID string `json:"request_id,omitempty"`
+	// TraceID is the distributed trace the request belongs to, when one was propagated
+	TraceID string `json:"trace_id,omitempty"`
+}
+
+// errorSpec describes how an error is exposed over HTTP
+type errorSpec struct {
+	Status     int
+	Retryable  bool
+	RetryAfter time.Duration
+}
+
+// errorCodeContextKey holds the code of the error body written for a request
+const errorCodeContextKey = "error_code"
+
+// transientFailure is used for infrastructure failures clients may retry
+var transientFailure = errorSpec{Status: http.StatusServiceUnavailable, Retryable: true, RetryAfter: time.Second}
+
+// domainErrorSpecs maps domain error codes to their HTTP representation.
+// Domain errors without an entry are treated as non-retryable client errors.
+var domainErrorSpecs = map[string]errorSpec{
+	// Handlers answer queued writes with their ID; this covers any other path
+	domainErrors.ErrWriteQueued.Code:                   {Status: http.StatusAccepted},
+	domainErrors.ErrUserNotFound.Code:                  {Status: http.StatusNotFound},
+	domainErrors.ErrNoteNotFound.Code:                  {Status: http.StatusNotFound},
+	domainErrors.ErrJobNotFound.Code:                   {Status: http.StatusNotFound},
+	domainErrors.ErrOIDCClientNotFound.Code:            {Status: http.StatusNotFound},
+	domainErrors.ErrSuppressionNotFound.Code:           {Status: http.StatusNotFound},
+	domainErrors.ErrJobAlreadyRunning.Code:             {Status: http.StatusConflict},
+	domainErrors.ErrUserAlreadyExists.Code:             {Status: http.StatusConflict},
+	domainErrors.ErrExternalIDTaken.Code:               {Status: http.StatusConflict},
+	domainErrors.ErrDeleteBlocked.Code:                 {Status: http.StatusConflict},
+	domainErrors.ErrLegalHoldActive.Code:               {Status: http.StatusConflict},
+	domainErrors.ErrAlreadyReferred.Code:               {Status: http.StatusConflict},
+	domainErrors.ErrInvalidStatusTransition.Code:       {Status: http.StatusConflict},
+	domainErrors.ErrStatusChanged.Code:                 {Status: http.StatusConflict},
+	domainErrors.ErrVerificationSentChanged.Code:       {Status: http.StatusConflict},
+	domainErrors.ErrUnauthorized.Code:                  {Status: http.StatusUnauthorized},
+	domainErrors.ErrInvalidCredentials.Code:            {Status: http.StatusUnauthorized},
+	domainErrors.ErrInvalidClientCredentials.Code:      {Status: http.StatusUnauthorized},
+	domainErrors.ErrInvalidAccessToken.Code:            {Status: http.StatusUnauthorized},
+	domainErrors.ErrForbidden.Code:                     {Status: http.StatusForbidden},
+	domainErrors.ErrNoteForbidden.Code:                 {Status: http.StatusForbidden},
+	domainErrors.ErrInvalidUserEmail.Code:              {Status: http.StatusBadRequest},
+	domainErrors.ErrInvalidUserPassword.Code:           {Status: http.StatusBadRequest},
+	domainErrors.ErrInvalidResidency.Code:              {Status: http.StatusBadRequest},
+	domainErrors.ErrInvalidCountry.Code:                {Status: http.StatusBadRequest},
+	domainErrors.ErrInvalidStatus.Code:                 {Status: http.StatusBadRequest},
+	domainErrors.ErrSuspensionReasonRequired.Code:      {Status: http.StatusBadRequest},
+	domainErrors.ErrInvalidSuspensionReason.Code:       {Status: http.StatusBadRequest},
+	domainErrors.ErrInvalidReactivationDate.Code:       {Status: http.StatusBadRequest},
+	domainErrors.ErrUnexpectedSuspensionDetails.Code:   {Status: http.StatusBadRequest},
+	domainErrors.ErrCrossRegionAccess.Code:             {Status: http.StatusMisdirectedRequest},
+	domainErrors.ErrTooManyBulkItems.Code:              {Status: http.StatusRequestEntityTooLarge},
+	domainErrors.ErrActionRateLimited.Code:             {Status: http.StatusTooManyRequests, Retryable: true, RetryAfter: time.Minute},
+	domainErrors.ErrBulkCapacityExceeded.Code:          {Status: http.StatusTooManyRequests, Retryable: true, RetryAfter: 5 * time.Second},
+	domainErrors.ErrFailedToCheckUserExistance.Code:    transientFailure,
+	domainErrors.ErrFailedToCreateUser.Code:            transientFailure,
+	domainErrors.ErrFailedToListUsers.Code:             transientFailure,
+	domainErrors.ErrFailedToUpdateUserTags.Code:        transientFailure,
+	domainErrors.ErrFailedToUpdateUserPreferences.Code: transientFailure,
+	domainErrors.ErrFailedToUpdateUserProfile.Code:     transientFailure,
+	domainErrors.ErrFailedToSyncUser.Code:              transientFailure,
+	domainErrors.ErrFailedToLookUpPhone.Code:           transientFailure,
+	domainErrors.ErrFailedToUpdateReferral.Code:        transientFailure,
+	domainErrors.ErrFailedToDeleteUser.Code:            transientFailure,
+	domainErrors.ErrFailedToUpdateUserStatus.Code:      transientFailure,
+	domainErrors.ErrFailedToStoreEvent.Code:            transientFailure,
+	domainErrors.ErrFailedToReadEvents.Code:            transientFailure,
+	domainErrors.ErrFailedToLoadJobState.Code:          transientFailure,
+	domainErrors.ErrFailedToSaveJobState.Code:          transientFailure,
+	domainErrors.ErrFailedToStoreDuplicates.Code:       transientFailure,
+	domainErrors.ErrFailedToListDuplicates.Code:        transientFailure,
+	domainErrors.ErrFailedToRegisterOIDCClient.Code:    transientFailure,
+	domainErrors.ErrFailedToUpdateOIDCClient.Code:      transientFailure,
+	domainErrors.ErrFailedToLoadOIDCClients.Code:       transientFailure,
+	domainErrors.ErrFailedToIssueTokens.Code:           transientFailure,
+	domainErrors.ErrFailedToCheckSuppressions.Code:     transientFailure,
+	domainErrors.ErrFailedToUpdateSuppressions.Code:    transientFailure,
+	domainErrors.ErrFailedToUpdateLegalHold.Code:       transientFailure,
+	domainErrors.ErrWriteQueueFull.Code:                transientFailure,
+	domainErrors.ErrFailedToQueueWrite.Code:            transientFailure,
+	domainErrors.ErrFailedToCountActions.Code:          transientFailure,
+	domainErrors.ErrFailedToUpdateVerification.Code:    transientFailure,
+	domainErrors.ErrFailedToRecordUsage.Code:           transientFailure,
+	domainErrors.ErrFailedToReadUsage.Code:             transientFailure,
+	domainErrors.ErrFailedToExportUsage.Code:           transientFailure,
+	// The caller's budget is spent; retrying with the same budget would fail again
+	domainErrors.ErrDeadlineExceeded.Code: {Status: http.StatusGatewayTimeout},
+	// An unexpected failure; nothing says a retry would fare better
+	domainErrors.ErrInternal.Code: {Status: http.StatusInternalServerError},
+	// Resources added by "user-service scaffold resource" (scaffold:errors)
+}
+
+// domainErrorSpec returns the HTTP representation of a domain error code
+func domainErrorSpec(code string) errorSpec {
+	if spec, ok := domainErrorSpecs[code]; ok {
+		return spec
+	}
+	return errorSpec{Status: http.StatusBadRequest}
+}
+
+// statusErrorSpecs gives retry hints for framework errors that only carry a status
+var statusErrorSpecs = map[int]errorSpec{
+	http.StatusTooManyRequests:    {Status: http.StatusTooManyRequests, Retryable: true, RetryAfter: time.Second},
+	http.StatusBadGateway:         {Status: http.StatusBadGateway, Retryable: true, RetryAfter: time.Second},
+	http.StatusServiceUnavailable: transientFailure,
+	http.StatusGatewayTimeout:     {Status: http.StatusGatewayTimeout, Retryable: true, RetryAfter: time.Second},
+}
+
+// respondWithError logs err and maps it to the matching HTTP error response
+func respondWithError(c echo.Context, log logger.Logger, err error, requestID, logMessage string) error {
+	log.Error(logMessage,
+		"request_id", requestID,
+		"error", err)
+
+	return renderError(c, err)
+}
+
+// NewHTTPErrorHandler returns the Echo error handler used for every error that
+// escapes a handler or middleware, so all error bodies share the same shape
+func NewHTTPErrorHandler(log logger.Logger) echo.HTTPErrorHandler {
+	log = log.With("component", "http_error_handler")
+
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		if renderErr := renderError(c, err); renderErr != nil {
+			log.Error("Failed to render error response",
+				"request_id", c.Response().Header().Get(echo.HeaderXRequestID),
+				"error", renderErr)
+		}
+	}
+}
+
+// renderError writes the ErrorResponse for err
+func renderError(c echo.Context, err error) error {
+	if deadlineExceeded(c, err) {
+		err = domainErrors.ErrDeadlineExceeded
+	}
+
+	// Handle malformed and invalid request bodies
+	if details, ok := validationDetails(err); ok {
+		return writeError(c, domainErrorSpec(domainErrors.ErrValidationFailed.Code), ErrorResponse{
+			Error:   domainErrors.ErrValidationFailed.Code,
+			Message: domainErrors.ErrValidationFailed.Message,
+			Details: details,
+		})
+	}
+
+	// Handle domain errors
+	var domainErr *domainErrors.DomainError
+	if errors.As(err, &domainErr) {
+		spec := domainErrorSpec(domainErr.Code)
+		response := ErrorResponse{
+			Error:   domainErr.Code,
+			Message: domainErr.Message,
+		}
+
+		// Some domain errors carry structured details, e.g. deletion blockers
+		var detailed interface{ Details() map[string]interface{} }
+		if errors.As(err, &detailed) {
+			response.Details = detailed.Details()
+		}
+
+		// Others know when a retry may succeed, e.g. per-account rate limits
+		var delayed interface{ RetryAfter() time.Duration }
+		if spec.Retryable && errors.As(err, &delayed) {
+			spec.RetryAfter = delayed.RetryAfter()
+		}
+
+		return writeError(c, spec, response)
+	}
+
+	// Handle framework errors (routing, binding, timeouts, rate limits)
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		spec, ok := statusErrorSpecs[httpErr.Code]
+		if !ok {
+			spec = errorSpec{Status: httpErr.Code}
+		}
+
+		message := http.StatusText(httpErr.Code)
+		if m, ok := httpErr.Message.(string); ok && m != "" {
+			message = m
+		}
+
+		return writeError(c, spec, ErrorResponse{
+			Error:   statusErrorCode(httpErr.Code),
+			Message: message,
+		})
+	}
+
+	// Handle generic errors
+	return renderError(c, domainErrors.ErrInternal)
+}
+
+// writeError is the single place error bodies are written, stamping them with
+// retry hints and the identifiers needed to find the request in logs
+func writeError(c echo.Context, spec errorSpec, response ErrorResponse) error {
+	c.Set(errorCodeContextKey, response.Error)
+	response.Retryable = spec.Retryable
+	response.RequestID = requestIDFrom(c)
+	response.TraceID = traceIDFrom(c.Request().Header.Get("traceparent"))
+
+	if spec.Retryable {
+		retryAfter := spec.RetryAfter
+		if header := c.Response().Header().Get(echo.HeaderRetryAfter); header != "" {
+			if seconds, err := strconv.Atoi(header); err == nil {
+				retryAfter = time.Duration(seconds) * time.Second
+			}
+		} else if retryAfter > 0 {
+			c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(int((retryAfter+time.Second-1)/time.Second)))
+		}
+		response.RetryAfterMS = retryAfter.Milliseconds()
+	}
+
+	return c.JSON(spec.Status, response)
+}
+
+// ErrorCodeFrom returns the code of the error body written for the request,
+// or "" when none was
+func ErrorCodeFrom(c echo.Context) string {
+	code, _ := c.Get(errorCodeContextKey).(string)
+	return code
+}
+
+// deadlineExceeded reports whether err is the result of the request running
+// out of time. Repositories translate driver errors into domain failures and
+// lose the cause, so infrastructure failures after the deadline count too.
+func deadlineExceeded(c echo.Context, err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	if !errors.Is(c.Request().Context().Err(), context.DeadlineExceeded) {
+		return false
+	}
+
+	var domainErr *domainErrors.DomainError
+	if errors.As(err, &domainErr) {
+		return domainErrorSpecs[domainErr.Code].Retryable
+	}
+	var httpErr *echo.HTTPError
+	return !errors.As(err, &httpErr)
+}
+
+func requestIDFrom(c echo.Context) string {
+	if requestID := c.Response().Header().Get(echo.HeaderXRequestID); requestID != "" {
+		return requestID
+	}
+	return c.Request().Header.Get(echo.HeaderXRequestID)
+}
+
+// traceIDFrom extracts the trace ID from a W3C traceparent header
+// ("00-<trace-id>-<parent-id>-<flags>")
+func traceIDFrom(traceparent string) string {
+	parts := strings.Split(traceparent, "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || parts[1] == strings.Repeat("0", 32) {
+		return ""
+	}
+	if _, err := hex.DecodeString(parts[1]); err != nil {
+		return ""
+	}
+	return parts[1]
+}
+
+// statusErrorCode derives an error code such as NOT_FOUND from an HTTP status
+func statusErrorCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "INVALID_REQUEST"
+	case http.StatusTooManyRequests:
+		return "RATE_LIMITED"
+	case http.StatusRequestEntityTooLarge:
+		return "REQUEST_TOO_LARGE"
+	case http.StatusGatewayTimeout:
+		return "TIMEOUT"
+	}
+
+	text := http.StatusText(status)
+	if text == "" {
+		return domainErrors.ErrInternal.Code
+	}
+	return strings.ToUpper(strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(text))
+}
@@ -0,0 +1,323 @@
+package handlers
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	domainErrors "user-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// domainErrorsDir holds the source of every domain error
+const domainErrorsDir = "../../../domain/errors"
+
+// errorContract documents the HTTP response of every domain error. Adding a
+// DomainError without an entry here fails TestErrorContract_EveryDomainErrorIsDocumented.
+var errorContract = []struct {
+	err       *domainErrors.DomainError
+	status    int
+	retryable bool
+}{
+	// Sensitive action limits
+	{domainErrors.ErrActionRateLimited, http.StatusTooManyRequests, true},
+	{domainErrors.ErrFailedToCountActions, http.StatusServiceUnavailable, true},
+
+	// Authentication
+	{domainErrors.ErrUnauthorized, http.StatusUnauthorized, false},
+	{domainErrors.ErrForbidden, http.StatusForbidden, false},
+
+	// Deadlines
+	{domainErrors.ErrDeadlineExceeded, http.StatusGatewayTimeout, false},
+
+	// Deletion
+	{domainErrors.ErrDeleteBlocked, http.StatusConflict, false},
+	{domainErrors.ErrFailedToDeleteUser, http.StatusServiceUnavailable, true},
+
+	// Duplicate detection
+	{domainErrors.ErrFailedToStoreDuplicates, http.StatusServiceUnavailable, true},
+	{domainErrors.ErrFailedToListDuplicates, http.StatusServiceUnavailable, true},
+
+	// Domain events
+	{domainErrors.ErrFailedToStoreEvent, http.StatusServiceUnavailable, true},
+	{domainErrors.ErrFailedToReadEvents, http.StatusServiceUnavailable, true},
+
+	// Scheduled jobs
+	{domainErrors.ErrJobNotFound, http.StatusNotFound, false},
+	{domainErrors.ErrJobAlreadyRunning, http.StatusConflict, false},
+	{domainErrors.ErrFailedToLoadJobState, http.StatusServiceUnavailable, true},
+	{domainErrors.ErrFailedToSaveJobState, http.StatusServiceUnavailable, true},
+
+	// Legal holds
+	{domainErrors.ErrLegalHoldActive, http.StatusConflict, false},
+	{domainErrors.ErrInvalidLegalHoldReason, http.StatusBadRequest, false},
+	{domainErrors.ErrInvalidLegalHoldExpiry, http.StatusBadRequest, false},
+	{domainErrors.ErrFailedToUpdateLegalHold, http.StatusServiceUnavailable, true},
+
+	// OIDC provider
+	{domainErrors.ErrOIDCClientNotFound, http.StatusNotFound, false},
+	{domainErrors.ErrInvalidClientCredentials, http.StatusUnauthorized, false},
+	{domainErrors.ErrInvalidRedirectURI, http.StatusBadRequest, false},
+	{domainErrors.ErrInvalidClientName, http.StatusBadRequest, false},
+	{domainErrors.ErrInvalidScope, http.StatusBadRequest, false},
+	{domainErrors.ErrInvalidClaimMapping, http.StatusBadRequest, false},
+	{domainErrors.ErrUnsupportedResponseType, http.StatusBadRequest, false},
+	{domainErrors.ErrPKCERequired, http.StatusBadRequest, false},
+	{domainErrors.ErrUnsupportedGrantType, http.StatusBadRequest, false},
+	{domainErrors.ErrInvalidAuthorizationCode, http.StatusBadRequest, false},
+	{domainErrors.ErrInvalidCredentials, http.StatusUnauthorized, false},
+	{domainErrors.ErrInvalidAccessToken, http.StatusUnauthorized, false},
+	{domainErrors.ErrFailedToRegisterOIDCClient, http.StatusServiceUnavailable, true},
+	{domainErrors.ErrFailedToUpdateOIDCClient, http.StatusServiceUnavailable, true},
+	{domainErrors.ErrFailedToLoadOIDCClients, http.StatusServiceUnavailable, true},
+	{domainErrors.ErrFailedToIssueTokens, http.StatusServiceUnavailable, true},
+
+	// Referrals
+	{domainErrors.ErrInvalidReferralCode, http.StatusBadRequest, false},
+	{domainErrors.ErrSelfReferral, http.StatusBadRequest, false},
+	{domainErrors.ErrReferralLoop, http.StatusBadRequest, false},
+	{domainErrors.ErrAlreadyReferred, http.StatusConflict, false},
+	{domainErrors.ErrFailedToUpdateReferral, http.StatusServiceUnavailable, true},
+
+	// Request parameters and unexpected failures
+	{domainErrors.ErrInvalidID, http.StatusBadRequest, false},
+	{domainErrors.ErrInvalidCursor, http.StatusBadRequest, false},
+	{domainErrors.ErrInvalidTime, http.StatusBadRequest, false},
+	{domainErrors.ErrValidationFailed, http.StatusBadRequest, false},
+	{domainErrors.ErrInternal, http.StatusInternalServerError, false},
+
+	// Data residency
+	{domainErrors.ErrInvalidResidency, http.StatusBadRequest, false},
+	{domainErrors.ErrCrossRegionAccess, http.StatusMisdirectedRequest, false},
+
+	// Country
+	{domainErrors.ErrInvalidCountry, http.StatusBadRequest, false},
+
+	// Account status
+	{domainErrors.ErrInvalidStatus, http.StatusBadRequest, false},
+	{domainErrors.ErrInvalidStatusTransition, http.StatusConflict, false},
+	{domainErrors.ErrStatusChanged, http.StatusConflict, false},
+	{domainErrors.ErrSuspensionReasonRequired, http.StatusBadRequest, false},
+	{domainErrors.ErrInvalidSuspensionReason, http.StatusBadRequest, false},
+	{domainErrors.ErrInvalidReactivationDate, http.StatusBadRequest, false},
+	{domainErrors.ErrUnexpectedSuspensionDetails, http.StatusBadRequest, false},
+	{domainErrors.ErrFailedToUpdateUserStatus, http.StatusServiceUnavailable, true},
+
+	// Suppression list
+	{domainErrors.ErrSuppressionNotFound, http.StatusNotFound, false},
+	{domainErrors.ErrInvalidSuppressionReason, http.StatusBadRequest, false},
+	{domainErrors.ErrInvalidSuppressionNote, http.StatusBadRequest, false},
+	{domainErrors.ErrInvalidSuppressionExpiry, http.StatusBadRequest, false},
+	{domainErrors.ErrFailedToCheckSuppressions, http.StatusServiceUnavailable, true},
+	{domainErrors.ErrFailedToUpdateSuppressions, http.StatusServiceUnavailable, true},
+
+	// Users, tags, profiles and notes
+	{domainErrors.ErrUserNotFound, http.StatusNotFound, false},
+	{domainErrors.ErrUserAlreadyExists, http.StatusConflict, false},
+	{domainErrors.ErrInvalidUserEmail, http.StatusBadRequest, false},
+	{domainErrors.ErrInvalidUserPassword, http.StatusBadRequest, false},
+	{domainErrors.ErrUserInactive, http.StatusBadRequest, false},
+	{domainErrors.ErrUserSuspended, http.StatusBadRequest, false},
+	{domainErrors.ErrFailedToCheckUserExistance, http.StatusServiceUnavailable, true},
+	{domainErrors.ErrFailedToCreateUser, http.StatusServiceUnavailable, true},
+	{domainErrors.ErrFailedToListUsers, http.StatusServiceUnavailable, true},
+	{domainErrors.ErrInvalidTag, http.StatusBadRequest, false},
+	{domainErrors.ErrTooManyTags, http.StatusBadRequest, false},
+	{domainErrors.ErrFailedToUpdateUserTags, http.StatusServiceUnavailable, true},
+	{domainErrors.ErrInvalidExternalID, http.StatusBadRequest, false},
+	{domainErrors.ErrInvalidLocale, http.StatusBadRequest, false},
+	{domainErrors.ErrInvalidTimezone, http.StatusBadRequest, false},
+	{domainErrors.ErrInvalidVisibility, http.StatusBadRequest, false},
+	{domainErrors.ErrInvalidDisplayName, http.StatusBadRequest, false},
+	{domainErrors.ErrInvalidPronouns, http.StatusBadRequest, false},
+	{domainErrors.ErrInvalidDateOfBirth, http.StatusBadRequest, false},
+	{domainErrors.ErrUnderMinimumAge, http.StatusBadRequest, false},
+	{domainErrors.ErrExternalIDTaken, http.StatusConflict, false},
+	{domainErrors.ErrFailedToUpdateUserProfile, http.StatusServiceUnavailable, true},
+	{domainErrors.ErrFailedToUpdateUserPreferences, http.StatusServiceUnavailable, true},
+	{domainErrors.ErrTooManyBulkItems, http.StatusRequestEntityTooLarge, false},
+	{domainErrors.ErrInvalidSyncPolicy, http.StatusBadRequest, false},
+	{domainErrors.ErrSyncCannotSuspend, http.StatusBadRequest, false},
+	{domainErrors.ErrFailedToSyncUser, http.StatusServiceUnavailable, true},
+	{domainErrors.ErrBulkCapacityExceeded, http.StatusTooManyRequests, true},
+	{domainErrors.ErrInvalidLookupPhone, http.StatusBadRequest, false},
+	{domainErrors.ErrFailedToLookUpPhone, http.StatusServiceUnavailable, true},
+	{domainErrors.ErrInvalidSearchQuery, http.StatusBadRequest, false},
+	{domainErrors.ErrNoteNotFound, http.StatusNotFound, false},
+	{domainErrors.ErrNoteForbidden, http.StatusForbidden, false},
+	{domainErrors.ErrInvalidNoteText, http.StatusBadRequest, false},
+	{domainErrors.ErrInvalidNoteVisibility, http.StatusBadRequest, false},
+
+	// Offline writes; conflicts and malformed writes only surface in replay, never over HTTP
+	{domainErrors.ErrWriteQueued, http.StatusAccepted, false},
+	{domainErrors.ErrWriteQueueFull, http.StatusServiceUnavailable, true},
+	{domainErrors.ErrFailedToQueueWrite, http.StatusServiceUnavailable, true},
+	{domainErrors.ErrQueuedWriteConflict, http.StatusBadRequest, false},
+	{domainErrors.ErrMalformedQueuedWrite, http.StatusBadRequest, false},
+
+	// Verification emails; concurrent requests are answered with the cooldown instead
+	{domainErrors.ErrVerificationSentChanged, http.StatusConflict, false},
+	{domainErrors.ErrFailedToUpdateVerification, http.StatusServiceUnavailable, true},
+
+	// Usage metering; recording and exporting run in the background, never over HTTP
+	{domainErrors.ErrInvalidUsageRange, http.StatusBadRequest, false},
+	{domainErrors.ErrFailedToReadUsage, http.StatusServiceUnavailable, true},
+	{domainErrors.ErrFailedToRecordUsage, http.StatusServiceUnavailable, true},
+	{domainErrors.ErrFailedToExportUsage, http.StatusServiceUnavailable, true},
+
+	// Resources added by "user-service scaffold resource" (scaffold:contract)
+}
+
+// declaredDomainErrors parses the domain errors package and returns the code
+// of every package-level DomainError by variable name
+func declaredDomainErrors(t *testing.T) map[string]string {
+	t.Helper()
+	fset := token.NewFileSet()
+	paths, err := filepath.Glob(filepath.Join(domainErrorsDir, "*.go"))
+	require.NoError(t, err)
+
+	declared := make(map[string]string)
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		require.NoError(t, err)
+
+		ast.Inspect(file, func(node ast.Node) bool {
+			spec, ok := node.(*ast.ValueSpec)
+			if !ok {
+				return true
+			}
+			for i, value := range spec.Values {
+				unary, ok := value.(*ast.UnaryExpr)
+				if !ok {
+					continue
+				}
+				literal, ok := unary.X.(*ast.CompositeLit)
+				if !ok || !isIdent(literal.Type, "DomainError") {
+					continue
+				}
+				declared[spec.Names[i].Name] = literalCode(t, literal)
+			}
+			return true
+		})
+	}
+	require.NotEmpty(t, declared)
+	return declared
+}
+
+func isIdent(expr ast.Expr, name string) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == name
+}
+
+// literalCode returns the Code field of a DomainError literal
+func literalCode(t *testing.T, literal *ast.CompositeLit) string {
+	for _, elt := range literal.Elts {
+		field, ok := elt.(*ast.KeyValueExpr)
+		if !ok || !isIdent(field.Key, "Code") {
+			continue
+		}
+		value, ok := field.Value.(*ast.BasicLit)
+		require.True(t, ok, "domain error codes must be string literals")
+		code, err := strconv.Unquote(value.Value)
+		require.NoError(t, err)
+		return code
+	}
+	t.Fatalf("domain error literal without a code")
+	return ""
+}
+
+func TestErrorContract_EveryDomainErrorIsDocumented(t *testing.T) {
+	// Given
+	declared := declaredDomainErrors(t)
+	documented := make(map[string]bool)
+	for _, entry := range errorContract {
+		assert.False(t, documented[entry.err.Code], "%s is documented twice", entry.err.Code)
+		documented[entry.err.Code] = true
+	}
+
+	// Then every declared error has a documented response, under its own code
+	owners := make(map[string]string)
+	for name, code := range declared {
+		assert.True(t, documented[code], "domainErrors.%s (%s) has no entry in errorContract", name, code)
+		if owner, taken := owners[code]; taken {
+			t.Errorf("domainErrors.%s and domainErrors.%s share the code %s", owner, name, code)
+		}
+		owners[code] = name
+	}
+	assert.Len(t, errorContract, len(declared), "errorContract documents errors that are no longer declared")
+}
+
+func TestErrorContract_DomainErrorsMapToDocumentedResponses(t *testing.T) {
+	for _, entry := range errorContract {
+		t.Run(entry.err.Code, func(t *testing.T) {
+			// When
+			rec, response := handleTestError(t, entry.err, nil)
+
+			// Then
+			assert.Equal(t, entry.status, rec.Code)
+			assert.Equal(t, entry.err.Code, response.Error)
+			assert.Equal(t, entry.err.Message, response.Message)
+			assert.Equal(t, entry.retryable, response.Retryable)
+			if entry.retryable {
+				assert.Positive(t, response.RetryAfterMS)
+			}
+		})
+	}
+}
+
+func TestErrorContract_SpecsOnlyNameDeclaredErrors(t *testing.T) {
+	// Given
+	declared := make(map[string]bool)
+	for _, code := range declaredDomainErrors(t) {
+		declared[code] = true
+	}
+
+	// Then no spec is left behind for a removed or renamed error
+	for code := range domainErrorSpecs {
+		assert.True(t, declared[code], "domainErrorSpecs maps %s, which is not a declared domain error", code)
+	}
+}
+
+// errorBodyCall matches handlers writing a response body with a status
+var errorBodyCall = regexp.MustCompile(`\.(JSON|JSONPretty|JSONBlob|String|Blob|HTML)\(http\.(Status\w+)`)
+
+// successStatuses are the statuses handlers may write bodies with directly
+var successStatuses = map[string]bool{
+	"StatusOK": true, "StatusCreated": true, "StatusAccepted": true, "StatusNonAuthoritativeInfo": true,
+	"StatusResetContent": true, "StatusPartialContent": true, "StatusMultiStatus": true,
+}
+
+func TestErrorContract_HandlersRenderErrorsThroughTheContract(t *testing.T) {
+	// Given every handler, including ones added later
+	paths, err := filepath.Glob("*.go")
+	require.NoError(t, err)
+
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		source, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		// Then error bodies go through writeError, so they keep the shared shape
+		for _, match := range errorBodyCall.FindAllStringSubmatch(string(source), -1) {
+			assert.True(t, successStatuses[match[2]],
+				"%s writes an error body with http.%s directly; use writeError or renderError", path, match[2])
+		}
+	}
+
+	// And bodiless error statuses, as HEAD responses use, stay allowed
+	assert.False(t, errorBodyCall.MatchString("c.NoContent(http.StatusNotFound)"))
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func handleTestError(t *testing.T, err error, prepare func(c echo.Context)) (*httptest.ResponseRecorder, ErrorResponse) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	if prepare != nil {
+		prepare(c)
+	}
+
+	NewHTTPErrorHandler(logger.New("test"))(err, c)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	return rec, response
+}
+
+func TestHTTPErrorHandler_NonRetryableDomainError(t *testing.T) {
+	rec, response := handleTestError(t, domainErrors.ErrUserNotFound, nil)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "USER_NOT_FOUND", response.Error)
+	assert.False(t, response.Retryable)
+	assert.Zero(t, response.RetryAfterMS)
+	assert.Empty(t, rec.Header().Get(echo.HeaderRetryAfter))
+}
+
+func TestHTTPErrorHandler_TransientDomainErrorIsRetryable(t *testing.T) {
+	rec, response := handleTestError(t, domainErrors.ErrFailedToListUsers, nil)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "FAILED_TO_LIST_USERS", response.Error)
+	assert.True(t, response.Retryable)
+	assert.Equal(t, int64(1000), response.RetryAfterMS)
+	assert.Equal(t, "1", rec.Header().Get(echo.HeaderRetryAfter))
+}
+
+func TestHTTPErrorHandler_RateLimitHonorsRetryAfterHeader(t *testing.T) {
+	rec, response := handleTestError(t, echo.NewHTTPError(http.StatusTooManyRequests), func(c echo.Context) {
+		c.Response().Header().Set(echo.HeaderRetryAfter, "5")
+	})
+
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "RATE_LIMITED", response.Error)
+	assert.True(t, response.Retryable)
+	assert.Equal(t, int64(5000), response.RetryAfterMS)
+}
+
+func TestHTTPErrorHandler_FrameworkErrorGetsCode(t *testing.T) {
+	rec, response := handleTestError(t, echo.ErrNotFound, nil)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "NOT_FOUND", response.Error)
+	assert.False(t, response.Retryable)
+}
+
+func TestHTTPErrorHandler_UnknownErrorIsInternal(t *testing.T) {
+	rec, response := handleTestError(t, errors.New("boom"), nil)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "INTERNAL_ERROR", response.Error)
+	assert.False(t, response.Retryable)
+}
+
+func TestHTTPErrorHandler_IncludesRequestAndTraceIDs(t *testing.T) {
+	_, response := handleTestError(t, domainErrors.ErrUserNotFound, func(c echo.Context) {
+		c.Response().Header().Set(echo.HeaderXRequestID, "req-123")
+		c.Request().Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	})
+
+	assert.Equal(t, "req-123", response.RequestID)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", response.TraceID)
+}
+
+func TestHTTPErrorHandler_IgnoresMalformedTraceparent(t *testing.T) {
+	_, response := handleTestError(t, domainErrors.ErrUserNotFound, func(c echo.Context) {
+		c.Request().Header.Set(echo.HeaderXRequestID, "client-req")
+		c.Request().Header.Set("traceparent", "00-not-a-trace-01")
+	})
+
+	assert.Equal(t, "client-req", response.RequestID)
+	assert.Empty(t, response.TraceID)
+}
+
+func TestHTTPErrorHandler_DeletionBlockedIncludesBlockers(t *testing.T) {
+	err := &domainErrors.DeletionBlockedError{Blockers: []domainErrors.DeletionBlocker{
+		{Checker: "billing", Reason: "2 open invoices"},
+	}}
+
+	rec, response := handleTestError(t, err, nil)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "DELETE_BLOCKED", response.Error)
+	require.Contains(t, response.Details, "blockers")
+	assert.Contains(t, rec.Body.String(), `"checker":"billing"`)
+}
+
+func TestHTTPErrorHandler_ActionRateLimitedUsesItsRetryAfter(t *testing.T) {
+	err := &domainErrors.ActionRateLimitedError{Action: "password_reset", Limit: 5, Window: time.Hour, Retry: 90 * time.Second}
+
+	rec, response := handleTestError(t, err, nil)
+
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "RATE_LIMITED", response.Error)
+	assert.True(t, response.Retryable)
+	assert.Equal(t, int64(90000), response.RetryAfterMS)
+	assert.Equal(t, "90", rec.Header().Get(echo.HeaderRetryAfter))
+	assert.Equal(t, "password_reset", response.Details["action"])
+}
+
+func TestHTTPErrorHandler_ContextDeadlineIsDeadlineExceeded(t *testing.T) {
+	rec, response := handleTestError(t, fmt.Errorf("query users: %w", context.DeadlineExceeded), nil)
+
+	assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+	assert.Equal(t, "DEADLINE_EXCEEDED", response.Error)
+	assert.False(t, response.Retryable)
+}
+
+func TestHTTPErrorHandler_FailureAfterDeadlineIsDeadlineExceeded(t *testing.T) {
+	expired := func(c echo.Context) {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		t.Cleanup(cancel)
+		c.SetRequest(c.Request().WithContext(ctx))
+	}
+
+	// A transient failure after the deadline was caused by it
+	rec, response := handleTestError(t, domainErrors.ErrFailedToListUsers, expired)
+	assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+	assert.Equal(t, "DEADLINE_EXCEEDED", response.Error)
+
+	// A definitive answer is still reported as is
+	rec, response = handleTestError(t, domainErrors.ErrUserNotFound, expired)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "USER_NOT_FOUND", response.Error)
+}
@@ -1,45 +1,54 @@
 package handlers
 
 import (
-	"context"
 	"net/http"
 	"runtime"
 	"time"
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
 	"user-service/internal/infrastructure"
 
 	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
 
 	"github.com/labstack/echo/v4"
 )
 
 type HealthHandler struct {
-	logger      logger.Logger
-	startTime   time.Time
-	connections *infrastructure.DatabaseConnections
+	logger    logger.Logger
+	startTime time.Time
+	health    *infrastructure.HealthRegistry
+	features  *infrastructure.FeatureMonitor
+	metrics   *metrics.Registry
 }
 
-func NewHealthHandler(logger logger.Logger, connections *infrastructure.DatabaseConnections) *HealthHandler {
+func NewHealthHandler(logger logger.Logger, health *infrastructure.HealthRegistry, features *infrastructure.FeatureMonitor, registry *metrics.Registry) *HealthHandler {
 	return &HealthHandler{
-		logger:      logger.With("component", "health_handler"),
-		startTime:   time.Now(),
-		connections: connections,
+		logger:    logger.With("component", "health_handler"),
+		startTime: time.Now(),
+		health:    health,
+		features:  features,
+		metrics:   registry,
 	}
 }
 
 type HealthResponse struct {
 	Status    string                 `json:"status"`
-	Timestamp time.Time              `json:"timestamp"`
+	Timestamp dto.Timestamp          `json:"timestamp"`
 	Service   string                 `json:"service"`
 	Version   string                 `json:"version"`
 	Uptime    string                 `json:"uptime"`
+	Score     *int                   `json:"score,omitempty"`
 	Checks    map[string]interface{} `json:"checks,omitempty"`
+	// DegradedFeatures lists the optional features currently unavailable
+	DegradedFeatures []ports.Degradation `json:"degraded_features,omitempty"`
 }
 
 type MetricsResponse struct {
-	Service   string    `json:"service"`
-	Version   string    `json:"version"`
-	Timestamp time.Time `json:"timestamp"`
-	Uptime    string    `json:"uptime"`
+	Service   string        `json:"service"`
+	Version   string        `json:"version"`
+	Timestamp dto.Timestamp `json:"timestamp"`
+	Uptime    string        `json:"uptime"`
 	Runtime   struct {
 		GoVersion   string `json:"go_version"`
 		Goroutines  int    `json:"goroutines"`
@@ -48,6 +57,8 @@ type MetricsResponse struct {
 		MemorySys   uint64 `json:"memory_sys"`
 		GCCount     uint32 `json:"gc_count"`
 	} `json:"runtime"`
+	Counters   map[string]map[string]uint64                 `json:"counters,omitempty"`
+	Histograms map[string]map[string]metrics.HistogramValue `json:"histograms,omitempty"`
 }
 
 // Health returns basic service health status
@@ -61,7 +72,7 @@ func (h *HealthHandler) Health(c echo.Context) error {
 
 	response := HealthResponse{
 		Status:    "healthy",
-		Timestamp: time.Now(),
+		Timestamp: dto.NewTimestamp(time.Now()),
 		Service:   "user-service",
 		Version:   "1.0.0",
 		Uptime:    time.Since(h.startTime).String(),
@@ -70,8 +81,10 @@ func (h *HealthHandler) Health(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
-// Ready checks if the service is ready to accept requests
-// This is where you'd add database connectivity checks, etc.
+// Ready checks if the service is ready to accept requests.
+// Degraded or unhealthy optional components lower the score but only an
+// unhealthy critical component makes the service not ready. Optional features
+// left without their components are listed, as requests using them degrade.
 func (h *HealthHandler) Ready(c echo.Context) error {
 	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
 
@@ -79,56 +92,48 @@ func (h *HealthHandler) Ready(c echo.Context) error {
 		"request_id", requestID,
 		"remote_ip", c.RealIP())
 
-	// Create context with timeout for health checks
-	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
-	defer cancel()
-
-	// Perform actual health checks
-	checks := h.connections.HealthCheck(ctx)
-
-	// Convert to response format and check if all are healthy
-	responseChecks := make(map[string]interface{})
-
-	allHealthy := true
-	for component, err := range checks {
-		if err != nil {
-			allHealthy = false
-			responseChecks[component] = map[string]interface{}{
-				"status":  "unhealthy",
-				"message": err.Error(),
-			}
-			h.logger.Warn("Component unhealthy during readiness check",
-				"component", component,
-				"error", err.Error(),
+	report := h.health.Check(c.Request().Context())
+
+	responseChecks := make(map[string]interface{}, len(report.Components))
+	for name, component := range report.Components {
+		responseChecks[name] = component
+		if component.Status != ports.HealthStatusHealthy {
+			h.logger.Warn("Component not healthy during readiness check",
+				"component", name,
+				"status", component.Status,
+				"reason", component.Reason,
+				"critical", component.Critical,
 				"request_id", requestID)
-		} else {
-			responseChecks[component] = map[string]interface{}{
-				"status":  "healthy",
-				"message": "Connection successful",
-			}
 		}
 	}
 
 	status := "ready"
 	httpStatus := http.StatusOK
-	if !allHealthy {
+	switch {
+	case !report.Ready:
 		status = "not_ready"
 		httpStatus = http.StatusServiceUnavailable
+	case report.Status == ports.HealthStatusDegraded:
+		status = "degraded"
 	}
 
 	response := HealthResponse{
 		Status:    status,
-		Timestamp: time.Now(),
+		Timestamp: dto.NewTimestamp(time.Now()),
 		Service:   "user-service",
 		Version:   "1.0.0",
 		Uptime:    time.Since(h.startTime).String(),
+		Score:     &report.Score,
 		Checks:    responseChecks,
 	}
+	if h.features != nil {
+		response.DegradedFeatures = h.features.Degradations()
+	}
 
 	h.logger.Info("Readiness check completed",
 		"status", status,
+		"score", report.Score,
 		"checks_count", len(responseChecks),
-		"healthy", allHealthy,
 		"request_id", requestID)
 
 	return c.JSON(httpStatus, response)
@@ -144,7 +149,7 @@ func (h *HealthHandler) Live(c echo.Context) error {
 
 	response := HealthResponse{
 		Status:    "alive",
-		Timestamp: time.Now(),
+		Timestamp: dto.NewTimestamp(time.Now()),
 		Service:   "user-service",
 		Version:   "1.0.0",
 		Uptime:    time.Since(h.startTime).String(),
@@ -167,7 +172,7 @@ func (h *HealthHandler) Metrics(c echo.Context) error {
 	response := MetricsResponse{
 		Service:   "user-service",
 		Version:   "1.0.0",
-		Timestamp: time.Now(),
+		Timestamp: dto.NewTimestamp(time.Now()),
 		Uptime:    time.Since(h.startTime).String(),
 	}
 
@@ -178,6 +183,11 @@ func (h *HealthHandler) Metrics(c echo.Context) error {
 	response.Runtime.MemorySys = m.Sys
 	response.Runtime.GCCount = m.NumGC
 
+	if h.metrics != nil {
+		response.Counters = h.metrics.Snapshot()
+		response.Histograms = h.metrics.HistogramSnapshot()
+	}
+
 	h.logger.Info("Metrics collected",
 		"goroutines", response.Runtime.Goroutines,
 		"memory_alloc_mb", response.Runtime.MemoryAlloc/1024/1024,
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"user-service/internal/application/ports"
+	"user-service/internal/infrastructure"
+	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubHealthChecker reports a fixed health result
+type stubHealthChecker struct {
+	status ports.HealthStatus
+	reason string
+}
+
+func (s stubHealthChecker) CheckHealth(ctx context.Context) ports.ComponentHealth {
+	return ports.ComponentHealth{Status: s.status, Reason: s.reason}
+}
+
+func performReadinessCheck(t *testing.T, components map[string]stubHealthChecker) (*httptest.ResponseRecorder, HealthResponse) {
+	registry := infrastructure.NewHealthRegistry([]string{"postgres"}, time.Second)
+	for name, checker := range components {
+		registry.Register(name, checker)
+	}
+
+	features := infrastructure.NewFeatureMonitor(map[string][]string{"cache": {"cache"}})
+	registry.Observe(features.Observe)
+
+	handler := NewHealthHandler(logger.New("test"), registry, features, metrics.NewRegistry())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/health/ready", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	err := handler.Ready(c)
+	require.NoError(t, err)
+
+	var response HealthResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	return rec, response
+}
+
+func TestHealthHandler_Ready_AllHealthy(t *testing.T) {
+	rec, response := performReadinessCheck(t, map[string]stubHealthChecker{
+		"postgres": {status: ports.HealthStatusHealthy},
+		"cache":    {status: ports.HealthStatusHealthy},
+	})
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "ready", response.Status)
+	require.NotNil(t, response.Score)
+	assert.Equal(t, 100, *response.Score)
+	assert.Empty(t, response.DegradedFeatures)
+}
+
+func TestHealthHandler_Ready_OptionalComponentDownIsDegraded(t *testing.T) {
+	rec, response := performReadinessCheck(t, map[string]stubHealthChecker{
+		"postgres": {status: ports.HealthStatusHealthy},
+		"cache":    {status: ports.HealthStatusUnhealthy, reason: "connection refused"},
+	})
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "degraded", response.Status)
+	require.NotNil(t, response.Score)
+	assert.Equal(t, 66, *response.Score)
+
+	cache := response.Checks["cache"].(map[string]interface{})
+	assert.Equal(t, "unhealthy", cache["status"])
+	assert.Equal(t, "connection refused", cache["reason"])
+	assert.Equal(t, false, cache["critical"])
+	assert.Equal(t, []ports.Degradation{{Feature: ports.FeatureCache, Reason: "cache is unhealthy: connection refused"}}, response.DegradedFeatures)
+}
+
+func TestHealthHandler_Ready_CriticalDegradedStaysReady(t *testing.T) {
+	rec, response := performReadinessCheck(t, map[string]stubHealthChecker{
+		"postgres": {status: ports.HealthStatusDegraded, reason: "replica down"},
+	})
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "degraded", response.Status)
+	assert.Equal(t, 50, *response.Score)
+}
+
+func TestHealthHandler_Ready_CriticalComponentDownIsNotReady(t *testing.T) {
+	rec, response := performReadinessCheck(t, map[string]stubHealthChecker{
+		"postgres": {status: ports.HealthStatusUnhealthy, reason: "ping failed"},
+		"cache":    {status: ports.HealthStatusHealthy},
+	})
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "not_ready", response.Status)
+	assert.Equal(t, 33, *response.Score)
+}
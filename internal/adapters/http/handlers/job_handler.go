@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+
+	"user-service/internal/adapters/http/middlewares/auth"
+	"user-service/internal/application/usecases"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type JobHandler struct {
+	jobUseCases usecases.JobUseCases
+	logger      logger.Logger
+}
+
+func NewJobHandler(jobUseCases usecases.JobUseCases, log logger.Logger) *JobHandler {
+	return &JobHandler{
+		jobUseCases: jobUseCases,
+		logger:      log.With("component", "job_handler"),
+	}
+}
+
+// ListJobs handles GET /api/v1/admin/jobs
+func (h *JobHandler) ListJobs(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	response, err := h.jobUseCases.ListJobs(c.Request().Context())
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to list jobs")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetJob handles GET /api/v1/admin/jobs/:name
+func (h *JobHandler) GetJob(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	response, err := h.jobUseCases.GetJob(c.Request().Context(), c.Param("name"))
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to get job")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// TriggerJob handles POST /api/v1/admin/jobs/:name/run. The job runs in the
+// background; poll GET /api/v1/admin/jobs/:name for its outcome.
+func (h *JobHandler) TriggerJob(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+	name := c.Param("name")
+	actor := auth.PrincipalFrom(c).Name
+
+	response, err := h.jobUseCases.TriggerJob(c.Request().Context(), name, actor)
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to trigger job")
+	}
+
+	h.logger.Info("Job triggered",
+		"request_id", requestID,
+		"job", name,
+		"actor", actor)
+
+	return c.JSON(http.StatusAccepted, response)
+}
+
+// PauseJob handles POST /api/v1/admin/jobs/:name/pause
+func (h *JobHandler) PauseJob(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+	name := c.Param("name")
+	actor := auth.PrincipalFrom(c).Name
+
+	response, err := h.jobUseCases.PauseJob(c.Request().Context(), name, actor)
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to pause job")
+	}
+
+	h.logger.Info("Job paused",
+		"request_id", requestID,
+		"job", name,
+		"actor", actor)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// ResumeJob handles POST /api/v1/admin/jobs/:name/resume
+func (h *JobHandler) ResumeJob(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+	name := c.Param("name")
+	actor := auth.PrincipalFrom(c).Name
+
+	response, err := h.jobUseCases.ResumeJob(c.Request().Context(), name, actor)
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to resume job")
+	}
+
+	h.logger.Info("Job resumed",
+		"request_id", requestID,
+		"job", name,
+		"actor", actor)
+
+	return c.JSON(http.StatusOK, response)
+}
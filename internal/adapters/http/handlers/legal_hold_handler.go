@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"user-service/internal/adapters/http/middlewares/auth"
+	"user-service/internal/application/dto"
+	"user-service/internal/application/usecases"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type LegalHoldHandler struct {
+	legalHoldUseCases usecases.LegalHoldUseCases
+	logger            logger.Logger
+}
+
+func NewLegalHoldHandler(legalHoldUseCases usecases.LegalHoldUseCases, log logger.Logger) *LegalHoldHandler {
+	return &LegalHoldHandler{
+		legalHoldUseCases: legalHoldUseCases,
+		logger:            log.With("component", "legal_hold_handler"),
+	}
+}
+
+// PlaceLegalHold handles PUT /api/v1/admin/users/:id/legal-hold
+func (h *LegalHoldHandler) PlaceLegalHold(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	userID, err := parseUserID(c)
+	if err != nil {
+		return renderError(c, domainErrors.ErrInvalidID)
+	}
+
+	var request dto.PlaceLegalHoldRequestDTO
+	if err := bindRequest(c, &request); err != nil {
+		h.logger.Warn("Invalid request body",
+			"request_id", requestID,
+			"error", err)
+		return renderError(c, err)
+	}
+
+	actor := auth.PrincipalFrom(c).Name
+
+	response, err := h.legalHoldUseCases.PlaceLegalHold(c.Request().Context(), userID, actor, &request)
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to place legal hold")
+	}
+
+	h.logger.Info("Legal hold placed",
+		"request_id", requestID,
+		"user_id", userID,
+		"actor", actor)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// ReleaseLegalHold handles DELETE /api/v1/admin/users/:id/legal-hold
+func (h *LegalHoldHandler) ReleaseLegalHold(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	userID, err := parseUserID(c)
+	if err != nil {
+		return renderError(c, domainErrors.ErrInvalidID)
+	}
+
+	actor := auth.PrincipalFrom(c).Name
+
+	if err := h.legalHoldUseCases.ReleaseLegalHold(c.Request().Context(), userID, actor); err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to release legal hold")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListLegalHolds handles GET /api/v1/admin/legal-holds
+func (h *LegalHoldHandler) ListLegalHolds(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	page := 1
+	pageSize := 20
+
+	if pageParam := c.QueryParam("page"); pageParam != "" {
+		if p, err := strconv.Atoi(pageParam); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	if sizeParam := c.QueryParam("page_size"); sizeParam != "" {
+		if ps, err := strconv.Atoi(sizeParam); err == nil && ps > 0 {
+			pageSize = ps
+		}
+	}
+
+	response, err := h.legalHoldUseCases.ListLegalHolds(c.Request().Context(), page, pageSize)
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to list legal holds")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,405 @@
+package handlers
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"user-service/internal/adapters/http/middlewares/auth"
+	"user-service/internal/application/dto"
+	"user-service/internal/application/usecases"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+// OAuthErrorResponse is the error body of the OAuth 2.0 protocol endpoints,
+// whose shape is set by RFC 6749 rather than ErrorResponse
+type OAuthErrorResponse struct {
+	Error       string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+// oauthErrorSpec describes how a domain error is exposed by the protocol endpoints
+type oauthErrorSpec struct {
+	Code   string
+	Status int
+}
+
+// oauthErrorSpecs maps domain error codes to OAuth 2.0 error codes. Other
+// errors are reported as server_error.
+var oauthErrorSpecs = map[string]oauthErrorSpec{
+	domainErrors.ErrInvalidClientCredentials.Code: {Code: "invalid_client", Status: http.StatusUnauthorized},
+	domainErrors.ErrUnsupportedGrantType.Code:     {Code: "unsupported_grant_type", Status: http.StatusBadRequest},
+	domainErrors.ErrInvalidAuthorizationCode.Code: {Code: "invalid_grant", Status: http.StatusBadRequest},
+	domainErrors.ErrUnsupportedResponseType.Code:  {Code: "unsupported_response_type", Status: http.StatusBadRequest},
+	domainErrors.ErrPKCERequired.Code:             {Code: "invalid_request", Status: http.StatusBadRequest},
+	domainErrors.ErrInvalidScope.Code:             {Code: "invalid_scope", Status: http.StatusBadRequest},
+	domainErrors.ErrInvalidAccessToken.Code:       {Code: "invalid_token", Status: http.StatusUnauthorized},
+}
+
+// loginTemplate is the sign-in page shown by the authorization endpoint. It
+// carries the authorization request along in hidden fields and asks the user
+// which of the requested scopes to share.
+var loginTemplate = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="utf-8"><title>Sign in to {{.ClientName}}</title></head>
+<body>
+<h1>Sign in to {{.ClientName}}</h1>
+{{if .Error}}<p role="alert">{{.Error}}</p>{{end}}
+<form method="post" action="{{.Action}}">
+{{range $name, $value := .Params}}<input type="hidden" name="{{$name}}" value="{{$value}}">
+{{end}}{{if .Scopes}}<fieldset>
+<legend>{{.ClientName}} would like to see</legend>
+{{range .Scopes}}<label><input type="checkbox" name="consent" value="{{.Name}}"{{if .Checked}} checked{{end}}> {{.Description}}</label>
+{{end}}</fieldset>
+{{end}}<label>Email <input type="email" name="email" value="{{.Email}}" autocomplete="username" required></label>
+<label>Password <input type="password" name="password" autocomplete="current-password" required></label>
+<button type="submit">Sign in</button>
+</form>
+</body>
+</html>
+`))
+
+// scopeDescriptions tell users what sharing each scope means
+var scopeDescriptions = map[string]string{
+	entities.ScopeProfile: "Your name",
+	entities.ScopeEmail:   "Your email address",
+	entities.ScopePhone:   "Your phone number",
+}
+
+// consentOption is a scope offered on the sign-in page
+type consentOption struct {
+	Name        string
+	Description string
+	Checked     bool
+}
+
+type OIDCHandler struct {
+	provider usecases.OIDCProvider
+	logger   logger.Logger
+}
+
+func NewOIDCHandler(provider usecases.OIDCProvider, log logger.Logger) *OIDCHandler {
+	return &OIDCHandler{
+		provider: provider,
+		logger:   log.With("component", "oidc_handler"),
+	}
+}
+
+// Discovery handles GET /.well-known/openid-configuration
+func (h *OIDCHandler) Discovery(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.provider.Discovery())
+}
+
+// KeySet handles GET /oauth/jwks
+func (h *OIDCHandler) KeySet(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.provider.KeySet())
+}
+
+// AuthorizeForm handles GET /oauth/authorize by showing the sign-in page
+func (h *OIDCHandler) AuthorizeForm(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := authorizeRequestFrom(c)
+
+	client, err := h.provider.ValidateAuthorization(c.Request().Context(), request)
+	if err != nil {
+		return h.authorizationError(c, request, err, requestID)
+	}
+
+	// Everything requested is offered checked; the user opts out
+	scopes, _ := client.GrantScopes(request.Scope)
+	return renderLogin(c, http.StatusOK, client, request, scopes, "", "")
+}
+
+// Authorize handles POST /oauth/authorize, signing the user in and sending
+// them back to the client with an authorization code
+func (h *OIDCHandler) Authorize(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := authorizeRequestFrom(c)
+	email := c.FormValue("email")
+	form, _ := c.FormParams()
+	consented := form["consent"]
+
+	code, err := h.provider.Authorize(c.Request().Context(), request, email, c.FormValue("password"), consented)
+	if errors.Is(err, domainErrors.ErrInvalidCredentials) {
+		client, validateErr := h.provider.ValidateAuthorization(c.Request().Context(), request)
+		if validateErr != nil {
+			return h.authorizationError(c, request, validateErr, requestID)
+		}
+		return renderLogin(c, http.StatusUnauthorized, client, request, consented, email, domainErrors.ErrInvalidCredentials.Message)
+	}
+	var limited *domainErrors.ActionRateLimitedError
+	if errors.As(err, &limited) {
+		client, validateErr := h.provider.ValidateAuthorization(c.Request().Context(), request)
+		if validateErr != nil {
+			return h.authorizationError(c, request, validateErr, requestID)
+		}
+		c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(int((limited.Retry+time.Second-1)/time.Second)))
+		return renderLogin(c, http.StatusTooManyRequests, client, request, consented, email, domainErrors.ErrActionRateLimited.Message)
+	}
+	if err != nil {
+		return h.authorizationError(c, request, err, requestID)
+	}
+
+	h.logger.Info("Authorization code issued",
+		"request_id", requestID,
+		"client_id", request.ClientID)
+
+	return c.Redirect(http.StatusSeeOther, redirectWith(request.RedirectURI, url.Values{"code": {code}, "state": {request.State}}))
+}
+
+// Token handles POST /oauth/token
+func (h *OIDCHandler) Token(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	request := &dto.TokenRequestDTO{
+		GrantType:    c.FormValue("grant_type"),
+		Code:         c.FormValue("code"),
+		RedirectURI:  c.FormValue("redirect_uri"),
+		ClientID:     c.FormValue("client_id"),
+		ClientSecret: c.FormValue("client_secret"),
+		CodeVerifier: c.FormValue("code_verifier"),
+	}
+	// client_secret_basic takes precedence over credentials in the body
+	if clientID, secret, ok := c.Request().BasicAuth(); ok {
+		request.ClientID, request.ClientSecret = unescapeBasic(clientID), unescapeBasic(secret)
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	response, err := h.provider.Exchange(c.Request().Context(), request)
+	if err != nil {
+		h.logger.Warn("Token request rejected",
+			"request_id", requestID,
+			"client_id", request.ClientID,
+			"error", err)
+		return writeOAuthError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// UserInfo handles GET and POST /oauth/userinfo
+func (h *OIDCHandler) UserInfo(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	token, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+	if !ok || token == "" {
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+		return writeOAuthError(c, domainErrors.ErrInvalidAccessToken)
+	}
+
+	claims, err := h.provider.UserInfo(c.Request().Context(), token)
+	if err != nil {
+		h.logger.Warn("UserInfo request rejected",
+			"request_id", requestID,
+			"error", err)
+		if errors.Is(err, domainErrors.ErrInvalidAccessToken) {
+			c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer error="invalid_token"`)
+		}
+		return writeOAuthError(c, err)
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return c.JSON(http.StatusOK, claims)
+}
+
+// RegisterClient handles POST /api/v1/admin/oidc/clients
+func (h *OIDCHandler) RegisterClient(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	var request dto.RegisterOIDCClientRequestDTO
+	if err := bindRequest(c, &request); err != nil {
+		h.logger.Warn("Invalid request body",
+			"request_id", requestID,
+			"error", err)
+		return renderError(c, err)
+	}
+
+	actor := auth.PrincipalFrom(c).Name
+
+	response, err := h.provider.RegisterClient(c.Request().Context(), &request, actor)
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to register OIDC client")
+	}
+
+	h.logger.Info("OIDC client registered",
+		"request_id", requestID,
+		"client_id", response.ClientID,
+		"actor", actor)
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return c.JSON(http.StatusCreated, response)
+}
+
+// ListClients handles GET /api/v1/admin/oidc/clients
+func (h *OIDCHandler) ListClients(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	clients, err := h.provider.ListClients(c.Request().Context())
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to list OIDC clients")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"clients": clients})
+}
+
+// UpdateClaimMappings handles PUT /api/v1/admin/oidc/clients/:client_id/claim-mappings
+func (h *OIDCHandler) UpdateClaimMappings(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+	clientID := c.Param("client_id")
+
+	var request dto.UpdateClaimMappingsRequestDTO
+	if err := bindRequest(c, &request); err != nil {
+		h.logger.Warn("Invalid request body",
+			"request_id", requestID,
+			"error", err)
+		return renderError(c, err)
+	}
+
+	actor := auth.PrincipalFrom(c).Name
+
+	response, err := h.provider.UpdateClaimMappings(c.Request().Context(), clientID, &request, actor)
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to update OIDC claim mappings")
+	}
+
+	h.logger.Info("OIDC claim mappings updated",
+		"request_id", requestID,
+		"client_id", clientID,
+		"actor", actor)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// authorizationError reports a failed authorization request. Problems with the
+// client or redirect URI are shown to the user, as redirecting would send them
+// to an unverified location; anything else is sent back to the client.
+func (h *OIDCHandler) authorizationError(c echo.Context, request *dto.AuthorizeRequestDTO, err error, requestID string) error {
+	h.logger.Warn("Authorization request rejected",
+		"request_id", requestID,
+		"client_id", request.ClientID,
+		"error", err)
+
+	if errors.Is(err, domainErrors.ErrOIDCClientNotFound) || errors.Is(err, domainErrors.ErrInvalidRedirectURI) ||
+		errors.Is(err, domainErrors.ErrFailedToLoadOIDCClients) {
+		return renderError(c, err)
+	}
+
+	spec := oauthErrorSpecFor(err)
+	params := url.Values{"error": {spec.Code}, "state": {request.State}}
+	var domainErr *domainErrors.DomainError
+	if errors.As(err, &domainErr) {
+		params.Set("error_description", domainErr.Message)
+	}
+	return c.Redirect(http.StatusSeeOther, redirectWith(request.RedirectURI, params))
+}
+
+// writeOAuthError writes the RFC 6749 error body for err
+func writeOAuthError(c echo.Context, err error) error {
+	spec := oauthErrorSpecFor(err)
+	response := OAuthErrorResponse{Error: spec.Code}
+
+	var domainErr *domainErrors.DomainError
+	if errors.As(err, &domainErr) {
+		response.Description = domainErr.Message
+	}
+	return c.JSON(spec.Status, response)
+}
+
+func oauthErrorSpecFor(err error) oauthErrorSpec {
+	var domainErr *domainErrors.DomainError
+	if errors.As(err, &domainErr) {
+		if spec, ok := oauthErrorSpecs[domainErr.Code]; ok {
+			return spec
+		}
+		if domainErrorSpecs[domainErr.Code].Retryable {
+			return oauthErrorSpec{Code: "temporarily_unavailable", Status: http.StatusServiceUnavailable}
+		}
+	}
+	return oauthErrorSpec{Code: "server_error", Status: http.StatusInternalServerError}
+}
+
+func authorizeRequestFrom(c echo.Context) *dto.AuthorizeRequestDTO {
+	return &dto.AuthorizeRequestDTO{
+		ResponseType:        c.FormValue("response_type"),
+		ClientID:            c.FormValue("client_id"),
+		RedirectURI:         c.FormValue("redirect_uri"),
+		Scope:               c.FormValue("scope"),
+		State:               c.FormValue("state"),
+		Nonce:               c.FormValue("nonce"),
+		CodeChallenge:       c.FormValue("code_challenge"),
+		CodeChallengeMethod: c.FormValue("code_challenge_method"),
+		UserAgent:           c.Request().UserAgent(),
+	}
+}
+
+// renderLogin shows the sign-in page, with the scopes in checked ticked
+func renderLogin(c echo.Context, status int, client *entities.OIDCClient, request *dto.AuthorizeRequestDTO, checked []string, email, message string) error {
+	requested, _ := client.GrantScopes(request.Scope)
+	var scopes []consentOption
+	for _, scope := range requested {
+		if scope == entities.ScopeOpenID {
+			continue
+		}
+		scopes = append(scopes, consentOption{Name: scope, Description: scopeDescriptions[scope], Checked: slices.Contains(checked, scope)})
+	}
+
+	var page strings.Builder
+	err := loginTemplate.Execute(&page, map[string]interface{}{
+		"ClientName": client.Name,
+		"Scopes":     scopes,
+		"Action":     usecases.OIDCAuthorizePath,
+		"Email":      email,
+		"Error":      message,
+		"Params": map[string]string{
+			"response_type":         request.ResponseType,
+			"client_id":             request.ClientID,
+			"redirect_uri":          request.RedirectURI,
+			"scope":                 request.Scope,
+			"state":                 request.State,
+			"nonce":                 request.Nonce,
+			"code_challenge":        request.CodeChallenge,
+			"code_challenge_method": request.CodeChallengeMethod,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return c.HTML(status, page.String())
+}
+
+// redirectWith adds the non-empty params to a redirect URI, keeping its query
+func redirectWith(redirectURI string, params url.Values) string {
+	target, err := url.Parse(redirectURI)
+	if err != nil {
+		return redirectURI
+	}
+
+	query := target.Query()
+	for name, values := range params {
+		if len(values) > 0 && values[0] != "" {
+			query.Set(name, values[0])
+		}
+	}
+	target.RawQuery = query.Encode()
+	return target.String()
+}
+
+// unescapeBasic decodes client credentials, which RFC 6749 form-encodes
+// before they are put in the Authorization header
+func unescapeBasic(value string) string {
+	if unescaped, err := url.QueryUnescape(value); err == nil {
+		return unescaped
+	}
+	return value
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	"user-service/internal/adapters/http/middlewares/auth"
+	"user-service/internal/application/dto"
+	"user-service/internal/application/usecases"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PhoneLookupHandler struct {
+	phoneLookupUseCases usecases.PhoneLookupUseCases
+	logger              logger.Logger
+}
+
+func NewPhoneLookupHandler(phoneLookupUseCases usecases.PhoneLookupUseCases, log logger.Logger) *PhoneLookupHandler {
+	return &PhoneLookupHandler{
+		phoneLookupUseCases: phoneLookupUseCases,
+		logger:              log.With("component", "phone_lookup_handler"),
+	}
+}
+
+// LookUpPhone handles POST /api/v1/internal/users/phone-lookup
+func (h *PhoneLookupHandler) LookUpPhone(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	var request dto.PhoneLookupRequestDTO
+	if err := bindRequest(c, &request); err != nil {
+		h.logger.Warn("Invalid request body",
+			"request_id", requestID,
+			"error", err)
+		return renderError(c, err)
+	}
+
+	actor := auth.PrincipalFrom(c).Name
+
+	response, err := h.phoneLookupUseCases.LookUpPhone(c.Request().Context(), actor, &request)
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to look up phone number")
+	}
+
+	h.logger.Info("Phone number looked up",
+		"request_id", requestID,
+		"actor", actor,
+		"matches", response.Count)
+
+	return c.JSON(http.StatusOK, response.VisibleTo(viewerOf(c)))
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"user-service/internal/adapters/http/middlewares/auth"
+	"user-service/internal/application/dto"
+	"user-service/internal/application/usecases"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ReferralHandler struct {
+	referralUseCases usecases.ReferralUseCases
+	logger           logger.Logger
+}
+
+func NewReferralHandler(referralUseCases usecases.ReferralUseCases, log logger.Logger) *ReferralHandler {
+	return &ReferralHandler{
+		referralUseCases: referralUseCases,
+		logger:           log.With("component", "referral_handler"),
+	}
+}
+
+// ListReferrals handles GET /api/v1/users/:id/referrals
+func (h *ReferralHandler) ListReferrals(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	userID, err := parseUserID(c)
+	if err != nil {
+		return renderError(c, domainErrors.ErrInvalidID)
+	}
+
+	page := 1
+	pageSize := 20
+
+	if pageParam := c.QueryParam("page"); pageParam != "" {
+		if p, err := strconv.Atoi(pageParam); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	if sizeParam := c.QueryParam("page_size"); sizeParam != "" {
+		if ps, err := strconv.Atoi(sizeParam); err == nil && ps > 0 {
+			pageSize = ps
+		}
+	}
+
+	response, err := h.referralUseCases.ListReferrals(c.Request().Context(), userID, page, pageSize)
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to list referrals")
+	}
+
+	return c.JSON(http.StatusOK, response.VisibleTo(viewerOf(c)))
+}
+
+// AttributeReferral handles PUT /api/v1/admin/users/:id/referrer
+func (h *ReferralHandler) AttributeReferral(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	userID, err := parseUserID(c)
+	if err != nil {
+		return renderError(c, domainErrors.ErrInvalidID)
+	}
+
+	var request dto.AttributeReferralRequestDTO
+	if err := bindRequest(c, &request); err != nil {
+		h.logger.Warn("Invalid request body",
+			"request_id", requestID,
+			"error", err)
+		return renderError(c, err)
+	}
+
+	actor := auth.PrincipalFrom(c).Name
+
+	response, err := h.referralUseCases.AttributeReferral(c.Request().Context(), userID, actor, &request)
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to attribute referral")
+	}
+
+	h.logger.Info("Referral attributed",
+		"request_id", requestID,
+		"user_id", userID,
+		"actor", actor)
+
+	return c.JSON(http.StatusOK, response.VisibleTo(viewerOf(c)))
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+
+	"user-service/internal/adapters/http/middlewares/auth"
+	"user-service/internal/application/dto"
+	"user-service/internal/application/usecases"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SuppressionHandler struct {
+	suppressionUseCases usecases.SuppressionUseCases
+	logger              logger.Logger
+}
+
+func NewSuppressionHandler(suppressionUseCases usecases.SuppressionUseCases, log logger.Logger) *SuppressionHandler {
+	return &SuppressionHandler{
+		suppressionUseCases: suppressionUseCases,
+		logger:              log.With("component", "suppression_handler"),
+	}
+}
+
+// CheckSuppression handles GET /api/v1/internal/suppressions/:email
+func (h *SuppressionHandler) CheckSuppression(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	response, err := h.suppressionUseCases.CheckSuppression(c.Request().Context(), c.Param("email"))
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to check suppression")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// Suppress handles PUT /api/v1/internal/suppressions/:email
+func (h *SuppressionHandler) Suppress(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	var request dto.SuppressRequestDTO
+	if err := bindRequest(c, &request); err != nil {
+		h.logger.Warn("Invalid request body",
+			"request_id", requestID,
+			"error", err)
+		return renderError(c, err)
+	}
+
+	source := auth.PrincipalFrom(c).Name
+
+	response, err := h.suppressionUseCases.Suppress(c.Request().Context(), c.Param("email"), source, &request)
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to suppress email")
+	}
+
+	h.logger.Info("Email suppressed",
+		"request_id", requestID,
+		"email", logger.MaskEmail(response.Email),
+		"reason", response.Reason,
+		"source", source)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// Unsuppress handles DELETE /api/v1/internal/suppressions/:email
+func (h *SuppressionHandler) Unsuppress(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	actor := auth.PrincipalFrom(c).Name
+
+	if err := h.suppressionUseCases.Unsuppress(c.Request().Context(), c.Param("email"), actor); err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to lift suppression")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"user-service/internal/application/usecases"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type UsageHandler struct {
+	usageUseCases usecases.UsageUseCases
+	logger        logger.Logger
+}
+
+func NewUsageHandler(usageUseCases usecases.UsageUseCases, log logger.Logger) *UsageHandler {
+	return &UsageHandler{
+		usageUseCases: usageUseCases,
+		logger:        log.With("component", "usage_handler"),
+	}
+}
+
+// GetUsage handles GET /api/v1/admin/usage. The optional tenant, from and to
+// query parameters narrow the report; from and to are RFC 3339 timestamps
+// widened to whole hours and default to the last 24 hours.
+func (h *UsageHandler) GetUsage(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	var from, to time.Time
+	for param, value := range map[string]*time.Time{"from": &from, "to": &to} {
+		raw := c.QueryParam(param)
+		if raw == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return renderError(c, domainErrors.ErrInvalidTime)
+		}
+		*value = parsed
+	}
+
+	response, err := h.usageUseCases.GetUsage(c.Request().Context(), c.QueryParam("tenant"), from, to)
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to get usage")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
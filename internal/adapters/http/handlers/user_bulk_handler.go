@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"user-service/internal/application/dto"
+	"user-service/internal/application/usecases"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+type UserBulkHandler struct {
+	bulkUseCases usecases.BulkUserUseCases
+	// maxItems is the most users accepted in a request, valid or not; 0
+	// leaves the limit to the use cases
+	maxItems int
+	logger   logger.Logger
+}
+
+func NewUserBulkHandler(bulkUseCases usecases.BulkUserUseCases, maxItems int, log logger.Logger) *UserBulkHandler {
+	return &UserBulkHandler{
+		bulkUseCases: bulkUseCases,
+		maxItems:     maxItems,
+		logger:       log.With("component", "user_bulk_handler"),
+	}
+}
+
+// BulkCreateUsers handles POST /api/v1/users/bulk. Items failing validation are
+// reported individually instead of rejecting the whole request; the response is
+// 201 when every user was created and 207 otherwise.
+func (h *UserBulkHandler) BulkCreateUsers(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	var request dto.BulkCreateUsersRequestDTO
+	if err := bindRequest(c, &request); err != nil {
+		h.logger.Warn("Invalid request body",
+			"request_id", requestID,
+			"error", err)
+		return renderError(c, err)
+	}
+
+	// Checked before validating items, so invalid ones cannot pad a request
+	// past the limit
+	if h.maxItems > 0 && len(request.Users) > h.maxItems {
+		return respondWithError(c, h.logger, domainErrors.ErrTooManyBulkItems, requestID, "Too many bulk items")
+	}
+
+	results := make([]dto.BulkItemResultDTO, len(request.Users))
+	valid := make([]dto.CreateUserRequestDTO, 0, len(request.Users))
+	indexes := make([]int, 0, len(request.Users))
+
+	for i, item := range request.Users {
+		if err := c.Validate(item); err != nil {
+			results[i] = dto.BulkItemFailure(i, domainErrors.ErrValidationFailed.Code, itemValidationMessage(err))
+			continue
+		}
+		valid = append(valid, item)
+		indexes = append(indexes, i)
+	}
+
+	response, err := h.bulkUseCases.BulkCreateUsers(c.Request().Context(), valid)
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to bulk create users")
+	}
+
+	// Map results for the validated subset back to their request positions
+	for j, result := range response.Results {
+		result.Index = indexes[j]
+		results[indexes[j]] = result
+	}
+	response.Results = results
+	response.Failed = len(results) - response.Created
+
+	h.logger.Info("Bulk create completed",
+		"request_id", requestID,
+		"created", response.Created,
+		"failed", response.Failed)
+
+	status := http.StatusCreated
+	if response.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+
+	return c.JSON(status, response.VisibleTo(viewerOf(c)))
+}
+
+// itemValidationMessage summarizes validation failures of a single bulk item
+func itemValidationMessage(err error) string {
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return "Request validation failed"
+	}
+
+	messages := make([]string, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		messages = append(messages, fieldError.Field()+": "+getValidationErrorMessage(fieldError))
+	}
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"user-service/internal/application/dto"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockBulkUserUseCases implements the BulkUserUseCases interface for testing
+type MockBulkUserUseCases struct {
+	mock.Mock
+}
+
+func (m *MockBulkUserUseCases) BulkCreateUsers(ctx context.Context, requests []dto.CreateUserRequestDTO) (*dto.BulkCreateUsersResponseDTO, error) {
+	args := m.Called(ctx, requests)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.BulkCreateUsersResponseDTO), args.Error(1)
+}
+
+func performBulkCreate(t *testing.T, handler *UserBulkHandler, body interface{}) *httptest.ResponseRecorder {
+	payload, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/bulk", bytes.NewReader(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := newTestEcho().NewContext(req, rec)
+
+	require.NoError(t, handler.BulkCreateUsers(c))
+	return rec
+}
+
+func TestUserBulkHandler_BulkCreateUsers_MergesValidationFailures(t *testing.T) {
+	// Given
+	mockUseCases := new(MockBulkUserUseCases)
+	handler := NewUserBulkHandler(mockUseCases, 10, logger.New("test"))
+
+	valid := dto.CreateUserRequestDTO{Email: "john@example.com", Password: "SecurePass123", FirstName: "John", LastName: "Doe"}
+	invalid := dto.CreateUserRequestDTO{Email: "jane@example.com", Password: "short", FirstName: "Jane", LastName: "Doe"}
+
+	mockUseCases.On("BulkCreateUsers", mock.Anything, []dto.CreateUserRequestDTO{valid}).Return(&dto.BulkCreateUsersResponseDTO{
+		Results: []dto.BulkItemResultDTO{{Index: 0, Status: dto.BulkItemCreated, User: &dto.UserResponseDTO{ID: 1, Email: valid.Email}}},
+		Created: 1,
+	}, nil)
+
+	// When
+	rec := performBulkCreate(t, handler, dto.BulkCreateUsersRequestDTO{Users: []dto.CreateUserRequestDTO{invalid, valid}})
+
+	// Then
+	assert.Equal(t, http.StatusMultiStatus, rec.Code)
+
+	var response dto.BulkCreateUsersResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Len(t, response.Results, 2)
+	assert.Equal(t, 1, response.Created)
+	assert.Equal(t, 1, response.Failed)
+	assert.Equal(t, 0, response.Results[0].Index)
+	assert.Equal(t, "VALIDATION_ERROR", response.Results[0].Error)
+	assert.Contains(t, response.Results[0].Message, "password")
+	assert.Equal(t, 1, response.Results[1].Index)
+	assert.Equal(t, dto.BulkItemCreated, response.Results[1].Status)
+}
+
+func TestUserBulkHandler_BulkCreateUsers_CapacityExceeded(t *testing.T) {
+	// Given
+	mockUseCases := new(MockBulkUserUseCases)
+	handler := NewUserBulkHandler(mockUseCases, 10, logger.New("test"))
+	mockUseCases.On("BulkCreateUsers", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrBulkCapacityExceeded)
+
+	// When
+	rec := performBulkCreate(t, handler, dto.BulkCreateUsersRequestDTO{Users: []dto.CreateUserRequestDTO{
+		{Email: "john@example.com", Password: "SecurePass123", FirstName: "John", LastName: "Doe"},
+	}})
+
+	// Then
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "5", rec.Header().Get(echo.HeaderRetryAfter))
+}
+
+func TestUserBulkHandler_BulkCreateUsers_EmptyRequest(t *testing.T) {
+	// Given
+	mockUseCases := new(MockBulkUserUseCases)
+	handler := NewUserBulkHandler(mockUseCases, 10, logger.New("test"))
+
+	// When
+	rec := performBulkCreate(t, handler, dto.BulkCreateUsersRequestDTO{})
+
+	// Then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUseCases.AssertNotCalled(t, "BulkCreateUsers", mock.Anything, mock.Anything)
+}
+
+func TestUserBulkHandler_BulkCreateUsers_TooManyItems(t *testing.T) {
+	// Given a request over the limit made mostly of invalid items
+	mockUseCases := new(MockBulkUserUseCases)
+	handler := NewUserBulkHandler(mockUseCases, 2, logger.New("test"))
+	users := []dto.CreateUserRequestDTO{{Email: "john@example.com", Password: "SecurePass123", FirstName: "John", LastName: "Doe"}}
+	for range 2 {
+		users = append(users, dto.CreateUserRequestDTO{Email: "not-an-email"})
+	}
+
+	// When
+	rec := performBulkCreate(t, handler, dto.BulkCreateUsersRequestDTO{Users: users})
+
+	// Then it is rejected whole
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Contains(t, rec.Body.String(), domainErrors.ErrTooManyBulkItems.Code)
+	mockUseCases.AssertNotCalled(t, "BulkCreateUsers", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+
+	"user-service/internal/adapters/http/middlewares/auth"
+	"user-service/internal/application/usecases"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type UserDeletionHandler struct {
+	deletionUseCases usecases.UserDeletionUseCases
+	logger           logger.Logger
+}
+
+func NewUserDeletionHandler(deletionUseCases usecases.UserDeletionUseCases, log logger.Logger) *UserDeletionHandler {
+	return &UserDeletionHandler{
+		deletionUseCases: deletionUseCases,
+		logger:           log.With("component", "user_deletion_handler"),
+	}
+}
+
+// DeleteUser handles DELETE /api/v1/admin/users/:id
+func (h *UserDeletionHandler) DeleteUser(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	userID, err := parseUserID(c)
+	if err != nil {
+		return renderError(c, domainErrors.ErrInvalidID)
+	}
+
+	actor := auth.PrincipalFrom(c).Name
+
+	if err := h.deletionUseCases.DeleteUser(c.Request().Context(), userID, actor); err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to delete user")
+	}
+
+	h.logger.Info("User deleted",
+		"request_id", requestID,
+		"user_id", userID,
+		"actor", actor)
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"user-service/internal/application/usecases"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type UserExistenceHandler struct {
+	existenceUseCases usecases.UserExistenceUseCases
+	cacheControl      string
+	logger            logger.Logger
+}
+
+// NewUserExistenceHandler creates the handler for HEAD existence checks; clients
+// may cache answers for maxAge
+func NewUserExistenceHandler(existenceUseCases usecases.UserExistenceUseCases, maxAge time.Duration, log logger.Logger) *UserExistenceHandler {
+	cacheControl := "no-cache"
+	if seconds := int(maxAge / time.Second); seconds > 0 {
+		cacheControl = fmt.Sprintf("private, max-age=%d", seconds)
+	}
+
+	return &UserExistenceHandler{
+		existenceUseCases: existenceUseCases,
+		cacheControl:      cacheControl,
+		logger:            log.With("component", "user_existence_handler"),
+	}
+}
+
+// UserExists handles HEAD /api/v1/users/:id
+func (h *UserExistenceHandler) UserExists(c echo.Context) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	exists, err := h.existenceUseCases.UserExists(c.Request().Context(), userID)
+	if err != nil {
+		requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+		return respondWithError(c, h.logger, err, requestID, "Failed to check user existence")
+	}
+
+	return h.respond(c, exists)
+}
+
+// EmailExists handles HEAD /api/v1/users/email/:email
+func (h *UserExistenceHandler) EmailExists(c echo.Context) error {
+	email := c.Param("email")
+	if email == "" {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	exists, err := h.existenceUseCases.EmailExists(c.Request().Context(), email)
+	if err != nil {
+		requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+		return respondWithError(c, h.logger, err, requestID, "Failed to check email existence")
+	}
+
+	return h.respond(c, exists)
+}
+
+func (h *UserExistenceHandler) respond(c echo.Context, exists bool) error {
+	c.Response().Header().Set(echo.HeaderCacheControl, h.cacheControl)
+	if !exists {
+		return c.NoContent(http.StatusNotFound)
+	}
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockUserExistenceUseCases implements the UserExistenceUseCases interface for testing
+type MockUserExistenceUseCases struct {
+	mock.Mock
+}
+
+func (m *MockUserExistenceUseCases) UserExists(ctx context.Context, id uint) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserExistenceUseCases) EmailExists(ctx context.Context, email string) (bool, error) {
+	args := m.Called(ctx, email)
+	return args.Bool(0), args.Error(1)
+}
+
+func performHead(t *testing.T, handlerFunc echo.HandlerFunc, paramName, paramValue string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodHead, "/", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames(paramName)
+	c.SetParamValues(paramValue)
+
+	require.NoError(t, handlerFunc(c))
+	return rec
+}
+
+func TestUserExistenceHandler_UserExists(t *testing.T) {
+	// Given
+	mockUseCases := new(MockUserExistenceUseCases)
+	handler := NewUserExistenceHandler(mockUseCases, 30*time.Second, logger.New("test"))
+	mockUseCases.On("UserExists", mock.Anything, uint(1)).Return(true, nil)
+	mockUseCases.On("UserExists", mock.Anything, uint(2)).Return(false, nil)
+
+	// When
+	found := performHead(t, handler.UserExists, "id", "1")
+	missing := performHead(t, handler.UserExists, "id", "2")
+	invalid := performHead(t, handler.UserExists, "id", "abc")
+
+	// Then
+	assert.Equal(t, http.StatusOK, found.Code)
+	assert.Empty(t, found.Body.String())
+	assert.Equal(t, "private, max-age=30", found.Header().Get(echo.HeaderCacheControl))
+	assert.Equal(t, http.StatusNotFound, missing.Code)
+	assert.Empty(t, missing.Body.String())
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+}
+
+func TestUserExistenceHandler_EmailExists(t *testing.T) {
+	// Given
+	mockUseCases := new(MockUserExistenceUseCases)
+	handler := NewUserExistenceHandler(mockUseCases, 0, logger.New("test"))
+	mockUseCases.On("EmailExists", mock.Anything, "john@example.com").Return(true, nil)
+
+	// When
+	rec := performHead(t, handler.EmailExists, "email", "john@example.com")
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-cache", rec.Header().Get(echo.HeaderCacheControl))
+}
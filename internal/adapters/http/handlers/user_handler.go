@@ -2,39 +2,48 @@ package handlers
 
 import (
 	"errors"
+	"math/rand/v2"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"user-service/internal/adapters/http/middlewares/auth"
 	"user-service/internal/application/dto"
 	"user-service/internal/application/usecases"
+	"user-service/internal/domain/entities"
 	domainErrors "user-service/internal/domain/errors"
 	"user-service/pkg/logger"
+	"user-service/pkg/pagination"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	// HeaderDeprecation marks responses of deprecated endpoints
+	HeaderDeprecation = "Deprecation"
+	// HeaderLink points deprecated endpoints at their successor
+	HeaderLink = "Link"
+)
+
+// userCursorSort is the order user listings are continued in with cursors
+const userCursorSort = "id"
+
 type UserHandler struct {
 	userUseCases usecases.UserUseCases
-	validator    *validator.Validate
+	cursors      *pagination.Codec
 	logger       logger.Logger
 }
 
-func NewUserHandler(userUseCases usecases.UserUseCases, log logger.Logger) *UserHandler {
+func NewUserHandler(userUseCases usecases.UserUseCases, cursors *pagination.Codec, log logger.Logger) *UserHandler {
 	return &UserHandler{
 		userUseCases: userUseCases,
-		validator:    validator.New(),
+		cursors:      cursors,
 		logger:       log.With("component", "user_handler"),
 	}
 }
 
-// ErrorResponse represents an error response
-type ErrorResponse struct {
-	Error   string                 `json:"error"`
-	Message string                 `json:"message"`
-	Details map[string]interface{} `json:"details,omitempty"`
-}
-
 // CreateUser handles POST /api/v1/users
 func (h *UserHandler) CreateUser(c echo.Context) error {
 	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
@@ -44,40 +53,28 @@ func (h *UserHandler) CreateUser(c echo.Context) error {
 		"remote_ip", c.RealIP(),
 		"user_agent", c.Request().UserAgent())
 
-	// Parse request body
+	// Parse and validate request body
 	var request dto.CreateUserRequestDTO
-	if err := c.Bind(&request); err != nil {
-		h.logger.Warn("Failed to bind request body",
+	if err := bindRequest(c, &request); err != nil {
+		h.logger.Warn("Invalid request body",
 			"request_id", requestID,
 			"error", err)
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "INVALID_REQUEST",
-			Message: "Invalid request body format",
-		})
+		return renderError(c, err)
 	}
 
-	// Validate request
-	if err := h.validator.Struct(request); err != nil {
-		h.logger.Warn("Request validation failed",
+	// External IDs are vouched for by the system that issued them
+	if request.ExternalID != nil && !auth.PrincipalFrom(c).HasRole(auth.RoleInternal, auth.RoleAdmin) {
+		h.logger.Warn("External ID supplied without an internal API key",
 			"request_id", requestID,
-			"error", err)
-
-		details := make(map[string]interface{})
-		if validationErrors, ok := err.(validator.ValidationErrors); ok {
-			for _, fieldError := range validationErrors {
-				details[fieldError.Field()] = getValidationErrorMessage(fieldError)
-			}
-		}
-
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "VALIDATION_ERROR",
-			Message: "Request validation failed",
-			Details: details,
-		})
+			"source", request.ExternalID.Source)
+		return renderError(c, domainErrors.ErrForbidden)
 	}
 
 	// Execute use case
 	response, err := h.userUseCases.CreateUser(c.Request().Context(), &request)
+	if queued, ok := queuedWrite(err); ok {
+		return c.JSON(http.StatusAccepted, queued)
+	}
 	if err != nil {
 		return h.handleError(c, err, requestID, "Failed to create user")
 	}
@@ -85,7 +82,29 @@ func (h *UserHandler) CreateUser(c echo.Context) error {
 	h.logger.Info("User created successfully",
 		"request_id", requestID,
 		"user_id", response.ID,
-		"email", response.Email)
+		"email", logger.MaskEmail(response.Email))
+
+	return c.JSON(http.StatusCreated, response.VisibleTo(viewerOf(c)))
+}
+
+// CreateUserDecoy answers suspected bots on POST /api/v1/users with a plausible
+// success response without creating anything
+func (h *UserHandler) CreateUserDecoy(c echo.Context) error {
+	var request dto.CreateUserRequestDTO
+	_ = c.Bind(&request)
+
+	now := dto.NewTimestamp(time.Now())
+	response := &dto.UserResponseDTO{
+		ID:        uint(rand.Uint32N(1_000_000) + 1),
+		Email:     request.Email,
+		FirstName: request.FirstName,
+		LastName:  request.LastName,
+		FullName:  (&entities.User{FirstName: request.FirstName, LastName: request.LastName}).FullName(),
+		Phone:     request.Phone,
+		Status:    entities.UserStatusActive,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
 
 	return c.JSON(http.StatusCreated, response)
 }
@@ -102,10 +121,7 @@ func (h *UserHandler) GetUser(c echo.Context) error {
 			"request_id", requestID,
 			"id_param", idParam,
 			"error", err)
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "INVALID_ID",
-			Message: "Invalid user ID format",
-		})
+		return renderError(c, domainErrors.ErrInvalidID)
 	}
 
 	h.logger.Info("Get user request received",
@@ -123,26 +139,32 @@ func (h *UserHandler) GetUser(c echo.Context) error {
 		"request_id", requestID,
 		"user_id", response.ID)
 
-	return c.JSON(http.StatusOK, response)
+	return c.JSON(http.StatusOK, response.VisibleTo(viewerOf(c)))
 }
 
 // GetUserByEmail handles GET /api/v1/users/email/:email
+//
+// Deprecated: emails do not survive path encoding reliably and end up in
+// access logs; clients should use GET /api/v1/users?email= instead.
 func (h *UserHandler) GetUserByEmail(c echo.Context) error {
 	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
 
+	c.Response().Header().Set(HeaderDeprecation, "true")
+	c.Response().Header().Add(HeaderLink, `</api/v1/users>; rel="successor-version"`)
+
 	email := c.Param("email")
 	if email == "" {
 		h.logger.Warn("Empty email parameter",
 			"request_id", requestID)
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "INVALID_EMAIL",
+		return writeError(c, errorSpec{Status: http.StatusBadRequest}, ErrorResponse{
+			Error:   domainErrors.ErrInvalidUserEmail.Code,
 			Message: "Email parameter is required",
 		})
 	}
 
 	h.logger.Info("Get user by email request received",
 		"request_id", requestID,
-		"email", email,
+		"email", logger.MaskEmail(email),
 		"remote_ip", c.RealIP())
 
 	// Execute use case
@@ -154,9 +176,32 @@ func (h *UserHandler) GetUserByEmail(c echo.Context) error {
 	h.logger.Info("User retrieved by email successfully",
 		"request_id", requestID,
 		"user_id", response.ID,
-		"email", response.Email)
+		"email", logger.MaskEmail(response.Email))
+
+	return c.JSON(http.StatusOK, response.VisibleTo(viewerOf(c)))
+}
+
+// GetUserByExternalID handles GET /api/v1/internal/users/by-external-id/:source/:id
+func (h *UserHandler) GetUserByExternalID(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+	source := c.Param("source")
+
+	h.logger.Info("Get user by external ID request received",
+		"request_id", requestID,
+		"source", source,
+		"remote_ip", c.RealIP())
+
+	response, err := h.userUseCases.GetUserByExternalID(c.Request().Context(), source, c.Param("id"))
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to get user by external ID")
+	}
+
+	h.logger.Info("User retrieved by external ID successfully",
+		"request_id", requestID,
+		"user_id", response.ID,
+		"source", source)
 
-	return c.JSON(http.StatusOK, response)
+	return c.JSON(http.StatusOK, response.VisibleTo(viewerOf(c)))
 }
 
 // ListUsers handles GET /api/v1/users
@@ -168,6 +213,28 @@ func (h *UserHandler) ListUsers(c echo.Context) error {
 		"remote_ip", c.RealIP())
 
 	// Parse query parameters
+	filter := dto.UserFilterDTO{
+		Tags:    c.QueryParams()["tag"],
+		Email:   c.QueryParam("email"),
+		Country: c.QueryParam("country"),
+	}
+
+	// An exact email lookup needs an address; an empty one would list everyone
+	if c.QueryParams().Has("email") && strings.TrimSpace(filter.Email) == "" {
+		return writeError(c, errorSpec{Status: http.StatusBadRequest}, ErrorResponse{
+			Error:   domainErrors.ErrInvalidUserEmail.Code,
+			Message: "Email parameter must not be empty",
+		})
+	}
+	// A cursor from a previous page continues the listing after it
+	if token := c.QueryParam("cursor"); token != "" {
+		afterID, err := h.decodeCursor(token)
+		if err != nil {
+			return renderError(c, domainErrors.ErrInvalidCursor)
+		}
+		filter.AfterID = afterID
+	}
+
 	page := 1
 	pageSize := 10
 
@@ -178,7 +245,7 @@ func (h *UserHandler) ListUsers(c echo.Context) error {
 	}
 
 	if sizeParam := c.QueryParam("page_size"); sizeParam != "" {
-		if ps, err := strconv.Atoi(sizeParam); err == nil && ps > 0 && ps <= 100 {
+		if ps, err := strconv.Atoi(sizeParam); err == nil && ps > 0 {
 			pageSize = ps
 		}
 	}
@@ -186,61 +253,209 @@ func (h *UserHandler) ListUsers(c echo.Context) error {
 	h.logger.Info("List users parameters",
 		"request_id", requestID,
 		"page", page,
-		"page_size", pageSize)
+		"page_size", pageSize,
+		"tags", filter.Tags,
+		"country", filter.Country,
+		"email", logger.MaskEmail(filter.Email))
 
 	// Execute use case
-	response, err := h.userUseCases.ListUsers(c.Request().Context(), page, pageSize)
+	response, err := h.userUseCases.ListUsers(c.Request().Context(), filter, page, pageSize)
 	if err != nil {
 		return h.handleError(c, err, requestID, "Failed to list users")
 	}
 
+	if count := len(response.Users); count > 0 && count == response.PageSize {
+		last := strconv.FormatUint(uint64(response.Users[count-1].ID), 10)
+		response.NextCursor = h.cursors.Encode(pagination.Cursor{Sort: userCursorSort, Keys: []string{last}})
+	}
+
 	h.logger.Info("Users listed successfully",
 		"request_id", requestID,
 		"count", len(response.Users),
 		"page", page)
 
-	return c.JSON(http.StatusOK, response)
+	return c.JSON(http.StatusOK, response.VisibleTo(viewerOf(c)))
 }
 
-// handleError handles different types of errors and returns appropriate HTTP responses
-func (h *UserHandler) handleError(c echo.Context, err error, requestID, logMessage string) error {
-	h.logger.Error(logMessage,
+// decodeCursor returns the ID of the last user of the page a cursor was
+// issued for
+func (h *UserHandler) decodeCursor(token string) (uint, error) {
+	cursor, err := h.cursors.Decode(token, userCursorSort)
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.ParseUint(cursor.Keys[0], 10, 0)
+	if err != nil || id == 0 {
+		return 0, pagination.ErrInvalidCursor
+	}
+	return uint(id), nil
+}
+
+// AddUserTags handles POST /api/v1/users/:id/tags
+func (h *UserHandler) AddUserTags(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	id, err := parseUserID(c)
+	if err != nil {
+		return renderError(c, domainErrors.ErrInvalidID)
+	}
+
+	var request dto.UserTagsRequestDTO
+	if err := bindRequest(c, &request); err != nil {
+		h.logger.Warn("Invalid request body",
+			"request_id", requestID,
+			"error", err)
+		return renderError(c, err)
+	}
+
+	h.logger.Info("Add user tags request received",
 		"request_id", requestID,
-		"error", err)
-
-	// Handle domain errors
-	var domainErr *domainErrors.DomainError
-	if errors.As(err, &domainErr) {
-		switch domainErr.Code {
-		case domainErrors.ErrUserNotFound.Code:
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   domainErr.Code,
-				Message: domainErr.Message,
-			})
-		case domainErrors.ErrUserAlreadyExists.Code:
-			return c.JSON(http.StatusConflict, ErrorResponse{
-				Error:   domainErr.Code,
-				Message: domainErr.Message,
-			})
-		case domainErrors.ErrInvalidUserEmail.Code,
-			domainErrors.ErrInvalidUserPassword.Code:
-			return c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   domainErr.Code,
-				Message: domainErr.Message,
-			})
-		default:
-			return c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   domainErr.Code,
-				Message: domainErr.Message,
-			})
-		}
+		"user_id", id,
+		"tags", request.Tags)
+
+	response, err := h.userUseCases.AddUserTags(c.Request().Context(), id, request.Tags)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to add user tags")
+	}
+
+	return c.JSON(http.StatusOK, response.VisibleTo(viewerOf(c)))
+}
+
+// RemoveUserTag handles DELETE /api/v1/users/:id/tags/:tag
+func (h *UserHandler) RemoveUserTag(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	id, err := parseUserID(c)
+	if err != nil {
+		return renderError(c, domainErrors.ErrInvalidID)
+	}
+
+	tag := c.Param("tag")
+
+	h.logger.Info("Remove user tag request received",
+		"request_id", requestID,
+		"user_id", id,
+		"tag", tag)
+
+	response, err := h.userUseCases.RemoveUserTags(c.Request().Context(), id, []string{tag})
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to remove user tag")
+	}
+
+	return c.JSON(http.StatusOK, response.VisibleTo(viewerOf(c)))
+}
+
+// UpdatePreferences handles PUT /api/v1/users/:id/preferences
+func (h *UserHandler) UpdatePreferences(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	id, err := parseUserID(c)
+	if err != nil {
+		return renderError(c, domainErrors.ErrInvalidID)
+	}
+
+	var request dto.UpdatePreferencesRequestDTO
+	if err := bindRequest(c, &request); err != nil {
+		h.logger.Warn("Invalid request body",
+			"request_id", requestID,
+			"error", err)
+		return renderError(c, err)
+	}
+
+	h.logger.Info("Update preferences request received",
+		"request_id", requestID,
+		"user_id", id)
+
+	response, err := h.userUseCases.UpdatePreferences(c.Request().Context(), id, &request)
+	if queued, ok := queuedWrite(err); ok {
+		return c.JSON(http.StatusAccepted, queued)
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to update user preferences")
 	}
 
-	// Handle generic errors
-	return c.JSON(http.StatusInternalServerError, ErrorResponse{
-		Error:   "INTERNAL_ERROR",
-		Message: "An internal error occurred",
-	})
+	return c.JSON(http.StatusOK, response.VisibleTo(viewerOf(c)))
+}
+
+// UpdateProfile handles PUT /api/v1/users/:id/profile
+func (h *UserHandler) UpdateProfile(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	id, err := parseUserID(c)
+	if err != nil {
+		return renderError(c, domainErrors.ErrInvalidID)
+	}
+
+	var request dto.UpdateProfileRequestDTO
+	if err := bindRequest(c, &request); err != nil {
+		h.logger.Warn("Invalid request body",
+			"request_id", requestID,
+			"error", err)
+		return renderError(c, err)
+	}
+
+	h.logger.Info("Update profile request received",
+		"request_id", requestID,
+		"user_id", id)
+
+	response, err := h.userUseCases.UpdateProfile(c.Request().Context(), id, &request)
+	if queued, ok := queuedWrite(err); ok {
+		return c.JSON(http.StatusAccepted, queued)
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to update user profile")
+	}
+
+	return c.JSON(http.StatusOK, response.VisibleTo(viewerOf(c)))
+}
+
+// viewerOf describes the caller to the response filtering. Staff and internal
+// services see every profile field, other API keys org fields and anonymous
+// callers public ones; only admins see dates of birth.
+func viewerOf(c echo.Context) dto.Viewer {
+	principal := auth.PrincipalFrom(c)
+	viewer := dto.Viewer{
+		Audience: entities.VisibilityPublic,
+		Admin:    principal.HasRole(auth.RoleAdmin),
+		Internal: principal.HasRole(auth.RoleInternal),
+	}
+	switch {
+	case principal.HasRole(auth.RoleAdmin, auth.RoleSupport, auth.RoleInternal):
+		viewer.Audience = entities.VisibilityPrivate
+	case principal != nil:
+		viewer.Audience = entities.VisibilityOrg
+	}
+	return viewer
+}
+
+// ViewerKey returns the key of the viewer a request's responses are filtered
+// for, which cached responses vary by
+func ViewerKey(c echo.Context) string {
+	return viewerOf(c).Key()
+}
+
+// parseUserID parses the :id path parameter
+func parseUserID(c echo.Context) (uint, error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+// handleError handles different types of errors and returns appropriate HTTP responses
+func (h *UserHandler) handleError(c echo.Context, err error, requestID, logMessage string) error {
+	return respondWithError(c, h.logger, err, requestID, logMessage)
+}
+
+// queuedWrite answers writes the use cases queued while the database is
+// unreachable
+func queuedWrite(err error) (*dto.QueuedWriteResponseDTO, bool) {
+	var queued *domainErrors.WriteQueuedError
+	if !errors.As(err, &queued) {
+		return nil, false
+	}
+	return &dto.QueuedWriteResponseDTO{WriteID: queued.ID, Status: dto.QueuedWriteStatusQueued}, true
 }
 
 // getValidationErrorMessage returns a user-friendly validation error message
@@ -254,6 +469,8 @@ func getValidationErrorMessage(fieldError validator.FieldError) string {
 		return "Minimum length is " + fieldError.Param() + " characters"
 	case "max":
 		return "Maximum length is " + fieldError.Param() + " characters"
+	case "oneof":
+		return "Must be one of: " + strings.Join(strings.Fields(fieldError.Param()), ", ")
 	default:
 		return "Invalid value"
 	}
@@ -4,13 +4,18 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"user-service/internal/adapters/http/middlewares/auth"
 	"user-service/internal/application/dto"
+	"user-service/internal/config"
 	"user-service/internal/domain/entities"
 	domainErrors "user-service/internal/domain/errors"
 	"user-service/pkg/logger"
+	"user-service/pkg/pagination"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -47,6 +52,14 @@ func (m *MockUserUseCases) GetUserByEmail(ctx context.Context, email string) (*d
 	return args.Get(0).(*dto.UserResponseDTO), args.Error(1)
 }
 
+func (m *MockUserUseCases) GetUserByExternalID(ctx context.Context, source, externalID string) (*dto.UserResponseDTO, error) {
+	args := m.Called(ctx, source, externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.UserResponseDTO), args.Error(1)
+}
+
 func (m *MockUserUseCases) UpdateUser(ctx context.Context, id uint, request *dto.UpdateUserRequestDTO) (*dto.UserResponseDTO, error) {
 	args := m.Called(ctx, id, request)
 	if args.Get(0) == nil {
@@ -55,18 +68,54 @@ func (m *MockUserUseCases) UpdateUser(ctx context.Context, id uint, request *dto
 	return args.Get(0).(*dto.UserResponseDTO), args.Error(1)
 }
 
-func (m *MockUserUseCases) ListUsers(ctx context.Context, page, pageSize int) (*dto.UserListResponseDTO, error) {
-	args := m.Called(ctx, page, pageSize)
+func (m *MockUserUseCases) ListUsers(ctx context.Context, filter dto.UserFilterDTO, page, pageSize int) (*dto.UserListResponseDTO, error) {
+	args := m.Called(ctx, filter, page, pageSize)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dto.UserListResponseDTO), args.Error(1)
 }
 
+func (m *MockUserUseCases) AddUserTags(ctx context.Context, id uint, tags []string) (*dto.UserResponseDTO, error) {
+	args := m.Called(ctx, id, tags)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.UserResponseDTO), args.Error(1)
+}
+
+func (m *MockUserUseCases) RemoveUserTags(ctx context.Context, id uint, tags []string) (*dto.UserResponseDTO, error) {
+	args := m.Called(ctx, id, tags)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.UserResponseDTO), args.Error(1)
+}
+
+func (m *MockUserUseCases) UpdatePreferences(ctx context.Context, id uint, request *dto.UpdatePreferencesRequestDTO) (*dto.UserResponseDTO, error) {
+	args := m.Called(ctx, id, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.UserResponseDTO), args.Error(1)
+}
+
+func (m *MockUserUseCases) UpdateProfile(ctx context.Context, id uint, request *dto.UpdateProfileRequestDTO) (*dto.UserResponseDTO, error) {
+	args := m.Called(ctx, id, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.UserResponseDTO), args.Error(1)
+}
+
 func setupTestHandler() (*UserHandler, *MockUserUseCases) {
 	mockUseCases := new(MockUserUseCases)
 	log := logger.New("test")
-	handler := NewUserHandler(mockUseCases, log)
+	cursors, err := pagination.NewCodec("user-handler-test-cursor-key-32chars")
+	if err != nil {
+		panic(err)
+	}
+	handler := NewUserHandler(mockUseCases, cursors, log)
 	return handler, mockUseCases
 }
 
@@ -100,7 +149,7 @@ func TestUserHandler_CreateUser_Success(t *testing.T) {
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 
 	rec := httptest.NewRecorder()
-	c := echo.New().NewContext(req, rec)
+	c := newTestEcho().NewContext(req, rec)
 
 	// Execute
 	err := handler.CreateUser(c)
@@ -137,7 +186,7 @@ func TestUserHandler_CreateUser_ValidationError(t *testing.T) {
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 
 	rec := httptest.NewRecorder()
-	c := echo.New().NewContext(req, rec)
+	c := newTestEcho().NewContext(req, rec)
 
 	// Execute
 	err := handler.CreateUser(c)
@@ -173,7 +222,7 @@ func TestUserHandler_CreateUser_UserAlreadyExists(t *testing.T) {
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 
 	rec := httptest.NewRecorder()
-	c := echo.New().NewContext(req, rec)
+	c := newTestEcho().NewContext(req, rec)
 
 	// Execute
 	err := handler.CreateUser(c)
@@ -190,6 +239,94 @@ func TestUserHandler_CreateUser_UserAlreadyExists(t *testing.T) {
 	mockUseCases.AssertExpectations(t)
 }
 
+func TestUserHandler_CreateUser_QueuedWhileDatabaseIsDown(t *testing.T) {
+	// Given a sign-up the use cases queue while the database is unreachable
+	handler, mockUseCases := setupTestHandler()
+
+	requestBody := dto.CreateUserRequestDTO{
+		Email:     "queued@example.com",
+		Password:  "SecurePass123",
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+	mockUseCases.On("CreateUser", mock.Anything, &requestBody).
+		Return(nil, &domainErrors.WriteQueuedError{ID: "write-1"})
+
+	jsonBody, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users", bytes.NewBuffer(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := newTestEcho().NewContext(req, rec)
+
+	// When the sign-up is submitted
+	err := handler.CreateUser(c)
+
+	// Then it is accepted with the queued write instead of an error body
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	var response dto.QueuedWriteResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, dto.QueuedWriteResponseDTO{WriteID: "write-1", Status: dto.QueuedWriteStatusQueued}, response)
+	assert.NotContains(t, rec.Body.String(), `"error"`)
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestUserHandler_UpdatePreferences_QueuedWhileDatabaseIsDown(t *testing.T) {
+	// Given a preference change the use cases queue while the database is unreachable
+	handler, mockUseCases := setupTestHandler()
+	mockUseCases.On("UpdatePreferences", mock.Anything, uint(1), mock.Anything).
+		Return(nil, fmt.Errorf("queue: %w", &domainErrors.WriteQueuedError{ID: "write-2"}))
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/users/1/preferences", strings.NewReader(`{"locale":"es"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := newTestEcho().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// When the change is submitted
+	err := handler.UpdatePreferences(c)
+
+	// Then it is accepted with the queued write
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	var response dto.QueuedWriteResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "write-2", response.WriteID)
+	assert.Equal(t, dto.QueuedWriteStatusQueued, response.Status)
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestUserHandler_CreateUser_ExternalIDRequiresInternalKey(t *testing.T) {
+	// Given an anonymous caller supplying an external ID
+	handler, mockUseCases := setupTestHandler()
+
+	requestBody := dto.CreateUserRequestDTO{
+		Email:      "test@example.com",
+		Password:   "SecurePass123",
+		FirstName:  "John",
+		LastName:   "Doe",
+		ExternalID: &dto.ExternalIDDTO{Source: "crm", ID: "C-1001"},
+	}
+
+	jsonBody, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users", bytes.NewBuffer(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := newTestEcho().NewContext(req, rec)
+
+	// When
+	err := handler.CreateUser(c)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	mockUseCases.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+}
+
 func TestUserHandler_GetUser_Success(t *testing.T) {
 	// Setup
 	handler, mockUseCases := setupTestHandler()
@@ -208,7 +345,7 @@ func TestUserHandler_GetUser_Success(t *testing.T) {
 	// Create request
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/1", nil)
 	rec := httptest.NewRecorder()
-	c := echo.New().NewContext(req, rec)
+	c := newTestEcho().NewContext(req, rec)
 	c.SetParamNames("id")
 	c.SetParamValues("1")
 
@@ -229,6 +366,59 @@ func TestUserHandler_GetUser_Success(t *testing.T) {
 	mockUseCases.AssertExpectations(t)
 }
 
+func TestUserHandler_GetUser_FiltersProfileByVisibility(t *testing.T) {
+	authenticator := auth.NewAuthenticator([]config.APIKeyConfig{
+		{Name: "community", Key: "community-key", Role: "partner"},
+		{Name: "support", Key: "support-key", Role: auth.RoleSupport},
+	})
+
+	tests := []struct {
+		name        string
+		apiKey      string
+		displayName string
+		pronouns    string
+	}{
+		{name: "anonymous caller", displayName: "JD"},
+		{name: "org caller", apiKey: "community-key", displayName: "JD", pronouns: "he/him"},
+		{name: "staff caller", apiKey: "support-key", displayName: "JD", pronouns: "he/him"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given a public display name and org-only pronouns
+			handler, mockUseCases := setupTestHandler()
+			mockUseCases.On("GetUserByID", mock.Anything, uint(1)).Return(&dto.UserResponseDTO{
+				ID:          1,
+				DisplayName: "JD",
+				Pronouns:    "he/him",
+				Preferences: entities.UserPreferences{Visibility: entities.ProfileVisibility{
+					DisplayName: entities.VisibilityPublic,
+					Pronouns:    entities.VisibilityOrg,
+				}},
+			}, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/users/1", nil)
+			if tt.apiKey != "" {
+				req.Header.Set(auth.HeaderAPIKey, tt.apiKey)
+			}
+			rec := httptest.NewRecorder()
+			c := newTestEcho().NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			// When
+			err := authenticator.Identify()(handler.GetUser)(c)
+
+			// Then
+			require.NoError(t, err)
+			var response dto.UserResponseDTO
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, tt.displayName, response.DisplayName)
+			assert.Equal(t, tt.pronouns, response.Pronouns)
+		})
+	}
+}
+
 func TestUserHandler_GetUser_NotFound(t *testing.T) {
 	// Setup
 	handler, mockUseCases := setupTestHandler()
@@ -238,7 +428,7 @@ func TestUserHandler_GetUser_NotFound(t *testing.T) {
 	// Create request
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/999", nil)
 	rec := httptest.NewRecorder()
-	c := echo.New().NewContext(req, rec)
+	c := newTestEcho().NewContext(req, rec)
 	c.SetParamNames("id")
 	c.SetParamValues("999")
 
@@ -264,7 +454,7 @@ func TestUserHandler_GetUser_InvalidID(t *testing.T) {
 	// Create request with invalid ID
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/invalid", nil)
 	rec := httptest.NewRecorder()
-	c := echo.New().NewContext(req, rec)
+	c := newTestEcho().NewContext(req, rec)
 	c.SetParamNames("id")
 	c.SetParamValues("invalid")
 
@@ -312,12 +502,12 @@ func TestUserHandler_ListUsers_Success(t *testing.T) {
 		PageSize: 10,
 	}
 
-	mockUseCases.On("ListUsers", mock.Anything, 1, 10).Return(expectedResponse, nil)
+	mockUseCases.On("ListUsers", mock.Anything, dto.UserFilterDTO{}, 1, 10).Return(expectedResponse, nil)
 
 	// Create request
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
 	rec := httptest.NewRecorder()
-	c := echo.New().NewContext(req, rec)
+	c := newTestEcho().NewContext(req, rec)
 
 	// Execute
 	err := handler.ListUsers(c)
@@ -348,12 +538,100 @@ func TestUserHandler_ListUsers_WithPagination(t *testing.T) {
 		PageSize: 5,
 	}
 
-	mockUseCases.On("ListUsers", mock.Anything, 2, 5).Return(expectedResponse, nil)
+	mockUseCases.On("ListUsers", mock.Anything, dto.UserFilterDTO{}, 2, 5).Return(expectedResponse, nil)
 
 	// Create request with pagination parameters
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/users?page=2&page_size=5", nil)
 	rec := httptest.NewRecorder()
-	c := echo.New().NewContext(req, rec)
+	c := newTestEcho().NewContext(req, rec)
+
+	// Execute
+	err := handler.ListUsers(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestUserHandler_ListUsers_WithCursor(t *testing.T) {
+	// Given a full first page
+	handler, mockUseCases := setupTestHandler()
+
+	firstPage := &dto.UserListResponseDTO{
+		Users:    []*dto.UserResponseDTO{{ID: 4}, {ID: 7}},
+		Total:    3,
+		Page:     1,
+		PageSize: 2,
+	}
+	mockUseCases.On("ListUsers", mock.Anything, dto.UserFilterDTO{}, 1, 2).Return(firstPage, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users?page_size=2", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, handler.ListUsers(newTestEcho().NewContext(req, rec)))
+
+	var first dto.UserListResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &first))
+	require.NotEmpty(t, first.NextCursor)
+
+	// When the next page is requested with its cursor
+	secondPage := &dto.UserListResponseDTO{
+		Users:    []*dto.UserResponseDTO{{ID: 9}},
+		Total:    3,
+		Page:     1,
+		PageSize: 2,
+	}
+	mockUseCases.On("ListUsers", mock.Anything, dto.UserFilterDTO{AfterID: 7}, 1, 2).Return(secondPage, nil)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/users?page_size=2&cursor="+first.NextCursor, nil)
+	rec = httptest.NewRecorder()
+	require.NoError(t, handler.ListUsers(newTestEcho().NewContext(req, rec)))
+
+	// Then the listing continues after the last user and ends there
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var second dto.UserListResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &second))
+	assert.Len(t, second.Users, 1)
+	assert.Empty(t, second.NextCursor)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestUserHandler_ListUsers_InvalidCursor(t *testing.T) {
+	// Given a cursor that was tampered with
+	handler, mockUseCases := setupTestHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users?cursor=eyJzIjoiaWQiLCJrIjpbIjEiXX0.forged", nil)
+	rec := httptest.NewRecorder()
+
+	// When
+	err := handler.ListUsers(newTestEcho().NewContext(req, rec))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "INVALID_CURSOR")
+	mockUseCases.AssertNotCalled(t, "ListUsers", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserHandler_ListUsers_WithTagFilter(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestHandler()
+
+	expectedResponse := &dto.UserListResponseDTO{
+		Users:    []*dto.UserResponseDTO{},
+		Page:     1,
+		PageSize: 10,
+	}
+
+	filter := dto.UserFilterDTO{Tags: []string{"vip", "beta_tester"}}
+	mockUseCases.On("ListUsers", mock.Anything, filter, 1, 10).Return(expectedResponse, nil)
+
+	// Create request with tag filters
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users?tag=vip&tag=beta_tester", nil)
+	rec := httptest.NewRecorder()
+	c := newTestEcho().NewContext(req, rec)
 
 	// Execute
 	err := handler.ListUsers(c)
@@ -364,3 +642,145 @@ func TestUserHandler_ListUsers_WithPagination(t *testing.T) {
 
 	mockUseCases.AssertExpectations(t)
 }
+
+func TestUserHandler_ListUsers_ByEmail(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestHandler()
+
+	expectedResponse := &dto.UserListResponseDTO{
+		Users:    []*dto.UserResponseDTO{{ID: 1, Email: "john+work@example.com"}},
+		Total:    1,
+		Page:     1,
+		PageSize: 10,
+	}
+
+	filter := dto.UserFilterDTO{Email: "john+work@example.com"}
+	mockUseCases.On("ListUsers", mock.Anything, filter, 1, 10).Return(expectedResponse, nil)
+
+	// An encoded plus sign must not turn into a space
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users?email=john%2Bwork%40example.com", nil)
+	rec := httptest.NewRecorder()
+	c := newTestEcho().NewContext(req, rec)
+
+	// Execute
+	err := handler.ListUsers(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestUserHandler_ListUsers_EmptyEmail(t *testing.T) {
+	handler, mockUseCases := setupTestHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users?email=", nil)
+	rec := httptest.NewRecorder()
+	c := newTestEcho().NewContext(req, rec)
+
+	require.NoError(t, handler.ListUsers(c))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "INVALID_EMAIL")
+	mockUseCases.AssertNotCalled(t, "ListUsers", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserHandler_GetUserByEmail_IsDeprecated(t *testing.T) {
+	handler, mockUseCases := setupTestHandler()
+	mockUseCases.On("GetUserByEmail", mock.Anything, "john@example.com").Return(&dto.UserResponseDTO{ID: 1, Email: "john@example.com"}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/email/john@example.com", nil)
+	rec := httptest.NewRecorder()
+	c := newTestEcho().NewContext(req, rec)
+	c.SetParamNames("email")
+	c.SetParamValues("john@example.com")
+
+	require.NoError(t, handler.GetUserByEmail(c))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "true", rec.Header().Get(HeaderDeprecation))
+	assert.Contains(t, rec.Header().Get(HeaderLink), `rel="successor-version"`)
+}
+
+func TestUserHandler_AddUserTags_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestHandler()
+
+	expectedResponse := &dto.UserResponseDTO{
+		ID:   1,
+		Tags: []string{"beta_tester", "vip"},
+	}
+
+	mockUseCases.On("AddUserTags", mock.Anything, uint(1), []string{"vip", "beta_tester"}).Return(expectedResponse, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/1/tags", bytes.NewBufferString(`{"tags":["vip","beta_tester"]}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := newTestEcho().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.AddUserTags(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.UserResponseDTO
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"beta_tester", "vip"}, response.Tags)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestUserHandler_AddUserTags_EmptyTags(t *testing.T) {
+	// Setup
+	handler, _ := setupTestHandler()
+
+	// Create request
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/1/tags", bytes.NewBufferString(`{"tags":[]}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := newTestEcho().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.AddUserTags(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "VALIDATION_ERROR", response.Error)
+}
+
+func TestUserHandler_RemoveUserTag_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestHandler()
+
+	expectedResponse := &dto.UserResponseDTO{ID: 1, Tags: []string{}}
+	mockUseCases.On("RemoveUserTags", mock.Anything, uint(1), []string{"vip"}).Return(expectedResponse, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/1/tags/vip", nil)
+	rec := httptest.NewRecorder()
+	c := newTestEcho().NewContext(req, rec)
+	c.SetParamNames("id", "tag")
+	c.SetParamValues("1", "vip")
+
+	// Execute
+	err := handler.RemoveUserTag(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	mockUseCases.AssertExpectations(t)
+}
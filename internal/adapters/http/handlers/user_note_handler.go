@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"user-service/internal/adapters/http/middlewares/auth"
+	"user-service/internal/application/dto"
+	"user-service/internal/application/usecases"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type UserNoteHandler struct {
+	noteUseCases usecases.UserNoteUseCases
+	logger       logger.Logger
+}
+
+func NewUserNoteHandler(noteUseCases usecases.UserNoteUseCases, log logger.Logger) *UserNoteHandler {
+	return &UserNoteHandler{
+		noteUseCases: noteUseCases,
+		logger:       log.With("component", "user_note_handler"),
+	}
+}
+
+// CreateNote handles POST /api/v1/admin/users/:id/notes
+func (h *UserNoteHandler) CreateNote(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	userID, err := parseUserID(c)
+	if err != nil {
+		return renderError(c, domainErrors.ErrInvalidID)
+	}
+
+	var request dto.CreateUserNoteRequestDTO
+	if err := bindRequest(c, &request); err != nil {
+		h.logger.Warn("Invalid request body",
+			"request_id", requestID,
+			"error", err)
+		return renderError(c, err)
+	}
+
+	author := auth.PrincipalFrom(c).Name
+
+	response, err := h.noteUseCases.CreateNote(c.Request().Context(), userID, author, &request)
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to create note")
+	}
+
+	h.logger.Info("Note created successfully",
+		"request_id", requestID,
+		"user_id", userID,
+		"note_id", response.ID,
+		"author", author)
+
+	return c.JSON(http.StatusCreated, response)
+}
+
+// ListNotes handles GET /api/v1/admin/users/:id/notes
+func (h *UserNoteHandler) ListNotes(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	userID, err := parseUserID(c)
+	if err != nil {
+		return renderError(c, domainErrors.ErrInvalidID)
+	}
+
+	page := 1
+	pageSize := 20
+
+	if pageParam := c.QueryParam("page"); pageParam != "" {
+		if p, err := strconv.Atoi(pageParam); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	if sizeParam := c.QueryParam("page_size"); sizeParam != "" {
+		if ps, err := strconv.Atoi(sizeParam); err == nil && ps > 0 {
+			pageSize = ps
+		}
+	}
+
+	viewer := auth.PrincipalFrom(c).Name
+
+	response, err := h.noteUseCases.ListNotes(c.Request().Context(), userID, viewer, page, pageSize)
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to list notes")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// UpdateNote handles PUT /api/v1/admin/users/:id/notes/:note_id
+func (h *UserNoteHandler) UpdateNote(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	userID, noteID, err := parseNoteParams(c)
+	if err != nil {
+		return writeError(c, errorSpec{Status: http.StatusBadRequest}, ErrorResponse{
+			Error:   domainErrors.ErrInvalidID.Code,
+			Message: "Invalid user or note ID format",
+		})
+	}
+
+	var request dto.UpdateUserNoteRequestDTO
+	if err := bindRequest(c, &request); err != nil {
+		h.logger.Warn("Invalid request body",
+			"request_id", requestID,
+			"error", err)
+		return renderError(c, err)
+	}
+
+	editor := auth.PrincipalFrom(c).Name
+
+	response, err := h.noteUseCases.UpdateNote(c.Request().Context(), userID, noteID, editor, &request)
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to update note")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// DeleteNote handles DELETE /api/v1/admin/users/:id/notes/:note_id
+func (h *UserNoteHandler) DeleteNote(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	userID, noteID, err := parseNoteParams(c)
+	if err != nil {
+		return writeError(c, errorSpec{Status: http.StatusBadRequest}, ErrorResponse{
+			Error:   domainErrors.ErrInvalidID.Code,
+			Message: "Invalid user or note ID format",
+		})
+	}
+
+	editor := auth.PrincipalFrom(c).Name
+
+	if err := h.noteUseCases.DeleteNote(c.Request().Context(), userID, noteID, editor); err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to delete note")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// parseNoteParams parses the :id and :note_id path parameters
+func parseNoteParams(c echo.Context) (uint, uint, error) {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	noteID, err := strconv.ParseUint(c.Param("note_id"), 10, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return userID, uint(noteID), nil
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+
+	"user-service/internal/adapters/http/middlewares/auth"
+	"user-service/internal/application/dto"
+	"user-service/internal/application/usecases"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type UserSearchHandler struct {
+	userSearchUseCases usecases.UserSearchUseCases
+	logger             logger.Logger
+}
+
+func NewUserSearchHandler(userSearchUseCases usecases.UserSearchUseCases, log logger.Logger) *UserSearchHandler {
+	return &UserSearchHandler{
+		userSearchUseCases: userSearchUseCases,
+		logger:             log.With("component", "user_search_handler"),
+	}
+}
+
+// SearchUsers handles POST /api/v1/admin/users/search
+func (h *UserSearchHandler) SearchUsers(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	var request dto.UserSearchRequestDTO
+	if err := bindRequest(c, &request); err != nil {
+		h.logger.Warn("Invalid request body",
+			"request_id", requestID,
+			"error", err)
+		return renderError(c, err)
+	}
+
+	actor := auth.PrincipalFrom(c).Name
+
+	response, err := h.userSearchUseCases.SearchUsers(c.Request().Context(), actor, &request)
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to search users")
+	}
+
+	h.logger.Info("Users searched",
+		"request_id", requestID,
+		"actor", actor,
+		"ticket_id", request.TicketID,
+		"matches", response.Count)
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+
+	"user-service/internal/adapters/http/middlewares/auth"
+	"user-service/internal/application/dto"
+	"user-service/internal/application/usecases"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type UserStatusHandler struct {
+	statusUseCases usecases.UserStatusUseCases
+	logger         logger.Logger
+}
+
+func NewUserStatusHandler(statusUseCases usecases.UserStatusUseCases, log logger.Logger) *UserStatusHandler {
+	return &UserStatusHandler{
+		statusUseCases: statusUseCases,
+		logger:         log.With("component", "user_status_handler"),
+	}
+}
+
+// ChangeStatus handles PUT /api/v1/admin/users/:id/status
+func (h *UserStatusHandler) ChangeStatus(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	userID, err := parseUserID(c)
+	if err != nil {
+		return renderError(c, domainErrors.ErrInvalidID)
+	}
+
+	var request dto.ChangeUserStatusRequestDTO
+	if err := bindRequest(c, &request); err != nil {
+		h.logger.Warn("Invalid request body",
+			"request_id", requestID,
+			"error", err)
+		return renderError(c, err)
+	}
+
+	actor := auth.PrincipalFrom(c).Name
+
+	response, err := h.statusUseCases.ChangeStatus(c.Request().Context(), userID, &request, actor)
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to change user status")
+	}
+
+	h.logger.Info("User status changed",
+		"request_id", requestID,
+		"user_id", userID,
+		"status", response.Status,
+		"actor", actor)
+
+	return c.JSON(http.StatusOK, response.VisibleTo(viewerOf(c)))
+}
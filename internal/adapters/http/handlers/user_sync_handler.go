@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+
+	"user-service/internal/adapters/http/middlewares/auth"
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
+	"user-service/internal/application/usecases"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type UserSyncHandler struct {
+	syncUseCases usecases.UserSyncUseCases
+	logger       logger.Logger
+}
+
+func NewUserSyncHandler(syncUseCases usecases.UserSyncUseCases, log logger.Logger) *UserSyncHandler {
+	return &UserSyncHandler{
+		syncUseCases: syncUseCases,
+		logger:       log.With("component", "user_sync_handler"),
+	}
+}
+
+// SyncUser handles PUT /api/v1/internal/users/sync
+func (h *UserSyncHandler) SyncUser(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	var request dto.SyncUserRequestDTO
+	if err := bindRequest(c, &request); err != nil {
+		h.logger.Warn("Invalid request body",
+			"request_id", requestID,
+			"error", err)
+		return renderError(c, err)
+	}
+
+	if request.Source == "" {
+		if principal := auth.PrincipalFrom(c); principal != nil {
+			request.Source = principal.Name
+		}
+	}
+
+	response, err := h.syncUseCases.SyncUser(c.Request().Context(), &request)
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to sync user")
+	}
+
+	h.logger.Info("User synced",
+		"request_id", requestID,
+		"user_id", response.User.ID,
+		"outcome", response.Outcome)
+
+	status := http.StatusOK
+	if response.Outcome == string(ports.UpsertCreated) {
+		status = http.StatusCreated
+	}
+
+	return c.JSON(status, response.VisibleTo(viewerOf(c)))
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"user-service/internal/application/usecases"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type VerificationHandler struct {
+	verificationUseCases usecases.VerificationUseCases
+	logger               logger.Logger
+}
+
+func NewVerificationHandler(verificationUseCases usecases.VerificationUseCases, log logger.Logger) *VerificationHandler {
+	return &VerificationHandler{
+		verificationUseCases: verificationUseCases,
+		logger:               log.With("component", "verification_handler"),
+	}
+}
+
+// ResendVerification handles POST /api/v1/users/:id/resend-verification.
+// A sent email is answered with 202; a request within the cooldown with 200
+// and the time left, which Retry-After repeats.
+func (h *VerificationHandler) ResendVerification(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	userID, err := parseUserID(c)
+	if err != nil {
+		return renderError(c, domainErrors.ErrInvalidID)
+	}
+
+	response, err := h.verificationUseCases.ResendVerification(c.Request().Context(), userID)
+	if err != nil {
+		return respondWithError(c, h.logger, err, requestID, "Failed to resend verification")
+	}
+
+	if response.CooldownRemainingSeconds > 0 {
+		c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(response.CooldownRemainingSeconds))
+	}
+
+	if !response.Sent {
+		h.logger.Info("Verification resend within cooldown",
+			"request_id", requestID,
+			"user_id", userID,
+			"cooldown_remaining_seconds", response.CooldownRemainingSeconds)
+		return c.JSON(http.StatusOK, response)
+	}
+
+	h.logger.Info("Verification resent",
+		"request_id", requestID,
+		"user_id", userID)
+
+	return c.JSON(http.StatusAccepted, response)
+}
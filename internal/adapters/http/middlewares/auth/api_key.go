@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"slices"
+	"strings"
+
+	"user-service/internal/config"
+	domainErrors "user-service/internal/domain/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	RoleAdmin    = "admin"
+	RoleSupport  = "support"
+	RoleInternal = "internal"
+
+	// HeaderAPIKey carries the caller's API key when not sent as a bearer token
+	HeaderAPIKey = "X-API-Key"
+
+	principalContextKey = "auth.principal"
+)
+
+// Principal identifies the authenticated caller of a request
+type Principal struct {
+	Name string
+	Role string
+	// Tenant is the customer the caller's usage is billed to
+	Tenant string
+	// Attributes describe the caller to authorization policies
+	Attributes map[string]string
+}
+
+// HasRole reports whether the principal has one of the given roles
+func (p *Principal) HasRole(roles ...string) bool {
+	return p != nil && slices.Contains(roles, p.Role)
+}
+
+// PrincipalFrom returns the authenticated principal, or nil for anonymous requests
+func PrincipalFrom(c echo.Context) *Principal {
+	principal, _ := c.Get(principalContextKey).(*Principal)
+	return principal
+}
+
+// CarryPrincipal copies the principal of one request's context onto another,
+// for work done on the caller's behalf outside its request
+func CarryPrincipal(from, to echo.Context) {
+	if principal := PrincipalFrom(from); principal != nil {
+		to.Set(principalContextKey, principal)
+	}
+}
+
+// Authenticator resolves API keys into principals
+type Authenticator struct {
+	keys []config.APIKeyConfig
+}
+
+// NewAuthenticator creates an authenticator from the configured API keys
+func NewAuthenticator(keys []config.APIKeyConfig) *Authenticator {
+	return &Authenticator{keys: keys}
+}
+
+// Identify attaches the caller's principal to the context when a valid key is
+// presented, without rejecting anonymous requests
+func (a *Authenticator) Identify() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if principal := a.resolve(c.Request()); principal != nil {
+				c.Set(principalContextKey, principal)
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireRole rejects requests that are not authenticated with one of the given roles
+func (a *Authenticator) RequireRole(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal := a.principal(c)
+			if principal == nil {
+				return domainErrors.ErrUnauthorized
+			}
+
+			if !principal.HasRole(roles...) {
+				return domainErrors.ErrForbidden
+			}
+
+			c.Set(principalContextKey, principal)
+			return next(c)
+		}
+	}
+}
+
+// principal returns the caller identified earlier in the chain, or resolves
+// it for routes registered before Identify runs
+func (a *Authenticator) principal(c echo.Context) *Principal {
+	if principal := PrincipalFrom(c); principal != nil {
+		return principal
+	}
+	return a.resolve(c.Request())
+}
+
+func (a *Authenticator) resolve(req *http.Request) *Principal {
+	key := req.Header.Get(HeaderAPIKey)
+	if key == "" {
+		if authorization := req.Header.Get(echo.HeaderAuthorization); strings.HasPrefix(authorization, "Bearer ") {
+			key = strings.TrimPrefix(authorization, "Bearer ")
+		}
+	}
+
+	if key == "" {
+		return nil
+	}
+
+	for _, candidate := range a.keys {
+		if candidate.Key != "" && subtle.ConstantTimeCompare([]byte(candidate.Key), []byte(key)) == 1 {
+			tenant := candidate.Tenant
+			if tenant == "" {
+				tenant = candidate.Name
+			}
+			return &Principal{Name: candidate.Name, Role: candidate.Role, Tenant: tenant, Attributes: candidate.Attributes}
+		}
+	}
+
+	return nil
+}
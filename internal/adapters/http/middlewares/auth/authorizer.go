@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"strconv"
+
+	"user-service/internal/adapters/policy"
+	"user-service/internal/application/ports"
+	"user-service/internal/config"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Authorizer guards routes by the action they perform. In rbac mode only the
+// roles a route allows count; otherwise policies are evaluated too, and in
+// dry_run mode disagreements with the role check are logged while the role
+// check still decides.
+type Authorizer struct {
+	authenticator *Authenticator
+	engine        *policy.Engine
+	mode          string
+	decisionLog   bool
+	logger        logger.Logger
+	metrics       *metrics.Registry
+}
+
+// NewAuthorizer creates an authorizer for the configured mode and policies
+func NewAuthorizer(authenticator *Authenticator, cfg config.AuthorizationConfig, log logger.Logger, registry *metrics.Registry) *Authorizer {
+	return &Authorizer{
+		authenticator: authenticator,
+		engine:        policy.NewEngine(cfg.Policies),
+		mode:          cfg.Mode,
+		decisionLog:   cfg.DecisionLog,
+		logger:        log.With("component", "authorization"),
+		metrics:       registry,
+	}
+}
+
+// Require guards a route performing action, which roles may perform under
+// role-based checks
+func (a *Authorizer) Require(action string, roles ...string) echo.MiddlewareFunc {
+	if a.mode == config.AuthorizationModeRBAC {
+		return a.authenticator.RequireRole(roles...)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal := a.authenticator.principal(c)
+			roleAllowed := principal.HasRole(roles...)
+			decision := a.engine.Decide(policy.Request{Action: action, Attributes: attributesOf(c, principal)})
+			a.record(c, action, principal, roleAllowed, decision)
+
+			allowed := roleAllowed
+			if a.mode == config.AuthorizationModeEnforce {
+				allowed = decision.Allowed
+			}
+			if !allowed {
+				if principal == nil {
+					return domainErrors.ErrUnauthorized
+				}
+				return domainErrors.ErrForbidden
+			}
+
+			if principal != nil {
+				c.Set(principalContextKey, principal)
+			}
+			return next(c)
+		}
+	}
+}
+
+// record writes the decision log and counts decisions, by whether the
+// policies agreed with the role check
+func (a *Authorizer) record(c echo.Context, action string, principal *Principal, roleAllowed bool, decision policy.Decision) {
+	agrees := roleAllowed == decision.Allowed
+	a.metrics.Counter("authorization_decisions_total").Inc(
+		"action", action,
+		"mode", a.mode,
+		"allowed", strconv.FormatBool(decision.Allowed),
+		"agrees_with_roles", strconv.FormatBool(agrees))
+
+	fields := []interface{}{
+		"request_id", c.Response().Header().Get(echo.HeaderXRequestID),
+		"action", action,
+		"subject", subjectName(principal),
+		"allowed", decision.Allowed,
+		"reason", decision.Reason,
+		"policy", decision.Policy,
+		"role_check_allowed", roleAllowed,
+	}
+	if !agrees {
+		a.logger.Warn("Policy decision differs from role check", fields...)
+	} else if a.decisionLog {
+		a.logger.Info("Authorization decision", fields...)
+	}
+}
+
+// attributesOf describes a request to the policies: the caller as subject.*,
+// the route's path parameters as resource.* and the request as context.*
+func attributesOf(c echo.Context, principal *Principal) map[string]string {
+	attributes := map[string]string{
+		"context.ip":     c.RealIP(),
+		"context.method": c.Request().Method,
+		"context.path":   c.Path(),
+	}
+	if region, ok := ports.ResidencyFrom(c.Request().Context()); ok {
+		attributes["context.residency"] = string(region)
+	}
+
+	for i, name := range c.ParamNames() {
+		attributes["resource."+name] = c.ParamValues()[i]
+	}
+
+	if principal != nil {
+		for name, value := range principal.Attributes {
+			attributes["subject."+name] = value
+		}
+		attributes["subject.name"] = principal.Name
+		attributes["subject.role"] = principal.Role
+	}
+	return attributes
+}
+
+func subjectName(principal *Principal) string {
+	if principal == nil {
+		return "anonymous"
+	}
+	return principal.Name
+}
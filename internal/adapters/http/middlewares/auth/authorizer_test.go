@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"user-service/internal/config"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+var testKeys = []config.APIKeyConfig{
+	{Name: "support-eu", Key: "support-key", Role: RoleSupport, Attributes: map[string]string{"region": "eu"}},
+	{Name: "ops", Key: "admin-key", Role: RoleAdmin},
+}
+
+// supportOnlyPolicies disagree with a role check allowing admins only
+var supportOnlyPolicies = []config.PolicyConfig{
+	{ID: "support-eu", Effect: "permit", Roles: []string{RoleSupport}, Actions: []string{"users.*"},
+		Conditions: []config.PolicyConditionConfig{{Attribute: "subject.region", Operator: "in", Values: []string{"eu"}}}},
+}
+
+func serve(authorizer *Authorizer, key string) error {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/users/1", nil)
+	if key != "" {
+		req.Header.Set(HeaderAPIKey, key)
+	}
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	handler := authorizer.Require("users.delete", RoleAdmin)(func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+	return handler(c)
+}
+
+func newTestAuthorizer(mode string, registry *metrics.Registry) *Authorizer {
+	cfg := config.AuthorizationConfig{Mode: mode, Policies: supportOnlyPolicies}
+	return NewAuthorizer(NewAuthenticator(testKeys), cfg, logger.New("test"), registry)
+}
+
+func TestAuthorizer_DryRunKeepsRoleCheck(t *testing.T) {
+	// Given policies that disagree with the role check
+	registry := metrics.NewRegistry()
+	authorizer := newTestAuthorizer(config.AuthorizationModeDryRun, registry)
+
+	// When support and admin callers make the request
+	supportErr := serve(authorizer, "support-key")
+	adminErr := serve(authorizer, "admin-key")
+
+	// Then the role check decides and the disagreements are counted
+	assert.Equal(t, domainErrors.ErrForbidden, supportErr)
+	assert.NoError(t, adminErr)
+	counter := registry.Counter("authorization_decisions_total")
+	assert.Equal(t, uint64(1), counter.Value("action", "users.delete", "mode", "dry_run", "allowed", "true", "agrees_with_roles", "false"))
+	assert.Equal(t, uint64(1), counter.Value("action", "users.delete", "mode", "dry_run", "allowed", "false", "agrees_with_roles", "false"))
+}
+
+func TestAuthorizer_EnforceLetsPoliciesDecide(t *testing.T) {
+	authorizer := newTestAuthorizer(config.AuthorizationModeEnforce, metrics.NewRegistry())
+
+	assert.NoError(t, serve(authorizer, "support-key"))
+	assert.Equal(t, domainErrors.ErrForbidden, serve(authorizer, "admin-key"))
+	assert.Equal(t, domainErrors.ErrUnauthorized, serve(authorizer, ""))
+}
+
+func TestAuthorizer_RBACIgnoresPolicies(t *testing.T) {
+	authorizer := newTestAuthorizer(config.AuthorizationModeRBAC, metrics.NewRegistry())
+
+	assert.Equal(t, domainErrors.ErrForbidden, serve(authorizer, "support-key"))
+	assert.NoError(t, serve(authorizer, "admin-key"))
+}
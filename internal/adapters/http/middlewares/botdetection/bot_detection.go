@@ -0,0 +1,251 @@
+package botdetection
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"user-service/internal/adapters/http/middlewares/auth"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ReputationChecker flags requests coming from known bad IPs or user agents
+type ReputationChecker interface {
+	Check(ctx context.Context, ip, userAgent string) (suspicious bool, reason string)
+}
+
+// Config configures the bot detection middleware
+type Config struct {
+	// HoneypotFields are hidden form fields real users never fill in
+	HoneypotFields []string
+	// TimestampField carries the time the form was rendered (unix seconds or
+	// RFC3339); forms submitted without it fail the MinSubmitTime check.
+	// Authenticated callers are API clients, not forms, and skip both traps.
+	TimestampField string
+	// MinSubmitTime is the fastest a human can plausibly submit the form
+	MinSubmitTime time.Duration
+	// Checkers are consulted for IP/user-agent reputation
+	Checkers []ReputationChecker
+	// Decoy produces the deceptive success response sent to detected bots
+	Decoy echo.HandlerFunc
+
+	Logger  logger.Logger
+	Metrics *metrics.Registry
+	Audit   ports.AuditLogger
+}
+
+// BotDetectionWithConfig returns a middleware that silently diverts suspected bots to a decoy response.
+// A missing User-Agent is only a signal: privacy tools and some clients strip
+// it, so it is counted and reported alongside a detection but diverts nothing
+// on its own.
+func BotDetectionWithConfig(config Config) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			var signals []string
+			if strings.TrimSpace(req.UserAgent()) == "" {
+				signals = append(signals, "missing_user_agent")
+			}
+			if config.Metrics != nil {
+				for _, signal := range signals {
+					config.Metrics.Counter("bot_signals_total").Inc("route", c.Path(), "signal", signal)
+				}
+			}
+
+			reason := ""
+			for _, checker := range config.Checkers {
+				if suspicious, why := checker.Check(req.Context(), c.RealIP(), req.UserAgent()); suspicious {
+					reason = why
+					break
+				}
+			}
+
+			// The trap fields belong to the sign-up form; API clients calling
+			// with a key never render it
+			formSubmission := auth.PrincipalFrom(c) == nil
+			if reason == "" && formSubmission && req.Body != nil && (len(config.HoneypotFields) > 0 || config.TimestampField != "") {
+				body, err := io.ReadAll(req.Body)
+				if err != nil {
+					return err
+				}
+
+				body, reason = config.inspectBody(body)
+				req.Body = io.NopCloser(bytes.NewReader(body))
+				req.ContentLength = int64(len(body))
+			}
+
+			if reason == "" {
+				return next(c)
+			}
+
+			config.flag(c, reason, signals)
+
+			if config.Decoy != nil {
+				return config.Decoy(c)
+			}
+			return next(c)
+		}
+	}
+}
+
+// inspectBody checks the bot trap fields and strips them so handlers never see them
+func (config Config) inspectBody(body []byte) ([]byte, string) {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return body, ""
+	}
+
+	reason := ""
+	stripped := false
+
+	for _, field := range config.HoneypotFields {
+		value, ok := payload[field]
+		if !ok {
+			continue
+		}
+		if s, isString := value.(string); !isString || strings.TrimSpace(s) != "" {
+			reason = "honeypot"
+		}
+		delete(payload, field)
+		stripped = true
+	}
+
+	if config.TimestampField != "" {
+		value, present := payload[config.TimestampField]
+		renderedAt, valid := parseTimestamp(value)
+		switch {
+		case reason != "":
+			// A filled honeypot already gave the request away
+		case !valid:
+			// Without a render time the form cannot be shown to be slow enough
+			reason = "missing_timestamp"
+		case time.Since(renderedAt) < config.MinSubmitTime:
+			reason = "submitted_too_fast"
+		}
+		if present {
+			delete(payload, config.TimestampField)
+			stripped = true
+		}
+	}
+
+	if !stripped {
+		return body, reason
+	}
+
+	cleaned, err := json.Marshal(payload)
+	if err != nil {
+		return body, reason
+	}
+	return cleaned, reason
+}
+
+func (config Config) flag(c echo.Context, reason string, signals []string) {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	if config.Logger != nil {
+		config.Logger.Warn("Suspected bot request diverted to decoy",
+			"request_id", requestID,
+			"reason", reason,
+			"signals", signals,
+			"path", c.Path(),
+			"remote_ip", c.RealIP(),
+			"user_agent", c.Request().UserAgent())
+	}
+
+	if config.Metrics != nil {
+		config.Metrics.Counter("bot_detections_total").Inc("route", c.Path(), "reason", reason)
+	}
+
+	if config.Audit != nil {
+		config.Audit.Record(c.Request().Context(), &entities.AuditEvent{
+			Action:    "bot.detected",
+			RequestID: requestID,
+			RemoteIP:  c.RealIP(),
+			Metadata: map[string]interface{}{
+				"reason":     reason,
+				"signals":    signals,
+				"route":      c.Path(),
+				"user_agent": c.Request().UserAgent(),
+			},
+		})
+	}
+}
+
+func parseTimestamp(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case float64:
+		return time.Unix(int64(v), 0), true
+	case string:
+		t, err := time.Parse(time.RFC3339, v)
+		return t, err == nil
+	default:
+		return time.Time{}, false
+	}
+}
+
+// StaticReputationChecker flags requests matching configured IPs, CIDRs or user-agent substrings
+type StaticReputationChecker struct {
+	userAgents []string
+	ips        []net.IP
+	networks   []*net.IPNet
+}
+
+// NewStaticReputationChecker creates a reputation checker from static deny lists
+func NewStaticReputationChecker(blockedUserAgents, blockedIPs []string) *StaticReputationChecker {
+	checker := &StaticReputationChecker{}
+
+	for _, ua := range blockedUserAgents {
+		if ua = strings.TrimSpace(ua); ua != "" {
+			checker.userAgents = append(checker.userAgents, strings.ToLower(ua))
+		}
+	}
+
+	for _, entry := range blockedIPs {
+		entry = strings.TrimSpace(entry)
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			checker.networks = append(checker.networks, network)
+		} else if ip := net.ParseIP(entry); ip != nil {
+			checker.ips = append(checker.ips, ip)
+		}
+	}
+
+	return checker
+}
+
+// Check implements ReputationChecker
+func (s *StaticReputationChecker) Check(ctx context.Context, ip, userAgent string) (bool, string) {
+	lowered := strings.ToLower(userAgent)
+	for _, ua := range s.userAgents {
+		if strings.Contains(lowered, ua) {
+			return true, "blocked_user_agent"
+		}
+	}
+
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false, ""
+	}
+
+	for _, blocked := range s.ips {
+		if blocked.Equal(parsed) {
+			return true, "blocked_ip"
+		}
+	}
+	for _, network := range s.networks {
+		if network.Contains(parsed) {
+			return true, "blocked_ip"
+		}
+	}
+
+	return false, ""
+}
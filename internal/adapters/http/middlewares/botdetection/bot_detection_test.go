@@ -0,0 +1,165 @@
+package botdetection
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"user-service/internal/adapters/http/middlewares/auth"
+	"user-service/internal/config"
+	"user-service/pkg/metrics"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBotDetection(body, userAgent string) (echo.Context, *httptest.ResponseRecorder, *metrics.Registry, echo.HandlerFunc, *string) {
+	registry := metrics.NewRegistry()
+	received := new(string)
+
+	next := func(c echo.Context) error {
+		b, _ := io.ReadAll(c.Request().Body)
+		*received = string(b)
+		return c.String(http.StatusCreated, "real")
+	}
+
+	handler := BotDetectionWithConfig(Config{
+		HoneypotFields: []string{"website"},
+		TimestampField: "form_rendered_at",
+		MinSubmitTime:  2 * time.Second,
+		Checkers:       []ReputationChecker{NewStaticReputationChecker([]string{"curl"}, []string{"10.0.0.0/8"})},
+		Decoy: func(c echo.Context) error {
+			return c.String(http.StatusCreated, "decoy")
+		},
+		Metrics: registry,
+	})(next)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("User-Agent", userAgent)
+	req.RemoteAddr = "192.0.2.1:1234"
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath("/api/v1/users")
+
+	return c, rec, registry, handler, received
+}
+
+func TestBotDetection_HumanRequestPassesThrough(t *testing.T) {
+	// Given
+	renderedAt := strconv.FormatInt(time.Now().Add(-10*time.Second).Unix(), 10)
+	body := `{"email":"john@example.com","website":"","form_rendered_at":` + renderedAt + `}`
+	c, rec, registry, handler, received := setupBotDetection(body, "Mozilla/5.0")
+
+	// When
+	err := handler(c)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "real", rec.Body.String())
+	assert.Equal(t, `{"email":"john@example.com"}`, *received, "trap fields should be stripped")
+	assert.Empty(t, registry.Snapshot())
+}
+
+func TestBotDetection_FilledHoneypotGetsDecoy(t *testing.T) {
+	// Given
+	c, rec, registry, handler, _ := setupBotDetection(`{"email":"bot@example.com","website":"http://spam"}`, "Mozilla/5.0")
+
+	// When
+	err := handler(c)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "decoy", rec.Body.String())
+	assert.Equal(t, uint64(1), registry.Counter("bot_detections_total").Value("route", "/api/v1/users", "reason", "honeypot"))
+}
+
+func TestBotDetection_SubmittedTooFastGetsDecoy(t *testing.T) {
+	// Given
+	body := `{"email":"bot@example.com","form_rendered_at":"` + time.Now().Format(time.RFC3339) + `"}`
+	c, rec, registry, handler, _ := setupBotDetection(body, "Mozilla/5.0")
+
+	// When
+	err := handler(c)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "decoy", rec.Body.String())
+	assert.Equal(t, uint64(1), registry.Counter("bot_detections_total").Value("route", "/api/v1/users", "reason", "submitted_too_fast"))
+}
+
+func TestBotDetection_MissingTimestampGetsDecoy(t *testing.T) {
+	// Given a form submitted without its render time
+	c, rec, registry, handler, _ := setupBotDetection(`{"email":"bot@example.com","website":""}`, "Mozilla/5.0")
+
+	// When
+	err := handler(c)
+
+	// Then it fails the minimum submit time check
+	require.NoError(t, err)
+	assert.Equal(t, "decoy", rec.Body.String())
+	assert.Equal(t, uint64(1), registry.Counter("bot_detections_total").Value("route", "/api/v1/users", "reason", "missing_timestamp"))
+}
+
+func TestBotDetection_APIClientsSkipFormTraps(t *testing.T) {
+	// Given an internal service creating a user without the form fields
+	c, rec, registry, handler, received := setupBotDetection(`{"email":"sync@example.com"}`, "user-sync/1.0")
+	c.Request().Header.Set(auth.HeaderAPIKey, "internal-key")
+	authenticator := auth.NewAuthenticator([]config.APIKeyConfig{{Name: "user-sync", Key: "internal-key", Role: auth.RoleInternal}})
+
+	// When
+	err := authenticator.Identify()(handler)(c)
+
+	// Then the request reaches the handler untouched
+	require.NoError(t, err)
+	assert.Equal(t, "real", rec.Body.String())
+	assert.Equal(t, `{"email":"sync@example.com"}`, *received)
+	assert.Empty(t, registry.Snapshot())
+}
+
+func TestBotDetection_MissingUserAgentAloneIsNotAVerdict(t *testing.T) {
+	// Given an otherwise human request without a User-Agent
+	renderedAt := strconv.FormatInt(time.Now().Add(-10*time.Second).Unix(), 10)
+	body := `{"email":"john@example.com","form_rendered_at":` + renderedAt + `}`
+	c, rec, registry, handler, _ := setupBotDetection(body, "")
+
+	// When
+	err := handler(c)
+
+	// Then it reaches the handler and the signal is only counted
+	require.NoError(t, err)
+	assert.Equal(t, "real", rec.Body.String())
+	assert.Equal(t, uint64(1), registry.Counter("bot_signals_total").Value("route", "/api/v1/users", "signal", "missing_user_agent"))
+	assert.Zero(t, registry.Counter("bot_detections_total").Value("route", "/api/v1/users", "reason", "missing_user_agent"))
+}
+
+func TestStaticReputationChecker(t *testing.T) {
+	checker := NewStaticReputationChecker([]string{"python-requests"}, []string{"203.0.113.7", "10.0.0.0/8"})
+
+	tests := []struct {
+		name       string
+		ip         string
+		userAgent  string
+		suspicious bool
+		reason     string
+	}{
+		{"clean request", "192.0.2.1", "Mozilla/5.0", false, ""},
+		{"missing user agent", "192.0.2.1", "", false, ""},
+		{"blocked user agent", "192.0.2.1", "Python-Requests/2.31", true, "blocked_user_agent"},
+		{"blocked ip", "203.0.113.7", "Mozilla/5.0", true, "blocked_ip"},
+		{"blocked cidr", "10.1.2.3", "Mozilla/5.0", true, "blocked_ip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suspicious, reason := checker.Check(t.Context(), tt.ip, tt.userAgent)
+			assert.Equal(t, tt.suspicious, suspicious)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+}
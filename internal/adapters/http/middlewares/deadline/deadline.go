@@ -0,0 +1,43 @@
+package deadline
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/deadline"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Deadline derives the request context deadline from the budget the caller
+// sends in X-Request-Timeout or grpc-timeout, capped at maxTimeout. Requests
+// arriving with no budget left are rejected without being handled.
+func Deadline(maxTimeout time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			budget, ok, err := deadline.FromHeader(req.Header)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid request timeout: "+err.Error())
+			}
+			if !ok {
+				return next(c)
+			}
+			if budget <= 0 {
+				return domainErrors.ErrDeadlineExceeded
+			}
+			if maxTimeout > 0 && budget > maxTimeout {
+				budget = maxTimeout
+			}
+
+			ctx, cancel := context.WithTimeout(req.Context(), budget)
+			defer cancel()
+
+			c.SetRequest(req.WithContext(ctx))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package deadline
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	domainErrors "user-service/internal/domain/errors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serve runs the middleware and returns the budget the handler saw
+func serve(t *testing.T, header, value string, maxTimeout time.Duration) (time.Duration, bool, error) {
+	t.Helper()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/1", nil)
+	if header != "" {
+		req.Header.Set(header, value)
+	}
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	var budget time.Duration
+	var hasDeadline bool
+	err := Deadline(maxTimeout)(func(c echo.Context) error {
+		var deadline time.Time
+		deadline, hasDeadline = c.Request().Context().Deadline()
+		budget = time.Until(deadline)
+		return nil
+	})(c)
+	return budget, hasDeadline, err
+}
+
+func TestDeadline_DerivesContextDeadline(t *testing.T) {
+	budget, ok, err := serve(t, "X-Request-Timeout", "500ms", 30*time.Second)
+
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.InDelta(t, 500*time.Millisecond, budget, float64(50*time.Millisecond))
+}
+
+func TestDeadline_HonorsGRPCTimeout(t *testing.T) {
+	budget, ok, err := serve(t, "Grpc-Timeout", "2S", 30*time.Second)
+
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.InDelta(t, 2*time.Second, budget, float64(50*time.Millisecond))
+}
+
+func TestDeadline_CapsBudget(t *testing.T) {
+	budget, _, err := serve(t, "X-Request-Timeout", "10m", time.Second)
+
+	require.NoError(t, err)
+	assert.InDelta(t, time.Second, budget, float64(50*time.Millisecond))
+}
+
+func TestDeadline_WithoutHeaderLeavesContextAlone(t *testing.T) {
+	_, ok, err := serve(t, "", "", time.Second)
+
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestDeadline_RejectsExhaustedBudget(t *testing.T) {
+	_, _, err := serve(t, "X-Request-Timeout", "0", time.Second)
+
+	assert.ErrorIs(t, err, domainErrors.ErrDeadlineExceeded)
+}
+
+func TestDeadline_RejectsMalformedHeader(t *testing.T) {
+	_, _, err := serve(t, "X-Request-Timeout", "soon", time.Second)
+
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
@@ -0,0 +1,33 @@
+package degradation
+
+import (
+	"fmt"
+
+	"user-service/internal/application/ports"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderWarning carries one warning per feature a response was served without
+const HeaderWarning = "Warning"
+
+// Warnings tracks the optional features each request has to do without (see
+// ports.NoteDegraded) and adds a Warning header per feature to its response,
+// e.g. `199 user-service "degraded mode: cache unavailable"`. Reasons stay in
+// the logs and health endpoints, as they may name internal hosts.
+func Warnings() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := ports.WithDegradations(c.Request().Context())
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			res := c.Response()
+			res.Before(func() {
+				for _, degradation := range ports.DegradationsFrom(ctx) {
+					res.Header().Add(HeaderWarning, fmt.Sprintf(`199 user-service "degraded mode: %s unavailable"`, degradation.Feature))
+				}
+			})
+			return next(c)
+		}
+	}
+}
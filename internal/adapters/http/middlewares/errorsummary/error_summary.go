@@ -0,0 +1,168 @@
+package errorsummary
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"user-service/internal/application/dto"
+	"user-service/internal/config"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// buckets is how many slices the window is counted in; codes leave the
+	// counts one slice at a time
+	buckets = 60
+
+	// unknownCode names 5xx responses written without an error body
+	unknownCode = "UNKNOWN"
+)
+
+// recentError is one recorded 5xx response
+type recentError struct {
+	at        time.Time
+	method    string
+	route     string
+	status    int
+	code      string
+	requestID string
+}
+
+// bucket counts the error codes recorded in one slice of the window
+type bucket struct {
+	start  time.Time
+	counts map[string]int
+}
+
+// Recorder keeps a rolling summary of the 5xx responses of this instance: the
+// latest ones in a ring and per-code counts over a window. Memory is bounded
+// by the configuration, however many errors are recorded.
+type Recorder struct {
+	config config.ErrorSummaryConfig
+	// codeOf returns the error code of the response written for a request
+	codeOf func(echo.Context) string
+	width  time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	recent  []recentError
+	next    int
+	buckets [buckets]bucket
+}
+
+// NewRecorder creates a recorder reading error codes with codeOf
+func NewRecorder(cfg config.ErrorSummaryConfig, codeOf func(echo.Context) string) *Recorder {
+	return &Recorder{
+		config: cfg,
+		codeOf: codeOf,
+		width:  cfg.Window / buckets,
+		now:    time.Now,
+		recent: make([]recentError, 0, cfg.RecentErrors),
+	}
+}
+
+// Middleware records every 5xx response once it is written, including the
+// ones the HTTP error handler writes after the middleware chain returned
+func (r *Recorder) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			res := c.Response()
+			res.After(func() {
+				if res.Status < http.StatusInternalServerError {
+					return
+				}
+				r.record(recentError{
+					method:    c.Request().Method,
+					route:     c.Path(),
+					status:    res.Status,
+					code:      r.codeOf(c),
+					requestID: res.Header().Get(echo.HeaderXRequestID),
+				})
+			})
+			return next(c)
+		}
+	}
+}
+
+func (r *Recorder) record(entry recentError) {
+	entry.at = r.now().UTC()
+	if entry.code == "" {
+		entry.code = unknownCode
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.recent) < cap(r.recent) {
+		r.recent = append(r.recent, entry)
+	} else {
+		r.recent[r.next] = entry
+	}
+	r.next = (r.next + 1) % cap(r.recent)
+
+	start := entry.at.Truncate(r.width)
+	b := &r.buckets[start.UnixNano()/int64(r.width)%buckets]
+	if !b.start.Equal(start) {
+		*b = bucket{start: start, counts: make(map[string]int)}
+	}
+	b.counts[entry.code]++
+}
+
+// Summary returns the latest 5xx responses, newest first, and the most
+// frequent error codes within the window
+func (r *Recorder) Summary() dto.ErrorSummaryResponseDTO {
+	now := r.now().UTC()
+	oldest := now.Add(-r.config.Window)
+
+	r.mu.Lock()
+	recent := make([]dto.RecentErrorDTO, 0, len(r.recent))
+	for i := 1; i <= len(r.recent); i++ {
+		entry := r.recent[(r.next-i+cap(r.recent))%cap(r.recent)]
+		recent = append(recent, dto.RecentErrorDTO{
+			Time:      dto.NewTimestamp(entry.at),
+			Method:    entry.method,
+			Route:     entry.route,
+			Status:    entry.status,
+			ErrorCode: entry.code,
+			RequestID: entry.requestID,
+		})
+	}
+
+	counts := make(map[string]int)
+	total := 0
+	for _, b := range r.buckets {
+		if !b.start.After(oldest) {
+			continue
+		}
+		for code, count := range b.counts {
+			counts[code] += count
+			total += count
+		}
+	}
+	r.mu.Unlock()
+
+	topCodes := make([]dto.ErrorCodeCountDTO, 0, len(counts))
+	for code, count := range counts {
+		topCodes = append(topCodes, dto.ErrorCodeCountDTO{ErrorCode: code, Count: count})
+	}
+	sort.Slice(topCodes, func(i, j int) bool {
+		if topCodes[i].Count != topCodes[j].Count {
+			return topCodes[i].Count > topCodes[j].Count
+		}
+		return topCodes[i].ErrorCode < topCodes[j].ErrorCode
+	})
+	if len(topCodes) > r.config.TopCodes {
+		topCodes = topCodes[:r.config.TopCodes]
+	}
+
+	return dto.ErrorSummaryResponseDTO{
+		Recent:        recent,
+		TopCodes:      topCodes,
+		WindowSeconds: int(r.config.Window / time.Second),
+		Total:         total,
+		GeneratedAt:   dto.NewTimestamp(now),
+	}
+}
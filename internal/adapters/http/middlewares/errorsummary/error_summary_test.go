@@ -0,0 +1,112 @@
+package errorsummary
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"user-service/internal/adapters/http/handlers"
+	"user-service/internal/config"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTestRecorder serves routes failing with the error named by the path
+func setupTestRecorder(recentErrors int, now *time.Time) (*echo.Echo, *Recorder) {
+	recorder := NewRecorder(config.ErrorSummaryConfig{RecentErrors: recentErrors, Window: time.Hour, TopCodes: 2}, handlers.ErrorCodeFrom)
+	recorder.now = func() time.Time { return *now }
+
+	e := echo.New()
+	e.HTTPErrorHandler = handlers.NewHTTPErrorHandler(logger.New("test"))
+	e.Use(recorder.Middleware())
+	e.GET("/users/:id", func(c echo.Context) error {
+		c.Response().Header().Set(echo.HeaderXRequestID, "req-"+c.Param("id"))
+		switch c.QueryParam("fail") {
+		case "database":
+			return domainErrors.ErrFailedToListUsers
+		case "unexpected":
+			return errors.New("unexpected")
+		case "missing":
+			return domainErrors.ErrUserNotFound
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+	return e, recorder
+}
+
+func serve(e *echo.Echo, target string) {
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+}
+
+func TestRecorder_RecordsServerErrors(t *testing.T) {
+	// Given
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	e, recorder := setupTestRecorder(10, &now)
+
+	// When a server error, a client error and a success are answered
+	serve(e, "/users/7?fail=database")
+	serve(e, "/users/8?fail=missing")
+	serve(e, "/users/9")
+
+	// Then only the server error is recorded, by route template
+	summary := recorder.Summary()
+	require.Len(t, summary.Recent, 1)
+	recent := summary.Recent[0]
+	assert.Equal(t, now, recent.Time.Time)
+	assert.Equal(t, http.MethodGet, recent.Method)
+	assert.Equal(t, "/users/:id", recent.Route)
+	assert.Equal(t, http.StatusServiceUnavailable, recent.Status)
+	assert.Equal(t, domainErrors.ErrFailedToListUsers.Code, recent.ErrorCode)
+	assert.Equal(t, "req-7", recent.RequestID)
+	assert.Equal(t, 1, summary.Total)
+	assert.Equal(t, 3600, summary.WindowSeconds)
+}
+
+func TestRecorder_KeepsLatestErrorsNewestFirst(t *testing.T) {
+	// Given a recorder keeping two errors
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	e, recorder := setupTestRecorder(2, &now)
+
+	// When three are answered
+	for _, id := range []string{"1", "2", "3"} {
+		serve(e, "/users/"+id+"?fail=database")
+		now = now.Add(time.Second)
+	}
+
+	// Then the oldest is dropped but still counted
+	summary := recorder.Summary()
+	require.Len(t, summary.Recent, 2)
+	assert.Equal(t, "req-3", summary.Recent[0].RequestID)
+	assert.Equal(t, "req-2", summary.Recent[1].RequestID)
+	assert.Equal(t, 3, summary.Total)
+}
+
+func TestRecorder_RanksCodesWithinWindow(t *testing.T) {
+	// Given errors from before the window
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	e, recorder := setupTestRecorder(10, &now)
+	serve(e, "/users/1?fail=unexpected")
+	serve(e, "/users/1?fail=unexpected")
+	serve(e, "/users/1?fail=unexpected")
+	now = now.Add(90 * time.Minute)
+
+	// When others follow within it
+	serve(e, "/users/1?fail=database")
+	serve(e, "/users/1?fail=database")
+	serve(e, "/users/1?fail=unexpected")
+
+	// Then only those are ranked
+	summary := recorder.Summary()
+	require.Len(t, summary.TopCodes, 2)
+	assert.Equal(t, domainErrors.ErrFailedToListUsers.Code, summary.TopCodes[0].ErrorCode)
+	assert.Equal(t, 2, summary.TopCodes[0].Count)
+	assert.Equal(t, "INTERNAL_ERROR", summary.TopCodes[1].ErrorCode)
+	assert.Equal(t, 1, summary.TopCodes[1].Count)
+	assert.Equal(t, 3, summary.Total)
+}
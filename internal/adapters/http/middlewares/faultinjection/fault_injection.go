@@ -0,0 +1,132 @@
+package faultinjection
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"user-service/internal/config"
+	"user-service/pkg/chaos"
+	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderLatency = "X-Chaos-Latency"
+	HeaderError   = "X-Chaos-Error"
+	HeaderDropDB  = "X-Chaos-Drop-DB"
+)
+
+// fault is the set of faults to inject into a single request
+type fault struct {
+	latency     time.Duration
+	errorStatus int
+	dropDB      bool
+}
+
+func (f fault) empty() bool {
+	return f.latency == 0 && f.errorStatus == 0 && !f.dropDB
+}
+
+// FaultInjection returns a middleware that injects latency, errors and dropped
+// database connections according to the configured rules and, when allowed,
+// X-Chaos-* request headers. It must never be installed in production.
+func FaultInjection(cfg config.ChaosConfig, log logger.Logger, registry *metrics.Registry) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			f := fromRules(cfg.Rules, c.Request().Method, c.Path())
+			if cfg.AllowHeaders {
+				f = f.merge(fromHeaders(c.Request().Header))
+			}
+
+			if f.empty() {
+				return next(c)
+			}
+
+			requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+			if f.latency > 0 {
+				registry.Counter("chaos_faults_injected_total").Inc("type", "latency", "route", c.Path())
+				select {
+				case <-time.After(f.latency):
+				case <-c.Request().Context().Done():
+					return c.Request().Context().Err()
+				}
+			}
+
+			if f.errorStatus > 0 {
+				registry.Counter("chaos_faults_injected_total").Inc("type", "error", "route", c.Path())
+				log.Warn("Injecting error response",
+					"request_id", requestID,
+					"route", c.Path(),
+					"status", f.errorStatus)
+				return echo.NewHTTPError(f.errorStatus, "Fault injected for resilience testing")
+			}
+
+			if f.dropDB {
+				registry.Counter("chaos_faults_injected_total").Inc("type", "drop_db", "route", c.Path())
+				log.Warn("Injecting dropped database connection",
+					"request_id", requestID,
+					"route", c.Path())
+				c.SetRequest(c.Request().WithContext(chaos.WithDroppedDB(c.Request().Context())))
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func fromRules(rules []config.ChaosRuleConfig, method, route string) fault {
+	var f fault
+	for _, rule := range rules {
+		if rule.Route != route || (rule.Method != "" && !strings.EqualFold(rule.Method, method)) {
+			continue
+		}
+
+		f.latency = rule.Latency
+		if rule.ErrorRate > 0 && rand.Float64() < rule.ErrorRate {
+			f.errorStatus = rule.ErrorStatus
+			if f.errorStatus == 0 {
+				f.errorStatus = http.StatusServiceUnavailable
+			}
+		}
+		if rule.DropDBRate > 0 && rand.Float64() < rule.DropDBRate {
+			f.dropDB = true
+		}
+		break
+	}
+	return f
+}
+
+func fromHeaders(header http.Header) fault {
+	var f fault
+
+	if latency, err := time.ParseDuration(header.Get(HeaderLatency)); err == nil && latency > 0 {
+		f.latency = min(latency, 30*time.Second)
+	}
+
+	if status, err := strconv.Atoi(header.Get(HeaderError)); err == nil && status >= 400 && status <= 599 {
+		f.errorStatus = status
+	}
+
+	if drop, err := strconv.ParseBool(header.Get(HeaderDropDB)); err == nil {
+		f.dropDB = drop
+	}
+
+	return f
+}
+
+func (f fault) merge(other fault) fault {
+	if other.latency > 0 {
+		f.latency = other.latency
+	}
+	if other.errorStatus > 0 {
+		f.errorStatus = other.errorStatus
+	}
+	f.dropDB = f.dropDB || other.dropDB
+	return f
+}
@@ -0,0 +1,96 @@
+package faultinjection
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"user-service/internal/config"
+	"user-service/pkg/chaos"
+	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runFaultInjection(t *testing.T, cfg config.ChaosConfig, headers map[string]string) (*httptest.ResponseRecorder, bool, bool) {
+	rec, called, droppedDB, err := runFaultInjectionWithError(cfg, headers)
+	require.NoError(t, err)
+	return rec, called, droppedDB
+}
+
+func runFaultInjectionWithError(cfg config.ChaosConfig, headers map[string]string) (*httptest.ResponseRecorder, bool, bool, error) {
+	called := false
+	droppedDB := false
+
+	next := func(c echo.Context) error {
+		called = true
+		droppedDB = chaos.DroppedDB(c.Request().Context())
+		return c.NoContent(http.StatusOK)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/1", nil)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath("/api/v1/users/:id")
+
+	err := FaultInjection(cfg, logger.New("test"), metrics.NewRegistry())(next)(c)
+
+	return rec, called, droppedDB, err
+}
+
+func TestFaultInjection_NoFaultsPassesThrough(t *testing.T) {
+	rec, called, droppedDB := runFaultInjection(t, config.ChaosConfig{Enabled: true}, map[string]string{
+		HeaderError: "503",
+	})
+
+	assert.True(t, called, "headers are ignored unless allowed")
+	assert.False(t, droppedDB)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestFaultInjection_HeaderTriggeredError(t *testing.T) {
+	_, called, _, err := runFaultInjectionWithError(config.ChaosConfig{Enabled: true, AllowHeaders: true}, map[string]string{
+		HeaderError: "503",
+	})
+
+	assert.False(t, called)
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusServiceUnavailable, httpErr.Code)
+}
+
+func TestFaultInjection_HeaderTriggeredLatencyAndDroppedDB(t *testing.T) {
+	start := time.Now()
+	rec, called, droppedDB := runFaultInjection(t, config.ChaosConfig{Enabled: true, AllowHeaders: true}, map[string]string{
+		HeaderLatency: "50ms",
+		HeaderDropDB:  "true",
+	})
+
+	assert.True(t, called)
+	assert.True(t, droppedDB)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestFaultInjection_RuleMatchesRoute(t *testing.T) {
+	cfg := config.ChaosConfig{
+		Enabled: true,
+		Rules: []config.ChaosRuleConfig{
+			{Method: "POST", Route: "/api/v1/users/:id", ErrorRate: 1},
+			{Method: "GET", Route: "/api/v1/users/:id", ErrorRate: 1, ErrorStatus: http.StatusBadGateway},
+		},
+	}
+
+	_, called, _, err := runFaultInjectionWithError(cfg, nil)
+
+	assert.False(t, called)
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadGateway, httpErr.Code)
+}
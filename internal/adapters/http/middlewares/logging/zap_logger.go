@@ -7,7 +7,9 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
-func ZapLogger(logger logger.Logger) echo.MiddlewareFunc {
+// ZapLogger writes one access log entry per request. Email addresses in the
+// URI are masked so lookups by email do not leak them into the logs.
+func ZapLogger(log logger.Logger) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			start := time.Now()
@@ -35,7 +37,7 @@ func ZapLogger(logger logger.Logger) echo.MiddlewareFunc {
 				"remote_ip", c.RealIP(),
 				"host", req.Host,
 				"method", req.Method,
-				"uri", req.RequestURI,
+				"uri", logger.MaskEmailsInURI(req.RequestURI),
 				"user_agent", req.UserAgent(),
 				"status", status,
 				"latency", latency.Nanoseconds(),
@@ -52,13 +54,13 @@ func ZapLogger(logger logger.Logger) echo.MiddlewareFunc {
 			// Log based on status code
 			switch {
 			case status >= 500:
-				logger.Error("HTTP request completed", fields...)
+				log.Error("HTTP request completed", fields...)
 			case status >= 400:
-				logger.Warn("HTTP request completed", fields...)
+				log.Warn("HTTP request completed", fields...)
 			case status >= 300:
-				logger.Info("HTTP request completed", fields...)
+				log.Info("HTTP request completed", fields...)
 			default:
-				logger.Debug("HTTP request completed", fields...)
+				log.Debug("HTTP request completed", fields...)
 			}
 
 			return nil
@@ -0,0 +1,56 @@
+package metering
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"user-service/internal/adapters/http/middlewares/auth"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+
+	"github.com/labstack/echo/v4"
+)
+
+// userRoute marks the routes addressing one user by ID
+const userRoute = "/users/:id"
+
+// Recorder counts the calls of tenants
+type Recorder interface {
+	// Record counts one call of tenant, addressing the user userID of region
+	// unless userID is 0
+	Record(tenant string, region entities.Residency, userID uint)
+}
+
+// Meter counts every call of an identified tenant once its response is
+// written, including responses the HTTP error handler writes. Anonymous calls
+// and server errors are not metered. A call to a route under /users/:id
+// makes that user active for the tenant, in the region the call was scoped to.
+func Meter(recorder Recorder) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			res := c.Response()
+			res.After(func() {
+				principal := auth.PrincipalFrom(c)
+				if principal == nil || principal.Tenant == "" || res.Status >= http.StatusInternalServerError {
+					return
+				}
+				region, _ := ports.ResidencyFrom(c.Request().Context())
+				recorder.Record(principal.Tenant, region, userOf(c))
+			})
+			return next(c)
+		}
+	}
+}
+
+// userOf returns the user a call addresses, or 0
+func userOf(c echo.Context) uint {
+	if !strings.Contains(c.Path(), userRoute) {
+		return 0
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint(id)
+}
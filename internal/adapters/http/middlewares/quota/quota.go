@@ -0,0 +1,129 @@
+package quota
+
+import (
+	"fmt"
+	"strconv"
+
+	"user-service/internal/adapters/http/middlewares/auth"
+	"user-service/internal/config"
+	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	roleAnonymous = "anonymous"
+
+	// HeaderPageSizeLimit tells clients the page size cap applied to their request
+	HeaderPageSizeLimit = "X-Page-Size-Limit"
+
+	defaultMaxPageSize = 100
+)
+
+// Limiter enforces per-role page size and export row quotas
+type Limiter struct {
+	config  config.QuotaConfig
+	logger  logger.Logger
+	metrics *metrics.Registry
+}
+
+// NewLimiter creates a quota limiter
+func NewLimiter(cfg config.QuotaConfig, log logger.Logger, registry *metrics.Registry) *Limiter {
+	return &Limiter{
+		config:  cfg,
+		logger:  log.With("component", "quota"),
+		metrics: registry,
+	}
+}
+
+// PageSize returns a middleware that caps the page_size query parameter to the
+// caller's quota before the handler reads it. Oversized requests are clamped,
+// unless an admin explicitly acknowledges them with the override parameter.
+func (l *Limiter) PageSize() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			query := req.URL.Query()
+
+			requested, err := strconv.Atoi(query.Get("page_size"))
+			if err != nil || requested < 1 {
+				return next(c)
+			}
+
+			effective := l.resolve(c, "page_size", requested, l.config.MaxPageSize, l.config.OverrideMaxPageSize)
+			if effective != requested {
+				query.Set("page_size", strconv.Itoa(effective))
+				req.URL.RawQuery = query.Encode()
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// MaxPageSize returns the caller's page size cap, as PageSize applies it
+// without the admin override
+func (l *Limiter) MaxPageSize(c echo.Context) int {
+	role := roleAnonymous
+	if principal := auth.PrincipalFrom(c); principal != nil {
+		role = principal.Role
+	}
+	return l.limitFor(role, l.config.MaxPageSize)
+}
+
+// ExportRows returns how many rows the caller may export for the requested amount
+func (l *Limiter) ExportRows(c echo.Context, requested int) int {
+	return l.resolve(c, "export_rows", requested, l.config.MaxExportRows, l.config.OverrideMaxExportRows)
+}
+
+func (l *Limiter) resolve(c echo.Context, kind string, requested int, limits map[string]int, overrideMax int) int {
+	role := roleAnonymous
+	principal := auth.PrincipalFrom(c)
+	if principal != nil {
+		role = principal.Role
+	}
+
+	limit := l.limitFor(role, limits)
+	if requested <= limit {
+		return requested
+	}
+
+	outcome := "clamped"
+	effective := limit
+
+	if principal.HasRole(auth.RoleAdmin) && c.Request().URL.Query().Get(l.config.OverrideParam) == "true" {
+		outcome = "overridden"
+		effective = requested
+		if overrideMax > 0 && effective > overrideMax {
+			effective = overrideMax
+		}
+	}
+
+	if effective < requested {
+		c.Response().Header().Set(HeaderPageSizeLimit, strconv.Itoa(effective))
+		c.Response().Header().Add("Warning", fmt.Sprintf(`299 - "%s reduced from %d to %d"`, kind, requested, effective))
+	}
+
+	l.metrics.Counter("oversized_requests_total").Inc("kind", kind, "role", role, "route", c.Path(), "outcome", outcome)
+	l.logger.Warn("Oversized request",
+		"request_id", c.Response().Header().Get(echo.HeaderXRequestID),
+		"kind", kind,
+		"role", role,
+		"route", c.Path(),
+		"requested", requested,
+		"effective", effective,
+		"outcome", outcome)
+
+	return effective
+}
+
+func (l *Limiter) limitFor(role string, limits map[string]int) int {
+	if limit, ok := limits[role]; ok {
+		return limit
+	}
+	if limit, ok := limits[roleAnonymous]; ok {
+		return limit
+	}
+	return defaultMaxPageSize
+}
@@ -0,0 +1,136 @@
+package quota
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"user-service/internal/adapters/http/middlewares/auth"
+	"user-service/internal/config"
+	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runPageSizeQuota(t *testing.T, target, apiKey string) (string, *httptest.ResponseRecorder, *metrics.Registry) {
+	registry := metrics.NewRegistry()
+	limiter := NewLimiter(config.QuotaConfig{
+		MaxPageSize:         map[string]int{"anonymous": 100, "admin": 500},
+		OverrideParam:       "acknowledge_large_request",
+		OverrideMaxPageSize: 2000,
+	}, logger.New("test"), registry)
+
+	authenticator := auth.NewAuthenticator([]config.APIKeyConfig{{Name: "ops", Key: "admin-key", Role: auth.RoleAdmin}})
+
+	var pageSize string
+	next := func(c echo.Context) error {
+		pageSize = c.QueryParam("page_size")
+		return c.NoContent(http.StatusOK)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if apiKey != "" {
+		req.Header.Set(auth.HeaderAPIKey, apiKey)
+	}
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath("/api/v1/users")
+
+	err := authenticator.Identify()(limiter.PageSize()(next))(c)
+	require.NoError(t, err)
+
+	return pageSize, rec, registry
+}
+
+func TestPageSizeQuota_WithinLimit(t *testing.T) {
+	pageSize, rec, registry := runPageSizeQuota(t, "/api/v1/users?page_size=50", "")
+
+	assert.Equal(t, "50", pageSize)
+	assert.Empty(t, rec.Header().Get(HeaderPageSizeLimit))
+	assert.Empty(t, registry.Snapshot())
+}
+
+func TestPageSizeQuota_AnonymousIsClamped(t *testing.T) {
+	pageSize, rec, registry := runPageSizeQuota(t, "/api/v1/users?page_size=1000&acknowledge_large_request=true", "")
+
+	assert.Equal(t, "100", pageSize)
+	assert.Equal(t, "100", rec.Header().Get(HeaderPageSizeLimit))
+	assert.Equal(t, uint64(1), registry.Counter("oversized_requests_total").Value(
+		"kind", "page_size", "role", "anonymous", "route", "/api/v1/users", "outcome", "clamped"))
+}
+
+func TestPageSizeQuota_AdminWithoutAcknowledgmentIsClamped(t *testing.T) {
+	pageSize, _, _ := runPageSizeQuota(t, "/api/v1/users?page_size=1000", "admin-key")
+
+	assert.Equal(t, "500", pageSize)
+}
+
+func TestPageSizeQuota_AdminOverride(t *testing.T) {
+	pageSize, rec, registry := runPageSizeQuota(t, "/api/v1/users?page_size=1000&acknowledge_large_request=true", "admin-key")
+
+	assert.Equal(t, "1000", pageSize)
+	assert.Empty(t, rec.Header().Get(HeaderPageSizeLimit))
+	assert.Equal(t, uint64(1), registry.Counter("oversized_requests_total").Value(
+		"kind", "page_size", "role", "admin", "route", "/api/v1/users", "outcome", "overridden"))
+}
+
+func TestPageSizeQuota_AdminOverrideHasHardCeiling(t *testing.T) {
+	pageSize, rec, _ := runPageSizeQuota(t, "/api/v1/users?page_size=100000&acknowledge_large_request=true", "admin-key")
+
+	assert.Equal(t, "2000", pageSize)
+	assert.Equal(t, "2000", rec.Header().Get(HeaderPageSizeLimit))
+}
+
+func TestLimiter_MaxPageSizeIsPerRole(t *testing.T) {
+	limiter := NewLimiter(config.QuotaConfig{MaxPageSize: map[string]int{"anonymous": 100, "admin": 500}}, logger.New("test"), metrics.NewRegistry())
+	authenticator := auth.NewAuthenticator([]config.APIKeyConfig{{Name: "ops", Key: "admin-key", Role: auth.RoleAdmin}})
+
+	maxPageSize := func(apiKey string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/internal/capabilities", nil)
+		if apiKey != "" {
+			req.Header.Set(auth.HeaderAPIKey, apiKey)
+		}
+		var limit int
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+		require.NoError(t, authenticator.Identify()(func(c echo.Context) error {
+			limit = limiter.MaxPageSize(c)
+			return nil
+		})(c))
+		return limit
+	}
+
+	assert.Equal(t, 100, maxPageSize(""))
+	assert.Equal(t, 500, maxPageSize("admin-key"))
+}
+
+func TestLimiter_ExportRowsIsPerRole(t *testing.T) {
+	// Given
+	limiter := NewLimiter(config.QuotaConfig{
+		MaxExportRows:         map[string]int{"anonymous": 0, "admin": 50000},
+		OverrideParam:         "acknowledge_large_request",
+		OverrideMaxExportRows: 100000,
+	}, logger.New("test"), metrics.NewRegistry())
+	authenticator := auth.NewAuthenticator([]config.APIKeyConfig{{Name: "ops", Key: "admin-key", Role: auth.RoleAdmin}})
+
+	exportRows := func(target, apiKey string, requested int) int {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if apiKey != "" {
+			req.Header.Set(auth.HeaderAPIKey, apiKey)
+		}
+		var rows int
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+		require.NoError(t, authenticator.Identify()(func(c echo.Context) error {
+			rows = limiter.ExportRows(c, requested)
+			return nil
+		})(c))
+		return rows
+	}
+
+	// Then anonymous callers export nothing and admins up to their quota,
+	// or the hard ceiling when they acknowledge a larger export
+	assert.Equal(t, 0, exportRows("/export", "", 10))
+	assert.Equal(t, 50000, exportRows("/export", "admin-key", 200000))
+	assert.Equal(t, 100000, exportRows("/export?acknowledge_large_request=true", "admin-key", 200000))
+}
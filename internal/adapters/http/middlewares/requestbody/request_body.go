@@ -0,0 +1,111 @@
+package requestbody
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+
+	"user-service/internal/config"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Enforce returns a middleware that applies the route's body size limit and,
+// when configured, only accepts UTF-8 application/json bodies on POST, PUT and
+// PATCH. It must run after routing so the route pattern is known.
+func Enforce(cfg config.RequestBodyConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if !hasBody(req) {
+				return next(c)
+			}
+
+			if cfg.RequireJSON && mutating(req.Method) {
+				if err := checkContentType(req.Header, slices.Contains(cfg.FormRoutes, c.Path())); err != nil {
+					return err
+				}
+			}
+
+			maxSizeKB := maxSizeFor(cfg, req.Method, c.Path())
+			if maxSizeKB <= 0 {
+				return next(c)
+			}
+
+			limit := int64(maxSizeKB) * 1024
+			if req.ContentLength > limit {
+				return tooLarge(maxSizeKB)
+			}
+			req.Body = &limitedBody{ReadCloser: req.Body, remaining: limit, maxSizeKB: maxSizeKB}
+
+			return next(c)
+		}
+	}
+}
+
+func hasBody(req *http.Request) bool {
+	return req.Body != nil && req.Body != http.NoBody && req.ContentLength != 0
+}
+
+func mutating(method string) bool {
+	return method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch
+}
+
+// checkContentType accepts a single application/json Content-Type, or a form
+// one when allowForm is set, whose only parameter is a UTF-8 charset. Other
+// charsets and parameters are rejected so the bytes the decoder sees are the
+// bytes upstream filters inspected.
+func checkContentType(header http.Header, allowForm bool) error {
+	values := header.Values(echo.HeaderContentType)
+	if len(values) != 1 {
+		return unsupported()
+	}
+
+	mediaType, params, err := mime.ParseMediaType(values[0])
+	if err != nil || (mediaType != echo.MIMEApplicationJSON && !(allowForm && mediaType == echo.MIMEApplicationForm)) {
+		return unsupported()
+	}
+	for name, value := range params {
+		if name != "charset" || !strings.EqualFold(value, "utf-8") {
+			return unsupported()
+		}
+	}
+	return nil
+}
+
+func maxSizeFor(cfg config.RequestBodyConfig, method, route string) int {
+	for _, rule := range cfg.Routes {
+		if rule.Route == route && (rule.Method == "" || strings.EqualFold(rule.Method, method)) {
+			return rule.MaxSizeKB
+		}
+	}
+	return cfg.MaxSizeKB
+}
+
+func unsupported() error {
+	return echo.NewHTTPError(http.StatusUnsupportedMediaType, "Content-Type must be application/json with a UTF-8 charset")
+}
+
+func tooLarge(maxSizeKB int) error {
+	return echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body exceeds %d KB", maxSizeKB))
+}
+
+// limitedBody fails reads once more than the limit has been read, for bodies
+// whose length was not declared up front
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	maxSizeKB int
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n, tooLarge(b.maxSizeKB)
+	}
+	return n, err
+}
@@ -0,0 +1,133 @@
+package requestbody
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"user-service/internal/config"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = config.RequestBodyConfig{
+	MaxSizeKB:   1,
+	RequireJSON: true,
+	FormRoutes:  []string{"/oauth/token"},
+	Routes: []config.RequestBodyRouteConfig{
+		{Method: "POST", Route: "/api/v1/users/bulk", MaxSizeKB: 4},
+	},
+}
+
+// runEnforce runs the middleware on a request whose handler reads the body
+func runEnforce(t *testing.T, req *http.Request, route string) error {
+	t.Helper()
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+	c.SetPath(route)
+
+	return Enforce(testConfig)(func(c echo.Context) error {
+		_, err := io.ReadAll(c.Request().Body)
+		return err
+	})(c)
+}
+
+func jsonRequest(method, body, contentType string) *http.Request {
+	req := httptest.NewRequest(method, "/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, contentType)
+	return req
+}
+
+func statusOf(t *testing.T, err error) int {
+	t.Helper()
+	var httpErr *echo.HTTPError
+	require.True(t, errors.As(err, &httpErr), "expected an HTTP error, got %v", err)
+	return httpErr.Code
+}
+
+func TestEnforce_AcceptsJSON(t *testing.T) {
+	for _, contentType := range []string{"application/json", "application/json; charset=utf-8", `application/json; charset="UTF-8"`} {
+		err := runEnforce(t, jsonRequest(http.MethodPost, `{}`, contentType), "/api/v1/users")
+
+		assert.NoError(t, err, contentType)
+	}
+}
+
+func TestEnforce_RejectsOtherContentTypes(t *testing.T) {
+	for _, contentType := range []string{
+		"",
+		"text/plain",
+		"application/x-www-form-urlencoded",
+		"application/json; charset=utf-16",
+		"application/json; charset=utf-7",
+		"application/json; charset=utf-8; boundary=x",
+		"application/json; charset=utf-8; charset=utf-16",
+	} {
+		err := runEnforce(t, jsonRequest(http.MethodPost, `{}`, contentType), "/api/v1/users")
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, statusOf(t, err), contentType)
+	}
+}
+
+func TestEnforce_AcceptsFormsOnFormRoutes(t *testing.T) {
+	form := jsonRequest(http.MethodPost, "grant_type=authorization_code", echo.MIMEApplicationForm)
+	assert.NoError(t, runEnforce(t, form, "/oauth/token"))
+
+	json := jsonRequest(http.MethodPost, `{}`, echo.MIMEApplicationJSON)
+	assert.NoError(t, runEnforce(t, json, "/oauth/token"), "form routes still accept JSON")
+
+	multipart := jsonRequest(http.MethodPost, "--x", echo.MIMEMultipartForm+"; boundary=x")
+	assert.Equal(t, http.StatusUnsupportedMediaType, statusOf(t, runEnforce(t, multipart, "/oauth/token")))
+}
+
+func TestEnforce_RejectsRepeatedContentTypeHeaders(t *testing.T) {
+	// Given
+	req := jsonRequest(http.MethodPut, `{}`, echo.MIMEApplicationJSON)
+	req.Header.Add(echo.HeaderContentType, "text/html")
+
+	// When
+	err := runEnforce(t, req, "/api/v1/internal/users/sync")
+
+	// Then
+	assert.Equal(t, http.StatusUnsupportedMediaType, statusOf(t, err))
+}
+
+func TestEnforce_BodilessRequestsSkipContentTypeCheck(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/jobs/health_check/run", nil)
+
+	err := runEnforce(t, req, "/api/v1/admin/jobs/:name/run")
+
+	assert.NoError(t, err)
+}
+
+func TestEnforce_RejectsDeclaredOversizedBody(t *testing.T) {
+	body := `{"text":"` + strings.Repeat("x", 2048) + `"}`
+
+	err := runEnforce(t, jsonRequest(http.MethodPost, body, echo.MIMEApplicationJSON), "/api/v1/users")
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, statusOf(t, err))
+}
+
+func TestEnforce_RejectsOversizedBodyWithoutContentLength(t *testing.T) {
+	// Given a chunked body that does not declare its length
+	req := jsonRequest(http.MethodPost, `{"text":"`+strings.Repeat("x", 2048)+`"}`, echo.MIMEApplicationJSON)
+	req.ContentLength = -1
+
+	// When
+	err := runEnforce(t, req, "/api/v1/users")
+
+	// Then the limit applies while the handler reads
+	assert.Equal(t, http.StatusRequestEntityTooLarge, statusOf(t, err))
+}
+
+func TestEnforce_RouteLimitOverridesDefault(t *testing.T) {
+	body := `{"users":"` + strings.Repeat("x", 2048) + `"}`
+
+	err := runEnforce(t, jsonRequest(http.MethodPost, body, echo.MIMEApplicationJSON), "/api/v1/users/bulk")
+
+	assert.NoError(t, err)
+}
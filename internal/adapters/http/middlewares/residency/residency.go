@@ -0,0 +1,47 @@
+package residency
+
+import (
+	"user-service/internal/adapters/http/middlewares/auth"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RegionAttribute is the API key attribute binding a caller to a region
+const RegionAttribute = "region"
+
+// Scope scopes persistence of each request to the caller's region, falling
+// back to home. Callers whose API key names a region are bound to it; the
+// region in header is only honored for internal callers, such as the regional
+// ingress, and stripped from everyone else's requests. Unknown regions are
+// rejected rather than served from the home region.
+func Scope(header string, home entities.Residency) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			principal := auth.PrincipalFrom(c)
+
+			value := req.Header.Get(header)
+			if !principal.HasRole(auth.RoleInternal) {
+				req.Header.Del(header)
+				value = ""
+			}
+			if principal != nil && principal.Attributes[RegionAttribute] != "" {
+				value = principal.Attributes[RegionAttribute]
+			}
+
+			region := home
+			if value != "" {
+				parsed, err := entities.ParseResidency(value)
+				if err != nil {
+					return err
+				}
+				region = parsed
+			}
+
+			c.SetRequest(req.WithContext(ports.WithResidency(req.Context(), region)))
+			return next(c)
+		}
+	}
+}
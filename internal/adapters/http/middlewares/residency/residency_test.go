@@ -0,0 +1,88 @@
+package residency
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"user-service/internal/adapters/http/middlewares/auth"
+	"user-service/internal/application/ports"
+	"user-service/internal/config"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testKeys = []config.APIKeyConfig{
+	{Name: "ingress", Key: "ingress-key", Role: auth.RoleInternal},
+	{Name: "support-us", Key: "support-us-key", Role: auth.RoleSupport, Attributes: map[string]string{RegionAttribute: "us"}},
+	{Name: "support", Key: "support-key", Role: auth.RoleSupport},
+}
+
+func serve(t *testing.T, apiKey, header string) (entities.Residency, error) {
+	t.Helper()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/1", nil)
+	if apiKey != "" {
+		req.Header.Set(auth.HeaderAPIKey, apiKey)
+	}
+	if header != "" {
+		req.Header.Set("X-Data-Region", header)
+	}
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	var region entities.Residency
+	handler := Scope("X-Data-Region", entities.ResidencyEU)(func(c echo.Context) error {
+		var ok bool
+		region, ok = ports.ResidencyFrom(c.Request().Context())
+		require.True(t, ok)
+		return nil
+	})
+	err := auth.NewAuthenticator(testKeys).Identify()(handler)(c)
+	return region, err
+}
+
+func TestScope_UsesHeaderRegionOfInternalCallers(t *testing.T) {
+	region, err := serve(t, "ingress-key", "US")
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.ResidencyUS, region)
+}
+
+func TestScope_DefaultsToHomeRegion(t *testing.T) {
+	region, err := serve(t, "ingress-key", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.ResidencyEU, region)
+}
+
+func TestScope_IgnoresHeaderOfOtherCallers(t *testing.T) {
+	// Given callers that are not internal naming another region
+	for _, apiKey := range []string{"", "support-key"} {
+		// When
+		region, err := serve(t, apiKey, "us")
+
+		// Then the header is ignored
+		require.NoError(t, err)
+		assert.Equal(t, entities.ResidencyEU, region, apiKey)
+	}
+}
+
+func TestScope_BindsCallersToTheirKeysRegion(t *testing.T) {
+	// Given a key bound to the US naming the home region
+	region, err := serve(t, "support-us-key", "eu")
+
+	// Then the key's region wins
+	require.NoError(t, err)
+	assert.Equal(t, entities.ResidencyUS, region)
+}
+
+func TestScope_RejectsUnknownRegion(t *testing.T) {
+	_, err := serve(t, "ingress-key", "apac")
+
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidResidency)
+}
@@ -0,0 +1,325 @@
+package responsecache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/config"
+	"user-service/internal/domain/entities"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// HeaderCache tells clients whether a response came from the server-side
+	// cache: HIT, STALE (served while being refreshed) or MISS
+	HeaderCache = "X-Cache"
+
+	keyPrefix = "user-service:response:"
+)
+
+// entry is a cached response
+type entry struct {
+	Status      int       `json:"status"`
+	ContentType string    `json:"content_type"`
+	Body        []byte    `json:"body"`
+	StoredAt    time.Time `json:"stored_at"`
+}
+
+// Cache sets Cache-Control on successful GET responses and, for routes
+// configured as server side, serves them from a shared cache with
+// stale-while-revalidate semantics. Requests with a query string always reach
+// the handler.
+type Cache struct {
+	routes              map[string]config.ResponseCacheRouteConfig
+	defaultCacheControl string
+	refreshTimeout      time.Duration
+	// store is nil when server-side caching is disabled
+	store ports.SharedCache
+	// health tells when the store is down, so requests skip it
+	health ports.FeatureHealth
+	logger logger.Logger
+	now    func() time.Time
+
+	// variation splits cached responses by caller; the zero value keeps one
+	// response per path
+	variation Variation
+
+	// refreshing holds the keys being revalidated, so a burst of stale hits
+	// triggers a single refresh
+	refreshing sync.Map
+}
+
+// Variation splits cached responses by something about the caller that
+// responses depend on, such as which fields the caller may see
+type Variation struct {
+	// Variants lists every value Of returns, so invalidation reaches them all
+	Variants []string
+	// Of returns the variant a request is served
+	Of func(echo.Context) string
+	// Carry copies what Of and the handler read from a request's context
+	// onto the context a stale response is refreshed in
+	Carry func(from, to echo.Context)
+}
+
+// Vary splits cached responses by variation. It must be set before the
+// middleware serves requests.
+func (c *Cache) Vary(variation Variation) {
+	c.variation = variation
+}
+
+// New creates a response cache; a nil store disables server-side caching
+func New(cfg config.ResponseCacheConfig, store ports.SharedCache, health ports.FeatureHealth, log logger.Logger) *Cache {
+	routes := make(map[string]config.ResponseCacheRouteConfig, len(cfg.Routes))
+	for _, route := range cfg.Routes {
+		routes[route.Route] = route
+	}
+
+	return &Cache{
+		routes:              routes,
+		defaultCacheControl: cfg.DefaultCacheControl,
+		refreshTimeout:      cfg.RefreshTimeout,
+		store:               store,
+		health:              health,
+		logger:              log.With("component", "response_cache"),
+		now:                 time.Now,
+	}
+}
+
+// Middleware applies the cache to GET requests. It must run after routing and
+// after the residency scope, as cached responses are kept per region.
+func (c *Cache) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			req := ctx.Request()
+			if req.Method != http.MethodGet {
+				return next(ctx)
+			}
+
+			route, ok := c.routes[ctx.Path()]
+			cacheControl := c.defaultCacheControl
+			if ok {
+				cacheControl = route.CacheControl()
+			}
+			if cacheControl != "" {
+				res := ctx.Response()
+				res.Before(func() {
+					if res.Status < http.StatusMultipleChoices && res.Header().Get(echo.HeaderCacheControl) == "" {
+						res.Header().Set(echo.HeaderCacheControl, cacheControl)
+					}
+				})
+			}
+
+			if !ok || !route.ServerSide || c.store == nil || req.URL.RawQuery != "" {
+				return next(ctx)
+			}
+			return c.serve(ctx, next, route)
+		}
+	}
+}
+
+// serve answers from the shared cache when possible and fills it otherwise
+func (c *Cache) serve(ctx echo.Context, next echo.HandlerFunc, route config.ResponseCacheRouteConfig) error {
+	// Serve from the handler rather than wait on a cache known to be down
+	if reason, degraded := c.health.Degraded(ports.FeatureCache); degraded {
+		ports.NoteDegraded(ctx.Request().Context(), ports.FeatureCache, reason)
+		return next(ctx)
+	}
+
+	region, _ := ports.ResidencyFrom(ctx.Request().Context())
+	key := c.key(region, c.variantOf(ctx), ctx.Request().URL.Path)
+
+	if cached := c.load(ctx.Request().Context(), key); cached != nil {
+		age := c.now().Sub(cached.StoredAt)
+		switch {
+		case age < route.MaxAge:
+			return c.write(ctx, cached, age, "HIT")
+		case age < route.MaxAge+route.StaleWhileRevalidate:
+			c.revalidate(ctx, next, key, route)
+			return c.write(ctx, cached, age, "STALE")
+		}
+	}
+
+	ctx.Response().Header().Set(HeaderCache, "MISS")
+	recorder := &teeWriter{ResponseWriter: ctx.Response().Writer}
+	ctx.Response().Writer = recorder
+	defer func() { ctx.Response().Writer = recorder.ResponseWriter }()
+
+	if err := next(ctx); err != nil {
+		return err
+	}
+
+	res := ctx.Response()
+	if res.Status == http.StatusOK {
+		c.save(ctx.Request().Context(), key, route, res.Status, res.Header().Get(echo.HeaderContentType), recorder.body.Bytes())
+	}
+	return nil
+}
+
+// revalidate refreshes a stale entry in the background by running the
+// handler again on a copy of the request
+func (c *Cache) revalidate(ctx echo.Context, next echo.HandlerFunc, key string, route config.ResponseCacheRouteConfig) {
+	if _, busy := c.refreshing.LoadOrStore(key, struct{}{}); busy {
+		return
+	}
+
+	original := ctx.Request()
+	requestID := ctx.Response().Header().Get(echo.HeaderXRequestID)
+	path, names, values := ctx.Path(), ctx.ParamNames(), ctx.ParamValues()
+	reqCtx, cancel := context.WithTimeout(context.WithoutCancel(original.Context()), c.refreshTimeout)
+	req := original.Clone(reqCtx)
+
+	go func() {
+		defer c.refreshing.Delete(key)
+		defer cancel()
+
+		buffer := &bufferWriter{header: make(http.Header)}
+		refresh := ctx.Echo().NewContext(req, buffer)
+		refresh.SetPath(path)
+		refresh.SetParamNames(names...)
+		refresh.SetParamValues(values...)
+		refresh.Response().Header().Set(echo.HeaderXRequestID, requestID)
+		if c.variation.Carry != nil {
+			c.variation.Carry(ctx, refresh)
+		}
+
+		if err := next(refresh); err != nil || refresh.Response().Status != http.StatusOK {
+			c.logger.Warn("Failed to revalidate cached response", "key", key, "status", refresh.Response().Status, "error", err)
+			return
+		}
+		c.save(reqCtx, key, route, http.StatusOK, buffer.header.Get(echo.HeaderContentType), buffer.body.Bytes())
+	}()
+}
+
+// InvalidateUser drops the cached responses about a user, i.e. those of
+// server-side routes with an :id parameter, in every region and variant
+func (c *Cache) InvalidateUser(ctx context.Context, userID uint) {
+	if c.store == nil || userID == 0 {
+		return
+	}
+
+	id := strconv.FormatUint(uint64(userID), 10)
+	regions := append([]entities.Residency{""}, entities.Residencies...)
+	variants := c.variation.Variants
+	if c.variation.Of == nil {
+		variants = []string{""}
+	}
+
+	var keys []string
+	for pattern, route := range c.routes {
+		if !route.ServerSide || !strings.Contains(pattern, "/:id") {
+			continue
+		}
+		path := strings.Replace(pattern, ":id", id, 1)
+		for _, region := range regions {
+			for _, variant := range variants {
+				keys = append(keys, c.key(region, variant, path))
+			}
+		}
+	}
+
+	if err := c.store.Delete(ctx, keys...); err != nil {
+		c.logger.Error("Failed to invalidate cached responses", "user_id", userID, "error", err)
+	}
+}
+
+// InvalidatingPublisher wraps publisher so every user event also drops the
+// cached responses about its user. Writes to users all emit an event, which
+// keeps invalidation next to the change rather than in each use case.
+func (c *Cache) InvalidatingPublisher(publisher ports.EventPublisher) ports.EventPublisher {
+	return &invalidatingPublisher{next: publisher, cache: c}
+}
+
+type invalidatingPublisher struct {
+	next  ports.EventPublisher
+	cache *Cache
+}
+
+// Publish implements ports.EventPublisher
+func (p *invalidatingPublisher) Publish(ctx context.Context, event *entities.UserEvent) error {
+	p.cache.InvalidateUser(ctx, event.UserID)
+	return p.next.Publish(ctx, event)
+}
+
+func (c *Cache) key(region entities.Residency, variant, path string) string {
+	if variant == "" {
+		return keyPrefix + string(region) + ":" + path
+	}
+	return keyPrefix + string(region) + ":" + variant + ":" + path
+}
+
+// variantOf returns the variant of the response a request is served
+func (c *Cache) variantOf(ctx echo.Context) string {
+	if c.variation.Of == nil {
+		return ""
+	}
+	return c.variation.Of(ctx)
+}
+
+// load returns the cached entry for key, or nil. Cache failures are logged
+// and treated as misses so they never fail the request.
+func (c *Cache) load(ctx context.Context, key string) *entry {
+	data, found, err := c.store.Get(ctx, key)
+	if err != nil {
+		c.logger.Warn("Failed to read cached response", "key", key, "error", err)
+		ports.NoteDegraded(ctx, ports.FeatureCache, err.Error())
+		return nil
+	}
+	if !found {
+		return nil
+	}
+
+	var cached entry
+	if err := json.Unmarshal(data, &cached); err != nil {
+		c.logger.Warn("Discarding malformed cached response", "key", key, "error", err)
+		return nil
+	}
+	return &cached
+}
+
+func (c *Cache) save(ctx context.Context, key string, route config.ResponseCacheRouteConfig, status int, contentType string, body []byte) {
+	data, err := json.Marshal(entry{Status: status, ContentType: contentType, Body: body, StoredAt: c.now().UTC()})
+	if err != nil {
+		c.logger.Error("Failed to encode response for caching", "key", key, "error", err)
+		return
+	}
+	if err := c.store.Set(ctx, key, data, route.MaxAge+route.StaleWhileRevalidate); err != nil {
+		c.logger.Warn("Failed to cache response", "key", key, "error", err)
+	}
+}
+
+func (c *Cache) write(ctx echo.Context, cached *entry, age time.Duration, outcome string) error {
+	header := ctx.Response().Header()
+	header.Set(HeaderCache, outcome)
+	header.Set("Age", strconv.Itoa(int(age/time.Second)))
+	return ctx.Blob(cached.Status, cached.ContentType, cached.Body)
+}
+
+// teeWriter passes a response through while keeping a copy of its body
+type teeWriter struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *teeWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// bufferWriter collects a response produced in the background
+type bufferWriter struct {
+	header http.Header
+	body   bytes.Buffer
+}
+
+func (w *bufferWriter) Header() http.Header         { return w.header }
+func (w *bufferWriter) Write(b []byte) (int, error) { return w.body.Write(b) }
+func (w *bufferWriter) WriteHeader(int)             {}
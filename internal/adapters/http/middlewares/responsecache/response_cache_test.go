@@ -0,0 +1,252 @@
+package responsecache
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"user-service/internal/adapters/http/middlewares/degradation"
+	"user-service/internal/application/ports"
+	"user-service/internal/config"
+	"user-service/internal/domain/entities"
+	"user-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCache is an in-memory ports.SharedCache
+type memoryCache struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{entries: make(map[string][]byte)}
+}
+
+func (m *memoryCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	value, ok := m.entries[key]
+	return value, ok, nil
+}
+
+func (m *memoryCache) Set(_ context.Context, key string, value []byte, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = value
+	return nil
+}
+
+func (m *memoryCache) Delete(_ context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, key := range keys {
+		delete(m.entries, key)
+	}
+	return nil
+}
+
+func (m *memoryCache) len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
+
+// stubHealth reports the cache as degraded while reason is set
+type stubHealth struct {
+	reason string
+}
+
+func (h *stubHealth) Degraded(feature ports.Feature) (string, bool) {
+	return h.reason, feature == ports.FeatureCache && h.reason != ""
+}
+
+// fixture serves GET /api/v1/users/:id through the cache, answering with the
+// number of times the handler ran
+type fixture struct {
+	echo   *echo.Echo
+	cache  *Cache
+	store  *memoryCache
+	health *stubHealth
+	calls  atomic.Int32
+	now    time.Time
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	f := &fixture{echo: echo.New(), store: newMemoryCache(), health: &stubHealth{}, now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	f.cache = New(config.ResponseCacheConfig{
+		DefaultCacheControl: "no-store",
+		RefreshTimeout:      time.Second,
+		Routes: []config.ResponseCacheRouteConfig{{
+			Route:                "/api/v1/users/:id",
+			MaxAge:               30 * time.Second,
+			StaleWhileRevalidate: time.Minute,
+			ServerSide:           true,
+		}},
+	}, f.store, f.health, logger.New("test"))
+	f.cache.now = func() time.Time { return f.now }
+
+	f.echo.Use(degradation.Warnings(), f.cache.Middleware())
+	f.echo.GET("/api/v1/users/:id", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]interface{}{"id": c.Param("id"), "calls": f.calls.Add(1)})
+	})
+	f.echo.GET("/api/v1/health", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+	})
+	return f
+}
+
+func (f *fixture) get(target string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	f.echo.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec
+}
+
+func TestCache_SetsCacheControl(t *testing.T) {
+	f := newFixture(t)
+
+	rec := f.get("/api/v1/users/1")
+	assert.Equal(t, "private, max-age=30, stale-while-revalidate=60", rec.Header().Get(echo.HeaderCacheControl))
+
+	rec = f.get("/api/v1/health")
+	assert.Equal(t, "no-store", rec.Header().Get(echo.HeaderCacheControl), "routes without a rule use the default")
+
+	rec = f.get("/api/v1/missing")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Empty(t, rec.Header().Get(echo.HeaderCacheControl), "errors are never cacheable")
+}
+
+func TestCache_ServesFreshResponsesFromStore(t *testing.T) {
+	// Given a response cached ten seconds ago
+	f := newFixture(t)
+	first := f.get("/api/v1/users/1")
+	require.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "MISS", first.Header().Get(HeaderCache))
+	f.now = f.now.Add(10 * time.Second)
+
+	// When
+	rec := f.get("/api/v1/users/1")
+
+	// Then
+	assert.Equal(t, "HIT", rec.Header().Get(HeaderCache))
+	assert.Equal(t, "10", rec.Header().Get("Age"))
+	assert.Equal(t, first.Body.String(), rec.Body.String())
+	assert.Equal(t, int32(1), f.calls.Load())
+}
+
+func TestCache_RevalidatesStaleResponsesInBackground(t *testing.T) {
+	// Given a response past its max age but within stale-while-revalidate
+	f := newFixture(t)
+	first := f.get("/api/v1/users/1")
+	f.now = f.now.Add(45 * time.Second)
+
+	// When
+	rec := f.get("/api/v1/users/1")
+
+	// Then the stale response is served and refreshed behind it
+	assert.Equal(t, "STALE", rec.Header().Get(HeaderCache))
+	assert.Equal(t, first.Body.String(), rec.Body.String())
+	require.Eventually(t, func() bool { return f.calls.Load() == 2 }, time.Second, 5*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return f.get("/api/v1/users/1").Header().Get(HeaderCache) == "HIT"
+	}, time.Second, 5*time.Millisecond)
+	assert.Contains(t, f.get("/api/v1/users/1").Body.String(), `"calls":2`)
+}
+
+func TestCache_RefetchesExpiredResponses(t *testing.T) {
+	f := newFixture(t)
+	f.get("/api/v1/users/1")
+	f.now = f.now.Add(2 * time.Minute)
+
+	rec := f.get("/api/v1/users/1")
+
+	assert.Equal(t, "MISS", rec.Header().Get(HeaderCache))
+	assert.Equal(t, int32(2), f.calls.Load())
+}
+
+func TestCache_BypassesStoreForQueries(t *testing.T) {
+	f := newFixture(t)
+
+	f.get("/api/v1/users/1?fields=email")
+	f.get("/api/v1/users/1?fields=email")
+
+	assert.Equal(t, int32(2), f.calls.Load())
+	assert.Zero(t, f.store.len())
+}
+
+func TestCache_SkipsStoreWhileDegraded(t *testing.T) {
+	// Given a cached response and a cache reported down since
+	f := newFixture(t)
+	f.get("/api/v1/users/1")
+	f.health.reason = "redis is unhealthy: connection refused"
+
+	// When
+	rec := f.get("/api/v1/users/1")
+
+	// Then the handler answers and the response says why
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get(HeaderCache))
+	assert.Equal(t, `199 user-service "degraded mode: cache unavailable"`, rec.Header().Get(degradation.HeaderWarning))
+	assert.Equal(t, int32(2), f.calls.Load())
+}
+
+func TestCache_InvalidatingPublisherDropsUserResponses(t *testing.T) {
+	// Given cached responses for two users
+	f := newFixture(t)
+	f.get("/api/v1/users/1")
+	f.get("/api/v1/users/2")
+	publisher := &recordingPublisher{}
+
+	// When user 1 changes
+	err := f.cache.InvalidatingPublisher(publisher).Publish(context.Background(), entities.NewUserEvent(entities.UserEventUpdated, 1, nil))
+
+	// Then only user 1 is fetched again
+	require.NoError(t, err)
+	assert.Len(t, publisher.events, 1)
+	assert.Equal(t, "MISS", f.get("/api/v1/users/1").Header().Get(HeaderCache))
+	assert.Equal(t, "HIT", f.get("/api/v1/users/2").Header().Get(HeaderCache))
+}
+
+type recordingPublisher struct {
+	events []*entities.UserEvent
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event *entities.UserEvent) error {
+	p.events = append(p.events, event)
+	return nil
+}
+
+func TestCache_VariesByViewer(t *testing.T) {
+	// Given responses varying by an X-Viewer header, cached for two viewers
+	f := newFixture(t)
+	f.cache.Vary(Variation{
+		Variants: []string{"public", "admin"},
+		Of:       func(c echo.Context) string { return c.Request().Header.Get("X-Viewer") },
+	})
+	getAs := func(viewer string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/users/1", nil)
+		req.Header.Set("X-Viewer", viewer)
+		rec := httptest.NewRecorder()
+		f.echo.ServeHTTP(rec, req)
+		return rec
+	}
+	assert.Equal(t, "MISS", getAs("public").Header().Get(HeaderCache))
+
+	// When another viewer asks, it is not served the first viewer's response
+	assert.Equal(t, "MISS", getAs("admin").Header().Get(HeaderCache))
+	assert.Equal(t, "HIT", getAs("public").Header().Get(HeaderCache))
+
+	// And a change to the user drops the responses of every viewer
+	f.cache.InvalidateUser(context.Background(), 1)
+	assert.Equal(t, "MISS", getAs("public").Header().Get(HeaderCache))
+	assert.Equal(t, "MISS", getAs("admin").Header().Get(HeaderCache))
+}
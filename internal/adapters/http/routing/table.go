@@ -0,0 +1,138 @@
+package routing
+
+import (
+	"slices"
+
+	"user-service/internal/config"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Middleware is a named middleware, so the chain of a route can be listed
+type Middleware struct {
+	Name string
+	Func echo.MiddlewareFunc
+}
+
+// Route is a registered route and the middlewares it runs, outermost first
+type Route struct {
+	Method     string
+	Path       string
+	Middleware []string
+}
+
+// Table registers routes on an Echo instance and records the middleware chain
+// of each. Optional middlewares, named in config.OptionalMiddlewares, run only
+// on routes the middleware configuration leaves them enabled for.
+type Table struct {
+	echo   *echo.Echo
+	config config.MiddlewareConfig
+	// global are the names of the middlewares every route runs
+	global []string
+	// optional tells which global middlewares may be switched per route
+	optional map[string]bool
+	routes   []Route
+}
+
+// NewTable creates a route table for e
+func NewTable(e *echo.Echo, cfg config.MiddlewareConfig) *Table {
+	return &Table{echo: e, config: cfg, optional: make(map[string]bool)}
+}
+
+// Use adds a middleware every route runs
+func (t *Table) Use(name string, mw echo.MiddlewareFunc) {
+	t.global = append(t.global, name)
+	t.echo.Use(mw)
+}
+
+// UseOptional adds a middleware every route runs unless the configuration
+// disables it for the route. The route is only known once the request is
+// routed, so the decision is made per request.
+func (t *Table) UseOptional(name string, mw echo.MiddlewareFunc) {
+	t.global = append(t.global, name)
+	t.optional[name] = true
+	t.echo.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		wrapped := mw(next)
+		return func(c echo.Context) error {
+			if !t.config.Enabled(name, c.Path()) {
+				return next(c)
+			}
+			return wrapped(c)
+		}
+	})
+}
+
+// Group creates a group of routes under prefix that run the given optional
+// middlewares
+func (t *Table) Group(prefix string, optional ...Middleware) *Group {
+	return &Group{table: t, prefix: prefix, optional: optional}
+}
+
+// Routes returns the registered routes in registration order
+func (t *Table) Routes() []Route {
+	return slices.Clone(t.routes)
+}
+
+// add registers a route with the optional middlewares the configuration
+// enables for it
+func (t *Table) add(method, path string, handler echo.HandlerFunc, optional []Middleware) {
+	chain := make([]string, 0, len(t.global)+len(optional))
+	for _, name := range t.global {
+		if !t.optional[name] || t.config.Enabled(name, path) {
+			chain = append(chain, name)
+		}
+	}
+
+	middlewares := make([]echo.MiddlewareFunc, 0, len(optional))
+	for _, mw := range optional {
+		if t.config.Enabled(mw.Name, path) {
+			chain = append(chain, mw.Name)
+			middlewares = append(middlewares, mw.Func)
+		}
+	}
+
+	t.echo.Add(method, path, handler, middlewares...)
+	t.routes = append(t.routes, Route{Method: method, Path: path, Middleware: chain})
+}
+
+// Group is a set of routes under a common prefix
+type Group struct {
+	table    *Table
+	prefix   string
+	optional []Middleware
+}
+
+// Group creates a nested group, running this group's middlewares before its own
+func (g *Group) Group(prefix string, optional ...Middleware) *Group {
+	return &Group{table: g.table, prefix: g.prefix + prefix, optional: g.with(optional)}
+}
+
+// GET registers a GET route
+func (g *Group) GET(path string, handler echo.HandlerFunc, optional ...Middleware) {
+	g.table.add(echo.GET, g.prefix+path, handler, g.with(optional))
+}
+
+// HEAD registers a HEAD route
+func (g *Group) HEAD(path string, handler echo.HandlerFunc, optional ...Middleware) {
+	g.table.add(echo.HEAD, g.prefix+path, handler, g.with(optional))
+}
+
+// POST registers a POST route
+func (g *Group) POST(path string, handler echo.HandlerFunc, optional ...Middleware) {
+	g.table.add(echo.POST, g.prefix+path, handler, g.with(optional))
+}
+
+// PUT registers a PUT route
+func (g *Group) PUT(path string, handler echo.HandlerFunc, optional ...Middleware) {
+	g.table.add(echo.PUT, g.prefix+path, handler, g.with(optional))
+}
+
+// DELETE registers a DELETE route
+func (g *Group) DELETE(path string, handler echo.HandlerFunc, optional ...Middleware) {
+	g.table.add(echo.DELETE, g.prefix+path, handler, g.with(optional))
+}
+
+// with returns the group's middlewares followed by those of a route
+func (g *Group) with(optional []Middleware) []Middleware {
+	return append(slices.Clone(g.optional), optional...)
+}
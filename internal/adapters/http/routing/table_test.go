@@ -0,0 +1,75 @@
+package routing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"user-service/internal/config"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// header returns a middleware that marks the responses it sees
+func header(name string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Response().Header().Add("X-Ran", name)
+			return next(c)
+		}
+	}
+}
+
+func ok(c echo.Context) error {
+	return c.NoContent(http.StatusOK)
+}
+
+func setupTestTable(groups ...config.MiddlewareGroupConfig) (*echo.Echo, *Table) {
+	e := echo.New()
+	table := NewTable(e, config.MiddlewareConfig{Groups: groups})
+	table.Use("request_id", header("request_id"))
+	table.UseOptional(config.MiddlewareCache, header(config.MiddlewareCache))
+
+	v1 := table.Group("/api/v1")
+	v1.GET("/health", ok)
+	admin := v1.Group("/admin", Middleware{Name: config.MiddlewareAuth, Func: header(config.MiddlewareAuth)})
+	admin.GET("/jobs", ok, Middleware{Name: config.MiddlewareQuota, Func: header(config.MiddlewareQuota)})
+	return e, table
+}
+
+func serve(e *echo.Echo, path string) []string {
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec.Header().Values("X-Ran")
+}
+
+func TestTable_RunsEveryMiddlewareByDefault(t *testing.T) {
+	e, table := setupTestTable()
+
+	assert.Equal(t, []string{"request_id", "cache", "auth", "quota"}, serve(e, "/api/v1/admin/jobs"))
+	require.Len(t, table.Routes(), 2)
+	assert.Equal(t, Route{Method: http.MethodGet, Path: "/api/v1/admin/jobs", Middleware: []string{"request_id", "cache", "auth", "quota"}}, table.Routes()[1])
+}
+
+func TestTable_SwitchesMiddlewaresPerGroup(t *testing.T) {
+	// Given caching and authorization disabled for admin routes and caching
+	// enabled again for jobs
+	e, table := setupTestTable(
+		config.MiddlewareGroupConfig{Prefix: "/api/v1/admin", Disable: []string{config.MiddlewareAuth, config.MiddlewareCache}},
+		config.MiddlewareGroupConfig{Prefix: "/api/v1/admin/jobs", Enable: []string{config.MiddlewareCache}},
+		config.MiddlewareGroupConfig{Prefix: "/api/v1/health", Disable: []string{config.MiddlewareCache, "request_id"}},
+	)
+
+	// When
+	jobs := serve(e, "/api/v1/admin/jobs")
+	health := serve(e, "/api/v1/health")
+
+	// Then the chains run and listed follow the configuration; middlewares
+	// that are not optional always run
+	assert.Equal(t, []string{"request_id", "cache", "quota"}, jobs)
+	assert.Equal(t, []string{"request_id"}, health)
+	assert.Equal(t, []string{"request_id"}, table.Routes()[0].Middleware)
+	assert.Equal(t, []string{"request_id", "cache", "quota"}, table.Routes()[1].Middleware)
+}
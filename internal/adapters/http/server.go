@@ -3,60 +3,252 @@ package http
 import (
 	"context"
 	"fmt"
+	"net"
+	stdhttp "net/http"
+	"os"
+	"strings"
+	"time"
+	"user-service/internal/adapters/audit"
+	"user-service/internal/adapters/backup"
+	"user-service/internal/adapters/billing"
+	"user-service/internal/adapters/cache"
+	"user-service/internal/adapters/deletion"
 	"user-service/internal/adapters/http/handlers"
+	"user-service/internal/adapters/http/middlewares/auth"
+	"user-service/internal/adapters/http/middlewares/botdetection"
+	"user-service/internal/adapters/http/middlewares/deadline"
+	"user-service/internal/adapters/http/middlewares/degradation"
+	"user-service/internal/adapters/http/middlewares/errorsummary"
+	"user-service/internal/adapters/http/middlewares/faultinjection"
 	"user-service/internal/adapters/http/middlewares/logging"
+	"user-service/internal/adapters/http/middlewares/metering"
+	"user-service/internal/adapters/http/middlewares/quota"
+	"user-service/internal/adapters/http/middlewares/requestbody"
+	"user-service/internal/adapters/http/middlewares/residency"
+	"user-service/internal/adapters/http/middlewares/responsecache"
+	"user-service/internal/adapters/http/routing"
+	"user-service/internal/adapters/messaging"
+	"user-service/internal/adapters/oidc"
+	"user-service/internal/adapters/persistence/action_counter_store"
+	"user-service/internal/adapters/persistence/duplicate_store"
+	"user-service/internal/adapters/persistence/event_store"
+	"user-service/internal/adapters/persistence/job_store"
+	"user-service/internal/adapters/persistence/note_repository"
+	"user-service/internal/adapters/persistence/oidc_store"
+	"user-service/internal/adapters/persistence/suppression_store"
+	"user-service/internal/adapters/persistence/usage_store"
 	"user-service/internal/adapters/persistence/user_repository"
+	"user-service/internal/adapters/persistence/write_journal"
+	"user-service/internal/adapters/searchtoken"
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
 	"user-service/internal/application/usecases"
 	"user-service/internal/config"
+	"user-service/internal/domain/entities"
 	"user-service/internal/infrastructure"
 	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
+	"user-service/pkg/pagination"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"golang.org/x/time/rate"
 )
 
 type Server struct {
-	echo        *echo.Echo
-	config      *config.Config
-	logger      logger.Logger
-	connections *infrastructure.DatabaseConnections
+	echo *echo.Echo
+	// routes registers routes with the middlewares configured for them
+	routes        *routing.Table
+	config        *config.Config
+	logger        logger.Logger
+	connections   *infrastructure.DatabaseConnections
+	accessLogger  logger.Logger
+	metrics       *metrics.Registry
+	authenticator *auth.Authenticator
+	authorizer    *auth.Authorizer
+	// homeRegion is the residency region of requests that name none
+	homeRegion entities.Residency
+	// localization is the locale and time zone of users who choose none
+	localization entities.Localization
+	// ageGates hold back new users younger than their region allows
+	ageGates entities.AgeGates
+	// requiredFields are the profile fields new users must have
+	requiredFields entities.RequiredProfileFields
+	scheduler      *usecases.JobScheduler
+	// sharedCache is nil while the Redis cache is disabled
+	sharedCache   *cache.RedisCache
+	responseCache *responsecache.Cache
+	// features tracks which optional features are usable
+	features *infrastructure.FeatureMonitor
+	// tokenSigner is set when the OIDC provider is enabled
+	tokenSigner ports.TokenSigner
+	// searchTokens derives the search tokens of phone numbers
+	searchTokens *searchtoken.Tokenizer
+	// cursors sign the cursors of cursor-paginated listings
+	cursors *pagination.Codec
+	// writeBehind is set when offline writes are enabled
+	writeBehind *usecases.WriteBehind
+	// errorSummary records the recent server errors of this instance
+	errorSummary *errorsummary.Recorder
+	// usageMeter is set when usage metering is enabled
+	usageMeter *usecases.UsageMeter
 }
 
-func NewServer(cfg *config.Config, log logger.Logger, connections *infrastructure.DatabaseConnections) (*Server, error) {
+func NewServer(cfg *config.Config, log logger.Logger, connections *infrastructure.DatabaseConnections, registry *metrics.Registry) (*Server, error) {
 	e := echo.New()
 
 	// Configure Echo
 	e.HideBanner = true
 	e.HidePort = true
+	e.HTTPErrorHandler = handlers.NewHTTPErrorHandler(log)
+	e.IPExtractor = newIPExtractor(cfg.Server.TrustedProxies)
+	if err := handlers.RegisterRequestBinding(e, handlers.NewBindingPolicy(cfg.Binding.RejectUnknownFields, cfg.Binding.LenientRoutes), cfg.Profile.RequiredFields); err != nil {
+		return nil, err
+	}
+
+	accessLogger, err := newAccessLogger(cfg, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create access logger: %w", err)
+	}
+
+	var homeRegion entities.Residency
+	if cfg.Residency.Enabled {
+		if homeRegion, err = entities.ParseResidency(cfg.Residency.HomeRegion); err != nil {
+			return nil, fmt.Errorf("residency.home_region: %w", err)
+		}
+	}
+
+	localization, err := entities.NewLocalization(cfg.Profile.DefaultLocale, cfg.Time.DisplayTimezone)
+	if err != nil {
+		return nil, fmt.Errorf("profile.default_locale and time.display_timezone: %w", err)
+	}
+
+	ageGates, err := newAgeGates(cfg.Profile.AgeGate)
+	if err != nil {
+		return nil, err
+	}
 
 	server := &Server{
-		echo:        e,
-		config:      cfg,
-		logger:      log,
-		connections: connections,
+		echo:           e,
+		routes:         routing.NewTable(e, cfg.Middleware),
+		config:         cfg,
+		logger:         log,
+		accessLogger:   accessLogger,
+		connections:    connections,
+		metrics:        registry,
+		homeRegion:     homeRegion,
+		localization:   localization,
+		ageGates:       ageGates,
+		requiredFields: entities.RequiredProfileFields(cfg.Profile.RequiredFields),
+		authenticator:  auth.NewAuthenticator(cfg.Security.APIKeys),
+		features:       infrastructure.NewFeatureMonitor(cfg.Health.Features),
+		errorSummary:   errorsummary.NewRecorder(cfg.ErrorSummary, handlers.ErrorCodeFrom),
+	}
+
+	server.authorizer = auth.NewAuthorizer(server.authenticator, cfg.Authorization, log, registry)
+	if cfg.Authorization.Mode != config.AuthorizationModeRBAC {
+		log.Info("Authorization policies loaded",
+			"mode", cfg.Authorization.Mode,
+			"policies", len(cfg.Authorization.Policies))
+	}
+
+	var sharedCache ports.SharedCache
+	if cfg.Cache.Enabled {
+		server.sharedCache = cache.NewRedisCache(cfg.Cache.Address, cfg.Cache.ConnectTimeout)
+		sharedCache = server.sharedCache
+	}
+	server.responseCache = responsecache.New(cfg.ResponseCache, sharedCache, server.features, log)
+	// User responses are filtered by who asks, so each viewer gets its own
+	variation := responsecache.Variation{
+		Variants: dto.ViewerKeys,
+		Of:       handlers.ViewerKey,
+		Carry:    auth.CarryPrincipal,
+	}
+	// Clients may ask for the uniform response envelope, in which case
+	// responses are wrapped in the serializer and cached per envelope too
+	if cfg.ResponseEnvelope.Enabled {
+		envelope := handlers.EnvelopePolicy{Header: cfg.ResponseEnvelope.Header, Default: cfg.ResponseEnvelope.Default}
+		handlers.RegisterResponseEnvelope(e, envelope)
+		variation.Variants = envelope.Variants(variation.Variants)
+		variation.Of = envelope.Variant(variation.Of)
+	}
+	server.responseCache.Vary(variation)
+
+	if cfg.OIDC.Enabled {
+		if server.tokenSigner, err = newTokenSigner(cfg.OIDC, log); err != nil {
+			return nil, err
+		}
+	}
+
+	if server.searchTokens, err = searchtoken.FromConfig(cfg.SearchTokens, log); err != nil {
+		return nil, err
+	}
+
+	if server.cursors, err = newCursorCodec(cfg.Pagination, log); err != nil {
+		return nil, err
+	}
+
+	if cfg.Usage.Enabled {
+		usageStore := usage_store.NewGormUsageStore(connections.GetGormDB())
+		if server.usageMeter, err = usecases.NewUsageMeter(usageStore, cfg.Usage.FlushInterval, log); err != nil {
+			return nil, err
+		}
 	}
 
 	// Setup middleware
 	server.setupMiddleware()
 
 	// Setup routes
-	server.setupRoutes()
+	if err := server.setupRoutes(); err != nil {
+		return nil, err
+	}
 
 	return server, nil
 }
 
 func (s *Server) setupMiddleware() {
 	// Request ID middleware
-	s.echo.Use(middleware.RequestID())
+	s.routes.Use("request_id", middleware.RequestID())
 
 	// Replace Echo's logger with our custom Zap logger
-	s.echo.Use(logging.ZapLogger(s.logger.With("component", "http")))
+	s.routes.Use("access_log", logging.ZapLogger(s.accessLogger))
+
+	// Keep the latest server errors for the admin summary
+	s.routes.Use("error_summary", s.errorSummary.Middleware())
 
 	// Recovery middleware
-	s.echo.Use(middleware.Recover())
+	s.routes.Use("recover", middleware.Recover())
+
+	// Warn clients when a response was served without an optional feature
+	s.routes.Use("degradation_warnings", degradation.Warnings())
+
+	// Limit requests per client IP before any work is done for them
+	if rps := s.config.Security.RateLimitRPS; rps > 0 {
+		s.routes.UseOptional(config.MiddlewareRateLimit, middleware.RateLimiter(
+			middleware.NewRateLimiterMemoryStoreWithConfig(middleware.RateLimiterMemoryStoreConfig{
+				Rate:  rate.Limit(rps),
+				Burst: s.config.Security.RateLimitBurst,
+			})))
+	}
+
+	// Reject oversized and non-JSON request bodies before handlers read them
+	s.routes.Use("request_body", requestbody.Enforce(s.config.RequestBody))
+
+	// Resolve the caller from API keys without rejecting anonymous requests
+	s.routes.Use("identify", s.authenticator.Identify())
+
+	// Count the calls of each tenant for billing
+	if s.usageMeter != nil {
+		s.routes.Use("usage_metering", metering.Meter(s.usageMeter))
+	}
+
+	// Scope persistence to the caller's data residency region
+	if s.config.Residency.Enabled {
+		s.routes.Use("residency", residency.Scope(s.config.Residency.Header, s.homeRegion))
+	}
 
 	// Security headers
-	s.echo.Use(middleware.SecureWithConfig(middleware.SecureConfig{
+	s.routes.Use("secure_headers", middleware.SecureWithConfig(middleware.SecureConfig{
 		XSSProtection:         "1; mode=block",
 		ContentTypeNosniff:    "nosniff",
 		XFrameOptions:         "DENY",
@@ -64,29 +256,214 @@ func (s *Server) setupMiddleware() {
 		ContentSecurityPolicy: "default-src 'self'",
 	}))
 
+	// Timestamps are always UTC; tell clients which zone to display them in
+	displayTimezone := s.config.Time.DisplayTimezone
+	s.routes.Use("display_timezone", func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Response().Header().Set("X-Display-Timezone", displayTimezone)
+			return next(c)
+		}
+	})
+
 	// CORS middleware
-	s.echo.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+	s.routes.Use("cors", middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: s.config.Server.CORS.AllowOrigins,
 		AllowMethods: s.config.Server.CORS.AllowMethods,
 		AllowHeaders: s.config.Server.CORS.AllowHeaders,
 	}))
 
+	// Fault injection for resilience testing, never in production
+	if s.config.Chaos.Enabled {
+		if s.config.IsProduction() {
+			s.logger.Warn("Fault injection is configured but ignored in production")
+		} else {
+			s.logger.Warn("Fault injection enabled",
+				"allow_headers", s.config.Chaos.AllowHeaders,
+				"rules", len(s.config.Chaos.Rules))
+			s.routes.Use("fault_injection", faultinjection.FaultInjection(s.config.Chaos, s.logger.With("component", "fault_injection"), s.metrics))
+		}
+	}
+
 	// Request timeout middleware
-	s.echo.Use(middleware.TimeoutWithConfig(middleware.TimeoutConfig{
+	s.routes.Use("timeout", middleware.TimeoutWithConfig(middleware.TimeoutConfig{
 		Timeout: s.config.Server.ReadTimeout,
 	}))
+
+	// Respect the budget the gateway gives the request, within the timeout above
+	if s.config.Deadline.Enabled {
+		s.routes.Use("deadline", deadline.Deadline(s.config.Deadline.MaxTimeout))
+	}
+
+	// Cache-Control and server-side caching of GET responses, kept per region
+	s.routes.UseOptional(config.MiddlewareCache, s.responseCache.Middleware())
 }
 
-func (s *Server) setupRoutes() {
+func (s *Server) setupRoutes() error {
 	// Health check handlers with database connections
-	healthHandler := handlers.NewHealthHandler(s.logger, s.connections) // Updated
-	userRepo := user_repository.NewGormUserRepository(s.connections.GetGormDB())
+	healthRegistry := infrastructure.NewHealthRegistry(s.config.Health.CriticalComponents, s.config.Health.CheckTimeout)
+	s.connections.RegisterHealthChecks(healthRegistry)
+	healthRegistry.Observe(s.features.Observe)
+
+	healthHandler := handlers.NewHealthHandler(s.logger, healthRegistry, s.features, s.metrics)
+	userRepo := s.userRepository()
+	auditLogger := audit.NewLogAuditLogger(s.logger)
+
+	eventStore := event_store.NewGormEventStore(s.connections.GetGormDB(), s.homeRegion)
+	eventPublisher := messaging.NewStoringEventPublisher(eventStore, messaging.NewLogEventPublisher(s.logger), s.logger)
+	eventPublisher = messaging.NewExternalIDEventPublisher(userRepo, eventPublisher, s.logger)
+	// Every change to a user emits an event, so events drive cache invalidation
+	eventPublisher = s.responseCache.InvalidatingPublisher(eventPublisher)
+	// Suppressed addresses must never be notified, so their events stop here
+	suppressionList := suppression_store.NewGormSuppressionList(s.connections.GetGormDB())
+	eventPublisher = messaging.NewSuppressingEventPublisher(suppressionList, eventPublisher, s.logger)
+	if s.config.Messaging.Enabled {
+		s.logger.Warn("RabbitMQ is connected for health checks only; domain events are still written to the log")
+	}
+
+	userUseCases := usecases.NewUserUseCases(userRepo, eventPublisher, s.localization, s.ageGates, s.requiredFields, s.logger)
+	if s.config.OfflineWrites.Enabled {
+		writeBehind, err := s.newWriteBehind(userUseCases, userRepo)
+		if err != nil {
+			return err
+		}
+		s.writeBehind = writeBehind
+		userUseCases = writeBehind
+	}
+
+	userHandler := handlers.NewUserHandler(userUseCases, s.cursors, s.logger)
+
+	bulkUseCases := usecases.NewBulkUserUseCases(userRepo, eventPublisher, usecases.BulkOptions{
+		MaxItems:    s.config.Bulk.MaxItems,
+		Concurrency: s.config.Bulk.Concurrency,
+		BatchSize:   s.config.Bulk.BatchSize,
+		MaxInFlight: s.config.Bulk.MaxInFlight,
+		Defaults:    s.localization,
+		AgeGates:    s.ageGates,
+		Required:    s.requiredFields,
+	}, s.logger)
+	bulkHandler := handlers.NewUserBulkHandler(bulkUseCases, s.config.Bulk.MaxItems, s.logger)
+
+	existenceUseCases := usecases.NewUserExistenceUseCases(userRepo, usecases.ExistenceOptions{
+		TTL:        s.config.Existence.CacheTTL,
+		MaxEntries: s.config.Existence.MaxEntries,
+	}, s.logger)
+	existenceHandler := handlers.NewUserExistenceHandler(existenceUseCases, s.config.Existence.CacheTTL, s.logger)
+
+	syncUseCases := usecases.NewUserSyncUseCases(userRepo, eventPublisher, s.localization, s.requiredFields, s.logger)
+	syncHandler := handlers.NewUserSyncHandler(syncUseCases, s.logger)
+
+	deletionUseCases := usecases.NewUserDeletionUseCases(userRepo, s.deletionCheckers(), eventPublisher, auditLogger, s.logger)
+	deletionHandler := handlers.NewUserDeletionHandler(deletionUseCases, s.logger)
+
+	legalHoldUseCases := usecases.NewLegalHoldUseCases(userRepo, auditLogger, s.logger)
+	legalHoldHandler := handlers.NewLegalHoldHandler(legalHoldUseCases, s.logger)
+
+	referralUseCases := usecases.NewReferralUseCases(userRepo, eventPublisher, auditLogger, s.logger)
+	referralHandler := handlers.NewReferralHandler(referralUseCases, s.logger)
+
+	statusUseCases := usecases.NewUserStatusUseCases(userRepo, eventPublisher, auditLogger, s.logger)
+	statusHandler := handlers.NewUserStatusHandler(statusUseCases, s.logger)
+
+	actionCounters := action_counter_store.NewGormActionCounterStore(s.connections.GetGormDB())
+	actionLimiter := usecases.NewSensitiveActionLimiter(userRepo, actionCounters, s.actionLimits(), auditLogger, s.logger)
+	actionLimitHandler := handlers.NewActionLimitHandler(actionLimiter, s.logger)
+
+	verificationUseCases := usecases.NewVerificationUseCases(userRepo, eventPublisher, actionLimiter, s.config.Verification.ResendCooldown, s.logger)
+	verificationHandler := handlers.NewVerificationHandler(verificationUseCases, s.logger)
+
+	phoneLookupUseCases := usecases.NewPhoneLookupUseCases(userRepo, auditLogger, s.logger)
+	phoneLookupHandler := handlers.NewPhoneLookupHandler(phoneLookupUseCases, s.logger)
+
+	userSearchUseCases := usecases.NewUserSearchUseCases(userRepo, auditLogger, s.logger)
+	userSearchHandler := handlers.NewUserSearchHandler(userSearchUseCases, s.logger)
+
+	suppressionUseCases := usecases.NewSuppressionUseCases(suppressionList, auditLogger, s.logger)
+	suppressionHandler := handlers.NewSuppressionHandler(suppressionUseCases, s.logger)
+
+	noteRepo := note_repository.NewGormUserNoteRepository(s.connections.GetGormDB())
+	noteUseCases := usecases.NewUserNoteUseCases(userRepo, noteRepo, auditLogger, s.logger)
+	noteHandler := handlers.NewUserNoteHandler(noteUseCases, s.logger)
 
-	userUseCases := usecases.NewUserUseCases(userRepo, s.logger)
+	// Resources added by "user-service scaffold resource" (scaffold:wiring)
 
-	userHandler := handlers.NewUserHandler(userUseCases, s.logger)
+	duplicateDetector := usecases.NewDuplicateDetector(
+		userRepo,
+		duplicate_store.NewGormDuplicateSuggestionStore(s.connections.GetGormDB()),
+		usecases.DuplicateOptions{
+			MinConfidence: s.config.Duplicates.MinConfidence,
+			ScanPageSize:  s.config.Duplicates.ScanPageSize,
+			MaxGroupSize:  s.config.Duplicates.MaxGroupSize,
+		},
+		s.logger,
+	)
+	duplicateHandler := handlers.NewDuplicateHandler(duplicateDetector, s.logger)
+
+	activityDigest := usecases.NewActivityDigestJob(eventStore, userRepo, eventPublisher, s.config.Jobs.ActivityDigestInterval, s.logger)
+
+	var regions []entities.Residency
+	if s.config.Residency.Enabled {
+		regions = entities.Residencies
+	}
+	suspensionExpiry := usecases.NewSuspensionExpiryJob(userRepo, eventPublisher, auditLogger, regions, s.logger)
+
+	jobs := []usecases.ScheduledJob{
+		{Job: infrastructure.NewHealthCheckJob(healthRegistry), Interval: s.config.Jobs.HealthCheckInterval},
+		{Job: duplicateDetector, Interval: s.config.Jobs.DuplicateDetectionInterval},
+		{Job: activityDigest, Interval: s.config.Jobs.ActivityDigestInterval},
+		{Job: suspensionExpiry, Interval: s.config.Jobs.SuspensionExpiryInterval},
+	}
+
+	var usageHandler *handlers.UsageHandler
+	if s.usageMeter != nil {
+		usageStore := usage_store.NewGormUsageStore(s.connections.GetGormDB())
+		usageHandler = handlers.NewUsageHandler(usecases.NewUsageUseCases(usageStore, s.logger), s.logger)
+
+		if cfg := s.config.Usage; cfg.ExportDestination != "" {
+			exporter, err := billing.NewUsageExporter(cfg.ExportDestination, s.newS3Client())
+			if err != nil {
+				return fmt.Errorf("usage.export_destination: %w", err)
+			}
+			s.logger.Info("Usage export enabled", "destination", exporter.String())
+			jobs = append(jobs, usecases.ScheduledJob{
+				Job:      usecases.NewUsageExportJob(usageStore, exporter, cfg.ExportDelay, s.logger),
+				Interval: cfg.ExportInterval,
+			})
+		}
+	}
+
+	s.scheduler = usecases.NewJobScheduler(
+		job_store.NewGormJobStateStore(s.connections.GetGormDB()),
+		jobs,
+		s.config.Jobs.PollInterval,
+		auditLogger,
+		s.logger,
+	)
+	jobHandler := handlers.NewJobHandler(s.scheduler, s.logger)
+
+	quotaLimiter := quota.NewLimiter(s.config.Quota, s.logger, s.metrics)
+	pageSizeQuota := routing.Middleware{Name: config.MiddlewareQuota, Func: quotaLimiter.PageSize()}
+	capabilitiesHandler := handlers.NewCapabilitiesHandler(s.capabilities(), quotaLimiter.MaxPageSize, s.logger)
+	errorSummaryHandler := handlers.NewErrorSummaryHandler(s.errorSummary.Summary, s.logger)
+	errorCatalogHandler := handlers.NewErrorCatalogHandler(s.logger)
+
+	// Bot mitigation for public sign-up endpoints
+	publicWriteMiddlewares := []routing.Middleware{}
+	if botCfg := s.config.Security.BotDetection; botCfg.Enabled {
+		publicWriteMiddlewares = append(publicWriteMiddlewares, routing.Middleware{Name: config.MiddlewareBotDetection, Func: botdetection.BotDetectionWithConfig(botdetection.Config{
+			HoneypotFields: botCfg.HoneypotFields,
+			TimestampField: botCfg.TimestampField,
+			MinSubmitTime:  botCfg.MinSubmitTime,
+			Checkers: []botdetection.ReputationChecker{
+				botdetection.NewStaticReputationChecker(botCfg.BlockedUserAgents, botCfg.BlockedIPs),
+			},
+			Decoy:   userHandler.CreateUserDecoy,
+			Logger:  s.logger.With("component", "bot_detection"),
+			Metrics: s.metrics,
+			Audit:   auditLogger,
+		})})
+	}
 	// API v1 routes
-	v1 := s.echo.Group("/api/v1")
+	v1 := s.routes.Group("/api/v1")
 
 	// Health endpoints
 	v1.GET("/health", healthHandler.Health)
@@ -96,34 +473,391 @@ func (s *Server) setupRoutes() {
 	// Metrics endpoint
 	v1.GET("/metrics", healthHandler.Metrics)
 
+	// Catalog of the error codes clients may receive
+	v1.GET("/errors", errorCatalogHandler.ErrorCatalog)
+
 	users := v1.Group("/users")
 	{
-		users.POST("", userHandler.CreateUser)
-		users.GET("", userHandler.ListUsers)
+		users.POST("", userHandler.CreateUser, publicWriteMiddlewares...)
+		users.POST("/bulk", bulkHandler.BulkCreateUsers, s.require("users.bulk_create", auth.RoleAdmin, auth.RoleInternal))
+		users.GET("", userHandler.ListUsers, pageSizeQuota)
 		users.GET("/:id", userHandler.GetUser)
+		users.HEAD("/:id", existenceHandler.UserExists)
 		users.GET("/email/:email", userHandler.GetUserByEmail)
+		users.HEAD("/email/:email", existenceHandler.EmailExists)
+		users.POST("/:id/tags", userHandler.AddUserTags, s.require("users.tag", auth.RoleAdmin, auth.RoleSupport, auth.RoleInternal))
+		users.DELETE("/:id/tags/:tag", userHandler.RemoveUserTag, s.require("users.tag", auth.RoleAdmin, auth.RoleSupport, auth.RoleInternal))
+		users.PUT("/:id/preferences", userHandler.UpdatePreferences, s.require("users.update_preferences", auth.RoleAdmin, auth.RoleInternal))
+		users.PUT("/:id/profile", userHandler.UpdateProfile, s.require("users.update_profile", auth.RoleAdmin, auth.RoleSupport, auth.RoleInternal))
+		users.POST("/:id/resend-verification", verificationHandler.ResendVerification)
+		users.GET("/:id/referrals", referralHandler.ListReferrals, pageSizeQuota)
+		// Resources added by "user-service scaffold resource" (scaffold:routes)
 	}
+
+	// Service-to-service endpoints for systems of record
+	internal := v1.Group("/internal", s.require("internal.access", auth.RoleInternal, auth.RoleAdmin))
+	{
+		internal.GET("/capabilities", capabilitiesHandler.Capabilities)
+		internal.PUT("/users/sync", syncHandler.SyncUser)
+		internal.GET("/users/by-external-id/:source/:id", userHandler.GetUserByExternalID)
+		internal.POST("/users/phone-lookup", phoneLookupHandler.LookUpPhone, s.require("users.phone_lookup", auth.RoleInternal, auth.RoleAdmin))
+		internal.GET("/suppressions/:email", suppressionHandler.CheckSuppression)
+		internal.PUT("/suppressions/:email", suppressionHandler.Suppress)
+		internal.DELETE("/suppressions/:email", suppressionHandler.Unsuppress)
+	}
+
+	// Support tooling, restricted to staff API keys
+	admin := v1.Group("/admin", s.require("admin.access", auth.RoleAdmin, auth.RoleSupport))
+	{
+		admin.POST("/users/search", userSearchHandler.SearchUsers, s.require("users.search", auth.RoleAdmin, auth.RoleSupport))
+		admin.GET("/users/:id/notes", noteHandler.ListNotes, pageSizeQuota)
+		admin.POST("/users/:id/notes", noteHandler.CreateNote)
+		admin.PUT("/users/:id/notes/:note_id", noteHandler.UpdateNote)
+		admin.DELETE("/users/:id/notes/:note_id", noteHandler.DeleteNote)
+		admin.DELETE("/users/:id", deletionHandler.DeleteUser, s.require("users.delete", auth.RoleAdmin))
+		admin.PUT("/users/:id/status", statusHandler.ChangeStatus, s.require("users.change_status", auth.RoleAdmin))
+		admin.DELETE("/users/:id/action-limits", actionLimitHandler.ResetLimits)
+		admin.PUT("/users/:id/legal-hold", legalHoldHandler.PlaceLegalHold, s.require("users.legal_hold", auth.RoleAdmin))
+		admin.DELETE("/users/:id/legal-hold", legalHoldHandler.ReleaseLegalHold, s.require("users.legal_hold", auth.RoleAdmin))
+		admin.GET("/legal-holds", legalHoldHandler.ListLegalHolds, pageSizeQuota)
+		admin.PUT("/users/:id/referrer", referralHandler.AttributeReferral, s.require("users.attribute_referral", auth.RoleAdmin))
+
+		admin.GET("/duplicates", duplicateHandler.ListSuggestions, pageSizeQuota)
+
+		admin.GET("/jobs", jobHandler.ListJobs)
+		admin.GET("/jobs/:name", jobHandler.GetJob)
+		admin.POST("/jobs/:name/run", jobHandler.TriggerJob, s.require("jobs.run", auth.RoleAdmin))
+		admin.POST("/jobs/:name/pause", jobHandler.PauseJob, s.require("jobs.pause", auth.RoleAdmin))
+		admin.POST("/jobs/:name/resume", jobHandler.ResumeJob, s.require("jobs.resume", auth.RoleAdmin))
+
+		admin.GET("/errors/recent", errorSummaryHandler.RecentErrors)
+
+		if usageHandler != nil {
+			admin.GET("/usage", usageHandler.GetUsage, s.require("usage.read", auth.RoleAdmin))
+		}
+	}
+
+	if s.tokenSigner != nil {
+		oidcProvider := usecases.NewOIDCProvider(
+			userRepo,
+			oidc_store.NewGormOIDCClientRepository(s.connections.GetGormDB()),
+			oidc_store.NewGormAuthorizationCodeStore(s.connections.GetGormDB()),
+			s.tokenSigner,
+			eventPublisher,
+			auditLogger,
+			actionLimiter,
+			usecases.OIDCOptions{
+				Issuer:         s.config.OIDC.Issuer,
+				CodeTTL:        s.config.OIDC.CodeTTL,
+				AccessTokenTTL: s.config.OIDC.AccessTokenTTL,
+				IDTokenTTL:     s.config.OIDC.IDTokenTTL,
+			},
+			s.logger,
+		)
+		oidcHandler := handlers.NewOIDCHandler(oidcProvider, s.logger)
+
+		// OpenID Connect provider endpoints, at the paths the discovery document names
+		provider := s.routes.Group("")
+		provider.GET(usecases.OIDCDiscoveryPath, oidcHandler.Discovery)
+		provider.GET(usecases.OIDCKeySetPath, oidcHandler.KeySet)
+		provider.GET(usecases.OIDCAuthorizePath, oidcHandler.AuthorizeForm)
+		provider.POST(usecases.OIDCAuthorizePath, oidcHandler.Authorize)
+		provider.POST(usecases.OIDCTokenPath, oidcHandler.Token)
+		provider.GET(usecases.OIDCUserInfoPath, oidcHandler.UserInfo)
+		provider.POST(usecases.OIDCUserInfoPath, oidcHandler.UserInfo)
+
+		admin.GET("/oidc/clients", oidcHandler.ListClients)
+		admin.POST("/oidc/clients", oidcHandler.RegisterClient, s.require("oidc_clients.register", auth.RoleAdmin))
+		admin.PUT("/oidc/clients/:client_id/claim-mappings", oidcHandler.UpdateClaimMappings, s.require("oidc_clients.update_claim_mappings", auth.RoleAdmin))
+	}
+
 	s.logRegisteredRoutes()
+	return nil
+}
+
+// require guards a route performing action, see auth.Authorizer.Require
+func (s *Server) require(action string, roles ...string) routing.Middleware {
+	return routing.Middleware{Name: config.MiddlewareAuth, Func: s.authorizer.Require(action, roles...)}
+}
+
+// userRepository returns the user repository, routed by residency region when
+// residency is enabled. When shadow reads are, the reads of the home region
+// are mirrored to the shadow database; it holds a copy of the home region
+// only, so other regions' users never reach it.
+func (s *Server) userRepository() ports.UserRepository {
+	shadowDB := s.connections.GetShadowGormDB()
+	if shadowDB != nil {
+		s.logger.Info("Shadow reads enabled", "percentage", s.config.ShadowReads.Percentage, "region", s.homeRegion)
+	}
+
+	return s.routeByResidency(func(region entities.Residency) ports.UserRepository {
+		repo := user_repository.NewGormUserRepository(s.connections.GetRegionGormDB(region), s.searchTokens)
+		if shadowDB == nil || region != s.homeRegion {
+			return repo
+		}
+
+		cfg := s.config.ShadowReads
+		shadow := user_repository.NewGormUserRepository(shadowDB, s.searchTokens)
+		return user_repository.NewShadowRepository(repo, shadow, user_repository.ShadowOptions{
+			Percentage:  cfg.Percentage,
+			Timeout:     cfg.Timeout,
+			MaxInFlight: cfg.MaxInFlight,
+		}, s.logger, s.metrics)
+	})
+}
+
+// routeByResidency returns the repository of each region behind a residency
+// router, or the home region's alone while residency is disabled
+func (s *Server) routeByResidency(repositoryOf func(entities.Residency) ports.UserRepository) ports.UserRepository {
+	if !s.config.Residency.Enabled {
+		return repositoryOf(s.homeRegion)
+	}
+
+	regions := make(map[entities.Residency]ports.UserRepository, len(entities.Residencies))
+	for _, region := range entities.Residencies {
+		regions[region] = repositoryOf(region)
+	}
+	return user_repository.NewResidencyRouter(regions, s.homeRegion)
+}
+
+// newWriteBehind opens the offline write journal and wraps the user use cases
+// to queue the configured writes in it while the databases are unreachable
+func (s *Server) newWriteBehind(users usecases.UserUseCases, userRepo ports.UserRepository) (*usecases.WriteBehind, error) {
+	cfg := s.config.OfflineWrites
+	journal, err := write_journal.NewFileWriteJournal(cfg.Path, cfg.MaxQueued)
+	if err != nil {
+		return nil, fmt.Errorf("offline_writes.path: %w", err)
+	}
+
+	operations := make([]entities.QueuedWriteOperation, len(cfg.Operations))
+	for i, operation := range cfg.Operations {
+		operations[i] = entities.QueuedWriteOperation(operation)
+	}
+
+	return usecases.NewWriteBehind(users, userRepo, journal, s.connections.PingDatabases, usecases.WriteBehindOptions{
+		Operations:    operations,
+		ProbeInterval: cfg.ProbeInterval,
+	}, s.logger, s.metrics)
+}
+
+// newS3Client creates an S3 client with the backup.s3 settings and the
+// standard AWS credential environment variables
+func (s *Server) newS3Client() *backup.S3Client {
+	cfg := s.config.Backup.S3
+	region := cfg.Region
+	if fromEnv := os.Getenv("AWS_REGION"); fromEnv != "" {
+		region = fromEnv
+	}
+
+	return backup.NewS3Client(backup.S3Config{
+		Region:          region,
+		Endpoint:        cfg.Endpoint,
+		PathStyle:       cfg.PathStyle,
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}, &stdhttp.Client{Timeout: time.Minute})
+}
+
+// capabilities describes the API versions, optional features and limits of
+// this deployment; the page size cap is filled in per caller
+func (s *Server) capabilities() dto.CapabilitiesResponseDTO {
+	return dto.CapabilitiesResponseDTO{
+		Service:     "user-service",
+		Version:     s.config.Version,
+		APIVersions: []string{"v1"},
+		Features: map[string]bool{
+			// Not offered by this service
+			"mfa":      false,
+			"webhooks": false,
+			"scim":     false,
+
+			"oidc":              s.config.OIDC.Enabled,
+			"data_residency":    s.config.Residency.Enabled,
+			"response_envelope": s.config.ResponseEnvelope.Enabled,
+			"offline_writes":    s.config.OfflineWrites.Enabled,
+			"shared_cache":      s.config.Cache.Enabled,
+			"bot_detection":     s.config.Security.BotDetection.Enabled,
+			"usage_metering":    s.config.Usage.Enabled,
+		},
+		Limits: dto.CapabilityLimitsDTO{
+			MaxBulkItems:     s.config.Bulk.MaxItems,
+			MaxRequestBodyKB: s.config.RequestBody.MaxSizeKB,
+		},
+	}
+}
+
+// newCursorCodec creates the cursor codec for the configured key, or the
+// development key when none is configured; configuration validation keeps
+// the latter out of production
+func newCursorCodec(cfg config.PaginationConfig, log logger.Logger) (*pagination.Codec, error) {
+	key, development := cfg.EffectiveCursorKey()
+	if development {
+		log.Warn("No pagination cursor key configured; cursors are signed with the development key")
+	}
+
+	cursors, err := pagination.NewCodec(key)
+	if err != nil {
+		return nil, fmt.Errorf("pagination.cursor_key: %w", err)
+	}
+	return cursors, nil
+}
+
+// newIPExtractor reads the client IP from X-Forwarded-For only when the
+// request came through one of the trusted proxies; otherwise the header is
+// ignored so callers cannot pick the IP rate limits and policies see
+func newIPExtractor(trustedProxies []string) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, proxy := range trustedProxies {
+		// Ranges are validated when the configuration is loaded
+		_, network, _ := net.ParseCIDR(proxy)
+		options = append(options, echo.TrustIPRange(network))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+// newAgeGates converts the configured age gates
+func newAgeGates(cfg config.AgeGateConfig) (entities.AgeGates, error) {
+	gates := entities.AgeGates{
+		Default: entities.AgeGate{MinimumAge: cfg.MinimumAge, Policy: entities.AgeGatePolicy(cfg.Policy)},
+		Regions: make(map[entities.Residency]entities.AgeGate, len(cfg.Regions)),
+	}
+	for name, gate := range cfg.Regions {
+		region, err := entities.ParseResidency(name)
+		if err != nil {
+			return entities.AgeGates{}, fmt.Errorf("profile.age_gate.regions.%s: %w", name, err)
+		}
+		gates.Regions[region] = entities.AgeGate{MinimumAge: gate.MinimumAge, Policy: entities.AgeGatePolicy(gate.Policy)}
+	}
+	return gates, nil
+}
+
+// actionLimits converts the configured per-account action limits
+func (s *Server) actionLimits() map[entities.SensitiveAction]entities.ActionLimit {
+	limits := make(map[entities.SensitiveAction]entities.ActionLimit, len(s.config.SensitiveActions.Limits))
+	for action, limit := range s.config.SensitiveActions.Limits {
+		limits[entities.SensitiveAction(action)] = entities.ActionLimit{Max: limit.Max, Window: limit.Window}
+	}
+	return limits
+}
+
+// newTokenSigner loads the OIDC signing key, or generates one when none is
+// configured; configuration validation keeps the latter out of production
+func newTokenSigner(cfg config.OIDCConfig, log logger.Logger) (ports.TokenSigner, error) {
+	if cfg.SigningKeyFile != "" {
+		signer, err := oidc.LoadRSASigner(cfg.SigningKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("oidc.signing_key_file: %w", err)
+		}
+		return signer, nil
+	}
+
+	log.Warn("No OIDC signing key configured; tokens are signed with a generated key and become invalid on restart")
+	return oidc.GenerateRSASigner()
+}
+
+// deletionCheckers builds the configured checkers that may veto user deletion
+func (s *Server) deletionCheckers() []usecases.GuardedChecker {
+	cfg := s.config.Deletion
+	client := &stdhttp.Client{}
+
+	checkers := make([]usecases.GuardedChecker, 0, len(cfg.Checkers))
+	for _, checker := range cfg.Checkers {
+		timeout := checker.Timeout
+		if timeout == 0 {
+			timeout = cfg.Timeout
+		}
+
+		checkers = append(checkers, usecases.GuardedChecker{
+			Checker:  deletion.NewHTTPChecker(checker.Name, checker.URL, client),
+			Timeout:  timeout,
+			FailOpen: checker.IsFailOpen(cfg.FailOpen),
+		})
+	}
+
+	return checkers
 }
 
 func (s *Server) logRegisteredRoutes() {
 	s.logger.Info("HTTP routes registered:")
-	for _, route := range s.echo.Routes() {
+	for _, route := range s.routes.Routes() {
 		s.logger.Info("Route registered",
 			"method", route.Method,
 			"path", route.Path,
-			"name", route.Name)
+			"middleware", strings.Join(route.Middleware, ","))
 	}
 }
 
+// Routes returns the registered routes and the middleware chain of each
+func (s *Server) Routes() []routing.Route {
+	return s.routes.Routes()
+}
+
+// newAccessLogger returns the dedicated access logger when configured, and the
+// application logger otherwise
+func newAccessLogger(cfg *config.Config, log logger.Logger) (logger.Logger, error) {
+	access := cfg.Logging.Access
+	if !access.Enabled {
+		return log.With("component", "http"), nil
+	}
+
+	format := access.Format
+	if format == "" {
+		format = cfg.Logging.Format
+	}
+
+	return logger.NewAccessLogger(cfg.Environment, logger.AccessOptions{
+		Output:     access.Output,
+		Format:     format,
+		SampleRate: access.SampleRate,
+		MaxSizeMB:  access.MaxSizeMB,
+		MaxBackups: access.MaxBackups,
+		MaxAgeDays: access.MaxAgeDays,
+	})
+}
+
 func (s *Server) Start() error {
 	address := fmt.Sprintf("%s:%s", s.config.Server.Host, s.config.Server.Port)
 	s.logger.Info("Starting HTTP server", "address", address)
 
+	if s.config.Jobs.Enabled {
+		s.scheduler.Start()
+	}
+	if s.writeBehind != nil {
+		s.writeBehind.Start()
+	}
+	if s.usageMeter != nil {
+		s.usageMeter.Start()
+	}
+
 	return s.echo.Start(address)
 }
 
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.Info("Shutting down HTTP server...")
-	return s.echo.Shutdown(ctx)
+	err := s.echo.Shutdown(ctx)
+	if stopErr := s.scheduler.Stop(ctx); stopErr != nil {
+		s.logger.Error("Failed to stop job scheduler", "error", stopErr)
+	}
+	if s.writeBehind != nil {
+		if stopErr := s.writeBehind.Stop(ctx); stopErr != nil {
+			s.logger.Error("Failed to stop write-behind", "error", stopErr)
+		}
+	}
+	// After the HTTP server, so the calls it finished serving are flushed too
+	if s.usageMeter != nil {
+		if stopErr := s.usageMeter.Stop(ctx); stopErr != nil {
+			s.logger.Error("Failed to stop usage metering", "error", stopErr)
+		}
+	}
+	if s.sharedCache != nil {
+		if closeErr := s.sharedCache.Close(); closeErr != nil {
+			s.logger.Error("Failed to close shared cache", "error", closeErr)
+		}
+	}
+	_ = s.accessLogger.Sync()
+	return err
 }
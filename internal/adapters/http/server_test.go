@@ -0,0 +1,103 @@
+package http
+
+import (
+	stdhttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"user-service/internal/config"
+	"user-service/internal/infrastructure"
+	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newOfflineServer builds the server from the shipped configuration without
+// connecting to any database, as the routes command does
+func newOfflineServer(t *testing.T) *Server {
+	t.Helper()
+	cfg, err := config.Load("../../../configs/config.yaml", "development", nil)
+	require.NoError(t, err)
+
+	log := logger.New("test")
+	server, err := NewServer(cfg, log, infrastructure.NewDisconnectedConnections(log), metrics.NewRegistry())
+	require.NoError(t, err)
+	return server
+}
+
+func TestServer_UserMutationsRequireAuthentication(t *testing.T) {
+	// Given
+	server := newOfflineServer(t)
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{stdhttp.MethodPut, "/api/v1/users/1/preferences", `{"security_digest_opt_out":true}`},
+		{stdhttp.MethodPut, "/api/v1/users/1/preferences", `{"display_name_visibility":"public"}`},
+		{stdhttp.MethodPut, "/api/v1/users/1/profile", `{"display_name":"Mallory"}`},
+		{stdhttp.MethodPost, "/api/v1/users/1/tags", `{"tags":["vip"]}`},
+		{stdhttp.MethodDelete, "/api/v1/users/1/tags/vip", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			// When an anonymous caller changes another user
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			server.echo.ServeHTTP(rec, req)
+
+			// Then the request is turned away before reaching the handler
+			assert.Equal(t, stdhttp.StatusUnauthorized, rec.Code)
+		})
+	}
+}
+
+func TestServer_ClientIPIgnoresSpoofedForwardedFor(t *testing.T) {
+	// Given the shipped configuration, which trusts no proxies
+	server := newOfflineServer(t)
+
+	// When a caller claims an internal address in X-Forwarded-For
+	req := httptest.NewRequest(stdhttp.MethodGet, "/api/v1/users/1", nil)
+	req.RemoteAddr = "198.51.100.7:41000"
+	req.Header.Set(echo.HeaderXForwardedFor, "10.0.0.1")
+	req.Header.Set(echo.HeaderXRealIP, "10.0.0.1")
+	c := server.echo.NewContext(req, httptest.NewRecorder())
+
+	// Then context.ip is still the address the request came from
+	assert.Equal(t, "198.51.100.7", c.RealIP())
+}
+
+func TestNewIPExtractor(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		expected       string
+	}{
+		{name: "no trusted proxies", remoteAddr: "10.1.2.3:41000", expected: "10.1.2.3"},
+		{name: "trusted proxy", trustedProxies: []string{"10.0.0.0/8"}, remoteAddr: "10.1.2.3:41000", expected: "203.0.113.9"},
+		{name: "untrusted proxy", trustedProxies: []string{"10.0.0.0/8"}, remoteAddr: "192.168.1.2:41000", expected: "192.168.1.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given a request forwarded for a public address
+			req := httptest.NewRequest(stdhttp.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set(echo.HeaderXForwardedFor, "203.0.113.9")
+
+			// When the client IP is extracted
+			ip := newIPExtractor(tt.trustedProxies)(req)
+
+			// Then the header is believed only from a trusted proxy
+			assert.Equal(t, tt.expected, ip)
+		})
+	}
+}
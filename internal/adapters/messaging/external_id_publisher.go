@@ -0,0 +1,44 @@
+package messaging
+
+import (
+	"context"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	"user-service/pkg/logger"
+)
+
+// ExternalIDEventPublisher adds the external IDs of the user an event is about
+// before handing it to the next publisher, so consumers can join events with
+// the records of the systems users came from
+type ExternalIDEventPublisher struct {
+	userRepo ports.UserRepository
+	next     ports.EventPublisher
+	logger   logger.Logger
+}
+
+// NewExternalIDEventPublisher wraps next with external ID enrichment
+func NewExternalIDEventPublisher(userRepo ports.UserRepository, next ports.EventPublisher, log logger.Logger) ports.EventPublisher {
+	return &ExternalIDEventPublisher{
+		userRepo: userRepo,
+		next:     next,
+		logger:   log.With("component", "external_id_event_publisher"),
+	}
+}
+
+// Publish implements ports.EventPublisher. An event whose external IDs could
+// not be looked up is published without them.
+func (p *ExternalIDEventPublisher) Publish(ctx context.Context, event *entities.UserEvent) error {
+	if event.UserID != 0 && event.ExternalIDs == nil {
+		externalIDs, err := p.userRepo.ListExternalIDs(ctx, event.UserID)
+		if err != nil {
+			p.logger.Warn("Failed to look up external IDs for event",
+				"type", event.Type,
+				"user_id", event.UserID,
+				"error", err)
+		}
+		event.ExternalIDs = externalIDs
+	}
+
+	return p.next.Publish(ctx, event)
+}
@@ -0,0 +1,60 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// externalIDRepository serves ListExternalIDs; other methods are not used
+type externalIDRepository struct {
+	ports.UserRepository
+	externalIDs map[uint]map[string]string
+	err         error
+}
+
+func (r *externalIDRepository) ListExternalIDs(_ context.Context, userID uint) (map[string]string, error) {
+	return r.externalIDs[userID], r.err
+}
+
+func TestExternalIDEventPublisher_AddsExternalIDs(t *testing.T) {
+	// Given
+	repo := &externalIDRepository{externalIDs: map[uint]map[string]string{1: {"crm": "C-1001"}}}
+	next := new(MockEventPublisher)
+	publisher := NewExternalIDEventPublisher(repo, next, logger.New("test"))
+	ctx := context.Background()
+	event := entities.NewUserEvent(entities.UserEventUpdated, 1, nil)
+	next.On("Publish", ctx, event).Return(nil)
+
+	// When
+	err := publisher.Publish(ctx, event)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"crm": "C-1001"}, event.ExternalIDs)
+	next.AssertExpectations(t)
+}
+
+func TestExternalIDEventPublisher_PublishesWhenLookupFails(t *testing.T) {
+	// Given
+	repo := &externalIDRepository{err: assert.AnError}
+	next := new(MockEventPublisher)
+	publisher := NewExternalIDEventPublisher(repo, next, logger.New("test"))
+	ctx := context.Background()
+	next.On("Publish", ctx, mock.Anything).Return(nil)
+
+	// When
+	err := publisher.Publish(ctx, entities.NewUserEvent(entities.UserEventUpdated, 1, nil))
+
+	// Then the event still goes out, without external IDs
+	require.NoError(t, err)
+	event := next.Calls[0].Arguments.Get(1).(*entities.UserEvent)
+	assert.Nil(t, event.ExternalIDs)
+}
@@ -0,0 +1,32 @@
+package messaging
+
+import (
+	"context"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	"user-service/pkg/logger"
+)
+
+// LogEventPublisher writes domain events to the application log.
+// It is used when no message broker is configured.
+type LogEventPublisher struct {
+	logger logger.Logger
+}
+
+// NewLogEventPublisher creates an event publisher backed by the application logger
+func NewLogEventPublisher(log logger.Logger) ports.EventPublisher {
+	return &LogEventPublisher{
+		logger: log.With("component", "event_publisher"),
+	}
+}
+
+// Publish implements ports.EventPublisher
+func (p *LogEventPublisher) Publish(ctx context.Context, event *entities.UserEvent) error {
+	p.logger.Info("Domain event published",
+		"type", event.Type,
+		"user_id", event.UserID,
+		"data", event.Data,
+		"occurred_at", event.OccurredAt)
+	return nil
+}
@@ -0,0 +1,47 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	"user-service/pkg/logger"
+)
+
+// StoringEventPublisher appends every domain event to the event store before
+// handing it to the next publisher, so the local history is complete even
+// when delivery to consumers fails
+type StoringEventPublisher struct {
+	store  ports.EventStore
+	next   ports.EventPublisher
+	logger logger.Logger
+}
+
+// NewStoringEventPublisher wraps next with event store persistence
+func NewStoringEventPublisher(store ports.EventStore, next ports.EventPublisher, log logger.Logger) ports.EventPublisher {
+	return &StoringEventPublisher{
+		store:  store,
+		next:   next,
+		logger: log.With("component", "storing_event_publisher"),
+	}
+}
+
+// Publish implements ports.EventPublisher. The event is forwarded even when it
+// could not be stored; both failures are reported.
+func (p *StoringEventPublisher) Publish(ctx context.Context, event *entities.UserEvent) error {
+	stored, storeErr := p.store.Append(ctx, event)
+	if storeErr != nil {
+		p.logger.Error("Failed to store domain event",
+			"type", event.Type,
+			"user_id", event.UserID,
+			"error", storeErr)
+	} else {
+		p.logger.Debug("Domain event stored",
+			"type", event.Type,
+			"sequence", stored.Sequence,
+			"version", stored.Version)
+	}
+
+	return errors.Join(storeErr, p.next.Publish(ctx, event))
+}
@@ -0,0 +1,93 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEventStore struct {
+	mock.Mock
+}
+
+func (m *MockEventStore) Append(ctx context.Context, event *entities.UserEvent) (*entities.StoredEvent, error) {
+	args := m.Called(ctx, event)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.StoredEvent), args.Error(1)
+}
+
+func (m *MockEventStore) ListSince(ctx context.Context, afterSequence uint64, limit int) ([]*entities.StoredEvent, error) {
+	args := m.Called(ctx, afterSequence, limit)
+	return args.Get(0).([]*entities.StoredEvent), args.Error(1)
+}
+
+func (m *MockEventStore) ListByAggregate(ctx context.Context, userID uint, afterVersion uint64, limit int) ([]*entities.StoredEvent, error) {
+	args := m.Called(ctx, userID, afterVersion, limit)
+	return args.Get(0).([]*entities.StoredEvent), args.Error(1)
+}
+
+func (m *MockEventStore) HandleOnce(ctx context.Context, handler string, sequence uint64, handle func(ctx context.Context) error) (bool, error) {
+	args := m.Called(ctx, handler, sequence, handle)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockEventStore) Checkpoint(ctx context.Context, handler string) (uint64, error) {
+	args := m.Called(ctx, handler)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+type MockEventPublisher struct {
+	mock.Mock
+}
+
+func (m *MockEventPublisher) Publish(ctx context.Context, event *entities.UserEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func TestStoringEventPublisher_StoresThenForwards(t *testing.T) {
+	// Given
+	store := new(MockEventStore)
+	next := new(MockEventPublisher)
+	publisher := NewStoringEventPublisher(store, next, logger.New("test"))
+	ctx := context.Background()
+	event := entities.NewUserEvent(entities.UserEventCreated, 7, nil)
+
+	store.On("Append", ctx, event).Return(&entities.StoredEvent{Sequence: 42, AggregateID: 7, Version: 1}, nil)
+	next.On("Publish", ctx, event).Return(nil)
+
+	// When
+	err := publisher.Publish(ctx, event)
+
+	// Then
+	assert.NoError(t, err)
+	store.AssertExpectations(t)
+	next.AssertExpectations(t)
+}
+
+func TestStoringEventPublisher_ForwardsWhenStoreFails(t *testing.T) {
+	// Given
+	store := new(MockEventStore)
+	next := new(MockEventPublisher)
+	publisher := NewStoringEventPublisher(store, next, logger.New("test"))
+	ctx := context.Background()
+	event := entities.NewUserEvent(entities.UserEventDeleted, 7, nil)
+
+	store.On("Append", ctx, event).Return(nil, domainErrors.ErrFailedToStoreEvent)
+	next.On("Publish", ctx, event).Return(nil)
+
+	// When
+	err := publisher.Publish(ctx, event)
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrFailedToStoreEvent)
+	next.AssertExpectations(t)
+}
@@ -0,0 +1,56 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+)
+
+// SuppressingEventPublisher drops events that would make the notification
+// service contact a suppressed address, and hands every other event to the
+// next publisher
+type SuppressingEventPublisher struct {
+	list   ports.SuppressionList
+	next   ports.EventPublisher
+	now    func() time.Time
+	logger logger.Logger
+}
+
+// NewSuppressingEventPublisher wraps next with suppression list enforcement
+func NewSuppressingEventPublisher(list ports.SuppressionList, next ports.EventPublisher, log logger.Logger) ports.EventPublisher {
+	return &SuppressingEventPublisher{
+		list:   list,
+		next:   next,
+		now:    time.Now,
+		logger: log.With("component", "suppressing_event_publisher"),
+	}
+}
+
+// Publish implements ports.EventPublisher. When the suppression list cannot
+// be checked the event is not published and the error is returned, so a
+// suppressed address is never contacted by mistake.
+func (p *SuppressingEventPublisher) Publish(ctx context.Context, event *entities.UserEvent) error {
+	if !event.Type.Notifies() || event.Recipient == "" {
+		return p.next.Publish(ctx, event)
+	}
+
+	suppression, err := p.list.Get(ctx, entities.CanonicalEmail(event.Recipient))
+	switch {
+	case errors.Is(err, domainErrors.ErrSuppressionNotFound):
+	case err != nil:
+		return err
+	case suppression.ActiveAt(p.now()):
+		p.logger.Info("Notification suppressed",
+			"type", event.Type,
+			"user_id", event.UserID,
+			"reason", suppression.Reason)
+		return nil
+	}
+
+	return p.next.Publish(ctx, event)
+}
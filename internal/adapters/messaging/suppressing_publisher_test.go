@@ -0,0 +1,112 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySuppressionList is an in-memory ports.SuppressionList
+type memorySuppressionList struct {
+	suppressions map[string]*entities.Suppression
+	err          error
+}
+
+func (l *memorySuppressionList) Get(_ context.Context, email string) (*entities.Suppression, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+	suppression, ok := l.suppressions[email]
+	if !ok {
+		return nil, domainErrors.ErrSuppressionNotFound
+	}
+	return suppression, nil
+}
+
+func (l *memorySuppressionList) Put(_ context.Context, suppression *entities.Suppression) error {
+	l.suppressions[suppression.Email] = suppression
+	return nil
+}
+
+func (l *memorySuppressionList) Delete(_ context.Context, email string) error {
+	delete(l.suppressions, email)
+	return nil
+}
+
+func setupSuppressingPublisher(suppressions ...*entities.Suppression) (*SuppressingEventPublisher, *memorySuppressionList, *MockEventPublisher) {
+	list := &memorySuppressionList{suppressions: make(map[string]*entities.Suppression)}
+	for _, suppression := range suppressions {
+		list.suppressions[suppression.Email] = suppression
+	}
+	next := new(MockEventPublisher)
+	publisher := NewSuppressingEventPublisher(list, next, logger.New("test")).(*SuppressingEventPublisher)
+	publisher.now = func() time.Time { return time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC) }
+	return publisher, list, next
+}
+
+func digestFor(email string) *entities.UserEvent {
+	return entities.NewUserEvent(entities.UserEventActivityDigest, 1, nil).About(&entities.User{ID: 1, Email: email})
+}
+
+func TestSuppressingEventPublisher_DropsNotificationsToSuppressedAddresses(t *testing.T) {
+	// Given an address that bounced
+	publisher, _, next := setupSuppressingPublisher(&entities.Suppression{Email: "jane@example.com", Reason: entities.SuppressionReasonBounced})
+
+	// When a digest for a variant of it is published
+	err := publisher.Publish(context.Background(), digestFor("Jane+alerts@Example.com"))
+
+	// Then it never reaches the notification service
+	require.NoError(t, err)
+	next.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestSuppressingEventPublisher_DropsVerificationEmailsToSuppressedAddresses(t *testing.T) {
+	// Given an address whose owner unsubscribed
+	publisher, _, next := setupSuppressingPublisher(&entities.Suppression{Email: "jane@example.com", Reason: entities.SuppressionReasonUnsubscribed})
+	user := &entities.User{ID: 1, Email: "jane@example.com"}
+
+	// When they ask for another verification email
+	err := publisher.Publish(context.Background(), entities.NewUserEvent(entities.UserEventVerificationRequested, user.ID, nil).About(user))
+
+	// Then none is sent
+	require.NoError(t, err)
+	next.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestSuppressingEventPublisher_PublishesOtherEvents(t *testing.T) {
+	lapsed := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	publisher, _, next := setupSuppressingPublisher(
+		&entities.Suppression{Email: "jane@example.com", Reason: entities.SuppressionReasonBounced},
+		&entities.Suppression{Email: "john@example.com", Reason: entities.SuppressionReasonUnsubscribed, ExpiresAt: &lapsed},
+	)
+	ctx := context.Background()
+	next.On("Publish", ctx, mock.Anything).Return(nil)
+
+	updated := entities.NewUserEvent(entities.UserEventUpdated, 1, nil).About(&entities.User{ID: 1, Email: "jane@example.com"})
+	require.NoError(t, publisher.Publish(ctx, updated), "events that notify nobody are not suppressed")
+	require.NoError(t, publisher.Publish(ctx, digestFor("john@example.com")), "lapsed suppressions no longer apply")
+	require.NoError(t, publisher.Publish(ctx, digestFor("ann@example.com")))
+
+	next.AssertNumberOfCalls(t, "Publish", 3)
+}
+
+func TestSuppressingEventPublisher_FailsWhenListIsUnavailable(t *testing.T) {
+	// Given a suppression list that cannot be read
+	publisher, list, next := setupSuppressingPublisher()
+	list.err = domainErrors.ErrFailedToCheckSuppressions
+
+	// When
+	err := publisher.Publish(context.Background(), digestFor("jane@example.com"))
+
+	// Then the notification is held back rather than risked
+	assert.ErrorIs(t, err, domainErrors.ErrFailedToCheckSuppressions)
+	next.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
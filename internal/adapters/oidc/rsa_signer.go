@@ -0,0 +1,165 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"user-service/internal/application/ports"
+)
+
+const algorithmRS256 = "RS256"
+
+var errInvalidToken = errors.New("invalid token")
+
+// RSASigner signs tokens with RS256 using a single RSA key
+type RSASigner struct {
+	key   *rsa.PrivateKey
+	keyID string
+}
+
+var _ ports.TokenSigner = (*RSASigner)(nil)
+
+// NewRSASigner creates a signer for key. The key ID is derived from the
+// public key, so it changes whenever the key is rotated.
+func NewRSASigner(key *rsa.PrivateKey) (*RSASigner, error) {
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode public key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return &RSASigner{key: key, keyID: base64.RawURLEncoding.EncodeToString(sum[:12])}, nil
+}
+
+// LoadRSASigner reads a PEM-encoded RSA private key (PKCS #1 or PKCS #8)
+func LoadRSASigner(path string) (*RSASigner, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("signing key %s is not PEM encoded", path)
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return NewRSASigner(key)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("signing key %s is not an RSA key", path)
+	}
+	return NewRSASigner(key)
+}
+
+// GenerateRSASigner creates a signer with a fresh 2048-bit key. Tokens it
+// signs do not survive a restart, so it is only meant for development.
+func GenerateRSASigner() (*RSASigner, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	return NewRSASigner(key)
+}
+
+// Sign implements ports.TokenSigner
+func (s *RSASigner) Sign(tokenType string, claims map[string]interface{}) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": algorithmRS256, "typ": tokenType, "kid": s.keyID})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Verify implements ports.TokenSigner
+func (s *RSASigner) Verify(tokenType, token string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errInvalidToken
+	}
+
+	var header struct {
+		Algorithm string `json:"alg"`
+		Type      string `json:"typ"`
+		KeyID     string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if header.Algorithm != algorithmRS256 || header.Type != tokenType || header.KeyID != s.keyID {
+		return nil, errInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errInvalidToken
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&s.key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, errInvalidToken
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// KeySet implements ports.TokenSigner
+func (s *RSASigner) KeySet() map[string]interface{} {
+	public := s.key.PublicKey
+	return map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": algorithmRS256,
+			"kid": s.keyID,
+			"n":   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+		}},
+	}
+}
+
+// Algorithm implements ports.TokenSigner
+func (s *RSASigner) Algorithm() string {
+	return algorithmRS256
+}
+
+func decodeSegment(segment string, target interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return errInvalidToken
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return errInvalidToken
+	}
+	return nil
+}
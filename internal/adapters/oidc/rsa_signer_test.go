@@ -0,0 +1,75 @@
+package oidc
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"user-service/internal/application/ports"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRSASigner_SignAndVerify(t *testing.T) {
+	signer, err := GenerateRSASigner()
+	require.NoError(t, err)
+
+	token, err := signer.Sign(ports.TokenTypeAccessToken, map[string]interface{}{"sub": "7", "scope": "openid"})
+	require.NoError(t, err)
+
+	claims, err := signer.Verify(ports.TokenTypeAccessToken, token)
+	require.NoError(t, err)
+	assert.Equal(t, "7", claims["sub"])
+	assert.Equal(t, "openid", claims["scope"])
+}
+
+func TestRSASigner_RejectsOtherTokens(t *testing.T) {
+	signer, err := GenerateRSASigner()
+	require.NoError(t, err)
+	other, err := GenerateRSASigner()
+	require.NoError(t, err)
+
+	idToken, err := signer.Sign(ports.TokenTypeIDToken, map[string]interface{}{"sub": "7"})
+	require.NoError(t, err)
+	_, err = signer.Verify(ports.TokenTypeAccessToken, idToken)
+	assert.Error(t, err, "an ID token is not an access token")
+
+	foreign, err := other.Sign(ports.TokenTypeAccessToken, map[string]interface{}{"sub": "7"})
+	require.NoError(t, err)
+	_, err = signer.Verify(ports.TokenTypeAccessToken, foreign)
+	assert.Error(t, err, "tokens of another key are rejected")
+
+	token, err := signer.Sign(ports.TokenTypeAccessToken, map[string]interface{}{"sub": "7"})
+	require.NoError(t, err)
+	parts := strings.Split(token, ".")
+	forged, err := signer.Sign(ports.TokenTypeAccessToken, map[string]interface{}{"sub": "1"})
+	require.NoError(t, err)
+	_, err = signer.Verify(ports.TokenTypeAccessToken, parts[0]+"."+strings.Split(forged, ".")[1]+"."+parts[2])
+	assert.Error(t, err, "tampered claims are rejected")
+}
+
+func TestLoadRSASigner(t *testing.T) {
+	// Given a PKCS #8 key on disk
+	generated, err := GenerateRSASigner()
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(generated.key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "signing.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+
+	// When
+	signer, err := LoadRSASigner(path)
+
+	// Then it publishes the same key
+	require.NoError(t, err)
+	assert.Equal(t, generated.KeySet(), signer.KeySet())
+	keys := signer.KeySet()["keys"].([]map[string]string)
+	assert.Equal(t, "RS256", keys[0]["alg"])
+	assert.Equal(t, "AQAB", keys[0]["e"])
+	assert.IsType(t, &rsa.PrivateKey{}, signer.key)
+}
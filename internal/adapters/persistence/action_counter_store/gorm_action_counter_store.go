@@ -0,0 +1,85 @@
+package action_counter_store
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+
+	"gorm.io/gorm"
+)
+
+// ActionCounterModel represents the database model for per-user action
+// counters; a user has at most one row per action. User IDs are only unique
+// within a residency region, which is empty while residency is disabled.
+type ActionCounterModel struct {
+	Residency   string    `gorm:"primaryKey;size:8;not null;default:''"`
+	UserID      uint      `gorm:"primaryKey"`
+	Action      string    `gorm:"primaryKey;size:50"`
+	Count       int       `gorm:"not null;default:0"`
+	WindowStart time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (ActionCounterModel) TableName() string {
+	return "user_action_counters"
+}
+
+// GormActionCounterStore implements the ActionCounterStore interface using GORM
+type GormActionCounterStore struct {
+	db *gorm.DB
+}
+
+// NewGormActionCounterStore creates a new GORM action counter store
+func NewGormActionCounterStore(db *gorm.DB) ports.ActionCounterStore {
+	return &GormActionCounterStore{db: db}
+}
+
+// incrementSQL counts an action in a single statement, so concurrent requests
+// cannot both slip under the limit. An expired window restarts at now.
+const incrementSQL = `
+INSERT INTO user_action_counters (residency, user_id, action, count, window_start)
+VALUES (@residency, @user_id, @action, 1, @now)
+ON CONFLICT (residency, user_id, action) DO UPDATE SET
+	count = CASE WHEN user_action_counters.window_start <= @expired THEN 1 ELSE user_action_counters.count + 1 END,
+	window_start = CASE WHEN user_action_counters.window_start <= @expired THEN EXCLUDED.window_start ELSE user_action_counters.window_start END
+RETURNING residency, user_id, action, count, window_start`
+
+// Increment implements ports.ActionCounterStore
+func (s *GormActionCounterStore) Increment(ctx context.Context, userID uint, action entities.SensitiveAction, now time.Time, window time.Duration) (*entities.ActionCount, error) {
+	var model ActionCounterModel
+	err := s.db.WithContext(ctx).Raw(incrementSQL, map[string]interface{}{
+		"residency": region(ctx),
+		"user_id":   userID,
+		"action":    string(action),
+		"now":       now.UTC(),
+		"expired":   now.UTC().Add(-window),
+	}).Scan(&model).Error
+	if err != nil {
+		return nil, domainErrors.ErrFailedToCountActions
+	}
+
+	return &entities.ActionCount{
+		UserID:      model.UserID,
+		Action:      entities.SensitiveAction(model.Action),
+		Count:       model.Count,
+		WindowStart: model.WindowStart,
+	}, nil
+}
+
+// Reset implements ports.ActionCounterStore
+func (s *GormActionCounterStore) Reset(ctx context.Context, userID uint) error {
+	err := s.db.WithContext(ctx).Where("residency = ? AND user_id = ?", region(ctx), userID).Delete(&ActionCounterModel{}).Error
+	if err != nil {
+		return domainErrors.ErrFailedToCountActions
+	}
+	return nil
+}
+
+// region returns the residency region ctx is scoped to, or empty
+func region(ctx context.Context) string {
+	residency, _ := ports.ResidencyFrom(ctx)
+	return string(residency)
+}
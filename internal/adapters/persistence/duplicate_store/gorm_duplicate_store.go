@@ -0,0 +1,117 @@
+package duplicate_store
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+
+	"gorm.io/gorm"
+)
+
+// insertBatchSize is the number of suggestions per INSERT statement
+const insertBatchSize = 500
+
+// DuplicateSuggestionModel represents the database model for duplicate suggestions
+type DuplicateSuggestionModel struct {
+	ID              uint      `gorm:"primarykey"`
+	UserID          uint      `gorm:"not null;uniqueIndex:idx_duplicate_pair"`
+	DuplicateUserID uint      `gorm:"not null;uniqueIndex:idx_duplicate_pair;index"`
+	Confidence      float64   `gorm:"not null;index"`
+	Signals         string    `gorm:"not null;size:100"`
+	DetectedAt      time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (DuplicateSuggestionModel) TableName() string {
+	return "duplicate_suggestions"
+}
+
+// GormDuplicateSuggestionStore implements the DuplicateSuggestionStore interface using GORM
+type GormDuplicateSuggestionStore struct {
+	db *gorm.DB
+}
+
+// NewGormDuplicateSuggestionStore creates a new GORM duplicate suggestion store
+func NewGormDuplicateSuggestionStore(db *gorm.DB) ports.DuplicateSuggestionStore {
+	return &GormDuplicateSuggestionStore{db: db}
+}
+
+// Replace implements ports.DuplicateSuggestionStore
+func (s *GormDuplicateSuggestionStore) Replace(ctx context.Context, suggestions []*entities.DuplicateSuggestion) error {
+	models := make([]DuplicateSuggestionModel, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		models = append(models, toModel(suggestion))
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&DuplicateSuggestionModel{}).Error; err != nil {
+			return err
+		}
+		if len(models) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(models, insertBatchSize).Error
+	})
+	if err != nil {
+		return domainErrors.ErrFailedToStoreDuplicates
+	}
+	return nil
+}
+
+// List implements ports.DuplicateSuggestionStore
+func (s *GormDuplicateSuggestionStore) List(ctx context.Context, minConfidence float64, limit, offset int) ([]*entities.DuplicateSuggestion, int64, error) {
+	query := s.db.WithContext(ctx).Model(&DuplicateSuggestionModel{}).Where("confidence >= ?", minConfidence)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, domainErrors.ErrFailedToListDuplicates
+	}
+
+	var models []DuplicateSuggestionModel
+	if err := query.Order("confidence DESC, id").Limit(limit).Offset(offset).Find(&models).Error; err != nil {
+		return nil, 0, domainErrors.ErrFailedToListDuplicates
+	}
+
+	suggestions := make([]*entities.DuplicateSuggestion, 0, len(models))
+	for i := range models {
+		suggestions = append(suggestions, toEntity(&models[i]))
+	}
+	return suggestions, total, nil
+}
+
+func toModel(suggestion *entities.DuplicateSuggestion) DuplicateSuggestionModel {
+	signals := make([]string, 0, len(suggestion.Signals))
+	for _, signal := range suggestion.Signals {
+		signals = append(signals, string(signal))
+	}
+
+	return DuplicateSuggestionModel{
+		UserID:          suggestion.UserID,
+		DuplicateUserID: suggestion.DuplicateUserID,
+		Confidence:      suggestion.Confidence,
+		Signals:         strings.Join(signals, ","),
+		DetectedAt:      suggestion.DetectedAt,
+	}
+}
+
+func toEntity(model *DuplicateSuggestionModel) *entities.DuplicateSuggestion {
+	var signals []entities.DuplicateSignal
+	for _, signal := range strings.Split(model.Signals, ",") {
+		if signal != "" {
+			signals = append(signals, entities.DuplicateSignal(signal))
+		}
+	}
+
+	return &entities.DuplicateSuggestion{
+		ID:              model.ID,
+		UserID:          model.UserID,
+		DuplicateUserID: model.DuplicateUserID,
+		Confidence:      model.Confidence,
+		Signals:         signals,
+		DetectedAt:      model.DetectedAt,
+	}
+}
@@ -0,0 +1,259 @@
+package event_store
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// appendAttempts bounds retries when concurrent appends race for the same version
+const appendAttempts = 5
+
+// settleWindow is how long a missing sequence may still be committed by an
+// append in flight. Sequences are drawn on insert, so events can commit out of
+// order; gaps older than this are left by appends that rolled back.
+const settleWindow = time.Minute
+
+// txKey carries the transaction of a HandleOnce call to the store calls its
+// handler makes
+type txKey struct{}
+
+// DomainEventModel represents the database model for stored domain events
+type DomainEventModel struct {
+	Sequence      uint64                 `gorm:"primaryKey;autoIncrement"`
+	Residency     string                 `gorm:"not null;size:8;default:'';uniqueIndex:idx_domain_events_region_aggregate_version,priority:1"`
+	AggregateType string                 `gorm:"not null;size:50;uniqueIndex:idx_domain_events_region_aggregate_version,priority:2"`
+	AggregateID   uint                   `gorm:"not null;uniqueIndex:idx_domain_events_region_aggregate_version,priority:3"`
+	Version       uint64                 `gorm:"not null;uniqueIndex:idx_domain_events_region_aggregate_version,priority:4"`
+	Type          string                 `gorm:"not null;size:100;index"`
+	Data          map[string]interface{} `gorm:"type:jsonb;serializer:json"`
+	OccurredAt    time.Time              `gorm:"not null"`
+	RecordedAt    time.Time              `gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (DomainEventModel) TableName() string {
+	return "domain_events"
+}
+
+// EventReceiptModel records that a handler processed an event
+type EventReceiptModel struct {
+	Handler   string    `gorm:"primaryKey;size:100"`
+	Sequence  uint64    `gorm:"primaryKey"`
+	HandledAt time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (EventReceiptModel) TableName() string {
+	return "event_handler_receipts"
+}
+
+// ObsoleteAggregateIndex kept versions unique per user ID alone, which
+// collides once users of several residency regions share the store
+const ObsoleteAggregateIndex = "idx_domain_events_aggregate_version"
+
+// GormEventStore implements the EventStore interface using GORM. Events are
+// recorded in the residency region of the context they are appended with, as
+// user IDs repeat across regions.
+type GormEventStore struct {
+	db *gorm.DB
+	// home is the region of unscoped contexts
+	home entities.Residency
+}
+
+// NewGormEventStore creates a new GORM event store
+func NewGormEventStore(db *gorm.DB, home entities.Residency) ports.EventStore {
+	return &GormEventStore{db: db, home: home}
+}
+
+// region returns the residency region ctx is scoped to
+func (s *GormEventStore) region(ctx context.Context) entities.Residency {
+	if region, ok := ports.ResidencyFrom(ctx); ok {
+		return region
+	}
+	return s.home
+}
+
+// inRegion filters events to those of region. Events recorded before
+// residency was enabled belong to the home region.
+func (s *GormEventStore) inRegion(db *gorm.DB, region entities.Residency) *gorm.DB {
+	if region == s.home {
+		return db.Where("residency IN ?", []string{string(region), ""})
+	}
+	return db.Where("residency = ?", string(region))
+}
+
+// conn returns the transaction of the HandleOnce call ctx belongs to, or the
+// database
+func (s *GormEventStore) conn(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return s.db.WithContext(ctx)
+}
+
+// Append implements ports.EventStore
+func (s *GormEventStore) Append(ctx context.Context, event *entities.UserEvent) (*entities.StoredEvent, error) {
+	model := &DomainEventModel{
+		Residency:     string(s.region(ctx)),
+		AggregateType: entities.UserAggregate,
+		AggregateID:   event.UserID,
+		Type:          string(event.Type),
+		Data:          event.Data,
+		OccurredAt:    event.OccurredAt,
+	}
+
+	var err error
+	for range appendAttempts {
+		err = s.conn(ctx).Transaction(func(tx *gorm.DB) error {
+			var current uint64
+			err := s.inRegion(tx.Model(&DomainEventModel{}), entities.Residency(model.Residency)).
+				Where("aggregate_type = ? AND aggregate_id = ?", model.AggregateType, model.AggregateID).
+				Select("COALESCE(MAX(version), 0)").
+				Scan(&current).Error
+			if err != nil {
+				return err
+			}
+
+			model.Sequence = 0
+			model.Version = current + 1
+			return tx.Create(model).Error
+		})
+		if !isDuplicateKey(err) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, domainErrors.ErrFailedToStoreEvent
+	}
+
+	return s.toEntity(model), nil
+}
+
+// ListSince implements ports.EventStore. Events are returned up to the first
+// recent gap in the sequence, as the missing event may still commit and a
+// cursor moved past it would skip it for good.
+func (s *GormEventStore) ListSince(ctx context.Context, afterSequence uint64, limit int) ([]*entities.StoredEvent, error) {
+	var models []DomainEventModel
+	err := s.conn(ctx).
+		Where("sequence > ?", afterSequence).
+		Order("sequence ASC").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, domainErrors.ErrFailedToReadEvents
+	}
+
+	settled := time.Now().Add(-settleWindow)
+	next := afterSequence + 1
+	for i, model := range models {
+		if model.Sequence != next && model.RecordedAt.After(settled) {
+			models = models[:i]
+			break
+		}
+		next = model.Sequence + 1
+	}
+
+	return s.toEntities(models), nil
+}
+
+// ListByAggregate implements ports.EventStore, reading the user of the region
+// ctx is scoped to
+func (s *GormEventStore) ListByAggregate(ctx context.Context, userID uint, afterVersion uint64, limit int) ([]*entities.StoredEvent, error) {
+	var models []DomainEventModel
+	err := s.inRegion(s.conn(ctx), s.region(ctx)).
+		Where("aggregate_type = ? AND aggregate_id = ? AND version > ?", entities.UserAggregate, userID, afterVersion).
+		Order("version ASC").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, domainErrors.ErrFailedToReadEvents
+	}
+
+	return s.toEntities(models), nil
+}
+
+// HandleOnce implements ports.EventStore. Inserting the receipt takes the row
+// lock, so a concurrent call for the same event waits for this one to commit
+// or roll back instead of running the handler twice. The handler's context
+// carries the transaction, so the events it stores commit with the receipt.
+func (s *GormEventStore) HandleOnce(ctx context.Context, handler string, sequence uint64, handle func(ctx context.Context) error) (bool, error) {
+	var handled bool
+
+	err := s.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&EventReceiptModel{Handler: handler, Sequence: sequence})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := handle(context.WithValue(ctx, txKey{}, tx)); err != nil {
+			return err
+		}
+		handled = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return handled, nil
+}
+
+// Checkpoint implements ports.EventStore
+func (s *GormEventStore) Checkpoint(ctx context.Context, handler string) (uint64, error) {
+	var checkpoint uint64
+	err := s.conn(ctx).Model(&EventReceiptModel{}).
+		Where("handler = ?", handler).
+		Select("COALESCE(MAX(sequence), 0)").
+		Scan(&checkpoint).Error
+	if err != nil {
+		return 0, domainErrors.ErrFailedToReadEvents
+	}
+
+	return checkpoint, nil
+}
+
+func (s *GormEventStore) toEntity(model *DomainEventModel) *entities.StoredEvent {
+	residency := entities.Residency(model.Residency)
+	if residency == "" {
+		residency = s.home
+	}
+	return &entities.StoredEvent{
+		Sequence:      model.Sequence,
+		AggregateType: model.AggregateType,
+		AggregateID:   model.AggregateID,
+		Residency:     residency,
+		Version:       model.Version,
+		Type:          entities.UserEventType(model.Type),
+		Data:          model.Data,
+		OccurredAt:    model.OccurredAt,
+		RecordedAt:    model.RecordedAt,
+	}
+}
+
+func (s *GormEventStore) toEntities(models []DomainEventModel) []*entities.StoredEvent {
+	events := make([]*entities.StoredEvent, 0, len(models))
+	for i := range models {
+		events = append(events, s.toEntity(&models[i]))
+	}
+	return events
+}
+
+func isDuplicateKey(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.Is(err, gorm.ErrDuplicatedKey) || strings.Contains(err.Error(), "duplicate key")
+}
@@ -0,0 +1,155 @@
+package job_store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxErrorLength bounds the stored error of a failed run
+const maxErrorLength = 2000
+
+// JobStateModel represents the database model for background job state
+type JobStateModel struct {
+	Name           string `gorm:"primaryKey;size:100"`
+	Paused         bool   `gorm:"not null;default:false"`
+	LastStatus     string `gorm:"not null;size:20;default:'never_run'"`
+	LastTrigger    string `gorm:"size:20"`
+	LastStartedAt  *time.Time
+	LastFinishedAt *time.Time
+	LastDurationMS int64  `gorm:"not null;default:0"`
+	LastError      string `gorm:"type:text"`
+	LeaseUntil     *time.Time
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (JobStateModel) TableName() string {
+	return "job_states"
+}
+
+// GormJobStateStore implements the JobStateStore interface using GORM
+type GormJobStateStore struct {
+	db *gorm.DB
+}
+
+// NewGormJobStateStore creates a new GORM job state store
+func NewGormJobStateStore(db *gorm.DB) ports.JobStateStore {
+	return &GormJobStateStore{db: db}
+}
+
+// Get implements ports.JobStateStore
+func (s *GormJobStateStore) Get(ctx context.Context, name string) (*entities.JobState, error) {
+	var model JobStateModel
+	err := s.db.WithContext(ctx).Where("name = ?", name).First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.NewJobState(name), nil
+	}
+	if err != nil {
+		return nil, domainErrors.ErrFailedToLoadJobState
+	}
+
+	return toEntity(&model), nil
+}
+
+// SetPaused implements ports.JobStateStore
+func (s *GormJobStateStore) SetPaused(ctx context.Context, name string, paused bool) (*entities.JobState, error) {
+	db := s.db.WithContext(ctx)
+	if err := s.ensure(db, name); err != nil {
+		return nil, err
+	}
+
+	if err := db.Model(&JobStateModel{}).Where("name = ?", name).Update("paused", paused).Error; err != nil {
+		return nil, domainErrors.ErrFailedToSaveJobState
+	}
+
+	return s.Get(ctx, name)
+}
+
+// Start implements ports.JobStateStore. The claim is taken with a conditional
+// update, so only one instance wins when several try at once.
+func (s *GormJobStateStore) Start(ctx context.Context, name string, trigger entities.JobTrigger, startedAt, leaseUntil time.Time) (bool, error) {
+	db := s.db.WithContext(ctx)
+	if err := s.ensure(db, name); err != nil {
+		return false, err
+	}
+
+	result := db.Model(&JobStateModel{}).
+		Where("name = ? AND (lease_until IS NULL OR lease_until < ?)", name, startedAt).
+		Updates(map[string]interface{}{
+			"last_status":     string(entities.JobStatusRunning),
+			"last_trigger":    string(trigger),
+			"last_started_at": startedAt,
+			"last_error":      "",
+			"lease_until":     leaseUntil,
+		})
+	if result.Error != nil {
+		return false, domainErrors.ErrFailedToSaveJobState
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// Finish implements ports.JobStateStore
+func (s *GormJobStateStore) Finish(ctx context.Context, name string, finishedAt time.Time, duration time.Duration, runErr error) error {
+	status, message := entities.JobStatusSucceeded, ""
+	if runErr != nil {
+		status, message = entities.JobStatusFailed, runErr.Error()
+		if len(message) > maxErrorLength {
+			message = message[:maxErrorLength]
+		}
+	}
+
+	err := s.db.WithContext(ctx).Model(&JobStateModel{}).
+		Where("name = ?", name).
+		Updates(map[string]interface{}{
+			"last_status":      string(status),
+			"last_finished_at": finishedAt,
+			"last_duration_ms": duration.Milliseconds(),
+			"last_error":       message,
+			"lease_until":      nil,
+		}).Error
+	if err != nil {
+		return domainErrors.ErrFailedToSaveJobState
+	}
+
+	return nil
+}
+
+// ensure creates the row of a job that has no stored state yet
+func (s *GormJobStateStore) ensure(db *gorm.DB, name string) error {
+	err := db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&JobStateModel{Name: name, LastStatus: string(entities.JobStatusNeverRun)}).Error
+	if err != nil {
+		return domainErrors.ErrFailedToSaveJobState
+	}
+	return nil
+}
+
+func toEntity(model *JobStateModel) *entities.JobState {
+	state := &entities.JobState{
+		Name:         model.Name,
+		Paused:       model.Paused,
+		LastStatus:   entities.JobStatus(model.LastStatus),
+		LastTrigger:  entities.JobTrigger(model.LastTrigger),
+		LastDuration: time.Duration(model.LastDurationMS) * time.Millisecond,
+		LastError:    model.LastError,
+	}
+	if model.LastStartedAt != nil {
+		state.LastStartedAt = *model.LastStartedAt
+	}
+	if model.LastFinishedAt != nil {
+		state.LastFinishedAt = *model.LastFinishedAt
+	}
+	if model.LeaseUntil != nil {
+		state.LeaseUntil = *model.LeaseUntil
+	}
+	return state
+}
@@ -0,0 +1,150 @@
+package note_repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+
+	"gorm.io/gorm"
+)
+
+// UserNoteModel represents the database model for support notes
+type UserNoteModel struct {
+	ID         uint           `gorm:"primarykey"`
+	UserID     uint           `gorm:"not null;index"`
+	Author     string         `gorm:"not null;size:100"`
+	Text       string         `gorm:"not null;type:text"`
+	Visibility string         `gorm:"not null;size:20;default:'internal'"`
+	CreatedAt  time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName specifies the table name for GORM
+func (UserNoteModel) TableName() string {
+	return "user_notes"
+}
+
+// GormUserNoteRepository implements the UserNoteRepository interface using GORM
+type GormUserNoteRepository struct {
+	db *gorm.DB
+}
+
+// NewGormUserNoteRepository creates a new GORM note repository
+func NewGormUserNoteRepository(db *gorm.DB) ports.UserNoteRepository {
+	return &GormUserNoteRepository{db: db}
+}
+
+// Create implements ports.UserNoteRepository
+func (r *GormUserNoteRepository) Create(ctx context.Context, note *entities.UserNote) (*entities.UserNote, error) {
+	model := r.toModel(note)
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return nil, r.handleError(err)
+	}
+
+	return r.toEntity(model), nil
+}
+
+// GetByID implements ports.UserNoteRepository
+func (r *GormUserNoteRepository) GetByID(ctx context.Context, userID, noteID uint) (*entities.UserNote, error) {
+	var model UserNoteModel
+
+	err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", noteID, userID).First(&model).Error
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+
+	return r.toEntity(&model), nil
+}
+
+// ListByUser implements ports.UserNoteRepository
+func (r *GormUserNoteRepository) ListByUser(ctx context.Context, userID uint, viewer string, limit, offset int) ([]*entities.UserNote, int64, error) {
+	query := r.db.WithContext(ctx).Model(&UserNoteModel{}).
+		Where("user_id = ?", userID).
+		Where("visibility <> ? OR author = ?", string(entities.NoteVisibilityPrivate), viewer)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, r.handleError(err)
+	}
+
+	var models []UserNoteModel
+	err := query.
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models).Error
+	if err != nil {
+		return nil, 0, r.handleError(err)
+	}
+
+	notes := make([]*entities.UserNote, 0, len(models))
+	for _, model := range models {
+		notes = append(notes, r.toEntity(&model))
+	}
+
+	return notes, total, nil
+}
+
+// Update implements ports.UserNoteRepository
+func (r *GormUserNoteRepository) Update(ctx context.Context, note *entities.UserNote) (*entities.UserNote, error) {
+	model := r.toModel(note)
+
+	err := r.db.WithContext(ctx).Model(model).
+		Select("Text", "Visibility", "UpdatedAt").
+		Updates(model).Error
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+
+	return r.toEntity(model), nil
+}
+
+// Delete implements ports.UserNoteRepository
+func (r *GormUserNoteRepository) Delete(ctx context.Context, userID, noteID uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", noteID, userID).Delete(&UserNoteModel{})
+	if result.Error != nil {
+		return r.handleError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrNoteNotFound
+	}
+	return nil
+}
+
+func (r *GormUserNoteRepository) toModel(note *entities.UserNote) *UserNoteModel {
+	return &UserNoteModel{
+		ID:         note.ID,
+		UserID:     note.UserID,
+		Author:     note.Author,
+		Text:       note.Text,
+		Visibility: string(note.Visibility),
+		CreatedAt:  note.CreatedAt,
+		UpdatedAt:  note.UpdatedAt,
+	}
+}
+
+func (r *GormUserNoteRepository) toEntity(model *UserNoteModel) *entities.UserNote {
+	return &entities.UserNote{
+		ID:         model.ID,
+		UserID:     model.UserID,
+		Author:     model.Author,
+		Text:       model.Text,
+		Visibility: entities.NoteVisibility(model.Visibility),
+		CreatedAt:  model.CreatedAt,
+		UpdatedAt:  model.UpdatedAt,
+	}
+}
+
+// Helper to convert GORM errors to domain errors
+func (r *GormUserNoteRepository) handleError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domainErrors.ErrNoteNotFound
+	}
+	return err
+}
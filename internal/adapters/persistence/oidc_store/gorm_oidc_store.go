@@ -0,0 +1,213 @@
+package oidc_store
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OIDCClientModel represents the database model for registered OIDC clients
+type OIDCClientModel struct {
+	ID            uint              `gorm:"primarykey"`
+	ClientID      string            `gorm:"not null;size:64;uniqueIndex"`
+	Name          string            `gorm:"not null;size:100"`
+	SecretHash    string            `gorm:"size:100"`
+	RedirectURIs  []string          `gorm:"type:jsonb;serializer:json;not null"`
+	Scopes        []string          `gorm:"type:jsonb;serializer:json;not null"`
+	ClaimMappings map[string]string `gorm:"type:jsonb;serializer:json"`
+	CreatedBy     string            `gorm:"not null;size:100"`
+	CreatedAt     time.Time         `gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (OIDCClientModel) TableName() string {
+	return "oidc_clients"
+}
+
+// AuthorizationCodeModel represents the database model for authorization codes
+// awaiting exchange
+type AuthorizationCodeModel struct {
+	CodeHash      string    `gorm:"primarykey;size:64"`
+	ClientID      string    `gorm:"not null;size:64"`
+	UserID        uint      `gorm:"not null"`
+	RedirectURI   string    `gorm:"not null;type:text"`
+	Scopes        string    `gorm:"not null;size:255"`
+	Nonce         string    `gorm:"size:255"`
+	CodeChallenge string    `gorm:"not null;size:128"`
+	AuthTime      time.Time `gorm:"not null"`
+	ExpiresAt     time.Time `gorm:"not null;index"`
+}
+
+// TableName specifies the table name for GORM
+func (AuthorizationCodeModel) TableName() string {
+	return "oidc_authorization_codes"
+}
+
+// GormOIDCClientRepository implements the OIDCClientRepository interface using GORM
+type GormOIDCClientRepository struct {
+	db *gorm.DB
+}
+
+// NewGormOIDCClientRepository creates a new GORM OIDC client repository
+func NewGormOIDCClientRepository(db *gorm.DB) ports.OIDCClientRepository {
+	return &GormOIDCClientRepository{db: db}
+}
+
+// Create implements ports.OIDCClientRepository
+func (r *GormOIDCClientRepository) Create(ctx context.Context, client *entities.OIDCClient) (*entities.OIDCClient, error) {
+	model := toClientModel(client)
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return nil, domainErrors.ErrFailedToRegisterOIDCClient
+	}
+	return toClientEntity(model), nil
+}
+
+// GetByClientID implements ports.OIDCClientRepository
+func (r *GormOIDCClientRepository) GetByClientID(ctx context.Context, clientID string) (*entities.OIDCClient, error) {
+	var model OIDCClientModel
+	err := r.db.WithContext(ctx).Where("client_id = ?", clientID).First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainErrors.ErrOIDCClientNotFound
+	}
+	if err != nil {
+		return nil, domainErrors.ErrFailedToLoadOIDCClients
+	}
+	return toClientEntity(&model), nil
+}
+
+// UpdateClaimMappings implements ports.OIDCClientRepository
+func (r *GormOIDCClientRepository) UpdateClaimMappings(ctx context.Context, clientID string, mappings map[string]string) (*entities.OIDCClient, error) {
+	// Updating through the model keeps the column's JSON serializer in play;
+	// Select makes an empty map clear the mappings rather than be skipped
+	result := r.db.WithContext(ctx).
+		Model(&OIDCClientModel{}).
+		Where("client_id = ?", clientID).
+		Select("ClaimMappings").
+		Updates(&OIDCClientModel{ClaimMappings: mappings})
+	if result.Error != nil {
+		return nil, domainErrors.ErrFailedToUpdateOIDCClient
+	}
+	if result.RowsAffected == 0 {
+		return nil, domainErrors.ErrOIDCClientNotFound
+	}
+	return r.GetByClientID(ctx, clientID)
+}
+
+// List implements ports.OIDCClientRepository
+func (r *GormOIDCClientRepository) List(ctx context.Context) ([]*entities.OIDCClient, error) {
+	var models []OIDCClientModel
+	if err := r.db.WithContext(ctx).Order("id").Find(&models).Error; err != nil {
+		return nil, domainErrors.ErrFailedToLoadOIDCClients
+	}
+
+	clients := make([]*entities.OIDCClient, 0, len(models))
+	for i := range models {
+		clients = append(clients, toClientEntity(&models[i]))
+	}
+	return clients, nil
+}
+
+// GormAuthorizationCodeStore implements the AuthorizationCodeStore interface using GORM
+type GormAuthorizationCodeStore struct {
+	db *gorm.DB
+}
+
+// NewGormAuthorizationCodeStore creates a new GORM authorization code store
+func NewGormAuthorizationCodeStore(db *gorm.DB) ports.AuthorizationCodeStore {
+	return &GormAuthorizationCodeStore{db: db}
+}
+
+// Save implements ports.AuthorizationCodeStore. Expired codes are pruned on
+// the way, which keeps the table small without a cleanup job.
+func (s *GormAuthorizationCodeStore) Save(ctx context.Context, code *entities.AuthorizationCode) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expires_at < ?", time.Now()).Delete(&AuthorizationCodeModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(toCodeModel(code)).Error
+	})
+	if err != nil {
+		return domainErrors.ErrFailedToIssueTokens
+	}
+	return nil
+}
+
+// Consume implements ports.AuthorizationCodeStore
+func (s *GormAuthorizationCodeStore) Consume(ctx context.Context, codeHash string) (*entities.AuthorizationCode, error) {
+	var models []AuthorizationCodeModel
+	err := s.db.WithContext(ctx).
+		Clauses(clause.Returning{}).
+		Where("code_hash = ?", codeHash).
+		Delete(&models).Error
+	if err != nil {
+		return nil, domainErrors.ErrFailedToIssueTokens
+	}
+	if len(models) == 0 {
+		return nil, domainErrors.ErrInvalidAuthorizationCode
+	}
+	return toCodeEntity(&models[0]), nil
+}
+
+func toClientModel(client *entities.OIDCClient) *OIDCClientModel {
+	return &OIDCClientModel{
+		ID:            client.ID,
+		ClientID:      client.ClientID,
+		Name:          client.Name,
+		SecretHash:    client.SecretHash,
+		RedirectURIs:  client.RedirectURIs,
+		Scopes:        client.Scopes,
+		ClaimMappings: client.ClaimMappings,
+		CreatedBy:     client.CreatedBy,
+		CreatedAt:     client.CreatedAt,
+	}
+}
+
+func toClientEntity(model *OIDCClientModel) *entities.OIDCClient {
+	return &entities.OIDCClient{
+		ID:            model.ID,
+		ClientID:      model.ClientID,
+		Name:          model.Name,
+		SecretHash:    model.SecretHash,
+		RedirectURIs:  model.RedirectURIs,
+		Scopes:        model.Scopes,
+		ClaimMappings: model.ClaimMappings,
+		CreatedBy:     model.CreatedBy,
+		CreatedAt:     model.CreatedAt,
+	}
+}
+
+func toCodeModel(code *entities.AuthorizationCode) *AuthorizationCodeModel {
+	return &AuthorizationCodeModel{
+		CodeHash:      code.CodeHash,
+		ClientID:      code.ClientID,
+		UserID:        code.UserID,
+		RedirectURI:   code.RedirectURI,
+		Scopes:        strings.Join(code.Scopes, " "),
+		Nonce:         code.Nonce,
+		CodeChallenge: code.CodeChallenge,
+		AuthTime:      code.AuthTime,
+		ExpiresAt:     code.ExpiresAt,
+	}
+}
+
+func toCodeEntity(model *AuthorizationCodeModel) *entities.AuthorizationCode {
+	return &entities.AuthorizationCode{
+		CodeHash:      model.CodeHash,
+		ClientID:      model.ClientID,
+		UserID:        model.UserID,
+		RedirectURI:   model.RedirectURI,
+		Scopes:        strings.Fields(model.Scopes),
+		Nonce:         model.Nonce,
+		CodeChallenge: model.CodeChallenge,
+		AuthTime:      model.AuthTime,
+		ExpiresAt:     model.ExpiresAt,
+	}
+}
@@ -0,0 +1,46 @@
+package persistence
+
+import (
+	"database/sql/driver"
+
+	"user-service/pkg/chaos"
+
+	"gorm.io/gorm"
+)
+
+// ChaosPlugin fails statements whose context was marked by the fault
+// injection middleware, simulating a dropped database connection
+type ChaosPlugin struct{}
+
+// Name implements gorm.Plugin
+func (ChaosPlugin) Name() string {
+	return "chaos"
+}
+
+// Initialize implements gorm.Plugin
+func (p ChaosPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+
+	if err := callbacks.Create().Before("gorm:create").Register("chaos:create", p.dropConnection); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("chaos:query", p.dropConnection); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("chaos:update", p.dropConnection); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("chaos:delete", p.dropConnection); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("chaos:row", p.dropConnection); err != nil {
+		return err
+	}
+	return callbacks.Raw().Before("gorm:raw").Register("chaos:raw", p.dropConnection)
+}
+
+func (ChaosPlugin) dropConnection(db *gorm.DB) {
+	if db.Statement.Context != nil && chaos.DroppedDB(db.Statement.Context) {
+		_ = db.AddError(driver.ErrBadConn)
+	}
+}
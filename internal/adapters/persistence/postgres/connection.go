@@ -6,19 +6,22 @@ import (
 	"fmt"
 	"time"
 
+	"user-service/internal/application/ports"
 	"user-service/internal/config"
 	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 type GormDB struct {
-	db     *gorm.DB
-	logger logger.Logger
+	db              *gorm.DB
+	logger          logger.Logger
+	degradedLatency time.Duration
 }
 
-func NewGormConnection(cfg *config.Config, log logger.Logger) (*GormDB, error) {
+func NewGormConnection(cfg *config.Config, log logger.Logger, registry *metrics.Registry) (*GormDB, error) {
 	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Database.Host, cfg.Database.Port, cfg.Database.Username, cfg.Database.Password, cfg.Database.Database, cfg.Database.SSLMode)
 
@@ -28,7 +31,8 @@ func NewGormConnection(cfg *config.Config, log logger.Logger) (*GormDB, error) {
 	customLogger := NewGormZapLoggerWithConfig(log, GormLoggerConfig{
 		LogLevel:                  gormLogLevel,
 		IgnoreRecordNotFoundError: true,
-		SlowThreshold:             200 * time.Millisecond,
+		SlowThreshold:             cfg.Database.SlowQuery.Threshold,
+		Metrics:                   registry,
 	})
 
 	gormConfig := &gorm.Config{
@@ -43,6 +47,28 @@ func NewGormConnection(cfg *config.Config, log logger.Logger) (*GormDB, error) {
 		return nil, fmt.Errorf("failed to connect to postgres with GORM: %w", err)
 	}
 
+	if cfg.Database.SlowQuery.Explain {
+		if cfg.IsProduction() && !cfg.Database.SlowQuery.ExplainInProduction {
+			log.Warn("Slow query EXPLAIN is configured but ignored in production")
+		} else if zapLogger, ok := customLogger.(*GormZapLogger); ok {
+			zapLogger.EnableExplain(db)
+			log.Info("Slow query EXPLAIN enabled", "threshold", cfg.Database.SlowQuery.Threshold)
+		}
+	}
+
+	if cfg.Database.QueryMetrics && registry != nil {
+		if err := db.Use(QueryMetricsPlugin{Registry: registry}); err != nil {
+			return nil, fmt.Errorf("failed to register query metrics plugin: %w", err)
+		}
+	}
+
+	if cfg.Chaos.Enabled && !cfg.IsProduction() {
+		if err := db.Use(ChaosPlugin{}); err != nil {
+			return nil, fmt.Errorf("failed to register chaos plugin: %w", err)
+		}
+		log.Warn("Database fault injection enabled")
+	}
+
 	// Get underlying sql.DB to configure connection pool
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -69,8 +95,9 @@ func NewGormConnection(cfg *config.Config, log logger.Logger) (*GormDB, error) {
 		"max_open_conns", cfg.Database.MaxOpenConns)
 
 	return &GormDB{
-		db:     db,
-		logger: log.With("component", "gorm"),
+		db:              db,
+		logger:          log.With("component", "gorm"),
+		degradedLatency: cfg.Health.DegradedLatency,
 	}, nil
 }
 
@@ -106,6 +133,55 @@ func (g *GormDB) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
+// CheckHealth implements ports.ComponentHealthChecker. The database is degraded
+// when it answers slowly or the connection pool is exhausted.
+func (g *GormDB) CheckHealth(ctx context.Context) ports.ComponentHealth {
+	start := time.Now()
+	err := g.HealthCheck(ctx)
+	latency := time.Since(start)
+
+	if err != nil {
+		return ports.ComponentHealth{
+			Status:  ports.HealthStatusUnhealthy,
+			Reason:  err.Error(),
+			Latency: latency,
+		}
+	}
+
+	if sqlDB, err := g.db.DB(); err == nil {
+		stats := sqlDB.Stats()
+		if stats.MaxOpenConnections > 0 && stats.InUse >= stats.MaxOpenConnections {
+			return ports.ComponentHealth{
+				Status:  ports.HealthStatusDegraded,
+				Reason:  fmt.Sprintf("connection pool exhausted (%d/%d in use)", stats.InUse, stats.MaxOpenConnections),
+				Latency: latency,
+			}
+		}
+	}
+
+	if g.degradedLatency > 0 && latency > g.degradedLatency {
+		return ports.ComponentHealth{
+			Status:  ports.HealthStatusDegraded,
+			Reason:  fmt.Sprintf("ping took %s (threshold %s)", latency, g.degradedLatency),
+			Latency: latency,
+		}
+	}
+
+	return ports.ComponentHealth{
+		Status:  ports.HealthStatusHealthy,
+		Latency: latency,
+	}
+}
+
+// ServerVersion returns the version reported by the PostgreSQL server
+func (g *GormDB) ServerVersion(ctx context.Context) (string, error) {
+	var version string
+	if err := g.db.WithContext(ctx).Raw("SHOW server_version").Scan(&version).Error; err != nil {
+		return "", fmt.Errorf("failed to read postgres server version: %w", err)
+	}
+	return version, nil
+}
+
 // AutoMigrate runs database migrations
 func (g *GormDB) AutoMigrate(models ...interface{}) error {
 	g.logger.Info("Running database migrations")
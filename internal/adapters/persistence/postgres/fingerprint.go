@@ -0,0 +1,26 @@
+package persistence
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	fingerprintStrings     = regexp.MustCompile(`'(?:[^']|'')*'`)
+	fingerprintNumbers     = regexp.MustCompile(`\b\d+(?:\.\d+)?\b`)
+	fingerprintParams      = regexp.MustCompile(`\$\d+`)
+	fingerprintLists       = regexp.MustCompile(`\(\s*\?(?:\s*,\s*\?)+\s*\)`)
+	fingerprintWhitespace  = regexp.MustCompile(`\s+`)
+	fingerprintPlaceholder = "?"
+)
+
+// Fingerprint normalizes a SQL statement so that queries differing only in
+// their literal values share the same fingerprint
+func Fingerprint(sql string) string {
+	fingerprint := fingerprintStrings.ReplaceAllString(sql, fingerprintPlaceholder)
+	fingerprint = fingerprintParams.ReplaceAllString(fingerprint, fingerprintPlaceholder)
+	fingerprint = fingerprintNumbers.ReplaceAllString(fingerprint, fingerprintPlaceholder)
+	fingerprint = fingerprintLists.ReplaceAllString(fingerprint, "(?...)")
+	fingerprint = fingerprintWhitespace.ReplaceAllString(fingerprint, " ")
+	return strings.TrimSpace(fingerprint)
+}
@@ -0,0 +1,27 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprint_ReplacesLiterals(t *testing.T) {
+	fingerprint := Fingerprint(`SELECT * FROM "users" WHERE email = 'john@example.com' AND id = 42 LIMIT 1`)
+
+	assert.Equal(t, `SELECT * FROM "users" WHERE email = ? AND id = ? LIMIT ?`, fingerprint)
+}
+
+func TestFingerprint_CollapsesListsAndWhitespace(t *testing.T) {
+	first := Fingerprint("SELECT * FROM \"user_tags\"\n  WHERE \"user_tags\".\"user_id\" IN (1,2,3)")
+	second := Fingerprint(`SELECT * FROM "user_tags" WHERE "user_tags"."user_id" IN ($1, $2)`)
+
+	assert.Equal(t, `SELECT * FROM "user_tags" WHERE "user_tags"."user_id" IN (?...)`, first)
+	assert.Equal(t, first, second)
+}
+
+func TestFingerprint_KeepsIdentifiersWithDigits(t *testing.T) {
+	fingerprint := Fingerprint(`SELECT t1.id FROM users t1 WHERE t1.name = 'it''s'`)
+
+	assert.Equal(t, `SELECT t1.id FROM users t1 WHERE t1.name = ?`, fingerprint)
+}
@@ -4,19 +4,29 @@ package persistence
 import (
 	"context"
 	"errors"
+	"regexp"
+	"strings"
 	"time"
 
 	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
 
+	"gorm.io/gorm"
 	gormLogger "gorm.io/gorm/logger"
 )
 
+const explainTimeout = 5 * time.Second
+
+var explainableStatement = regexp.MustCompile(`(?i)^\s*(select|insert|update|delete|with)\s`)
+
 // GormZapLogger adapts your zap logger to work with GORM
 type GormZapLogger struct {
 	logger                    logger.Logger
 	logLevel                  gormLogger.LogLevel
 	ignoreRecordNotFoundError bool
 	slowThreshold             time.Duration
+	metrics                   *metrics.Registry
+	explain                   *slowQueryExplainer
 }
 
 // NewGormZapLogger creates a new GORM logger using your zap logger
@@ -36,6 +46,7 @@ func NewGormZapLoggerWithConfig(zapLogger logger.Logger, config GormLoggerConfig
 		logLevel:                  config.LogLevel,
 		ignoreRecordNotFoundError: config.IgnoreRecordNotFoundError,
 		slowThreshold:             config.SlowThreshold,
+		metrics:                   config.Metrics,
 	}
 }
 
@@ -44,6 +55,18 @@ type GormLoggerConfig struct {
 	LogLevel                  gormLogger.LogLevel
 	IgnoreRecordNotFoundError bool
 	SlowThreshold             time.Duration
+	// Metrics receives slow query counts per fingerprint when set
+	Metrics *metrics.Registry
+}
+
+// EnableExplain makes the logger run EXPLAIN for slow queries against db and
+// log the plan. Plans are captured in the background, at most one at a time.
+func (l *GormZapLogger) EnableExplain(db *gorm.DB) {
+	l.explain = &slowQueryExplainer{
+		db:     db.Session(&gorm.Session{Logger: gormLogger.Discard, NewDB: true}),
+		logger: l.logger,
+		slots:  make(chan struct{}, 1),
+	}
 }
 
 // LogMode implements gorm.io/gorm/logger.Interface
@@ -94,7 +117,14 @@ func (l *GormZapLogger) Trace(ctx context.Context, begin time.Time, fc func() (s
 	case err != nil && l.logLevel >= gormLogger.Error && (!errors.Is(err, gormLogger.ErrRecordNotFound) || !l.ignoreRecordNotFoundError):
 		l.logger.Error("database query failed", append(fields, "error", err)...)
 	case elapsed > l.slowThreshold && l.slowThreshold != 0 && l.logLevel >= gormLogger.Warn:
-		l.logger.Warn("slow query detected", append(fields, "threshold", l.slowThreshold)...)
+		fingerprint := Fingerprint(sql)
+		if l.metrics != nil {
+			l.metrics.Counter("slow_queries_total").Inc("fingerprint", fingerprint)
+		}
+		l.logger.Warn("slow query detected", append(fields, "threshold", l.slowThreshold, "fingerprint", fingerprint)...)
+		if l.explain != nil {
+			l.explain.capture(sql, fingerprint)
+		}
 	case l.logLevel == gormLogger.Info:
 		l.logger.Debug("database query executed", fields...)
 	}
@@ -114,3 +144,58 @@ func StringToGormLogLevel(level string) gormLogger.LogLevel {
 		return gormLogger.Warn
 	}
 }
+
+// slowQueryExplainer logs the execution plan of slow statements
+type slowQueryExplainer struct {
+	db     *gorm.DB
+	logger logger.Logger
+	slots  chan struct{}
+}
+
+// capture runs EXPLAIN in the background so the slow request is not delayed
+// further; plans are skipped while another one is still being captured
+func (e *slowQueryExplainer) capture(sql, fingerprint string) {
+	if !explainableStatement.MatchString(sql) {
+		return
+	}
+
+	select {
+	case e.slots <- struct{}{}:
+	default:
+		return
+	}
+
+	go func() {
+		defer func() { <-e.slots }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), explainTimeout)
+		defer cancel()
+
+		plan, err := e.run(ctx, sql)
+		if err != nil {
+			e.logger.Warn("failed to explain slow query", "fingerprint", fingerprint, "error", err)
+			return
+		}
+
+		e.logger.Warn("slow query plan", "fingerprint", fingerprint, "plan", plan)
+	}()
+}
+
+func (e *slowQueryExplainer) run(ctx context.Context, sql string) (string, error) {
+	rows, err := e.db.WithContext(ctx).Raw("EXPLAIN " + sql).Rows()
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var lines []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return "", err
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n"), rows.Err()
+}
@@ -0,0 +1,44 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
+
+	"github.com/stretchr/testify/assert"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+func TestGormZapLogger_CountsSlowQueriesByFingerprint(t *testing.T) {
+	// Given
+	registry := metrics.NewRegistry()
+	log := NewGormZapLoggerWithConfig(logger.New("test"), GormLoggerConfig{
+		LogLevel:      gormLogger.Warn,
+		SlowThreshold: 10 * time.Millisecond,
+		Metrics:       registry,
+	})
+	begin := time.Now().Add(-time.Second)
+
+	// When
+	for _, id := range []string{"1", "2"} {
+		log.Trace(context.Background(), begin, func() (string, int64) {
+			return `SELECT * FROM "users" WHERE id = ` + id, 1
+		}, nil)
+	}
+	log.Trace(context.Background(), time.Now(), func() (string, int64) {
+		return `SELECT * FROM "users" WHERE id = 3`, 1
+	}, nil)
+
+	// Then
+	counter := registry.Counter("slow_queries_total")
+	assert.Equal(t, uint64(2), counter.Value("fingerprint", `SELECT * FROM "users" WHERE id = ?`))
+}
+
+func TestExplainableStatement(t *testing.T) {
+	assert.True(t, explainableStatement.MatchString(`SELECT * FROM "users"`))
+	assert.True(t, explainableStatement.MatchString(" update users set name = 'x'"))
+	assert.False(t, explainableStatement.MatchString("CREATE INDEX idx ON users (email)"))
+}
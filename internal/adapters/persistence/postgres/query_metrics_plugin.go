@@ -0,0 +1,100 @@
+package persistence
+
+import (
+	"errors"
+	"time"
+
+	"user-service/pkg/metrics"
+
+	"gorm.io/gorm"
+)
+
+const queryStartKey = "query_metrics:start"
+
+// QueryMetricsPlugin exports per-fingerprint query counts and latencies, so the
+// statements behind database load can be found without full query logging
+type QueryMetricsPlugin struct {
+	Registry *metrics.Registry
+}
+
+// Name implements gorm.Plugin
+func (QueryMetricsPlugin) Name() string {
+	return "query_metrics"
+}
+
+// Initialize implements gorm.Plugin
+func (p QueryMetricsPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+
+	if err := callbacks.Create().Before("gorm:create").Register("query_metrics:before_create", p.start); err != nil {
+		return err
+	}
+	if err := callbacks.Create().After("gorm:create").Register("query_metrics:after_create", p.observe("create")); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("query_metrics:before_query", p.start); err != nil {
+		return err
+	}
+	if err := callbacks.Query().After("gorm:query").Register("query_metrics:after_query", p.observe("query")); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("query_metrics:before_update", p.start); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register("query_metrics:after_update", p.observe("update")); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("query_metrics:before_delete", p.start); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("gorm:delete").Register("query_metrics:after_delete", p.observe("delete")); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("query_metrics:before_row", p.start); err != nil {
+		return err
+	}
+	if err := callbacks.Row().After("gorm:row").Register("query_metrics:after_row", p.observe("row")); err != nil {
+		return err
+	}
+	if err := callbacks.Raw().Before("gorm:raw").Register("query_metrics:before_raw", p.start); err != nil {
+		return err
+	}
+	return callbacks.Raw().After("gorm:raw").Register("query_metrics:after_raw", p.observe("raw"))
+}
+
+func (QueryMetricsPlugin) start(db *gorm.DB) {
+	db.InstanceSet(queryStartKey, time.Now())
+}
+
+func (p QueryMetricsPlugin) observe(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(queryStartKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		sql := db.Statement.SQL.String()
+		if sql == "" {
+			return
+		}
+
+		outcome := "ok"
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			outcome = "error"
+		}
+
+		fingerprint := Fingerprint(sql)
+		p.Registry.Counter("db_queries_total").Inc(
+			"fingerprint", fingerprint,
+			"operation", operation,
+			"outcome", outcome)
+		p.Registry.Histogram("db_query_duration_seconds", metrics.DefaultLatencyBuckets).Observe(
+			time.Since(start).Seconds(),
+			"fingerprint", fingerprint,
+			"operation", operation)
+	}
+}
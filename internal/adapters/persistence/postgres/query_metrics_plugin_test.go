@@ -0,0 +1,50 @@
+package persistence
+
+import (
+	"testing"
+
+	"user-service/pkg/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type queryMetricsTestModel struct {
+	ID    uint
+	Email string
+}
+
+func TestQueryMetricsPlugin_RecordsPerFingerprint(t *testing.T) {
+	// Given
+	db, err := gorm.Open(postgres.Open("host=localhost dbname=test"), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+
+	registry := metrics.NewRegistry()
+	require.NoError(t, db.Use(QueryMetricsPlugin{Registry: registry}))
+
+	// When
+	var model queryMetricsTestModel
+	db.Where("email = ?", "a@example.com").First(&model)
+	db.Where("email = ?", "b@example.com").First(&model)
+
+	// Then
+	snapshot := registry.Snapshot()["db_queries_total"]
+	require.Len(t, snapshot, 1)
+	for key, count := range snapshot {
+		assert.Contains(t, key, "operation=query")
+		assert.Contains(t, key, "outcome=ok")
+		assert.Contains(t, key, `email = ?`)
+		assert.Equal(t, uint64(2), count)
+	}
+
+	histograms := registry.HistogramSnapshot()["db_query_duration_seconds"]
+	require.Len(t, histograms, 1)
+	for _, value := range histograms {
+		assert.Equal(t, uint64(2), value.Count)
+	}
+}
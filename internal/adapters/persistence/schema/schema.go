@@ -0,0 +1,229 @@
+package schema
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"user-service/internal/adapters/persistence/action_counter_store"
+	"user-service/internal/adapters/persistence/duplicate_store"
+	"user-service/internal/adapters/persistence/event_store"
+	"user-service/internal/adapters/persistence/job_store"
+	"user-service/internal/adapters/persistence/note_repository"
+	"user-service/internal/adapters/persistence/oidc_store"
+	"user-service/internal/adapters/persistence/suppression_store"
+	"user-service/internal/adapters/persistence/usage_store"
+	"user-service/internal/adapters/persistence/user_repository"
+
+	"gorm.io/gorm"
+	gormSchema "gorm.io/gorm/schema"
+)
+
+// VersionModel records each successful migration, so a running service can
+// tell which schema the database is at
+type VersionModel struct {
+	ID             uint      `gorm:"primarykey"`
+	ServiceVersion string    `gorm:"size:100;not null"`
+	Checksum       string    `gorm:"size:64;not null"`
+	AppliedAt      time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (VersionModel) TableName() string {
+	return "schema_versions"
+}
+
+// Version is the schema a database was last migrated to
+type Version struct {
+	ServiceVersion string
+	Checksum       string
+	AppliedAt      time.Time
+}
+
+// Models returns every model stored in the primary database
+func Models() []interface{} {
+	return []interface{}{
+		&user_repository.UserModel{},
+		&user_repository.UserTagModel{},
+		&user_repository.UserExternalIDModel{},
+		&note_repository.UserNoteModel{},
+		&event_store.DomainEventModel{},
+		&event_store.EventReceiptModel{},
+		&job_store.JobStateModel{},
+		&duplicate_store.DuplicateSuggestionModel{},
+		&action_counter_store.ActionCounterModel{},
+		&oidc_store.OIDCClientModel{},
+		&oidc_store.AuthorizationCodeModel{},
+		&suppression_store.SuppressionModel{},
+		&usage_store.UsageRollupModel{},
+		&usage_store.UsageActiveUserModel{},
+		&usage_store.UsageBatchModel{},
+		&usage_store.UsageExportModel{},
+		&VersionModel{},
+		// Resources added by "user-service scaffold resource" (scaffold:models)
+	}
+}
+
+// RegionalModels returns the models stored in dedicated residency databases
+func RegionalModels() []interface{} {
+	return []interface{}{
+		&user_repository.UserModel{},
+		&user_repository.UserTagModel{},
+		&user_repository.UserExternalIDModel{},
+		&VersionModel{},
+	}
+}
+
+// obsoleteIndex is an index replaced by one over other columns. AutoMigrate
+// only adds indexes, so it is dropped before migrating.
+type obsoleteIndex struct {
+	model interface{}
+	name  string
+}
+
+// obsoleteIndexes lists the indexes dropped by Migrate
+var obsoleteIndexes = []obsoleteIndex{
+	{&event_store.DomainEventModel{}, event_store.ObsoleteAggregateIndex},
+}
+
+// rekeyedTable is a table whose primary key gained column. AutoMigrate neither
+// adds primary key columns to existing tables nor changes their primary key,
+// so both are done before migrating.
+type rekeyedTable struct {
+	model  interface{}
+	column string
+}
+
+// rekeyedTables lists the tables rekeyed by Migrate
+var rekeyedTables = []rekeyedTable{
+	{&action_counter_store.ActionCounterModel{}, "Residency"},
+	{&usage_store.UsageActiveUserModel{}, "Residency"},
+}
+
+// rekeyTables adds the new key column to tables of the models being migrated
+// that lack it and rebuilds their primary key from the model; existing rows
+// take the column's default
+func rekeyTables(db *gorm.DB, models []interface{}) error {
+	cache := &sync.Map{}
+	for _, table := range rekeyedTables {
+		if !migrates(models, table.model) || !db.Migrator().HasTable(table.model) || db.Migrator().HasColumn(table.model, table.column) {
+			continue
+		}
+
+		parsed, err := gormSchema.Parse(table.model, cache, db.NamingStrategy)
+		if err != nil {
+			return fmt.Errorf("failed to parse model %T: %w", table.model, err)
+		}
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Migrator().AddColumn(table.model, table.column); err != nil {
+				return err
+			}
+			return tx.Exec(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s_pkey, ADD PRIMARY KEY (%s)",
+				parsed.Table, parsed.Table, strings.Join(parsed.PrimaryFieldDBNames, ", "))).Error
+		})
+		if err != nil {
+			return fmt.Errorf("failed to rekey table %s: %w", parsed.Table, err)
+		}
+	}
+	return nil
+}
+
+// migrates reports whether models include model
+func migrates(models []interface{}, model interface{}) bool {
+	for _, candidate := range models {
+		if fmt.Sprintf("%T", candidate) == fmt.Sprintf("%T", model) {
+			return true
+		}
+	}
+	return false
+}
+
+// dropObsoleteIndexes drops the obsolete indexes of the models being migrated
+func dropObsoleteIndexes(db *gorm.DB, models []interface{}) error {
+	for _, index := range obsoleteIndexes {
+		if !migrates(models, index.model) || !db.Migrator().HasTable(index.model) || !db.Migrator().HasIndex(index.model, index.name) {
+			continue
+		}
+		if err := db.Migrator().DropIndex(index.model, index.name); err != nil {
+			return fmt.Errorf("failed to drop index %s: %w", index.name, err)
+		}
+	}
+	return nil
+}
+
+// Checksum fingerprints the tables and columns models map to, so a database
+// migrated by another build of the service can be told apart
+func Checksum(db *gorm.DB, models []interface{}) (string, error) {
+	cache := &sync.Map{}
+	var lines []string
+
+	for _, model := range models {
+		parsed, err := gormSchema.Parse(model, cache, db.NamingStrategy)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse model %T: %w", model, err)
+		}
+		for _, field := range parsed.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("%s.%s %s %d pk=%t notnull=%t",
+				parsed.Table, field.DBName, field.DataType, field.Size, field.PrimaryKey, field.NotNull))
+		}
+	}
+
+	sort.Strings(lines)
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// Migrate migrates models and records the resulting schema version
+func Migrate(ctx context.Context, db *gorm.DB, models []interface{}, serviceVersion string) (*Version, error) {
+	db = db.WithContext(ctx)
+	if err := dropObsoleteIndexes(db, models); err != nil {
+		return nil, err
+	}
+	if err := rekeyTables(db, models); err != nil {
+		return nil, err
+	}
+	if err := db.AutoMigrate(models...); err != nil {
+		return nil, err
+	}
+
+	checksum, err := Checksum(db, models)
+	if err != nil {
+		return nil, err
+	}
+
+	record := VersionModel{ServiceVersion: serviceVersion, Checksum: checksum, AppliedAt: time.Now().UTC()}
+	if err := db.Create(&record).Error; err != nil {
+		return nil, fmt.Errorf("failed to record schema version: %w", err)
+	}
+
+	return &Version{ServiceVersion: record.ServiceVersion, Checksum: record.Checksum, AppliedAt: record.AppliedAt}, nil
+}
+
+// Current returns the schema version a database was last migrated to, or
+// nil when it was never migrated with version tracking
+func Current(ctx context.Context, db *gorm.DB) (*Version, error) {
+	db = db.WithContext(ctx)
+	if !db.Migrator().HasTable(&VersionModel{}) {
+		return nil, nil
+	}
+
+	var record VersionModel
+	err := db.Order("id DESC").First(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	return &Version{ServiceVersion: record.ServiceVersion, Checksum: record.Checksum, AppliedAt: record.AppliedAt}, nil
+}
@@ -0,0 +1,91 @@
+package suppression_store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SuppressionModel represents the database model for suppressed addresses;
+// an address has at most one row
+type SuppressionModel struct {
+	Email     string `gorm:"primaryKey;size:255"`
+	Reason    string `gorm:"not null;size:20"`
+	Source    string `gorm:"not null;size:100"`
+	Note      string `gorm:"size:500"`
+	ExpiresAt *time.Time
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (SuppressionModel) TableName() string {
+	return "email_suppressions"
+}
+
+// GormSuppressionList implements the SuppressionList interface using GORM
+type GormSuppressionList struct {
+	db *gorm.DB
+}
+
+// NewGormSuppressionList creates a new GORM suppression list
+func NewGormSuppressionList(db *gorm.DB) ports.SuppressionList {
+	return &GormSuppressionList{db: db}
+}
+
+// Get implements ports.SuppressionList
+func (l *GormSuppressionList) Get(ctx context.Context, email string) (*entities.Suppression, error) {
+	var model SuppressionModel
+	err := l.db.WithContext(ctx).Where("email = ?", email).First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainErrors.ErrSuppressionNotFound
+	}
+	if err != nil {
+		return nil, domainErrors.ErrFailedToCheckSuppressions
+	}
+
+	return &entities.Suppression{
+		Email:     model.Email,
+		Reason:    entities.SuppressionReason(model.Reason),
+		Source:    model.Source,
+		Note:      model.Note,
+		ExpiresAt: model.ExpiresAt,
+		CreatedAt: model.CreatedAt,
+	}, nil
+}
+
+// Put implements ports.SuppressionList
+func (l *GormSuppressionList) Put(ctx context.Context, suppression *entities.Suppression) error {
+	model := &SuppressionModel{
+		Email:     suppression.Email,
+		Reason:    string(suppression.Reason),
+		Source:    suppression.Source,
+		Note:      suppression.Note,
+		ExpiresAt: suppression.ExpiresAt,
+		CreatedAt: suppression.CreatedAt,
+	}
+
+	err := l.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(model).Error
+	if err != nil {
+		return domainErrors.ErrFailedToUpdateSuppressions
+	}
+	return nil
+}
+
+// Delete implements ports.SuppressionList
+func (l *GormSuppressionList) Delete(ctx context.Context, email string) error {
+	result := l.db.WithContext(ctx).Where("email = ?", email).Delete(&SuppressionModel{})
+	if result.Error != nil {
+		return domainErrors.ErrFailedToUpdateSuppressions
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrSuppressionNotFound
+	}
+	return nil
+}
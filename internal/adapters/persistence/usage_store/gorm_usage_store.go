@@ -0,0 +1,269 @@
+package usage_store
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// activeUsersPerInsert bounds the rows of one active user insert
+const activeUsersPerInsert = 1000
+
+// UsageRollupModel represents the database model for the API calls of one
+// tenant in one hour
+type UsageRollupModel struct {
+	Tenant   string    `gorm:"primaryKey;size:100"`
+	Hour     time.Time `gorm:"primaryKey"`
+	APICalls int64     `gorm:"not null;default:0"`
+	// Revision counts the flushes into the rollup; ExportedRevision is the
+	// revision last delivered to billing
+	Revision         int64 `gorm:"not null;default:0"`
+	ExportedRevision int64 `gorm:"not null;default:0;index"`
+}
+
+// TableName specifies the table name for GORM
+func (UsageRollupModel) TableName() string {
+	return "usage_rollups"
+}
+
+// UsageActiveUserModel records that a tenant's calls addressed a user in an
+// hour; active users are counted from these rows, so they stay distinct
+// across instances. User IDs are only unique within a residency region.
+type UsageActiveUserModel struct {
+	Tenant    string    `gorm:"primaryKey;size:100"`
+	Hour      time.Time `gorm:"primaryKey"`
+	Residency string    `gorm:"primaryKey;size:8;not null;default:''"`
+	UserID    uint      `gorm:"primaryKey"`
+}
+
+// TableName specifies the table name for GORM
+func (UsageActiveUserModel) TableName() string {
+	return "usage_active_users"
+}
+
+// UsageBatchModel records a flushed batch, so a retried flush is skipped
+type UsageBatchModel struct {
+	ID        string    `gorm:"primaryKey;size:64"`
+	FlushedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (UsageBatchModel) TableName() string {
+	return "usage_batches"
+}
+
+// UsageExportModel records the latest export of an hour
+type UsageExportModel struct {
+	Hour       time.Time `gorm:"primaryKey"`
+	Version    int       `gorm:"not null"`
+	ExportedAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (UsageExportModel) TableName() string {
+	return "usage_exports"
+}
+
+// GormUsageStore implements the UsageStore interface using GORM
+type GormUsageStore struct {
+	db *gorm.DB
+}
+
+// NewGormUsageStore creates a new GORM usage store
+func NewGormUsageStore(db *gorm.DB) ports.UsageStore {
+	return &GormUsageStore{db: db}
+}
+
+// addCallsSQL adds calls to a rollup and moves its revision, taking the row
+// lock until the flush commits
+const addCallsSQL = `
+INSERT INTO usage_rollups (tenant, hour, api_calls, revision, exported_revision)
+VALUES (@tenant, @hour, @calls, 1, 0)
+ON CONFLICT (tenant, hour) DO UPDATE SET
+	api_calls = usage_rollups.api_calls + EXCLUDED.api_calls,
+	revision = usage_rollups.revision + 1`
+
+// rollupsSQL selects rollups with the distinct users counted per rollup
+const rollupsSQL = `
+SELECT r.tenant, r.hour, r.api_calls, r.revision,
+	(SELECT COUNT(*) FROM usage_active_users a WHERE a.tenant = r.tenant AND a.hour = r.hour) AS active_users
+FROM usage_rollups r`
+
+// rollupRow is a row of rollupsSQL
+type rollupRow struct {
+	Tenant      string
+	Hour        time.Time
+	APICalls    int64
+	Revision    int64
+	ActiveUsers int64
+}
+
+// Flush implements ports.UsageStore. Inserting the batch receipt takes its
+// row lock, so a concurrent retry of the same batch waits for this flush to
+// commit or roll back instead of counting it twice.
+func (s *GormUsageStore) Flush(ctx context.Context, batch *entities.UsageBatch) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&UsageBatchModel{ID: batch.ID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		for _, delta := range batch.Deltas {
+			hour := delta.Hour.UTC()
+			users := make([]UsageActiveUserModel, len(delta.ActiveUsers))
+			for i, user := range delta.ActiveUsers {
+				users[i] = UsageActiveUserModel{Tenant: delta.Tenant, Hour: hour, Residency: string(user.Residency), UserID: user.ID}
+			}
+			if len(users) > 0 {
+				err := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(users, activeUsersPerInsert).Error
+				if err != nil {
+					return err
+				}
+			}
+
+			err := tx.Exec(addCallsSQL, map[string]interface{}{
+				"tenant": delta.Tenant,
+				"hour":   hour,
+				"calls":  delta.APICalls,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return domainErrors.ErrFailedToRecordUsage
+	}
+
+	return nil
+}
+
+// List implements ports.UsageStore
+func (s *GormUsageStore) List(ctx context.Context, tenant string, from, to time.Time) ([]*entities.UsageRollup, error) {
+	query := rollupsSQL + ` WHERE r.hour >= @from AND r.hour < @to`
+	params := map[string]interface{}{"from": from.UTC(), "to": to.UTC()}
+	if tenant != "" {
+		query += ` AND r.tenant = @tenant`
+		params["tenant"] = tenant
+	}
+
+	var rows []rollupRow
+	if err := s.db.WithContext(ctx).Raw(query+` ORDER BY r.hour, r.tenant`, params).Scan(&rows).Error; err != nil {
+		return nil, domainErrors.ErrFailedToReadUsage
+	}
+
+	return toEntities(rows), nil
+}
+
+// ActiveUsers implements ports.UsageStore
+func (s *GormUsageStore) ActiveUsers(ctx context.Context, tenant string, from, to time.Time) (map[string]int64, error) {
+	query := s.db.WithContext(ctx).Model(&UsageActiveUserModel{}).
+		Select("tenant, COUNT(DISTINCT (residency, user_id)) AS active_users").
+		Where("hour >= ? AND hour < ?", from.UTC(), to.UTC()).
+		Group("tenant")
+	if tenant != "" {
+		query = query.Where("tenant = ?", tenant)
+	}
+
+	var rows []struct {
+		Tenant      string
+		ActiveUsers int64
+	}
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, domainErrors.ErrFailedToReadUsage
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Tenant] = row.ActiveUsers
+	}
+	return counts, nil
+}
+
+// NextExport implements ports.UsageStore. The rollups are read in one
+// statement, so their calls, users and revisions are consistent.
+func (s *GormUsageStore) NextExport(ctx context.Context, before time.Time) (*entities.UsageExport, error) {
+	db := s.db.WithContext(ctx)
+
+	var hours []time.Time
+	err := db.Model(&UsageRollupModel{}).
+		Where("hour < ? AND revision > exported_revision", before.UTC()).
+		Order("hour").
+		Limit(1).
+		Pluck("hour", &hours).Error
+	if err != nil {
+		return nil, domainErrors.ErrFailedToReadUsage
+	}
+	if len(hours) == 0 {
+		return nil, nil
+	}
+	hour := hours[0].UTC()
+
+	var rows []rollupRow
+	if err := db.Raw(rollupsSQL+` WHERE r.hour = @hour ORDER BY r.tenant`, map[string]interface{}{"hour": hour}).Scan(&rows).Error; err != nil {
+		return nil, domainErrors.ErrFailedToReadUsage
+	}
+
+	var versions []int
+	if err := db.Model(&UsageExportModel{}).Where("hour = ?", hour).Pluck("version", &versions).Error; err != nil {
+		return nil, domainErrors.ErrFailedToReadUsage
+	}
+	version := 1
+	if len(versions) > 0 {
+		version = versions[0] + 1
+	}
+
+	return &entities.UsageExport{Hour: hour, Version: version, Rollups: toEntities(rows)}, nil
+}
+
+// CompleteExport implements ports.UsageStore
+func (s *GormUsageStore) CompleteExport(ctx context.Context, export *entities.UsageExport) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, rollup := range export.Rollups {
+			err := tx.Model(&UsageRollupModel{}).
+				Where("tenant = ? AND hour = ? AND exported_revision < ?", rollup.Tenant, export.Hour.UTC(), rollup.Revision).
+				Update("exported_revision", rollup.Revision).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "hour"}},
+			DoUpdates: clause.AssignmentColumns([]string{"version", "exported_at"}),
+		}).Create(&UsageExportModel{
+			Hour:       export.Hour.UTC(),
+			Version:    export.Version,
+			ExportedAt: time.Now().UTC(),
+		}).Error
+	})
+	if err != nil {
+		return domainErrors.ErrFailedToExportUsage
+	}
+
+	return nil
+}
+
+func toEntities(rows []rollupRow) []*entities.UsageRollup {
+	rollups := make([]*entities.UsageRollup, len(rows))
+	for i, row := range rows {
+		rollups[i] = &entities.UsageRollup{
+			Tenant:      row.Tenant,
+			Hour:        row.Hour.UTC(),
+			APICalls:    row.APICalls,
+			ActiveUsers: row.ActiveUsers,
+			Revision:    row.Revision,
+		}
+	}
+	return rollups
+}
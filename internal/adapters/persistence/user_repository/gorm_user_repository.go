@@ -3,28 +3,65 @@ package user_repository
 import (
 	"context"
 	"errors"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
+	"user-service/internal/adapters/searchtoken"
 	"user-service/internal/application/ports"
 	"user-service/internal/domain/entities"
 	domainErrors "user-service/internal/domain/errors"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // UserModel represents the database model for users
 type UserModel struct {
-	ID        uint           `gorm:"primarykey"`
-	Email     string         `gorm:"uniqueIndex;not null"`
-	Password  string         `gorm:"not null"`
-	FirstName string         `gorm:"not null"`
-	LastName  string         `gorm:"not null"`
-	Phone     string         `gorm:""`
-	Status    string         `gorm:"not null;default:'active'"`
-	CreatedAt time.Time      `gorm:"autoCreateTime"`
-	UpdatedAt time.Time      `gorm:"autoUpdateTime"`
-	DeletedAt gorm.DeletedAt `gorm:"index"` // For soft deletes
+	ID        uint   `gorm:"primarykey"`
+	Email     string `gorm:"uniqueIndex;not null"`
+	Password  string `gorm:"not null"`
+	FirstName string `gorm:"not null"`
+	LastName  string `gorm:"not null"`
+	Phone     string `gorm:""`
+	// PhoneToken is the search token of the normalized phone number, so
+	// lookups do not depend on how the number itself is stored
+	PhoneToken string `gorm:"size:64;not null;default:'';index"`
+	// Optional profile fields
+	DisplayName string     `gorm:"size:50;not null;default:''"`
+	Pronouns    string     `gorm:"size:40;not null;default:''"`
+	DateOfBirth *time.Time `gorm:"type:date"`
+	Status      string     `gorm:"not null;default:'active'"`
+	Residency   string     `gorm:"size:8;not null;default:'';index"`
+	Country     string     `gorm:"size:2;not null;default:'';index"`
+	// Referrals; users stored before codes existed get theirs from the
+	// migration backfill
+	ReferralCode string `gorm:"size:16;not null;default:'';uniqueIndex:idx_users_referral_code,where:referral_code <> ''"`
+	ReferredByID *uint  `gorm:"index"`
+	// VerificationSentAt is when a verification email was last requested
+	VerificationSentAt *time.Time `gorm:""`
+	// Suspension details, empty unless the user is suspended
+	SuspensionReason string     `gorm:"size:20;not null;default:''"`
+	SuspensionNote   string     `gorm:"size:1000;not null;default:''"`
+	ReactivateAt     *time.Time `gorm:"index"`
+	// Legal hold, cleared when released
+	LegalHold          bool       `gorm:"not null;default:false;index"`
+	LegalHoldReason    string     `gorm:"size:500;not null;default:''"`
+	LegalHoldPlacedBy  string     `gorm:"size:100;not null;default:''"`
+	LegalHoldPlacedAt  *time.Time `gorm:""`
+	LegalHoldExpiresAt *time.Time `gorm:""`
+	// Preferences
+	SecurityDigestOptOut  bool                  `gorm:"not null;default:false"`
+	Locale                string                `gorm:"size:35;not null;default:''"`
+	Timezone              string                `gorm:"size:64;not null;default:''"`
+	DisplayNameVisibility string                `gorm:"size:10;not null;default:'private'"`
+	PronounsVisibility    string                `gorm:"size:10;not null;default:'private'"`
+	Tags                  []UserTagModel        `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	ExternalIDs           []UserExternalIDModel `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	CreatedAt             time.Time             `gorm:"autoCreateTime"`
+	UpdatedAt             time.Time             `gorm:"autoUpdateTime"`
+	DeletedAt             gorm.DeletedAt        `gorm:"index"` // For soft deletes
 }
 
 // TableName specifies the table name for GORM
@@ -32,14 +69,47 @@ func (UserModel) TableName() string {
 	return "users"
 }
 
+// UserTagModel represents a free-form tag attached to a user
+type UserTagModel struct {
+	UserID    uint      `gorm:"primaryKey"`
+	Tag       string    `gorm:"primaryKey;size:50;index"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (UserTagModel) TableName() string {
+	return "user_tags"
+}
+
+// referralCodeIndex keeps referral codes unique
+const referralCodeIndex = "idx_users_referral_code"
+
+// externalIDIndex keeps an external ID to one user per source system
+const externalIDIndex = "idx_user_external_ids_source_external_id"
+
+// UserExternalIDModel represents the ID a source system knows a user by
+type UserExternalIDModel struct {
+	UserID     uint      `gorm:"primaryKey"`
+	Source     string    `gorm:"primaryKey;size:50;uniqueIndex:idx_user_external_ids_source_external_id,priority:1"`
+	ExternalID string    `gorm:"size:255;not null;uniqueIndex:idx_user_external_ids_source_external_id,priority:2"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (UserExternalIDModel) TableName() string {
+	return "user_external_ids"
+}
+
 // GormUserRepository implements the UserRepository interface using GORM
 type GormUserRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	tokens *searchtoken.Tokenizer
 }
 
-// NewGormUserRepository creates a new GORM user repository
-func NewGormUserRepository(db *gorm.DB) ports.UserRepository {
-	return &GormUserRepository{db: db}
+// NewGormUserRepository creates a new GORM user repository; tokens derives the
+// search tokens of phone numbers
+func NewGormUserRepository(db *gorm.DB, tokens *searchtoken.Tokenizer) ports.UserRepository {
+	return &GormUserRepository{db: db, tokens: tokens}
 }
 
 // Create implements ports.UserRepository
@@ -53,21 +123,129 @@ func (r *GormUserRepository) Create(ctx context.Context, user *entities.User) (*
 		return nil, domainErrors.ErrUserAlreadyExists
 	}
 
-	gormModel := r.toModel(user)
-
 	// Create user in database
-	if err := r.db.WithContext(ctx).Create(gormModel).Error; err != nil {
+	var gormModel *UserModel
+	err = drawingReferralCodes(user, func() error {
+		gormModel = r.toModel(user)
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(gormModel).Error; err != nil {
+				return err
+			}
+			return r.createExternalIDs(tx, gormModel, user.ExternalIDs)
+		})
+	})
+	if err != nil {
 		return nil, r.handleError(err)
 	}
 
 	return r.toEntity(gormModel), nil
 }
 
+// CreateBatch implements ports.UserRepository
+func (r *GormUserRepository) CreateBatch(ctx context.Context, users []*entities.User, batchSize int) ([]*entities.User, error) {
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	models := make([]*UserModel, 0, len(users))
+	for _, user := range users {
+		models = append(models, r.toModel(user))
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.CreateInBatches(models, batchSize).Error; err != nil {
+			return err
+		}
+		for i, model := range models {
+			if err := r.createExternalIDs(tx, model, users[i].ExternalIDs); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+
+	created := make([]*entities.User, 0, len(models))
+	for _, model := range models {
+		created = append(created, r.toEntity(model))
+	}
+
+	return created, nil
+}
+
+// UpsertByEmail implements ports.UserRepository. The existing row is locked
+// for the duration of the transaction so concurrent syncs apply in turn.
+func (r *GormUserRepository) UpsertByEmail(ctx context.Context, user *entities.User, resolve ports.UpsertResolver) (*entities.User, ports.UpsertOutcome, error) {
+	var result *entities.User
+	var outcome ports.UpsertOutcome
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var model UserModel
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("email = ?", user.Email).
+			First(&model).Error
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			created := r.toModel(user)
+			if err := tx.Create(created).Error; err != nil {
+				return err
+			}
+			result, outcome = r.toEntity(created), ports.UpsertCreated
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", model.ID).Find(&model.Tags).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", model.ID).Find(&model.ExternalIDs).Error; err != nil {
+			return err
+		}
+
+		existing := r.toEntity(&model)
+		if !resolve(existing) {
+			result, outcome = existing, ports.UpsertUnchanged
+			return nil
+		}
+
+		updated := r.toModel(existing)
+		err = tx.Model(&UserModel{}).Where("id = ?", existing.ID).Updates(map[string]interface{}{
+			"first_name":        updated.FirstName,
+			"last_name":         updated.LastName,
+			"phone":             updated.Phone,
+			"phone_token":       updated.PhoneToken,
+			"status":            updated.Status,
+			"suspension_reason": updated.SuspensionReason,
+			"suspension_note":   updated.SuspensionNote,
+			"reactivate_at":     updated.ReactivateAt,
+			"updated_at":        updated.UpdatedAt,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		result, outcome = existing, ports.UpsertUpdated
+		return nil
+	})
+	if err != nil {
+		if handled := r.handleError(err); handled != err {
+			return nil, "", handled
+		}
+		return nil, "", domainErrors.ErrFailedToSyncUser
+	}
+
+	return result, outcome, nil
+}
+
 // GetByID implements ports.UserRepository
 func (r *GormUserRepository) GetByID(ctx context.Context, id uint) (*entities.User, error) {
 	var model UserModel
 
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error
+	err := r.db.WithContext(ctx).Preload("Tags").Preload("ExternalIDs").Where("id = ?", id).First(&model).Error
 	if err != nil {
 		return nil, r.handleError(err)
 	}
@@ -79,7 +257,7 @@ func (r *GormUserRepository) GetByID(ctx context.Context, id uint) (*entities.Us
 func (r *GormUserRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
 	var model UserModel
 
-	err := r.db.WithContext(ctx).Where("email = ?", email).First(&model).Error
+	err := r.db.WithContext(ctx).Preload("Tags").Preload("ExternalIDs").Where("email = ?", email).First(&model).Error
 	if err != nil {
 		return nil, r.handleError(err)
 	}
@@ -87,6 +265,44 @@ func (r *GormUserRepository) GetByEmail(ctx context.Context, email string) (*ent
 	return r.toEntity(&model), nil
 }
 
+// GetByExternalID implements ports.UserRepository
+func (r *GormUserRepository) GetByExternalID(ctx context.Context, source, externalID string) (*entities.User, error) {
+	owner := r.db.Model(&UserExternalIDModel{}).
+		Select("user_id").
+		Where("source = ? AND external_id = ?", source, externalID)
+
+	var model UserModel
+	err := r.db.WithContext(ctx).Preload("Tags").Preload("ExternalIDs").Where("id IN (?)", owner).First(&model).Error
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+
+	return r.toEntity(&model), nil
+}
+
+// ListExternalIDs implements ports.UserRepository
+func (r *GormUserRepository) ListExternalIDs(ctx context.Context, userID uint) (map[string]string, error) {
+	var models []UserExternalIDModel
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return externalIDsOf(models), nil
+}
+
+// ExistsByID implements ports.UserRepository
+func (r *GormUserRepository) ExistsByID(ctx context.Context, id uint) (bool, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).Model(&UserModel{}).
+		Where("id = ?", id).
+		Limit(1).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return false, domainErrors.ErrFailedToCheckUserExistance
+	}
+
+	return len(ids) > 0, nil
+}
+
 // ExistsByEmail implements ports.UserRepository
 func (r *GormUserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 	var count int64
@@ -98,11 +314,86 @@ func (r *GormUserRepository) ExistsByEmail(ctx context.Context, email string) (b
 	return count > 0, nil
 }
 
+// GetByPhone implements ports.UserRepository
+func (r *GormUserRepository) GetByPhone(ctx context.Context, phone string) ([]*entities.User, error) {
+	token := PhoneToken(r.tokens, phone)
+	if token == "" {
+		return nil, nil
+	}
+
+	var models []UserModel
+	err := r.db.WithContext(ctx).Preload("Tags").Preload("ExternalIDs").
+		Where("phone_token = ?", token).
+		Order("id").
+		Limit(ports.MaxPhoneMatches).
+		Find(&models).Error
+	if err != nil {
+		return nil, domainErrors.ErrFailedToLookUpPhone
+	}
+
+	return r.toEntities(models), nil
+}
+
+// ExistsByPhone implements ports.UserRepository
+func (r *GormUserRepository) ExistsByPhone(ctx context.Context, phone string) (bool, error) {
+	token := PhoneToken(r.tokens, phone)
+	if token == "" {
+		return false, nil
+	}
+
+	var ids []uint
+	err := r.db.WithContext(ctx).Model(&UserModel{}).
+		Where("phone_token = ?", token).
+		Limit(1).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return false, domainErrors.ErrFailedToCheckUserExistance
+	}
+
+	return len(ids) > 0, nil
+}
+
+// GetByReferralCode implements ports.UserRepository
+func (r *GormUserRepository) GetByReferralCode(ctx context.Context, code string) (*entities.User, error) {
+	if code == "" {
+		return nil, domainErrors.ErrUserNotFound
+	}
+
+	var model UserModel
+	err := r.db.WithContext(ctx).Preload("Tags").Preload("ExternalIDs").Where("referral_code = ?", code).First(&model).Error
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+
+	return r.toEntity(&model), nil
+}
+
+// FindExistingEmails implements ports.UserRepository
+func (r *GormUserRepository) FindExistingEmails(ctx context.Context, emails []string) ([]string, error) {
+	if len(emails) == 0 {
+		return nil, nil
+	}
+
+	var existing []string
+	err := r.db.WithContext(ctx).Model(&UserModel{}).
+		Where("email IN ?", emails).
+		Pluck("email", &existing).Error
+	if err != nil {
+		return nil, domainErrors.ErrFailedToCheckUserExistance
+	}
+
+	return existing, nil
+}
+
 // List implements ports.UserRepository
-func (r *GormUserRepository) List(ctx context.Context, limit, offset int) ([]*entities.User, error) {
+func (r *GormUserRepository) List(ctx context.Context, filter ports.UserFilter, limit, offset int) ([]*entities.User, error) {
 	var models []UserModel
 
-	err := r.db.Model(&UserModel{}).
+	// A stable order keeps pages from overlapping
+	err := r.filtered(ctx, filter).
+		Preload("Tags").
+		Preload("ExternalIDs").
+		Order("id").
 		Limit(limit).
 		Offset(offset).
 		Find(&models).Error
@@ -114,10 +405,272 @@ func (r *GormUserRepository) List(ctx context.Context, limit, offset int) ([]*en
 	return r.toEntities(models), nil
 }
 
+// Count implements ports.UserRepository
+func (r *GormUserRepository) Count(ctx context.Context, filter ports.UserFilter) (int64, error) {
+	var total int64
+	if err := r.filtered(ctx, filter).Count(&total).Error; err != nil {
+		return 0, domainErrors.ErrFailedToListUsers
+	}
+	return total, nil
+}
+
+// filtered returns a query over the users matching filter
+func (r *GormUserRepository) filtered(ctx context.Context, filter ports.UserFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&UserModel{})
+
+	if len(filter.Residencies) > 0 {
+		query = query.Where("residency IN ?", filter.Residencies)
+	}
+
+	if filter.Email != "" {
+		query = query.Where("email = ?", filter.Email)
+	}
+
+	if filter.Country != "" {
+		query = query.Where("country = ?", filter.Country)
+	}
+
+	if filter.LegalHold {
+		query = query.Where("legal_hold")
+	}
+
+	if filter.ReferredBy != 0 {
+		query = query.Where("referred_by_id = ?", filter.ReferredBy)
+	}
+
+	if filter.ReactivationDueBy != nil {
+		query = query.Where("status = ? AND reactivate_at <= ?", string(entities.UserStatusSuspended), *filter.ReactivationDueBy)
+	}
+
+	if filter.AfterID != 0 {
+		query = query.Where("id > ?", filter.AfterID)
+	}
+
+	// Partial matches scan the table; they are only offered to support staff
+	// with small limits
+	if filter.EmailContains != "" {
+		query = query.Where("LOWER(email) LIKE ?", "%"+escapeLike(strings.ToLower(filter.EmailContains))+"%")
+	}
+
+	if filter.PhoneContains != "" {
+		query = query.Where("regexp_replace(phone, '[^0-9]', '', 'g') LIKE ?", "%"+escapeLike(filter.PhoneContains)+"%")
+	}
+
+	if len(filter.Tags) > 0 {
+		tagged := r.db.Model(&UserTagModel{}).
+			Select("user_id").
+			Where("tag IN ?", filter.Tags).
+			Group("user_id").
+			Having("COUNT(DISTINCT tag) = ?", len(filter.Tags))
+		query = query.Where("id IN (?)", tagged)
+	}
+
+	return query
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern, so fragments match
+// literally
+func escapeLike(fragment string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(fragment)
+}
+
+// AddTags implements ports.UserRepository
+func (r *GormUserRepository) AddTags(ctx context.Context, userID uint, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	models := make([]UserTagModel, 0, len(tags))
+	for _, tag := range tags {
+		models = append(models, UserTagModel{UserID: userID, Tag: tag})
+	}
+
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models).Error
+	if err != nil {
+		return domainErrors.ErrFailedToUpdateUserTags
+	}
+
+	return nil
+}
+
+// RemoveTags implements ports.UserRepository
+func (r *GormUserRepository) RemoveTags(ctx context.Context, userID uint, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND tag IN ?", userID, tags).
+		Delete(&UserTagModel{}).Error
+	if err != nil {
+		return domainErrors.ErrFailedToUpdateUserTags
+	}
+
+	return nil
+}
+
+// UpdateStatus implements ports.UserRepository
+func (r *GormUserRepository) UpdateStatus(ctx context.Context, user *entities.User, from entities.UserStatus) error {
+	model := r.toModel(user)
+	result := r.db.WithContext(ctx).Model(&UserModel{}).
+		Where("id = ? AND status = ?", user.ID, string(from)).
+		Updates(map[string]interface{}{
+			"status":            model.Status,
+			"suspension_reason": model.SuspensionReason,
+			"suspension_note":   model.SuspensionNote,
+			"reactivate_at":     model.ReactivateAt,
+			"updated_at":        time.Now(),
+		})
+	if result.Error != nil {
+		return domainErrors.ErrFailedToUpdateUserStatus
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	// Nothing matched: either the user is gone or its status moved on
+	exists, err := r.ExistsByID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domainErrors.ErrUserNotFound
+	}
+	return domainErrors.ErrStatusChanged
+}
+
+// UpdatePreferences implements ports.UserRepository
+func (r *GormUserRepository) UpdatePreferences(ctx context.Context, userID uint, preferences entities.UserPreferences) error {
+	result := r.db.WithContext(ctx).Model(&UserModel{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"security_digest_opt_out": preferences.SecurityDigestOptOut,
+			"locale":                  preferences.Locale,
+			"timezone":                preferences.Timezone,
+			"display_name_visibility": string(preferences.Visibility.DisplayName),
+			"pronouns_visibility":     string(preferences.Visibility.Pronouns),
+			"updated_at":              time.Now(),
+		})
+	if result.Error != nil {
+		return domainErrors.ErrFailedToUpdateUserPreferences
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrUserNotFound
+	}
+	return nil
+}
+
+// UpdateProfile implements ports.UserRepository
+func (r *GormUserRepository) UpdateProfile(ctx context.Context, user *entities.User) error {
+	result := r.db.WithContext(ctx).Model(&UserModel{}).
+		Where("id = ?", user.ID).
+		Updates(map[string]interface{}{
+			"display_name": user.DisplayName,
+			"pronouns":     user.Pronouns,
+			"country":      user.Country,
+			"updated_at":   time.Now(),
+		})
+	if result.Error != nil {
+		return domainErrors.ErrFailedToUpdateUserProfile
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrUserNotFound
+	}
+	return nil
+}
+
+// UpdateLegalHold implements ports.UserRepository
+func (r *GormUserRepository) UpdateLegalHold(ctx context.Context, user *entities.User) error {
+	// Selected columns are written even when zero, which clears a released hold
+	model := &UserModel{UpdatedAt: time.Now()}
+	setLegalHold(model, user.LegalHold)
+
+	result := r.db.WithContext(ctx).Model(&UserModel{}).
+		Where("id = ?", user.ID).
+		Select("LegalHold", "LegalHoldReason", "LegalHoldPlacedBy", "LegalHoldPlacedAt", "LegalHoldExpiresAt", "UpdatedAt").
+		Updates(model)
+	if result.Error != nil {
+		return domainErrors.ErrFailedToUpdateLegalHold
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrUserNotFound
+	}
+	return nil
+}
+
+// UpdateReferrer implements ports.UserRepository
+func (r *GormUserRepository) UpdateReferrer(ctx context.Context, user *entities.User) error {
+	result := r.db.WithContext(ctx).Model(&UserModel{}).
+		Where("id = ? AND referred_by_id IS NULL", user.ID).
+		Updates(map[string]interface{}{
+			"referred_by_id": user.ReferredBy,
+			"updated_at":     time.Now(),
+		})
+	if result.Error != nil {
+		return domainErrors.ErrFailedToUpdateReferral
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	// Nothing matched: either the user is gone or someone referred them first
+	exists, err := r.ExistsByID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domainErrors.ErrUserNotFound
+	}
+	return domainErrors.ErrAlreadyReferred
+}
+
+// UpdateVerificationSentAt implements ports.UserRepository
+func (r *GormUserRepository) UpdateVerificationSentAt(ctx context.Context, user *entities.User, previous *time.Time) error {
+	query := r.db.WithContext(ctx).Model(&UserModel{}).Where("id = ?", user.ID)
+	if previous == nil {
+		query = query.Where("verification_sent_at IS NULL")
+	} else {
+		query = query.Where("verification_sent_at = ?", *previous)
+	}
+
+	result := query.Update("verification_sent_at", user.VerificationSentAt)
+	if result.Error != nil {
+		return domainErrors.ErrFailedToUpdateVerification
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	// Nothing matched: either the user is gone or another request won
+	exists, err := r.ExistsByID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domainErrors.ErrUserNotFound
+	}
+	return domainErrors.ErrVerificationSentChanged
+}
+
+// Delete implements ports.UserRepository
+func (r *GormUserRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&UserModel{}, id)
+	if result.Error != nil {
+		return domainErrors.ErrFailedToDeleteUser
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrUserNotFound
+	}
+
+	return nil
+}
+
 // Helper functions for conversion between domain entities and GORM models
 
 func (r *GormUserRepository) toModel(user *entities.User) *UserModel {
-	return &UserModel{
+	model := &UserModel{
 		ID:        user.ID,
 		Email:     user.Email,
 		Password:  user.Password,
@@ -125,23 +678,141 @@ func (r *GormUserRepository) toModel(user *entities.User) *UserModel {
 		LastName:  user.LastName,
 		Phone:     user.Phone,
 		Status:    string(user.Status),
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+
+		PhoneToken:   PhoneToken(r.tokens, user.Phone),
+		Residency:    string(user.Residency),
+		Country:      user.Country,
+		ReferralCode: user.ReferralCode,
+		ReferredByID: user.ReferredBy,
+		CreatedAt:    user.CreatedAt,
+		UpdatedAt:    user.UpdatedAt,
+
+		VerificationSentAt: user.VerificationSentAt,
+
+		DisplayName: user.DisplayName,
+		Pronouns:    user.Pronouns,
+		DateOfBirth: user.DateOfBirth,
+
+		SecurityDigestOptOut:  user.Preferences.SecurityDigestOptOut,
+		Locale:                user.Preferences.Locale,
+		Timezone:              user.Preferences.Timezone,
+		DisplayNameVisibility: string(user.Preferences.Visibility.DisplayName),
+		PronounsVisibility:    string(user.Preferences.Visibility.Pronouns),
 	}
+
+	if user.Suspension != nil {
+		model.SuspensionReason = string(user.Suspension.Reason)
+		model.SuspensionNote = user.Suspension.Note
+		model.ReactivateAt = user.Suspension.ReactivateAt
+	}
+	setLegalHold(model, user.LegalHold)
+
+	return model
+}
+
+// setLegalHold stores hold in the legal hold columns, or clears them
+func setLegalHold(model *UserModel, hold *entities.LegalHold) {
+	if hold == nil {
+		return
+	}
+	model.LegalHold = true
+	model.LegalHoldReason = hold.Reason
+	model.LegalHoldPlacedBy = hold.PlacedBy
+	model.LegalHoldPlacedAt = &hold.PlacedAt
+	model.LegalHoldExpiresAt = hold.ExpiresAt
 }
 
 func (r *GormUserRepository) toEntity(model *UserModel) *entities.User {
-	return &entities.User{
-		ID:        model.ID,
-		Email:     model.Email,
-		Password:  model.Password,
-		FirstName: model.FirstName,
-		LastName:  model.LastName,
-		Phone:     model.Phone,
-		Status:    entities.UserStatus(model.Status),
+	tags := make([]string, 0, len(model.Tags))
+	for _, tag := range model.Tags {
+		tags = append(tags, tag.Tag)
+	}
+	slices.Sort(tags)
+
+	user := &entities.User{
+		ID:           model.ID,
+		Email:        model.Email,
+		Password:     model.Password,
+		FirstName:    model.FirstName,
+		LastName:     model.LastName,
+		Phone:        model.Phone,
+		DisplayName:  model.DisplayName,
+		Pronouns:     model.Pronouns,
+		DateOfBirth:  model.DateOfBirth,
+		Status:       entities.UserStatus(model.Status),
+		Residency:    entities.Residency(model.Residency),
+		Country:      model.Country,
+		Tags:         tags,
+		ExternalIDs:  externalIDsOf(model.ExternalIDs),
+		ReferralCode: model.ReferralCode,
+		ReferredBy:   model.ReferredByID,
+
+		VerificationSentAt: model.VerificationSentAt,
+
+		Preferences: entities.UserPreferences{
+			SecurityDigestOptOut: model.SecurityDigestOptOut,
+			Locale:               model.Locale,
+			Timezone:             model.Timezone,
+			Visibility: entities.ProfileVisibility{
+				DisplayName: entities.Visibility(model.DisplayNameVisibility),
+				Pronouns:    entities.Visibility(model.PronounsVisibility),
+			},
+		},
 		CreatedAt: model.CreatedAt,
 		UpdatedAt: model.UpdatedAt,
 	}
+
+	if model.SuspensionReason != "" {
+		user.Suspension = &entities.Suspension{
+			Reason:       entities.SuspensionReason(model.SuspensionReason),
+			Note:         model.SuspensionNote,
+			ReactivateAt: model.ReactivateAt,
+		}
+	}
+
+	if model.LegalHold {
+		user.LegalHold = &entities.LegalHold{
+			Reason:    model.LegalHoldReason,
+			PlacedBy:  model.LegalHoldPlacedBy,
+			ExpiresAt: model.LegalHoldExpiresAt,
+		}
+		if model.LegalHoldPlacedAt != nil {
+			user.LegalHold.PlacedAt = *model.LegalHoldPlacedAt
+		}
+	}
+
+	return user
+}
+
+// createExternalIDs stores the external IDs of a newly created user. They are
+// inserted explicitly rather than as an association, which GORM would insert
+// with ON CONFLICT DO NOTHING and so silently drop IDs already taken.
+func (r *GormUserRepository) createExternalIDs(tx *gorm.DB, model *UserModel, externalIDs map[string]string) error {
+	if len(externalIDs) == 0 {
+		return nil
+	}
+
+	models := make([]UserExternalIDModel, 0, len(externalIDs))
+	for _, source := range slices.Sorted(maps.Keys(externalIDs)) {
+		models = append(models, UserExternalIDModel{UserID: model.ID, Source: source, ExternalID: externalIDs[source]})
+	}
+	if err := tx.Create(&models).Error; err != nil {
+		return err
+	}
+	model.ExternalIDs = models
+	return nil
+}
+
+// externalIDsOf maps sources to external IDs, nil when there are none
+func externalIDsOf(models []UserExternalIDModel) map[string]string {
+	if len(models) == 0 {
+		return nil
+	}
+	ids := make(map[string]string, len(models))
+	for _, model := range models {
+		ids[model.Source] = model.ExternalID
+	}
+	return ids
 }
 
 func (r *GormUserRepository) toEntities(models []UserModel) []*entities.User {
@@ -162,6 +833,16 @@ func (r *GormUserRepository) handleError(err error) error {
 		return domainErrors.ErrUserNotFound
 	}
 
+	// The external ID index is checked first, duplicates otherwise mean the email
+	if strings.Contains(err.Error(), externalIDIndex) {
+		return domainErrors.ErrExternalIDTaken
+	}
+
+	// Create draws a new code on a collision, so one left means it kept colliding
+	if strings.Contains(err.Error(), referralCodeIndex) {
+		return domainErrors.ErrFailedToCreateUser
+	}
+
 	// Handle unique constraint violation for email
 	if errors.Is(err, gorm.ErrDuplicatedKey) ||
 		(err.Error() != "" && (strings.Contains(err.Error(), "duplicate key") ||
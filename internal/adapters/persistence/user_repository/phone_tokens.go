@@ -0,0 +1,61 @@
+package user_repository
+
+import (
+	"context"
+	"fmt"
+
+	"user-service/internal/adapters/searchtoken"
+	"user-service/internal/domain/entities"
+
+	"gorm.io/gorm"
+)
+
+// phoneTokenField names phone numbers in their search tokens
+const phoneTokenField = "phone"
+
+// phoneTokenBatchSize is the number of users read per backfill query
+const phoneTokenBatchSize = 500
+
+// PhoneToken returns the search token of a phone number's normalized digits,
+// or "" when it has none
+func PhoneToken(tokens *searchtoken.Tokenizer, phone string) string {
+	return tokens.Token(phoneTokenField, entities.NormalizePhone(phone))
+}
+
+// BackfillPhoneTokens recomputes the phone token of every user, so users
+// stored before tokens existed or under another key can be looked up. It
+// returns the number of users whose token changed.
+func BackfillPhoneTokens(ctx context.Context, db *gorm.DB, tokens *searchtoken.Tokenizer) (int, error) {
+	db = db.WithContext(ctx)
+	updated := 0
+	var lastID uint
+
+	for {
+		var models []UserModel
+		err := db.Select("id", "phone", "phone_token").
+			Where("id > ?", lastID).
+			Order("id").
+			Limit(phoneTokenBatchSize).
+			Find(&models).Error
+		if err != nil {
+			return updated, fmt.Errorf("failed to read users: %w", err)
+		}
+
+		for _, model := range models {
+			lastID = model.ID
+			token := PhoneToken(tokens, model.Phone)
+			if token == model.PhoneToken {
+				continue
+			}
+			err := db.Model(&UserModel{}).Where("id = ?", model.ID).UpdateColumn("phone_token", token).Error
+			if err != nil {
+				return updated, fmt.Errorf("failed to update phone token of user %d: %w", model.ID, err)
+			}
+			updated++
+		}
+
+		if len(models) < phoneTokenBatchSize {
+			return updated, nil
+		}
+	}
+}
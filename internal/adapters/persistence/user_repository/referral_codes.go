@@ -0,0 +1,62 @@
+package user_repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"user-service/internal/domain/entities"
+
+	"gorm.io/gorm"
+)
+
+// referralCodeBatchSize is the number of users given a code per backfill query
+const referralCodeBatchSize = 500
+
+// referralCodeAttempts is the number of referral codes drawn for a new user
+// before giving up
+const referralCodeAttempts = 3
+
+// drawingReferralCodes runs create, drawing a new referral code for user each
+// time the code collides with a stored one, up to referralCodeAttempts times
+func drawingReferralCodes(user *entities.User, create func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := create()
+		if err == nil || !strings.Contains(err.Error(), referralCodeIndex) || attempt == referralCodeAttempts {
+			return err
+		}
+		user.ReferralCode = entities.NewReferralCode()
+	}
+}
+
+// BackfillReferralCodes gives a referral code to every user stored before
+// codes existed, deleted ones included as codes stay unique across them. It
+// returns the number of users given one.
+func BackfillReferralCodes(ctx context.Context, db *gorm.DB) (int, error) {
+	db = db.WithContext(ctx)
+	updated := 0
+
+	for {
+		var ids []uint
+		err := db.Unscoped().Model(&UserModel{}).
+			Where("referral_code = ''").
+			Order("id").
+			Limit(referralCodeBatchSize).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return updated, fmt.Errorf("failed to read users: %w", err)
+		}
+
+		for _, id := range ids {
+			err := db.Unscoped().Model(&UserModel{}).Where("id = ?", id).UpdateColumn("referral_code", entities.NewReferralCode()).Error
+			if err != nil {
+				return updated, fmt.Errorf("failed to give user %d a referral code: %w", id, err)
+			}
+			updated++
+		}
+
+		if len(ids) < referralCodeBatchSize {
+			return updated, nil
+		}
+	}
+}
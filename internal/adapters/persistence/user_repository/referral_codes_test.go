@@ -0,0 +1,58 @@
+package user_repository
+
+import (
+	"errors"
+	"testing"
+
+	"user-service/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// errReferralCodeTaken is the unique violation of a colliding referral code
+var errReferralCodeTaken = errors.New(`ERROR: duplicate key value violates unique constraint "` + referralCodeIndex + `" (SQLSTATE 23505)`)
+
+func TestDrawingReferralCodes_DrawsANewCodeOnCollision(t *testing.T) {
+	// Given a code that is already taken
+	user := &entities.User{ReferralCode: "TAKEN123"}
+	var tried []string
+
+	// When
+	err := drawingReferralCodes(user, func() error {
+		tried = append(tried, user.ReferralCode)
+		if user.ReferralCode == "TAKEN123" {
+			return errReferralCodeTaken
+		}
+		return nil
+	})
+
+	// Then the user is stored under a fresh code
+	assert.NoError(t, err)
+	assert.Len(t, tried, 2)
+	assert.NotEqual(t, "TAKEN123", user.ReferralCode)
+}
+
+func TestDrawingReferralCodes_GivesUpAfterRepeatedCollisions(t *testing.T) {
+	attempts := 0
+
+	err := drawingReferralCodes(&entities.User{}, func() error {
+		attempts++
+		return errReferralCodeTaken
+	})
+
+	assert.ErrorIs(t, err, errReferralCodeTaken)
+	assert.Equal(t, referralCodeAttempts, attempts)
+}
+
+func TestDrawingReferralCodes_OtherFailuresAreNotRetried(t *testing.T) {
+	attempts := 0
+	failure := errors.New("connection refused")
+
+	err := drawingReferralCodes(&entities.User{ReferralCode: "KEEP1234"}, func() error {
+		attempts++
+		return failure
+	})
+
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, 1, attempts)
+}
@@ -0,0 +1,363 @@
+package user_repository
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+)
+
+// ResidencyRouter sends user persistence to the repository of the region the
+// context is scoped to. Every user read or written is checked against that
+// region, so regions sharing a database still cannot see each other's users.
+type ResidencyRouter struct {
+	regions map[entities.Residency]ports.UserRepository
+	// home is the region used for unscoped contexts and the one users stored
+	// before residency was tracked belong to
+	home entities.Residency
+}
+
+// NewResidencyRouter creates a router over one repository per region
+func NewResidencyRouter(regions map[entities.Residency]ports.UserRepository, home entities.Residency) ports.UserRepository {
+	return &ResidencyRouter{regions: regions, home: home}
+}
+
+// route returns the region ctx is scoped to and its repository
+func (r *ResidencyRouter) route(ctx context.Context) (entities.Residency, ports.UserRepository, error) {
+	region, ok := ports.ResidencyFrom(ctx)
+	if !ok {
+		region = r.home
+	}
+
+	repo, ok := r.regions[region]
+	if !ok {
+		return "", nil, domainErrors.ErrCrossRegionAccess
+	}
+	return region, repo, nil
+}
+
+// residencyOf returns the region a stored user is resident in
+func (r *ResidencyRouter) residencyOf(user *entities.User) entities.Residency {
+	if user.Residency == "" {
+		return r.home
+	}
+	return user.Residency
+}
+
+// assign stamps new users with region and rejects users resident elsewhere
+func (r *ResidencyRouter) assign(user *entities.User, region entities.Residency) error {
+	if user.Residency == "" {
+		user.Residency = region
+	}
+	if user.Residency != region {
+		return domainErrors.ErrCrossRegionAccess
+	}
+	return nil
+}
+
+// check rejects users read from region that are resident in another one
+func (r *ResidencyRouter) check(user *entities.User, err error, region entities.Residency) (*entities.User, error) {
+	if err != nil {
+		return nil, err
+	}
+	if r.residencyOf(user) != region {
+		return nil, domainErrors.ErrCrossRegionAccess
+	}
+	return user, nil
+}
+
+// owned verifies the user with id is resident in the region ctx is scoped to
+func (r *ResidencyRouter) owned(ctx context.Context, id uint) (ports.UserRepository, error) {
+	region, repo, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := repo.GetByID(ctx, id)
+	if _, err := r.check(user, err, region); err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+// Create implements ports.UserRepository
+func (r *ResidencyRouter) Create(ctx context.Context, user *entities.User) (*entities.User, error) {
+	region, repo, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.assign(user, region); err != nil {
+		return nil, err
+	}
+	return repo.Create(ctx, user)
+}
+
+// CreateBatch implements ports.UserRepository
+func (r *ResidencyRouter) CreateBatch(ctx context.Context, users []*entities.User, batchSize int) ([]*entities.User, error) {
+	region, repo, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		if err := r.assign(user, region); err != nil {
+			return nil, err
+		}
+	}
+	return repo.CreateBatch(ctx, users, batchSize)
+}
+
+// UpsertByEmail implements ports.UserRepository. An existing user resident in
+// another region is left untouched.
+func (r *ResidencyRouter) UpsertByEmail(ctx context.Context, user *entities.User, resolve ports.UpsertResolver) (*entities.User, ports.UpsertOutcome, error) {
+	region, repo, err := r.route(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := r.assign(user, region); err != nil {
+		return nil, "", err
+	}
+
+	crossRegion := false
+	result, outcome, err := repo.UpsertByEmail(ctx, user, func(existing *entities.User) bool {
+		if r.residencyOf(existing) != region {
+			crossRegion = true
+			return false
+		}
+		return resolve(existing)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if crossRegion {
+		return nil, "", domainErrors.ErrCrossRegionAccess
+	}
+	return result, outcome, nil
+}
+
+// GetByID implements ports.UserRepository
+func (r *ResidencyRouter) GetByID(ctx context.Context, id uint) (*entities.User, error) {
+	region, repo, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := repo.GetByID(ctx, id)
+	return r.check(user, err, region)
+}
+
+// GetByEmail implements ports.UserRepository
+func (r *ResidencyRouter) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
+	region, repo, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := repo.GetByEmail(ctx, email)
+	return r.check(user, err, region)
+}
+
+// GetByExternalID implements ports.UserRepository
+func (r *ResidencyRouter) GetByExternalID(ctx context.Context, source, externalID string) (*entities.User, error) {
+	region, repo, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := repo.GetByExternalID(ctx, source, externalID)
+	return r.check(user, err, region)
+}
+
+// ListExternalIDs implements ports.UserRepository
+func (r *ResidencyRouter) ListExternalIDs(ctx context.Context, userID uint) (map[string]string, error) {
+	// Not checked against the owner, which would miss deleted users
+	_, repo, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.ListExternalIDs(ctx, userID)
+}
+
+// ExistsByID implements ports.UserRepository
+func (r *ResidencyRouter) ExistsByID(ctx context.Context, id uint) (bool, error) {
+	return r.exists(r.GetByID(ctx, id))
+}
+
+// ExistsByEmail implements ports.UserRepository
+func (r *ResidencyRouter) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	return r.exists(r.GetByEmail(ctx, email))
+}
+
+// GetByPhone implements ports.UserRepository; users resident in other
+// regions sharing the database are left out
+func (r *ResidencyRouter) GetByPhone(ctx context.Context, phone string) ([]*entities.User, error) {
+	region, repo, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	users, err := repo.GetByPhone(ctx, phone)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(users, func(user *entities.User) bool {
+		return r.residencyOf(user) != region
+	}), nil
+}
+
+// ExistsByPhone implements ports.UserRepository
+func (r *ResidencyRouter) ExistsByPhone(ctx context.Context, phone string) (bool, error) {
+	users, err := r.GetByPhone(ctx, phone)
+	if err != nil {
+		return false, err
+	}
+	return len(users) > 0, nil
+}
+
+// GetByReferralCode implements ports.UserRepository; users only refer users
+// of their own region
+func (r *ResidencyRouter) GetByReferralCode(ctx context.Context, code string) (*entities.User, error) {
+	region, repo, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := repo.GetByReferralCode(ctx, code)
+	return r.check(user, err, region)
+}
+
+// exists turns a residency-checked lookup into an existence answer
+func (r *ResidencyRouter) exists(_ *entities.User, err error) (bool, error) {
+	if errors.Is(err, domainErrors.ErrUserNotFound) {
+		return false, nil
+	}
+	if errors.Is(err, domainErrors.ErrCrossRegionAccess) {
+		return false, err
+	}
+	if err != nil {
+		return false, domainErrors.ErrFailedToCheckUserExistance
+	}
+	return true, nil
+}
+
+// FindExistingEmails implements ports.UserRepository. It only answers which
+// emails are taken, which must include other regions sharing the database for
+// inserts to avoid the unique email constraint.
+func (r *ResidencyRouter) FindExistingEmails(ctx context.Context, emails []string) ([]string, error) {
+	_, repo, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.FindExistingEmails(ctx, emails)
+}
+
+// List implements ports.UserRepository
+func (r *ResidencyRouter) List(ctx context.Context, filter ports.UserFilter, limit, offset int) ([]*entities.User, error) {
+	repo, filter, err := r.scope(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return repo.List(ctx, filter, limit, offset)
+}
+
+// Count implements ports.UserRepository
+func (r *ResidencyRouter) Count(ctx context.Context, filter ports.UserFilter) (int64, error) {
+	repo, filter, err := r.scope(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return repo.Count(ctx, filter)
+}
+
+// scope returns the repository of the region ctx is scoped to and filter
+// narrowed to the users resident in it
+func (r *ResidencyRouter) scope(ctx context.Context, filter ports.UserFilter) (ports.UserRepository, ports.UserFilter, error) {
+	region, repo, err := r.route(ctx)
+	if err != nil {
+		return nil, filter, err
+	}
+
+	filter.Residencies = []entities.Residency{region}
+	if region == r.home {
+		filter.Residencies = append(filter.Residencies, "")
+	}
+	return repo, filter, nil
+}
+
+// AddTags implements ports.UserRepository
+func (r *ResidencyRouter) AddTags(ctx context.Context, userID uint, tags []string) error {
+	repo, err := r.owned(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return repo.AddTags(ctx, userID, tags)
+}
+
+// RemoveTags implements ports.UserRepository
+func (r *ResidencyRouter) RemoveTags(ctx context.Context, userID uint, tags []string) error {
+	repo, err := r.owned(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return repo.RemoveTags(ctx, userID, tags)
+}
+
+// UpdateStatus implements ports.UserRepository
+func (r *ResidencyRouter) UpdateStatus(ctx context.Context, user *entities.User, from entities.UserStatus) error {
+	repo, err := r.owned(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	return repo.UpdateStatus(ctx, user, from)
+}
+
+// UpdatePreferences implements ports.UserRepository
+func (r *ResidencyRouter) UpdatePreferences(ctx context.Context, userID uint, preferences entities.UserPreferences) error {
+	repo, err := r.owned(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return repo.UpdatePreferences(ctx, userID, preferences)
+}
+
+// UpdateProfile implements ports.UserRepository
+func (r *ResidencyRouter) UpdateProfile(ctx context.Context, user *entities.User) error {
+	repo, err := r.owned(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	return repo.UpdateProfile(ctx, user)
+}
+
+// UpdateLegalHold implements ports.UserRepository
+func (r *ResidencyRouter) UpdateLegalHold(ctx context.Context, user *entities.User) error {
+	repo, err := r.owned(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	return repo.UpdateLegalHold(ctx, user)
+}
+
+// UpdateReferrer implements ports.UserRepository
+func (r *ResidencyRouter) UpdateReferrer(ctx context.Context, user *entities.User) error {
+	repo, err := r.owned(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	return repo.UpdateReferrer(ctx, user)
+}
+
+// UpdateVerificationSentAt implements ports.UserRepository
+func (r *ResidencyRouter) UpdateVerificationSentAt(ctx context.Context, user *entities.User, previous *time.Time) error {
+	repo, err := r.owned(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	return repo.UpdateVerificationSentAt(ctx, user, previous)
+}
+
+// Delete implements ports.UserRepository
+func (r *ResidencyRouter) Delete(ctx context.Context, id uint) error {
+	repo, err := r.owned(ctx, id)
+	if err != nil {
+		return err
+	}
+	return repo.Delete(ctx, id)
+}
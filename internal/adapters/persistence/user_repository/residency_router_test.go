@@ -0,0 +1,212 @@
+package user_repository
+
+import (
+	"context"
+	"testing"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryUserRepository stores users in memory; methods the router tests do
+// not use panic through the embedded nil interface
+type memoryUserRepository struct {
+	ports.UserRepository
+	users   map[uint]*entities.User
+	filters []ports.UserFilter
+	deleted []uint
+}
+
+func newMemoryUserRepository(users ...*entities.User) *memoryUserRepository {
+	repo := &memoryUserRepository{users: map[uint]*entities.User{}}
+	for _, user := range users {
+		repo.users[user.ID] = user
+	}
+	return repo
+}
+
+func (m *memoryUserRepository) Create(ctx context.Context, user *entities.User) (*entities.User, error) {
+	user.ID = uint(len(m.users) + 100)
+	m.users[user.ID] = user
+	return user, nil
+}
+
+func (m *memoryUserRepository) GetByID(ctx context.Context, id uint) (*entities.User, error) {
+	if user, ok := m.users[id]; ok {
+		return user, nil
+	}
+	return nil, domainErrors.ErrUserNotFound
+}
+
+func (m *memoryUserRepository) UpsertByEmail(ctx context.Context, user *entities.User, resolve ports.UpsertResolver) (*entities.User, ports.UpsertOutcome, error) {
+	for _, existing := range m.users {
+		if existing.Email == user.Email {
+			if resolve(existing) {
+				return existing, ports.UpsertUpdated, nil
+			}
+			return existing, ports.UpsertUnchanged, nil
+		}
+	}
+	created, err := m.Create(ctx, user)
+	return created, ports.UpsertCreated, err
+}
+
+func (m *memoryUserRepository) List(ctx context.Context, filter ports.UserFilter, limit, offset int) ([]*entities.User, error) {
+	m.filters = append(m.filters, filter)
+	return nil, nil
+}
+
+func (m *memoryUserRepository) Delete(ctx context.Context, id uint) error {
+	m.deleted = append(m.deleted, id)
+	return nil
+}
+
+func TestResidencyRouter_CreateAssignsRequestRegion(t *testing.T) {
+	// Given
+	eu, us := newMemoryUserRepository(), newMemoryUserRepository()
+	router := NewResidencyRouter(map[entities.Residency]ports.UserRepository{
+		entities.ResidencyEU: eu,
+		entities.ResidencyUS: us,
+	}, entities.ResidencyEU)
+	ctx := ports.WithResidency(context.Background(), entities.ResidencyUS)
+
+	// When
+	created, err := router.Create(ctx, &entities.User{Email: "jane@example.com"})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, entities.ResidencyUS, created.Residency)
+	assert.Len(t, us.users, 1)
+	assert.Empty(t, eu.users)
+}
+
+func TestResidencyRouter_CreateRejectsForeignResidency(t *testing.T) {
+	// Given
+	eu := newMemoryUserRepository()
+	router := NewResidencyRouter(map[entities.Residency]ports.UserRepository{entities.ResidencyEU: eu}, entities.ResidencyEU)
+
+	// When
+	_, err := router.Create(context.Background(), &entities.User{Email: "jane@example.com", Residency: entities.ResidencyUS})
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrCrossRegionAccess)
+	assert.Empty(t, eu.users)
+}
+
+func TestResidencyRouter_BlocksCrossRegionReadsOnSharedDatabase(t *testing.T) {
+	// Given: both regions share one database
+	shared := newMemoryUserRepository(
+		&entities.User{ID: 1, Residency: entities.ResidencyEU},
+		&entities.User{ID: 2, Residency: entities.ResidencyUS},
+		&entities.User{ID: 3},
+	)
+	router := NewResidencyRouter(map[entities.Residency]ports.UserRepository{
+		entities.ResidencyEU: shared,
+		entities.ResidencyUS: shared,
+	}, entities.ResidencyEU)
+	usCtx := ports.WithResidency(context.Background(), entities.ResidencyUS)
+
+	// When
+	_, crossErr := router.GetByID(usCtx, 1)
+	_, legacyErr := router.GetByID(usCtx, 3)
+	own, ownErr := router.GetByID(usCtx, 2)
+	home, homeErr := router.GetByID(context.Background(), 3)
+
+	// Then
+	assert.ErrorIs(t, crossErr, domainErrors.ErrCrossRegionAccess)
+	assert.ErrorIs(t, legacyErr, domainErrors.ErrCrossRegionAccess, "users without residency belong to the home region")
+	require.NoError(t, ownErr)
+	assert.Equal(t, uint(2), own.ID)
+	require.NoError(t, homeErr)
+	assert.Equal(t, uint(3), home.ID)
+}
+
+func TestResidencyRouter_Exists(t *testing.T) {
+	// Given
+	shared := newMemoryUserRepository(&entities.User{ID: 1, Residency: entities.ResidencyEU})
+	router := NewResidencyRouter(map[entities.Residency]ports.UserRepository{
+		entities.ResidencyEU: shared,
+		entities.ResidencyUS: shared,
+	}, entities.ResidencyEU)
+
+	// When
+	exists, err := router.ExistsByID(context.Background(), 1)
+	missing, missingErr := router.ExistsByID(context.Background(), 9)
+	_, crossErr := router.ExistsByID(ports.WithResidency(context.Background(), entities.ResidencyUS), 1)
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, exists)
+	require.NoError(t, missingErr)
+	assert.False(t, missing)
+	assert.ErrorIs(t, crossErr, domainErrors.ErrCrossRegionAccess)
+}
+
+func TestResidencyRouter_DeleteChecksResidencyFirst(t *testing.T) {
+	// Given
+	shared := newMemoryUserRepository(&entities.User{ID: 1, Residency: entities.ResidencyEU})
+	router := NewResidencyRouter(map[entities.Residency]ports.UserRepository{
+		entities.ResidencyEU: shared,
+		entities.ResidencyUS: shared,
+	}, entities.ResidencyEU)
+
+	// When
+	err := router.Delete(ports.WithResidency(context.Background(), entities.ResidencyUS), 1)
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrCrossRegionAccess)
+	assert.Empty(t, shared.deleted)
+}
+
+func TestResidencyRouter_UpsertLeavesForeignUserUntouched(t *testing.T) {
+	// Given
+	shared := newMemoryUserRepository(&entities.User{ID: 1, Email: "jane@example.com", Residency: entities.ResidencyEU})
+	router := NewResidencyRouter(map[entities.Residency]ports.UserRepository{
+		entities.ResidencyEU: shared,
+		entities.ResidencyUS: shared,
+	}, entities.ResidencyEU)
+	resolved := false
+
+	// When
+	_, _, err := router.UpsertByEmail(ports.WithResidency(context.Background(), entities.ResidencyUS),
+		&entities.User{Email: "jane@example.com"},
+		func(existing *entities.User) bool {
+			resolved = true
+			return true
+		})
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrCrossRegionAccess)
+	assert.False(t, resolved)
+}
+
+func TestResidencyRouter_ListFiltersByRegion(t *testing.T) {
+	// Given
+	repo := newMemoryUserRepository()
+	router := NewResidencyRouter(map[entities.Residency]ports.UserRepository{
+		entities.ResidencyEU: repo,
+		entities.ResidencyUS: repo,
+	}, entities.ResidencyEU)
+
+	// When
+	_, _ = router.List(context.Background(), ports.UserFilter{Tags: []string{"vip"}}, 10, 0)
+	_, _ = router.List(ports.WithResidency(context.Background(), entities.ResidencyUS), ports.UserFilter{}, 10, 0)
+
+	// Then
+	require.Len(t, repo.filters, 2)
+	assert.Equal(t, []entities.Residency{entities.ResidencyEU, ""}, repo.filters[0].Residencies)
+	assert.Equal(t, []string{"vip"}, repo.filters[0].Tags)
+	assert.Equal(t, []entities.Residency{entities.ResidencyUS}, repo.filters[1].Residencies)
+}
+
+func TestResidencyRouter_UnknownRegion(t *testing.T) {
+	router := NewResidencyRouter(map[entities.Residency]ports.UserRepository{}, entities.ResidencyEU)
+
+	_, err := router.GetByID(context.Background(), 1)
+
+	assert.ErrorIs(t, err, domainErrors.ErrCrossRegionAccess)
+}
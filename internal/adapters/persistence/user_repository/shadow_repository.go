@@ -0,0 +1,327 @@
+package user_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
+)
+
+// maxLoggedDifferences caps the differing fields logged per mismatch
+const maxLoggedDifferences = 20
+
+// Shadow read outcomes
+const (
+	shadowMatch    = "match"
+	shadowMismatch = "mismatch"
+	shadowTimeout  = "timeout"
+	// shadowSkipped means too many mirrored reads were in flight
+	shadowSkipped = "skipped"
+)
+
+// ShadowOptions controls which reads are mirrored
+type ShadowOptions struct {
+	// Percentage of reads mirrored, from 0 to 100
+	Percentage float64
+	// Timeout bounds a mirrored read
+	Timeout time.Duration
+	// MaxInFlight caps concurrent mirrored reads
+	MaxInFlight int
+}
+
+// ShadowRepository serves every call from the primary repository and mirrors
+// a sample of reads to a shadow repository, such as a new storage
+// implementation being migrated to. The results are compared in the
+// background and mismatches logged with the paths of the fields that differ;
+// values are left out as they may be personal data. Writes only go to the
+// primary.
+type ShadowRepository struct {
+	ports.UserRepository
+	shadow  ports.UserRepository
+	options ShadowOptions
+	// slots holds a token per mirrored read in flight
+	slots   chan struct{}
+	sample  func() float64
+	pending sync.WaitGroup
+	logger  logger.Logger
+	metrics *metrics.Registry
+}
+
+// NewShadowRepository mirrors reads of primary to shadow
+func NewShadowRepository(primary, shadow ports.UserRepository, options ShadowOptions, log logger.Logger, registry *metrics.Registry) *ShadowRepository {
+	return &ShadowRepository{
+		UserRepository: primary,
+		shadow:         shadow,
+		options:        options,
+		slots:          make(chan struct{}, options.MaxInFlight),
+		sample:         func() float64 { return rand.Float64() * 100 },
+		logger:         log.With("component", "shadow_reads"),
+		metrics:        registry,
+	}
+}
+
+// GetByID implements ports.UserRepository
+func (r *ShadowRepository) GetByID(ctx context.Context, id uint) (*entities.User, error) {
+	user, err := r.UserRepository.GetByID(ctx, id)
+	mirror(ctx, r, "GetByID", user, err, func(ctx context.Context) (*entities.User, error) {
+		return r.shadow.GetByID(ctx, id)
+	})
+	return user, err
+}
+
+// GetByEmail implements ports.UserRepository
+func (r *ShadowRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
+	user, err := r.UserRepository.GetByEmail(ctx, email)
+	mirror(ctx, r, "GetByEmail", user, err, func(ctx context.Context) (*entities.User, error) {
+		return r.shadow.GetByEmail(ctx, email)
+	})
+	return user, err
+}
+
+// GetByExternalID implements ports.UserRepository
+func (r *ShadowRepository) GetByExternalID(ctx context.Context, source, externalID string) (*entities.User, error) {
+	user, err := r.UserRepository.GetByExternalID(ctx, source, externalID)
+	mirror(ctx, r, "GetByExternalID", user, err, func(ctx context.Context) (*entities.User, error) {
+		return r.shadow.GetByExternalID(ctx, source, externalID)
+	})
+	return user, err
+}
+
+// ListExternalIDs implements ports.UserRepository
+func (r *ShadowRepository) ListExternalIDs(ctx context.Context, userID uint) (map[string]string, error) {
+	ids, err := r.UserRepository.ListExternalIDs(ctx, userID)
+	mirror(ctx, r, "ListExternalIDs", ids, err, func(ctx context.Context) (map[string]string, error) {
+		return r.shadow.ListExternalIDs(ctx, userID)
+	})
+	return ids, err
+}
+
+// ExistsByID implements ports.UserRepository
+func (r *ShadowRepository) ExistsByID(ctx context.Context, id uint) (bool, error) {
+	exists, err := r.UserRepository.ExistsByID(ctx, id)
+	mirror(ctx, r, "ExistsByID", exists, err, func(ctx context.Context) (bool, error) {
+		return r.shadow.ExistsByID(ctx, id)
+	})
+	return exists, err
+}
+
+// ExistsByEmail implements ports.UserRepository
+func (r *ShadowRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	exists, err := r.UserRepository.ExistsByEmail(ctx, email)
+	mirror(ctx, r, "ExistsByEmail", exists, err, func(ctx context.Context) (bool, error) {
+		return r.shadow.ExistsByEmail(ctx, email)
+	})
+	return exists, err
+}
+
+// GetByPhone implements ports.UserRepository
+func (r *ShadowRepository) GetByPhone(ctx context.Context, phone string) ([]*entities.User, error) {
+	users, err := r.UserRepository.GetByPhone(ctx, phone)
+	mirror(ctx, r, "GetByPhone", users, err, func(ctx context.Context) ([]*entities.User, error) {
+		return r.shadow.GetByPhone(ctx, phone)
+	})
+	return users, err
+}
+
+// ExistsByPhone implements ports.UserRepository
+func (r *ShadowRepository) ExistsByPhone(ctx context.Context, phone string) (bool, error) {
+	exists, err := r.UserRepository.ExistsByPhone(ctx, phone)
+	mirror(ctx, r, "ExistsByPhone", exists, err, func(ctx context.Context) (bool, error) {
+		return r.shadow.ExistsByPhone(ctx, phone)
+	})
+	return exists, err
+}
+
+// GetByReferralCode implements ports.UserRepository
+func (r *ShadowRepository) GetByReferralCode(ctx context.Context, code string) (*entities.User, error) {
+	user, err := r.UserRepository.GetByReferralCode(ctx, code)
+	mirror(ctx, r, "GetByReferralCode", user, err, func(ctx context.Context) (*entities.User, error) {
+		return r.shadow.GetByReferralCode(ctx, code)
+	})
+	return user, err
+}
+
+// FindExistingEmails implements ports.UserRepository
+func (r *ShadowRepository) FindExistingEmails(ctx context.Context, emails []string) ([]string, error) {
+	existing, err := r.UserRepository.FindExistingEmails(ctx, emails)
+	mirror(ctx, r, "FindExistingEmails", existing, err, func(ctx context.Context) ([]string, error) {
+		return r.shadow.FindExistingEmails(ctx, emails)
+	})
+	return existing, err
+}
+
+// List implements ports.UserRepository
+func (r *ShadowRepository) List(ctx context.Context, filter ports.UserFilter, limit, offset int) ([]*entities.User, error) {
+	users, err := r.UserRepository.List(ctx, filter, limit, offset)
+	mirror(ctx, r, "List", users, err, func(ctx context.Context) ([]*entities.User, error) {
+		return r.shadow.List(ctx, filter, limit, offset)
+	})
+	return users, err
+}
+
+// Count implements ports.UserRepository
+func (r *ShadowRepository) Count(ctx context.Context, filter ports.UserFilter) (int64, error) {
+	total, err := r.UserRepository.Count(ctx, filter)
+	mirror(ctx, r, "Count", total, err, func(ctx context.Context) (int64, error) {
+		return r.shadow.Count(ctx, filter)
+	})
+	return total, err
+}
+
+// mirror repeats a sampled read on the shadow repository in the background
+// and compares its result with the primary's. The primary result is
+// flattened before returning, as the caller may change it.
+func mirror[T any](ctx context.Context, r *ShadowRepository, method string, result T, err error, read func(ctx context.Context) (T, error)) {
+	if r.sample() >= r.options.Percentage {
+		return
+	}
+	select {
+	case r.slots <- struct{}{}:
+	default:
+		r.metrics.Counter("shadow_reads_total").Inc("method", method, "outcome", shadowSkipped)
+		return
+	}
+
+	expected := flatten(result)
+	r.pending.Add(1)
+	go func() {
+		defer func() {
+			<-r.slots
+			r.pending.Done()
+		}()
+
+		// The read outlives the request but keeps its scope, e.g. residency
+		shadowCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.options.Timeout)
+		defer cancel()
+		shadowResult, shadowErr := read(shadowCtx)
+
+		r.compare(method, expected, err, flatten(shadowResult), shadowErr)
+	}()
+}
+
+// compare records the outcome of a mirrored read and logs mismatches
+func (r *ShadowRepository) compare(method string, expected map[string]string, err error, actual map[string]string, shadowErr error) {
+	if errors.Is(shadowErr, context.DeadlineExceeded) {
+		r.metrics.Counter("shadow_reads_total").Inc("method", method, "outcome", shadowTimeout)
+		return
+	}
+
+	var differences []string
+	switch {
+	case err != nil || shadowErr != nil:
+		if err == nil || shadowErr == nil || err.Error() != shadowErr.Error() {
+			differences = []string{"error"}
+		}
+	default:
+		differences = diff(expected, actual)
+	}
+
+	if len(differences) == 0 {
+		r.metrics.Counter("shadow_reads_total").Inc("method", method, "outcome", shadowMatch)
+		return
+	}
+
+	r.metrics.Counter("shadow_reads_total").Inc("method", method, "outcome", shadowMismatch)
+	fields := []interface{}{
+		"method", method,
+		"differences", len(differences),
+		"fields", differences[:min(len(differences), maxLoggedDifferences)],
+	}
+	if err != nil {
+		fields = append(fields, "primary_error", err.Error())
+	}
+	if shadowErr != nil {
+		fields = append(fields, "shadow_error", shadowErr.Error())
+	}
+	r.logger.Warn("Shadow read differs from primary", fields...)
+}
+
+// wait blocks until every mirrored read in flight is compared
+func (r *ShadowRepository) wait() {
+	r.pending.Wait()
+}
+
+// diff returns the sorted paths whose values differ between two flattened results
+func diff(expected, actual map[string]string) []string {
+	var differences []string
+	for path, value := range expected {
+		if other, ok := actual[path]; !ok || other != value {
+			differences = append(differences, path)
+		}
+	}
+	for path := range actual {
+		if _, ok := expected[path]; !ok {
+			differences = append(differences, path)
+		}
+	}
+	slices.Sort(differences)
+	return differences
+}
+
+// flatten maps every leaf of a value to its rendering, keyed by its path
+// such as "[0].Preferences.Locale", so results can be compared field by field
+func flatten(value any) map[string]string {
+	leaves := make(map[string]string)
+	flattenValue("", reflect.ValueOf(value), leaves)
+	return leaves
+}
+
+func flattenValue(path string, value reflect.Value, leaves map[string]string) {
+	if !value.IsValid() {
+		leaves[path] = "nil"
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			leaves[path] = "nil"
+			return
+		}
+		flattenValue(path, value.Elem(), leaves)
+	case reflect.Struct:
+		if at, ok := value.Interface().(time.Time); ok {
+			leaves[path] = at.UTC().Format(time.RFC3339Nano)
+			return
+		}
+		for i := range value.NumField() {
+			if field := value.Type().Field(i); field.IsExported() {
+				flattenValue(fieldPath(path, field.Name), value.Field(i), leaves)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		leaves[fieldPath(path, "len")] = strconv.Itoa(value.Len())
+		for i := range value.Len() {
+			flattenValue(path+"["+strconv.Itoa(i)+"]", value.Index(i), leaves)
+		}
+	case reflect.Map:
+		leaves[fieldPath(path, "len")] = strconv.Itoa(value.Len())
+		keys := make(map[string]reflect.Value, value.Len())
+		for _, key := range value.MapKeys() {
+			keys[fmt.Sprint(key.Interface())] = key
+		}
+		for _, name := range slices.Sorted(maps.Keys(keys)) {
+			flattenValue(path+"["+name+"]", value.MapIndex(keys[name]), leaves)
+		}
+	default:
+		leaves[path] = fmt.Sprint(value.Interface())
+	}
+}
+
+func fieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,105 @@
+package user_repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/domain/entities"
+	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestShadow(percentage float64, primary, shadow *memoryUserRepository) (*ShadowRepository, *metrics.Registry) {
+	registry := metrics.NewRegistry()
+	repo := NewShadowRepository(primary, shadow, ShadowOptions{
+		Percentage:  percentage,
+		Timeout:     time.Second,
+		MaxInFlight: 4,
+	}, logger.New("test"), registry)
+	repo.sample = func() float64 { return 50 }
+	return repo, registry
+}
+
+func shadowOutcomes(registry *metrics.Registry, method string) map[string]uint64 {
+	outcomes := make(map[string]uint64)
+	for _, outcome := range []string{shadowMatch, shadowMismatch, shadowTimeout, shadowSkipped} {
+		if count := registry.Counter("shadow_reads_total").Value("method", method, "outcome", outcome); count > 0 {
+			outcomes[outcome] = count
+		}
+	}
+	return outcomes
+}
+
+func TestShadowRepository_ComparesMirroredReads(t *testing.T) {
+	// Given a shadow that has one user like the primary and another one out of date
+	ctx := context.Background()
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	primary := newMemoryUserRepository(
+		&entities.User{ID: 1, FirstName: "Ada", Tags: []string{"beta"}, CreatedAt: createdAt},
+		&entities.User{ID: 2, FirstName: "Grace", Tags: []string{"beta", "vip"}},
+	)
+	shadow := newMemoryUserRepository(
+		&entities.User{ID: 1, FirstName: "Ada", Tags: []string{"beta"}, CreatedAt: createdAt.In(time.FixedZone("CET", 3600))},
+		&entities.User{ID: 2, FirstName: "Grce", Tags: []string{"beta"}},
+	)
+	repo, registry := setupTestShadow(100, primary, shadow)
+
+	// When
+	first, err := repo.GetByID(ctx, 1)
+	require.NoError(t, err)
+	second, err := repo.GetByID(ctx, 2)
+	require.NoError(t, err)
+	_, err = repo.GetByID(ctx, 3)
+	repo.wait()
+
+	// Then callers get the primary's results, and the shadow's are compared
+	assert.Equal(t, "Ada", first.FirstName)
+	assert.Equal(t, "Grace", second.FirstName)
+	assert.Error(t, err)
+	assert.Equal(t, map[string]uint64{shadowMatch: 2, shadowMismatch: 1}, shadowOutcomes(registry, "GetByID"),
+		"a missing user matches when both miss it, and instants match across zones")
+}
+
+func TestShadowRepository_ReportsShadowOnlyFailures(t *testing.T) {
+	ctx := context.Background()
+	primary := newMemoryUserRepository(&entities.User{ID: 1})
+	repo, registry := setupTestShadow(100, primary, newMemoryUserRepository())
+
+	_, err := repo.GetByID(ctx, 1)
+	repo.wait()
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]uint64{shadowMismatch: 1}, shadowOutcomes(registry, "GetByID"))
+}
+
+func TestShadowRepository_OnlyMirrorsSampledReads(t *testing.T) {
+	// Given reads sampled outside the percentage
+	ctx := context.Background()
+	primary := newMemoryUserRepository(&entities.User{ID: 1})
+	shadow := newMemoryUserRepository()
+	repo, registry := setupTestShadow(10, primary, shadow)
+
+	// When a user is read and another created
+	_, err := repo.GetByID(ctx, 1)
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, &entities.User{Email: "new@example.com"})
+	require.NoError(t, err)
+	repo.wait()
+
+	// Then nothing reaches the shadow
+	assert.Empty(t, registry.Snapshot())
+	assert.Len(t, primary.users, 2)
+	assert.Empty(t, shadow.users)
+}
+
+func TestDiff_ListsDifferingFieldPaths(t *testing.T) {
+	expected := flatten([]*entities.User{{ID: 1, Tags: []string{"a", "b"}, ExternalIDs: map[string]string{"crm": "1"}}})
+	actual := flatten([]*entities.User{{ID: 1, Tags: []string{"a"}, ExternalIDs: map[string]string{"crm": "2"}}})
+
+	assert.Equal(t, []string{"[0].ExternalIDs[crm]", "[0].Tags.len", "[0].Tags[1]"}, diff(expected, actual))
+	assert.Empty(t, diff(expected, expected))
+}
@@ -0,0 +1,209 @@
+package write_journal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+)
+
+const (
+	pendingDir  = "pending"
+	rejectedDir = "rejected"
+	// tempSuffix marks files being written; leftovers of a crash are removed
+	tempSuffix = ".tmp"
+)
+
+// FileWriteJournal keeps each queued write in a file of its own under a
+// local directory, so it neither depends on the database it stands in for
+// nor on a separate store. Files are written to a temporary name, synced and
+// renamed, so a crash leaves either the whole write or none of it. Names are
+// zero-padded sequence numbers, which keeps a directory listing in order.
+type FileWriteJournal struct {
+	dir       string
+	maxQueued int
+
+	mu   sync.Mutex
+	next uint64
+	size int
+}
+
+var _ ports.WriteJournal = (*FileWriteJournal)(nil)
+
+// NewFileWriteJournal opens the journal in dir, creating it when missing, and
+// holds at most maxQueued writes
+func NewFileWriteJournal(dir string, maxQueued int) (*FileWriteJournal, error) {
+	for _, sub := range []string{pendingDir, rejectedDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o700); err != nil {
+			return nil, fmt.Errorf("failed to create write journal directory: %w", err)
+		}
+	}
+
+	j := &FileWriteJournal{dir: dir, maxQueued: maxQueued, next: 1}
+	names, err := j.names(pendingDir)
+	if err != nil {
+		return nil, err
+	}
+	rejected, err := j.names(rejectedDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rejected writes keep their IDs, so numbering continues after both
+	for _, name := range append(names, rejected...) {
+		if seq, err := strconv.ParseUint(strings.TrimSuffix(name, ".json"), 10, 64); err == nil && seq >= j.next {
+			j.next = seq + 1
+		}
+	}
+	j.size = len(names)
+	return j, nil
+}
+
+// Append implements ports.WriteJournal
+func (j *FileWriteJournal) Append(ctx context.Context, write *entities.QueuedWrite) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.size >= j.maxQueued {
+		return domainErrors.ErrWriteQueueFull
+	}
+
+	write.ID = fmt.Sprintf("%020d", j.next)
+	if err := j.store(pendingDir, write); err != nil {
+		return domainErrors.ErrFailedToQueueWrite
+	}
+	j.next++
+	j.size++
+	return nil
+}
+
+// Pending implements ports.WriteJournal
+func (j *FileWriteJournal) Pending(ctx context.Context) ([]*entities.QueuedWrite, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	names, err := j.names(pendingDir)
+	if err != nil {
+		return nil, err
+	}
+
+	writes := make([]*entities.QueuedWrite, 0, len(names))
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(j.dir, pendingDir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read queued write %s: %w", name, err)
+		}
+		var write entities.QueuedWrite
+		if err := json.Unmarshal(data, &write); err != nil {
+			return nil, fmt.Errorf("failed to decode queued write %s: %w", name, err)
+		}
+		writes = append(writes, &write)
+	}
+	return writes, nil
+}
+
+// Complete implements ports.WriteJournal
+func (j *FileWriteJournal) Complete(ctx context.Context, id string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.remove(id)
+}
+
+// Reject implements ports.WriteJournal
+func (j *FileWriteJournal) Reject(ctx context.Context, write *entities.QueuedWrite) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := j.store(rejectedDir, write); err != nil {
+		return fmt.Errorf("failed to store rejected write %s: %w", write.ID, err)
+	}
+	return j.remove(write.ID)
+}
+
+// Len implements ports.WriteJournal
+func (j *FileWriteJournal) Len(ctx context.Context) (int, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.size, nil
+}
+
+// store writes write durably to sub
+func (j *FileWriteJournal) store(sub string, write *entities.QueuedWrite) error {
+	data, err := json.Marshal(write)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(j.dir, sub, write.ID+".json")
+	file, err := os.OpenFile(path+tempSuffix, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(path+tempSuffix, path); err != nil {
+		return err
+	}
+	return syncDir(filepath.Join(j.dir, sub))
+}
+
+// remove deletes a pending write, which may already be gone
+func (j *FileWriteJournal) remove(id string) error {
+	err := os.Remove(filepath.Join(j.dir, pendingDir, id+".json"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove queued write %s: %w", id, err)
+	}
+	j.size--
+	return syncDir(filepath.Join(j.dir, pendingDir))
+}
+
+// names lists the writes in sub in order, clearing leftover temporary files
+func (j *FileWriteJournal) names(sub string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(j.dir, sub))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list write journal: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		switch name := entry.Name(); {
+		case strings.HasSuffix(name, tempSuffix):
+			_ = os.Remove(filepath.Join(j.dir, sub, name))
+		case strings.HasSuffix(name, ".json"):
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// syncDir makes renames and removals in dir durable
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -0,0 +1,91 @@
+package write_journal
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func queuedWrite(userID uint) *entities.QueuedWrite {
+	return &entities.QueuedWrite{
+		Operation: entities.QueuedUpdatePreferences,
+		UserID:    userID,
+		Payload:   json.RawMessage(`{"security_digest_opt_out":true}`),
+		QueuedAt:  time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestFileWriteJournal_KeepsWritesInOrder(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	journal, err := NewFileWriteJournal(t.TempDir(), 10)
+	require.NoError(t, err)
+
+	// When
+	for _, id := range []uint{1, 2, 3} {
+		require.NoError(t, journal.Append(ctx, queuedWrite(id)))
+	}
+	require.NoError(t, journal.Complete(ctx, "00000000000000000001"))
+
+	// Then
+	pending, err := journal.Pending(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, uint(2), pending[0].UserID)
+	assert.Equal(t, uint(3), pending[1].UserID)
+	assert.JSONEq(t, `{"security_digest_opt_out":true}`, string(pending[0].Payload))
+	size, _ := journal.Len(ctx)
+	assert.Equal(t, 2, size)
+}
+
+func TestFileWriteJournal_SurvivesRestart(t *testing.T) {
+	// Given a journal with a queued and a rejected write, and a write cut short
+	ctx := context.Background()
+	dir := t.TempDir()
+	journal, err := NewFileWriteJournal(dir, 10)
+	require.NoError(t, err)
+	require.NoError(t, journal.Append(ctx, queuedWrite(1)))
+	rejected := queuedWrite(2)
+	require.NoError(t, journal.Append(ctx, rejected))
+	rejected.Rejection = "stale"
+	require.NoError(t, journal.Reject(ctx, rejected))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, pendingDir, "00000000000000000003.json"+tempSuffix), []byte("{"), 0o600))
+
+	// When it is opened again
+	reopened, err := NewFileWriteJournal(dir, 10)
+	require.NoError(t, err)
+	write := queuedWrite(3)
+	require.NoError(t, reopened.Append(ctx, write))
+
+	// Then the queued write is kept, numbering continues and the partial write is gone
+	assert.Equal(t, "00000000000000000003", write.ID)
+	pending, err := reopened.Pending(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, uint(1), pending[0].UserID)
+	assert.FileExists(t, filepath.Join(dir, rejectedDir, "00000000000000000002.json"))
+	assert.NoFileExists(t, filepath.Join(dir, pendingDir, "00000000000000000003.json"+tempSuffix))
+}
+
+func TestFileWriteJournal_RejectsWritesWhenFull(t *testing.T) {
+	// Given a full journal
+	ctx := context.Background()
+	journal, err := NewFileWriteJournal(t.TempDir(), 1)
+	require.NoError(t, err)
+	require.NoError(t, journal.Append(ctx, queuedWrite(1)))
+
+	// When
+	err = journal.Append(ctx, queuedWrite(2))
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrWriteQueueFull)
+}
@@ -0,0 +1,116 @@
+package policy
+
+import (
+	"net/netip"
+	"slices"
+	"strings"
+
+	"user-service/internal/config"
+)
+
+// Decision reasons
+const (
+	ReasonPermitted = "permitted"
+	ReasonForbidden = "forbidden"
+	// ReasonNoPermit means no policy permits the request, which is denied
+	ReasonNoPermit = "no_permit"
+)
+
+// Request is what an authorization decision is made about. Attributes are
+// keyed by scope, e.g. subject.role, resource.id or context.ip.
+type Request struct {
+	Action     string
+	Attributes map[string]string
+}
+
+// Decision is the outcome of evaluating the policies for a request
+type Decision struct {
+	Allowed bool
+	Reason  string
+	// Policy is the policy that decided, empty when none permitted
+	Policy string
+}
+
+// Engine evaluates policies with deny-by-default semantics: a request is
+// allowed when a permit policy matches and no forbid policy does
+type Engine struct {
+	policies []config.PolicyConfig
+}
+
+// NewEngine creates an engine over validated policies
+func NewEngine(policies []config.PolicyConfig) *Engine {
+	return &Engine{policies: policies}
+}
+
+// Decide evaluates every policy against the request
+func (e *Engine) Decide(request Request) Decision {
+	decision := Decision{Reason: ReasonNoPermit}
+
+	for _, policy := range e.policies {
+		if !matches(policy, request) {
+			continue
+		}
+		if policy.Effect == "forbid" {
+			return Decision{Reason: ReasonForbidden, Policy: policy.ID}
+		}
+		if !decision.Allowed {
+			decision = Decision{Allowed: true, Reason: ReasonPermitted, Policy: policy.ID}
+		}
+	}
+
+	return decision
+}
+
+func matches(policy config.PolicyConfig, request Request) bool {
+	if len(policy.Roles) > 0 && !slices.Contains(policy.Roles, request.Attributes["subject.role"]) {
+		return false
+	}
+	if !slices.ContainsFunc(policy.Actions, func(pattern string) bool { return actionMatches(pattern, request.Action) }) {
+		return false
+	}
+	for _, condition := range policy.Conditions {
+		if !holds(condition, request.Attributes) {
+			return false
+		}
+	}
+	return true
+}
+
+// actionMatches matches an action against a name, "prefix.*" or "*"
+func actionMatches(pattern, action string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(action, prefix)
+	}
+	return pattern == action
+}
+
+// holds evaluates a condition. Missing attributes are empty, so not_in holds
+// for them and in only when an empty value is listed.
+func holds(condition config.PolicyConditionConfig, attributes map[string]string) bool {
+	value := attributes[condition.Attribute]
+	values := make([]string, 0, len(condition.Values))
+	for _, candidate := range condition.Values {
+		if name, ok := strings.CutPrefix(candidate, "$"); ok {
+			candidate = attributes[name]
+		}
+		values = append(values, candidate)
+	}
+
+	switch condition.Operator {
+	case config.PolicyOperatorIn:
+		return slices.Contains(values, value)
+	case config.PolicyOperatorNotIn:
+		return !slices.Contains(values, value)
+	case config.PolicyOperatorCIDR:
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return false
+		}
+		return slices.ContainsFunc(values, func(cidr string) bool {
+			prefix, err := netip.ParsePrefix(cidr)
+			return err == nil && prefix.Contains(addr.Unmap())
+		})
+	default:
+		return false
+	}
+}
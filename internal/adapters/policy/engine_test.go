@@ -0,0 +1,80 @@
+package policy
+
+import (
+	"testing"
+
+	"user-service/internal/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testPolicies() []config.PolicyConfig {
+	return []config.PolicyConfig{
+		{ID: "admins", Effect: "permit", Roles: []string{"admin"}, Actions: []string{"*"}},
+		{ID: "support-staff", Effect: "permit", Roles: []string{"support"}, Actions: []string{"admin.access"}},
+		{ID: "support-own-region", Effect: "forbid", Roles: []string{"support"}, Actions: []string{"admin.*"},
+			Conditions: []config.PolicyConditionConfig{{Attribute: "context.residency", Operator: "not_in", Values: []string{"$subject.region"}}}},
+		{ID: "blocked-network", Effect: "forbid", Actions: []string{"jobs.*"},
+			Conditions: []config.PolicyConditionConfig{{Attribute: "context.ip", Operator: "cidr", Values: []string{"203.0.113.0/24"}}}},
+	}
+}
+
+func TestEngine_Decide(t *testing.T) {
+	engine := NewEngine(testPolicies())
+
+	tests := []struct {
+		name       string
+		action     string
+		attributes map[string]string
+		want       Decision
+	}{
+		{
+			name:       "admin permitted anything",
+			action:     "users.delete",
+			attributes: map[string]string{"subject.role": "admin"},
+			want:       Decision{Allowed: true, Reason: ReasonPermitted, Policy: "admins"},
+		},
+		{
+			name:       "support in their region",
+			action:     "admin.access",
+			attributes: map[string]string{"subject.role": "support", "subject.region": "eu", "context.residency": "eu"},
+			want:       Decision{Allowed: true, Reason: ReasonPermitted, Policy: "support-staff"},
+		},
+		{
+			name:       "forbid wins over permit",
+			action:     "admin.access",
+			attributes: map[string]string{"subject.role": "support", "subject.region": "eu", "context.residency": "us"},
+			want:       Decision{Reason: ReasonForbidden, Policy: "support-own-region"},
+		},
+		{
+			name:       "denied without a permit",
+			action:     "users.delete",
+			attributes: map[string]string{"subject.role": "support", "subject.region": "eu", "context.residency": "eu"},
+			want:       Decision{Reason: ReasonNoPermit},
+		},
+		{
+			name:       "anonymous denied",
+			action:     "internal.access",
+			attributes: map[string]string{},
+			want:       Decision{Reason: ReasonNoPermit},
+		},
+		{
+			name:       "cidr matches",
+			action:     "jobs.run",
+			attributes: map[string]string{"subject.role": "admin", "context.ip": "203.0.113.7"},
+			want:       Decision{Reason: ReasonForbidden, Policy: "blocked-network"},
+		},
+		{
+			name:       "cidr does not match",
+			action:     "jobs.run",
+			attributes: map[string]string{"subject.role": "admin", "context.ip": "198.51.100.7"},
+			want:       Decision{Allowed: true, Reason: ReasonPermitted, Policy: "admins"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, engine.Decide(Request{Action: tt.action, Attributes: tt.attributes}))
+		})
+	}
+}
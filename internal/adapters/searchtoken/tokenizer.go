@@ -0,0 +1,59 @@
+package searchtoken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"user-service/internal/config"
+	"user-service/pkg/logger"
+)
+
+// MinKeyLength is the shortest key accepted, in bytes
+const MinKeyLength = 32
+
+// Tokenizer derives search tokens: keyed hashes of field values that can be
+// indexed and matched by equality without the index revealing the values.
+// Lookups keep working once the fields themselves are encrypted at rest, and
+// unlike plain hashes the tokens cannot be reversed by hashing candidate
+// values without the key.
+type Tokenizer struct {
+	key []byte
+}
+
+// New creates a tokenizer; tokens only match those derived with the same key
+func New(key string) (*Tokenizer, error) {
+	if len(key) < MinKeyLength {
+		return nil, fmt.Errorf("search token key must be at least %d characters", MinKeyLength)
+	}
+	return &Tokenizer{key: []byte(key)}, nil
+}
+
+// Token returns the token of a normalized field value, or "" for an empty
+// value. The field name is part of the hash, so equal values of different
+// fields get different tokens.
+func (t *Tokenizer) Token(field, value string) string {
+	if value == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, t.key)
+	mac.Write([]byte(field + ":" + value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// FromConfig creates the tokenizer for the configured key, or the development
+// key when none is configured; configuration validation keeps the latter out
+// of production
+func FromConfig(cfg config.SearchTokensConfig, log logger.Logger) (*Tokenizer, error) {
+	key, development := cfg.EffectiveKey()
+	if development {
+		log.Warn("No search token key configured; phone numbers are indexed with the development key")
+	}
+
+	tokens, err := New(key)
+	if err != nil {
+		return nil, fmt.Errorf("search_tokens.key: %w", err)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,27 @@
+package searchtoken
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenizer_Token(t *testing.T) {
+	tokens, err := New("a-search-token-key-of-32-characters")
+	require.NoError(t, err)
+	other, err := New("another-search-token-key-of-32-chars")
+	require.NoError(t, err)
+
+	token := tokens.Token("phone", "15550100000")
+	assert.Len(t, token, 64)
+	assert.Equal(t, token, tokens.Token("phone", "15550100000"))
+	assert.NotEqual(t, token, tokens.Token("email", "15550100000"), "fields get their own tokens")
+	assert.NotEqual(t, token, other.Token("phone", "15550100000"), "keys get their own tokens")
+	assert.Empty(t, tokens.Token("phone", ""))
+}
+
+func TestNew_RejectsShortKeys(t *testing.T) {
+	_, err := New("too-short")
+	assert.Error(t, err)
+}
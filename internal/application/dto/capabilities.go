@@ -0,0 +1,25 @@
+package dto
+
+// CapabilitiesResponseDTO describes what this deployment supports, so
+// internal SDKs and gateways adapt to it instead of hard-coding each
+// environment
+type CapabilitiesResponseDTO struct {
+	Service string `json:"service"`
+	Version string `json:"version"`
+	// APIVersions are the API versions served, e.g. "v1" for /api/v1
+	APIVersions []string `json:"api_versions"`
+	// Features tells which optional features are enabled. Features this
+	// service does not offer are listed as disabled.
+	Features map[string]bool     `json:"features"`
+	Limits   CapabilityLimitsDTO `json:"limits"`
+}
+
+// CapabilityLimitsDTO are the limits requests are held to
+type CapabilityLimitsDTO struct {
+	// MaxPageSize is the caller's page size cap; larger pages are clamped
+	MaxPageSize int `json:"max_page_size"`
+	// MaxBulkItems is the most users a bulk request may hold
+	MaxBulkItems int `json:"max_bulk_items"`
+	// MaxRequestBodyKB caps bodies on routes without a limit of their own
+	MaxRequestBodyKB int `json:"max_request_body_kb"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import "user-service/internal/domain/entities"
+
+// DuplicateSuggestionResponseDTO proposes merging DuplicateUserID into UserID
+type DuplicateSuggestionResponseDTO struct {
+	// UserID is the older account, suggested as the one to keep
+	UserID          uint                       `json:"user_id"`
+	DuplicateUserID uint                       `json:"duplicate_user_id"`
+	Confidence      float64                    `json:"confidence"`
+	Signals         []entities.DuplicateSignal `json:"signals"`
+	DetectedAt      Timestamp                  `json:"detected_at"`
+}
+
+// DuplicateSuggestionListResponseDTO for paginated duplicate suggestions
+type DuplicateSuggestionListResponseDTO struct {
+	Suggestions []*DuplicateSuggestionResponseDTO `json:"suggestions"`
+	Total       int64                             `json:"total"`
+	Page        int                               `json:"page"`
+	PageSize    int                               `json:"page_size"`
+}
+
+func DuplicateSuggestionToResponseDTO(suggestion *entities.DuplicateSuggestion) *DuplicateSuggestionResponseDTO {
+	return &DuplicateSuggestionResponseDTO{
+		UserID:          suggestion.UserID,
+		DuplicateUserID: suggestion.DuplicateUserID,
+		Confidence:      suggestion.Confidence,
+		Signals:         suggestion.Signals,
+		DetectedAt:      NewTimestamp(suggestion.DetectedAt),
+	}
+}
@@ -0,0 +1,22 @@
+package dto
+
+// ErrorCatalogResponseDTO lists every error code of the domain, so clients
+// can handle errors without reading server code
+type ErrorCatalogResponseDTO struct {
+	// Locale is the language of the messages, e.g. es
+	Locale string                 `json:"locale"`
+	Errors []ErrorCatalogEntryDTO `json:"errors"`
+}
+
+// ErrorCatalogEntryDTO describes the error responses carrying one code
+type ErrorCatalogEntryDTO struct {
+	Code    string `json:"code"`
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+	// Field is the request field the error is about, if any
+	Field     string `json:"field,omitempty"`
+	Retryable bool   `json:"retryable"`
+	// RetryAfterMS is the usual minimum wait before retrying; responses may
+	// suggest another
+	RetryAfterMS int64 `json:"retry_after_ms,omitempty"`
+}
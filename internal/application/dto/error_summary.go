@@ -0,0 +1,35 @@
+package dto
+
+// ErrorSummaryResponseDTO summarizes the server errors one instance answered
+// recently, so on-call can triage before opening the log aggregator
+type ErrorSummaryResponseDTO struct {
+	// Recent lists the latest 5xx responses, newest first
+	Recent []RecentErrorDTO `json:"recent"`
+	// TopCodes ranks the error codes of the 5xx responses within the window
+	TopCodes []ErrorCodeCountDTO `json:"top_codes"`
+	// WindowSeconds is how far back TopCodes counts
+	WindowSeconds int `json:"window_seconds"`
+	// Total is the number of 5xx responses within the window
+	Total int `json:"total"`
+	// GeneratedAt is when the summary was taken
+	GeneratedAt Timestamp `json:"generated_at"`
+}
+
+// RecentErrorDTO is one 5xx response
+type RecentErrorDTO struct {
+	Time   Timestamp `json:"time"`
+	Method string    `json:"method"`
+	// Route is the route template, e.g. /api/v1/users/:id, so user IDs and
+	// emails in paths stay out of the summary
+	Route     string `json:"route"`
+	Status    int    `json:"status"`
+	ErrorCode string `json:"error_code"`
+	// RequestID finds the request in the logs
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// ErrorCodeCountDTO counts the responses with one error code
+type ErrorCodeCountDTO struct {
+	ErrorCode string `json:"error_code"`
+	Count     int    `json:"count"`
+}
@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	"user-service/internal/domain/entities"
+)
+
+// JobResponseDTO describes a background job and its last run
+type JobResponseDTO struct {
+	Name           string              `json:"name"`
+	Interval       string              `json:"interval"`
+	Paused         bool                `json:"paused"`
+	Running        bool                `json:"running"`
+	LastStatus     entities.JobStatus  `json:"last_status"`
+	LastTrigger    entities.JobTrigger `json:"last_trigger,omitempty"`
+	LastStartedAt  Timestamp           `json:"last_started_at"`
+	LastFinishedAt Timestamp           `json:"last_finished_at"`
+	LastDurationMS int64               `json:"last_duration_ms"`
+	LastError      string              `json:"last_error,omitempty"`
+	// NextRunAt is null while the job is paused
+	NextRunAt Timestamp `json:"next_run_at"`
+}
+
+// JobListResponseDTO lists every registered job
+type JobListResponseDTO struct {
+	Jobs []*JobResponseDTO `json:"jobs"`
+}
+
+// JobToResponseDTO describes a job scheduled every interval as of now
+func JobToResponseDTO(state *entities.JobState, interval time.Duration, now time.Time) *JobResponseDTO {
+	response := &JobResponseDTO{
+		Name:           state.Name,
+		Interval:       interval.String(),
+		Paused:         state.Paused,
+		Running:        state.Running(now),
+		LastStatus:     state.LastStatus,
+		LastTrigger:    state.LastTrigger,
+		LastStartedAt:  NewTimestamp(state.LastStartedAt),
+		LastFinishedAt: NewTimestamp(state.LastFinishedAt),
+		LastDurationMS: state.LastDuration.Milliseconds(),
+		LastError:      state.LastError,
+	}
+
+	if !state.Paused {
+		next := state.DueAt(interval)
+		if next.Before(now) {
+			next = now
+		}
+		response.NextRunAt = NewTimestamp(next)
+	}
+
+	return response
+}
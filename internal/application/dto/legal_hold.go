@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"time"
+
+	"user-service/internal/domain/entities"
+)
+
+// PlaceLegalHoldRequestDTO for placing a user under a legal hold
+type PlaceLegalHoldRequestDTO struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+	// ExpiresAt lifts the hold at that time; omitted means until released
+	ExpiresAt *Timestamp `json:"expires_at"`
+}
+
+// ToEntity validates the request as a hold placed by actor
+func (dto *PlaceLegalHoldRequestDTO) ToEntity(actor string, now time.Time) (*entities.LegalHold, error) {
+	var expiresAt *time.Time
+	if dto.ExpiresAt != nil && !dto.ExpiresAt.IsZero() {
+		expiresAt = &dto.ExpiresAt.Time
+	}
+	return entities.NewLegalHold(dto.Reason, actor, expiresAt, now)
+}
+
+// LegalHoldResponseDTO describes the legal hold of a user
+type LegalHoldResponseDTO struct {
+	UserID    uint       `json:"user_id"`
+	Email     string     `json:"email"`
+	Reason    string     `json:"reason"`
+	PlacedBy  string     `json:"placed_by"`
+	PlacedAt  Timestamp  `json:"placed_at"`
+	ExpiresAt *Timestamp `json:"expires_at,omitempty"`
+	// Active is false once the hold has lapsed
+	Active bool `json:"active"`
+}
+
+// LegalHoldListResponseDTO for the report of held accounts
+type LegalHoldListResponseDTO struct {
+	Holds    []*LegalHoldResponseDTO `json:"holds"`
+	Page     int                     `json:"page"`
+	PageSize int                     `json:"page_size"`
+}
+
+// LegalHoldToResponseDTO describes the legal hold of a held user as of now
+func LegalHoldToResponseDTO(user *entities.User, now time.Time) *LegalHoldResponseDTO {
+	hold := user.LegalHold
+	response := &LegalHoldResponseDTO{
+		UserID:   user.ID,
+		Email:    user.Email,
+		Reason:   hold.Reason,
+		PlacedBy: hold.PlacedBy,
+		PlacedAt: NewTimestamp(hold.PlacedAt),
+		Active:   hold.ActiveAt(now),
+	}
+	if hold.ExpiresAt != nil {
+		expiresAt := NewTimestamp(*hold.ExpiresAt)
+		response.ExpiresAt = &expiresAt
+	}
+	return response
+}
@@ -0,0 +1,82 @@
+package dto
+
+import "user-service/internal/domain/entities"
+
+// RegisterOIDCClientRequestDTO for registering an app with the OIDC provider
+type RegisterOIDCClientRequestDTO struct {
+	Name         string   `json:"name" validate:"required,max=100"`
+	RedirectURIs []string `json:"redirect_uris" validate:"required,min=1,max=10"`
+	// Scopes the client may request; defaults to openid, profile and email
+	Scopes []string `json:"scopes" validate:"omitempty,max=10"`
+	// Confidential clients (server-side apps) receive a secret; public ones
+	// (single-page and mobile apps) rely on PKCE alone
+	Confidential bool `json:"confidential"`
+	// ClaimMappings renames standard claims in /userinfo responses
+	ClaimMappings map[string]string `json:"claim_mappings" validate:"omitempty,max=10"`
+}
+
+// UpdateClaimMappingsRequestDTO replaces a client's claim mappings; an empty
+// object restores the standard claim names
+type UpdateClaimMappingsRequestDTO struct {
+	ClaimMappings map[string]string `json:"claim_mappings" validate:"max=10"`
+}
+
+// OIDCClientResponseDTO for OIDC client responses
+type OIDCClientResponseDTO struct {
+	ClientID      string            `json:"client_id"`
+	Name          string            `json:"name"`
+	RedirectURIs  []string          `json:"redirect_uris"`
+	Scopes        []string          `json:"scopes"`
+	Confidential  bool              `json:"confidential"`
+	ClaimMappings map[string]string `json:"claim_mappings,omitempty"`
+	// ClientSecret is only returned when the client is registered
+	ClientSecret string    `json:"client_secret,omitempty"`
+	CreatedBy    string    `json:"created_by"`
+	CreatedAt    Timestamp `json:"created_at"`
+}
+
+// AuthorizeRequestDTO holds the parameters of an authorization request
+type AuthorizeRequestDTO struct {
+	ResponseType        string `query:"response_type" form:"response_type"`
+	ClientID            string `query:"client_id" form:"client_id"`
+	RedirectURI         string `query:"redirect_uri" form:"redirect_uri"`
+	Scope               string `query:"scope" form:"scope"`
+	State               string `query:"state" form:"state"`
+	Nonce               string `query:"nonce" form:"nonce"`
+	CodeChallenge       string `query:"code_challenge" form:"code_challenge"`
+	CodeChallengeMethod string `query:"code_challenge_method" form:"code_challenge_method"`
+	// UserAgent is the browser the user signs in from, recorded as their device
+	UserAgent string `query:"-" form:"-"`
+}
+
+// TokenRequestDTO holds the parameters of a token request
+type TokenRequestDTO struct {
+	GrantType    string `form:"grant_type"`
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+	CodeVerifier string `form:"code_verifier"`
+}
+
+// TokenResponseDTO is the token endpoint's successful response
+type TokenResponseDTO struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	IDToken     string `json:"id_token"`
+	Scope       string `json:"scope"`
+}
+
+func OIDCClientToResponseDTO(client *entities.OIDCClient) *OIDCClientResponseDTO {
+	return &OIDCClientResponseDTO{
+		ClientID:      client.ClientID,
+		Name:          client.Name,
+		RedirectURIs:  client.RedirectURIs,
+		Scopes:        client.Scopes,
+		Confidential:  client.IsConfidential(),
+		ClaimMappings: client.ClaimMappings,
+		CreatedBy:     client.CreatedBy,
+		CreatedAt:     NewTimestamp(client.CreatedAt),
+	}
+}
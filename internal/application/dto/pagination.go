@@ -0,0 +1,66 @@
+package dto
+
+// PageMeta describes the page a paginated response holds
+type PageMeta struct {
+	Page     int `json:"page"`
+	PageSize int `json:"page_size"`
+	// Total is the number of items reported with the page, when one is
+	Total *int64 `json:"total,omitempty"`
+	// NextCursor continues cursor-paginated listings after the page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// Paginated is implemented by paginated responses, so response envelopes can
+// carry the items as data and the page as meta
+type Paginated interface {
+	PageItems() interface{}
+	PageMeta() PageMeta
+}
+
+var (
+	_ Paginated = (*UserListResponseDTO)(nil)
+	_ Paginated = (*UserNoteListResponseDTO)(nil)
+	_ Paginated = (*DuplicateSuggestionListResponseDTO)(nil)
+	_ Paginated = (*LegalHoldListResponseDTO)(nil)
+)
+
+// PageItems implements Paginated
+func (dto *UserListResponseDTO) PageItems() interface{} {
+	return dto.Users
+}
+
+// PageMeta implements Paginated
+func (dto *UserListResponseDTO) PageMeta() PageMeta {
+	total := int64(dto.Total)
+	return PageMeta{Page: dto.Page, PageSize: dto.PageSize, Total: &total, NextCursor: dto.NextCursor}
+}
+
+// PageItems implements Paginated
+func (dto *UserNoteListResponseDTO) PageItems() interface{} {
+	return dto.Notes
+}
+
+// PageMeta implements Paginated
+func (dto *UserNoteListResponseDTO) PageMeta() PageMeta {
+	return PageMeta{Page: dto.Page, PageSize: dto.PageSize, Total: &dto.Total}
+}
+
+// PageItems implements Paginated
+func (dto *DuplicateSuggestionListResponseDTO) PageItems() interface{} {
+	return dto.Suggestions
+}
+
+// PageMeta implements Paginated
+func (dto *DuplicateSuggestionListResponseDTO) PageMeta() PageMeta {
+	return PageMeta{Page: dto.Page, PageSize: dto.PageSize, Total: &dto.Total}
+}
+
+// PageItems implements Paginated
+func (dto *LegalHoldListResponseDTO) PageItems() interface{} {
+	return dto.Holds
+}
+
+// PageMeta implements Paginated
+func (dto *LegalHoldListResponseDTO) PageMeta() PageMeta {
+	return PageMeta{Page: dto.Page, PageSize: dto.PageSize}
+}
@@ -0,0 +1,27 @@
+package dto
+
+// PhoneLookupRequestDTO asks for the users sharing a phone number. The number
+// is sent in the body so it stays out of URLs and access logs.
+type PhoneLookupRequestDTO struct {
+	Phone string `json:"phone" validate:"required,max=32"`
+}
+
+// PhoneLookupResponseDTO lists the users sharing a phone number
+type PhoneLookupResponseDTO struct {
+	Users []*UserResponseDTO `json:"users"`
+	Count int                `json:"count"`
+	// Truncated tells that the lookup hit its limit, so more users may share
+	// the number
+	Truncated bool `json:"truncated"`
+}
+
+// VisibleTo returns the users as a viewer may see them, see
+// UserResponseDTO.VisibleTo
+func (dto *PhoneLookupResponseDTO) VisibleTo(viewer Viewer) *PhoneLookupResponseDTO {
+	filtered := *dto
+	filtered.Users = make([]*UserResponseDTO, 0, len(dto.Users))
+	for _, user := range dto.Users {
+		filtered.Users = append(filtered.Users, user.VisibleTo(viewer))
+	}
+	return &filtered
+}
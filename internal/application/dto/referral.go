@@ -0,0 +1,7 @@
+package dto
+
+// AttributeReferralRequestDTO attributes a user to the user who referred
+// them after sign-up, e.g. when they forgot to enter the code
+type AttributeReferralRequestDTO struct {
+	ReferralCode string `json:"referral_code" validate:"required,max=16"`
+}
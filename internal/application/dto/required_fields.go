@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// profileFieldRules are the CreateUserRequestDTO fields a deployment may make
+// mandatory, by JSON name, with the struct field and the rules that replace
+// its tag when they are
+var profileFieldRules = map[string]struct{ field, rules string }{
+	"last_name": {"LastName", "required,min=2,max=50"},
+	"phone":     {"Phone", "required,min=10,max=15"},
+}
+
+// ProfileFields lists the profile fields that can be made mandatory
+func ProfileFields() []string {
+	return slices.Sorted(maps.Keys(profileFieldRules))
+}
+
+// RequiredProfileFieldRules returns validation rules making the given fields
+// mandatory on user creation, keyed by struct field as validators expect
+func RequiredProfileFieldRules(fields []string) (map[string]string, error) {
+	rules := make(map[string]string, len(fields))
+	for _, name := range fields {
+		rule, ok := profileFieldRules[name]
+		if !ok {
+			return nil, fmt.Errorf("profile.required_fields: %q is not one of %s", name, strings.Join(ProfileFields(), ", "))
+		}
+		rules[rule.field] = rule.rules
+	}
+	return rules, nil
+}
@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+
+	"user-service/internal/domain/entities"
+)
+
+// SuppressRequestDTO for suppressing communications to an email address
+type SuppressRequestDTO struct {
+	Reason string `json:"reason" validate:"required,oneof=bounced unsubscribed legal_hold"`
+	Note   string `json:"note" validate:"max=500"`
+	// ExpiresAt lifts the suppression at that time; omitted means never
+	ExpiresAt *Timestamp `json:"expires_at"`
+}
+
+// ToEntity validates the request as a suppression of email by source
+func (dto *SuppressRequestDTO) ToEntity(email, source string, now time.Time) (*entities.Suppression, error) {
+	var expiresAt *time.Time
+	if dto.ExpiresAt != nil && !dto.ExpiresAt.IsZero() {
+		expiresAt = &dto.ExpiresAt.Time
+	}
+	return entities.NewSuppression(email, dto.Reason, source, dto.Note, expiresAt, now)
+}
+
+// SuppressionResponseDTO for suppression responses
+type SuppressionResponseDTO struct {
+	Email     string                     `json:"email"`
+	Reason    entities.SuppressionReason `json:"reason"`
+	Source    string                     `json:"source"`
+	Note      string                     `json:"note,omitempty"`
+	ExpiresAt *Timestamp                 `json:"expires_at,omitempty"`
+	CreatedAt Timestamp                  `json:"created_at"`
+}
+
+// SuppressionCheckResponseDTO tells whether communications to an address are
+// suppressed, and why
+type SuppressionCheckResponseDTO struct {
+	Email       string                  `json:"email"`
+	Suppressed  bool                    `json:"suppressed"`
+	Suppression *SuppressionResponseDTO `json:"suppression,omitempty"`
+}
+
+func SuppressionToResponseDTO(suppression *entities.Suppression) *SuppressionResponseDTO {
+	response := &SuppressionResponseDTO{
+		Email:     suppression.Email,
+		Reason:    suppression.Reason,
+		Source:    suppression.Source,
+		Note:      suppression.Note,
+		CreatedAt: NewTimestamp(suppression.CreatedAt),
+	}
+	if suppression.ExpiresAt != nil {
+		expiresAt := NewTimestamp(*suppression.ExpiresAt)
+		response.ExpiresAt = &expiresAt
+	}
+	return response
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+)
+
+// TimestampLayout is the wire format of every timestamp exposed by the API
+const TimestampLayout = time.RFC3339
+
+// Timestamp is a point in time that always travels as an RFC 3339 string in
+// UTC, e.g. "2024-05-01T12:30:00Z". Clients must apply their own display
+// timezone; the API never emits local times or offsets.
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp wraps t, normalized to UTC
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t.UTC()}
+}
+
+// MarshalJSON renders the timestamp in UTC, or null when it is unset
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + t.UTC().Format(TimestampLayout) + `"`), nil
+}
+
+// UnmarshalJSON accepts RFC 3339 timestamps only. The format requires an
+// explicit offset, so a local time without one is rejected rather than guessed.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return fmt.Errorf("timestamp must be an RFC 3339 string")
+	}
+
+	parsed, err := time.Parse(TimestampLayout, string(data[1:len(data)-1]))
+	if err != nil {
+		return fmt.Errorf("timestamp must be RFC 3339 with an explicit offset: %w", err)
+	}
+
+	t.Time = parsed.UTC()
+	return nil
+}
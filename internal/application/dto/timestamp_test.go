@@ -0,0 +1,122 @@
+package dto
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+	"user-service/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimestamp_MarshalJSON_AlwaysUTC(t *testing.T) {
+	// Given a time recorded in a zone far from UTC
+	tokyo := time.FixedZone("JST", 9*60*60)
+	local := time.Date(2024, 5, 1, 9, 30, 0, 0, tokyo)
+
+	// When
+	data, err := json.Marshal(NewTimestamp(local))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, `"2024-05-01T00:30:00Z"`, string(data))
+}
+
+func TestTimestamp_MarshalJSON_UTCEvenWhenNotNormalized(t *testing.T) {
+	// Given a timestamp built without NewTimestamp
+	newYork := time.FixedZone("EST", -5*60*60)
+	ts := Timestamp{Time: time.Date(2024, 1, 15, 20, 0, 0, 0, newYork)}
+
+	// When
+	data, err := json.Marshal(ts)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, `"2024-01-16T01:00:00Z"`, string(data))
+}
+
+func TestTimestamp_MarshalJSON_ZeroIsNull(t *testing.T) {
+	data, err := json.Marshal(Timestamp{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+}
+
+func TestTimestamp_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "utc",
+			input:    `"2024-05-01T00:30:00Z"`,
+			expected: time.Date(2024, 5, 1, 0, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "offset is converted to utc",
+			input:    `"2024-05-01T09:30:00+09:00"`,
+			expected: time.Date(2024, 5, 1, 0, 30, 0, 0, time.UTC),
+		},
+		{
+			name:    "local time without offset is ambiguous",
+			input:   `"2024-05-01T09:30:00"`,
+			wantErr: true,
+		},
+		{
+			name:    "date only",
+			input:   `"2024-05-01"`,
+			wantErr: true,
+		},
+		{
+			name:    "unix seconds",
+			input:   `1714523400`,
+			wantErr: true,
+		},
+		{
+			name:  "null",
+			input: `null`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ts Timestamp
+			err := json.Unmarshal([]byte(tt.input), &ts)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.expected.Equal(ts.Time))
+			assert.Equal(t, time.UTC, ts.Location())
+		})
+	}
+}
+
+func TestUserToResponseDTO_TimestampsSerializeInUTC(t *testing.T) {
+	// Given a user loaded with non-UTC timestamps
+	saoPaulo := time.FixedZone("BRT", -3*60*60)
+	user := &entities.User{
+		ID:        1,
+		Email:     "test@example.com",
+		FirstName: "John",
+		LastName:  "Doe",
+		Status:    entities.UserStatusActive,
+		CreatedAt: time.Date(2024, 3, 10, 22, 15, 0, 0, saoPaulo),
+		UpdatedAt: time.Date(2024, 3, 10, 23, 45, 0, 0, saoPaulo),
+	}
+
+	// When
+	data, err := json.Marshal(UserToResponseDTO(user))
+
+	// Then
+	require.NoError(t, err)
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &body))
+	assert.Equal(t, "2024-03-11T01:15:00Z", body["created_at"])
+	assert.Equal(t, "2024-03-11T02:45:00Z", body["updated_at"])
+}
@@ -0,0 +1,26 @@
+package dto
+
+// UsageResponseDTO is the metered usage of tenants over a range of whole
+// hours. It trails live traffic by up to the flush interval.
+type UsageResponseDTO struct {
+	From    Timestamp        `json:"from"`
+	To      Timestamp        `json:"to"`
+	Tenants []TenantUsageDTO `json:"tenants"`
+}
+
+// TenantUsageDTO is the usage of one tenant over the range
+type TenantUsageDTO struct {
+	Tenant   string `json:"tenant"`
+	APICalls int64  `json:"api_calls"`
+	// ActiveUsers counts distinct users over the whole range, which is not
+	// the sum of the hourly counts
+	ActiveUsers int64            `json:"active_users"`
+	Hours       []HourlyUsageDTO `json:"hours"`
+}
+
+// HourlyUsageDTO is the usage of one tenant in one hour
+type HourlyUsageDTO struct {
+	Hour        Timestamp `json:"hour"`
+	APICalls    int64     `json:"api_calls"`
+	ActiveUsers int64     `json:"active_users"`
+}
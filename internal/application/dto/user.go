@@ -2,7 +2,9 @@ package dto
 
 import (
 	"time"
+
 	"user-service/internal/domain/entities"
+	userErrors "user-service/internal/domain/errors"
 )
 
 // CreateUserRequestDTO for user creation
@@ -10,8 +12,39 @@ type CreateUserRequestDTO struct {
 	Email     string `json:"email" validate:"required,email"`
 	Password  string `json:"password" validate:"required,min=8"`
 	FirstName string `json:"first_name" validate:"required,min=2,max=50"`
-	LastName  string `json:"last_name" validate:"required,min=2,max=50"`
-	Phone     string `json:"phone" validate:"omitempty,min=10,max=15"`
+	// LastName and Phone are optional unless the deployment requires them,
+	// see RequiredProfileFieldRules
+	LastName string `json:"last_name" validate:"omitempty,min=2,max=50"`
+	Phone    string `json:"phone" validate:"omitempty,min=10,max=15"`
+	// Residency defaults to the region the request is served in
+	Residency string `json:"residency,omitempty" validate:"omitempty,oneof=eu us EU US"`
+	// Country (ISO 3166-1 alpha-2) is optional; it supplies the default
+	// Locale and Timezone and the calling code of a Phone in national format
+	Country string `json:"country,omitempty" validate:"omitempty,len=2"`
+	// Locale (BCP 47) and Timezone (IANA) default to the country's, then to
+	// the deployment's
+	Locale   string `json:"locale,omitempty" validate:"omitempty,max=35"`
+	Timezone string `json:"timezone,omitempty" validate:"omitempty,max=64"`
+	// ExternalID is the ID a source system knows the user by; only internal
+	// callers may set it
+	ExternalID *ExternalIDDTO `json:"external_id,omitempty"`
+	// DisplayName and Pronouns are optional; their visibility defaults to
+	// private
+	DisplayName           string `json:"display_name,omitempty" validate:"omitempty,max=50"`
+	Pronouns              string `json:"pronouns,omitempty" validate:"omitempty,max=40"`
+	DisplayNameVisibility string `json:"display_name_visibility,omitempty" validate:"omitempty,oneof=public org private"`
+	PronounsVisibility    string `json:"pronouns_visibility,omitempty" validate:"omitempty,oneof=public org private"`
+	// DateOfBirth (YYYY-MM-DD) is optional; users younger than the age gate
+	// of their region are rejected or held for guardian consent
+	DateOfBirth string `json:"date_of_birth,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	// ReferralCode is the code of the user who referred this one, if any
+	ReferralCode string `json:"referral_code,omitempty" validate:"omitempty,max=16"`
+}
+
+// ExternalIDDTO identifies a user in another system
+type ExternalIDDTO struct {
+	Source string `json:"source" validate:"required,max=50"`
+	ID     string `json:"id" validate:"required,max=255"`
 }
 
 // UpdateUserRequestDTO for user updates
@@ -23,15 +56,70 @@ type UpdateUserRequestDTO struct {
 
 // UserResponseDTO for user responses (excludes sensitive data)
 type UserResponseDTO struct {
-	ID        uint                `json:"id"`
-	Email     string              `json:"email"`
-	FirstName string              `json:"first_name"`
-	LastName  string              `json:"last_name"`
-	FullName  string              `json:"full_name"`
-	Phone     string              `json:"phone"`
-	Status    entities.UserStatus `json:"status"`
-	CreatedAt time.Time           `json:"created_at"`
-	UpdatedAt time.Time           `json:"updated_at"`
+	ID        uint   `json:"id"`
+	Email     string `json:"email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	FullName  string `json:"full_name"`
+	Phone     string `json:"phone"`
+	// DisplayName and Pronouns are left out for callers their visibility
+	// does not reach, see VisibleTo
+	DisplayName string `json:"display_name,omitempty"`
+	Pronouns    string `json:"pronouns,omitempty"`
+	// DateOfBirth (YYYY-MM-DD) is only shown to admins
+	DateOfBirth string              `json:"date_of_birth,omitempty"`
+	Status      entities.UserStatus `json:"status"`
+	Residency   entities.Residency  `json:"residency,omitempty"`
+	Country     string              `json:"country,omitempty"`
+	Tags        []string            `json:"tags"`
+	// ExternalIDs maps source systems to the ID they know the user by
+	ExternalIDs map[string]string `json:"external_ids,omitempty"`
+	// ReferralCode is the code the user shares to refer others
+	ReferralCode string `json:"referral_code,omitempty"`
+	// ReferredBy is the ID of the user who referred this one, if any
+	ReferredBy *uint `json:"referred_by,omitempty"`
+	// Suspension is present while the user is suspended
+	Suspension  *SuspensionResponseDTO   `json:"suspension,omitempty"`
+	Preferences entities.UserPreferences `json:"preferences"`
+	CreatedAt   Timestamp                `json:"created_at"`
+	UpdatedAt   Timestamp                `json:"updated_at"`
+}
+
+// UserTagsRequestDTO for adding tags to a user
+type UserTagsRequestDTO struct {
+	Tags []string `json:"tags" validate:"required,min=1,max=20,dive,required,max=50"`
+}
+
+// UpdatePreferencesRequestDTO changes a user's preferences; omitted
+// preferences keep their value
+type UpdatePreferencesRequestDTO struct {
+	SecurityDigestOptOut *bool   `json:"security_digest_opt_out"`
+	Locale               *string `json:"locale" validate:"omitempty,max=35"`
+	Timezone             *string `json:"timezone" validate:"omitempty,max=64"`
+	// DisplayNameVisibility and PronounsVisibility are public, org or private
+	DisplayNameVisibility *string `json:"display_name_visibility" validate:"omitempty,oneof=public org private"`
+	PronounsVisibility    *string `json:"pronouns_visibility" validate:"omitempty,oneof=public org private"`
+}
+
+// UpdateProfileRequestDTO changes a user's optional profile fields; omitted
+// fields keep their value and empty ones are cleared
+type UpdateProfileRequestDTO struct {
+	DisplayName *string `json:"display_name" validate:"omitempty,max=50"`
+	Pronouns    *string `json:"pronouns" validate:"omitempty,max=40"`
+	// Country is an ISO 3166-1 alpha-2 code; changing it keeps the user's
+	// locale, time zone and phone number
+	Country *string `json:"country" validate:"omitempty,max=2"`
+}
+
+// UserFilterDTO narrows down user listings
+type UserFilterDTO struct {
+	Tags []string `json:"tags,omitempty"`
+	// Email looks up the user with exactly this email address
+	Email string `json:"email,omitempty"`
+	// Country restricts the listing to users living in this country
+	Country string `json:"country,omitempty"`
+	// AfterID continues the listing after the user with this ID
+	AfterID uint `json:"after_id,omitempty"`
 }
 
 // UserListResponseDTO for paginated user lists
@@ -40,31 +128,178 @@ type UserListResponseDTO struct {
 	Total    int                `json:"total"`
 	Page     int                `json:"page"`
 	PageSize int                `json:"page_size"`
+	// NextCursor continues the listing after this page, set when the page is
+	// full
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // Conversion methods
 func (dto *CreateUserRequestDTO) ToEntity() (*entities.User, error) {
-	return entities.NewUser(
+	user, err := entities.NewUser(
 		dto.Email,
 		dto.Password,
 		dto.FirstName,
 		dto.LastName,
 		dto.Phone,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.Residency != "" {
+		if user.Residency, err = entities.ParseResidency(dto.Residency); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := user.Preferences.Localize(dto.Locale, dto.Timezone); err != nil {
+		return nil, err
+	}
+
+	if dto.Country != "" {
+		country, err := entities.LookupCountry(dto.Country)
+		if err != nil {
+			return nil, err
+		}
+		user.Country = country.Code
+		user.Preferences.DefaultTo(country.Localization)
+		user.Phone = country.InternationalPhone(user.Phone)
+	}
+
+	if err := user.SetProfile(dto.DisplayName, dto.Pronouns); err != nil {
+		return nil, err
+	}
+	if err := user.Preferences.Visibility.SetVisibility(dto.DisplayNameVisibility, dto.PronounsVisibility); err != nil {
+		return nil, err
+	}
+
+	if dto.DateOfBirth != "" {
+		dateOfBirth, err := time.Parse(time.DateOnly, dto.DateOfBirth)
+		if err != nil {
+			return nil, userErrors.ErrInvalidDateOfBirth
+		}
+		if err := user.SetDateOfBirth(dateOfBirth, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	if dto.ExternalID != nil {
+		if err := user.SetExternalID(dto.ExternalID.Source, dto.ExternalID.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return user, nil
 }
 
 func UserToResponseDTO(user *entities.User) *UserResponseDTO {
-	return &UserResponseDTO{
-		ID:        user.ID,
-		Email:     user.Email,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		FullName:  user.FullName(),
-		Phone:     user.Phone,
-		Status:    user.Status,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+	response := &UserResponseDTO{
+		ID:           user.ID,
+		Email:        user.Email,
+		FirstName:    user.FirstName,
+		LastName:     user.LastName,
+		FullName:     user.FullName(),
+		Phone:        user.Phone,
+		DisplayName:  user.DisplayName,
+		Pronouns:     user.Pronouns,
+		Status:       user.Status,
+		Residency:    user.Residency,
+		Country:      user.Country,
+		Tags:         tagsOrEmpty(user.Tags),
+		ExternalIDs:  user.ExternalIDs,
+		ReferralCode: user.ReferralCode,
+		ReferredBy:   user.ReferredBy,
+		Preferences:  user.Preferences,
+		CreatedAt:    NewTimestamp(user.CreatedAt),
+		UpdatedAt:    NewTimestamp(user.UpdatedAt),
+	}
+
+	if user.DateOfBirth != nil {
+		response.DateOfBirth = user.DateOfBirth.Format(time.DateOnly)
+	}
+
+	if user.Suspension != nil {
+		response.Suspension = &SuspensionResponseDTO{ReasonCode: user.Suspension.Reason}
+		if user.Suspension.ReactivateAt != nil {
+			reactivateAt := NewTimestamp(*user.Suspension.ReactivateAt)
+			response.Suspension.ReactivateAt = &reactivateAt
+		}
 	}
+
+	return response
+}
+
+// Viewer is the caller a response is filtered for
+type Viewer struct {
+	// Audience is the narrowest profile visibility the viewer may see
+	Audience entities.Visibility
+	// Admin viewers also see the date of birth
+	Admin bool
+	// Internal viewers, the services that assign them, also see the external
+	// IDs, as admins do
+	Internal bool
+}
+
+// ViewerKeys are the keys of every distinct viewer
+var ViewerKeys = []string{
+	string(entities.VisibilityPublic),
+	string(entities.VisibilityOrg),
+	string(entities.VisibilityPrivate),
+	"internal",
+	"admin",
+}
+
+// Key names what the viewer may see; viewers with the same key see the same
+// responses
+func (v Viewer) Key() string {
+	if v.Admin {
+		return "admin"
+	}
+	if v.Internal {
+		return "internal"
+	}
+	return string(v.Audience)
+}
+
+// VisibleTo returns the response as a viewer may see it: profile fields whose
+// visibility does not reach the viewer's audience are left out, the date of
+// birth is only shown to admins and the external IDs only to admins and
+// internal viewers
+func (dto *UserResponseDTO) VisibleTo(viewer Viewer) *UserResponseDTO {
+	visibility := dto.Preferences.Visibility
+	showDisplayName := visibility.DisplayName.VisibleTo(viewer.Audience)
+	showPronouns := visibility.Pronouns.VisibleTo(viewer.Audience)
+	showDateOfBirth := viewer.Admin
+	showExternalIDs := viewer.Admin || viewer.Internal
+	if showDisplayName && showPronouns && showDateOfBirth && showExternalIDs {
+		return dto
+	}
+
+	filtered := *dto
+	if !showDisplayName {
+		filtered.DisplayName = ""
+	}
+	if !showPronouns {
+		filtered.Pronouns = ""
+	}
+	if !showDateOfBirth {
+		filtered.DateOfBirth = ""
+	}
+	if !showExternalIDs {
+		filtered.ExternalIDs = nil
+	}
+	return &filtered
+}
+
+// VisibleTo returns the page as a viewer may see it, see
+// UserResponseDTO.VisibleTo
+func (dto *UserListResponseDTO) VisibleTo(viewer Viewer) *UserListResponseDTO {
+	filtered := *dto
+	filtered.Users = make([]*UserResponseDTO, 0, len(dto.Users))
+	for _, user := range dto.Users {
+		filtered.Users = append(filtered.Users, user.VisibleTo(viewer))
+	}
+	return &filtered
 }
 
 func UsersToResponseDTOs(users []*entities.User) []*UserResponseDTO {
@@ -74,3 +309,43 @@ func UsersToResponseDTOs(users []*entities.User) []*UserResponseDTO {
 	}
 	return dtos
 }
+
+func tagsOrEmpty(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+// ChangeUserStatusRequestDTO moves a user to another status
+type ChangeUserStatusRequestDTO struct {
+	Status string `json:"status" validate:"required,oneof=pending active inactive suspended"`
+	// Reason explains the change and is kept in the audit log
+	Reason string `json:"reason" validate:"omitempty,max=500"`
+	// ReasonCode, Note and ReactivateAt describe a suspension and are only
+	// accepted when Status is suspended, which requires a ReasonCode
+	ReasonCode   string     `json:"reason_code" validate:"omitempty,oneof=fraud abuse payment"`
+	Note         string     `json:"note" validate:"omitempty,max=1000"`
+	ReactivateAt *Timestamp `json:"reactivate_at"`
+}
+
+// HasSuspensionDetails reports whether any suspension detail was given
+func (dto *ChangeUserStatusRequestDTO) HasSuspensionDetails() bool {
+	return dto.ReasonCode != "" || dto.Note != "" || dto.ReactivateAt != nil
+}
+
+// ToSuspension converts the suspension details into a Suspension starting at now
+func (dto *ChangeUserStatusRequestDTO) ToSuspension(now time.Time) (*entities.Suspension, error) {
+	var reactivateAt *time.Time
+	if dto.ReactivateAt != nil && !dto.ReactivateAt.IsZero() {
+		reactivateAt = &dto.ReactivateAt.Time
+	}
+	return entities.NewSuspension(dto.ReasonCode, dto.Note, reactivateAt, now)
+}
+
+// SuspensionResponseDTO describes why a user is suspended. The internal note
+// is deliberately left out.
+type SuspensionResponseDTO struct {
+	ReasonCode   entities.SuspensionReason `json:"reason_code"`
+	ReactivateAt *Timestamp                `json:"reactivate_at,omitempty"`
+}
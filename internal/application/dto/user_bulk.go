@@ -0,0 +1,52 @@
+package dto
+
+// Bulk item statuses
+const (
+	BulkItemCreated = "created"
+	BulkItemFailed  = "failed"
+)
+
+// BulkCreateUsersRequestDTO for creating many users in one request
+type BulkCreateUsersRequestDTO struct {
+	Users []CreateUserRequestDTO `json:"users" validate:"required,min=1"`
+}
+
+// BulkItemResultDTO reports the outcome for one item of a bulk request
+type BulkItemResultDTO struct {
+	Index   int              `json:"index"`
+	Status  string           `json:"status"`
+	User    *UserResponseDTO `json:"user,omitempty"`
+	Error   string           `json:"error,omitempty"`
+	Message string           `json:"message,omitempty"`
+}
+
+// BulkCreateUsersResponseDTO for bulk creation results, in request order
+type BulkCreateUsersResponseDTO struct {
+	Results []BulkItemResultDTO `json:"results"`
+	Created int                 `json:"created"`
+	Failed  int                 `json:"failed"`
+}
+
+// VisibleTo returns the results as a viewer may see them, see
+// UserResponseDTO.VisibleTo
+func (dto *BulkCreateUsersResponseDTO) VisibleTo(viewer Viewer) *BulkCreateUsersResponseDTO {
+	filtered := *dto
+	filtered.Results = make([]BulkItemResultDTO, len(dto.Results))
+	for i, result := range dto.Results {
+		if result.User != nil {
+			result.User = result.User.VisibleTo(viewer)
+		}
+		filtered.Results[i] = result
+	}
+	return &filtered
+}
+
+// BulkItemFailure builds a failed item result
+func BulkItemFailure(index int, code, message string) BulkItemResultDTO {
+	return BulkItemResultDTO{
+		Index:   index,
+		Status:  BulkItemFailed,
+		Error:   code,
+		Message: message,
+	}
+}
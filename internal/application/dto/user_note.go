@@ -0,0 +1,54 @@
+package dto
+
+import "user-service/internal/domain/entities"
+
+// CreateUserNoteRequestDTO for adding a support note to a user
+type CreateUserNoteRequestDTO struct {
+	Text       string `json:"text" validate:"required,max=5000"`
+	Visibility string `json:"visibility" validate:"omitempty,oneof=internal private"`
+}
+
+// UpdateUserNoteRequestDTO for editing a support note
+type UpdateUserNoteRequestDTO struct {
+	Text       string `json:"text" validate:"required,max=5000"`
+	Visibility string `json:"visibility" validate:"omitempty,oneof=internal private"`
+}
+
+// UserNoteResponseDTO for note responses
+type UserNoteResponseDTO struct {
+	ID         uint                    `json:"id"`
+	UserID     uint                    `json:"user_id"`
+	Author     string                  `json:"author"`
+	Text       string                  `json:"text"`
+	Visibility entities.NoteVisibility `json:"visibility"`
+	CreatedAt  Timestamp               `json:"created_at"`
+	UpdatedAt  Timestamp               `json:"updated_at"`
+}
+
+// UserNoteListResponseDTO for paginated note lists
+type UserNoteListResponseDTO struct {
+	Notes    []*UserNoteResponseDTO `json:"notes"`
+	Total    int64                  `json:"total"`
+	Page     int                    `json:"page"`
+	PageSize int                    `json:"page_size"`
+}
+
+func UserNoteToResponseDTO(note *entities.UserNote) *UserNoteResponseDTO {
+	return &UserNoteResponseDTO{
+		ID:         note.ID,
+		UserID:     note.UserID,
+		Author:     note.Author,
+		Text:       note.Text,
+		Visibility: note.Visibility,
+		CreatedAt:  NewTimestamp(note.CreatedAt),
+		UpdatedAt:  NewTimestamp(note.UpdatedAt),
+	}
+}
+
+func UserNotesToResponseDTOs(notes []*entities.UserNote) []*UserNoteResponseDTO {
+	dtos := make([]*UserNoteResponseDTO, 0, len(notes))
+	for _, note := range notes {
+		dtos = append(dtos, UserNoteToResponseDTO(note))
+	}
+	return dtos
+}
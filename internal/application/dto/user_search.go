@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"user-service/internal/domain/entities"
+	"user-service/pkg/logger"
+)
+
+// UserSearchRequestDTO looks users up by part of their email address or
+// phone number, for support staff working a ticket. The query is sent in the
+// body so it stays out of URLs and access logs.
+type UserSearchRequestDTO struct {
+	Email string `json:"email" validate:"omitempty,max=254"`
+	Phone string `json:"phone" validate:"omitempty,max=32"`
+	// Country narrows the matches down to users living in the country
+	Country string `json:"country" validate:"omitempty,len=2"`
+	// TicketID is the support ticket the search is made for
+	TicketID string `json:"ticket_id" validate:"required,max=64"`
+	Reason   string `json:"reason" validate:"omitempty,max=500"`
+}
+
+// UserSearchMatchDTO is a user found by a search, with their contact details
+// masked; the full record is one audited GET away
+type UserSearchMatchDTO struct {
+	ID        uint                `json:"id"`
+	Email     string              `json:"email"`
+	Phone     string              `json:"phone,omitempty"`
+	Country   string              `json:"country,omitempty"`
+	Status    entities.UserStatus `json:"status"`
+	CreatedAt Timestamp           `json:"created_at"`
+}
+
+// UserSearchResponseDTO lists the users a search found
+type UserSearchResponseDTO struct {
+	Users []*UserSearchMatchDTO `json:"users"`
+	Count int                   `json:"count"`
+	// Truncated tells that the search hit its limit and should be narrowed
+	Truncated bool `json:"truncated"`
+}
+
+func UserToSearchMatchDTO(user *entities.User) *UserSearchMatchDTO {
+	return &UserSearchMatchDTO{
+		ID:        user.ID,
+		Email:     logger.MaskEmail(user.Email),
+		Phone:     logger.MaskPhone(user.Phone),
+		Country:   user.Country,
+		Status:    user.Status,
+		CreatedAt: NewTimestamp(user.CreatedAt),
+	}
+}
@@ -0,0 +1,32 @@
+package dto
+
+// SyncUserRequestDTO carries a user record from an external source of record
+type SyncUserRequestDTO struct {
+	Email     string `json:"email" validate:"required,email"`
+	FirstName string `json:"first_name" validate:"required,min=2,max=50"`
+	// LastName and Phone are optional unless the deployment requires them,
+	// which is checked when a user is created, see
+	// entities.RequiredProfileFields
+	LastName string `json:"last_name" validate:"omitempty,min=2,max=50"`
+	Phone    string `json:"phone" validate:"omitempty,min=10,max=15"`
+	Status   string `json:"status" validate:"omitempty,oneof=active inactive suspended"`
+	// Policy is prefer-existing, prefer-incoming or merge (the default)
+	Policy string `json:"policy" validate:"omitempty,oneof=prefer-existing prefer-incoming merge"`
+	// Source names the system the record comes from, e.g. "hr"
+	Source string `json:"source" validate:"omitempty,max=50"`
+}
+
+// SyncUserResponseDTO reports the outcome of a sync
+type SyncUserResponseDTO struct {
+	User    *UserResponseDTO `json:"user"`
+	Outcome string           `json:"outcome"`
+	Changes []string         `json:"changes"`
+}
+
+// VisibleTo returns the outcome as a viewer may see it, see
+// UserResponseDTO.VisibleTo
+func (dto *SyncUserResponseDTO) VisibleTo(viewer Viewer) *SyncUserResponseDTO {
+	filtered := *dto
+	filtered.User = dto.User.VisibleTo(viewer)
+	return &filtered
+}
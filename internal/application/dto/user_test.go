@@ -54,6 +54,18 @@ func TestCreateUserRequestDTO_ToEntity(t *testing.T) {
 			expectError:   true,
 			errorContains: "password must be at least 8 characters",
 		},
+		{
+			name: "unknown residency",
+			dto: CreateUserRequestDTO{
+				Email:     "test@example.com",
+				Password:  "SecurePass123",
+				FirstName: "John",
+				LastName:  "Doe",
+				Residency: "apac",
+			},
+			expectError:   true,
+			errorContains: "INVALID_RESIDENCY",
+		},
 	}
 
 	for _, tt := range tests {
@@ -78,6 +90,21 @@ func TestCreateUserRequestDTO_ToEntity(t *testing.T) {
 	}
 }
 
+func TestCreateUserRequestDTO_ToEntityNormalizesResidency(t *testing.T) {
+	dto := CreateUserRequestDTO{
+		Email:     "test@example.com",
+		Password:  "SecurePass123",
+		FirstName: "John",
+		LastName:  "Doe",
+		Residency: "EU",
+	}
+
+	entity, err := dto.ToEntity()
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.ResidencyEU, entity.Residency)
+}
+
 func TestUserToResponseDTO(t *testing.T) {
 	// Given
 	now := time.Now()
@@ -105,8 +132,8 @@ func TestUserToResponseDTO(t *testing.T) {
 	assert.Equal(t, "John Doe", dto.FullName)
 	assert.Equal(t, user.Phone, dto.Phone)
 	assert.Equal(t, user.Status, dto.Status)
-	assert.Equal(t, user.CreatedAt, dto.CreatedAt)
-	assert.Equal(t, user.UpdatedAt, dto.UpdatedAt)
+	assert.True(t, user.CreatedAt.Equal(dto.CreatedAt.Time))
+	assert.True(t, user.UpdatedAt.Equal(dto.UpdatedAt.Time))
 }
 
 func TestUsersToResponseDTOs(t *testing.T) {
@@ -176,7 +203,7 @@ func TestCreateUserRequestDTO_JSONSerialization(t *testing.T) {
 
 func TestUserResponseDTO_JSONSerialization(t *testing.T) {
 	// Given
-	now := time.Now()
+	now := NewTimestamp(time.Now())
 	dto := UserResponseDTO{
 		ID:        1,
 		Email:     "test@example.com",
@@ -259,3 +286,57 @@ func TestUserListResponseDTO_Structure(t *testing.T) {
 	assert.Equal(t, 1, decoded.Page)
 	assert.Equal(t, 2, decoded.PageSize)
 }
+
+func TestUserResponseDTO_VisibleTo(t *testing.T) {
+	// Given a user with a public display name, private pronouns and a date of birth
+	dateOfBirth := time.Date(2001, 4, 2, 0, 0, 0, 0, time.UTC)
+	user := &entities.User{
+		ID:          1,
+		DisplayName: "JD",
+		Pronouns:    "she/her",
+		DateOfBirth: &dateOfBirth,
+		ExternalIDs: map[string]string{"hr": "E-1"},
+		Preferences: entities.UserPreferences{Visibility: entities.ProfileVisibility{
+			DisplayName: entities.VisibilityPublic,
+			Pronouns:    entities.VisibilityPrivate,
+		}},
+	}
+	response := UserToResponseDTO(user)
+
+	// When
+	public := response.VisibleTo(Viewer{Audience: entities.VisibilityPublic})
+	staff := response.VisibleTo(Viewer{Audience: entities.VisibilityPrivate})
+	internal := response.VisibleTo(Viewer{Audience: entities.VisibilityPrivate, Internal: true})
+	admin := response.VisibleTo(Viewer{Audience: entities.VisibilityPrivate, Admin: true})
+
+	// Then
+	assert.Equal(t, "JD", public.DisplayName)
+	assert.Empty(t, public.Pronouns)
+	assert.Equal(t, "she/her", staff.Pronouns)
+	assert.Empty(t, staff.DateOfBirth, "only admins see dates of birth")
+	assert.Equal(t, "2001-04-02", admin.DateOfBirth)
+	assert.Equal(t, "2001-04-02", response.DateOfBirth, "filtering leaves the response itself alone")
+	assert.Nil(t, public.ExternalIDs)
+	assert.Nil(t, staff.ExternalIDs, "only admins and internal services see external IDs")
+	assert.Empty(t, internal.DateOfBirth)
+	assert.Equal(t, map[string]string{"hr": "E-1"}, internal.ExternalIDs)
+	assert.Equal(t, map[string]string{"hr": "E-1"}, admin.ExternalIDs)
+}
+
+func TestPhoneLookupResponseDTO_VisibleTo(t *testing.T) {
+	// Given a lookup matching a user with a date of birth
+	dateOfBirth := time.Date(2001, 4, 2, 0, 0, 0, 0, time.UTC)
+	response := &PhoneLookupResponseDTO{
+		Users: []*UserResponseDTO{UserToResponseDTO(&entities.User{ID: 1, DateOfBirth: &dateOfBirth})},
+		Count: 1,
+	}
+
+	// When
+	staff := response.VisibleTo(Viewer{Audience: entities.VisibilityPrivate})
+
+	// Then every match is filtered
+	require.Len(t, staff.Users, 1)
+	assert.Empty(t, staff.Users[0].DateOfBirth)
+	assert.Equal(t, 1, staff.Count)
+	assert.Equal(t, "2001-04-02", response.Users[0].DateOfBirth)
+}
@@ -0,0 +1,25 @@
+package dto
+
+import "time"
+
+// VerificationResendResponseDTO tells clients whether a verification email
+// went out and when the next one may be requested, so they can show a
+// countdown instead of retrying
+type VerificationResendResponseDTO struct {
+	// Sent is false when an earlier request already sent one within the cooldown
+	Sent bool `json:"sent"`
+	// CooldownRemainingSeconds is how long until another resend is accepted
+	CooldownRemainingSeconds int `json:"cooldown_remaining_seconds"`
+	// NextResendAt is when another resend is accepted
+	NextResendAt Timestamp `json:"next_resend_at"`
+}
+
+// NewVerificationResendResponseDTO describes a resend decided at now with
+// remaining cooldown left
+func NewVerificationResendResponseDTO(sent bool, now time.Time, remaining time.Duration) *VerificationResendResponseDTO {
+	return &VerificationResendResponseDTO{
+		Sent:                     sent,
+		CooldownRemainingSeconds: int((remaining + time.Second - 1) / time.Second),
+		NextResendAt:             NewTimestamp(now.Add(remaining)),
+	}
+}
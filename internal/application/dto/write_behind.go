@@ -0,0 +1,11 @@
+package dto
+
+// QueuedWriteStatusQueued is the status of a write waiting in the offline queue
+const QueuedWriteStatusQueued = "queued"
+
+// QueuedWriteResponseDTO answers a write accepted while the database is
+// unreachable
+type QueuedWriteResponseDTO struct {
+	WriteID string `json:"write_id"`
+	Status  string `json:"status"`
+}
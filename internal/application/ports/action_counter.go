@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain/entities"
+)
+
+// ActionCounterStore persists per-user counters of sensitive actions so
+// limits hold across restarts and instances
+type ActionCounterStore interface {
+	// Increment counts one occurrence of action at now and returns the count
+	// for the window it falls in. A window older than window is restarted.
+	Increment(ctx context.Context, userID uint, action entities.SensitiveAction, now time.Time, window time.Duration) (*entities.ActionCount, error)
+
+	// Reset clears every counter of a user
+	Reset(ctx context.Context, userID uint) error
+}
@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+	"user-service/internal/domain/entities"
+)
+
+// AuditLogger records audit events for later review
+type AuditLogger interface {
+	Record(ctx context.Context, event *entities.AuditEvent)
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"user-service/internal/domain/entities"
+)
+
+// DeletionChecker lets a dependent system veto the deletion of a user, for
+// example while it still holds open invoices for them
+type DeletionChecker interface {
+	// Name identifies the checker in blockers and logs
+	Name() string
+
+	// CheckDeletion returns the reasons the user cannot be deleted, if any. An
+	// error means the checker could not decide.
+	CheckDeletion(ctx context.Context, user *entities.User) ([]string, error)
+}
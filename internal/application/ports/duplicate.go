@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"user-service/internal/domain/entities"
+)
+
+// DuplicateSuggestionStore persists the latest duplicate detection results
+type DuplicateSuggestionStore interface {
+	// Replace swaps the stored suggestions for the given ones atomically
+	Replace(ctx context.Context, suggestions []*entities.DuplicateSuggestion) error
+
+	// List returns suggestions with at least minConfidence, most confident
+	// first, with their total count
+	List(ctx context.Context, minConfidence float64, limit, offset int) ([]*entities.DuplicateSuggestion, int64, error)
+}
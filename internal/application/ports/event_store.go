@@ -0,0 +1,35 @@
+package ports
+
+import (
+	"context"
+	"user-service/internal/domain/entities"
+)
+
+// EventStore persists every domain event with a global sequence number and a
+// version per aggregate, so consumers can read changes and replay history
+type EventStore interface {
+	// Append stores an event at the next version of its aggregate, in the
+	// residency region ctx is scoped to
+	Append(ctx context.Context, event *entities.UserEvent) (*entities.StoredEvent, error)
+
+	// ListSince returns up to limit events with a sequence greater than
+	// afterSequence, oldest first. Events behind one that may still commit are
+	// held back, so a cursor at the last event returned never skips one.
+	ListSince(ctx context.Context, afterSequence uint64, limit int) ([]*entities.StoredEvent, error)
+
+	// ListByAggregate returns up to limit events of one user of the residency
+	// region ctx is scoped to with a version greater than afterVersion, oldest
+	// first
+	ListByAggregate(ctx context.Context, userID uint, afterVersion uint64, limit int) ([]*entities.StoredEvent, error)
+
+	// HandleOnce runs handle for the event unless handler already processed it,
+	// and reports whether it ran. The receipt is recorded in the same
+	// transaction, so a failed handle leaves the event to be retried; handlers
+	// with side effects outside the database must still be idempotent. handle
+	// is given a context bound to the transaction, so the events it appends
+	// with it commit or roll back with the receipt.
+	HandleOnce(ctx context.Context, handler string, sequence uint64, handle func(ctx context.Context) error) (bool, error)
+
+	// Checkpoint returns the highest sequence handler has processed, or 0
+	Checkpoint(ctx context.Context, handler string) (uint64, error)
+}
@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+	"user-service/internal/domain/entities"
+)
+
+// EventPublisher publishes user domain events to interested consumers
+type EventPublisher interface {
+	Publish(ctx context.Context, event *entities.UserEvent) error
+}
@@ -0,0 +1,72 @@
+package ports
+
+import (
+	"context"
+	"sync"
+)
+
+// Feature is an optional capability the service keeps serving requests
+// without, in a degraded mode
+type Feature string
+
+const (
+	FeatureSearch   Feature = "search"
+	FeatureCache    Feature = "cache"
+	FeatureWebhooks Feature = "webhooks"
+)
+
+// FeatureHealth tells whether an optional feature is currently usable, so
+// callers can fall back instead of failing
+type FeatureHealth interface {
+	// Degraded reports whether feature is unavailable, and why
+	Degraded(feature Feature) (reason string, degraded bool)
+}
+
+// Degradation records that a request was served without a feature
+type Degradation struct {
+	Feature Feature `json:"feature"`
+	Reason  string  `json:"reason"`
+}
+
+type degradationsKey struct{}
+
+type degradations struct {
+	mu    sync.Mutex
+	items []Degradation
+}
+
+// WithDegradations lets code handling the request behind ctx note the
+// features it had to do without, see NoteDegraded
+func WithDegradations(ctx context.Context) context.Context {
+	return context.WithValue(ctx, degradationsKey{}, &degradations{})
+}
+
+// NoteDegraded records that the request behind ctx is served without feature.
+// It does nothing when ctx does not track degradations.
+func NoteDegraded(ctx context.Context, feature Feature, reason string) {
+	tracked, ok := ctx.Value(degradationsKey{}).(*degradations)
+	if !ok {
+		return
+	}
+
+	tracked.mu.Lock()
+	defer tracked.mu.Unlock()
+	for _, item := range tracked.items {
+		if item.Feature == feature {
+			return
+		}
+	}
+	tracked.items = append(tracked.items, Degradation{Feature: feature, Reason: reason})
+}
+
+// DegradationsFrom returns the features the request behind ctx did without
+func DegradationsFrom(ctx context.Context) []Degradation {
+	tracked, ok := ctx.Value(degradationsKey{}).(*degradations)
+	if !ok {
+		return nil
+	}
+
+	tracked.mu.Lock()
+	defer tracked.mu.Unlock()
+	return append([]Degradation(nil), tracked.items...)
+}
@@ -0,0 +1,31 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain/entities"
+)
+
+// Job is a unit of background work run by the scheduler
+type Job interface {
+	Name() string
+	Run(ctx context.Context) error
+}
+
+// JobStateStore persists job state so pauses and run history survive restarts
+// and instances don't run the same job concurrently
+type JobStateStore interface {
+	// Get returns the state of a job, or a never-run state when none is stored
+	Get(ctx context.Context, name string) (*entities.JobState, error)
+
+	// SetPaused pauses or resumes the schedule of a job
+	SetPaused(ctx context.Context, name string, paused bool) (*entities.JobState, error)
+
+	// Start claims the job until leaseUntil and records the run as started.
+	// It reports false when another run still holds the claim.
+	Start(ctx context.Context, name string, trigger entities.JobTrigger, startedAt, leaseUntil time.Time) (bool, error)
+
+	// Finish records the outcome of a run and releases the claim
+	Finish(ctx context.Context, name string, finishedAt time.Time, duration time.Duration, runErr error) error
+}
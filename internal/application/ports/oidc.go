@@ -0,0 +1,56 @@
+package ports
+
+import (
+	"context"
+
+	"user-service/internal/domain/entities"
+)
+
+// OIDCClientRepository stores the clients registered with the OIDC provider
+type OIDCClientRepository interface {
+	// Create registers a new client
+	Create(ctx context.Context, client *entities.OIDCClient) (*entities.OIDCClient, error)
+
+	// GetByClientID retrieves a client by its public client ID
+	GetByClientID(ctx context.Context, clientID string) (*entities.OIDCClient, error)
+
+	// UpdateClaimMappings replaces the claim mappings of a client
+	UpdateClaimMappings(ctx context.Context, clientID string, mappings map[string]string) (*entities.OIDCClient, error)
+
+	// List returns every registered client, oldest first
+	List(ctx context.Context) ([]*entities.OIDCClient, error)
+}
+
+// AuthorizationCodeStore keeps authorization codes until they are exchanged
+type AuthorizationCodeStore interface {
+	// Save stores a newly issued code
+	Save(ctx context.Context, code *entities.AuthorizationCode) error
+
+	// Consume removes and returns the code with the given hash, so each code
+	// is exchanged at most once. It fails with ErrInvalidAuthorizationCode
+	// when no such code exists.
+	Consume(ctx context.Context, codeHash string) (*entities.AuthorizationCode, error)
+}
+
+// Token types, set in the JOSE typ header so one kind of token cannot be
+// passed off as another
+const (
+	TokenTypeIDToken     = "JWT"
+	TokenTypeAccessToken = "at+jwt"
+)
+
+// TokenSigner signs and verifies the JSON Web Tokens the OIDC provider issues
+type TokenSigner interface {
+	// Sign returns a signed token of the given type carrying claims
+	Sign(tokenType string, claims map[string]interface{}) (string, error)
+
+	// Verify checks the signature and type of token and returns its claims.
+	// Time-based claims are left to the caller.
+	Verify(tokenType, token string) (map[string]interface{}, error)
+
+	// KeySet returns the JSON Web Key Set clients verify tokens with
+	KeySet() map[string]interface{}
+
+	// Algorithm is the JWS algorithm tokens are signed with, e.g. RS256
+	Algorithm() string
+}
@@ -2,8 +2,29 @@ package ports
 
 import (
 	"context"
+	"time"
 )
 
 type HealthChecker interface {
 	HealthCheck(ctx context.Context) error
 }
+
+type HealthStatus string
+
+const (
+	HealthStatusHealthy   HealthStatus = "healthy"
+	HealthStatusDegraded  HealthStatus = "degraded"
+	HealthStatusUnhealthy HealthStatus = "unhealthy"
+)
+
+// ComponentHealth is the graded health report of a single dependency
+type ComponentHealth struct {
+	Status  HealthStatus
+	Reason  string
+	Latency time.Duration
+}
+
+// ComponentHealthChecker reports graded health instead of a plain up/down error
+type ComponentHealthChecker interface {
+	CheckHealth(ctx context.Context) ComponentHealth
+}
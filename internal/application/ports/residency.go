@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"user-service/internal/domain/entities"
+)
+
+type residencyKey struct{}
+
+// WithResidency scopes persistence done with ctx to the given region
+func WithResidency(ctx context.Context, residency entities.Residency) context.Context {
+	return context.WithValue(ctx, residencyKey{}, residency)
+}
+
+// ResidencyFrom returns the region persistence done with ctx is scoped to
+func ResidencyFrom(ctx context.Context) (entities.Residency, bool) {
+	residency, ok := ctx.Value(residencyKey{}).(entities.Residency)
+	return residency, ok
+}
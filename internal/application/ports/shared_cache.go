@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// SharedCache is a key-value cache shared by every instance of the service
+type SharedCache interface {
+	// Get returns the value stored under key, and false when there is none
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores value under key for ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the given keys; missing keys are ignored
+	Delete(ctx context.Context, keys ...string) error
+}
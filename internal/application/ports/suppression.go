@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+	"user-service/internal/domain/entities"
+)
+
+// SuppressionList stores the email addresses no communications may go to,
+// keyed by their canonical form
+type SuppressionList interface {
+	// Get returns the suppression of an address, including lapsed ones
+	Get(ctx context.Context, email string) (*entities.Suppression, error)
+
+	// Put adds a suppression, replacing any of the same address
+	Put(ctx context.Context, suppression *entities.Suppression) error
+
+	// Delete lifts the suppression of an address
+	Delete(ctx context.Context, email string) error
+}
@@ -0,0 +1,41 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain/entities"
+)
+
+// UsageStore persists hourly usage rollups per tenant, shared by every
+// instance metering calls
+type UsageStore interface {
+	// Flush adds a batch to the rollups in one transaction. A batch whose ID
+	// was flushed before is skipped, so a flush retried after an ambiguous
+	// failure is not counted twice.
+	Flush(ctx context.Context, batch *entities.UsageBatch) error
+
+	// List returns the rollups of the hours within [from, to), of one tenant
+	// or of all when tenant is empty, ordered by hour and tenant
+	List(ctx context.Context, tenant string, from, to time.Time) ([]*entities.UsageRollup, error)
+
+	// ActiveUsers counts the distinct users of each tenant within [from, to),
+	// of one tenant or of all when tenant is empty
+	ActiveUsers(ctx context.Context, tenant string, from, to time.Time) (map[string]int64, error)
+
+	// NextExport returns the oldest hour before before whose rollups changed
+	// since they were last exported, at the version it is exported with, or
+	// nil when every such hour is exported
+	NextExport(ctx context.Context, before time.Time) (*entities.UsageExport, error)
+
+	// CompleteExport records that export was delivered. Rollups that changed
+	// since NextExport read them stay due for the next version.
+	CompleteExport(ctx context.Context, export *entities.UsageExport) error
+}
+
+// UsageExporter delivers usage exports to the billing system
+type UsageExporter interface {
+	// Export stores content under name, replacing an earlier delivery of the
+	// same name
+	Export(ctx context.Context, name string, content []byte) error
+}
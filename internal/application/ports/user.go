@@ -2,23 +2,134 @@ package ports
 
 import (
 	"context"
+	"time"
 	"user-service/internal/domain/entities"
 )
 
+// UserFilter narrows down user listings
+type UserFilter struct {
+	// Tags restricts results to users carrying all of the given tags
+	Tags []string
+	// Residencies restricts results to users resident in one of the given regions
+	Residencies []entities.Residency
+	// Email restricts results to the user with exactly this email address
+	Email string
+	// Country restricts results to users living in the country with this
+	// ISO 3166-1 alpha-2 code
+	Country string
+	// LegalHold restricts results to users with a legal hold, lapsed or not
+	LegalHold bool
+	// ReferredBy restricts results to the users the user with this ID referred
+	ReferredBy uint
+	// ReactivationDueBy restricts results to suspended users due to be
+	// reactivated by this time
+	ReactivationDueBy *time.Time
+	// EmailContains restricts results to users whose email address contains
+	// this fragment, ignoring case
+	EmailContains string
+	// PhoneContains restricts results to users whose phone number's digits
+	// contain these digits
+	PhoneContains string
+	// AfterID restricts results to users with a greater ID, continuing a
+	// listing from a cursor
+	AfterID uint
+}
+
+// MaxPhoneMatches caps the users a phone lookup returns
+const MaxPhoneMatches = 100
+
+// UpsertOutcome reports what an upsert did
+type UpsertOutcome string
+
+const (
+	UpsertCreated   UpsertOutcome = "created"
+	UpsertUpdated   UpsertOutcome = "updated"
+	UpsertUnchanged UpsertOutcome = "unchanged"
+)
+
+// UpsertResolver reconciles an existing user with the incoming data in place
+// and reports whether anything changed
+type UpsertResolver func(existing *entities.User) (changed bool)
+
 // UserRepository defines the contract for user persistence
 type UserRepository interface {
 	// Create a new user
 	Create(ctx context.Context, user *entities.User) (*entities.User, error)
 
+	// CreateBatch inserts users in batches of batchSize within one transaction
+	CreateBatch(ctx context.Context, users []*entities.User, batchSize int) ([]*entities.User, error)
+
+	// UpsertByEmail creates the user when its email is unknown and otherwise
+	// lets resolve update the existing user, atomically
+	UpsertByEmail(ctx context.Context, user *entities.User, resolve UpsertResolver) (*entities.User, UpsertOutcome, error)
+
 	// GetByID retrieves a user by their ID
 	GetByID(ctx context.Context, id uint) (*entities.User, error)
 
 	// GetByEmail retrieves a user by their email (useful for login)
 	GetByEmail(ctx context.Context, email string) (*entities.User, error)
 
+	// GetByExternalID retrieves the user a source system knows by externalID
+	GetByExternalID(ctx context.Context, source, externalID string) (*entities.User, error)
+
+	// ListExternalIDs returns a user's external IDs by source, nil if none.
+	// Deleted users keep theirs.
+	ListExternalIDs(ctx context.Context, userID uint) (map[string]string, error)
+
+	// ExistsByID checks if a user with the given ID exists without loading it
+	ExistsByID(ctx context.Context, id uint) (bool, error)
+
 	// ExistsByEmail checks if a user with the given email exists
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
 
+	// GetByPhone retrieves the users sharing a phone number, matched on its
+	// normalized digits through a search token, at most MaxPhoneMatches of
+	// them ordered by ID
+	GetByPhone(ctx context.Context, phone string) ([]*entities.User, error)
+
+	// ExistsByPhone checks if any user has the given phone number
+	ExistsByPhone(ctx context.Context, phone string) (bool, error)
+
+	// GetByReferralCode retrieves the user whose referral code is code
+	GetByReferralCode(ctx context.Context, code string) (*entities.User, error)
+
+	// FindExistingEmails returns which of the given emails are already registered
+	FindExistingEmails(ctx context.Context, emails []string) ([]string, error)
+
 	// List users with pagination (useful for admin features)
-	List(ctx context.Context, limit, offset int) ([]*entities.User, error)
+	List(ctx context.Context, filter UserFilter, limit, offset int) ([]*entities.User, error)
+
+	// Count returns how many users match a filter
+	Count(ctx context.Context, filter UserFilter) (int64, error)
+
+	// AddTags attaches tags to a user, ignoring ones already present
+	AddTags(ctx context.Context, userID uint, tags []string) error
+
+	// RemoveTags detaches tags from a user
+	RemoveTags(ctx context.Context, userID uint, tags []string) error
+
+	// UpdateStatus stores the user's status and suspension details, provided
+	// the stored status is still from. It fails with ErrStatusChanged otherwise.
+	UpdateStatus(ctx context.Context, user *entities.User, from entities.UserStatus) error
+
+	// UpdatePreferences stores the user's preferences
+	UpdatePreferences(ctx context.Context, userID uint, preferences entities.UserPreferences) error
+
+	// UpdateProfile stores the user's display name and pronouns
+	UpdateProfile(ctx context.Context, user *entities.User) error
+
+	// UpdateLegalHold stores the user's legal hold, clearing it when nil
+	UpdateLegalHold(ctx context.Context, user *entities.User) error
+
+	// UpdateReferrer stores who referred the user, provided nobody is stored
+	// yet. It fails with ErrAlreadyReferred otherwise.
+	UpdateReferrer(ctx context.Context, user *entities.User) error
+
+	// UpdateVerificationSentAt stores when a verification email was last
+	// requested for the user, provided the stored time is still previous. It
+	// fails with ErrVerificationSentChanged otherwise.
+	UpdateVerificationSentAt(ctx context.Context, user *entities.User, previous *time.Time) error
+
+	// Delete soft-deletes a user
+	Delete(ctx context.Context, id uint) error
 }
@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+	"user-service/internal/domain/entities"
+)
+
+// UserNoteRepository defines the contract for support note persistence
+type UserNoteRepository interface {
+	// Create a new note
+	Create(ctx context.Context, note *entities.UserNote) (*entities.UserNote, error)
+
+	// GetByID retrieves a note belonging to the given user
+	GetByID(ctx context.Context, userID, noteID uint) (*entities.UserNote, error)
+
+	// ListByUser returns the notes of a user visible to viewer, newest first, with the total count
+	ListByUser(ctx context.Context, userID uint, viewer string, limit, offset int) ([]*entities.UserNote, int64, error)
+
+	// Update persists changes to an existing note
+	Update(ctx context.Context, note *entities.UserNote) (*entities.UserNote, error)
+
+	// Delete removes a note
+	Delete(ctx context.Context, userID, noteID uint) error
+}
@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+
+	"user-service/internal/domain/entities"
+)
+
+// WriteJournal durably queues writes accepted while the database is
+// unreachable, in the order they were accepted
+type WriteJournal interface {
+	// Append assigns the write an ID and stores it. It fails with
+	// ErrWriteQueueFull once the journal holds its maximum.
+	Append(ctx context.Context, write *entities.QueuedWrite) error
+
+	// Pending returns the queued writes, oldest first
+	Pending(ctx context.Context) ([]*entities.QueuedWrite, error)
+
+	// Complete removes a write that was applied
+	Complete(ctx context.Context, id string) error
+
+	// Reject moves a write that cannot be applied aside, keeping it with its
+	// rejection for operators to review
+	Reject(ctx context.Context, write *entities.QueuedWrite) error
+
+	// Len returns the number of queued writes
+	Len(ctx context.Context) (int, error)
+}
@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+	"strconv"
+	"time"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	userErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+)
+
+// SensitiveActionLimiter limits how often a single account may perform
+// sensitive actions, independently of the IP-based rate limit, so spreading
+// attempts over many addresses does not help an attacker
+type SensitiveActionLimiter interface {
+	// Allow counts an attempt of action for the user and fails with an
+	// ActionRateLimitedError once the user exceeded the action's limit
+	Allow(ctx context.Context, userID uint, action entities.SensitiveAction) error
+
+	// Reset clears the user's counters, e.g. once support verified the
+	// account holder
+	Reset(ctx context.Context, userID uint, actor string) error
+}
+
+// sensitiveActionLimiterImpl implements SensitiveActionLimiter interface
+type sensitiveActionLimiterImpl struct {
+	userRepo ports.UserRepository
+	counters ports.ActionCounterStore
+	limits   map[entities.SensitiveAction]entities.ActionLimit
+	audit    ports.AuditLogger
+	logger   logger.Logger
+	now      func() time.Time
+}
+
+// NewSensitiveActionLimiter creates a limiter enforcing limits; actions
+// without a limit are not restricted
+func NewSensitiveActionLimiter(userRepo ports.UserRepository, counters ports.ActionCounterStore, limits map[entities.SensitiveAction]entities.ActionLimit, audit ports.AuditLogger, log logger.Logger) SensitiveActionLimiter {
+	return &sensitiveActionLimiterImpl{
+		userRepo: userRepo,
+		counters: counters,
+		limits:   limits,
+		audit:    audit,
+		logger:   log.With("component", "sensitive_action_limiter"),
+		now:      time.Now,
+	}
+}
+
+// Allow implements SensitiveActionLimiter
+func (l *sensitiveActionLimiterImpl) Allow(ctx context.Context, userID uint, action entities.SensitiveAction) error {
+	limit, ok := l.limits[action]
+	if !ok || limit.Max <= 0 {
+		return nil
+	}
+
+	now := l.now()
+	count, err := l.counters.Increment(ctx, userID, action, now, limit.Window)
+	if err != nil {
+		return err
+	}
+
+	if count.Count > limit.Max {
+		l.logger.Warn("Sensitive action limit exceeded",
+			"user_id", userID,
+			"action", action,
+			"count", count.Count,
+			"limit", limit.Max)
+		return &userErrors.ActionRateLimitedError{
+			Action: string(action),
+			Limit:  limit.Max,
+			Window: limit.Window,
+			Retry:  count.RetryAfter(limit, now),
+		}
+	}
+
+	return nil
+}
+
+// Reset implements SensitiveActionLimiter
+func (l *sensitiveActionLimiterImpl) Reset(ctx context.Context, userID uint, actor string) error {
+	l.logger.Info("Reset action limits use case called", "user_id", userID, "actor", actor)
+
+	exists, err := l.userRepo.ExistsByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return userErrors.ErrUserNotFound
+	}
+
+	if err := l.counters.Reset(ctx, userID); err != nil {
+		return err
+	}
+
+	l.audit.Record(ctx, &entities.AuditEvent{
+		Action:       "user.action_limits_reset",
+		ActorID:      actor,
+		ResourceType: "user",
+		ResourceID:   strconv.FormatUint(uint64(userID), 10),
+	})
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeActionCounterStore keeps action counters in memory with the same
+// window semantics as the database store
+type fakeActionCounterStore struct {
+	counts map[entities.SensitiveAction]*entities.ActionCount
+}
+
+func newFakeActionCounterStore() *fakeActionCounterStore {
+	return &fakeActionCounterStore{counts: make(map[entities.SensitiveAction]*entities.ActionCount)}
+}
+
+func (f *fakeActionCounterStore) Increment(ctx context.Context, userID uint, action entities.SensitiveAction, now time.Time, window time.Duration) (*entities.ActionCount, error) {
+	count, ok := f.counts[action]
+	if !ok || !count.WindowStart.After(now.Add(-window)) {
+		count = &entities.ActionCount{UserID: userID, Action: action, WindowStart: now}
+		f.counts[action] = count
+	}
+	count.Count++
+	copied := *count
+	return &copied, nil
+}
+
+func (f *fakeActionCounterStore) Reset(ctx context.Context, userID uint) error {
+	clear(f.counts)
+	return nil
+}
+
+func setupTestActionLimiter(now *time.Time) (SensitiveActionLimiter, *MockUserRepository, *fakeActionCounterStore, *MockAuditLogger) {
+	mockRepo := new(MockUserRepository)
+	store := newFakeActionCounterStore()
+	mockAudit := new(MockAuditLogger)
+	limits := map[entities.SensitiveAction]entities.ActionLimit{
+		entities.SensitiveActionPasswordReset: {Max: 2, Window: time.Hour},
+	}
+
+	limiter := NewSensitiveActionLimiter(mockRepo, store, limits, mockAudit, logger.New("test"))
+	limiter.(*sensitiveActionLimiterImpl).now = func() time.Time { return *now }
+	return limiter, mockRepo, store, mockAudit
+}
+
+func TestSensitiveActionLimiter_Allow_RejectsOverLimit(t *testing.T) {
+	// Given a limit of two password resets per hour
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	limiter, _, _, _ := setupTestActionLimiter(&now)
+	ctx := context.Background()
+
+	require.NoError(t, limiter.Allow(ctx, 1, entities.SensitiveActionPasswordReset))
+	now = now.Add(10 * time.Minute)
+	require.NoError(t, limiter.Allow(ctx, 1, entities.SensitiveActionPasswordReset))
+
+	// When a third reset is attempted within the hour
+	now = now.Add(10 * time.Minute)
+	err := limiter.Allow(ctx, 1, entities.SensitiveActionPasswordReset)
+
+	// Then it is rejected until the window started by the first reset ends
+	var limited *domainErrors.ActionRateLimitedError
+	require.ErrorAs(t, err, &limited)
+	assert.ErrorIs(t, err, domainErrors.ErrActionRateLimited)
+	assert.Equal(t, 40*time.Minute, limited.RetryAfter())
+
+	// And allowed again once it did
+	now = now.Add(40 * time.Minute)
+	assert.NoError(t, limiter.Allow(ctx, 1, entities.SensitiveActionPasswordReset))
+}
+
+func TestSensitiveActionLimiter_Allow_UnlimitedAction(t *testing.T) {
+	now := time.Now()
+	limiter, _, store, _ := setupTestActionLimiter(&now)
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, limiter.Allow(context.Background(), 1, entities.SensitiveActionEmailChange))
+	}
+	assert.Empty(t, store.counts, "actions without a limit are not counted")
+}
+
+func TestSensitiveActionLimiter_Reset(t *testing.T) {
+	// Given a user who hit the limit
+	now := time.Now()
+	limiter, mockRepo, _, mockAudit := setupTestActionLimiter(&now)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		_ = limiter.Allow(ctx, 1, entities.SensitiveActionPasswordReset)
+	}
+
+	mockRepo.On("ExistsByID", ctx, uint(1)).Return(true, nil)
+	mockAudit.On("Record", ctx, mock.MatchedBy(func(event *entities.AuditEvent) bool {
+		return event.Action == "user.action_limits_reset" && event.ActorID == "support"
+	})).Return()
+
+	// When
+	err := limiter.Reset(ctx, 1, "support")
+
+	// Then
+	require.NoError(t, err)
+	assert.NoError(t, limiter.Allow(ctx, 1, entities.SensitiveActionPasswordReset))
+	mockAudit.AssertExpectations(t)
+}
@@ -0,0 +1,243 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	userErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+)
+
+// activityDigestPageSize is the number of events read per query
+const activityDigestPageSize = 500
+
+// digestedEvents are the events a digest reports on
+var digestedEvents = []entities.UserEventType{
+	entities.UserEventSignedIn,
+	entities.UserEventUpdated,
+	entities.UserEventPreferencesChanged,
+}
+
+// digestKey identifies a user across residency regions, where user IDs repeat
+type digestKey struct {
+	residency entities.Residency
+	userID    uint
+}
+
+func compareDigestKeys(a, b digestKey) int {
+	if c := strings.Compare(string(a.residency), string(b.residency)); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.userID, b.userID)
+}
+
+// inRegion scopes ctx to the region of a user, leaving it unscoped for users
+// stored while residency was disabled
+func (k digestKey) inRegion(ctx context.Context) context.Context {
+	if k.residency == "" {
+		return ctx
+	}
+	return ports.WithResidency(ctx, k.residency)
+}
+
+// ActivityDigestJob summarizes the account activity of each user (sign-ins,
+// profile changes and new devices) since its previous run and publishes the
+// summaries as user.activity_digest events, which the notification service
+// turns into a security digest email. It runs as a scheduled job.
+type ActivityDigestJob struct {
+	events    ports.EventStore
+	userRepo  ports.UserRepository
+	publisher ports.EventPublisher
+	// period is how far back a digest looks; events older than that, e.g.
+	// left unread while the job was paused, are not reported
+	period time.Duration
+	now    func() time.Time
+	logger logger.Logger
+}
+
+// NewActivityDigestJob creates the activity digest job
+func NewActivityDigestJob(events ports.EventStore, userRepo ports.UserRepository, publisher ports.EventPublisher, period time.Duration, log logger.Logger) *ActivityDigestJob {
+	return &ActivityDigestJob{
+		events:    events,
+		userRepo:  userRepo,
+		publisher: publisher,
+		period:    period,
+		now:       time.Now,
+		logger:    log.With("component", "activity_digest"),
+	}
+}
+
+// Name implements ports.Job
+func (j *ActivityDigestJob) Name() string {
+	return "activity_digest"
+}
+
+// Run implements ports.Job. Events are read from the event store after the
+// job's checkpoint, and the checkpoint only moves once every digest is
+// published, so a failed run is retried whole. Users who opted out or are not
+// active get no digest.
+func (j *ActivityDigestJob) Run(ctx context.Context) error {
+	after, err := j.events.Checkpoint(ctx, j.Name())
+	if err != nil {
+		return err
+	}
+
+	end := j.now().UTC()
+	start := end.Add(-j.period)
+	digests, through, err := j.summarize(ctx, after, start, end)
+	if err != nil {
+		return err
+	}
+	if through == after {
+		j.logger.Info("No new events to digest")
+		return nil
+	}
+
+	published, skipped := 0, 0
+	_, err = j.events.HandleOnce(ctx, j.Name(), through, func(ctx context.Context) error {
+		for _, key := range slices.SortedFunc(maps.Keys(digests), compareDigestKeys) {
+			digest := digests[key]
+			regionCtx := key.inRegion(ctx)
+			user, err := j.userRepo.GetByID(regionCtx, key.userID)
+			if errors.Is(err, userErrors.ErrUserNotFound) {
+				skipped++
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if !user.IsActive() || user.Preferences.SecurityDigestOptOut {
+				skipped++
+				continue
+			}
+
+			if err := j.publisher.Publish(regionCtx, digest.Event().About(user)); err != nil {
+				return err
+			}
+			published++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	j.logger.Info("Activity digests published",
+		"published", published,
+		"skipped", skipped,
+		"through_sequence", through)
+	return nil
+}
+
+// summarize builds a digest per user from the events after the checkpoint
+// that occurred within [start, end), and returns the sequence of the last
+// event read
+func (j *ActivityDigestJob) summarize(ctx context.Context, after uint64, start, end time.Time) (map[digestKey]*entities.ActivityDigest, uint64, error) {
+	digests := make(map[digestKey]*entities.ActivityDigest)
+	// devices holds the devices each user signed in from during the period
+	devices := make(map[digestKey]map[string]entities.Device)
+	through := after
+
+	for {
+		events, err := j.events.ListSince(ctx, through, activityDigestPageSize)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for _, event := range events {
+			through = event.Sequence
+			if event.AggregateID == 0 || event.OccurredAt.Before(start) || !slices.Contains(digestedEvents, event.Type) {
+				continue
+			}
+
+			key := digestKey{residency: event.Residency, userID: event.AggregateID}
+			digest, ok := digests[key]
+			if !ok {
+				digest = &entities.ActivityDigest{UserID: key.userID, Residency: key.residency, AfterSequence: after, PeriodStart: start, PeriodEnd: end}
+				digests[key] = digest
+			}
+
+			switch event.Type {
+			case entities.UserEventSignedIn:
+				digest.SignIns++
+				if device, ok := deviceOf(event); ok {
+					if devices[key] == nil {
+						devices[key] = make(map[string]entities.Device)
+					}
+					if _, seen := devices[key][device.ID]; !seen {
+						devices[key][device.ID] = device
+					}
+				}
+			case entities.UserEventUpdated, entities.UserEventPreferencesChanged:
+				digest.ProfileChanges++
+			}
+		}
+
+		if len(events) < activityDigestPageSize {
+			break
+		}
+	}
+
+	for key, seen := range devices {
+		known, err := j.knownDevices(key.inRegion(ctx), key.userID, after, start)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, id := range slices.Sorted(maps.Keys(seen)) {
+			if !known[id] {
+				digests[key].NewDevices = append(digests[key].NewDevices, seen[id])
+			}
+		}
+	}
+
+	return digests, through, nil
+}
+
+// knownDevices returns the devices a user of the region ctx is scoped to
+// signed in from before the period being summarized
+func (j *ActivityDigestJob) knownDevices(ctx context.Context, userID uint, after uint64, start time.Time) (map[string]bool, error) {
+	known := make(map[string]bool)
+	var version uint64
+
+	for {
+		events, err := j.events.ListByAggregate(ctx, userID, version, activityDigestPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range events {
+			version = event.Version
+			if event.Sequence > after && !event.OccurredAt.Before(start) {
+				// Within the period; later events of the user are too
+				return known, nil
+			}
+			if event.Type != entities.UserEventSignedIn {
+				continue
+			}
+			if device, ok := deviceOf(event); ok {
+				known[device.ID] = true
+			}
+		}
+
+		if len(events) < activityDigestPageSize {
+			return known, nil
+		}
+	}
+}
+
+// deviceOf returns the device recorded by a sign-in event
+func deviceOf(event *entities.StoredEvent) (entities.Device, bool) {
+	id, _ := event.Data["device_id"].(string)
+	if id == "" {
+		return entities.Device{}, false
+	}
+	name, _ := event.Data["device_name"].(string)
+	return entities.Device{ID: id, Name: name, FirstSeenAt: event.OccurredAt}, true
+}
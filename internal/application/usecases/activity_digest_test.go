@@ -0,0 +1,248 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryEventStore is an in-memory ports.EventStore
+type memoryEventStore struct {
+	events   []*entities.StoredEvent
+	receipts map[string]uint64
+}
+
+func newMemoryEventStore() *memoryEventStore {
+	return &memoryEventStore{receipts: make(map[string]uint64)}
+}
+
+func (s *memoryEventStore) Append(ctx context.Context, event *entities.UserEvent) (*entities.StoredEvent, error) {
+	region, _ := ports.ResidencyFrom(ctx)
+	var version uint64
+	for _, stored := range s.events {
+		if stored.Residency == region && stored.AggregateID == event.UserID {
+			version = stored.Version
+		}
+	}
+
+	stored := &entities.StoredEvent{
+		Sequence:      uint64(len(s.events) + 1),
+		AggregateType: entities.UserAggregate,
+		AggregateID:   event.UserID,
+		Residency:     region,
+		Version:       version + 1,
+		Type:          event.Type,
+		Data:          event.Data,
+		OccurredAt:    event.OccurredAt,
+		RecordedAt:    event.OccurredAt,
+	}
+	s.events = append(s.events, stored)
+	return stored, nil
+}
+
+func (s *memoryEventStore) ListSince(_ context.Context, afterSequence uint64, limit int) ([]*entities.StoredEvent, error) {
+	var events []*entities.StoredEvent
+	for _, stored := range s.events {
+		if stored.Sequence > afterSequence && len(events) < limit {
+			events = append(events, stored)
+		}
+	}
+	return events, nil
+}
+
+func (s *memoryEventStore) ListByAggregate(ctx context.Context, userID uint, afterVersion uint64, limit int) ([]*entities.StoredEvent, error) {
+	region, _ := ports.ResidencyFrom(ctx)
+	var events []*entities.StoredEvent
+	for _, stored := range s.events {
+		if stored.Residency == region && stored.AggregateID == userID && stored.Version > afterVersion && len(events) < limit {
+			events = append(events, stored)
+		}
+	}
+	return events, nil
+}
+
+func (s *memoryEventStore) HandleOnce(ctx context.Context, handler string, sequence uint64, handle func(ctx context.Context) error) (bool, error) {
+	if s.receipts[handler] >= sequence {
+		return false, nil
+	}
+	if err := handle(ctx); err != nil {
+		return false, err
+	}
+	s.receipts[handler] = sequence
+	return true, nil
+}
+
+func (s *memoryEventStore) Checkpoint(_ context.Context, handler string) (uint64, error) {
+	return s.receipts[handler], nil
+}
+
+// record stores an event of user that occurred at the given time
+func (s *memoryEventStore) record(eventType entities.UserEventType, userID uint, data map[string]interface{}, occurredAt time.Time) {
+	s.recordIn(context.Background(), eventType, userID, data, occurredAt)
+}
+
+// recordIn stores an event of user in the residency region of ctx
+func (s *memoryEventStore) recordIn(ctx context.Context, eventType entities.UserEventType, userID uint, data map[string]interface{}, occurredAt time.Time) {
+	event := entities.NewUserEvent(eventType, userID, data)
+	event.OccurredAt = occurredAt
+	_, _ = s.Append(ctx, event)
+}
+
+func signInData(userAgent string) map[string]interface{} {
+	device := entities.DeviceFromUserAgent(userAgent, time.Time{})
+	return map[string]interface{}{"client_id": "web", "device_id": device.ID, "device_name": device.Name}
+}
+
+func setupTestActivityDigest(now time.Time) (*ActivityDigestJob, *memoryEventStore, *MockUserRepository, *MockEventPublisher) {
+	store := newMemoryEventStore()
+	mockRepo := new(MockUserRepository)
+	mockPublisher := new(MockEventPublisher)
+
+	job := NewActivityDigestJob(store, mockRepo, mockPublisher, 7*24*time.Hour, logger.New("test"))
+	job.now = func() time.Time { return now }
+	return job, store, mockRepo, mockPublisher
+}
+
+func TestActivityDigestJob_PublishesDigests(t *testing.T) {
+	// Given a user who signed in from a known and a new browser this week and
+	// changed their profile once
+	now := time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)
+	job, store, mockRepo, mockPublisher := setupTestActivityDigest(now)
+	ctx := context.Background()
+	store.record(entities.UserEventSignedIn, 1, signInData("Firefox"), now.Add(-30*24*time.Hour))
+	store.record(entities.UserEventSignedIn, 1, signInData("Firefox"), now.Add(-3*24*time.Hour))
+	store.record(entities.UserEventSignedIn, 1, signInData("Safari"), now.Add(-2*24*time.Hour))
+	store.record(entities.UserEventSignedIn, 1, signInData("Safari"), now.Add(-time.Hour))
+	store.record(entities.UserEventUpdated, 1, nil, now.Add(-24*time.Hour))
+	store.record(entities.UserEventTagsChanged, 1, nil, now.Add(-24*time.Hour))
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, Status: entities.UserStatusActive}, nil)
+
+	var digest *entities.UserEvent
+	mockPublisher.On("Publish", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		digest = args.Get(1).(*entities.UserEvent)
+	}).Return(nil)
+
+	// When
+	err := job.Run(ctx)
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, digest)
+	assert.Equal(t, entities.UserEventActivityDigest, digest.Type)
+	assert.Equal(t, "1:0", digest.Data["digest_id"])
+	assert.Equal(t, now.Add(-7*24*time.Hour), digest.Data["period_start"])
+	assert.Equal(t, 3, digest.Data["sign_ins"], "the sign-in before the period is left out")
+	assert.Equal(t, 1, digest.Data["profile_changes"])
+
+	devices := digest.Data["new_devices"].([]entities.Device)
+	require.Len(t, devices, 1)
+	assert.Equal(t, "Safari", devices[0].Name)
+	assert.Equal(t, now.Add(-2*24*time.Hour), devices[0].FirstSeenAt)
+}
+
+func TestActivityDigestJob_KeepsUsersOfEachRegionApart(t *testing.T) {
+	// Given users sharing an ID in two regions, one signing in from a new
+	// browser and the other changing their profile
+	now := time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)
+	job, store, mockRepo, mockPublisher := setupTestActivityDigest(now)
+	ctx := context.Background()
+	eu := ports.WithResidency(ctx, entities.ResidencyEU)
+	us := ports.WithResidency(ctx, entities.ResidencyUS)
+	store.recordIn(eu, entities.UserEventSignedIn, 1, signInData("Firefox"), now.Add(-30*24*time.Hour))
+	store.recordIn(us, entities.UserEventSignedIn, 1, signInData("Safari"), now.Add(-30*24*time.Hour))
+	store.recordIn(eu, entities.UserEventSignedIn, 1, signInData("Safari"), now.Add(-time.Hour))
+	store.recordIn(us, entities.UserEventUpdated, 1, nil, now.Add(-time.Hour))
+	mockRepo.On("GetByID", eu, uint(1)).Return(&entities.User{ID: 1, Email: "ada@example.com", Status: entities.UserStatusActive}, nil)
+	mockRepo.On("GetByID", us, uint(1)).Return(&entities.User{ID: 1, Email: "grace@example.com", Status: entities.UserStatusActive}, nil)
+
+	digests := make(map[string]*entities.UserEvent)
+	mockPublisher.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		event := args.Get(1).(*entities.UserEvent)
+		digests[event.Recipient] = event
+	}).Return(nil)
+
+	// When
+	err := job.Run(ctx)
+
+	// Then each user only hears about their own activity
+	require.NoError(t, err)
+	require.Len(t, digests, 2)
+	ada, grace := digests["ada@example.com"], digests["grace@example.com"]
+	assert.Equal(t, "eu:1:0", ada.Data["digest_id"])
+	assert.Equal(t, 1, ada.Data["sign_ins"])
+	assert.Equal(t, 0, ada.Data["profile_changes"])
+	assert.Len(t, ada.Data["new_devices"], 1, "Safari is only known to the other region's user")
+	assert.Equal(t, "us:1:0", grace.Data["digest_id"])
+	assert.Equal(t, 0, grace.Data["sign_ins"])
+	assert.Equal(t, 1, grace.Data["profile_changes"])
+	assert.Empty(t, grace.Data["new_devices"])
+}
+
+func TestActivityDigestJob_ResumesFromCheckpoint(t *testing.T) {
+	// Given a run that already covered the stored events
+	now := time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)
+	job, store, mockRepo, mockPublisher := setupTestActivityDigest(now)
+	ctx := context.Background()
+	store.record(entities.UserEventSignedIn, 1, signInData("Firefox"), now.Add(-time.Hour))
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, Status: entities.UserStatusActive}, nil)
+	mockPublisher.On("Publish", ctx, mock.Anything).Return(nil)
+	require.NoError(t, job.Run(ctx))
+
+	// When it runs again a week later
+	now = now.Add(7 * 24 * time.Hour)
+	job.now = func() time.Time { return now }
+	err := job.Run(ctx)
+
+	// Then nothing is reported twice
+	require.NoError(t, err)
+	mockPublisher.AssertNumberOfCalls(t, "Publish", 1)
+}
+
+func TestActivityDigestJob_SkipsOptedOutAndInactiveUsers(t *testing.T) {
+	now := time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)
+	job, store, mockRepo, mockPublisher := setupTestActivityDigest(now)
+	ctx := context.Background()
+	for id := uint(1); id <= 3; id++ {
+		store.record(entities.UserEventUpdated, id, nil, now.Add(-time.Hour))
+	}
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, Status: entities.UserStatusActive, Preferences: entities.UserPreferences{SecurityDigestOptOut: true}}, nil)
+	mockRepo.On("GetByID", ctx, uint(2)).Return(&entities.User{ID: 2, Status: entities.UserStatusSuspended}, nil)
+	mockRepo.On("GetByID", ctx, uint(3)).Return(nil, domainErrors.ErrUserNotFound)
+
+	err := job.Run(ctx)
+
+	require.NoError(t, err)
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestActivityDigestJob_RetriesAfterPublishFailure(t *testing.T) {
+	// Given a run whose digest could not be published
+	now := time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)
+	job, store, mockRepo, mockPublisher := setupTestActivityDigest(now)
+	ctx := context.Background()
+	store.record(entities.UserEventUpdated, 1, nil, now.Add(-time.Hour))
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, Status: entities.UserStatusActive}, nil)
+	mockPublisher.On("Publish", ctx, mock.Anything).Return(errors.New("broker down")).Once()
+	mockPublisher.On("Publish", ctx, mock.Anything).Return(nil).Once()
+	require.Error(t, job.Run(ctx))
+
+	// When it runs again
+	err := job.Run(ctx)
+
+	// Then the same digest goes out
+	require.NoError(t, err)
+	mockPublisher.AssertNumberOfCalls(t, "Publish", 2)
+	first := mockPublisher.Calls[0].Arguments.Get(1).(*entities.UserEvent)
+	second := mockPublisher.Calls[1].Arguments.Get(1).(*entities.UserEvent)
+	assert.Equal(t, first.Data["digest_id"], second.Data["digest_id"])
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	"user-service/pkg/logger"
+)
+
+// admitByAge applies the age gate of the region a new user will reside in:
+// the one they chose, else the one the request is served in. It reports
+// whether the user was made pending guardian consent.
+func admitByAge(ctx context.Context, gates entities.AgeGates, user *entities.User, now time.Time) (bool, error) {
+	region := user.Residency
+	if region == "" {
+		region, _ = ports.ResidencyFrom(ctx)
+	}
+	return gates.For(region).Admit(user, now)
+}
+
+// requestGuardianConsent publishes the event that starts the guardian consent
+// flow for a user the age gate made pending. The flow activates the user
+// through the status endpoint once a guardian consents.
+func requestGuardianConsent(ctx context.Context, publisher ports.EventPublisher, log logger.Logger, user *entities.User) {
+	event := entities.NewUserEvent(entities.UserEventGuardianConsentRequired, user.ID, nil).About(user)
+	if err := publisher.Publish(ctx, event); err != nil {
+		log.Error("Failed to publish guardian consent request", "user_id", user.ID, "error", err)
+	}
+}
@@ -0,0 +1,180 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	"user-service/pkg/logger"
+	"user-service/pkg/pagination"
+)
+
+// DuplicateOptions tunes duplicate detection
+type DuplicateOptions struct {
+	// MinConfidence drops weaker suggestions when detecting and listing
+	MinConfidence float64
+	// ScanPageSize is the number of users loaded per query while scanning
+	ScanPageSize int
+	// MaxGroupSize skips groups of users sharing a key that are too large to
+	// be one person, such as a shared office phone number
+	MaxGroupSize int
+}
+
+// DuplicateUseCases defines the interface for reviewing duplicate accounts
+type DuplicateUseCases interface {
+	ListSuggestions(ctx context.Context, page, pageSize int) (*dto.DuplicateSuggestionListResponseDTO, error)
+}
+
+// DuplicateDetector finds probable duplicate accounts. It runs as a scheduled
+// job and serves the stored suggestions to the admin API.
+type DuplicateDetector struct {
+	userRepo ports.UserRepository
+	store    ports.DuplicateSuggestionStore
+	options  DuplicateOptions
+	now      func() time.Time
+	logger   logger.Logger
+}
+
+// NewDuplicateDetector creates a duplicate detector
+func NewDuplicateDetector(userRepo ports.UserRepository, store ports.DuplicateSuggestionStore, options DuplicateOptions, log logger.Logger) *DuplicateDetector {
+	options.ScanPageSize = max(options.ScanPageSize, 1)
+	if options.MaxGroupSize < 2 {
+		options.MaxGroupSize = 20
+	}
+
+	return &DuplicateDetector{
+		userRepo: userRepo,
+		store:    store,
+		options:  options,
+		now:      time.Now,
+		logger:   log.With("component", "duplicate_detector"),
+	}
+}
+
+// Name implements ports.Job
+func (d *DuplicateDetector) Name() string {
+	return "duplicate_detection"
+}
+
+// Run implements ports.Job. Users are grouped by canonical email, phone, name
+// and the sound of their name so only accounts sharing one of them are compared, and the stored
+// suggestions are replaced with the new results.
+func (d *DuplicateDetector) Run(ctx context.Context) error {
+	groups := make(map[string][]*entities.User)
+	scanned := 0
+
+	for offset := 0; ; offset += d.options.ScanPageSize {
+		users, err := d.userRepo.List(ctx, ports.UserFilter{}, d.options.ScanPageSize, offset)
+		if err != nil {
+			return err
+		}
+
+		for _, user := range users {
+			for _, key := range duplicateKeys(user) {
+				groups[key] = append(groups[key], user)
+			}
+		}
+		scanned += len(users)
+
+		if len(users) < d.options.ScanPageSize {
+			break
+		}
+	}
+
+	suggestions := d.compare(groups)
+	if err := d.store.Replace(ctx, suggestions); err != nil {
+		return err
+	}
+
+	d.logger.Info("Duplicate detection finished", "users_scanned", scanned, "suggestions", len(suggestions))
+	return nil
+}
+
+// duplicateKeys returns the keys under which user is grouped for comparison
+func duplicateKeys(user *entities.User) []string {
+	keys := []string{"email:" + entities.CanonicalEmail(user.Email)}
+	if phone := entities.NormalizePhone(user.Phone); phone != "" {
+		keys = append(keys, "phone:"+phone)
+	}
+	if name := entities.NormalizedName(user); name != "" {
+		keys = append(keys, "name:"+name)
+	}
+	// Names spelled differently but sounding alike are only compared when
+	// grouped by how they sound
+	if sound := entities.PhoneticName(user); sound != "" {
+		keys = append(keys, "sound:"+sound)
+	}
+	return keys
+}
+
+// compare scores every pair of users within a group, most confident first
+func (d *DuplicateDetector) compare(groups map[string][]*entities.User) []*entities.DuplicateSuggestion {
+	now := d.now().UTC()
+	seen := make(map[[2]uint]bool)
+	var suggestions []*entities.DuplicateSuggestion
+
+	for key, users := range groups {
+		if len(users) > d.options.MaxGroupSize {
+			d.logger.Warn("Skipping oversized duplicate group", "key_type", keyType(key), "users", len(users))
+			continue
+		}
+
+		for i, a := range users {
+			for _, b := range users[i+1:] {
+				pair := [2]uint{min(a.ID, b.ID), max(a.ID, b.ID)}
+				if seen[pair] {
+					continue
+				}
+				seen[pair] = true
+
+				suggestion := entities.NewDuplicateSuggestion(a, b, now)
+				if suggestion != nil && suggestion.Confidence >= d.options.MinConfidence {
+					suggestions = append(suggestions, suggestion)
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(suggestions, func(a, b *entities.DuplicateSuggestion) int {
+		return cmp.Or(
+			cmp.Compare(b.Confidence, a.Confidence),
+			cmp.Compare(a.UserID, b.UserID),
+			cmp.Compare(a.DuplicateUserID, b.DuplicateUserID),
+		)
+	})
+	return suggestions
+}
+
+// keyType strips the value from a group key so it can be logged
+func keyType(key string) string {
+	kind, _, _ := strings.Cut(key, ":")
+	return kind
+}
+
+// ListSuggestions returns a page of the latest duplicate suggestions
+func (d *DuplicateDetector) ListSuggestions(ctx context.Context, page, pageSize int) (*dto.DuplicateSuggestionListResponseDTO, error) {
+	d.logger.Info("ListSuggestions use case called", "page", page, "page_size", pageSize)
+
+	page, pageSize = pagination.DefaultLimits.Page(page, pageSize)
+
+	suggestions, total, err := d.store.List(ctx, d.options.MinConfidence, pageSize, pagination.Offset(page, pageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.DuplicateSuggestionListResponseDTO{
+		Suggestions: make([]*dto.DuplicateSuggestionResponseDTO, 0, len(suggestions)),
+		Total:       total,
+		Page:        page,
+		PageSize:    pageSize,
+	}
+	for _, suggestion := range suggestions {
+		response.Suggestions = append(response.Suggestions, dto.DuplicateSuggestionToResponseDTO(suggestion))
+	}
+	return response, nil
+}
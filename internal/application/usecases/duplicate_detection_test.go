@@ -0,0 +1,142 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryDuplicateStore keeps the last replaced suggestions in memory
+type memoryDuplicateStore struct {
+	suggestions []*entities.DuplicateSuggestion
+}
+
+func (s *memoryDuplicateStore) Replace(_ context.Context, suggestions []*entities.DuplicateSuggestion) error {
+	s.suggestions = suggestions
+	return nil
+}
+
+func (s *memoryDuplicateStore) List(_ context.Context, minConfidence float64, limit, offset int) ([]*entities.DuplicateSuggestion, int64, error) {
+	var matching []*entities.DuplicateSuggestion
+	for _, suggestion := range s.suggestions {
+		if suggestion.Confidence >= minConfidence {
+			matching = append(matching, suggestion)
+		}
+	}
+	total := int64(len(matching))
+	if offset >= len(matching) {
+		return nil, total, nil
+	}
+	return matching[offset:min(offset+limit, len(matching))], total, nil
+}
+
+func setupTestDuplicateDetector(options DuplicateOptions) (*DuplicateDetector, *MockUserRepository, *memoryDuplicateStore) {
+	mockRepo := new(MockUserRepository)
+	store := &memoryDuplicateStore{}
+	return NewDuplicateDetector(mockRepo, store, options, logger.New("test")), mockRepo, store
+}
+
+func TestDuplicateDetector_Run_FindsDuplicatesAcrossPages(t *testing.T) {
+	// Given two pages of users with a shared phone split across them
+	detector, mockRepo, store := setupTestDuplicateDetector(DuplicateOptions{MinConfidence: 0.6, ScanPageSize: 2})
+
+	mockRepo.On("List", mock.Anything, ports.UserFilter{}, 2, 0).Return([]*entities.User{
+		{ID: 1, Email: "john.doe@gmail.com", FirstName: "John", LastName: "Doe", Phone: "+34 600 123 456"},
+		{ID: 2, Email: "jane@example.com", FirstName: "Jane", LastName: "Smith"},
+	}, nil)
+	mockRepo.On("List", mock.Anything, ports.UserFilter{}, 2, 2).Return([]*entities.User{
+		{ID: 3, Email: "johndoe@gmail.com", FirstName: "John", LastName: "Doe"},
+		{ID: 4, Email: "j.smith@example.com", FirstName: "Janet", LastName: "Smyth", Phone: "0034 600 123 456"},
+	}, nil)
+	mockRepo.On("List", mock.Anything, ports.UserFilter{}, 2, 4).Return([]*entities.User{}, nil)
+
+	// When
+	err := detector.Run(context.Background())
+
+	// Then the email match ranks above the phone match
+	require.NoError(t, err)
+	require.Len(t, store.suggestions, 2)
+	assert.Equal(t, [2]uint{1, 3}, [2]uint{store.suggestions[0].UserID, store.suggestions[0].DuplicateUserID})
+	assert.Equal(t, [2]uint{1, 4}, [2]uint{store.suggestions[1].UserID, store.suggestions[1].DuplicateUserID})
+	assert.Equal(t, []entities.DuplicateSignal{entities.DuplicateSignalPhone}, store.suggestions[1].Signals)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDuplicateDetector_Run_NameAloneIsBelowThreshold(t *testing.T) {
+	// Given two unrelated accounts that only share a name
+	detector, mockRepo, store := setupTestDuplicateDetector(DuplicateOptions{MinConfidence: 0.6, ScanPageSize: 10})
+
+	mockRepo.On("List", mock.Anything, ports.UserFilter{}, 10, 0).Return([]*entities.User{
+		{ID: 1, Email: "john@example.com", FirstName: "John", LastName: "Doe"},
+		{ID: 2, Email: "jdoe@example.org", FirstName: "John", LastName: "Doe"},
+	}, nil)
+
+	// When
+	err := detector.Run(context.Background())
+
+	// Then
+	require.NoError(t, err)
+	assert.Empty(t, store.suggestions)
+}
+
+func TestDuplicateDetector_Run_ComparesNamesThatSoundAlike(t *testing.T) {
+	// Given two accounts whose names are spelled differently
+	detector, mockRepo, store := setupTestDuplicateDetector(DuplicateOptions{MinConfidence: 0.3, ScanPageSize: 10})
+
+	mockRepo.On("List", mock.Anything, ports.UserFilter{}, 10, 0).Return([]*entities.User{
+		{ID: 1, Email: "john@example.com", FirstName: "John", LastName: "Doe"},
+		{ID: 2, Email: "jdoe@example.org", FirstName: "Jon", LastName: "Doe"},
+	}, nil)
+
+	// When
+	err := detector.Run(context.Background())
+
+	// Then they are still compared
+	require.NoError(t, err)
+	require.Len(t, store.suggestions, 1)
+	assert.Equal(t, []entities.DuplicateSignal{entities.DuplicateSignalName}, store.suggestions[0].Signals)
+}
+
+func TestDuplicateDetector_Run_SkipsOversizedGroups(t *testing.T) {
+	// Given three accounts sharing a switchboard number, above the group limit
+	detector, mockRepo, store := setupTestDuplicateDetector(DuplicateOptions{MinConfidence: 0.6, ScanPageSize: 10, MaxGroupSize: 2})
+
+	mockRepo.On("List", mock.Anything, ports.UserFilter{}, 10, 0).Return([]*entities.User{
+		{ID: 1, Email: "a@example.com", FirstName: "Ann", LastName: "Lee", Phone: "5550100000"},
+		{ID: 2, Email: "b@example.com", FirstName: "Bob", LastName: "Ray", Phone: "5550100000"},
+		{ID: 3, Email: "c@example.com", FirstName: "Cid", LastName: "Fox", Phone: "5550100000"},
+	}, nil)
+
+	// When
+	err := detector.Run(context.Background())
+
+	// Then
+	require.NoError(t, err)
+	assert.Empty(t, store.suggestions)
+}
+
+func TestDuplicateDetector_ListSuggestions_Paginates(t *testing.T) {
+	// Given
+	detector, _, store := setupTestDuplicateDetector(DuplicateOptions{MinConfidence: 0.6})
+	store.suggestions = []*entities.DuplicateSuggestion{
+		{UserID: 1, DuplicateUserID: 2, Confidence: 0.94},
+		{UserID: 3, DuplicateUserID: 4, Confidence: 0.7},
+		{UserID: 5, DuplicateUserID: 6, Confidence: 0.4},
+	}
+
+	// When
+	response, err := detector.ListSuggestions(context.Background(), 2, 1)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), response.Total)
+	require.Len(t, response.Suggestions, 1)
+	assert.Equal(t, uint(3), response.Suggestions[0].UserID)
+}
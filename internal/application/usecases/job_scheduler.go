@@ -0,0 +1,283 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	userErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+)
+
+// finishTimeout bounds recording the outcome of a run, which must happen even
+// when the run itself was cancelled
+const finishTimeout = 5 * time.Second
+
+// ScheduledJob is a job together with its schedule
+type ScheduledJob struct {
+	Job      ports.Job
+	Interval time.Duration
+	// Timeout bounds one run and how long its claim on the job lasts;
+	// it defaults to Interval
+	Timeout time.Duration
+}
+
+// JobUseCases defines the interface for operating background jobs
+type JobUseCases interface {
+	ListJobs(ctx context.Context) (*dto.JobListResponseDTO, error)
+	GetJob(ctx context.Context, name string) (*dto.JobResponseDTO, error)
+	TriggerJob(ctx context.Context, name, actor string) (*dto.JobResponseDTO, error)
+	PauseJob(ctx context.Context, name, actor string) (*dto.JobResponseDTO, error)
+	ResumeJob(ctx context.Context, name, actor string) (*dto.JobResponseDTO, error)
+}
+
+// JobScheduler runs registered jobs on their interval. Runs are claimed in the
+// state store, so with several instances each due run happens once.
+type JobScheduler struct {
+	jobs         []ScheduledJob
+	store        ports.JobStateStore
+	audit        ports.AuditLogger
+	pollInterval time.Duration
+	now          func() time.Time
+	logger       logger.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewJobScheduler creates a scheduler checking for due jobs every pollInterval
+func NewJobScheduler(store ports.JobStateStore, jobs []ScheduledJob, pollInterval time.Duration, audit ports.AuditLogger, log logger.Logger) *JobScheduler {
+	for i := range jobs {
+		if jobs[i].Timeout <= 0 {
+			jobs[i].Timeout = jobs[i].Interval
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &JobScheduler{
+		jobs:         jobs,
+		store:        store,
+		audit:        audit,
+		pollInterval: pollInterval,
+		now:          func() time.Time { return time.Now().UTC() },
+		logger:       log.With("component", "job_scheduler"),
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+}
+
+// Start runs due jobs every poll interval until Stop is called
+func (s *JobScheduler) Start() {
+	s.logger.Info("Job scheduler started", "jobs", len(s.jobs), "poll_interval", s.pollInterval)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			s.runDue()
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops scheduling and cancels running jobs, waiting for them to record
+// their outcome until ctx ends
+func (s *JobScheduler) Stop(ctx context.Context) error {
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("Job scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("job scheduler did not stop in time: %w", ctx.Err())
+	}
+}
+
+// runDue starts every unpaused job whose interval has elapsed
+func (s *JobScheduler) runDue() {
+	for _, job := range s.jobs {
+		state, err := s.store.Get(s.ctx, job.Job.Name())
+		if err != nil {
+			s.logger.Error("Failed to load job state", "job", job.Job.Name(), "error", err)
+			continue
+		}
+		if state.Paused || s.now().Before(state.DueAt(job.Interval)) {
+			continue
+		}
+
+		if _, err := s.launch(job, entities.JobTriggerSchedule); err != nil {
+			s.logger.Error("Failed to start job", "job", job.Job.Name(), "error", err)
+		}
+	}
+}
+
+// launch claims the job and runs it in the background, reporting whether
+// the claim was won
+func (s *JobScheduler) launch(job ScheduledJob, trigger entities.JobTrigger) (bool, error) {
+	startedAt := s.now()
+	claimed, err := s.store.Start(s.ctx, job.Job.Name(), trigger, startedAt, startedAt.Add(job.Timeout))
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(job, trigger, startedAt)
+	}()
+
+	return true, nil
+}
+
+func (s *JobScheduler) run(job ScheduledJob, trigger entities.JobTrigger, startedAt time.Time) {
+	name := job.Job.Name()
+	s.logger.Info("Job started", "job", name, "trigger", trigger)
+
+	ctx, cancel := context.WithTimeout(s.ctx, job.Timeout)
+	runErr := runJob(ctx, job.Job)
+	cancel()
+
+	duration := s.now().Sub(startedAt)
+	if runErr != nil {
+		s.logger.Error("Job failed", "job", name, "duration", duration, "error", runErr)
+	} else {
+		s.logger.Info("Job succeeded", "job", name, "duration", duration)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), finishTimeout)
+	defer cancel()
+	if err := s.store.Finish(ctx, name, s.now(), duration, runErr); err != nil {
+		s.logger.Error("Failed to record job outcome", "job", name, "error", err)
+	}
+}
+
+// runJob runs a job, turning a panic into an error so one broken job cannot
+// take the scheduler down
+func runJob(ctx context.Context, job ports.Job) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("job panicked: %v", recovered)
+		}
+	}()
+	return job.Run(ctx)
+}
+
+// ListJobs describes every registered job
+func (s *JobScheduler) ListJobs(ctx context.Context) (*dto.JobListResponseDTO, error) {
+	response := &dto.JobListResponseDTO{Jobs: make([]*dto.JobResponseDTO, 0, len(s.jobs))}
+	for _, job := range s.jobs {
+		state, err := s.store.Get(ctx, job.Job.Name())
+		if err != nil {
+			return nil, err
+		}
+		response.Jobs = append(response.Jobs, dto.JobToResponseDTO(state, job.Interval, s.now()))
+	}
+	return response, nil
+}
+
+// GetJob describes one job
+func (s *JobScheduler) GetJob(ctx context.Context, name string) (*dto.JobResponseDTO, error) {
+	job, err := s.find(name)
+	if err != nil {
+		return nil, err
+	}
+	return s.describe(ctx, job)
+}
+
+// TriggerJob runs a job now, outside its schedule and even while paused
+func (s *JobScheduler) TriggerJob(ctx context.Context, name, actor string) (*dto.JobResponseDTO, error) {
+	s.logger.Info("TriggerJob use case called", "job", name, "actor", actor)
+
+	job, err := s.find(name)
+	if err != nil {
+		return nil, err
+	}
+
+	claimed, err := s.launch(job, entities.JobTriggerManual)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, userErrors.ErrJobAlreadyRunning
+	}
+
+	s.recordAudit(ctx, "job.triggered", actor, name)
+	return s.describe(ctx, job)
+}
+
+// PauseJob stops scheduled runs of a job; a run in progress completes
+func (s *JobScheduler) PauseJob(ctx context.Context, name, actor string) (*dto.JobResponseDTO, error) {
+	return s.setPaused(ctx, name, actor, true)
+}
+
+// ResumeJob restores scheduled runs of a job
+func (s *JobScheduler) ResumeJob(ctx context.Context, name, actor string) (*dto.JobResponseDTO, error) {
+	return s.setPaused(ctx, name, actor, false)
+}
+
+func (s *JobScheduler) setPaused(ctx context.Context, name, actor string, paused bool) (*dto.JobResponseDTO, error) {
+	s.logger.Info("Job schedule change requested", "job", name, "paused", paused, "actor", actor)
+
+	job, err := s.find(name)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.store.SetPaused(ctx, name, paused)
+	if err != nil {
+		return nil, err
+	}
+
+	action := "job.resumed"
+	if paused {
+		action = "job.paused"
+	}
+	s.recordAudit(ctx, action, actor, name)
+
+	return dto.JobToResponseDTO(state, job.Interval, s.now()), nil
+}
+
+func (s *JobScheduler) find(name string) (ScheduledJob, error) {
+	for _, job := range s.jobs {
+		if job.Job.Name() == name {
+			return job, nil
+		}
+	}
+	return ScheduledJob{}, userErrors.ErrJobNotFound
+}
+
+func (s *JobScheduler) describe(ctx context.Context, job ScheduledJob) (*dto.JobResponseDTO, error) {
+	state, err := s.store.Get(ctx, job.Job.Name())
+	if err != nil {
+		return nil, err
+	}
+	return dto.JobToResponseDTO(state, job.Interval, s.now()), nil
+}
+
+func (s *JobScheduler) recordAudit(ctx context.Context, action, actor, name string) {
+	s.audit.Record(ctx, &entities.AuditEvent{
+		Action:       action,
+		ActorID:      actor,
+		ResourceType: "job",
+		ResourceID:   name,
+	})
+}
@@ -0,0 +1,223 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"user-service/internal/domain/entities"
+	userErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryJobStateStore implements the JobStateStore interface in memory
+type memoryJobStateStore struct {
+	mu     sync.Mutex
+	states map[string]*entities.JobState
+}
+
+func newMemoryJobStateStore() *memoryJobStateStore {
+	return &memoryJobStateStore{states: map[string]*entities.JobState{}}
+}
+
+func (m *memoryJobStateStore) state(name string) *entities.JobState {
+	if _, ok := m.states[name]; !ok {
+		m.states[name] = entities.NewJobState(name)
+	}
+	return m.states[name]
+}
+
+func (m *memoryJobStateStore) Get(ctx context.Context, name string) (*entities.JobState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state := *m.state(name)
+	return &state, nil
+}
+
+func (m *memoryJobStateStore) SetPaused(ctx context.Context, name string, paused bool) (*entities.JobState, error) {
+	m.mu.Lock()
+	m.state(name).Paused = paused
+	m.mu.Unlock()
+	return m.Get(ctx, name)
+}
+
+func (m *memoryJobStateStore) Start(ctx context.Context, name string, trigger entities.JobTrigger, startedAt, leaseUntil time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state := m.state(name)
+	if startedAt.Before(state.LeaseUntil) {
+		return false, nil
+	}
+	state.LastStatus, state.LastTrigger = entities.JobStatusRunning, trigger
+	state.LastStartedAt, state.LeaseUntil, state.LastError = startedAt, leaseUntil, ""
+	return true, nil
+}
+
+func (m *memoryJobStateStore) Finish(ctx context.Context, name string, finishedAt time.Time, duration time.Duration, runErr error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state := m.state(name)
+	state.LastStatus = entities.JobStatusSucceeded
+	if runErr != nil {
+		state.LastStatus, state.LastError = entities.JobStatusFailed, runErr.Error()
+	}
+	state.LastFinishedAt, state.LastDuration, state.LeaseUntil = finishedAt, duration, time.Time{}
+	return nil
+}
+
+// funcJob adapts a function to the Job interface
+type funcJob struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+func (j funcJob) Name() string                  { return j.name }
+func (j funcJob) Run(ctx context.Context) error { return j.run(ctx) }
+
+func setupTestScheduler(jobs ...ScheduledJob) (*JobScheduler, *memoryJobStateStore, *MockAuditLogger) {
+	store := newMemoryJobStateStore()
+	mockAudit := new(MockAuditLogger)
+	mockAudit.On("Record", mock.Anything, mock.Anything).Return()
+	return NewJobScheduler(store, jobs, time.Hour, mockAudit, logger.New("test")), store, mockAudit
+}
+
+func stopScheduler(t *testing.T, scheduler *JobScheduler) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, scheduler.Stop(ctx))
+}
+
+func TestJobScheduler_TriggerRecordsOutcome(t *testing.T) {
+	// Given
+	scheduler, _, mockAudit := setupTestScheduler(ScheduledJob{
+		Job:      funcJob{name: "cleanup", run: func(ctx context.Context) error { return errors.New("disk full") }},
+		Interval: time.Hour,
+	})
+	ctx := context.Background()
+
+	// When
+	triggered, err := scheduler.TriggerJob(ctx, "cleanup", "ops")
+	stopScheduler(t, scheduler)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, entities.JobTriggerManual, triggered.LastTrigger)
+
+	job, err := scheduler.GetJob(ctx, "cleanup")
+	require.NoError(t, err)
+	assert.Equal(t, entities.JobStatusFailed, job.LastStatus)
+	assert.Equal(t, "disk full", job.LastError)
+	assert.False(t, job.Running)
+	assert.False(t, job.LastFinishedAt.IsZero())
+	mockAudit.AssertCalled(t, "Record", ctx, mock.MatchedBy(func(event *entities.AuditEvent) bool {
+		return event.Action == "job.triggered" && event.ResourceID == "cleanup" && event.ActorID == "ops"
+	}))
+}
+
+func TestJobScheduler_TriggerRejectsRunningJob(t *testing.T) {
+	// Given
+	release := make(chan struct{})
+	scheduler, _, _ := setupTestScheduler(ScheduledJob{
+		Job:      funcJob{name: "slow", run: func(ctx context.Context) error { <-release; return nil }},
+		Interval: time.Hour,
+	})
+	ctx := context.Background()
+
+	// When
+	_, firstErr := scheduler.TriggerJob(ctx, "slow", "ops")
+	_, secondErr := scheduler.TriggerJob(ctx, "slow", "ops")
+	close(release)
+	stopScheduler(t, scheduler)
+
+	// Then
+	require.NoError(t, firstErr)
+	assert.ErrorIs(t, secondErr, userErrors.ErrJobAlreadyRunning)
+}
+
+func TestJobScheduler_UnknownJob(t *testing.T) {
+	scheduler, _, _ := setupTestScheduler()
+
+	_, err := scheduler.TriggerJob(context.Background(), "missing", "ops")
+
+	assert.ErrorIs(t, err, userErrors.ErrJobNotFound)
+}
+
+func TestJobScheduler_RunDueSkipsPausedAndRecentJobs(t *testing.T) {
+	// Given
+	var mu sync.Mutex
+	ran := map[string]int{}
+	record := func(name string) funcJob {
+		return funcJob{name: name, run: func(ctx context.Context) error {
+			mu.Lock()
+			ran[name]++
+			mu.Unlock()
+			return nil
+		}}
+	}
+	scheduler, store, _ := setupTestScheduler(
+		ScheduledJob{Job: record("due"), Interval: time.Minute},
+		ScheduledJob{Job: record("paused"), Interval: time.Minute},
+		ScheduledJob{Job: record("recent"), Interval: time.Hour},
+	)
+	ctx := context.Background()
+	_, err := scheduler.PauseJob(ctx, "paused", "ops")
+	require.NoError(t, err)
+	store.state("recent").LastStartedAt = time.Now().Add(-time.Minute)
+
+	// When
+	scheduler.runDue()
+	stopScheduler(t, scheduler)
+
+	// Then
+	assert.Equal(t, map[string]int{"due": 1}, ran)
+}
+
+func TestJobScheduler_PanickingJobFails(t *testing.T) {
+	// Given
+	scheduler, _, _ := setupTestScheduler(ScheduledJob{
+		Job:      funcJob{name: "broken", run: func(ctx context.Context) error { panic("nil map") }},
+		Interval: time.Hour,
+	})
+	ctx := context.Background()
+
+	// When
+	_, err := scheduler.TriggerJob(ctx, "broken", "ops")
+	stopScheduler(t, scheduler)
+
+	// Then
+	require.NoError(t, err)
+	job, err := scheduler.GetJob(ctx, "broken")
+	require.NoError(t, err)
+	assert.Equal(t, entities.JobStatusFailed, job.LastStatus)
+	assert.Contains(t, job.LastError, "nil map")
+}
+
+func TestJobScheduler_ListJobs(t *testing.T) {
+	// Given
+	scheduler, _, _ := setupTestScheduler(
+		ScheduledJob{Job: funcJob{name: "a"}, Interval: time.Minute},
+		ScheduledJob{Job: funcJob{name: "b"}, Interval: time.Hour},
+	)
+	ctx := context.Background()
+	_, err := scheduler.PauseJob(ctx, "b", "ops")
+	require.NoError(t, err)
+
+	// When
+	response, err := scheduler.ListJobs(ctx)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, response.Jobs, 2)
+	assert.Equal(t, "a", response.Jobs[0].Name)
+	assert.Equal(t, entities.JobStatusNeverRun, response.Jobs[0].LastStatus)
+	assert.False(t, response.Jobs[0].NextRunAt.IsZero())
+	assert.Equal(t, "1h0m0s", response.Jobs[1].Interval)
+	assert.True(t, response.Jobs[1].Paused)
+	assert.True(t, response.Jobs[1].NextRunAt.IsZero())
+}
@@ -0,0 +1,128 @@
+package usecases
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	"user-service/pkg/logger"
+	"user-service/pkg/pagination"
+)
+
+// LegalHoldUseCases defines the interface for legal holds, which keep users
+// from being erased while their data must be preserved
+type LegalHoldUseCases interface {
+	PlaceLegalHold(ctx context.Context, userID uint, actor string, request *dto.PlaceLegalHoldRequestDTO) (*dto.LegalHoldResponseDTO, error)
+	ReleaseLegalHold(ctx context.Context, userID uint, actor string) error
+	ListLegalHolds(ctx context.Context, page, pageSize int) (*dto.LegalHoldListResponseDTO, error)
+}
+
+// legalHoldUseCasesImpl implements LegalHoldUseCases interface
+type legalHoldUseCasesImpl struct {
+	userRepo ports.UserRepository
+	audit    ports.AuditLogger
+	now      func() time.Time
+	logger   logger.Logger
+}
+
+// NewLegalHoldUseCases creates a new instance of legal hold use cases
+func NewLegalHoldUseCases(userRepo ports.UserRepository, audit ports.AuditLogger, log logger.Logger) LegalHoldUseCases {
+	return &legalHoldUseCasesImpl{
+		userRepo: userRepo,
+		audit:    audit,
+		now:      time.Now,
+		logger:   log.With("component", "legal_hold_usecases"),
+	}
+}
+
+// PlaceLegalHold places a user under a legal hold, replacing any earlier hold
+func (uc *legalHoldUseCasesImpl) PlaceLegalHold(ctx context.Context, userID uint, actor string, request *dto.PlaceLegalHoldRequestDTO) (*dto.LegalHoldResponseDTO, error) {
+	uc.logger.Info("PlaceLegalHold use case called", "user_id", userID, "actor", actor)
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	hold, err := request.ToEntity(actor, now)
+	if err != nil {
+		return nil, err
+	}
+
+	user.LegalHold = hold
+	if err := uc.userRepo.UpdateLegalHold(ctx, user); err != nil {
+		return nil, err
+	}
+
+	uc.recordAudit(ctx, "user.legal_hold_placed", actor, userID, map[string]interface{}{
+		"reason":     hold.Reason,
+		"expires_at": hold.ExpiresAt,
+	})
+
+	uc.logger.Info("PlaceLegalHold success", "user_id", userID)
+	return dto.LegalHoldToResponseDTO(user, now), nil
+}
+
+// ReleaseLegalHold lifts a user's legal hold; releasing a user without one
+// does nothing
+func (uc *legalHoldUseCasesImpl) ReleaseLegalHold(ctx context.Context, userID uint, actor string) error {
+	uc.logger.Info("ReleaseLegalHold use case called", "user_id", userID, "actor", actor)
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.LegalHold == nil {
+		return nil
+	}
+
+	released := user.LegalHold
+	user.LegalHold = nil
+	if err := uc.userRepo.UpdateLegalHold(ctx, user); err != nil {
+		return err
+	}
+
+	uc.recordAudit(ctx, "user.legal_hold_released", actor, userID, map[string]interface{}{
+		"reason": released.Reason,
+	})
+
+	uc.logger.Info("ReleaseLegalHold success", "user_id", userID)
+	return nil
+}
+
+// ListLegalHolds reports the held accounts of the caller's region, including
+// lapsed holds not yet released
+func (uc *legalHoldUseCasesImpl) ListLegalHolds(ctx context.Context, page, pageSize int) (*dto.LegalHoldListResponseDTO, error) {
+	uc.logger.Info("ListLegalHolds use case called", "page", page, "page_size", pageSize)
+
+	page, pageSize = pagination.DefaultLimits.Page(page, pageSize)
+
+	users, err := uc.userRepo.List(ctx, ports.UserFilter{LegalHold: true}, pageSize, pagination.Offset(page, pageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	holds := make([]*dto.LegalHoldResponseDTO, 0, len(users))
+	for _, user := range users {
+		if user.LegalHold != nil {
+			holds = append(holds, dto.LegalHoldToResponseDTO(user, now))
+		}
+	}
+
+	return &dto.LegalHoldListResponseDTO{Holds: holds, Page: page, PageSize: pageSize}, nil
+}
+
+func (uc *legalHoldUseCasesImpl) recordAudit(ctx context.Context, action, actor string, userID uint, metadata map[string]interface{}) {
+	uc.audit.Record(ctx, &entities.AuditEvent{
+		Action:       action,
+		ActorID:      actor,
+		ResourceType: "user",
+		ResourceID:   strconv.FormatUint(uint64(userID), 10),
+		Metadata:     metadata,
+	})
+}
@@ -0,0 +1,106 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var legalHoldNow = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func setupTestLegalHoldUseCases() (LegalHoldUseCases, *MockUserRepository, *MockAuditLogger) {
+	mockRepo := new(MockUserRepository)
+	mockAudit := new(MockAuditLogger)
+	useCases := NewLegalHoldUseCases(mockRepo, mockAudit, logger.New("test"))
+	useCases.(*legalHoldUseCasesImpl).now = func() time.Time { return legalHoldNow }
+	return useCases, mockRepo, mockAudit
+}
+
+func TestLegalHoldUseCases_PlaceLegalHold(t *testing.T) {
+	// Given
+	useCases, mockRepo, mockAudit := setupTestLegalHoldUseCases()
+	ctx := context.Background()
+	expiresAt := dto.NewTimestamp(legalHoldNow.Add(90 * 24 * time.Hour))
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, Email: "jane@example.com"}, nil)
+	mockRepo.On("UpdateLegalHold", ctx, mock.MatchedBy(func(user *entities.User) bool {
+		return user.LegalHold != nil && user.LegalHold.Reason == "Case 2024-17" && user.LegalHold.PlacedBy == "counsel"
+	})).Return(nil)
+	mockAudit.On("Record", ctx, mock.MatchedBy(func(event *entities.AuditEvent) bool {
+		return event.Action == "user.legal_hold_placed" && event.ActorID == "counsel" && event.ResourceID == "1"
+	})).Return()
+
+	// When
+	result, err := useCases.PlaceLegalHold(ctx, 1, "counsel", &dto.PlaceLegalHoldRequestDTO{Reason: " Case 2024-17 ", ExpiresAt: &expiresAt})
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, result.Active)
+	assert.Equal(t, legalHoldNow, result.PlacedAt.Time)
+	assert.Equal(t, &expiresAt, result.ExpiresAt)
+	mockRepo.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestLegalHoldUseCases_PlaceLegalHold_PastExpiry(t *testing.T) {
+	useCases, mockRepo, _ := setupTestLegalHoldUseCases()
+	ctx := context.Background()
+	expiresAt := dto.NewTimestamp(legalHoldNow.Add(-time.Minute))
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1}, nil)
+
+	_, err := useCases.PlaceLegalHold(ctx, 1, "counsel", &dto.PlaceLegalHoldRequestDTO{Reason: "Case 2024-17", ExpiresAt: &expiresAt})
+
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidLegalHoldExpiry)
+	mockRepo.AssertNotCalled(t, "UpdateLegalHold", mock.Anything, mock.Anything)
+}
+
+func TestLegalHoldUseCases_ReleaseLegalHold(t *testing.T) {
+	// Given a held user
+	useCases, mockRepo, mockAudit := setupTestLegalHoldUseCases()
+	ctx := context.Background()
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, LegalHold: &entities.LegalHold{Reason: "Case 2024-17"}}, nil)
+	mockRepo.On("UpdateLegalHold", ctx, mock.MatchedBy(func(user *entities.User) bool {
+		return user.ID == 1 && user.LegalHold == nil
+	})).Return(nil)
+	mockAudit.On("Record", ctx, mock.MatchedBy(func(event *entities.AuditEvent) bool {
+		return event.Action == "user.legal_hold_released"
+	})).Return()
+
+	// When
+	err := useCases.ReleaseLegalHold(ctx, 1, "counsel")
+
+	// Then
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestLegalHoldUseCases_ListLegalHolds(t *testing.T) {
+	// Given one active and one lapsed hold
+	useCases, mockRepo, _ := setupTestLegalHoldUseCases()
+	ctx := context.Background()
+	lapsed := legalHoldNow.Add(-time.Hour)
+	mockRepo.On("List", ctx, ports.UserFilter{LegalHold: true}, 20, 20).Return([]*entities.User{
+		{ID: 1, LegalHold: &entities.LegalHold{Reason: "Case 2024-17"}},
+		{ID: 2, LegalHold: &entities.LegalHold{Reason: "Audit", ExpiresAt: &lapsed}},
+	}, nil)
+
+	// When the second page is requested
+	result, err := useCases.ListLegalHolds(ctx, 2, 20)
+
+	// Then both are reported, with whether they still apply
+	require.NoError(t, err)
+	require.Len(t, result.Holds, 2)
+	assert.True(t, result.Holds[0].Active)
+	assert.False(t, result.Holds[1].Active)
+}
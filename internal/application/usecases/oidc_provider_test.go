@@ -0,0 +1,455 @@
+package usecases
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"user-service/internal/application/dto"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fakeOIDCClientRepository keeps clients in memory
+type fakeOIDCClientRepository struct {
+	clients map[string]*entities.OIDCClient
+}
+
+func (f *fakeOIDCClientRepository) Create(ctx context.Context, client *entities.OIDCClient) (*entities.OIDCClient, error) {
+	client.ID = uint(len(f.clients) + 1)
+	f.clients[client.ClientID] = client
+	return client, nil
+}
+
+func (f *fakeOIDCClientRepository) GetByClientID(ctx context.Context, clientID string) (*entities.OIDCClient, error) {
+	client, ok := f.clients[clientID]
+	if !ok {
+		return nil, domainErrors.ErrOIDCClientNotFound
+	}
+	return client, nil
+}
+
+func (f *fakeOIDCClientRepository) UpdateClaimMappings(ctx context.Context, clientID string, mappings map[string]string) (*entities.OIDCClient, error) {
+	client, ok := f.clients[clientID]
+	if !ok {
+		return nil, domainErrors.ErrOIDCClientNotFound
+	}
+	client.ClaimMappings = mappings
+	return client, nil
+}
+
+func (f *fakeOIDCClientRepository) List(ctx context.Context) ([]*entities.OIDCClient, error) {
+	clients := make([]*entities.OIDCClient, 0, len(f.clients))
+	for _, client := range f.clients {
+		clients = append(clients, client)
+	}
+	return clients, nil
+}
+
+// fakeAuthorizationCodeStore keeps codes in memory; Consume removes them
+type fakeAuthorizationCodeStore struct {
+	codes map[string]*entities.AuthorizationCode
+}
+
+func (f *fakeAuthorizationCodeStore) Save(ctx context.Context, code *entities.AuthorizationCode) error {
+	f.codes[code.CodeHash] = code
+	return nil
+}
+
+func (f *fakeAuthorizationCodeStore) Consume(ctx context.Context, codeHash string) (*entities.AuthorizationCode, error) {
+	code, ok := f.codes[codeHash]
+	if !ok {
+		return nil, domainErrors.ErrInvalidAuthorizationCode
+	}
+	delete(f.codes, codeHash)
+	return code, nil
+}
+
+// fakeTokenSigner "signs" tokens as type.base64(claims), which is enough to
+// check what the provider puts in them
+type fakeTokenSigner struct{}
+
+func (fakeTokenSigner) Sign(tokenType string, claims map[string]interface{}) (string, error) {
+	payload, err := json.Marshal(claims)
+	return tokenType + "." + base64.RawURLEncoding.EncodeToString(payload), err
+}
+
+func (fakeTokenSigner) Verify(tokenType, token string) (map[string]interface{}, error) {
+	payload, ok := strings.CutPrefix(token, tokenType+".")
+	if !ok {
+		return nil, errors.New("wrong token type")
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, err
+	}
+	var claims map[string]interface{}
+	return claims, json.Unmarshal(data, &claims)
+}
+
+func (fakeTokenSigner) KeySet() map[string]interface{} {
+	return map[string]interface{}{"keys": []interface{}{}}
+}
+func (fakeTokenSigner) Algorithm() string { return "none" }
+
+const (
+	testIssuer      = "https://id.example.com"
+	testRedirectURI = "https://app.example.com/callback"
+	testVerifier    = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+)
+
+func setupTestOIDCProvider(t *testing.T) (OIDCProvider, *MockUserRepository, *fakeAuthorizationCodeStore, *MockAuditLogger, *dto.OIDCClientResponseDTO) {
+	t.Helper()
+
+	mockRepo := new(MockUserRepository)
+	mockAudit := new(MockAuditLogger)
+	mockAudit.On("Record", mock.Anything, mock.Anything).Return()
+	mockPublisher := new(MockEventPublisher)
+	mockPublisher.On("Publish", mock.Anything, mock.Anything).Return(nil)
+	codes := &fakeAuthorizationCodeStore{codes: make(map[string]*entities.AuthorizationCode)}
+	limits := map[entities.SensitiveAction]entities.ActionLimit{
+		entities.SensitiveActionSignIn: {Max: 3, Window: time.Hour},
+	}
+	limiter := NewSensitiveActionLimiter(mockRepo, newFakeActionCounterStore(), limits, mockAudit, logger.New("test"))
+
+	provider := NewOIDCProvider(
+		mockRepo,
+		&fakeOIDCClientRepository{clients: make(map[string]*entities.OIDCClient)},
+		codes,
+		fakeTokenSigner{},
+		mockPublisher,
+		mockAudit,
+		limiter,
+		OIDCOptions{Issuer: testIssuer + "/", CodeTTL: time.Minute, AccessTokenTTL: time.Hour, IDTokenTTL: time.Hour},
+		logger.New("test"),
+	)
+
+	client, err := provider.RegisterClient(context.Background(), &dto.RegisterOIDCClientRequestDTO{
+		Name:         "Web app",
+		RedirectURIs: []string{testRedirectURI},
+		Scopes:       []string{"openid", "email", "profile"},
+		Confidential: true,
+	}, "admin")
+	require.NoError(t, err)
+
+	return provider, mockRepo, codes, mockAudit, client
+}
+
+func authorizeRequest(clientID string) *dto.AuthorizeRequestDTO {
+	sum := sha256.Sum256([]byte(testVerifier))
+	return &dto.AuthorizeRequestDTO{
+		ResponseType:        "code",
+		ClientID:            clientID,
+		RedirectURI:         testRedirectURI,
+		Scope:               "openid email",
+		State:               "xyz",
+		Nonce:               "n-0S6_WzA2Mj",
+		CodeChallenge:       base64.RawURLEncoding.EncodeToString(sum[:]),
+		CodeChallengeMethod: "S256",
+	}
+}
+
+func activeUser(t *testing.T) *entities.User {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	require.NoError(t, err)
+	return &entities.User{ID: 7, Email: "jane@example.com", Password: string(hash), FirstName: "Jane", LastName: "Doe", Phone: "+15551234567", Status: entities.UserStatusActive}
+}
+
+func TestOIDCProvider_RegisterClient_ReturnsSecretOnce(t *testing.T) {
+	// Given a registered confidential client
+	provider, _, _, _, client := setupTestOIDCProvider(t)
+
+	// Then its secret is only part of the registration response
+	assert.NotEmpty(t, client.ClientID)
+	assert.NotEmpty(t, client.ClientSecret)
+	assert.True(t, client.Confidential)
+
+	listed, err := provider.ListClients(context.Background())
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Empty(t, listed[0].ClientSecret)
+}
+
+func TestOIDCProvider_AuthorizationCodeFlow(t *testing.T) {
+	// Given
+	provider, mockRepo, _, _, client := setupTestOIDCProvider(t)
+	ctx := context.Background()
+	user := activeUser(t)
+	mockRepo.On("GetByEmail", ctx, "jane@example.com").Return(user, nil)
+	mockRepo.On("GetByID", ctx, uint(7)).Return(user, nil)
+
+	// When the user signs in and the client exchanges the code
+	code, err := provider.Authorize(ctx, authorizeRequest(client.ClientID), " Jane@Example.com ", "correct horse", []string{"email"})
+	require.NoError(t, err)
+
+	tokens, err := provider.Exchange(ctx, &dto.TokenRequestDTO{
+		GrantType:    "authorization_code",
+		Code:         code,
+		RedirectURI:  testRedirectURI,
+		ClientID:     client.ClientID,
+		ClientSecret: client.ClientSecret,
+		CodeVerifier: testVerifier,
+	})
+	require.NoError(t, err)
+
+	// Then the ID token identifies the user to the client
+	assert.Equal(t, "Bearer", tokens.TokenType)
+	assert.Equal(t, "email openid", tokens.Scope)
+	idClaims, err := fakeTokenSigner{}.Verify("JWT", tokens.IDToken)
+	require.NoError(t, err)
+	assert.Equal(t, testIssuer, idClaims["iss"])
+	assert.Equal(t, "7", idClaims["sub"])
+	assert.Equal(t, client.ClientID, idClaims["aud"])
+	assert.Equal(t, "n-0S6_WzA2Mj", idClaims["nonce"])
+
+	// And the access token only releases the granted claims
+	claims, err := provider.UserInfo(ctx, tokens.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"sub": "7", "email": "jane@example.com"}, claims)
+}
+
+func TestOIDCProvider_Exchange_RejectsInvalidCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(request *dto.TokenRequestDTO)
+		expected error
+	}{
+		{name: "wrong verifier", modify: func(r *dto.TokenRequestDTO) { r.CodeVerifier = strings.Repeat("a", 43) }, expected: domainErrors.ErrInvalidAuthorizationCode},
+		{name: "other redirect URI", modify: func(r *dto.TokenRequestDTO) { r.RedirectURI = "https://app.example.com/other" }, expected: domainErrors.ErrInvalidAuthorizationCode},
+		{name: "wrong secret", modify: func(r *dto.TokenRequestDTO) { r.ClientSecret = "guess" }, expected: domainErrors.ErrInvalidClientCredentials},
+		{name: "unknown client", modify: func(r *dto.TokenRequestDTO) { r.ClientID = "unknown" }, expected: domainErrors.ErrInvalidClientCredentials},
+		{name: "other grant type", modify: func(r *dto.TokenRequestDTO) { r.GrantType = "password" }, expected: domainErrors.ErrUnsupportedGrantType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given an issued code
+			provider, mockRepo, _, _, client := setupTestOIDCProvider(t)
+			ctx := context.Background()
+			user := activeUser(t)
+			mockRepo.On("GetByEmail", ctx, "jane@example.com").Return(user, nil)
+			mockRepo.On("GetByID", ctx, uint(7)).Return(user, nil)
+			code, err := provider.Authorize(ctx, authorizeRequest(client.ClientID), "jane@example.com", "correct horse", []string{"email"})
+			require.NoError(t, err)
+
+			request := &dto.TokenRequestDTO{
+				GrantType:    "authorization_code",
+				Code:         code,
+				RedirectURI:  testRedirectURI,
+				ClientID:     client.ClientID,
+				ClientSecret: client.ClientSecret,
+				CodeVerifier: testVerifier,
+			}
+			tt.modify(request)
+
+			// When
+			_, err = provider.Exchange(ctx, request)
+
+			// Then
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestOIDCProvider_Exchange_CodesAreSingleUse(t *testing.T) {
+	// Given a code exchanged once
+	provider, mockRepo, codes, _, client := setupTestOIDCProvider(t)
+	ctx := context.Background()
+	user := activeUser(t)
+	mockRepo.On("GetByEmail", ctx, "jane@example.com").Return(user, nil)
+	mockRepo.On("GetByID", ctx, uint(7)).Return(user, nil)
+	code, err := provider.Authorize(ctx, authorizeRequest(client.ClientID), "jane@example.com", "correct horse", []string{"email"})
+	require.NoError(t, err)
+	request := &dto.TokenRequestDTO{
+		GrantType:    "authorization_code",
+		Code:         code,
+		RedirectURI:  testRedirectURI,
+		ClientID:     client.ClientID,
+		ClientSecret: client.ClientSecret,
+		CodeVerifier: testVerifier,
+	}
+	_, err = provider.Exchange(ctx, request)
+	require.NoError(t, err)
+
+	// When it is presented again
+	_, err = provider.Exchange(ctx, request)
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidAuthorizationCode)
+	assert.Empty(t, codes.codes)
+}
+
+func TestOIDCProvider_Authorize_RejectsBadCredentials(t *testing.T) {
+	provider, mockRepo, codes, _, client := setupTestOIDCProvider(t)
+	ctx := context.Background()
+	suspended := activeUser(t)
+	suspended.Status = entities.UserStatusSuspended
+	mockRepo.On("GetByEmail", ctx, "jane@example.com").Return(activeUser(t), nil)
+	mockRepo.On("GetByEmail", ctx, "suspended@example.com").Return(suspended, nil)
+	mockRepo.On("GetByEmail", ctx, "nobody@example.com").Return(nil, domainErrors.ErrUserNotFound)
+
+	_, err := provider.Authorize(ctx, authorizeRequest(client.ClientID), "jane@example.com", "wrong", nil)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidCredentials)
+
+	_, err = provider.Authorize(ctx, authorizeRequest(client.ClientID), "suspended@example.com", "correct horse", nil)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidCredentials)
+
+	_, err = provider.Authorize(ctx, authorizeRequest(client.ClientID), "nobody@example.com", "correct horse", nil)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidCredentials)
+
+	assert.Empty(t, codes.codes)
+}
+
+func TestOIDCProvider_Authorize_LimitsAndAuditsFailedSignIns(t *testing.T) {
+	// Given an account whose password is being guessed
+	provider, mockRepo, codes, mockAudit, client := setupTestOIDCProvider(t)
+	ctx := context.Background()
+	mockRepo.On("GetByEmail", ctx, "jane@example.com").Return(activeUser(t), nil)
+
+	// When the guesses exceed the sign-in limit
+	for range 3 {
+		_, err := provider.Authorize(ctx, authorizeRequest(client.ClientID), "jane@example.com", "wrong", nil)
+		require.ErrorIs(t, err, domainErrors.ErrInvalidCredentials)
+	}
+	_, err := provider.Authorize(ctx, authorizeRequest(client.ClientID), "jane@example.com", "correct horse", nil)
+
+	// Then even the right password is turned away, and every failure is audited
+	var limited *domainErrors.ActionRateLimitedError
+	require.ErrorAs(t, err, &limited)
+	assert.Equal(t, string(entities.SensitiveActionSignIn), limited.Action)
+	assert.Empty(t, codes.codes)
+	mockAudit.AssertCalled(t, "Record", ctx, mock.MatchedBy(func(event *entities.AuditEvent) bool {
+		return event.Action == "user.sign_in_failed" && event.ResourceID == "7" && event.Metadata["reason"] == "invalid_password"
+	}))
+	mockAudit.AssertCalled(t, "Record", ctx, mock.MatchedBy(func(event *entities.AuditEvent) bool {
+		return event.Action == "user.sign_in_failed" && event.Metadata["reason"] == "rate_limited"
+	}))
+}
+
+func TestOIDCProvider_ValidateAuthorization(t *testing.T) {
+	provider, _, _, _, client := setupTestOIDCProvider(t)
+	ctx := context.Background()
+
+	request := authorizeRequest(client.ClientID)
+	request.RedirectURI = "https://evil.example.com/callback"
+	_, err := provider.ValidateAuthorization(ctx, request)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidRedirectURI)
+
+	request = authorizeRequest(client.ClientID)
+	request.CodeChallengeMethod = "plain"
+	_, err = provider.ValidateAuthorization(ctx, request)
+	assert.ErrorIs(t, err, domainErrors.ErrPKCERequired)
+
+	request = authorizeRequest(client.ClientID)
+	request.Scope = "openid phone"
+	_, err = provider.ValidateAuthorization(ctx, request)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidScope, "phone was not registered for the client")
+}
+
+func TestOIDCProvider_UserInfo_RejectsIDTokens(t *testing.T) {
+	provider, _, _, _, _ := setupTestOIDCProvider(t)
+	idToken, err := fakeTokenSigner{}.Sign("JWT", map[string]interface{}{"iss": testIssuer, "sub": "7", "exp": time.Now().Add(time.Hour).Unix()})
+	require.NoError(t, err)
+
+	_, err = provider.UserInfo(context.Background(), idToken)
+
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidAccessToken)
+}
+
+// signIn runs the code flow for the test user, returning the tokens issued
+func signIn(t *testing.T, provider OIDCProvider, client *dto.OIDCClientResponseDTO, scope string, consented []string) *dto.TokenResponseDTO {
+	t.Helper()
+	request := authorizeRequest(client.ClientID)
+	request.Scope = scope
+
+	code, err := provider.Authorize(context.Background(), request, "jane@example.com", "correct horse", consented)
+	require.NoError(t, err)
+
+	tokens, err := provider.Exchange(context.Background(), &dto.TokenRequestDTO{
+		GrantType:    "authorization_code",
+		Code:         code,
+		RedirectURI:  testRedirectURI,
+		ClientID:     client.ClientID,
+		ClientSecret: client.ClientSecret,
+		CodeVerifier: testVerifier,
+	})
+	require.NoError(t, err)
+	return tokens
+}
+
+func TestOIDCProvider_Authorize_GrantsOnlyConsentedScopes(t *testing.T) {
+	// Given a user who shares their profile but not their email
+	provider, mockRepo, _, _, client := setupTestOIDCProvider(t)
+	ctx := context.Background()
+	user := activeUser(t)
+	mockRepo.On("GetByEmail", ctx, "jane@example.com").Return(user, nil)
+	mockRepo.On("GetByID", ctx, uint(7)).Return(user, nil)
+
+	// When
+	tokens := signIn(t, provider, client, "openid profile email", []string{"profile", "phone"})
+
+	// Then only the consented scope is granted, and its claims released
+	assert.Equal(t, "openid profile", tokens.Scope)
+	claims, err := provider.UserInfo(ctx, tokens.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "Jane Doe", claims["name"])
+	assert.NotContains(t, claims, "email")
+	assert.NotContains(t, claims, "phone_number", "scopes not requested cannot be consented to")
+}
+
+func TestOIDCProvider_UserInfo_MapsAndAuditsClaims(t *testing.T) {
+	// Given a client that expects the email as "mail"
+	provider, mockRepo, _, mockAudit, client := setupTestOIDCProvider(t)
+	ctx := context.Background()
+	user := activeUser(t)
+	mockRepo.On("GetByEmail", ctx, "jane@example.com").Return(user, nil)
+	mockRepo.On("GetByID", ctx, uint(7)).Return(user, nil)
+	updated, err := provider.UpdateClaimMappings(ctx, client.ClientID, &dto.UpdateClaimMappingsRequestDTO{
+		ClaimMappings: map[string]string{"email": "mail"},
+	}, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"email": "mail"}, updated.ClaimMappings)
+	tokens := signIn(t, provider, client, "openid email", []string{"email"})
+
+	// When
+	claims, err := provider.UserInfo(ctx, tokens.AccessToken)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"sub": "7", "mail": "jane@example.com"}, claims)
+
+	var issued *entities.AuditEvent
+	for _, call := range mockAudit.Calls {
+		if event := call.Arguments.Get(1).(*entities.AuditEvent); event.Action == "oidc.claims_issued" {
+			issued = event
+		}
+	}
+	require.NotNil(t, issued)
+	assert.Equal(t, client.ClientID, issued.ActorID)
+	assert.Equal(t, "7", issued.ResourceID)
+	assert.Equal(t, []string{"mail", "sub"}, issued.Metadata["claims"], "claim names are audited, never values")
+}
+
+func TestOIDCProvider_UpdateClaimMappings_RejectsInvalidMappings(t *testing.T) {
+	provider, _, _, _, client := setupTestOIDCProvider(t)
+	ctx := context.Background()
+
+	_, err := provider.UpdateClaimMappings(ctx, client.ClientID, &dto.UpdateClaimMappingsRequestDTO{
+		ClaimMappings: map[string]string{"email": "name"},
+	}, "admin")
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidClaimMapping)
+
+	_, err = provider.UpdateClaimMappings(ctx, "unknown", &dto.UpdateClaimMappingsRequestDTO{}, "admin")
+	assert.ErrorIs(t, err, domainErrors.ErrOIDCClientNotFound)
+}
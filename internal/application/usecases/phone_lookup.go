@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"context"
+
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+)
+
+// Lengths of the normalized phone numbers a lookup accepts, from the
+// shortest national numbers to the E.164 maximum
+const (
+	minLookupPhoneDigits = 7
+	maxLookupPhoneDigits = 15
+)
+
+// PhoneLookupUseCases defines the interface for finding the accounts that
+// share a phone number, used by fraud investigations
+type PhoneLookupUseCases interface {
+	LookUpPhone(ctx context.Context, actor string, request *dto.PhoneLookupRequestDTO) (*dto.PhoneLookupResponseDTO, error)
+}
+
+// phoneLookupUseCasesImpl implements PhoneLookupUseCases interface
+type phoneLookupUseCasesImpl struct {
+	userRepo ports.UserRepository
+	audit    ports.AuditLogger
+	logger   logger.Logger
+}
+
+// NewPhoneLookupUseCases creates a new instance of phone lookup use cases
+func NewPhoneLookupUseCases(userRepo ports.UserRepository, audit ports.AuditLogger, log logger.Logger) PhoneLookupUseCases {
+	return &phoneLookupUseCasesImpl{
+		userRepo: userRepo,
+		audit:    audit,
+		logger:   log.With("component", "phone_lookup_usecases"),
+	}
+}
+
+// LookUpPhone returns the users whose phone number has the same digits as
+// the requested one. Every lookup is audited with the number of matches; the
+// number itself is left out of logs and audit events.
+func (uc *phoneLookupUseCasesImpl) LookUpPhone(ctx context.Context, actor string, request *dto.PhoneLookupRequestDTO) (*dto.PhoneLookupResponseDTO, error) {
+	uc.logger.Info("LookUpPhone use case called", "actor", actor)
+
+	digits := len(entities.NormalizePhone(request.Phone))
+	if digits < minLookupPhoneDigits || digits > maxLookupPhoneDigits {
+		return nil, domainErrors.ErrInvalidLookupPhone
+	}
+
+	users, err := uc.userRepo.GetByPhone(ctx, request.Phone)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]uint, 0, len(users))
+	for _, user := range users {
+		matches = append(matches, user.ID)
+	}
+	uc.audit.Record(ctx, &entities.AuditEvent{
+		Action:       "user.phone_lookup",
+		ActorID:      actor,
+		ResourceType: "user",
+		Metadata: map[string]interface{}{
+			"matches":  len(users),
+			"user_ids": matches,
+		},
+	})
+
+	uc.logger.Info("LookUpPhone success", "actor", actor, "matches", len(users))
+	return &dto.PhoneLookupResponseDTO{
+		Users:     dto.UsersToResponseDTOs(users),
+		Count:     len(users),
+		Truncated: len(users) >= ports.MaxPhoneMatches,
+	}, nil
+}
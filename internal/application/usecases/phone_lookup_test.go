@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"user-service/internal/application/dto"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestPhoneLookupUseCases() (PhoneLookupUseCases, *MockUserRepository, *MockAuditLogger) {
+	mockRepo := new(MockUserRepository)
+	mockAudit := new(MockAuditLogger)
+	return NewPhoneLookupUseCases(mockRepo, mockAudit, logger.New("test")), mockRepo, mockAudit
+}
+
+func TestPhoneLookupUseCases_LookUpPhone(t *testing.T) {
+	// Given two accounts sharing a phone number
+	useCases, mockRepo, mockAudit := setupTestPhoneLookupUseCases()
+	ctx := context.Background()
+	mockRepo.On("GetByPhone", ctx, "+1 (555) 010-0000").Return([]*entities.User{
+		{ID: 3, Email: "a@example.com", Phone: "+15550100000"},
+		{ID: 7, Email: "b@example.com", Phone: "001 555 010 0000"},
+	}, nil)
+	mockAudit.On("Record", ctx, mock.MatchedBy(func(event *entities.AuditEvent) bool {
+		_, hasPhone := event.Metadata["phone"]
+		return event.Action == "user.phone_lookup" && event.ActorID == "fraud-svc" &&
+			event.Metadata["matches"] == 2 && !hasPhone
+	})).Return()
+
+	// When
+	result, err := useCases.LookUpPhone(ctx, "fraud-svc", &dto.PhoneLookupRequestDTO{Phone: "+1 (555) 010-0000"})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Count)
+	assert.False(t, result.Truncated)
+	assert.Equal(t, uint(3), result.Users[0].ID)
+	assert.Equal(t, uint(7), result.Users[1].ID)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestPhoneLookupUseCases_LookUpPhone_RejectsMalformedNumbers(t *testing.T) {
+	useCases, mockRepo, _ := setupTestPhoneLookupUseCases()
+
+	for _, phone := range []string{"12345", "+1 555 0100 0000 0000", "not a phone"} {
+		_, err := useCases.LookUpPhone(context.Background(), "fraud-svc", &dto.PhoneLookupRequestDTO{Phone: phone})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidLookupPhone, phone)
+	}
+	mockRepo.AssertNotCalled(t, "GetByPhone", mock.Anything, mock.Anything)
+}
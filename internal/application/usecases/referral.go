@@ -0,0 +1,152 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+	"user-service/pkg/pagination"
+)
+
+// ReferralUseCases defines the interface for referrals, which record who
+// brought a user to the service for the rewards service to credit them
+type ReferralUseCases interface {
+	ListReferrals(ctx context.Context, userID uint, page, pageSize int) (*dto.UserListResponseDTO, error)
+	AttributeReferral(ctx context.Context, userID uint, actor string, request *dto.AttributeReferralRequestDTO) (*dto.UserResponseDTO, error)
+}
+
+// referralUseCasesImpl implements ReferralUseCases interface
+type referralUseCasesImpl struct {
+	userRepo  ports.UserRepository
+	publisher ports.EventPublisher
+	audit     ports.AuditLogger
+	logger    logger.Logger
+}
+
+// NewReferralUseCases creates a new instance of referral use cases
+func NewReferralUseCases(userRepo ports.UserRepository, publisher ports.EventPublisher, audit ports.AuditLogger, log logger.Logger) ReferralUseCases {
+	return &referralUseCasesImpl{
+		userRepo:  userRepo,
+		publisher: publisher,
+		audit:     audit,
+		logger:    log.With("component", "referral_usecases"),
+	}
+}
+
+// ListReferrals lists the users a user referred, oldest first
+func (uc *referralUseCasesImpl) ListReferrals(ctx context.Context, userID uint, page, pageSize int) (*dto.UserListResponseDTO, error) {
+	uc.logger.Info("ListReferrals use case called", "user_id", userID, "page", page, "page_size", pageSize)
+
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	page, pageSize = pagination.DefaultLimits.Page(page, pageSize)
+
+	users, err := uc.userRepo.List(ctx, ports.UserFilter{ReferredBy: userID}, pageSize, pagination.Offset(page, pageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.UserListResponseDTO{
+		Users:    dto.UsersToResponseDTOs(users),
+		Total:    len(users),
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// AttributeReferral attributes a user who signed up without a referral code
+// to the owner of the code
+func (uc *referralUseCasesImpl) AttributeReferral(ctx context.Context, userID uint, actor string, request *dto.AttributeReferralRequestDTO) (*dto.UserResponseDTO, error) {
+	uc.logger.Info("AttributeReferral use case called", "user_id", userID, "actor", actor)
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	referrer, err := referUser(ctx, uc.userRepo, user, request.ReferralCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.userRepo.UpdateReferrer(ctx, user); err != nil {
+		return nil, err
+	}
+
+	uc.audit.Record(ctx, &entities.AuditEvent{
+		Action:       "user.referral_attributed",
+		ActorID:      actor,
+		ResourceType: "user",
+		ResourceID:   strconv.FormatUint(uint64(userID), 10),
+		Metadata:     map[string]interface{}{"referrer_id": referrer.ID},
+	})
+	publishReferral(ctx, uc.publisher, uc.logger, user, referrer)
+
+	uc.logger.Info("AttributeReferral success", "user_id", userID, "referrer_id", referrer.ID)
+	return dto.UserToResponseDTO(user), nil
+}
+
+// referUser attributes user to the owner of a referral code, which must be a
+// user of the same region, and returns the owner. Self-referrals and
+// referrals closing a loop are refused.
+func referUser(ctx context.Context, repo ports.UserRepository, user *entities.User, code string) (*entities.User, error) {
+	referrer, err := repo.GetByReferralCode(ctx, entities.NormalizeReferralCode(code))
+	if errors.Is(err, domainErrors.ErrUserNotFound) || errors.Is(err, domainErrors.ErrCrossRegionAccess) {
+		return nil, domainErrors.ErrInvalidReferralCode
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ancestors, err := referrerChain(ctx, repo, referrer)
+	if err != nil {
+		return nil, err
+	}
+	if err := user.ReferBy(referrer, ancestors); err != nil {
+		return nil, err
+	}
+	return referrer, nil
+}
+
+// referrerChain returns the IDs of the users who referred user, its referrer
+// and so on, nearest first. The chain ends at a user who signed up on their
+// own or who is gone. Chains longer than MaxReferralDepth or already looping
+// are treated as loops.
+func referrerChain(ctx context.Context, repo ports.UserRepository, user *entities.User) ([]uint, error) {
+	var chain []uint
+	for next := user.ReferredBy; next != nil; {
+		if len(chain) == entities.MaxReferralDepth || slices.Contains(chain, *next) {
+			return nil, domainErrors.ErrReferralLoop
+		}
+		chain = append(chain, *next)
+
+		referrer, err := repo.GetByID(ctx, *next)
+		if errors.Is(err, domainErrors.ErrUserNotFound) || errors.Is(err, domainErrors.ErrCrossRegionAccess) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		next = referrer.ReferredBy
+	}
+	return chain, nil
+}
+
+// publishReferral publishes the event the rewards service credits referrers on
+func publishReferral(ctx context.Context, publisher ports.EventPublisher, log logger.Logger, user, referrer *entities.User) {
+	event := entities.NewUserEvent(entities.UserEventReferred, user.ID, map[string]interface{}{
+		"referrer_id":   referrer.ID,
+		"referral_code": referrer.ReferralCode,
+	}).About(user)
+	if err := publisher.Publish(ctx, event); err != nil {
+		log.Error("Failed to publish referral", "user_id", user.ID, "referrer_id", referrer.ID, "error", err)
+	}
+}
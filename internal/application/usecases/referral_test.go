@@ -0,0 +1,164 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestReferralUseCases() (ReferralUseCases, *MockUserRepository, *MockEventPublisher, *MockAuditLogger) {
+	mockRepo := new(MockUserRepository)
+	mockPublisher := new(MockEventPublisher)
+	mockAudit := new(MockAuditLogger)
+	useCases := NewReferralUseCases(mockRepo, mockPublisher, mockAudit, logger.New("test"))
+	return useCases, mockRepo, mockPublisher, mockAudit
+}
+
+func referredBy(id uint) *uint {
+	return &id
+}
+
+func TestUserUseCases_CreateUser_WithReferralCode(t *testing.T) {
+	// Given a referrer who was referred themselves
+	useCases, mockRepo, mockPublisher := setupTestUseCasesWithPublisher()
+	ctx := context.Background()
+	referrer := &entities.User{ID: 7, Email: "ana@example.com", ReferralCode: "ANA23456", ReferredBy: referredBy(2), Status: entities.UserStatusActive}
+
+	mockRepo.On("ExistsByEmail", ctx, "ben@example.com").Return(false, nil)
+	mockRepo.On("GetByReferralCode", ctx, "ANA23456").Return(referrer, nil)
+	mockRepo.On("GetByID", ctx, uint(2)).Return(&entities.User{ID: 2, Status: entities.UserStatusActive}, nil)
+	mockRepo.On("Create", ctx, mock.MatchedBy(func(user *entities.User) bool {
+		return user.ReferredBy != nil && *user.ReferredBy == 7 && len(user.ReferralCode) == entities.ReferralCodeLength
+	})).Return(&entities.User{ID: 8, Email: "ben@example.com", ReferralCode: "BEN23456", ReferredBy: referredBy(7), Status: entities.UserStatusActive}, nil)
+	mockPublisher.On("Publish", ctx, mock.MatchedBy(func(event *entities.UserEvent) bool {
+		return event.Type == entities.UserEventReferred && event.UserID == 8 && event.Data["referrer_id"] == uint(7)
+	})).Return(nil)
+
+	// When
+	result, err := useCases.CreateUser(ctx, &dto.CreateUserRequestDTO{
+		Email:        "ben@example.com",
+		Password:     "SecurePass123",
+		FirstName:    "Ben",
+		ReferralCode: " ana23456 ",
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, referredBy(7), result.ReferredBy)
+	assert.Equal(t, "BEN23456", result.ReferralCode)
+	mockRepo.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+}
+
+func TestUserUseCases_CreateUser_UnknownReferralCode(t *testing.T) {
+	// Given
+	useCases, mockRepo, _ := setupTestUseCasesWithPublisher()
+	ctx := context.Background()
+	mockRepo.On("ExistsByEmail", ctx, "ben@example.com").Return(false, nil)
+	mockRepo.On("GetByReferralCode", ctx, "NOPE2345").Return(nil, domainErrors.ErrUserNotFound)
+
+	// When
+	_, err := useCases.CreateUser(ctx, &dto.CreateUserRequestDTO{
+		Email:        "ben@example.com",
+		Password:     "SecurePass123",
+		FirstName:    "Ben",
+		ReferralCode: "NOPE2345",
+	})
+
+	// Then nothing is created
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidReferralCode)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestReferralUseCases_AttributeReferral(t *testing.T) {
+	// Given
+	useCases, mockRepo, mockPublisher, mockAudit := setupTestReferralUseCases()
+	ctx := context.Background()
+	referrer := &entities.User{ID: 7, Email: "ana@example.com", ReferralCode: "ANA23456", Status: entities.UserStatusActive}
+
+	mockRepo.On("GetByID", ctx, uint(8)).Return(&entities.User{ID: 8, Email: "ben@example.com", Status: entities.UserStatusActive}, nil)
+	mockRepo.On("GetByReferralCode", ctx, "ANA23456").Return(referrer, nil)
+	mockRepo.On("UpdateReferrer", ctx, mock.MatchedBy(func(user *entities.User) bool {
+		return user.ID == 8 && user.ReferredBy != nil && *user.ReferredBy == 7
+	})).Return(nil)
+	mockAudit.On("Record", ctx, mock.MatchedBy(func(event *entities.AuditEvent) bool {
+		return event.Action == "user.referral_attributed" && event.ActorID == "support" && event.ResourceID == "8"
+	})).Return()
+	mockPublisher.On("Publish", ctx, mock.MatchedBy(func(event *entities.UserEvent) bool {
+		return event.Type == entities.UserEventReferred && event.UserID == 8
+	})).Return(nil)
+
+	// When
+	result, err := useCases.AttributeReferral(ctx, 8, "support", &dto.AttributeReferralRequestDTO{ReferralCode: "ANA23456"})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, referredBy(7), result.ReferredBy)
+	mockRepo.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+}
+
+func TestReferralUseCases_AttributeReferral_Loop(t *testing.T) {
+	// Given user 8 referred user 2, who referred user 7
+	useCases, mockRepo, _, _ := setupTestReferralUseCases()
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(8)).Return(&entities.User{ID: 8, Email: "ben@example.com", Status: entities.UserStatusActive}, nil)
+	mockRepo.On("GetByReferralCode", ctx, "ANA23456").Return(&entities.User{ID: 7, Email: "ana@example.com", ReferredBy: referredBy(2), Status: entities.UserStatusActive}, nil)
+	mockRepo.On("GetByID", ctx, uint(2)).Return(&entities.User{ID: 2, ReferredBy: referredBy(8), Status: entities.UserStatusActive}, nil)
+
+	// When user 8 is attributed to user 7
+	_, err := useCases.AttributeReferral(ctx, 8, "support", &dto.AttributeReferralRequestDTO{ReferralCode: "ANA23456"})
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrReferralLoop)
+	mockRepo.AssertNotCalled(t, "UpdateReferrer", mock.Anything, mock.Anything)
+}
+
+func TestReferralUseCases_AttributeReferral_LoopingChain(t *testing.T) {
+	// Given stored referrals that already loop between users 2 and 3
+	useCases, mockRepo, _, _ := setupTestReferralUseCases()
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(8)).Return(&entities.User{ID: 8, Email: "ben@example.com", Status: entities.UserStatusActive}, nil)
+	mockRepo.On("GetByReferralCode", ctx, "ANA23456").Return(&entities.User{ID: 7, Email: "ana@example.com", ReferredBy: referredBy(2), Status: entities.UserStatusActive}, nil)
+	mockRepo.On("GetByID", ctx, uint(2)).Return(&entities.User{ID: 2, ReferredBy: referredBy(3)}, nil)
+	mockRepo.On("GetByID", ctx, uint(3)).Return(&entities.User{ID: 3, ReferredBy: referredBy(2)}, nil)
+
+	// When
+	_, err := useCases.AttributeReferral(ctx, 8, "support", &dto.AttributeReferralRequestDTO{ReferralCode: "ANA23456"})
+
+	// Then the walk stops instead of going round
+	assert.ErrorIs(t, err, domainErrors.ErrReferralLoop)
+}
+
+func TestReferralUseCases_ListReferrals(t *testing.T) {
+	// Given
+	useCases, mockRepo, _, _ := setupTestReferralUseCases()
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(7)).Return(&entities.User{ID: 7}, nil)
+	mockRepo.On("List", ctx, ports.UserFilter{ReferredBy: 7}, 10, 10).Return([]*entities.User{
+		{ID: 8, ReferredBy: referredBy(7)},
+	}, nil)
+
+	// When
+	result, err := useCases.ListReferrals(ctx, 7, 2, 10)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, result.Users, 1)
+	assert.Equal(t, uint(8), result.Users[0].ID)
+	assert.Equal(t, 2, result.Page)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	userErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+)
+
+// SuppressionUseCases defines the interface for the communications
+// suppression list, which the notification service checks and updates
+type SuppressionUseCases interface {
+	CheckSuppression(ctx context.Context, email string) (*dto.SuppressionCheckResponseDTO, error)
+	Suppress(ctx context.Context, email, source string, request *dto.SuppressRequestDTO) (*dto.SuppressionResponseDTO, error)
+	Unsuppress(ctx context.Context, email, actor string) error
+}
+
+// suppressionUseCasesImpl implements SuppressionUseCases interface
+type suppressionUseCasesImpl struct {
+	list   ports.SuppressionList
+	audit  ports.AuditLogger
+	now    func() time.Time
+	logger logger.Logger
+}
+
+// NewSuppressionUseCases creates a new instance of suppression list use cases
+func NewSuppressionUseCases(list ports.SuppressionList, audit ports.AuditLogger, log logger.Logger) SuppressionUseCases {
+	return &suppressionUseCasesImpl{
+		list:   list,
+		audit:  audit,
+		now:    time.Now,
+		logger: log.With("component", "suppression_usecases"),
+	}
+}
+
+// CheckSuppression reports whether communications to an address are
+// suppressed; lapsed suppressions no longer count
+func (uc *suppressionUseCasesImpl) CheckSuppression(ctx context.Context, email string) (*dto.SuppressionCheckResponseDTO, error) {
+	email = entities.CanonicalEmail(email)
+	response := &dto.SuppressionCheckResponseDTO{Email: email}
+
+	suppression, err := uc.list.Get(ctx, email)
+	if errors.Is(err, userErrors.ErrSuppressionNotFound) {
+		return response, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if suppression.ActiveAt(uc.now()) {
+		response.Suppressed = true
+		response.Suppression = dto.SuppressionToResponseDTO(suppression)
+	}
+	return response, nil
+}
+
+// Suppress adds an address to the suppression list, replacing any earlier
+// suppression of it
+func (uc *suppressionUseCasesImpl) Suppress(ctx context.Context, email, source string, request *dto.SuppressRequestDTO) (*dto.SuppressionResponseDTO, error) {
+	uc.logger.Info("Suppress use case called", "email", logger.MaskEmail(email), "reason", request.Reason, "source", source)
+
+	suppression, err := request.ToEntity(email, source, uc.now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.list.Put(ctx, suppression); err != nil {
+		return nil, err
+	}
+
+	uc.recordAudit(ctx, "suppression.added", source, suppression)
+
+	uc.logger.Info("Suppress success", "email", logger.MaskEmail(suppression.Email), "reason", suppression.Reason)
+	return dto.SuppressionToResponseDTO(suppression), nil
+}
+
+// Unsuppress lifts the suppression of an address
+func (uc *suppressionUseCasesImpl) Unsuppress(ctx context.Context, email, actor string) error {
+	uc.logger.Info("Unsuppress use case called", "email", logger.MaskEmail(email), "actor", actor)
+
+	suppression, err := uc.list.Get(ctx, entities.CanonicalEmail(email))
+	if err != nil {
+		return err
+	}
+
+	if err := uc.list.Delete(ctx, suppression.Email); err != nil {
+		return err
+	}
+
+	uc.recordAudit(ctx, "suppression.removed", actor, suppression)
+
+	uc.logger.Info("Unsuppress success", "email", logger.MaskEmail(suppression.Email))
+	return nil
+}
+
+func (uc *suppressionUseCasesImpl) recordAudit(ctx context.Context, action, actor string, suppression *entities.Suppression) {
+	uc.audit.Record(ctx, &entities.AuditEvent{
+		Action:       action,
+		ActorID:      actor,
+		ResourceType: "suppression",
+		ResourceID:   logger.MaskEmail(suppression.Email),
+		Metadata: map[string]interface{}{
+			"reason": suppression.Reason,
+		},
+	})
+}
@@ -0,0 +1,120 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/application/dto"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockSuppressionList is a mock implementation of ports.SuppressionList
+type MockSuppressionList struct {
+	mock.Mock
+}
+
+func (m *MockSuppressionList) Get(ctx context.Context, email string) (*entities.Suppression, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Suppression), args.Error(1)
+}
+
+func (m *MockSuppressionList) Put(ctx context.Context, suppression *entities.Suppression) error {
+	args := m.Called(ctx, suppression)
+	return args.Error(0)
+}
+
+func (m *MockSuppressionList) Delete(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+var suppressionNow = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func setupTestSuppressionUseCases() (SuppressionUseCases, *MockSuppressionList, *MockAuditLogger) {
+	mockList := new(MockSuppressionList)
+	mockAudit := new(MockAuditLogger)
+	useCases := NewSuppressionUseCases(mockList, mockAudit, logger.New("test"))
+	useCases.(*suppressionUseCasesImpl).now = func() time.Time { return suppressionNow }
+	return useCases, mockList, mockAudit
+}
+
+func TestSuppressionUseCases_CheckSuppression(t *testing.T) {
+	lapsed := suppressionNow.Add(-time.Hour)
+	tests := []struct {
+		name        string
+		suppression *entities.Suppression
+		err         error
+		suppressed  bool
+	}{
+		{name: "suppressed", suppression: &entities.Suppression{Email: "jane@example.com", Reason: entities.SuppressionReasonBounced}, suppressed: true},
+		{name: "lapsed", suppression: &entities.Suppression{Email: "jane@example.com", Reason: entities.SuppressionReasonUnsubscribed, ExpiresAt: &lapsed}},
+		{name: "not listed", err: domainErrors.ErrSuppressionNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockList, _ := setupTestSuppressionUseCases()
+			ctx := context.Background()
+			mockList.On("Get", ctx, "jane@example.com").Return(tt.suppression, tt.err)
+
+			// When the address is checked in another spelling
+			result, err := useCases.CheckSuppression(ctx, "Jane@Example.com")
+
+			// Then
+			require.NoError(t, err)
+			assert.Equal(t, "jane@example.com", result.Email)
+			assert.Equal(t, tt.suppressed, result.Suppressed)
+			assert.Equal(t, tt.suppressed, result.Suppression != nil)
+		})
+	}
+}
+
+func TestSuppressionUseCases_Suppress(t *testing.T) {
+	// Given
+	useCases, mockList, mockAudit := setupTestSuppressionUseCases()
+	ctx := context.Background()
+	mockList.On("Put", ctx, mock.MatchedBy(func(suppression *entities.Suppression) bool {
+		return suppression.Email == "jane@example.com" &&
+			suppression.Reason == entities.SuppressionReasonUnsubscribed &&
+			suppression.Source == "notifications"
+	})).Return(nil)
+	mockAudit.On("Record", ctx, mock.MatchedBy(func(event *entities.AuditEvent) bool {
+		return event.Action == "suppression.added" && event.ActorID == "notifications"
+	})).Return()
+
+	// When
+	result, err := useCases.Suppress(ctx, "jane@example.com", "notifications", &dto.SuppressRequestDTO{Reason: "unsubscribed"})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, entities.SuppressionReasonUnsubscribed, result.Reason)
+	assert.Equal(t, suppressionNow, result.CreatedAt.Time)
+	mockList.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestSuppressionUseCases_Unsuppress_NotListed(t *testing.T) {
+	// Given
+	useCases, mockList, mockAudit := setupTestSuppressionUseCases()
+	ctx := context.Background()
+	mockList.On("Get", ctx, "jane@example.com").Return(nil, domainErrors.ErrSuppressionNotFound)
+
+	// When
+	err := useCases.Unsuppress(ctx, "jane@example.com", "notifications")
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrSuppressionNotFound)
+	mockList.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	mockAudit.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+}
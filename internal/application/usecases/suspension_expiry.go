@@ -0,0 +1,141 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	userErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+)
+
+// suspensionExpiryPageSize is the number of due users read per query
+const suspensionExpiryPageSize = 100
+
+// suspensionExpiryActor is who the audit trail names for reactivations
+const suspensionExpiryActor = "system:suspension_expiry"
+
+// SuspensionExpiryJob reactivates suspended users once the reactivation date
+// of their suspension has passed. It runs as a scheduled job.
+type SuspensionExpiryJob struct {
+	userRepo  ports.UserRepository
+	publisher ports.EventPublisher
+	audit     ports.AuditLogger
+	// regions are the residency regions to reactivate users in; nil while
+	// residency is disabled
+	regions []entities.Residency
+	now     func() time.Time
+	logger  logger.Logger
+}
+
+// NewSuspensionExpiryJob creates the suspension expiry job
+func NewSuspensionExpiryJob(userRepo ports.UserRepository, publisher ports.EventPublisher, audit ports.AuditLogger, regions []entities.Residency, log logger.Logger) *SuspensionExpiryJob {
+	return &SuspensionExpiryJob{
+		userRepo:  userRepo,
+		publisher: publisher,
+		audit:     audit,
+		regions:   regions,
+		now:       time.Now,
+		logger:    log.With("component", "suspension_expiry"),
+	}
+}
+
+// Name implements ports.Job
+func (j *SuspensionExpiryJob) Name() string {
+	return "suspension_expiry"
+}
+
+// Run implements ports.Job. Users whose status changed meanwhile are left
+// alone; a failure stops the run and the next one picks up where it left off.
+func (j *SuspensionExpiryJob) Run(ctx context.Context) error {
+	now := j.now().UTC()
+	scopes := []context.Context{ctx}
+	if len(j.regions) > 0 {
+		scopes = scopes[:0]
+		for _, region := range j.regions {
+			scopes = append(scopes, ports.WithResidency(ctx, region))
+		}
+	}
+
+	reactivated := 0
+	for _, scoped := range scopes {
+		count, err := j.reactivateDue(scoped, now)
+		reactivated += count
+		if err != nil {
+			return err
+		}
+	}
+
+	j.logger.Info("Expired suspensions lifted", "reactivated", reactivated)
+	return nil
+}
+
+// reactivateDue reactivates the due users of the region ctx is scoped to
+func (j *SuspensionExpiryJob) reactivateDue(ctx context.Context, now time.Time) (int, error) {
+	reactivated := 0
+	filter := ports.UserFilter{ReactivationDueBy: &now}
+
+	for {
+		users, err := j.userRepo.List(ctx, filter, suspensionExpiryPageSize, 0)
+		if err != nil {
+			return reactivated, err
+		}
+
+		for _, user := range users {
+			filter.AfterID = user.ID
+			done, err := j.reactivate(ctx, user)
+			if err != nil {
+				return reactivated, err
+			}
+			if done {
+				reactivated++
+			}
+		}
+
+		if len(users) < suspensionExpiryPageSize {
+			return reactivated, nil
+		}
+	}
+}
+
+// reactivate lifts the suspension of a user and reports whether it did
+func (j *SuspensionExpiryJob) reactivate(ctx context.Context, user *entities.User) (bool, error) {
+	suspension := user.Suspension
+	from := user.Status
+	if err := user.Activate(); err != nil {
+		j.logger.Warn("Suspension expiry rejected", "user_id", user.ID, "status", from, "error", err)
+		return false, nil
+	}
+
+	// Guarded by the current status so a concurrent change is not overwritten
+	err := j.userRepo.UpdateStatus(ctx, user, from)
+	if errors.Is(err, userErrors.ErrStatusChanged) || errors.Is(err, userErrors.ErrUserNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	data := map[string]interface{}{"from": string(from), "to": string(user.Status), "reason": "suspension_expired"}
+	if suspension != nil {
+		data["suspension"] = suspension.EventData()
+	}
+	event := entities.NewUserEvent(entities.UserEventStatusChanged, user.ID, data).About(user)
+	if err := j.publisher.Publish(ctx, event); err != nil {
+		j.logger.Error("Failed to publish user status changed event", "user_id", user.ID, "error", err)
+	}
+
+	j.audit.Record(ctx, &entities.AuditEvent{
+		Action:       "user.status_changed",
+		ActorID:      suspensionExpiryActor,
+		ResourceType: "user",
+		ResourceID:   strconv.FormatUint(uint64(user.ID), 10),
+		Metadata:     data,
+	})
+
+	j.logger.Info("Suspension expired", "user_id", user.ID)
+	return true, nil
+}
@@ -0,0 +1,93 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestSuspensionExpiry(now time.Time, regions []entities.Residency) (*SuspensionExpiryJob, *MockUserRepository, *MockEventPublisher, *MockAuditLogger) {
+	mockRepo := new(MockUserRepository)
+	mockPublisher := new(MockEventPublisher)
+	mockAudit := new(MockAuditLogger)
+
+	job := NewSuspensionExpiryJob(mockRepo, mockPublisher, mockAudit, regions, logger.New("test"))
+	job.now = func() time.Time { return now }
+	return job, mockRepo, mockPublisher, mockAudit
+}
+
+func suspendedUntil(id uint, reactivateAt time.Time) *entities.User {
+	return &entities.User{
+		ID:         id,
+		Status:     entities.UserStatusSuspended,
+		Suspension: &entities.Suspension{Reason: entities.SuspensionReasonPayment, ReactivateAt: &reactivateAt},
+	}
+}
+
+func TestSuspensionExpiryJob_ReactivatesDueUsers(t *testing.T) {
+	// Given two users whose suspension ended, one reactivated meanwhile by support
+	now := time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)
+	job, mockRepo, mockPublisher, mockAudit := setupTestSuspensionExpiry(now, nil)
+	ctx := context.Background()
+
+	mockRepo.On("List", ctx, ports.UserFilter{ReactivationDueBy: &now}, suspensionExpiryPageSize, 0).
+		Return([]*entities.User{suspendedUntil(1, now.Add(-time.Hour)), suspendedUntil(2, now.Add(-time.Minute))}, nil)
+	mockRepo.On("UpdateStatus", ctx, mock.MatchedBy(func(user *entities.User) bool {
+		return user.ID == 1 && user.Status == entities.UserStatusActive && user.Suspension == nil
+	}), entities.UserStatusSuspended).Return(nil)
+	mockRepo.On("UpdateStatus", ctx, mock.MatchedBy(func(user *entities.User) bool { return user.ID == 2 }), entities.UserStatusSuspended).
+		Return(domainErrors.ErrStatusChanged)
+	mockPublisher.On("Publish", ctx, mock.MatchedBy(func(event *entities.UserEvent) bool {
+		return event.Type == entities.UserEventStatusChanged && event.UserID == 1 && event.Data["reason"] == "suspension_expired"
+	})).Return(nil).Once()
+	mockAudit.On("Record", ctx, mock.MatchedBy(func(event *entities.AuditEvent) bool {
+		return event.ResourceID == "1" && event.ActorID == suspensionExpiryActor
+	})).Once()
+
+	// When
+	err := job.Run(ctx)
+
+	// Then only the user still suspended is reactivated
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestSuspensionExpiryJob_CoversEveryRegion(t *testing.T) {
+	// Given residency regions with nothing due
+	now := time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)
+	job, mockRepo, _, _ := setupTestSuspensionExpiry(now, entities.Residencies)
+	ctx := context.Background()
+	for _, region := range entities.Residencies {
+		mockRepo.On("List", ports.WithResidency(ctx, region), ports.UserFilter{ReactivationDueBy: &now}, suspensionExpiryPageSize, 0).
+			Return([]*entities.User{}, nil).Once()
+	}
+
+	// When
+	err := job.Run(ctx)
+
+	// Then each region is looked at
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSuspensionExpiryJob_StopsOnRepositoryFailure(t *testing.T) {
+	now := time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)
+	job, mockRepo, _, _ := setupTestSuspensionExpiry(now, nil)
+	ctx := context.Background()
+	mockRepo.On("List", ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil, domainErrors.ErrFailedToListUsers)
+
+	err := job.Run(ctx)
+
+	assert.ErrorIs(t, err, domainErrors.ErrFailedToListUsers)
+}
@@ -0,0 +1,179 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+)
+
+const (
+	// defaultUsageRange is reported when no range is asked for
+	defaultUsageRange = 24 * time.Hour
+	// maxUsageRange bounds the hours read per request
+	maxUsageRange = 31 * 24 * time.Hour
+	// usageExportMaxHours bounds the hours exported per run, so a long
+	// backlog is caught up over several runs
+	usageExportMaxHours = 168
+)
+
+// UsageUseCases defines the interface for reading metered usage
+type UsageUseCases interface {
+	// GetUsage reports the usage of one tenant, or all when tenant is empty,
+	// over the whole hours covering [from, to). Zero times default to the
+	// last 24 hours.
+	GetUsage(ctx context.Context, tenant string, from, to time.Time) (*dto.UsageResponseDTO, error)
+}
+
+// usageUseCasesImpl implements UsageUseCases interface
+type usageUseCasesImpl struct {
+	store  ports.UsageStore
+	now    func() time.Time
+	logger logger.Logger
+}
+
+// NewUsageUseCases creates a new instance of usage use cases
+func NewUsageUseCases(store ports.UsageStore, log logger.Logger) UsageUseCases {
+	return &usageUseCasesImpl{
+		store:  store,
+		now:    time.Now,
+		logger: log.With("component", "usage_usecases"),
+	}
+}
+
+// GetUsage implements UsageUseCases
+func (uc *usageUseCasesImpl) GetUsage(ctx context.Context, tenant string, from, to time.Time) (*dto.UsageResponseDTO, error) {
+	if to.IsZero() {
+		to = uc.now()
+	}
+	if from.IsZero() {
+		from = to.Add(-defaultUsageRange)
+	}
+
+	// Widen the range to whole hours, the granularity usage is kept at
+	from = entities.UsageHour(from)
+	if hour := entities.UsageHour(to); !hour.Equal(to) {
+		to = hour.Add(time.Hour)
+	}
+	to = to.UTC()
+	if !from.Before(to) || to.Sub(from) > maxUsageRange {
+		return nil, domainErrors.ErrInvalidUsageRange
+	}
+
+	uc.logger.Info("GetUsage use case called", "tenant", tenant, "from", from, "to", to)
+
+	rollups, err := uc.store.List(ctx, tenant, from, to)
+	if err != nil {
+		return nil, err
+	}
+	activeUsers, err := uc.store.ActiveUsers(ctx, tenant, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.UsageResponseDTO{
+		From:    dto.NewTimestamp(from),
+		To:      dto.NewTimestamp(to),
+		Tenants: []dto.TenantUsageDTO{},
+	}
+	index := make(map[string]int)
+	for _, rollup := range rollups {
+		i, ok := index[rollup.Tenant]
+		if !ok {
+			i = len(response.Tenants)
+			index[rollup.Tenant] = i
+			response.Tenants = append(response.Tenants, dto.TenantUsageDTO{
+				Tenant:      rollup.Tenant,
+				ActiveUsers: activeUsers[rollup.Tenant],
+			})
+		}
+
+		usage := &response.Tenants[i]
+		usage.APICalls += rollup.APICalls
+		usage.Hours = append(usage.Hours, dto.HourlyUsageDTO{
+			Hour:        dto.NewTimestamp(rollup.Hour),
+			APICalls:    rollup.APICalls,
+			ActiveUsers: rollup.ActiveUsers,
+		})
+	}
+
+	return response, nil
+}
+
+// UsageExportJob delivers the hourly usage rollups to the billing system, one
+// CSV file per hour. It runs as a scheduled job.
+//
+// Accuracy: an hour is exported once it ended more than the export delay
+// ago, which exceeds the flush interval, so every running instance has
+// flushed it. An hour that changes after its export, e.g. by a flush retried
+// after a database outage, is exported again with the next version, and an
+// export whose delivery is not recorded is delivered again under the same
+// name. Billing takes the highest version of each hour.
+type UsageExportJob struct {
+	store    ports.UsageStore
+	exporter ports.UsageExporter
+	delay    time.Duration
+	now      func() time.Time
+	logger   logger.Logger
+}
+
+// NewUsageExportJob creates the usage export job
+func NewUsageExportJob(store ports.UsageStore, exporter ports.UsageExporter, delay time.Duration, log logger.Logger) *UsageExportJob {
+	return &UsageExportJob{
+		store:    store,
+		exporter: exporter,
+		delay:    delay,
+		now:      time.Now,
+		logger:   log.With("component", "usage_export"),
+	}
+}
+
+// Name implements ports.Job
+func (j *UsageExportJob) Name() string {
+	return "usage_export"
+}
+
+// Run implements ports.Job, exporting the due hours oldest first
+func (j *UsageExportJob) Run(ctx context.Context) error {
+	before := entities.UsageHour(j.now().Add(-j.delay))
+
+	exported := 0
+	for exported < usageExportMaxHours {
+		export, err := j.store.NextExport(ctx, before)
+		if err != nil {
+			return err
+		}
+		if export == nil {
+			break
+		}
+
+		var content bytes.Buffer
+		if err := export.WriteCSV(&content); err != nil {
+			return err
+		}
+		if err := j.exporter.Export(ctx, export.FileName(), content.Bytes()); err != nil {
+			j.logger.Error("Failed to deliver usage export",
+				"file", export.FileName(),
+				"error", err)
+			return domainErrors.ErrFailedToExportUsage
+		}
+		if err := j.store.CompleteExport(ctx, export); err != nil {
+			return err
+		}
+
+		j.logger.Info("Usage exported",
+			"file", export.FileName(),
+			"tenants", len(export.Rollups))
+		exported++
+	}
+
+	if exported == 0 {
+		j.logger.Info("No usage to export")
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	"user-service/pkg/logger"
+)
+
+// usageKey identifies the usage of one tenant in one hour
+type usageKey struct {
+	tenant string
+	hour   time.Time
+}
+
+// usageCounts is the usage of one tenant in one hour not yet flushed
+type usageCounts struct {
+	calls int64
+	users map[entities.UsageUser]struct{}
+}
+
+// UsageMeter counts the API calls and active users of each tenant in memory
+// and flushes them to the hourly rollups every flush interval. Every instance
+// runs its own meter.
+//
+// Accuracy: a call is counted in the hour it arrived in, and exactly once as
+// long as the instance stops cleanly. A failed flush is retried as the same
+// batch, which the store skips when the failed attempt did commit; calls
+// recorded meanwhile wait for the following batch. An instance that crashes
+// loses the calls of at most one flush interval.
+type UsageMeter struct {
+	store    ports.UsageStore
+	interval time.Duration
+	// instance prefixes batch IDs, so batches of different instances and
+	// runs never collide
+	instance string
+	now      func() time.Time
+
+	mu     sync.Mutex
+	counts map[usageKey]*usageCounts
+	// flushMu serializes flushes, so a batch is retried before the next one
+	flushMu sync.Mutex
+	// pending is the batch whose flush failed, retried before new usage
+	pending  *entities.UsageBatch
+	sequence uint64
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	logger logger.Logger
+}
+
+// NewUsageMeter creates a usage meter flushing to store every interval
+func NewUsageMeter(store ports.UsageStore, interval time.Duration, log logger.Logger) (*UsageMeter, error) {
+	instance := make([]byte, 8)
+	if _, err := rand.Read(instance); err != nil {
+		return nil, fmt.Errorf("failed to generate usage meter instance ID: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &UsageMeter{
+		store:    store,
+		interval: interval,
+		instance: hex.EncodeToString(instance),
+		now:      time.Now,
+		counts:   make(map[usageKey]*usageCounts),
+		ctx:      ctx,
+		cancel:   cancel,
+		logger:   log.With("component", "usage_meter"),
+	}, nil
+}
+
+// Record counts one call of tenant, addressing the user userID of region
+// unless userID is 0
+func (m *UsageMeter) Record(tenant string, region entities.Residency, userID uint) {
+	key := usageKey{tenant: tenant, hour: entities.UsageHour(m.now())}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counts, ok := m.counts[key]
+	if !ok {
+		counts = &usageCounts{users: make(map[entities.UsageUser]struct{})}
+		m.counts[key] = counts
+	}
+	counts.calls++
+	if userID != 0 {
+		counts.users[entities.UsageUser{Residency: region, ID: userID}] = struct{}{}
+	}
+}
+
+// Flush adds the usage recorded so far to the rollups, retrying a batch that
+// failed earlier first. Usage that fails to flush is kept for the next call.
+func (m *UsageMeter) Flush(ctx context.Context) error {
+	m.flushMu.Lock()
+	defer m.flushMu.Unlock()
+
+	if m.pending != nil {
+		if err := m.store.Flush(ctx, m.pending); err != nil {
+			return err
+		}
+		m.pending = nil
+	}
+
+	batch := m.takeBatch()
+	if batch == nil {
+		return nil
+	}
+	if err := m.store.Flush(ctx, batch); err != nil {
+		m.pending = batch
+		return err
+	}
+	return nil
+}
+
+// takeBatch moves the recorded usage into a new batch, or returns nil when
+// nothing was recorded. Deltas are ordered by tenant and hour, so concurrent
+// flushes of several instances lock rollups in the same order.
+func (m *UsageMeter) takeBatch() *entities.UsageBatch {
+	m.mu.Lock()
+	counts := m.counts
+	m.counts = make(map[usageKey]*usageCounts)
+	m.mu.Unlock()
+
+	if len(counts) == 0 {
+		return nil
+	}
+
+	keys := slices.SortedFunc(maps.Keys(counts), func(a, b usageKey) int {
+		return cmp.Or(cmp.Compare(a.tenant, b.tenant), a.hour.Compare(b.hour))
+	})
+	m.sequence++
+	batch := &entities.UsageBatch{
+		ID:     fmt.Sprintf("%s-%d", m.instance, m.sequence),
+		Deltas: make([]*entities.UsageDelta, 0, len(keys)),
+	}
+	for _, key := range keys {
+		batch.Deltas = append(batch.Deltas, &entities.UsageDelta{
+			Tenant:      key.tenant,
+			Hour:        key.hour,
+			APICalls:    counts[key].calls,
+			ActiveUsers: slices.SortedFunc(maps.Keys(counts[key].users), entities.UsageUser.Compare),
+		})
+	}
+	return batch
+}
+
+// Start flushes every flush interval until Stop
+func (m *UsageMeter) Start() {
+	m.logger.Info("Usage metering enabled", "flush_interval", m.interval)
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-m.ctx.Done():
+				return
+			case <-ticker.C:
+				if err := m.Flush(m.ctx); err != nil {
+					m.logger.Warn("Failed to flush usage, retrying at the next interval", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops the periodic flushes and flushes what is left, within ctx
+func (m *UsageMeter) Stop(ctx context.Context) error {
+	m.cancel()
+	m.wg.Wait()
+
+	if err := m.Flush(ctx); err != nil {
+		return fmt.Errorf("failed to flush usage on stop: %w", err)
+	}
+	return nil
+}
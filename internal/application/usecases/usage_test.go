@@ -0,0 +1,471 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRollup is a rollup row of fakeUsageStore
+type fakeRollup struct {
+	calls            int64
+	revision         int64
+	exportedRevision int64
+}
+
+// fakeUsageStore keeps usage in memory with the semantics of the GORM store:
+// batches are applied once, active users are distinct rows and every flush
+// into a rollup moves its revision
+type fakeUsageStore struct {
+	mu       sync.Mutex
+	batches  map[string]bool
+	rollups  map[usageKey]*fakeRollup
+	users    map[usageKey]map[entities.UsageUser]bool
+	versions map[time.Time]int
+	// flushErr fails the next flush, after applying it when committed is set,
+	// as when the connection drops while the commit succeeds
+	flushErr  error
+	committed bool
+}
+
+func newFakeUsageStore() *fakeUsageStore {
+	return &fakeUsageStore{
+		batches:  make(map[string]bool),
+		rollups:  make(map[usageKey]*fakeRollup),
+		users:    make(map[usageKey]map[entities.UsageUser]bool),
+		versions: make(map[time.Time]int),
+	}
+}
+
+func (s *fakeUsageStore) Flush(ctx context.Context, batch *entities.UsageBatch) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.flushErr
+	s.flushErr = nil
+	if err != nil && !s.committed {
+		return err
+	}
+	if s.batches[batch.ID] {
+		return err
+	}
+	s.batches[batch.ID] = true
+
+	for _, delta := range batch.Deltas {
+		key := usageKey{tenant: delta.Tenant, hour: delta.Hour}
+		rollup, ok := s.rollups[key]
+		if !ok {
+			rollup = &fakeRollup{}
+			s.rollups[key] = rollup
+			s.users[key] = make(map[entities.UsageUser]bool)
+		}
+		rollup.calls += delta.APICalls
+		rollup.revision++
+		for _, user := range delta.ActiveUsers {
+			s.users[key][user] = true
+		}
+	}
+	return err
+}
+
+// sortedKeys returns the rollup keys matching keep, by hour and tenant
+func (s *fakeUsageStore) sortedKeys(keep func(usageKey) bool) []usageKey {
+	var keys []usageKey
+	for key := range s.rollups {
+		if keep(key) {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, func(a, b usageKey) int {
+		return cmp.Or(a.hour.Compare(b.hour), cmp.Compare(a.tenant, b.tenant))
+	})
+	return keys
+}
+
+func (s *fakeUsageStore) rollup(key usageKey) *entities.UsageRollup {
+	return &entities.UsageRollup{
+		Tenant:      key.tenant,
+		Hour:        key.hour,
+		APICalls:    s.rollups[key].calls,
+		ActiveUsers: int64(len(s.users[key])),
+		Revision:    s.rollups[key].revision,
+	}
+}
+
+func (s *fakeUsageStore) List(ctx context.Context, tenant string, from, to time.Time) ([]*entities.UsageRollup, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var rollups []*entities.UsageRollup
+	for _, key := range s.sortedKeys(func(key usageKey) bool {
+		return (tenant == "" || key.tenant == tenant) && !key.hour.Before(from) && key.hour.Before(to)
+	}) {
+		rollups = append(rollups, s.rollup(key))
+	}
+	return rollups, nil
+}
+
+func (s *fakeUsageStore) ActiveUsers(ctx context.Context, tenant string, from, to time.Time) (map[string]int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	distinct := make(map[string]map[entities.UsageUser]bool)
+	for key, users := range s.users {
+		if (tenant != "" && key.tenant != tenant) || key.hour.Before(from) || !key.hour.Before(to) {
+			continue
+		}
+		if distinct[key.tenant] == nil {
+			distinct[key.tenant] = make(map[entities.UsageUser]bool)
+		}
+		maps.Copy(distinct[key.tenant], users)
+	}
+
+	counts := make(map[string]int64, len(distinct))
+	for tenant, users := range distinct {
+		counts[tenant] = int64(len(users))
+	}
+	return counts, nil
+}
+
+func (s *fakeUsageStore) NextExport(ctx context.Context, before time.Time) (*entities.UsageExport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	due := s.sortedKeys(func(key usageKey) bool {
+		return key.hour.Before(before) && s.rollups[key].revision > s.rollups[key].exportedRevision
+	})
+	if len(due) == 0 {
+		return nil, nil
+	}
+
+	hour := due[0].hour
+	export := &entities.UsageExport{Hour: hour, Version: s.versions[hour] + 1}
+	for _, key := range s.sortedKeys(func(key usageKey) bool { return key.hour.Equal(hour) }) {
+		export.Rollups = append(export.Rollups, s.rollup(key))
+	}
+	return export, nil
+}
+
+func (s *fakeUsageStore) CompleteExport(ctx context.Context, export *entities.UsageExport) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, rollup := range export.Rollups {
+		row := s.rollups[usageKey{tenant: rollup.Tenant, hour: export.Hour}]
+		row.exportedRevision = max(row.exportedRevision, rollup.Revision)
+	}
+	s.versions[export.Hour] = export.Version
+	return nil
+}
+
+// fakeUsageExporter keeps delivered files by name
+type fakeUsageExporter struct {
+	files map[string]string
+	err   error
+}
+
+func (e *fakeUsageExporter) Export(ctx context.Context, name string, content []byte) error {
+	if e.err != nil {
+		return e.err
+	}
+	e.files[name] = string(content)
+	return nil
+}
+
+func setupTestUsageMeter(t *testing.T, store *fakeUsageStore, now *time.Time) *UsageMeter {
+	t.Helper()
+	meter, err := NewUsageMeter(store, time.Minute, logger.New("test"))
+	require.NoError(t, err)
+	meter.now = func() time.Time { return *now }
+	return meter
+}
+
+func TestUsageMeter_CountsCallsInTheHourTheyArrive(t *testing.T) {
+	// Given calls on both sides of an hour boundary
+	store := newFakeUsageStore()
+	now := time.Date(2024, 5, 1, 11, 59, 59, 0, time.UTC)
+	meter := setupTestUsageMeter(t, store, &now)
+	ctx := context.Background()
+
+	meter.Record("acme", "", 1)
+	now = now.Add(2 * time.Second)
+	meter.Record("acme", "", 1)
+	meter.Record("acme", "", 0)
+
+	// When they are flushed in the later hour
+	now = now.Add(5 * time.Minute)
+	require.NoError(t, meter.Flush(ctx))
+
+	// Then each call counts in the hour it arrived in
+	rollups, err := store.List(ctx, "acme", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, rollups, 2)
+	assert.Equal(t, time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC), rollups[0].Hour)
+	assert.Equal(t, int64(1), rollups[0].APICalls)
+	assert.Equal(t, int64(1), rollups[0].ActiveUsers)
+	assert.Equal(t, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), rollups[1].Hour)
+	assert.Equal(t, int64(2), rollups[1].APICalls)
+	assert.Equal(t, int64(1), rollups[1].ActiveUsers)
+}
+
+func TestUsageMeter_RetriesFailedFlushWithoutLosingUsage(t *testing.T) {
+	// Given a flush that fails before it is applied
+	store := newFakeUsageStore()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	meter := setupTestUsageMeter(t, store, &now)
+	ctx := context.Background()
+
+	meter.Record("acme", "", 1)
+	store.flushErr = domainErrors.ErrFailedToRecordUsage
+	require.Error(t, meter.Flush(ctx))
+
+	// When more calls arrive and the next flush succeeds
+	meter.Record("acme", "", 2)
+	require.NoError(t, meter.Flush(ctx))
+
+	// Then every call is counted once
+	rollups, err := store.List(ctx, "", now, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, rollups, 1)
+	assert.Equal(t, int64(2), rollups[0].APICalls)
+	assert.Equal(t, int64(2), rollups[0].ActiveUsers)
+}
+
+func TestUsageMeter_RetriesAmbiguousFlushWithoutCountingTwice(t *testing.T) {
+	// Given a flush that is applied although the meter sees it fail
+	store := newFakeUsageStore()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	meter := setupTestUsageMeter(t, store, &now)
+	ctx := context.Background()
+
+	meter.Record("acme", "", 1)
+	meter.Record("acme", "", 1)
+	store.flushErr = errors.New("connection reset")
+	store.committed = true
+	require.Error(t, meter.Flush(ctx))
+
+	// When it is retried along with a new call
+	meter.Record("acme", "", 1)
+	require.NoError(t, meter.Flush(ctx))
+
+	// Then the retried batch is not counted again
+	rollups, err := store.List(ctx, "acme", now, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, rollups, 1)
+	assert.Equal(t, int64(3), rollups[0].APICalls)
+	assert.Len(t, store.batches, 2)
+}
+
+func TestUsageMeter_CountsActiveUsersOnceAcrossInstances(t *testing.T) {
+	// Given two instances serving the same tenant and user
+	store := newFakeUsageStore()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	first := setupTestUsageMeter(t, store, &now)
+	second := setupTestUsageMeter(t, store, &now)
+	ctx := context.Background()
+
+	first.Record("acme", "", 1)
+	second.Record("acme", "", 1)
+	second.Record("acme", "", 2)
+
+	// When both flush
+	require.NoError(t, first.Flush(ctx))
+	require.NoError(t, second.Flush(ctx))
+
+	// Then calls add up and users stay distinct
+	rollups, err := store.List(ctx, "acme", now, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, rollups, 1)
+	assert.Equal(t, int64(3), rollups[0].APICalls)
+	assert.Equal(t, int64(2), rollups[0].ActiveUsers)
+}
+
+func TestUsageMeter_KeepsUsersOfEachRegionApart(t *testing.T) {
+	// Given calls to users of different regions sharing an ID
+	store := newFakeUsageStore()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	meter := setupTestUsageMeter(t, store, &now)
+	ctx := context.Background()
+
+	meter.Record("acme", entities.ResidencyEU, 1)
+	meter.Record("acme", entities.ResidencyUS, 1)
+	meter.Record("acme", entities.ResidencyUS, 1)
+
+	// When
+	require.NoError(t, meter.Flush(ctx))
+
+	// Then each region's user counts as active
+	rollups, err := store.List(ctx, "acme", now, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, rollups, 1)
+	assert.Equal(t, int64(2), rollups[0].ActiveUsers)
+}
+
+func TestUsageMeter_StopFlushesRemainingUsage(t *testing.T) {
+	// Given a started meter with unflushed calls
+	store := newFakeUsageStore()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	meter := setupTestUsageMeter(t, store, &now)
+	meter.Start()
+	meter.Record("acme", "", 1)
+
+	// When
+	err := meter.Stop(context.Background())
+
+	// Then
+	require.NoError(t, err)
+	rollups, err := store.List(context.Background(), "acme", now, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, rollups, 1)
+	assert.Equal(t, int64(1), rollups[0].APICalls)
+}
+
+func setupTestUsageExportJob(store *fakeUsageStore, now *time.Time) (*UsageExportJob, *fakeUsageExporter) {
+	exporter := &fakeUsageExporter{files: make(map[string]string)}
+	job := NewUsageExportJob(store, exporter, 15*time.Minute, logger.New("test"))
+	job.now = func() time.Time { return *now }
+	return job, exporter
+}
+
+// flushUsage flushes calls of tenant to userIDs at hour through a fresh meter
+func flushUsage(t *testing.T, store *fakeUsageStore, tenant string, hour time.Time, userIDs ...uint) {
+	t.Helper()
+	meter := setupTestUsageMeter(t, store, &hour)
+	for _, userID := range userIDs {
+		meter.Record(tenant, "", userID)
+	}
+	require.NoError(t, meter.Flush(context.Background()))
+}
+
+func TestUsageExportJob_ExportsClosedHoursOnly(t *testing.T) {
+	// Given usage in an hour past the export delay and in one that is not
+	store := newFakeUsageStore()
+	flushUsage(t, store, "acme", time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC), 1, 2, 2)
+	flushUsage(t, store, "globex", time.Date(2024, 5, 1, 10, 45, 0, 0, time.UTC), 0)
+	flushUsage(t, store, "acme", time.Date(2024, 5, 1, 11, 5, 0, 0, time.UTC), 1)
+	now := time.Date(2024, 5, 1, 12, 10, 0, 0, time.UTC)
+	job, exporter := setupTestUsageExportJob(store, &now)
+
+	// When
+	err := job.Run(context.Background())
+
+	// Then only the closed hour is exported, one row per tenant
+	require.NoError(t, err)
+	require.Len(t, exporter.files, 1)
+	assert.Equal(t, "tenant,hour_start,hour_end,api_calls,active_users,version\n"+
+		"acme,2024-05-01T10:00:00Z,2024-05-01T11:00:00Z,3,2,1\n"+
+		"globex,2024-05-01T10:00:00Z,2024-05-01T11:00:00Z,1,0,1\n",
+		exporter.files["usage-20240501T10Z-v1.csv"])
+
+	// And it is not exported again while unchanged
+	require.NoError(t, job.Run(context.Background()))
+	assert.Len(t, exporter.files, 1)
+}
+
+func TestUsageExportJob_ReexportsChangedHourWithNextVersion(t *testing.T) {
+	// Given an exported hour
+	store := newFakeUsageStore()
+	hour := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	flushUsage(t, store, "acme", hour, 1)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	job, exporter := setupTestUsageExportJob(store, &now)
+	require.NoError(t, job.Run(context.Background()))
+
+	// When a late flush changes it
+	flushUsage(t, store, "acme", hour, 2)
+	err := job.Run(context.Background())
+
+	// Then the corrected hour is exported as the next version
+	require.NoError(t, err)
+	assert.Contains(t, exporter.files["usage-20240501T10Z-v1.csv"], "acme,2024-05-01T10:00:00Z,2024-05-01T11:00:00Z,1,1,1")
+	assert.Contains(t, exporter.files["usage-20240501T10Z-v2.csv"], "acme,2024-05-01T10:00:00Z,2024-05-01T11:00:00Z,2,2,2")
+}
+
+func TestUsageExportJob_RetriesUndeliveredExport(t *testing.T) {
+	// Given a destination that is down
+	store := newFakeUsageStore()
+	flushUsage(t, store, "acme", time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), 1)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	job, exporter := setupTestUsageExportJob(store, &now)
+	exporter.err = errors.New("bucket unavailable")
+	require.ErrorIs(t, job.Run(context.Background()), domainErrors.ErrFailedToExportUsage)
+
+	// When it is back
+	exporter.err = nil
+	err := job.Run(context.Background())
+
+	// Then the hour is delivered under its first version
+	require.NoError(t, err)
+	assert.Contains(t, exporter.files, "usage-20240501T10Z-v1.csv")
+}
+
+func TestUsageUseCases_GetUsage_SummarizesTenants(t *testing.T) {
+	// Given usage of two tenants over two hours
+	store := newFakeUsageStore()
+	flushUsage(t, store, "acme", time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), 1, 2)
+	flushUsage(t, store, "acme", time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC), 2, 3, 0)
+	flushUsage(t, store, "globex", time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC), 1)
+	useCases := NewUsageUseCases(store, logger.New("test"))
+
+	// When asked for a range that is widened to whole hours
+	response, err := useCases.GetUsage(context.Background(), "", time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC), time.Date(2024, 5, 1, 11, 30, 0, 0, time.UTC))
+
+	// Then calls add up and active users are distinct over the range
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), response.From.Time)
+	assert.Equal(t, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), response.To.Time)
+	require.Len(t, response.Tenants, 2)
+	acme := response.Tenants[0]
+	assert.Equal(t, "acme", acme.Tenant)
+	assert.Equal(t, int64(5), acme.APICalls)
+	assert.Equal(t, int64(3), acme.ActiveUsers)
+	require.Len(t, acme.Hours, 2)
+	assert.Equal(t, int64(2), acme.Hours[0].ActiveUsers)
+	assert.Equal(t, int64(2), acme.Hours[1].ActiveUsers)
+	assert.Equal(t, "globex", response.Tenants[1].Tenant)
+}
+
+func TestUsageUseCases_GetUsage_DefaultsToLastDay(t *testing.T) {
+	// Given
+	useCases := NewUsageUseCases(newFakeUsageStore(), logger.New("test"))
+	now := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	useCases.(*usageUseCasesImpl).now = func() time.Time { return now }
+
+	// When
+	response, err := useCases.GetUsage(context.Background(), "acme", time.Time{}, time.Time{})
+
+	// Then the current hour is included
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 4, 30, 12, 0, 0, 0, time.UTC), response.From.Time)
+	assert.Equal(t, time.Date(2024, 5, 1, 13, 0, 0, 0, time.UTC), response.To.Time)
+	assert.Empty(t, response.Tenants)
+}
+
+func TestUsageUseCases_GetUsage_RejectsInvalidRanges(t *testing.T) {
+	useCases := NewUsageUseCases(newFakeUsageStore(), logger.New("test"))
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	for name, end := range map[string]time.Time{
+		"reversed":  start.Add(-time.Hour),
+		"too long":  start.Add(32 * 24 * time.Hour),
+		"same hour": start,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := useCases.GetUsage(context.Background(), "", start, end)
+
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidUsageRange)
+		})
+	}
+}
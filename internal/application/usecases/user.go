@@ -5,10 +5,14 @@ import (
 	"context"
 	"errors"
 	"net/mail"
+	"strings"
+	"time"
 	"user-service/internal/application/dto"
 	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
 	userErrors "user-service/internal/domain/errors"
 	"user-service/pkg/logger"
+	"user-service/pkg/pagination"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -18,44 +22,102 @@ type UserUseCases interface {
 	CreateUser(ctx context.Context, request *dto.CreateUserRequestDTO) (*dto.UserResponseDTO, error)
 	GetUserByID(ctx context.Context, id uint) (*dto.UserResponseDTO, error)
 	GetUserByEmail(ctx context.Context, email string) (*dto.UserResponseDTO, error)
-	ListUsers(ctx context.Context, page, pageSize int) (*dto.UserListResponseDTO, error)
+	GetUserByExternalID(ctx context.Context, source, externalID string) (*dto.UserResponseDTO, error)
+	ListUsers(ctx context.Context, filter dto.UserFilterDTO, page, pageSize int) (*dto.UserListResponseDTO, error)
+	AddUserTags(ctx context.Context, id uint, tags []string) (*dto.UserResponseDTO, error)
+	RemoveUserTags(ctx context.Context, id uint, tags []string) (*dto.UserResponseDTO, error)
+	UpdatePreferences(ctx context.Context, id uint, request *dto.UpdatePreferencesRequestDTO) (*dto.UserResponseDTO, error)
+	UpdateProfile(ctx context.Context, id uint, request *dto.UpdateProfileRequestDTO) (*dto.UserResponseDTO, error)
 }
 
 // userUseCasesImpl implements UserUseCases interface
 type userUseCasesImpl struct {
-	userRepo ports.UserRepository
+	userRepo  ports.UserRepository
+	publisher ports.EventPublisher
+	// defaults are the locale and time zone of users who choose none
+	defaults entities.Localization
+	// ageGates hold back new users younger than their region allows
+	ageGates entities.AgeGates
+	// required are the profile fields new users must have
+	required entities.RequiredProfileFields
 	logger   logger.Logger
 }
 
 // NewUserUseCases creates a new instance of user use cases
-func NewUserUseCases(userRepo ports.UserRepository, log logger.Logger) UserUseCases {
+func NewUserUseCases(userRepo ports.UserRepository, publisher ports.EventPublisher, defaults entities.Localization, ageGates entities.AgeGates, required entities.RequiredProfileFields, log logger.Logger) UserUseCases {
 	return &userUseCasesImpl{
-		userRepo: userRepo,
-		logger:   log.With("component", "user_usecases"),
+		userRepo:  userRepo,
+		publisher: publisher,
+		defaults:  defaults,
+		ageGates:  ageGates,
+		required:  required,
+		logger:    log.With("component", "user_usecases"),
 	}
 }
 
 func (uc *userUseCasesImpl) CreateUser(ctx context.Context, request *dto.CreateUserRequestDTO) (*dto.UserResponseDTO, error) {
-	uc.logger.Info("CreateUser use case called", "email", request.Email)
-	if _, err := mail.ParseAddress(request.Email); err != nil {
-		return nil, userErrors.ErrInvalidUserEmail
+	uc.logger.Info("CreateUser use case called", "email", logger.MaskEmail(request.Email))
+
+	domainEntity, awaitingConsent, err := uc.prepareUser(ctx, request)
+	if err != nil {
+		return nil, err
 	}
+	return uc.createUser(ctx, domainEntity, awaitingConsent, request.ReferralCode)
+}
 
-	if _, err := uc.userRepo.ExistsByEmail(ctx, request.Email); err != nil {
-		return nil, userErrors.ErrUserAlreadyExists
+// prepareUser validates a sign-up and builds its user with the password
+// hashed, without touching the database. It reports whether the age gate
+// holds the user for guardian consent.
+func (uc *userUseCasesImpl) prepareUser(ctx context.Context, request *dto.CreateUserRequestDTO) (*entities.User, bool, error) {
+	if _, err := mail.ParseAddress(request.Email); err != nil {
+		return nil, false, userErrors.ErrInvalidUserEmail
 	}
 
 	domainEntity, err := request.ToEntity()
 
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+	if err := uc.required.Check(domainEntity); err != nil {
+		return nil, false, err
+	}
+	domainEntity.Preferences.DefaultTo(uc.defaults)
+
+	awaitingConsent, err := admitByAge(ctx, uc.ageGates, domainEntity, time.Now())
+	if err != nil {
+		uc.logger.Info("CreateUser rejected by age gate", "email", logger.MaskEmail(request.Email))
+		return nil, false, err
 	}
 
 	domainEntity.Password, err = hashPassword(domainEntity.Password)
 
+	if err != nil {
+		return nil, false, err
+	}
+
+	return domainEntity, awaitingConsent, nil
+}
+
+// createUser stores a prepared user, attributed to the owner of referralCode
+// when one is given
+func (uc *userUseCasesImpl) createUser(ctx context.Context, domainEntity *entities.User, awaitingConsent bool, referralCode string) (*dto.UserResponseDTO, error) {
+	// A failed check is not a duplicate; queued sign-ups retry on it
+	exists, err := uc.userRepo.ExistsByEmail(ctx, domainEntity.Email)
 	if err != nil {
 		return nil, err
 	}
+	if exists {
+		return nil, userErrors.ErrUserAlreadyExists
+	}
+
+	var referrer *entities.User
+	if referralCode != "" {
+		var err error
+		if referrer, err = referUser(ctx, uc.userRepo, domainEntity, referralCode); err != nil {
+			uc.logger.Info("CreateUser rejected referral", "email", logger.MaskEmail(domainEntity.Email), "error", err)
+			return nil, err
+		}
+	}
 
 	createUser, err := uc.userRepo.Create(ctx, domainEntity)
 
@@ -63,13 +125,24 @@ func (uc *userUseCasesImpl) CreateUser(ctx context.Context, request *dto.CreateU
 		switch {
 		case errors.Is(err, userErrors.ErrFailedToCheckUserExistance):
 			return nil, userErrors.ErrFailedToCheckUserExistance
+		case errors.Is(err, userErrors.ErrUserAlreadyExists):
+			return nil, userErrors.ErrUserAlreadyExists
+		case errors.Is(err, userErrors.ErrExternalIDTaken):
+			return nil, userErrors.ErrExternalIDTaken
 		default:
 			return nil, userErrors.ErrFailedToCreateUser
 
 		}
 	}
 
-	uc.logger.Info("CreateUser success", "email", request.Email)
+	if awaitingConsent {
+		requestGuardianConsent(ctx, uc.publisher, uc.logger, createUser)
+	}
+	if referrer != nil {
+		publishReferral(ctx, uc.publisher, uc.logger, createUser, referrer)
+	}
+
+	uc.logger.Info("CreateUser success", "email", logger.MaskEmail(domainEntity.Email), "awaiting_guardian_consent", awaitingConsent)
 
 	return dto.UserToResponseDTO(createUser), nil
 }
@@ -88,7 +161,7 @@ func (uc *userUseCasesImpl) GetUserByID(ctx context.Context, id uint) (*dto.User
 
 // GetUserByEmail retrieves a user by their email address
 func (uc *userUseCasesImpl) GetUserByEmail(ctx context.Context, email string) (*dto.UserResponseDTO, error) {
-	uc.logger.Info("GetUserByEmail use case called", "email", email)
+	uc.logger.Info("GetUserByEmail use case called", "email", logger.MaskEmail(email))
 
 	user, err := uc.userRepo.GetByEmail(ctx, email)
 
@@ -99,20 +172,61 @@ func (uc *userUseCasesImpl) GetUserByEmail(ctx context.Context, email string) (*
 	return dto.UserToResponseDTO(user), nil
 }
 
+// GetUserByExternalID retrieves the user a source system knows by externalID
+func (uc *userUseCasesImpl) GetUserByExternalID(ctx context.Context, source, externalID string) (*dto.UserResponseDTO, error) {
+	uc.logger.Info("GetUserByExternalID use case called", "source", source)
+
+	user, err := uc.userRepo.GetByExternalID(ctx, strings.ToLower(source), externalID)
+	if err != nil {
+		return nil, err
+	}
+	uc.logger.Info("GetUserByExternalID success", "user_id", user.ID, "source", source)
+	return dto.UserToResponseDTO(user), nil
+}
+
+// userListLimits are the page limits of user listings; the quota middleware
+// caps page sizes per caller
+var userListLimits = pagination.Limits{DefaultSize: 10}
+
 // ListUsers retrieves a paginated list of users
-func (uc *userUseCasesImpl) ListUsers(ctx context.Context, page, pageSize int) (*dto.UserListResponseDTO, error) {
-	uc.logger.Info("ListUsers use case called", "page", page, "page_size", pageSize)
+func (uc *userUseCasesImpl) ListUsers(ctx context.Context, filter dto.UserFilterDTO, page, pageSize int) (*dto.UserListResponseDTO, error) {
+	uc.logger.Info("ListUsers use case called", "page", page, "page_size", pageSize, "tags", filter.Tags, "email", logger.MaskEmail(filter.Email))
 
-	if page < 0 {
-		page = 0
+	tags, err := entities.NormalizeTags(filter.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	page, pageSize = userListLimits.Page(page, pageSize)
+
+	// Emails are stored lower-cased, see entities.NewUser
+	email := strings.ToLower(strings.TrimSpace(filter.Email))
+
+	var country string
+	if filter.Country != "" {
+		match, err := entities.LookupCountry(filter.Country)
+		if err != nil {
+			return nil, err
+		}
+		country = match.Code
 	}
 
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
+	// Listings continued from a cursor start right after it
+	offset := pagination.Offset(page, pageSize)
+	if filter.AfterID != 0 {
+		offset = 0
 	}
 
-	users, err := uc.userRepo.List(ctx, pageSize, page)
+	matching := ports.UserFilter{Tags: tags, Email: email, Country: country}
+	listed := matching
+	listed.AfterID = filter.AfterID
+	users, err := uc.userRepo.List(ctx, listed, pageSize, offset)
+	if err != nil {
+		return nil, err
+	}
 
+	// The total covers the whole listing, not what is left after a cursor
+	total, err := uc.userRepo.Count(ctx, matching)
 	if err != nil {
 		return nil, err
 	}
@@ -125,10 +239,181 @@ func (uc *userUseCasesImpl) ListUsers(ctx context.Context, page, pageSize int) (
 		Users:    response,
 		Page:     page,
 		PageSize: pageSize,
-		Total:    len(users),
+		Total:    int(total),
 	}, nil
 }
 
+// AddUserTags attaches tags to a user and emits a tag change event when something changed
+func (uc *userUseCasesImpl) AddUserTags(ctx context.Context, id uint, tags []string) (*dto.UserResponseDTO, error) {
+	uc.logger.Info("AddUserTags use case called", "user_id", id, "tags", tags)
+
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	added, err := user.AddTags(tags...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(added) > 0 {
+		if err := uc.userRepo.AddTags(ctx, user.ID, added); err != nil {
+			return nil, err
+		}
+		uc.publishTagsChanged(ctx, user, added, nil)
+	}
+
+	uc.logger.Info("AddUserTags success", "user_id", id, "added", added)
+	return dto.UserToResponseDTO(user), nil
+}
+
+// RemoveUserTags detaches tags from a user and emits a tag change event when something changed
+func (uc *userUseCasesImpl) RemoveUserTags(ctx context.Context, id uint, tags []string) (*dto.UserResponseDTO, error) {
+	uc.logger.Info("RemoveUserTags use case called", "user_id", id, "tags", tags)
+
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := user.RemoveTags(tags...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(removed) > 0 {
+		if err := uc.userRepo.RemoveTags(ctx, user.ID, removed); err != nil {
+			return nil, err
+		}
+		uc.publishTagsChanged(ctx, user, nil, removed)
+	}
+
+	uc.logger.Info("RemoveUserTags success", "user_id", id, "removed", removed)
+	return dto.UserToResponseDTO(user), nil
+}
+
+// UpdatePreferences applies the preferences present in the request and emits
+// a preference change event when something changed
+func (uc *userUseCasesImpl) UpdatePreferences(ctx context.Context, id uint, request *dto.UpdatePreferencesRequestDTO) (*dto.UserResponseDTO, error) {
+	uc.logger.Info("UpdatePreferences use case called", "user_id", id)
+
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := user.Preferences
+	if request.SecurityDigestOptOut != nil {
+		preferences.SecurityDigestOptOut = *request.SecurityDigestOptOut
+	}
+	if request.Locale != nil || request.Timezone != nil {
+		if err := preferences.Localize(stringOrEmpty(request.Locale), stringOrEmpty(request.Timezone)); err != nil {
+			return nil, err
+		}
+	}
+	if request.DisplayNameVisibility != nil || request.PronounsVisibility != nil {
+		if err := preferences.Visibility.SetVisibility(stringOrEmpty(request.DisplayNameVisibility), stringOrEmpty(request.PronounsVisibility)); err != nil {
+			return nil, err
+		}
+	}
+	if preferences == user.Preferences {
+		return dto.UserToResponseDTO(user), nil
+	}
+
+	if err := uc.userRepo.UpdatePreferences(ctx, user.ID, preferences); err != nil {
+		return nil, err
+	}
+	user.Preferences = preferences
+
+	event := entities.NewUserEvent(entities.UserEventPreferencesChanged, user.ID, map[string]interface{}{
+		"preferences": preferences,
+	}).About(user)
+	if err := uc.publisher.Publish(ctx, event); err != nil {
+		uc.logger.Error("Failed to publish preference change event", "user_id", user.ID, "error", err)
+	}
+
+	uc.logger.Info("UpdatePreferences success", "user_id", id)
+	return dto.UserToResponseDTO(user), nil
+}
+
+// UpdateProfile applies the profile fields present in the request and emits
+// an update event naming the changed fields when something changed. The
+// values stay out of the event, whose consumers do not filter by visibility.
+func (uc *userUseCasesImpl) UpdateProfile(ctx context.Context, id uint, request *dto.UpdateProfileRequestDTO) (*dto.UserResponseDTO, error) {
+	uc.logger.Info("UpdateProfile use case called", "user_id", id)
+
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	displayName, pronouns := user.DisplayName, user.Pronouns
+	if request.DisplayName != nil {
+		displayName = *request.DisplayName
+	}
+	if request.Pronouns != nil {
+		pronouns = *request.Pronouns
+	}
+
+	updated := *user
+	if err := updated.SetProfile(displayName, pronouns); err != nil {
+		return nil, err
+	}
+	if request.Country != nil {
+		if err := updated.SetCountry(*request.Country); err != nil {
+			return nil, err
+		}
+	}
+
+	var changes []string
+	if updated.DisplayName != user.DisplayName {
+		changes = append(changes, "display_name")
+	}
+	if updated.Pronouns != user.Pronouns {
+		changes = append(changes, "pronouns")
+	}
+	if updated.Country != user.Country {
+		changes = append(changes, "country")
+	}
+	if len(changes) == 0 {
+		return dto.UserToResponseDTO(user), nil
+	}
+
+	if err := uc.userRepo.UpdateProfile(ctx, &updated); err != nil {
+		return nil, err
+	}
+
+	event := entities.NewUserEvent(entities.UserEventUpdated, user.ID, map[string]interface{}{
+		"changes": changes,
+	}).About(&updated)
+	if err := uc.publisher.Publish(ctx, event); err != nil {
+		uc.logger.Error("Failed to publish profile update event", "user_id", user.ID, "error", err)
+	}
+
+	uc.logger.Info("UpdateProfile success", "user_id", id, "changes", changes)
+	return dto.UserToResponseDTO(&updated), nil
+}
+
+func (uc *userUseCasesImpl) publishTagsChanged(ctx context.Context, user *entities.User, added, removed []string) {
+	event := entities.NewUserEvent(entities.UserEventTagsChanged, user.ID, map[string]interface{}{
+		"added":   added,
+		"removed": removed,
+		"tags":    user.Tags,
+	}).About(user)
+
+	if err := uc.publisher.Publish(ctx, event); err != nil {
+		uc.logger.Error("Failed to publish tag change event", "user_id", user.ID, "error", err)
+	}
+}
+
+func stringOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
 // hashPassword hashes a plain text password using bcrypt
 func hashPassword(password string) (string, error) {
 	hashInBytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
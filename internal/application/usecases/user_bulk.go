@@ -0,0 +1,262 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	userErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+)
+
+// BulkOptions bounds the work done by a bulk import
+type BulkOptions struct {
+	MaxItems    int
+	Concurrency int
+	BatchSize   int
+	MaxInFlight int
+	// Defaults are the locale and time zone of users who choose none
+	Defaults entities.Localization
+	// AgeGates hold back users younger than their region allows
+	AgeGates entities.AgeGates
+	// Required are the profile fields new users must have
+	Required entities.RequiredProfileFields
+}
+
+// BulkUserUseCases defines the interface for bulk user operations
+type BulkUserUseCases interface {
+	BulkCreateUsers(ctx context.Context, requests []dto.CreateUserRequestDTO) (*dto.BulkCreateUsersResponseDTO, error)
+}
+
+// bulkUserUseCasesImpl implements BulkUserUseCases interface
+type bulkUserUseCasesImpl struct {
+	userRepo  ports.UserRepository
+	publisher ports.EventPublisher
+	options   BulkOptions
+	inFlight  chan struct{}
+	logger    logger.Logger
+}
+
+// NewBulkUserUseCases creates a new instance of bulk user use cases
+func NewBulkUserUseCases(userRepo ports.UserRepository, publisher ports.EventPublisher, options BulkOptions, log logger.Logger) BulkUserUseCases {
+	options.Concurrency = max(options.Concurrency, 1)
+	options.BatchSize = max(options.BatchSize, 1)
+	options.MaxInFlight = max(options.MaxInFlight, 1)
+
+	return &bulkUserUseCasesImpl{
+		userRepo:  userRepo,
+		publisher: publisher,
+		options:   options,
+		inFlight:  make(chan struct{}, options.MaxInFlight),
+		logger:    log.With("component", "bulk_user_usecases"),
+	}
+}
+
+// BulkCreateUsers creates every valid user and reports a result per item, in
+// request order. Items are validated and hashed with bounded concurrency and
+// inserted in batches; one event is published for the whole import.
+func (uc *bulkUserUseCasesImpl) BulkCreateUsers(ctx context.Context, requests []dto.CreateUserRequestDTO) (*dto.BulkCreateUsersResponseDTO, error) {
+	uc.logger.Info("BulkCreateUsers use case called", "items", len(requests))
+
+	if uc.options.MaxItems > 0 && len(requests) > uc.options.MaxItems {
+		return nil, userErrors.ErrTooManyBulkItems
+	}
+
+	// Shed load instead of queueing when imports are already saturating the service
+	select {
+	case uc.inFlight <- struct{}{}:
+		defer func() { <-uc.inFlight }()
+	default:
+		return nil, userErrors.ErrBulkCapacityExceeded
+	}
+
+	results := make([]dto.BulkItemResultDTO, len(requests))
+	awaitingConsent := make([]bool, len(requests))
+	users := uc.prepareUsers(ctx, requests, results, awaitingConsent)
+
+	pending, err := uc.skipExisting(ctx, users, results)
+	if err != nil {
+		return nil, err
+	}
+
+	created := uc.insert(ctx, pending, users, results)
+
+	response := &dto.BulkCreateUsersResponseDTO{Results: results}
+	createdIDs := make([]uint, 0, len(created))
+	for _, result := range results {
+		if result.Status == dto.BulkItemCreated {
+			response.Created++
+			createdIDs = append(createdIDs, result.User.ID)
+		} else {
+			response.Failed++
+		}
+	}
+
+	if len(createdIDs) > 0 {
+		event := entities.NewUserEvent(entities.UserEventBulkCreated, 0, map[string]interface{}{
+			"user_ids": createdIDs,
+			"count":    len(createdIDs),
+		})
+		if err := uc.publisher.Publish(ctx, event); err != nil {
+			uc.logger.Error("Failed to publish bulk created event", "count", len(createdIDs), "error", err)
+		}
+	}
+
+	for i, result := range results {
+		if result.Status == dto.BulkItemCreated && awaitingConsent[i] {
+			user := *users[i]
+			user.ID = result.User.ID
+			requestGuardianConsent(ctx, uc.publisher, uc.logger, &user)
+		}
+	}
+
+	uc.logger.Info("BulkCreateUsers success", "created", response.Created, "failed", response.Failed)
+	return response, nil
+}
+
+// prepareUsers validates, age gates and hashes items concurrently. Failed items
+// get their result filled in and a nil entry in the returned slice; items the
+// age gate made pending are marked in awaitingConsent.
+func (uc *bulkUserUseCasesImpl) prepareUsers(ctx context.Context, requests []dto.CreateUserRequestDTO, results []dto.BulkItemResultDTO, awaitingConsent []bool) []*entities.User {
+	users := make([]*entities.User, len(requests))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(uc.options.Concurrency, len(requests)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				user, err := requests[i].ToEntity()
+				if err == nil {
+					err = uc.options.Required.Check(user)
+				}
+				if err == nil {
+					user.Preferences.DefaultTo(uc.options.Defaults)
+					awaitingConsent[i], err = admitByAge(ctx, uc.options.AgeGates, user, time.Now())
+				}
+				if err == nil {
+					user.Password, err = hashPassword(user.Password)
+				}
+				if err != nil {
+					results[i] = bulkFailure(i, err)
+					continue
+				}
+				users[i] = user
+			}
+		}()
+	}
+
+	for i := range requests {
+		if ctx.Err() != nil {
+			results[i] = bulkFailure(i, ctx.Err())
+			continue
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return users
+}
+
+// skipExisting marks items whose email is repeated in the request or already
+// registered, returning the indexes still to be inserted
+func (uc *bulkUserUseCasesImpl) skipExisting(ctx context.Context, users []*entities.User, results []dto.BulkItemResultDTO) ([]int, error) {
+	seen := make(map[string]bool, len(users))
+	var pending []int
+	var emails []string
+
+	for i, user := range users {
+		if user == nil {
+			continue
+		}
+		if seen[user.Email] {
+			results[i] = bulkFailure(i, userErrors.ErrUserAlreadyExists)
+			continue
+		}
+		seen[user.Email] = true
+		pending = append(pending, i)
+		emails = append(emails, user.Email)
+	}
+
+	if len(emails) == 0 {
+		return nil, nil
+	}
+
+	existing, err := uc.userRepo.FindExistingEmails(ctx, emails)
+	if err != nil {
+		return nil, err
+	}
+
+	registered := make(map[string]bool, len(existing))
+	for _, email := range existing {
+		registered[email] = true
+	}
+
+	remaining := pending[:0]
+	for _, i := range pending {
+		if registered[users[i].Email] {
+			results[i] = bulkFailure(i, userErrors.ErrUserAlreadyExists)
+			continue
+		}
+		remaining = append(remaining, i)
+	}
+
+	return remaining, nil
+}
+
+// insert creates the pending users in batches. When a batch insert fails, for
+// example because a concurrent request registered one of the emails, users are
+// created one by one so each item still gets its own outcome.
+func (uc *bulkUserUseCasesImpl) insert(ctx context.Context, pending []int, users []*entities.User, results []dto.BulkItemResultDTO) []*entities.User {
+	if len(pending) == 0 {
+		return nil
+	}
+
+	batch := make([]*entities.User, 0, len(pending))
+	for _, i := range pending {
+		batch = append(batch, users[i])
+	}
+
+	created, err := uc.userRepo.CreateBatch(ctx, batch, uc.options.BatchSize)
+	if err == nil && len(created) == len(pending) {
+		for j, i := range pending {
+			results[i] = dto.BulkItemResultDTO{Index: i, Status: dto.BulkItemCreated, User: dto.UserToResponseDTO(created[j])}
+		}
+		return created
+	}
+
+	uc.logger.Warn("Batch insert failed, creating users individually", "items", len(pending), "error", err)
+
+	created = nil
+	for _, i := range pending {
+		user, err := uc.userRepo.Create(ctx, users[i])
+		if err != nil {
+			var domainErr *userErrors.DomainError
+			if !errors.As(err, &domainErr) {
+				err = userErrors.ErrFailedToCreateUser
+			}
+			results[i] = bulkFailure(i, err)
+			continue
+		}
+		results[i] = dto.BulkItemResultDTO{Index: i, Status: dto.BulkItemCreated, User: dto.UserToResponseDTO(user)}
+		created = append(created, user)
+	}
+
+	return created
+}
+
+func bulkFailure(index int, err error) dto.BulkItemResultDTO {
+	var domainErr *userErrors.DomainError
+	if errors.As(err, &domainErr) {
+		return dto.BulkItemFailure(index, domainErr.Code, domainErr.Message)
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return dto.BulkItemFailure(index, "CANCELLED", "Request ended before the item was processed")
+	}
+	return dto.BulkItemFailure(index, userErrors.ErrValidationFailed.Code, err.Error())
+}
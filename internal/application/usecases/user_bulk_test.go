@@ -0,0 +1,153 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"user-service/internal/application/dto"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestBulkUseCases(options BulkOptions) (BulkUserUseCases, *MockUserRepository, *MockEventPublisher) {
+	mockRepo := new(MockUserRepository)
+	mockPublisher := new(MockEventPublisher)
+	useCases := NewBulkUserUseCases(mockRepo, mockPublisher, options, logger.New("test"))
+	return useCases, mockRepo, mockPublisher
+}
+
+func bulkRequest(email string) dto.CreateUserRequestDTO {
+	return dto.CreateUserRequestDTO{
+		Email:     email,
+		Password:  "SecurePass123",
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+}
+
+func TestBulkUserUseCases_BulkCreateUsers_PerItemResults(t *testing.T) {
+	// Given
+	useCases, mockRepo, mockPublisher := setupTestBulkUseCases(BulkOptions{MaxItems: 10, Concurrency: 2, BatchSize: 50})
+	ctx := context.Background()
+
+	requests := []dto.CreateUserRequestDTO{
+		bulkRequest("new@example.com"),
+		bulkRequest("taken@example.com"),
+		bulkRequest("not-an-email"),
+		bulkRequest("new@example.com"),
+		bulkRequest("other@example.com"),
+	}
+
+	mockRepo.On("FindExistingEmails", ctx, []string{"new@example.com", "taken@example.com", "other@example.com"}).
+		Return([]string{"taken@example.com"}, nil)
+	mockRepo.On("CreateBatch", ctx, mock.MatchedBy(func(users []*entities.User) bool {
+		return len(users) == 2 && users[0].Email == "new@example.com" && users[1].Email == "other@example.com" &&
+			users[0].Password != "SecurePass123"
+	}), 50).Return([]*entities.User{
+		{ID: 1, Email: "new@example.com"},
+		{ID: 2, Email: "other@example.com"},
+	}, nil)
+	mockPublisher.On("Publish", ctx, mock.MatchedBy(func(event *entities.UserEvent) bool {
+		return event.Type == entities.UserEventBulkCreated && event.Data["count"] == 2
+	})).Return(nil)
+
+	// When
+	result, err := useCases.BulkCreateUsers(ctx, requests)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Created)
+	assert.Equal(t, 3, result.Failed)
+	require.Len(t, result.Results, 5)
+
+	assert.Equal(t, dto.BulkItemCreated, result.Results[0].Status)
+	assert.Equal(t, uint(1), result.Results[0].User.ID)
+	assert.Equal(t, "USER_ALREADY_EXISTS", result.Results[1].Error)
+	assert.Equal(t, "VALIDATION_ERROR", result.Results[2].Error)
+	assert.Equal(t, "USER_ALREADY_EXISTS", result.Results[3].Error)
+	assert.Equal(t, dto.BulkItemCreated, result.Results[4].Status)
+	for i, item := range result.Results {
+		assert.Equal(t, i, item.Index)
+	}
+
+	mockRepo.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+}
+
+func TestBulkUserUseCases_BulkCreateUsers_FallsBackToSingleInserts(t *testing.T) {
+	// Given
+	useCases, mockRepo, mockPublisher := setupTestBulkUseCases(BulkOptions{BatchSize: 50})
+	ctx := context.Background()
+
+	requests := []dto.CreateUserRequestDTO{bulkRequest("first@example.com"), bulkRequest("raced@example.com")}
+
+	mockRepo.On("FindExistingEmails", ctx, mock.Anything).Return([]string{}, nil)
+	mockRepo.On("CreateBatch", ctx, mock.Anything, 50).Return(nil, domainErrors.ErrUserAlreadyExists)
+	mockRepo.On("Create", ctx, mock.MatchedBy(func(user *entities.User) bool {
+		return user.Email == "first@example.com"
+	})).Return(&entities.User{ID: 7, Email: "first@example.com"}, nil)
+	mockRepo.On("Create", ctx, mock.MatchedBy(func(user *entities.User) bool {
+		return user.Email == "raced@example.com"
+	})).Return(nil, domainErrors.ErrUserAlreadyExists)
+	mockPublisher.On("Publish", ctx, mock.Anything).Return(nil)
+
+	// When
+	result, err := useCases.BulkCreateUsers(ctx, requests)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, uint(7), result.Results[0].User.ID)
+	assert.Equal(t, "USER_ALREADY_EXISTS", result.Results[1].Error)
+}
+
+func TestBulkUserUseCases_BulkCreateUsers_TooManyItems(t *testing.T) {
+	// Given
+	useCases, mockRepo, _ := setupTestBulkUseCases(BulkOptions{MaxItems: 1})
+
+	// When
+	result, err := useCases.BulkCreateUsers(context.Background(), []dto.CreateUserRequestDTO{
+		bulkRequest("a@example.com"),
+		bulkRequest("b@example.com"),
+	})
+
+	// Then
+	assert.Nil(t, result)
+	assert.Equal(t, domainErrors.ErrTooManyBulkItems, err)
+	mockRepo.AssertNotCalled(t, "FindExistingEmails", mock.Anything, mock.Anything)
+}
+
+func TestBulkUserUseCases_BulkCreateUsers_ShedsLoadWhenBusy(t *testing.T) {
+	// Given
+	useCases, mockRepo, _ := setupTestBulkUseCases(BulkOptions{MaxInFlight: 1})
+	ctx := context.Background()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mockRepo.On("FindExistingEmails", ctx, mock.Anything).Run(func(mock.Arguments) {
+		close(started)
+		<-release
+	}).Return(nil, errors.New("unavailable"))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = useCases.BulkCreateUsers(ctx, []dto.CreateUserRequestDTO{bulkRequest("a@example.com")})
+	}()
+	<-started
+
+	// When
+	result, err := useCases.BulkCreateUsers(ctx, []dto.CreateUserRequestDTO{bulkRequest("b@example.com")})
+
+	// Then
+	assert.Nil(t, result)
+	assert.Equal(t, domainErrors.ErrBulkCapacityExceeded, err)
+
+	close(release)
+	<-done
+}
@@ -0,0 +1,140 @@
+package usecases
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	userErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+)
+
+// GuardedChecker pairs a deletion checker with its timeout and failure policy
+type GuardedChecker struct {
+	Checker ports.DeletionChecker
+	Timeout time.Duration
+	// FailOpen allows the deletion when the checker errors or times out;
+	// otherwise the failure blocks it
+	FailOpen bool
+}
+
+// UserDeletionUseCases defines the interface for deleting users
+type UserDeletionUseCases interface {
+	DeleteUser(ctx context.Context, id uint, actor string) error
+}
+
+// userDeletionUseCasesImpl implements UserDeletionUseCases interface
+type userDeletionUseCasesImpl struct {
+	userRepo  ports.UserRepository
+	checkers  []GuardedChecker
+	publisher ports.EventPublisher
+	audit     ports.AuditLogger
+	logger    logger.Logger
+}
+
+// NewUserDeletionUseCases creates a new instance of user deletion use cases
+func NewUserDeletionUseCases(userRepo ports.UserRepository, checkers []GuardedChecker, publisher ports.EventPublisher, audit ports.AuditLogger, log logger.Logger) UserDeletionUseCases {
+	return &userDeletionUseCasesImpl{
+		userRepo:  userRepo,
+		checkers:  checkers,
+		publisher: publisher,
+		audit:     audit,
+		logger:    log.With("component", "user_deletion_usecases"),
+	}
+}
+
+// DeleteUser deletes a user once every registered checker has allowed it.
+// Users under a legal hold are never deleted.
+func (uc *userDeletionUseCasesImpl) DeleteUser(ctx context.Context, id uint, actor string) error {
+	uc.logger.Info("DeleteUser use case called", "user_id", id, "actor", actor)
+
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := user.CanTransitionTo(entities.UserStatusDeleted); err != nil {
+		return err
+	}
+
+	if err := user.Erasable(time.Now()); err != nil {
+		uc.logger.Warn("User deletion blocked by legal hold", "user_id", id, "actor", actor)
+		return err
+	}
+
+	if blockers := uc.runCheckers(ctx, user); len(blockers) > 0 {
+		uc.logger.Warn("User deletion blocked", "user_id", id, "blockers", blockers)
+		return &userErrors.DeletionBlockedError{Blockers: blockers}
+	}
+
+	if err := uc.userRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	if err := uc.publisher.Publish(ctx, entities.NewUserEvent(entities.UserEventDeleted, id, nil)); err != nil {
+		uc.logger.Error("Failed to publish user deleted event", "user_id", id, "error", err)
+	}
+
+	uc.audit.Record(ctx, &entities.AuditEvent{
+		Action:       "user.deleted",
+		ActorID:      actor,
+		ResourceType: "user",
+		ResourceID:   strconv.FormatUint(uint64(id), 10),
+	})
+
+	uc.logger.Info("DeleteUser success", "user_id", id)
+	return nil
+}
+
+// runCheckers consults every checker concurrently and collects their blockers
+func (uc *userDeletionUseCasesImpl) runCheckers(ctx context.Context, user *entities.User) []userErrors.DeletionBlocker {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		blockers []userErrors.DeletionBlocker
+	)
+
+	for _, guarded := range uc.checkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			found := uc.runChecker(ctx, guarded, user)
+
+			mu.Lock()
+			blockers = append(blockers, found...)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return blockers
+}
+
+func (uc *userDeletionUseCasesImpl) runChecker(ctx context.Context, guarded GuardedChecker, user *entities.User) []userErrors.DeletionBlocker {
+	name := guarded.Checker.Name()
+
+	if guarded.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, guarded.Timeout)
+		defer cancel()
+	}
+
+	reasons, err := guarded.Checker.CheckDeletion(ctx, user)
+	if err != nil {
+		if guarded.FailOpen {
+			uc.logger.Warn("Deletion checker failed, allowing deletion", "checker", name, "user_id", user.ID, "error", err)
+			return nil
+		}
+		uc.logger.Error("Deletion checker failed, blocking deletion", "checker", name, "user_id", user.ID, "error", err)
+		return []userErrors.DeletionBlocker{{Checker: name, Reason: "checker unavailable"}}
+	}
+
+	blockers := make([]userErrors.DeletionBlocker, 0, len(reasons))
+	for _, reason := range reasons {
+		blockers = append(blockers, userErrors.DeletionBlocker{Checker: name, Reason: reason})
+	}
+	return blockers
+}
@@ -0,0 +1,166 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubDeletionChecker answers deletion checks with fixed reasons or an error
+type stubDeletionChecker struct {
+	name    string
+	reasons []string
+	err     error
+	delay   time.Duration
+}
+
+func (s *stubDeletionChecker) Name() string {
+	return s.name
+}
+
+func (s *stubDeletionChecker) CheckDeletion(ctx context.Context, user *entities.User) ([]string, error) {
+	if s.delay > 0 {
+		select {
+		case <-time.After(s.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return s.reasons, s.err
+}
+
+func setupTestDeletionUseCases(checkers ...GuardedChecker) (UserDeletionUseCases, *MockUserRepository, *MockEventPublisher, *MockAuditLogger) {
+	mockRepo := new(MockUserRepository)
+	mockPublisher := new(MockEventPublisher)
+	mockAudit := new(MockAuditLogger)
+	useCases := NewUserDeletionUseCases(mockRepo, checkers, mockPublisher, mockAudit, logger.New("test"))
+	return useCases, mockRepo, mockPublisher, mockAudit
+}
+
+func TestUserDeletionUseCases_DeleteUser_Success(t *testing.T) {
+	// Given
+	useCases, mockRepo, mockPublisher, mockAudit := setupTestDeletionUseCases(GuardedChecker{
+		Checker: &stubDeletionChecker{name: "billing"},
+	})
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1}, nil)
+	mockRepo.On("Delete", ctx, uint(1)).Return(nil)
+	mockPublisher.On("Publish", ctx, mock.MatchedBy(func(event *entities.UserEvent) bool {
+		return event.Type == entities.UserEventDeleted && event.UserID == 1
+	})).Return(nil)
+	mockAudit.On("Record", ctx, mock.MatchedBy(func(event *entities.AuditEvent) bool {
+		return event.Action == "user.deleted" && event.ActorID == "ops"
+	})).Return()
+
+	// When
+	err := useCases.DeleteUser(ctx, 1, "ops")
+
+	// Then
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestUserDeletionUseCases_DeleteUser_BlockedListsEveryBlocker(t *testing.T) {
+	// Given
+	useCases, mockRepo, _, _ := setupTestDeletionUseCases(
+		GuardedChecker{Checker: &stubDeletionChecker{name: "billing", reasons: []string{"2 open invoices"}}},
+		GuardedChecker{Checker: &stubDeletionChecker{name: "orders", err: errors.New("connection refused")}},
+		GuardedChecker{Checker: &stubDeletionChecker{name: "analytics", err: errors.New("connection refused")}, FailOpen: true},
+	)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1}, nil)
+
+	// When
+	err := useCases.DeleteUser(ctx, 1, "ops")
+
+	// Then
+	var blocked *domainErrors.DeletionBlockedError
+	require.ErrorAs(t, err, &blocked)
+	assert.ErrorIs(t, err, domainErrors.ErrDeleteBlocked)
+	assert.ElementsMatch(t, []domainErrors.DeletionBlocker{
+		{Checker: "billing", Reason: "2 open invoices"},
+		{Checker: "orders", Reason: "checker unavailable"},
+	}, blocked.Blockers)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestUserDeletionUseCases_DeleteUser_CheckerTimeout(t *testing.T) {
+	// Given
+	useCases, mockRepo, _, _ := setupTestDeletionUseCases(GuardedChecker{
+		Checker: &stubDeletionChecker{name: "billing", delay: time.Second},
+		Timeout: 10 * time.Millisecond,
+	})
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1}, nil)
+
+	// When
+	start := time.Now()
+	err := useCases.DeleteUser(ctx, 1, "ops")
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrDeleteBlocked)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestUserDeletionUseCases_DeleteUser_NotFound(t *testing.T) {
+	// Given
+	useCases, mockRepo, _, _ := setupTestDeletionUseCases()
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(9)).Return(nil, domainErrors.ErrUserNotFound)
+
+	// When
+	err := useCases.DeleteUser(ctx, 9, "ops")
+
+	// Then
+	assert.Equal(t, domainErrors.ErrUserNotFound, err)
+}
+
+func TestUserDeletionUseCases_DeleteUser_LegalHold(t *testing.T) {
+	lapsed := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name    string
+		hold    *entities.LegalHold
+		blocked bool
+	}{
+		{name: "active hold", hold: &entities.LegalHold{Reason: "litigation"}, blocked: true},
+		{name: "lapsed hold", hold: &entities.LegalHold{Reason: "litigation", ExpiresAt: &lapsed}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockRepo, mockPublisher, mockAudit := setupTestDeletionUseCases()
+			ctx := context.Background()
+			mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, LegalHold: tt.hold}, nil)
+			mockRepo.On("Delete", ctx, uint(1)).Return(nil).Maybe()
+			mockPublisher.On("Publish", ctx, mock.Anything).Return(nil).Maybe()
+			mockAudit.On("Record", ctx, mock.Anything).Return().Maybe()
+
+			// When
+			err := useCases.DeleteUser(ctx, 1, "ops")
+
+			// Then
+			if !tt.blocked {
+				require.NoError(t, err)
+				mockRepo.AssertCalled(t, "Delete", ctx, uint(1))
+				return
+			}
+			assert.ErrorIs(t, err, domainErrors.ErrLegalHoldActive)
+			mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"time"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	"user-service/pkg/cache"
+	"user-service/pkg/logger"
+)
+
+// ExistenceOptions configures caching of existence checks
+type ExistenceOptions struct {
+	// TTL is how long both positive and negative answers are reused; a user
+	// created or deleted meanwhile is reported stale for at most this long
+	TTL        time.Duration
+	MaxEntries int
+}
+
+// UserExistenceUseCases defines the interface for cheap user existence checks
+type UserExistenceUseCases interface {
+	UserExists(ctx context.Context, id uint) (bool, error)
+	EmailExists(ctx context.Context, email string) (bool, error)
+}
+
+// userExistenceUseCasesImpl implements UserExistenceUseCases interface
+type userExistenceUseCasesImpl struct {
+	userRepo ports.UserRepository
+	byID     *cache.TTL[existenceKey[uint], bool]
+	byEmail  *cache.TTL[existenceKey[string], bool]
+	logger   logger.Logger
+}
+
+// existenceKey keeps answers for different residency regions apart, since a
+// user only exists for requests scoped to the region it is resident in
+type existenceKey[T comparable] struct {
+	region entities.Residency
+	value  T
+}
+
+func newExistenceKey[T comparable](ctx context.Context, value T) existenceKey[T] {
+	region, _ := ports.ResidencyFrom(ctx)
+	return existenceKey[T]{region: region, value: value}
+}
+
+// NewUserExistenceUseCases creates a new instance of user existence use cases
+func NewUserExistenceUseCases(userRepo ports.UserRepository, options ExistenceOptions, log logger.Logger) UserExistenceUseCases {
+	return &userExistenceUseCasesImpl{
+		userRepo: userRepo,
+		byID:     cache.NewTTL[existenceKey[uint], bool](options.TTL, options.MaxEntries),
+		byEmail:  cache.NewTTL[existenceKey[string], bool](options.TTL, options.MaxEntries),
+		logger:   log.With("component", "user_existence_usecases"),
+	}
+}
+
+// UserExists reports whether a user with the given ID exists
+func (uc *userExistenceUseCasesImpl) UserExists(ctx context.Context, id uint) (bool, error) {
+	key := newExistenceKey(ctx, id)
+	if exists, ok := uc.byID.Get(key); ok {
+		return exists, nil
+	}
+
+	exists, err := uc.userRepo.ExistsByID(ctx, id)
+	if err != nil {
+		uc.logger.Error("Failed to check user existence", "user_id", id, "error", err)
+		return false, err
+	}
+
+	uc.byID.Set(key, exists)
+	return exists, nil
+}
+
+// EmailExists reports whether a user with the given email exists
+func (uc *userExistenceUseCasesImpl) EmailExists(ctx context.Context, email string) (bool, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	key := newExistenceKey(ctx, email)
+	if exists, ok := uc.byEmail.Get(key); ok {
+		return exists, nil
+	}
+
+	exists, err := uc.userRepo.ExistsByEmail(ctx, email)
+	if err != nil {
+		uc.logger.Error("Failed to check email existence", "error", err)
+		return false, err
+	}
+
+	uc.byEmail.Set(key, exists)
+	return exists, nil
+}
@@ -0,0 +1,68 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTestExistenceUseCases() (UserExistenceUseCases, *MockUserRepository) {
+	mockRepo := new(MockUserRepository)
+	useCases := NewUserExistenceUseCases(mockRepo, ExistenceOptions{TTL: time.Minute, MaxEntries: 100}, logger.New("test"))
+	return useCases, mockRepo
+}
+
+func TestUserExistenceUseCases_UserExists_CachesAnswer(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestExistenceUseCases()
+	ctx := context.Background()
+	mockRepo.On("ExistsByID", ctx, uint(7)).Return(true, nil).Once()
+
+	// When
+	first, firstErr := useCases.UserExists(ctx, 7)
+	second, secondErr := useCases.UserExists(ctx, 7)
+
+	// Then
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.True(t, first)
+	assert.True(t, second)
+	mockRepo.AssertNumberOfCalls(t, "ExistsByID", 1)
+}
+
+func TestUserExistenceUseCases_EmailExists_CachesNegativeAnswerNormalized(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestExistenceUseCases()
+	ctx := context.Background()
+	mockRepo.On("ExistsByEmail", ctx, "ghost@example.com").Return(false, nil).Once()
+
+	// When
+	first, _ := useCases.EmailExists(ctx, "Ghost@Example.com")
+	second, _ := useCases.EmailExists(ctx, " ghost@example.com ")
+
+	// Then
+	assert.False(t, first)
+	assert.False(t, second)
+	mockRepo.AssertNumberOfCalls(t, "ExistsByEmail", 1)
+}
+
+func TestUserExistenceUseCases_UserExists_DoesNotCacheErrors(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestExistenceUseCases()
+	ctx := context.Background()
+	mockRepo.On("ExistsByID", ctx, uint(7)).Return(false, domainErrors.ErrFailedToCheckUserExistance).Once()
+	mockRepo.On("ExistsByID", ctx, uint(7)).Return(true, nil).Once()
+
+	// When
+	_, err := useCases.UserExists(ctx, 7)
+	exists, retryErr := useCases.UserExists(ctx, 7)
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrFailedToCheckUserExistance)
+	assert.NoError(t, retryErr)
+	assert.True(t, exists)
+}
@@ -0,0 +1,162 @@
+package usecases
+
+import (
+	"context"
+	"strconv"
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	userErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+	"user-service/pkg/pagination"
+)
+
+// UserNoteUseCases defines the interface for support note operations
+type UserNoteUseCases interface {
+	CreateNote(ctx context.Context, userID uint, author string, request *dto.CreateUserNoteRequestDTO) (*dto.UserNoteResponseDTO, error)
+	ListNotes(ctx context.Context, userID uint, viewer string, page, pageSize int) (*dto.UserNoteListResponseDTO, error)
+	UpdateNote(ctx context.Context, userID, noteID uint, editor string, request *dto.UpdateUserNoteRequestDTO) (*dto.UserNoteResponseDTO, error)
+	DeleteNote(ctx context.Context, userID, noteID uint, editor string) error
+}
+
+// userNoteUseCasesImpl implements UserNoteUseCases interface
+type userNoteUseCasesImpl struct {
+	userRepo ports.UserRepository
+	noteRepo ports.UserNoteRepository
+	audit    ports.AuditLogger
+	logger   logger.Logger
+}
+
+// NewUserNoteUseCases creates a new instance of support note use cases
+func NewUserNoteUseCases(userRepo ports.UserRepository, noteRepo ports.UserNoteRepository, audit ports.AuditLogger, log logger.Logger) UserNoteUseCases {
+	return &userNoteUseCasesImpl{
+		userRepo: userRepo,
+		noteRepo: noteRepo,
+		audit:    audit,
+		logger:   log.With("component", "user_note_usecases"),
+	}
+}
+
+// CreateNote adds a support note to an existing user
+func (uc *userNoteUseCasesImpl) CreateNote(ctx context.Context, userID uint, author string, request *dto.CreateUserNoteRequestDTO) (*dto.UserNoteResponseDTO, error) {
+	uc.logger.Info("CreateNote use case called", "user_id", userID, "author", author)
+
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	note, err := entities.NewUserNote(userID, author, request.Text, entities.NoteVisibility(request.Visibility))
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := uc.noteRepo.Create(ctx, note)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.recordAudit(ctx, "user_note.created", author, created)
+
+	uc.logger.Info("CreateNote success", "user_id", userID, "note_id", created.ID)
+	return dto.UserNoteToResponseDTO(created), nil
+}
+
+// ListNotes returns a page of notes on a user visible to the viewer
+func (uc *userNoteUseCasesImpl) ListNotes(ctx context.Context, userID uint, viewer string, page, pageSize int) (*dto.UserNoteListResponseDTO, error) {
+	uc.logger.Info("ListNotes use case called", "user_id", userID, "page", page, "page_size", pageSize)
+
+	page, pageSize = pagination.DefaultLimits.Page(page, pageSize)
+
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	notes, total, err := uc.noteRepo.ListByUser(ctx, userID, viewer, pageSize, pagination.Offset(page, pageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.UserNoteListResponseDTO{
+		Notes:    dto.UserNotesToResponseDTOs(notes),
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// UpdateNote edits a note; only its author may change it
+func (uc *userNoteUseCasesImpl) UpdateNote(ctx context.Context, userID, noteID uint, editor string, request *dto.UpdateUserNoteRequestDTO) (*dto.UserNoteResponseDTO, error) {
+	uc.logger.Info("UpdateNote use case called", "user_id", userID, "note_id", noteID, "editor", editor)
+
+	note, err := uc.noteRepo.GetByID(ctx, userID, noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !note.VisibleTo(editor) {
+		return nil, userErrors.ErrNoteNotFound
+	}
+
+	if note.Author != editor {
+		return nil, userErrors.ErrNoteForbidden
+	}
+
+	visibility := entities.NoteVisibility(request.Visibility)
+	if visibility == "" {
+		visibility = note.Visibility
+	}
+
+	if err := note.Edit(request.Text, visibility); err != nil {
+		return nil, err
+	}
+
+	updated, err := uc.noteRepo.Update(ctx, note)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.recordAudit(ctx, "user_note.updated", editor, updated)
+
+	uc.logger.Info("UpdateNote success", "user_id", userID, "note_id", noteID)
+	return dto.UserNoteToResponseDTO(updated), nil
+}
+
+// DeleteNote removes a note; only its author may delete it
+func (uc *userNoteUseCasesImpl) DeleteNote(ctx context.Context, userID, noteID uint, editor string) error {
+	uc.logger.Info("DeleteNote use case called", "user_id", userID, "note_id", noteID, "editor", editor)
+
+	note, err := uc.noteRepo.GetByID(ctx, userID, noteID)
+	if err != nil {
+		return err
+	}
+
+	if !note.VisibleTo(editor) {
+		return userErrors.ErrNoteNotFound
+	}
+
+	if note.Author != editor {
+		return userErrors.ErrNoteForbidden
+	}
+
+	if err := uc.noteRepo.Delete(ctx, userID, noteID); err != nil {
+		return err
+	}
+
+	uc.recordAudit(ctx, "user_note.deleted", editor, note)
+
+	uc.logger.Info("DeleteNote success", "user_id", userID, "note_id", noteID)
+	return nil
+}
+
+func (uc *userNoteUseCasesImpl) recordAudit(ctx context.Context, action, actor string, note *entities.UserNote) {
+	uc.audit.Record(ctx, &entities.AuditEvent{
+		Action:       action,
+		ActorID:      actor,
+		ResourceType: "user_note",
+		ResourceID:   strconv.FormatUint(uint64(note.ID), 10),
+		Metadata: map[string]interface{}{
+			"user_id":    note.UserID,
+			"visibility": note.Visibility,
+		},
+	})
+}
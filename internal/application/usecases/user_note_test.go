@@ -0,0 +1,173 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"user-service/internal/application/dto"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockUserNoteRepository implements the UserNoteRepository interface for testing
+type MockUserNoteRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserNoteRepository) Create(ctx context.Context, note *entities.UserNote) (*entities.UserNote, error) {
+	args := m.Called(ctx, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.UserNote), args.Error(1)
+}
+
+func (m *MockUserNoteRepository) GetByID(ctx context.Context, userID, noteID uint) (*entities.UserNote, error) {
+	args := m.Called(ctx, userID, noteID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.UserNote), args.Error(1)
+}
+
+func (m *MockUserNoteRepository) ListByUser(ctx context.Context, userID uint, viewer string, limit, offset int) ([]*entities.UserNote, int64, error) {
+	args := m.Called(ctx, userID, viewer, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*entities.UserNote), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockUserNoteRepository) Update(ctx context.Context, note *entities.UserNote) (*entities.UserNote, error) {
+	args := m.Called(ctx, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.UserNote), args.Error(1)
+}
+
+func (m *MockUserNoteRepository) Delete(ctx context.Context, userID, noteID uint) error {
+	args := m.Called(ctx, userID, noteID)
+	return args.Error(0)
+}
+
+// MockAuditLogger implements the AuditLogger interface for testing
+type MockAuditLogger struct {
+	mock.Mock
+}
+
+func (m *MockAuditLogger) Record(ctx context.Context, event *entities.AuditEvent) {
+	m.Called(ctx, event)
+}
+
+func setupTestNoteUseCases() (UserNoteUseCases, *MockUserRepository, *MockUserNoteRepository, *MockAuditLogger) {
+	mockUserRepo := new(MockUserRepository)
+	mockNoteRepo := new(MockUserNoteRepository)
+	mockAudit := new(MockAuditLogger)
+	useCases := NewUserNoteUseCases(mockUserRepo, mockNoteRepo, mockAudit, logger.New("test"))
+	return useCases, mockUserRepo, mockNoteRepo, mockAudit
+}
+
+func TestUserNoteUseCases_CreateNote_Success(t *testing.T) {
+	// Given
+	useCases, mockUserRepo, mockNoteRepo, mockAudit := setupTestNoteUseCases()
+	ctx := context.Background()
+
+	mockUserRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1}, nil)
+	mockNoteRepo.On("Create", ctx, mock.MatchedBy(func(note *entities.UserNote) bool {
+		return note.UserID == 1 &&
+			note.Author == "alice" &&
+			note.Text == "Called about billing" &&
+			note.Visibility == entities.NoteVisibilityInternal
+	})).Return(&entities.UserNote{ID: 7, UserID: 1, Author: "alice", Text: "Called about billing", Visibility: entities.NoteVisibilityInternal}, nil)
+	mockAudit.On("Record", ctx, mock.MatchedBy(func(event *entities.AuditEvent) bool {
+		return event.Action == "user_note.created" && event.ActorID == "alice" && event.ResourceID == "7"
+	})).Return()
+
+	// When
+	result, err := useCases.CreateNote(ctx, 1, "alice", &dto.CreateUserNoteRequestDTO{Text: "  Called about billing  "})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), result.ID)
+	assert.Equal(t, entities.NoteVisibilityInternal, result.Visibility)
+
+	mockUserRepo.AssertExpectations(t)
+	mockNoteRepo.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestUserNoteUseCases_CreateNote_UserNotFound(t *testing.T) {
+	// Given
+	useCases, mockUserRepo, mockNoteRepo, _ := setupTestNoteUseCases()
+	ctx := context.Background()
+
+	mockUserRepo.On("GetByID", ctx, uint(99)).Return(nil, domainErrors.ErrUserNotFound)
+
+	// When
+	result, err := useCases.CreateNote(ctx, 99, "alice", &dto.CreateUserNoteRequestDTO{Text: "hello"})
+
+	// Then
+	assert.Nil(t, result)
+	assert.Equal(t, domainErrors.ErrUserNotFound, err)
+	mockNoteRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestUserNoteUseCases_ListNotes_Pagination(t *testing.T) {
+	// Given
+	useCases, mockUserRepo, mockNoteRepo, _ := setupTestNoteUseCases()
+	ctx := context.Background()
+
+	notes := []*entities.UserNote{{ID: 3, UserID: 1, Author: "bob", Text: "note"}}
+	mockUserRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1}, nil)
+	mockNoteRepo.On("ListByUser", ctx, uint(1), "alice", 5, 5).Return(notes, int64(6), nil)
+
+	// When
+	result, err := useCases.ListNotes(ctx, 1, "alice", 2, 5)
+
+	// Then
+	require.NoError(t, err)
+	assert.Len(t, result.Notes, 1)
+	assert.Equal(t, int64(6), result.Total)
+	assert.Equal(t, 2, result.Page)
+	assert.Equal(t, 5, result.PageSize)
+}
+
+func TestUserNoteUseCases_UpdateNote_OnlyAuthor(t *testing.T) {
+	// Given
+	useCases, _, mockNoteRepo, _ := setupTestNoteUseCases()
+	ctx := context.Background()
+
+	mockNoteRepo.On("GetByID", ctx, uint(1), uint(3)).Return(&entities.UserNote{
+		ID: 3, UserID: 1, Author: "bob", Text: "note", Visibility: entities.NoteVisibilityInternal,
+	}, nil)
+
+	// When
+	result, err := useCases.UpdateNote(ctx, 1, 3, "alice", &dto.UpdateUserNoteRequestDTO{Text: "changed"})
+
+	// Then
+	assert.Nil(t, result)
+	assert.Equal(t, domainErrors.ErrNoteForbidden, err)
+	mockNoteRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUserNoteUseCases_DeleteNote_PrivateNoteHiddenFromOthers(t *testing.T) {
+	// Given
+	useCases, _, mockNoteRepo, _ := setupTestNoteUseCases()
+	ctx := context.Background()
+
+	mockNoteRepo.On("GetByID", ctx, uint(1), uint(3)).Return(&entities.UserNote{
+		ID: 3, UserID: 1, Author: "bob", Text: "note", Visibility: entities.NoteVisibilityPrivate,
+	}, nil)
+
+	// When
+	err := useCases.DeleteNote(ctx, 1, 3, "alice")
+
+	// Then
+	assert.Equal(t, domainErrors.ErrNoteNotFound, err)
+	mockNoteRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
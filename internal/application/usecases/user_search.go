@@ -0,0 +1,127 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+)
+
+// Bounds of admin searches, so a search narrows down to a few accounts rather
+// than browsing them
+const (
+	minSearchEmailChars  = 3
+	minSearchPhoneDigits = 4
+	// MaxUserSearchResults caps the users a search returns
+	MaxUserSearchResults = 20
+)
+
+// UserSearchUseCases defines the interface for support staff finding users
+// by part of their email address or phone number
+type UserSearchUseCases interface {
+	SearchUsers(ctx context.Context, actor string, request *dto.UserSearchRequestDTO) (*dto.UserSearchResponseDTO, error)
+}
+
+// userSearchUseCasesImpl implements UserSearchUseCases interface
+type userSearchUseCasesImpl struct {
+	userRepo ports.UserRepository
+	audit    ports.AuditLogger
+	logger   logger.Logger
+}
+
+// NewUserSearchUseCases creates a new instance of user search use cases
+func NewUserSearchUseCases(userRepo ports.UserRepository, audit ports.AuditLogger, log logger.Logger) UserSearchUseCases {
+	return &userSearchUseCasesImpl{
+		userRepo: userRepo,
+		audit:    audit,
+		logger:   log.With("component", "user_search_usecases"),
+	}
+}
+
+// SearchUsers returns the users whose email address contains the email
+// fragment and whose phone number contains the phone digits, at most
+// MaxUserSearchResults of them with their contact details masked. Every
+// search is audited with its ticket, reason and matches; the query itself is
+// left out of logs and audit events.
+func (uc *userSearchUseCasesImpl) SearchUsers(ctx context.Context, actor string, request *dto.UserSearchRequestDTO) (*dto.UserSearchResponseDTO, error) {
+	uc.logger.Info("SearchUsers use case called", "actor", actor, "ticket_id", request.TicketID)
+
+	email := strings.ToLower(strings.TrimSpace(request.Email))
+	phone := phoneDigits(request.Phone)
+	if email == "" && phone == "" ||
+		email != "" && len(email) < minSearchEmailChars ||
+		request.Phone != "" && len(phone) < minSearchPhoneDigits {
+		return nil, domainErrors.ErrInvalidSearchQuery
+	}
+
+	// A country only narrows a search down; it never finds users by itself
+	var country string
+	if request.Country != "" {
+		match, err := entities.LookupCountry(request.Country)
+		if err != nil {
+			return nil, err
+		}
+		country = match.Code
+	}
+
+	// One more than returned tells whether the search was truncated
+	users, err := uc.userRepo.List(ctx, ports.UserFilter{EmailContains: email, PhoneContains: phone, Country: country}, MaxUserSearchResults+1, 0)
+	if err != nil {
+		return nil, err
+	}
+	truncated := len(users) > MaxUserSearchResults
+	if truncated {
+		users = users[:MaxUserSearchResults]
+	}
+
+	fields := make([]string, 0, 3)
+	if email != "" {
+		fields = append(fields, "email")
+	}
+	if phone != "" {
+		fields = append(fields, "phone")
+	}
+	if country != "" {
+		fields = append(fields, "country")
+	}
+	matches := make([]uint, 0, len(users))
+	results := make([]*dto.UserSearchMatchDTO, 0, len(users))
+	for _, user := range users {
+		matches = append(matches, user.ID)
+		results = append(results, dto.UserToSearchMatchDTO(user))
+	}
+	uc.audit.Record(ctx, &entities.AuditEvent{
+		Action:       "user.search",
+		ActorID:      actor,
+		ResourceType: "user",
+		Metadata: map[string]interface{}{
+			"ticket_id": request.TicketID,
+			"reason":    request.Reason,
+			"fields":    fields,
+			"matches":   len(users),
+			"user_ids":  matches,
+			"truncated": truncated,
+		},
+	})
+
+	uc.logger.Info("SearchUsers success", "actor", actor, "ticket_id", request.TicketID, "reason", request.Reason, "matches", len(users))
+	return &dto.UserSearchResponseDTO{
+		Users:     results,
+		Count:     len(results),
+		Truncated: truncated,
+	}, nil
+}
+
+// phoneDigits keeps the digits of a phone number fragment
+func phoneDigits(phone string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+}
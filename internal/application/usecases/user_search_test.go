@@ -0,0 +1,116 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestUserSearchUseCases() (UserSearchUseCases, *MockUserRepository, *MockAuditLogger) {
+	mockRepo := new(MockUserRepository)
+	mockAudit := new(MockAuditLogger)
+	return NewUserSearchUseCases(mockRepo, mockAudit, logger.New("test")), mockRepo, mockAudit
+}
+
+func TestUserSearchUseCases_SearchUsers(t *testing.T) {
+	// Given a user matching part of an email address and phone number
+	useCases, mockRepo, mockAudit := setupTestUserSearchUseCases()
+	ctx := context.Background()
+	mockRepo.On("List", ctx, ports.UserFilter{EmailContains: "ada.l", PhoneContains: "0958"}, MaxUserSearchResults+1, 0).Return([]*entities.User{
+		{ID: 3, Email: "ada.lovelace@example.com", Phone: "+44 20 7946 0958", Status: entities.UserStatusActive},
+	}, nil)
+	mockAudit.On("Record", ctx, mock.MatchedBy(func(event *entities.AuditEvent) bool {
+		_, hasEmail := event.Metadata["email"]
+		return event.Action == "user.search" && event.ActorID == "agent-7" &&
+			event.Metadata["ticket_id"] == "SUP-1234" && event.Metadata["reason"] == "locked out" &&
+			event.Metadata["matches"] == 1 && !hasEmail
+	})).Return()
+
+	// When
+	result, err := useCases.SearchUsers(ctx, "agent-7", &dto.UserSearchRequestDTO{
+		Email:    " Ada.L ",
+		Phone:    "09-58",
+		TicketID: "SUP-1234",
+		Reason:   "locked out",
+	})
+
+	// Then the match is returned with its contact details masked
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Count)
+	assert.False(t, result.Truncated)
+	assert.Equal(t, uint(3), result.Users[0].ID)
+	assert.Equal(t, "a***@example.com", result.Users[0].Email)
+	assert.Equal(t, "***0958", result.Users[0].Phone)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestUserSearchUseCases_SearchUsers_NarrowsByCountry(t *testing.T) {
+	// Given
+	useCases, mockRepo, mockAudit := setupTestUserSearchUseCases()
+	ctx := context.Background()
+	mockRepo.On("List", ctx, ports.UserFilter{EmailContains: "ada.l", Country: "GB"}, MaxUserSearchResults+1, 0).Return([]*entities.User{
+		{ID: 3, Email: "ada.lovelace@example.com", Country: "GB", Status: entities.UserStatusActive},
+	}, nil)
+	mockAudit.On("Record", ctx, mock.MatchedBy(func(event *entities.AuditEvent) bool {
+		fields, _ := event.Metadata["fields"].([]string)
+		return len(fields) == 2 && fields[1] == "country"
+	})).Return()
+
+	// When
+	result, err := useCases.SearchUsers(ctx, "agent-7", &dto.UserSearchRequestDTO{Email: "ada.l", Country: "gb", TicketID: "SUP-1234"})
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Count)
+	assert.Equal(t, "GB", result.Users[0].Country)
+	mockAudit.AssertExpectations(t)
+
+	// And an unknown country is rejected
+	_, err = useCases.SearchUsers(ctx, "agent-7", &dto.UserSearchRequestDTO{Email: "ada.l", Country: "UK", TicketID: "SUP-1234"})
+	assert.Equal(t, domainErrors.ErrInvalidCountry, err)
+}
+
+func TestUserSearchUseCases_SearchUsers_LimitsResults(t *testing.T) {
+	// Given more matches than a search returns
+	useCases, mockRepo, mockAudit := setupTestUserSearchUseCases()
+	ctx := context.Background()
+	users := make([]*entities.User, MaxUserSearchResults+1)
+	for i := range users {
+		users[i] = &entities.User{ID: uint(i + 1), Email: "user@example.com"}
+	}
+	mockRepo.On("List", ctx, ports.UserFilter{EmailContains: "example"}, MaxUserSearchResults+1, 0).Return(users, nil)
+	mockAudit.On("Record", ctx, mock.Anything).Return()
+
+	// When
+	result, err := useCases.SearchUsers(ctx, "agent-7", &dto.UserSearchRequestDTO{Email: "example", TicketID: "SUP-1234"})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, MaxUserSearchResults, result.Count)
+	assert.True(t, result.Truncated)
+}
+
+func TestUserSearchUseCases_SearchUsers_RejectsBroadQueries(t *testing.T) {
+	useCases, mockRepo, _ := setupTestUserSearchUseCases()
+
+	for _, request := range []*dto.UserSearchRequestDTO{
+		{TicketID: "SUP-1234"},
+		{Email: "ab", TicketID: "SUP-1234"},
+		{Phone: "12-3", TicketID: "SUP-1234"},
+		{Email: "ada", Phone: "no digits", TicketID: "SUP-1234"},
+		{Country: "GB", TicketID: "SUP-1234"},
+	} {
+		_, err := useCases.SearchUsers(context.Background(), "agent-7", request)
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidSearchQuery, request)
+	}
+	mockRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
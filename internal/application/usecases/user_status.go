@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+	"strconv"
+	"time"
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	userErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+)
+
+// UserStatusUseCases defines the interface for changing a user's status
+type UserStatusUseCases interface {
+	ChangeStatus(ctx context.Context, id uint, request *dto.ChangeUserStatusRequestDTO, actor string) (*dto.UserResponseDTO, error)
+}
+
+// userStatusUseCasesImpl implements UserStatusUseCases interface
+type userStatusUseCasesImpl struct {
+	userRepo  ports.UserRepository
+	publisher ports.EventPublisher
+	audit     ports.AuditLogger
+	logger    logger.Logger
+}
+
+// NewUserStatusUseCases creates a new instance of user status use cases
+func NewUserStatusUseCases(userRepo ports.UserRepository, publisher ports.EventPublisher, audit ports.AuditLogger, log logger.Logger) UserStatusUseCases {
+	return &userStatusUseCasesImpl{
+		userRepo:  userRepo,
+		publisher: publisher,
+		audit:     audit,
+		logger:    log.With("component", "user_status_usecases"),
+	}
+}
+
+// ChangeStatus moves a user to a new status if the status state machine
+// allows it, recording who made the change and why
+func (uc *userStatusUseCasesImpl) ChangeStatus(ctx context.Context, id uint, request *dto.ChangeUserStatusRequestDTO, actor string) (*dto.UserResponseDTO, error) {
+	uc.logger.Info("ChangeStatus use case called", "user_id", id, "status", request.Status, "actor", actor)
+
+	status, err := entities.ParseUserStatus(request.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	from := user.Status
+	if err := uc.transition(user, status, request); err != nil {
+		uc.logger.Warn("Status transition rejected", "user_id", id, "from", from, "to", status, "error", err)
+		return nil, err
+	}
+
+	// Guarded by the current status so a concurrent change is not overwritten
+	if err := uc.userRepo.UpdateStatus(ctx, user, from); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{"from": string(from), "to": string(status)}
+	if request.Reason != "" {
+		data["reason"] = request.Reason
+	}
+	// Downstream services such as email tailor their messaging to the suspension
+	if user.Suspension != nil {
+		data["suspension"] = user.Suspension.EventData()
+	}
+	event := entities.NewUserEvent(entities.UserEventStatusChanged, id, data).About(user)
+	if err := uc.publisher.Publish(ctx, event); err != nil {
+		uc.logger.Error("Failed to publish user status changed event", "user_id", id, "error", err)
+	}
+
+	metadata := map[string]interface{}{
+		"from":   string(from),
+		"to":     string(status),
+		"reason": request.Reason,
+	}
+	if user.Suspension != nil {
+		metadata["suspension"] = user.Suspension.EventData()
+	}
+	uc.audit.Record(ctx, &entities.AuditEvent{
+		Action:       "user.status_changed",
+		ActorID:      actor,
+		ResourceType: "user",
+		ResourceID:   strconv.FormatUint(uint64(id), 10),
+		Metadata:     metadata,
+	})
+
+	uc.logger.Info("ChangeStatus success", "user_id", id, "from", from, "to", status)
+	return dto.UserToResponseDTO(user), nil
+}
+
+// transition applies the requested status to user; suspensions must carry
+// their details and other statuses must not
+func (uc *userStatusUseCasesImpl) transition(user *entities.User, status entities.UserStatus, request *dto.ChangeUserStatusRequestDTO) error {
+	if status != entities.UserStatusSuspended {
+		if request.HasSuspensionDetails() {
+			return userErrors.ErrUnexpectedSuspensionDetails
+		}
+		return user.TransitionTo(status)
+	}
+
+	suspension, err := request.ToSuspension(time.Now())
+	if err != nil {
+		return err
+	}
+	return user.Suspend(suspension)
+}
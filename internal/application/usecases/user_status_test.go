@@ -0,0 +1,149 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+	"user-service/internal/application/dto"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestStatusUseCases() (UserStatusUseCases, *MockUserRepository, *MockEventPublisher, *MockAuditLogger) {
+	mockRepo := new(MockUserRepository)
+	mockPublisher := new(MockEventPublisher)
+	mockAudit := new(MockAuditLogger)
+	useCases := NewUserStatusUseCases(mockRepo, mockPublisher, mockAudit, logger.New("test"))
+	return useCases, mockRepo, mockPublisher, mockAudit
+}
+
+func TestUserStatusUseCases_ChangeStatus_Success(t *testing.T) {
+	// Given
+	useCases, mockRepo, mockPublisher, mockAudit := setupTestStatusUseCases()
+	ctx := context.Background()
+	reactivateAt := dto.NewTimestamp(time.Now().Add(72 * time.Hour).Truncate(time.Second))
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, Status: entities.UserStatusActive}, nil)
+	mockRepo.On("UpdateStatus", ctx, mock.MatchedBy(func(user *entities.User) bool {
+		return user.Status == entities.UserStatusSuspended && user.Suspension.Reason == entities.SuspensionReasonPayment
+	}), entities.UserStatusActive).Return(nil)
+	mockPublisher.On("Publish", ctx, mock.MatchedBy(func(event *entities.UserEvent) bool {
+		suspension, _ := event.Data["suspension"].(map[string]interface{})
+		return event.Type == entities.UserEventStatusChanged &&
+			event.Data["reason"] == "chargeback" &&
+			suspension["reason_code"] == "payment" &&
+			suspension["note"] == "card reported stolen" &&
+			suspension["reactivate_at"] == reactivateAt.Format(time.RFC3339)
+	})).Return(nil)
+	mockAudit.On("Record", ctx, mock.MatchedBy(func(event *entities.AuditEvent) bool {
+		return event.Action == "user.status_changed" &&
+			event.ActorID == "ops" &&
+			event.Metadata["from"] == "active" &&
+			event.Metadata["to"] == "suspended" &&
+			event.Metadata["reason"] == "chargeback"
+	})).Return()
+
+	// When
+	response, err := useCases.ChangeStatus(ctx, 1, &dto.ChangeUserStatusRequestDTO{
+		Status:       "suspended",
+		Reason:       "chargeback",
+		ReasonCode:   "payment",
+		Note:         "card reported stolen",
+		ReactivateAt: &reactivateAt,
+	}, "ops")
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, entities.UserStatusSuspended, response.Status)
+	require.NotNil(t, response.Suspension)
+	assert.Equal(t, entities.SuspensionReasonPayment, response.Suspension.ReasonCode)
+	mockRepo.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestUserStatusUseCases_ChangeStatus_InvalidTransition(t *testing.T) {
+	// Given a pending user, who must be activated before being suspended
+	useCases, mockRepo, _, _ := setupTestStatusUseCases()
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, Status: entities.UserStatusPending}, nil)
+
+	// When
+	response, err := useCases.ChangeStatus(ctx, 1, &dto.ChangeUserStatusRequestDTO{Status: "suspended", ReasonCode: "abuse"}, "ops")
+
+	// Then
+	assert.Nil(t, response)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidStatusTransition)
+	mockRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserStatusUseCases_ChangeStatus_ConcurrentChange(t *testing.T) {
+	// Given the user's status changes between reading and writing it
+	useCases, mockRepo, mockPublisher, mockAudit := setupTestStatusUseCases()
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, Status: entities.UserStatusSuspended}, nil)
+	mockRepo.On("UpdateStatus", ctx, mock.Anything, entities.UserStatusSuspended).Return(domainErrors.ErrStatusChanged)
+
+	// When
+	_, err := useCases.ChangeStatus(ctx, 1, &dto.ChangeUserStatusRequestDTO{Status: "active"}, "ops")
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrStatusChanged)
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	mockAudit.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+}
+
+func TestUserStatusUseCases_ChangeStatus_SuspensionDetails(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  entities.UserStatus
+		request  dto.ChangeUserStatusRequestDTO
+		expected error
+	}{
+		{
+			name:     "suspending without a reason code",
+			current:  entities.UserStatusActive,
+			request:  dto.ChangeUserStatusRequestDTO{Status: "suspended"},
+			expected: domainErrors.ErrSuspensionReasonRequired,
+		},
+		{
+			name:    "reactivation date in the past",
+			current: entities.UserStatusActive,
+			request: dto.ChangeUserStatusRequestDTO{
+				Status:       "suspended",
+				ReasonCode:   "fraud",
+				ReactivateAt: &dto.Timestamp{Time: time.Now().Add(-time.Hour)},
+			},
+			expected: domainErrors.ErrInvalidReactivationDate,
+		},
+		{
+			name:     "suspension details on another status",
+			current:  entities.UserStatusSuspended,
+			request:  dto.ChangeUserStatusRequestDTO{Status: "active", ReasonCode: "fraud"},
+			expected: domainErrors.ErrUnexpectedSuspensionDetails,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockRepo, _, _ := setupTestStatusUseCases()
+			ctx := context.Background()
+			mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, Status: tt.current}, nil)
+
+			// When
+			_, err := useCases.ChangeStatus(ctx, 1, &tt.request, "ops")
+
+			// Then
+			assert.ErrorIs(t, err, tt.expected)
+			mockRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	userErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+)
+
+// UserSyncUseCases defines the interface for syncing users from external systems
+type UserSyncUseCases interface {
+	SyncUser(ctx context.Context, request *dto.SyncUserRequestDTO) (*dto.SyncUserResponseDTO, error)
+}
+
+// userSyncUseCasesImpl implements UserSyncUseCases interface
+type userSyncUseCasesImpl struct {
+	userRepo  ports.UserRepository
+	publisher ports.EventPublisher
+	// defaults are the locale and time zone synced users are created with
+	defaults entities.Localization
+	// required are the profile fields synced users must be created with
+	required entities.RequiredProfileFields
+	logger   logger.Logger
+}
+
+// NewUserSyncUseCases creates a new instance of user sync use cases
+func NewUserSyncUseCases(userRepo ports.UserRepository, publisher ports.EventPublisher, defaults entities.Localization, required entities.RequiredProfileFields, log logger.Logger) UserSyncUseCases {
+	return &userSyncUseCasesImpl{
+		userRepo:  userRepo,
+		publisher: publisher,
+		defaults:  defaults,
+		required:  required,
+		logger:    log.With("component", "user_sync_usecases"),
+	}
+}
+
+// SyncUser creates or updates a user from an external source of record. Events
+// are only published when a user was created or its data actually changed.
+func (uc *userSyncUseCasesImpl) SyncUser(ctx context.Context, request *dto.SyncUserRequestDTO) (*dto.SyncUserResponseDTO, error) {
+	uc.logger.Info("SyncUser use case called", "email", logger.MaskEmail(request.Email), "policy", request.Policy, "source", request.Source)
+
+	policy, err := entities.ParseSyncPolicy(request.Policy)
+	if err != nil {
+		return nil, err
+	}
+
+	incoming, err := uc.newSyncedUser(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Existing users only change status when the source sends one explicitly
+	patch := *incoming
+	patch.Status = entities.UserStatus(strings.ToLower(request.Status))
+
+	var changes []string
+	var rejected error
+	user, outcome, err := uc.userRepo.UpsertByEmail(ctx, incoming, func(existing *entities.User) bool {
+		changes, rejected = existing.ApplySync(&patch, policy)
+		return len(changes) > 0
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rejected != nil {
+		uc.logger.Warn("SyncUser rejected", "user_id", user.ID, "error", rejected)
+		return nil, rejected
+	}
+
+	switch outcome {
+	case ports.UpsertCreated:
+		uc.publish(ctx, entities.UserEventCreated, user, map[string]interface{}{"source": request.Source})
+	case ports.UpsertUpdated:
+		uc.publish(ctx, entities.UserEventUpdated, user, map[string]interface{}{
+			"source":  request.Source,
+			"changes": changes,
+		})
+	}
+
+	uc.logger.Info("SyncUser success", "user_id", user.ID, "outcome", outcome, "changes", changes)
+
+	if changes == nil {
+		changes = []string{}
+	}
+
+	return &dto.SyncUserResponseDTO{
+		User:    dto.UserToResponseDTO(user),
+		Outcome: string(outcome),
+		Changes: changes,
+	}, nil
+}
+
+// newSyncedUser builds the incoming user. Synced users get an unusable random
+// password until they reset it themselves.
+func (uc *userSyncUseCasesImpl) newSyncedUser(request *dto.SyncUserRequestDTO) (*entities.User, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, userErrors.ErrFailedToSyncUser
+	}
+
+	user, err := entities.NewUser(request.Email, "Aa1"+hex.EncodeToString(secret), request.FirstName, request.LastName, request.Phone)
+	if err != nil {
+		return nil, userErrors.NewUserValidationError("", err.Error())
+	}
+	if err := uc.required.Check(user); err != nil {
+		return nil, err
+	}
+
+	user.Password, err = hashPassword(user.Password)
+	if err != nil {
+		return nil, userErrors.ErrFailedToSyncUser
+	}
+
+	user.Status = entities.UserStatus(strings.ToLower(request.Status))
+	if user.Status == "" {
+		user.Status = entities.UserStatusActive
+	}
+	if err := entities.CheckSyncStatus(user.Status); err != nil {
+		return nil, err
+	}
+	user.Preferences.DefaultTo(uc.defaults)
+
+	return user, nil
+}
+
+func (uc *userSyncUseCasesImpl) publish(ctx context.Context, eventType entities.UserEventType, user *entities.User, data map[string]interface{}) {
+	event := entities.NewUserEvent(eventType, user.ID, data).About(user)
+	if err := uc.publisher.Publish(ctx, event); err != nil {
+		uc.logger.Error("Failed to publish sync event", "user_id", user.ID, "type", eventType, "error", err)
+	}
+}
@@ -0,0 +1,145 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestSyncUseCases() (UserSyncUseCases, *MockUserRepository, *MockEventPublisher) {
+	mockRepo := new(MockUserRepository)
+	mockPublisher := new(MockEventPublisher)
+	useCases := NewUserSyncUseCases(mockRepo, mockPublisher, testLocalization, nil, logger.New("test"))
+	return useCases, mockRepo, mockPublisher
+}
+
+// resolveAgainst makes the mocked upsert run the resolver on an existing user
+func resolveAgainst(existing *entities.User) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		args.Get(2).(ports.UpsertResolver)(existing)
+	}
+}
+
+func TestUserSyncUseCases_SyncUser_CreatesUnknownUser(t *testing.T) {
+	// Given
+	useCases, mockRepo, mockPublisher := setupTestSyncUseCases()
+	ctx := context.Background()
+
+	created := &entities.User{ID: 3, Email: "new.hire@example.com", FirstName: "New", Status: entities.UserStatusActive}
+	mockRepo.On("UpsertByEmail", ctx, mock.MatchedBy(func(user *entities.User) bool {
+		return user.Email == "new.hire@example.com" && user.Status == entities.UserStatusActive && user.Password != ""
+	}), mock.Anything).Return(created, ports.UpsertCreated, nil)
+	mockPublisher.On("Publish", ctx, mock.MatchedBy(func(event *entities.UserEvent) bool {
+		return event.Type == entities.UserEventCreated && event.UserID == 3 && event.Data["source"] == "hr"
+	})).Return(nil)
+
+	// When
+	result, err := useCases.SyncUser(ctx, &dto.SyncUserRequestDTO{
+		Email:     "New.Hire@example.com",
+		FirstName: "New",
+		Source:    "hr",
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "created", result.Outcome)
+	assert.Equal(t, uint(3), result.User.ID)
+	mockPublisher.AssertExpectations(t)
+}
+
+func TestUserSyncUseCases_SyncUser_RequiresProfileFields(t *testing.T) {
+	// Given a deployment requiring last names
+	mockRepo := new(MockUserRepository)
+	useCases := NewUserSyncUseCases(mockRepo, new(MockEventPublisher), testLocalization, entities.RequiredProfileFields{"last_name"}, logger.New("test"))
+
+	// When a user without one is synced
+	_, err := useCases.SyncUser(context.Background(), &dto.SyncUserRequestDTO{
+		Email:     "new.hire@example.com",
+		FirstName: "New",
+		Source:    "hr",
+	})
+
+	// Then it is rejected before reaching the database
+	var domainErr *domainErrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "last_name", domainErr.Field)
+	mockRepo.AssertNotCalled(t, "UpsertByEmail", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserSyncUseCases_SyncUser_UpdatesChangedFields(t *testing.T) {
+	// Given
+	useCases, mockRepo, mockPublisher := setupTestSyncUseCases()
+	ctx := context.Background()
+
+	existing := &entities.User{ID: 5, Email: "john@example.com", FirstName: "John", LastName: "Doe", Status: entities.UserStatusSuspended}
+	mockRepo.On("UpsertByEmail", ctx, mock.Anything, mock.Anything).
+		Run(resolveAgainst(existing)).
+		Return(existing, ports.UpsertUpdated, nil)
+	mockPublisher.On("Publish", ctx, mock.MatchedBy(func(event *entities.UserEvent) bool {
+		return event.Type == entities.UserEventUpdated && assert.ObjectsAreEqual([]string{"last_name"}, event.Data["changes"])
+	})).Return(nil)
+
+	// When
+	result, err := useCases.SyncUser(ctx, &dto.SyncUserRequestDTO{
+		Email:     "john@example.com",
+		FirstName: "John",
+		LastName:  "Smith",
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "updated", result.Outcome)
+	assert.Equal(t, []string{"last_name"}, result.Changes)
+	assert.Equal(t, entities.UserStatusSuspended, existing.Status, "status is only synced when sent")
+	mockPublisher.AssertExpectations(t)
+}
+
+func TestUserSyncUseCases_SyncUser_UnchangedPublishesNothing(t *testing.T) {
+	// Given
+	useCases, mockRepo, mockPublisher := setupTestSyncUseCases()
+	ctx := context.Background()
+
+	existing := &entities.User{ID: 5, Email: "john@example.com", FirstName: "John", LastName: "Doe", Status: entities.UserStatusActive}
+	mockRepo.On("UpsertByEmail", ctx, mock.Anything, mock.Anything).
+		Run(resolveAgainst(existing)).
+		Return(existing, ports.UpsertUnchanged, nil)
+
+	// When
+	result, err := useCases.SyncUser(ctx, &dto.SyncUserRequestDTO{
+		Email:     "john@example.com",
+		FirstName: "Johnny",
+		Policy:    string(entities.SyncPreferExisting),
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "unchanged", result.Outcome)
+	assert.Empty(t, result.Changes)
+	assert.Equal(t, "John", existing.FirstName)
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestUserSyncUseCases_SyncUser_InvalidPolicy(t *testing.T) {
+	// Given
+	useCases, mockRepo, _ := setupTestSyncUseCases()
+
+	// When
+	result, err := useCases.SyncUser(context.Background(), &dto.SyncUserRequestDTO{
+		Email:     "john@example.com",
+		FirstName: "John",
+		Policy:    "newest",
+	})
+
+	// Then
+	assert.Nil(t, result)
+	assert.Equal(t, domainErrors.ErrInvalidSyncPolicy, err)
+	mockRepo.AssertNotCalled(t, "UpsertByEmail", mock.Anything, mock.Anything, mock.Anything)
+}
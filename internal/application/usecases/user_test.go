@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
 	"user-service/internal/domain/entities"
 	domainErrors "user-service/internal/domain/errors"
 	"user-service/pkg/logger"
@@ -28,6 +29,22 @@ func (m *MockUserRepository) Create(ctx context.Context, user *entities.User) (*
 	return args.Get(0).(*entities.User), args.Error(1)
 }
 
+func (m *MockUserRepository) CreateBatch(ctx context.Context, users []*entities.User, batchSize int) ([]*entities.User, error) {
+	args := m.Called(ctx, users, batchSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpsertByEmail(ctx context.Context, user *entities.User, resolve ports.UpsertResolver) (*entities.User, ports.UpsertOutcome, error) {
+	args := m.Called(ctx, user, resolve)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(ports.UpsertOutcome), args.Error(2)
+	}
+	return args.Get(0).(*entities.User), args.Get(1).(ports.UpsertOutcome), args.Error(2)
+}
+
 func (m *MockUserRepository) GetByID(ctx context.Context, id uint) (*entities.User, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -44,6 +61,22 @@ func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*ent
 	return args.Get(0).(*entities.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetByExternalID(ctx context.Context, source, externalID string) (*entities.User, error) {
+	args := m.Called(ctx, source, externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
+func (m *MockUserRepository) ListExternalIDs(ctx context.Context, userID uint) (map[string]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
 func (m *MockUserRepository) Update(ctx context.Context, user *entities.User) (*entities.User, error) {
 	args := m.Called(ctx, user)
 	if args.Get(0) == nil {
@@ -52,29 +85,136 @@ func (m *MockUserRepository) Update(ctx context.Context, user *entities.User) (*
 	return args.Get(0).(*entities.User), args.Error(1)
 }
 
+func (m *MockUserRepository) UpdateStatus(ctx context.Context, user *entities.User, from entities.UserStatus) error {
+	args := m.Called(ctx, user, from)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdatePreferences(ctx context.Context, userID uint, preferences entities.UserPreferences) error {
+	args := m.Called(ctx, userID, preferences)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateProfile(ctx context.Context, user *entities.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateLegalHold(ctx context.Context, user *entities.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
 func (m *MockUserRepository) Delete(ctx context.Context, id uint) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) ExistsByID(ctx context.Context, id uint) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockUserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 	args := m.Called(ctx, email)
 	return args.Bool(0), args.Error(1)
 }
 
-func (m *MockUserRepository) List(ctx context.Context, limit, offset int) ([]*entities.User, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockUserRepository) GetByPhone(ctx context.Context, phone string) ([]*entities.User, error) {
+	args := m.Called(ctx, phone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.User), args.Error(1)
+}
+
+func (m *MockUserRepository) ExistsByPhone(ctx context.Context, phone string) (bool, error) {
+	args := m.Called(ctx, phone)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserRepository) GetByReferralCode(ctx context.Context, code string) (*entities.User, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateReferrer(ctx context.Context, user *entities.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateVerificationSentAt(ctx context.Context, user *entities.User, previous *time.Time) error {
+	args := m.Called(ctx, user, previous)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) FindExistingEmails(ctx context.Context, emails []string) ([]string, error) {
+	args := m.Called(ctx, emails)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockUserRepository) List(ctx context.Context, filter ports.UserFilter, limit, offset int) ([]*entities.User, error) {
+	args := m.Called(ctx, filter, limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*entities.User), args.Error(1)
 }
 
+func (m *MockUserRepository) Count(ctx context.Context, filter ports.UserFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) AddTags(ctx context.Context, userID uint, tags []string) error {
+	args := m.Called(ctx, userID, tags)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) RemoveTags(ctx context.Context, userID uint, tags []string) error {
+	args := m.Called(ctx, userID, tags)
+	return args.Error(0)
+}
+
+// MockEventPublisher implements the EventPublisher interface for testing
+type MockEventPublisher struct {
+	mock.Mock
+}
+
+func (m *MockEventPublisher) Publish(ctx context.Context, event *entities.UserEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
 func setupTestUseCases() (UserUseCases, *MockUserRepository) {
+	useCases, mockRepo, _ := setupTestUseCasesWithPublisher()
+	return useCases, mockRepo
+}
+
+// testLocalization is the deployment default of the use cases under test
+var testLocalization = entities.Localization{Locale: "en-US", Timezone: "UTC"}
+
+// testAgeGates reject under-16s, but hold under-13s in the US for guardian
+// consent
+var testAgeGates = entities.AgeGates{
+	Default: entities.AgeGate{MinimumAge: 16, Policy: entities.AgeGateReject},
+	Regions: map[entities.Residency]entities.AgeGate{
+		entities.ResidencyUS: {MinimumAge: 13, Policy: entities.AgeGateGuardianConsent},
+	},
+}
+
+func setupTestUseCasesWithPublisher() (UserUseCases, *MockUserRepository, *MockEventPublisher) {
 	mockRepo := new(MockUserRepository)
+	mockPublisher := new(MockEventPublisher)
 	log := logger.New("test")
-	useCases := NewUserUseCases(mockRepo, log)
-	return useCases, mockRepo
+	useCases := NewUserUseCases(mockRepo, mockPublisher, testLocalization, testAgeGates, nil, log)
+	return useCases, mockRepo, mockPublisher
 }
 
 // CreateUser Tests
@@ -133,6 +273,139 @@ func TestUserUseCases_CreateUser_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUserUseCases_CreateUser_DefaultsLocalization(t *testing.T) {
+	// Given a user who chose a locale but no time zone
+	useCases, mockRepo := setupTestUseCases()
+	ctx := context.Background()
+	request := &dto.CreateUserRequestDTO{
+		Email:     "test@example.com",
+		Password:  "SecurePass123",
+		FirstName: "John",
+		Locale:    "es-es",
+	}
+
+	mockRepo.On("ExistsByEmail", ctx, "test@example.com").Return(false, nil)
+	var created *entities.User
+	mockRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*entities.User)
+	}).Return(&entities.User{ID: 1}, nil)
+
+	// When
+	_, err := useCases.CreateUser(ctx, request)
+
+	// Then the deployment's time zone fills the gap
+	require.NoError(t, err)
+	assert.Equal(t, "es-ES", created.Preferences.Locale)
+	assert.Equal(t, "UTC", created.Preferences.Timezone)
+}
+
+func TestUserUseCases_CreateUser_DerivesDefaultsFromCountry(t *testing.T) {
+	// Given a user in Spain who chose a locale and gave a national number
+	useCases, mockRepo := setupTestUseCases()
+	ctx := context.Background()
+	request := &dto.CreateUserRequestDTO{
+		Email:     "test@example.com",
+		Password:  "SecurePass123",
+		FirstName: "Juan",
+		Phone:     "600 12 34 56",
+		Country:   "es",
+		Locale:    "ca-ES",
+	}
+
+	mockRepo.On("ExistsByEmail", ctx, "test@example.com").Return(false, nil)
+	var created *entities.User
+	mockRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*entities.User)
+	}).Return(&entities.User{ID: 1, Country: "ES"}, nil)
+
+	// When
+	result, err := useCases.CreateUser(ctx, request)
+
+	// Then the country fills in what the user left out
+	require.NoError(t, err)
+	assert.Equal(t, "ES", result.Country)
+	assert.Equal(t, "ES", created.Country)
+	assert.Equal(t, "ca-ES", created.Preferences.Locale)
+	assert.Equal(t, "Europe/Madrid", created.Preferences.Timezone)
+	assert.Equal(t, "+34 600 12 34 56", created.Phone)
+}
+
+func TestUserUseCases_CreateUser_InvalidCountry(t *testing.T) {
+	useCases, _ := setupTestUseCases()
+	request := &dto.CreateUserRequestDTO{
+		Email:     "test@example.com",
+		Password:  "SecurePass123",
+		FirstName: "John",
+		Country:   "XX",
+	}
+
+	result, err := useCases.CreateUser(context.Background(), request)
+
+	assert.Nil(t, result)
+	assert.Equal(t, domainErrors.ErrInvalidCountry, err)
+}
+
+func TestUserUseCases_CreateUser_InvalidTimezone(t *testing.T) {
+	useCases, mockRepo := setupTestUseCases()
+	mockRepo.On("ExistsByEmail", mock.Anything, "test@example.com").Return(false, nil)
+	request := &dto.CreateUserRequestDTO{
+		Email:     "test@example.com",
+		Password:  "SecurePass123",
+		FirstName: "John",
+		Timezone:  "Mars/Olympus_Mons",
+	}
+
+	result, err := useCases.CreateUser(context.Background(), request)
+
+	assert.Nil(t, result)
+	assert.Equal(t, domainErrors.ErrInvalidTimezone, err)
+}
+
+func TestUserUseCases_CreateUser_AgeGate(t *testing.T) {
+	newRequest := func(residency string, age int) *dto.CreateUserRequestDTO {
+		return &dto.CreateUserRequestDTO{
+			Email:       "teen@example.com",
+			Password:    "SecurePass123",
+			FirstName:   "Sam",
+			Residency:   residency,
+			DateOfBirth: time.Now().AddDate(-age, 0, -1).Format(time.DateOnly),
+		}
+	}
+
+	t.Run("14 year old rejected by the default gate", func(t *testing.T) {
+		useCases, mockRepo, _ := setupTestUseCasesWithPublisher()
+		mockRepo.On("ExistsByEmail", mock.Anything, "teen@example.com").Return(false, nil)
+
+		result, err := useCases.CreateUser(context.Background(), newRequest("", 14))
+
+		assert.Nil(t, result)
+		assert.Equal(t, domainErrors.ErrUnderMinimumAge, err)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("12 year old held for guardian consent in the US", func(t *testing.T) {
+		useCases, mockRepo, mockPublisher := setupTestUseCasesWithPublisher()
+		ctx := context.Background()
+		request := newRequest("us", 12)
+		birth, _ := time.Parse(time.DateOnly, request.DateOfBirth)
+		stored := &entities.User{ID: 7, Status: entities.UserStatusPending, Residency: entities.ResidencyUS, DateOfBirth: &birth}
+
+		mockRepo.On("ExistsByEmail", ctx, "teen@example.com").Return(false, nil)
+		mockRepo.On("Create", ctx, mock.MatchedBy(func(user *entities.User) bool {
+			return user.Status == entities.UserStatusPending && user.DateOfBirth != nil
+		})).Return(stored, nil)
+		mockPublisher.On("Publish", ctx, mock.MatchedBy(func(event *entities.UserEvent) bool {
+			return event.Type == entities.UserEventGuardianConsentRequired && event.UserID == 7 && event.IsMinor != nil && *event.IsMinor
+		})).Return(nil)
+
+		result, err := useCases.CreateUser(ctx, request)
+
+		require.NoError(t, err)
+		assert.Equal(t, entities.UserStatusPending, result.Status)
+		mockPublisher.AssertExpectations(t)
+	})
+}
+
 func TestUserUseCases_CreateUser_EmailAlreadyExists(t *testing.T) {
 	// Given
 	useCases, mockRepo := setupTestUseCases()
@@ -159,6 +432,44 @@ func TestUserUseCases_CreateUser_EmailAlreadyExists(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUserUseCases_CreateUser_EmailTaken(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestUseCases()
+	ctx := context.Background()
+	mockRepo.On("ExistsByEmail", ctx, "existing@example.com").Return(true, nil)
+
+	// When
+	_, err := useCases.CreateUser(ctx, &dto.CreateUserRequestDTO{
+		Email:     "existing@example.com",
+		Password:  "SecurePass123",
+		FirstName: "John",
+		LastName:  "Doe",
+	})
+
+	// Then
+	assert.Equal(t, domainErrors.ErrUserAlreadyExists, err)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestUserUseCases_CreateUser_ExistenceCheckFails(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestUseCases()
+	ctx := context.Background()
+	mockRepo.On("ExistsByEmail", ctx, "test@example.com").Return(false, domainErrors.ErrFailedToCheckUserExistance)
+
+	// When
+	_, err := useCases.CreateUser(ctx, &dto.CreateUserRequestDTO{
+		Email:     "test@example.com",
+		Password:  "SecurePass123",
+		FirstName: "John",
+		LastName:  "Doe",
+	})
+
+	// Then the failure is reported as is, not as a duplicate
+	assert.Equal(t, domainErrors.ErrFailedToCheckUserExistance, err)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
 func TestUserUseCases_CreateUser_InvalidUserData(t *testing.T) {
 	// Given
 	useCases, _ := setupTestUseCases()
@@ -195,7 +506,7 @@ func TestUserUseCases_CreateUser_RepositoryExistsError(t *testing.T) {
 	}
 
 	// Mock repository to return error when checking if email exists
-	mockRepo.On("ExistsByEmail", ctx, "test@example.com").Return(true, nil)
+	mockRepo.On("ExistsByEmail", ctx, "test@example.com").Return(false, nil)
 	mockRepo.On("Create", ctx, mock.MatchedBy(func(user *entities.User) bool {
 		return user.Email == "test@example.com"
 	})).Return(nil, domainErrors.ErrFailedToCheckUserExistance)
@@ -224,7 +535,7 @@ func TestUserUseCases_CreateUser_RepositoryCreateError(t *testing.T) {
 	}
 
 	// Mock successful email check but failed create
-	mockRepo.On("ExistsByEmail", ctx, "test@example.com").Return(true, nil)
+	mockRepo.On("ExistsByEmail", ctx, "test@example.com").Return(false, nil)
 	mockRepo.On("Create", ctx, mock.Anything).Return(nil, assert.AnError)
 
 	// When
@@ -238,6 +549,34 @@ func TestUserUseCases_CreateUser_RepositoryCreateError(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUserUseCases_CreateUser_WithExternalID(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestUseCases()
+	ctx := context.Background()
+
+	request := &dto.CreateUserRequestDTO{
+		Email:      "test@example.com",
+		Password:   "SecurePass123",
+		FirstName:  "John",
+		LastName:   "Doe",
+		ExternalID: &dto.ExternalIDDTO{Source: " CRM ", ID: "C-1001"},
+	}
+
+	mockRepo.On("ExistsByEmail", ctx, "test@example.com").Return(false, nil)
+	mockRepo.On("Create", ctx, mock.MatchedBy(func(user *entities.User) bool {
+		return user.ExternalIDs["crm"] == "C-1001"
+	})).Return(nil, domainErrors.ErrExternalIDTaken)
+
+	// When
+	result, err := useCases.CreateUser(ctx, request)
+
+	// Then the taken ID is reported rather than a generic failure
+	assert.Nil(t, result)
+	assert.Equal(t, domainErrors.ErrExternalIDTaken, err)
+
+	mockRepo.AssertExpectations(t)
+}
+
 // GetUserByID Tests
 func TestUserUseCases_GetUserByID_Success(t *testing.T) {
 	// Given
@@ -334,6 +673,25 @@ func TestUserUseCases_GetUserByEmail_NotFound(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUserUseCases_GetUserByExternalID(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestUseCases()
+	ctx := context.Background()
+
+	user := &entities.User{ID: 1, Email: "test@example.com", ExternalIDs: map[string]string{"crm": "C-1001"}}
+	mockRepo.On("GetByExternalID", ctx, "crm", "C-1001").Return(user, nil)
+
+	// When
+	result, err := useCases.GetUserByExternalID(ctx, "CRM", "C-1001")
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), result.ID)
+	assert.Equal(t, map[string]string{"crm": "C-1001"}, result.ExternalIDs)
+
+	mockRepo.AssertExpectations(t)
+}
+
 // ListUsers Tests
 func TestUserUseCases_ListUsers_Success(t *testing.T) {
 	// Given
@@ -357,37 +715,81 @@ func TestUserUseCases_ListUsers_Success(t *testing.T) {
 		},
 	}
 
-	mockRepo.On("List", ctx, 10, 0).Return(expectedUsers, nil)
+	mockRepo.On("List", ctx, ports.UserFilter{Tags: []string{}}, 10, 0).Return(expectedUsers, nil)
+	mockRepo.On("Count", ctx, ports.UserFilter{Tags: []string{}}).Return(int64(2), nil)
 
 	// When
-	result, err := useCases.ListUsers(ctx, 0, 10)
+	result, err := useCases.ListUsers(ctx, dto.UserFilterDTO{}, 0, 10)
 
 	// Then
 	require.NoError(t, err)
 	require.NotNil(t, result)
 	assert.Len(t, result.Users, 2)
 	assert.Equal(t, 2, result.Total)
-	assert.Equal(t, 0, result.Page)
+	assert.Equal(t, 1, result.Page)
 	assert.Equal(t, 10, result.PageSize)
 
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUserUseCases_ListUsers_ByEmailIsNormalized(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestUseCases()
+	ctx := context.Background()
+
+	user := &entities.User{ID: 1, Email: "john@example.com", Status: entities.UserStatusActive}
+	mockRepo.On("List", ctx, ports.UserFilter{Tags: []string{}, Email: "john@example.com"}, 10, 0).Return([]*entities.User{user}, nil)
+	mockRepo.On("Count", ctx, ports.UserFilter{Tags: []string{}, Email: "john@example.com"}).Return(int64(1), nil)
+
+	// When
+	result, err := useCases.ListUsers(ctx, dto.UserFilterDTO{Email: " John@Example.com "}, 0, 10)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, result.Users, 1)
+	assert.Equal(t, uint(1), result.Users[0].ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserUseCases_ListUsers_ByCountry(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestUseCases()
+	ctx := context.Background()
+
+	user := &entities.User{ID: 1, Email: "juan@example.com", Country: "ES", Status: entities.UserStatusActive}
+	mockRepo.On("List", ctx, ports.UserFilter{Tags: []string{}, Country: "ES"}, 10, 0).Return([]*entities.User{user}, nil)
+	mockRepo.On("Count", ctx, ports.UserFilter{Tags: []string{}, Country: "ES"}).Return(int64(1), nil)
+
+	// When
+	result, err := useCases.ListUsers(ctx, dto.UserFilterDTO{Country: "es"}, 0, 10)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, result.Users, 1)
+	assert.Equal(t, "ES", result.Users[0].Country)
+
+	// And an unknown country is rejected before reaching the repository
+	_, err = useCases.ListUsers(ctx, dto.UserFilterDTO{Country: "Spain"}, 0, 10)
+	assert.Equal(t, domainErrors.ErrInvalidCountry, err)
+	mockRepo.AssertNumberOfCalls(t, "List", 1)
+}
+
 func TestUserUseCases_ListUsers_InvalidPagination(t *testing.T) {
 	// Given
 	useCases, mockRepo := setupTestUseCases()
 	ctx := context.Background()
 
 	// Mock for corrected pagination parameters
-	mockRepo.On("List", ctx, 10, 0).Return([]*entities.User{}, nil)
+	mockRepo.On("List", ctx, ports.UserFilter{Tags: []string{}}, 10, 0).Return([]*entities.User{}, nil)
+	mockRepo.On("Count", ctx, ports.UserFilter{Tags: []string{}}).Return(int64(0), nil)
 
 	// When - Pass invalid pagination parameters
-	result, err := useCases.ListUsers(ctx, -1, 150) // Invalid page and page_size
+	result, err := useCases.ListUsers(ctx, dto.UserFilterDTO{}, -1, 0) // Invalid page and page_size
 
 	// Then
 	require.NoError(t, err)
 	require.NotNil(t, result)
-	assert.Equal(t, 0, result.Page)      // Should default to 1
+	assert.Equal(t, 1, result.Page)      // Should default to 1
 	assert.Equal(t, 10, result.PageSize) // Should default to 10
 
 	mockRepo.AssertExpectations(t)
@@ -408,30 +810,65 @@ func TestUserUseCases_ListUsers_SecondPage(t *testing.T) {
 	}
 
 	// For page 2 with page_size 5, offset should be 5
-	mockRepo.On("List", ctx, 5, 1).Return(expectedUsers, nil)
+	mockRepo.On("List", ctx, ports.UserFilter{Tags: []string{}}, 5, 5).Return(expectedUsers, nil)
+	mockRepo.On("Count", ctx, ports.UserFilter{Tags: []string{}}).Return(int64(6), nil)
 
 	// When
-	result, err := useCases.ListUsers(ctx, 1, 5)
+	result, err := useCases.ListUsers(ctx, dto.UserFilterDTO{}, 2, 5)
 
-	// Then
+	// Then the total counts every user, not just this page
 	require.NoError(t, err)
 	require.NotNil(t, result)
-	assert.Equal(t, 1, result.Page)
+	assert.Equal(t, 2, result.Page)
+	assert.Equal(t, 6, result.Total)
 	assert.Equal(t, 5, result.PageSize)
 	assert.Len(t, result.Users, 1)
 
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUserUseCases_ListUsers_FromCursor(t *testing.T) {
+	// Given a listing continued after user 20
+	useCases, mockRepo := setupTestUseCases()
+	ctx := context.Background()
+	mockRepo.On("List", ctx, ports.UserFilter{Tags: []string{}, AfterID: 20}, 10, 0).Return([]*entities.User{{ID: 21}}, nil)
+	mockRepo.On("Count", ctx, ports.UserFilter{Tags: []string{}}).Return(int64(21), nil)
+
+	// When
+	result, err := useCases.ListUsers(ctx, dto.UserFilterDTO{AfterID: 20}, 3, 10)
+
+	// Then the page number is ignored and the total still covers every user
+	require.NoError(t, err)
+	assert.Len(t, result.Users, 1)
+	assert.Equal(t, 21, result.Total)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserUseCases_ListUsers_LeavesPageSizeToQuota(t *testing.T) {
+	// Given an admin whose acknowledged override the quota middleware let through
+	useCases, mockRepo := setupTestUseCases()
+	ctx := context.Background()
+	mockRepo.On("List", ctx, ports.UserFilter{Tags: []string{}}, 5000, 0).Return([]*entities.User{}, nil)
+	mockRepo.On("Count", ctx, ports.UserFilter{Tags: []string{}}).Return(int64(0), nil)
+
+	// When
+	result, err := useCases.ListUsers(ctx, dto.UserFilterDTO{}, 0, 5000)
+
+	// Then the page is not capped again
+	require.NoError(t, err)
+	assert.Equal(t, 5000, result.PageSize)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestUserUseCases_ListUsers_RepositoryError(t *testing.T) {
 	// Given
 	useCases, mockRepo := setupTestUseCases()
 	ctx := context.Background()
 
-	mockRepo.On("List", ctx, 10, 1).Return(nil, domainErrors.ErrFailedToListUsers)
+	mockRepo.On("List", ctx, ports.UserFilter{Tags: []string{}}, 10, 0).Return(nil, domainErrors.ErrFailedToListUsers)
 
 	// When
-	result, err := useCases.ListUsers(ctx, 1, 10)
+	result, err := useCases.ListUsers(ctx, dto.UserFilterDTO{}, 1, 10)
 
 	// Then
 	assert.Error(t, err)
@@ -446,10 +883,11 @@ func TestUserUseCases_ListUsers_EmptyResult(t *testing.T) {
 	useCases, mockRepo := setupTestUseCases()
 	ctx := context.Background()
 
-	mockRepo.On("List", ctx, 10, 1).Return([]*entities.User{}, nil)
+	mockRepo.On("List", ctx, ports.UserFilter{Tags: []string{}}, 10, 0).Return([]*entities.User{}, nil)
+	mockRepo.On("Count", ctx, ports.UserFilter{Tags: []string{}}).Return(int64(0), nil)
 
 	// When
-	result, err := useCases.ListUsers(ctx, 1, 10)
+	result, err := useCases.ListUsers(ctx, dto.UserFilterDTO{}, 1, 10)
 
 	// Then
 	require.NoError(t, err)
@@ -461,3 +899,220 @@ func TestUserUseCases_ListUsers_EmptyResult(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+// Tag Tests
+func TestUserUseCases_AddUserTags_PublishesEventForNewTags(t *testing.T) {
+	// Given
+	useCases, mockRepo, mockPublisher := setupTestUseCasesWithPublisher()
+	ctx := context.Background()
+
+	existingUser := &entities.User{
+		ID:     1,
+		Email:  "test@example.com",
+		Status: entities.UserStatusActive,
+		Tags:   []string{"vip"},
+	}
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(existingUser, nil)
+	mockRepo.On("AddTags", ctx, uint(1), []string{"beta_tester"}).Return(nil)
+	mockPublisher.On("Publish", ctx, mock.MatchedBy(func(event *entities.UserEvent) bool {
+		return event.Type == entities.UserEventTagsChanged &&
+			event.UserID == 1 &&
+			assert.ObjectsAreEqual([]string{"beta_tester"}, event.Data["added"])
+	})).Return(nil)
+
+	// When
+	result, err := useCases.AddUserTags(ctx, 1, []string{"VIP", " Beta_Tester "})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"beta_tester", "vip"}, result.Tags)
+
+	mockRepo.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+}
+
+func TestUserUseCases_AddUserTags_NoChangeSkipsEvent(t *testing.T) {
+	// Given
+	useCases, mockRepo, mockPublisher := setupTestUseCasesWithPublisher()
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, Tags: []string{"vip"}}, nil)
+
+	// When
+	result, err := useCases.AddUserTags(ctx, 1, []string{"vip"})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vip"}, result.Tags)
+
+	mockRepo.AssertNotCalled(t, "AddTags", mock.Anything, mock.Anything, mock.Anything)
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestUserUseCases_AddUserTags_InvalidTag(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestUseCases()
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1}, nil)
+
+	// When
+	result, err := useCases.AddUserTags(ctx, 1, []string{"not a tag!"})
+
+	// Then
+	assert.Nil(t, result)
+	assert.Equal(t, domainErrors.ErrInvalidTag, err)
+}
+
+func TestUserUseCases_RemoveUserTags_PublishesEvent(t *testing.T) {
+	// Given
+	useCases, mockRepo, mockPublisher := setupTestUseCasesWithPublisher()
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, Tags: []string{"beta_tester", "vip"}}, nil)
+	mockRepo.On("RemoveTags", ctx, uint(1), []string{"vip"}).Return(nil)
+	mockPublisher.On("Publish", ctx, mock.AnythingOfType("*entities.UserEvent")).Return(nil)
+
+	// When
+	result, err := useCases.RemoveUserTags(ctx, 1, []string{"vip"})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"beta_tester"}, result.Tags)
+
+	mockRepo.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+}
+
+func TestUserUseCases_ListUsers_WithTagFilter(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestUseCases()
+	ctx := context.Background()
+
+	mockRepo.On("List", ctx, ports.UserFilter{Tags: []string{"vip"}}, 10, 0).Return([]*entities.User{}, nil)
+	mockRepo.On("Count", ctx, ports.UserFilter{Tags: []string{"vip"}}).Return(int64(0), nil)
+
+	// When
+	result, err := useCases.ListUsers(ctx, dto.UserFilterDTO{Tags: []string{"VIP"}}, 1, 10)
+
+	// Then
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserUseCases_UpdatePreferences(t *testing.T) {
+	// Given
+	useCases, mockRepo, mockPublisher := setupTestUseCasesWithPublisher()
+	ctx := context.Background()
+	optOut := true
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, Status: entities.UserStatusActive}, nil)
+	mockRepo.On("UpdatePreferences", ctx, uint(1), entities.UserPreferences{SecurityDigestOptOut: true}).Return(nil)
+	mockPublisher.On("Publish", ctx, mock.MatchedBy(func(event *entities.UserEvent) bool {
+		return event.Type == entities.UserEventPreferencesChanged && event.UserID == 1
+	})).Return(nil)
+
+	// When
+	result, err := useCases.UpdatePreferences(ctx, 1, &dto.UpdatePreferencesRequestDTO{SecurityDigestOptOut: &optOut})
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, result.Preferences.SecurityDigestOptOut)
+
+	// And an unchanged preference is not written again
+	mockRepo.On("GetByID", ctx, uint(2)).Return(&entities.User{ID: 2, Preferences: entities.UserPreferences{SecurityDigestOptOut: true}}, nil)
+	_, err = useCases.UpdatePreferences(ctx, 2, &dto.UpdatePreferencesRequestDTO{SecurityDigestOptOut: &optOut})
+	require.NoError(t, err)
+
+	mockRepo.AssertNumberOfCalls(t, "UpdatePreferences", 1)
+	mockPublisher.AssertNumberOfCalls(t, "Publish", 1)
+}
+
+func TestUserUseCases_UpdatePreferences_Localization(t *testing.T) {
+	// Given
+	useCases, mockRepo, mockPublisher := setupTestUseCasesWithPublisher()
+	ctx := context.Background()
+	locale, timezone := "pt-br", "America/Sao_Paulo"
+	current := entities.UserPreferences{Locale: "en-US", Timezone: "UTC"}
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, Status: entities.UserStatusActive, Preferences: current}, nil)
+	mockRepo.On("UpdatePreferences", ctx, uint(1), entities.UserPreferences{Locale: "pt-BR", Timezone: "America/Sao_Paulo"}).Return(nil)
+	mockPublisher.On("Publish", ctx, mock.MatchedBy(func(event *entities.UserEvent) bool {
+		return event.Locale == "pt-BR" && event.Timezone == "America/Sao_Paulo"
+	})).Return(nil)
+
+	// When
+	result, err := useCases.UpdatePreferences(ctx, 1, &dto.UpdatePreferencesRequestDTO{Locale: &locale, Timezone: &timezone})
+
+	// Then the event is localized for the new preferences
+	require.NoError(t, err)
+	assert.Equal(t, "pt-BR", result.Preferences.Locale)
+	mockPublisher.AssertExpectations(t)
+
+	// And an unknown time zone is rejected
+	unknown := "Nowhere/City"
+	_, err = useCases.UpdatePreferences(ctx, 1, &dto.UpdatePreferencesRequestDTO{Timezone: &unknown})
+	assert.Equal(t, domainErrors.ErrInvalidTimezone, err)
+}
+
+func TestUserUseCases_UpdateProfile(t *testing.T) {
+	// Given
+	useCases, mockRepo, mockPublisher := setupTestUseCasesWithPublisher()
+	ctx := context.Background()
+	displayName, pronouns := "JD", "they/them"
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, DisplayName: "JD", Status: entities.UserStatusActive}, nil)
+	mockRepo.On("UpdateProfile", ctx, mock.MatchedBy(func(user *entities.User) bool {
+		return user.ID == 1 && user.DisplayName == "JD" && user.Pronouns == "they/them"
+	})).Return(nil)
+	mockPublisher.On("Publish", ctx, mock.MatchedBy(func(event *entities.UserEvent) bool {
+		changes, _ := event.Data["changes"].([]string)
+		return event.Type == entities.UserEventUpdated && len(changes) == 1 && changes[0] == "pronouns" && event.Data["pronouns"] == nil
+	})).Return(nil)
+
+	// When only the pronouns change
+	result, err := useCases.UpdateProfile(ctx, 1, &dto.UpdateProfileRequestDTO{DisplayName: &displayName, Pronouns: &pronouns})
+
+	// Then the event names the field but leaves its value out
+	require.NoError(t, err)
+	assert.Equal(t, "they/them", result.Pronouns)
+	mockPublisher.AssertExpectations(t)
+
+	// And an unchanged profile is not written again
+	mockRepo.On("GetByID", ctx, uint(2)).Return(&entities.User{ID: 2, DisplayName: "JD"}, nil)
+	_, err = useCases.UpdateProfile(ctx, 2, &dto.UpdateProfileRequestDTO{DisplayName: &displayName})
+	require.NoError(t, err)
+	mockRepo.AssertNumberOfCalls(t, "UpdateProfile", 1)
+}
+
+func TestUserUseCases_UpdateProfile_Country(t *testing.T) {
+	// Given
+	useCases, mockRepo, mockPublisher := setupTestUseCasesWithPublisher()
+	ctx := context.Background()
+	country := "pt"
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, Country: "ES", Phone: "+34 600 12 34 56", Status: entities.UserStatusActive}, nil)
+	mockRepo.On("UpdateProfile", ctx, mock.MatchedBy(func(user *entities.User) bool {
+		return user.ID == 1 && user.Country == "PT" && user.Phone == "+34 600 12 34 56"
+	})).Return(nil)
+	mockPublisher.On("Publish", ctx, mock.MatchedBy(func(event *entities.UserEvent) bool {
+		changes, _ := event.Data["changes"].([]string)
+		return len(changes) == 1 && changes[0] == "country" && event.Country == "PT"
+	})).Return(nil)
+
+	// When the user moves
+	result, err := useCases.UpdateProfile(ctx, 1, &dto.UpdateProfileRequestDTO{Country: &country})
+
+	// Then the country changes but the phone number stays
+	require.NoError(t, err)
+	assert.Equal(t, "PT", result.Country)
+	mockPublisher.AssertExpectations(t)
+
+	// And an unknown country is rejected
+	invalid := "Portugal"
+	_, err = useCases.UpdateProfile(ctx, 1, &dto.UpdateProfileRequestDTO{Country: &invalid})
+	assert.Equal(t, domainErrors.ErrInvalidCountry, err)
+	mockRepo.AssertNumberOfCalls(t, "UpdateProfile", 1)
+}
@@ -0,0 +1,131 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	userErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// VerificationUseCases defines the interface for verification email requests
+type VerificationUseCases interface {
+	// ResendVerification asks for another verification email, at most once
+	// per cooldown; requests within it report the time left instead
+	ResendVerification(ctx context.Context, userID uint) (*dto.VerificationResendResponseDTO, error)
+}
+
+// verificationUseCasesImpl implements VerificationUseCases interface
+type verificationUseCasesImpl struct {
+	userRepo  ports.UserRepository
+	publisher ports.EventPublisher
+	limiter   SensitiveActionLimiter
+	cooldown  time.Duration
+	// requests collapses concurrent resends for the same user into one, keyed
+	// by resendKey
+	requests singleflight.Group
+	logger   logger.Logger
+	now      func() time.Time
+}
+
+// NewVerificationUseCases creates verification use cases allowing one
+// verification email per cooldown; limiter additionally caps resends per
+// account over its window
+func NewVerificationUseCases(userRepo ports.UserRepository, publisher ports.EventPublisher, limiter SensitiveActionLimiter, cooldown time.Duration, log logger.Logger) VerificationUseCases {
+	return &verificationUseCasesImpl{
+		userRepo:  userRepo,
+		publisher: publisher,
+		limiter:   limiter,
+		cooldown:  cooldown,
+		logger:    log.With("component", "verification_usecases"),
+		now:       time.Now,
+	}
+}
+
+// ResendVerification implements VerificationUseCases
+func (uc *verificationUseCasesImpl) ResendVerification(ctx context.Context, userID uint) (*dto.VerificationResendResponseDTO, error) {
+	uc.logger.Info("ResendVerification use case called", "user_id", userID)
+
+	// Concurrent requests for the same user share one attempt and its answer
+	response, err, shared := uc.requests.Do(resendKey(ctx, userID), func() (interface{}, error) {
+		return uc.resend(ctx, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if shared {
+		uc.logger.Debug("Verification resend collapsed with a concurrent request", "user_id", userID)
+	}
+	return response.(*dto.VerificationResendResponseDTO), nil
+}
+
+// resendKey identifies a user within the residency region ctx is scoped to,
+// as user IDs repeat across regions
+func resendKey(ctx context.Context, userID uint) string {
+	key := strconv.FormatUint(uint64(userID), 10)
+	if region, ok := ports.ResidencyFrom(ctx); ok {
+		key = string(region) + ":" + key
+	}
+	return key
+}
+
+// resend sends a verification email unless one went out within the cooldown
+func (uc *verificationUseCasesImpl) resend(ctx context.Context, userID uint) (*dto.VerificationResendResponseDTO, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Status == entities.UserStatusSuspended {
+		return nil, userErrors.ErrUserSuspended
+	}
+
+	// Stored with microsecond precision, so the guard below can match it
+	now := uc.now().UTC().Truncate(time.Microsecond)
+	if remaining := user.VerificationResendIn(now, uc.cooldown); remaining > 0 {
+		return dto.NewVerificationResendResponseDTO(false, now, remaining), nil
+	}
+
+	if err := uc.limiter.Allow(ctx, userID, entities.SensitiveActionVerificationResend); err != nil {
+		return nil, err
+	}
+
+	// Guarded by the previous time, so only one instance sends per cooldown
+	previous := user.VerificationSentAt
+	user.VerificationSentAt = &now
+	if err := uc.userRepo.UpdateVerificationSentAt(ctx, user, previous); err != nil {
+		if errors.Is(err, userErrors.ErrVerificationSentChanged) {
+			return uc.remainingCooldown(ctx, userID, now)
+		}
+		return nil, err
+	}
+
+	event := entities.NewUserEvent(entities.UserEventVerificationRequested, user.ID, nil).About(user)
+	if err := uc.publisher.Publish(ctx, event); err != nil {
+		// Nothing was sent, so the user should not have to wait out the cooldown
+		user.VerificationSentAt = previous
+		if restoreErr := uc.userRepo.UpdateVerificationSentAt(ctx, user, &now); restoreErr != nil {
+			uc.logger.Error("Failed to restore verification cooldown", "user_id", userID, "error", restoreErr)
+		}
+		uc.logger.Error("Failed to publish verification requested event", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	uc.logger.Info("ResendVerification success", "user_id", userID)
+	return dto.NewVerificationResendResponseDTO(true, now, uc.cooldown), nil
+}
+
+// remainingCooldown reports the cooldown started by a request that won the
+// race on another instance
+func (uc *verificationUseCasesImpl) remainingCooldown(ctx context.Context, userID uint, now time.Time) (*dto.VerificationResendResponseDTO, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewVerificationResendResponseDTO(false, now, user.VerificationResendIn(now, uc.cooldown)), nil
+}
@@ -0,0 +1,259 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestVerificationUseCases(now time.Time) (VerificationUseCases, *MockUserRepository, *MockEventPublisher) {
+	mockRepo := new(MockUserRepository)
+	mockPublisher := new(MockEventPublisher)
+	limits := map[entities.SensitiveAction]entities.ActionLimit{
+		entities.SensitiveActionVerificationResend: {Max: 2, Window: time.Hour},
+	}
+	limiter := NewSensitiveActionLimiter(mockRepo, newFakeActionCounterStore(), limits, new(MockAuditLogger), logger.New("test"))
+	limiter.(*sensitiveActionLimiterImpl).now = func() time.Time { return now }
+
+	useCases := NewVerificationUseCases(mockRepo, mockPublisher, limiter, time.Minute, logger.New("test"))
+	useCases.(*verificationUseCasesImpl).now = func() time.Time { return now }
+	return useCases, mockRepo, mockPublisher
+}
+
+func TestVerificationUseCases_ResendVerification_Sends(t *testing.T) {
+	// Given a user who never asked for a verification email
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	useCases, mockRepo, mockPublisher := setupTestVerificationUseCases(now)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, Email: "ada@example.com", Status: entities.UserStatusPending}, nil)
+	mockRepo.On("UpdateVerificationSentAt", ctx, mock.MatchedBy(func(user *entities.User) bool {
+		return user.VerificationSentAt != nil && user.VerificationSentAt.Equal(now)
+	}), (*time.Time)(nil)).Return(nil)
+	mockPublisher.On("Publish", ctx, mock.MatchedBy(func(event *entities.UserEvent) bool {
+		return event.Type == entities.UserEventVerificationRequested && event.Recipient == "ada@example.com"
+	})).Return(nil)
+
+	// When
+	response, err := useCases.ResendVerification(ctx, 1)
+
+	// Then it is sent and the full cooldown starts
+	require.NoError(t, err)
+	assert.True(t, response.Sent)
+	assert.Equal(t, 60, response.CooldownRemainingSeconds)
+	assert.Equal(t, now.Add(time.Minute), response.NextResendAt.Time)
+	mockRepo.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+}
+
+func TestVerificationUseCases_ResendVerification_WithinCooldown(t *testing.T) {
+	// Given a verification email sent 45 seconds ago
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	useCases, mockRepo, mockPublisher := setupTestVerificationUseCases(now)
+	ctx := context.Background()
+
+	sentAt := now.Add(-45 * time.Second)
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, VerificationSentAt: &sentAt}, nil)
+
+	// When
+	response, err := useCases.ResendVerification(ctx, 1)
+
+	// Then nothing is sent and the time left is reported
+	require.NoError(t, err)
+	assert.False(t, response.Sent)
+	assert.Equal(t, 15, response.CooldownRemainingSeconds)
+	mockRepo.AssertNotCalled(t, "UpdateVerificationSentAt", mock.Anything, mock.Anything, mock.Anything)
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestVerificationUseCases_ResendVerification_LostRaceReportsCooldown(t *testing.T) {
+	// Given another instance sends one between the read and the write
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	useCases, mockRepo, mockPublisher := setupTestVerificationUseCases(now)
+	ctx := context.Background()
+
+	winner := now.Add(-time.Second)
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1}, nil).Once()
+	mockRepo.On("UpdateVerificationSentAt", ctx, mock.Anything, (*time.Time)(nil)).Return(domainErrors.ErrVerificationSentChanged)
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, VerificationSentAt: &winner}, nil).Once()
+
+	// When
+	response, err := useCases.ResendVerification(ctx, 1)
+
+	// Then the winner's cooldown is reported and nothing is sent twice
+	require.NoError(t, err)
+	assert.False(t, response.Sent)
+	assert.Equal(t, 59, response.CooldownRemainingSeconds)
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestVerificationUseCases_ResendVerification_PublishFailureRestoresCooldown(t *testing.T) {
+	// Given a publisher that is down
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	useCases, mockRepo, mockPublisher := setupTestVerificationUseCases(now)
+	ctx := context.Background()
+
+	previous := now.Add(-time.Hour)
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, VerificationSentAt: &previous}, nil)
+	mockRepo.On("UpdateVerificationSentAt", ctx, mock.Anything, &previous).Return(nil)
+	mockRepo.On("UpdateVerificationSentAt", ctx, mock.MatchedBy(func(user *entities.User) bool {
+		return user.VerificationSentAt.Equal(previous)
+	}), mock.MatchedBy(func(sent *time.Time) bool { return sent.Equal(now) })).Return(nil)
+	mockPublisher.On("Publish", ctx, mock.Anything).Return(errors.New("broker unavailable"))
+
+	// When
+	response, err := useCases.ResendVerification(ctx, 1)
+
+	// Then the request fails and the previous time is put back
+	assert.Nil(t, response)
+	assert.Error(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVerificationUseCases_ResendVerification_RejectsSuspendedUsers(t *testing.T) {
+	// Given
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	useCases, mockRepo, _ := setupTestVerificationUseCases(now)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.User{ID: 1, Status: entities.UserStatusSuspended}, nil)
+
+	// When
+	_, err := useCases.ResendVerification(ctx, 1)
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrUserSuspended)
+}
+
+func TestVerificationUseCases_ResendVerification_AppliesAccountLimit(t *testing.T) {
+	// Given a user who used up their resends for the hour
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	useCases, mockRepo, mockPublisher := setupTestVerificationUseCases(now)
+	ctx := context.Background()
+
+	// (the cooldown is cleared on every load so only the account limit applies)
+	user := &entities.User{ID: 1}
+	mockRepo.On("GetByID", ctx, uint(1)).Run(func(mock.Arguments) {
+		user.VerificationSentAt = nil
+	}).Return(user, nil)
+	mockRepo.On("UpdateVerificationSentAt", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockPublisher.On("Publish", ctx, mock.Anything).Return(nil)
+	for range 2 {
+		_, err := useCases.ResendVerification(ctx, 1)
+		require.NoError(t, err)
+	}
+
+	// When
+	_, err := useCases.ResendVerification(ctx, 1)
+
+	// Then
+	var limited *domainErrors.ActionRateLimitedError
+	assert.ErrorAs(t, err, &limited)
+}
+
+func TestVerificationUseCases_ResendVerification_CollapsesConcurrentRequests(t *testing.T) {
+	// Given a request that is still loading the user
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	useCases, mockRepo, mockPublisher := setupTestVerificationUseCases(now)
+	ctx := context.Background()
+
+	loading := make(chan struct{})
+	release := make(chan struct{})
+	mockRepo.On("GetByID", ctx, uint(1)).Run(func(mock.Arguments) {
+		close(loading)
+		<-release
+	}).Return(&entities.User{ID: 1}, nil).Once()
+	mockRepo.On("UpdateVerificationSentAt", ctx, mock.Anything, (*time.Time)(nil)).Return(nil).Once()
+	mockPublisher.On("Publish", ctx, mock.Anything).Return(nil).Once()
+
+	// When a second request arrives meanwhile
+	responses := make([]*dto.VerificationResendResponseDTO, 2)
+	var wg sync.WaitGroup
+	for i := range responses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response, err := useCases.ResendVerification(ctx, 1)
+			assert.NoError(t, err)
+			responses[i] = response
+		}()
+		if i == 0 {
+			<-loading
+		}
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// Then both share the one email that was sent
+	require.NotNil(t, responses[0])
+	require.NotNil(t, responses[1])
+	assert.True(t, responses[0].Sent)
+	assert.Equal(t, responses[0], responses[1])
+	mockRepo.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+}
+
+func TestVerificationUseCases_ResendVerification_KeepsRegionsApart(t *testing.T) {
+	// Given a request for a user of one region that is still loading them
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	useCases, mockRepo, mockPublisher := setupTestVerificationUseCases(now)
+	eu := ports.WithResidency(context.Background(), entities.ResidencyEU)
+	us := ports.WithResidency(context.Background(), entities.ResidencyUS)
+
+	loading := make(chan struct{})
+	release := make(chan struct{})
+	mockRepo.On("GetByID", eu, uint(1)).Run(func(mock.Arguments) {
+		close(loading)
+		<-release
+	}).Return(&entities.User{ID: 1, Email: "ada@example.com"}, nil).Once()
+	mockRepo.On("GetByID", us, uint(1)).Return(&entities.User{ID: 1, Email: "grace@example.com"}, nil).Once()
+	mockRepo.On("UpdateVerificationSentAt", mock.Anything, mock.Anything, (*time.Time)(nil)).Return(nil)
+	var recipients []string
+	var mu sync.Mutex
+	mockPublisher.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		mu.Lock()
+		defer mu.Unlock()
+		recipients = append(recipients, args.Get(1).(*entities.UserEvent).Recipient)
+	}).Return(nil)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, err := useCases.ResendVerification(eu, 1)
+		assert.NoError(t, err)
+	}()
+	<-loading
+
+	// When the user with the same ID in another region asks meanwhile
+	done := make(chan *dto.VerificationResendResponseDTO)
+	go func() {
+		response, err := useCases.ResendVerification(us, 1)
+		assert.NoError(t, err)
+		done <- response
+	}()
+
+	// Then their request is not collapsed into the first
+	select {
+	case response := <-done:
+		require.NotNil(t, response)
+		assert.True(t, response.Sent)
+	case <-time.After(time.Second):
+		t.Fatal("request waited for another region's user")
+	}
+	close(release)
+	wg.Wait()
+	assert.ElementsMatch(t, []string{"grace@example.com", "ada@example.com"}, recipients)
+}
@@ -0,0 +1,369 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"user-service/internal/application/dto"
+	"user-service/internal/application/ports"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
+)
+
+// Outcomes of queued writes
+const (
+	writeQueued   = "queued"
+	writeApplied  = "applied"
+	writeRejected = "rejected"
+)
+
+// WriteBehindOptions controls which writes are queued and how often the
+// database is checked
+type WriteBehindOptions struct {
+	// Operations are the writes that may be queued
+	Operations []entities.QueuedWriteOperation
+	// ProbeInterval is how often the database is checked and queued writes
+	// applied
+	ProbeInterval time.Duration
+}
+
+// userWriter is implemented by the use cases of NewUserUseCases. Queued
+// sign-ups are prepared before they are queued and stored once applied.
+type userWriter interface {
+	UserUseCases
+	prepareUser(ctx context.Context, request *dto.CreateUserRequestDTO) (*entities.User, bool, error)
+	createUser(ctx context.Context, user *entities.User, awaitingConsent bool, referralCode string) (*dto.UserResponseDTO, error)
+}
+
+// queuedUserCreation is the payload of a queued sign-up. The user is queued
+// validated and with its password hashed, so no password reaches the disk.
+type queuedUserCreation struct {
+	User            *entities.User `json:"user"`
+	PasswordHash    string         `json:"password_hash"`
+	DateOfBirth     *time.Time     `json:"date_of_birth,omitempty"`
+	AwaitingConsent bool           `json:"awaiting_consent,omitempty"`
+	ReferralCode    string         `json:"referral_code,omitempty"`
+}
+
+// WriteBehind wraps the user use cases so sign-ups and preference and
+// profile changes are accepted while the database is unreachable. They are
+// queued in a durable journal, answered with ErrWriteQueued and applied in
+// order once the database is back. While writes are queued, new ones queue
+// behind them so none overtakes an older one.
+//
+// Sign-ups are validated before they are queued; a sign-up whose email was
+// registered meanwhile is rejected when applied. Updates are checked against
+// the user when applied and rejected when the user changed elsewhere after
+// they were queued. Rejected writes stay in the journal for operators.
+type WriteBehind struct {
+	userWriter
+	userRepo ports.UserRepository
+	journal  ports.WriteJournal
+	// probe fails while the database is unreachable
+	probe   func(ctx context.Context) error
+	options WriteBehindOptions
+	online  atomic.Bool
+	// backlog counts queued writes
+	backlog atomic.Int64
+	// replayed records when a queued write was last applied to each user, so
+	// later writes to the user are not taken for conflicts with it
+	replayed map[uint]time.Time
+	now      func() time.Time
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	logger   logger.Logger
+	metrics  *metrics.Registry
+}
+
+var _ UserUseCases = (*WriteBehind)(nil)
+
+// NewWriteBehind wraps users, which must come from NewUserUseCases
+func NewWriteBehind(users UserUseCases, userRepo ports.UserRepository, journal ports.WriteJournal, probe func(ctx context.Context) error, options WriteBehindOptions, log logger.Logger, registry *metrics.Registry) (*WriteBehind, error) {
+	writer, ok := users.(userWriter)
+	if !ok {
+		return nil, fmt.Errorf("write-behind needs the user use cases of NewUserUseCases, got %T", users)
+	}
+
+	backlog, err := journal.Len(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &WriteBehind{
+		userWriter: writer,
+		userRepo:   userRepo,
+		journal:    journal,
+		probe:      probe,
+		options:    options,
+		replayed:   make(map[uint]time.Time),
+		now:        time.Now,
+		ctx:        ctx,
+		cancel:     cancel,
+		logger:     log.With("component", "write_behind"),
+		metrics:    registry,
+	}
+	w.online.Store(true)
+	w.backlog.Store(int64(backlog))
+	return w, nil
+}
+
+// CreateUser implements UserUseCases
+func (w *WriteBehind) CreateUser(ctx context.Context, request *dto.CreateUserRequestDTO) (*dto.UserResponseDTO, error) {
+	if !w.queues(entities.QueuedCreateUser) {
+		return w.userWriter.CreateUser(ctx, request)
+	}
+
+	user, awaitingConsent, err := w.userWriter.prepareUser(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return nil, w.enqueue(ctx, entities.QueuedCreateUser, 0, queuedUserCreation{
+		User:            user,
+		PasswordHash:    user.Password,
+		DateOfBirth:     user.DateOfBirth,
+		AwaitingConsent: awaitingConsent,
+		ReferralCode:    request.ReferralCode,
+	})
+}
+
+// UpdatePreferences implements UserUseCases
+func (w *WriteBehind) UpdatePreferences(ctx context.Context, id uint, request *dto.UpdatePreferencesRequestDTO) (*dto.UserResponseDTO, error) {
+	if !w.queues(entities.QueuedUpdatePreferences) {
+		return w.userWriter.UpdatePreferences(ctx, id, request)
+	}
+	return nil, w.enqueue(ctx, entities.QueuedUpdatePreferences, id, request)
+}
+
+// UpdateProfile implements UserUseCases
+func (w *WriteBehind) UpdateProfile(ctx context.Context, id uint, request *dto.UpdateProfileRequestDTO) (*dto.UserResponseDTO, error) {
+	if !w.queues(entities.QueuedUpdateProfile) {
+		return w.userWriter.UpdateProfile(ctx, id, request)
+	}
+	return nil, w.enqueue(ctx, entities.QueuedUpdateProfile, id, request)
+}
+
+// queues reports whether writes of operation go to the journal: while the
+// database is unreachable, and until the writes queued meanwhile are applied
+func (w *WriteBehind) queues(operation entities.QueuedWriteOperation) bool {
+	if !slices.Contains(w.options.Operations, operation) {
+		return false
+	}
+	return !w.online.Load() || w.backlog.Load() > 0
+}
+
+// enqueue appends a write to the journal and returns the WriteQueuedError
+// naming it
+func (w *WriteBehind) enqueue(ctx context.Context, operation entities.QueuedWriteOperation, userID uint, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return domainErrors.ErrFailedToQueueWrite
+	}
+
+	region, _ := ports.ResidencyFrom(ctx)
+	write := &entities.QueuedWrite{
+		Operation: operation,
+		UserID:    userID,
+		Residency: region,
+		Payload:   data,
+		QueuedAt:  w.now().UTC(),
+	}
+	if err := w.journal.Append(ctx, write); err != nil {
+		w.logger.Error("Failed to queue write", "operation", operation, "user_id", userID, "error", err)
+		return err
+	}
+	w.backlog.Add(1)
+	w.metrics.Counter("write_behind_total").Inc("operation", string(operation), "outcome", writeQueued)
+
+	w.logger.Info("Write queued", "write_id", write.ID, "operation", operation, "user_id", userID)
+	return &domainErrors.WriteQueuedError{ID: write.ID}
+}
+
+// Start checks the database every probe interval and applies queued writes
+// once it is reachable, starting with the writes left from earlier runs
+func (w *WriteBehind) Start() {
+	w.logger.Info("Offline writes enabled", "operations", w.options.Operations, "queued", w.backlog.Load())
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.options.ProbeInterval)
+		defer ticker.Stop()
+
+		for {
+			w.check(w.ctx)
+			select {
+			case <-w.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops checking the database, waiting for writes being applied until
+// ctx ends. Writes still queued are applied after the next start.
+func (w *WriteBehind) Stop(ctx context.Context) error {
+	w.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("write-behind did not stop in time: %w", ctx.Err())
+	}
+}
+
+// check probes the database and applies queued writes when it is reachable
+func (w *WriteBehind) check(ctx context.Context) {
+	probeCtx, cancel := context.WithTimeout(ctx, w.options.ProbeInterval)
+	err := w.probe(probeCtx)
+	cancel()
+	wasOnline := w.online.Swap(err == nil)
+	if err != nil {
+		if wasOnline {
+			w.logger.Warn("Database unreachable, queueing writes", "error", err)
+		}
+		return
+	}
+	if !wasOnline {
+		w.logger.Info("Database reachable again", "queued", w.backlog.Load())
+	}
+
+	if w.backlog.Load() > 0 {
+		w.replay(ctx)
+	}
+}
+
+// replay applies queued writes in order. It stops at the first write that
+// fails for a reason that may pass, such as the database going away again,
+// and leaves it queued for the next check. Writes that cannot be applied are
+// rejected.
+func (w *WriteBehind) replay(ctx context.Context) {
+	writes, err := w.journal.Pending(ctx)
+	if err != nil {
+		w.logger.Error("Failed to read queued writes", "error", err)
+		return
+	}
+
+	for _, write := range writes {
+		err := w.apply(ctx, write)
+		if err != nil && transient(err) {
+			w.logger.Warn("Applying queued writes paused", "write_id", write.ID, "error", err)
+			return
+		}
+
+		outcome := writeApplied
+		if err != nil {
+			outcome = writeRejected
+			write.Rejection = err.Error()
+			err = w.journal.Reject(ctx, write)
+		} else {
+			err = w.journal.Complete(ctx, write.ID)
+		}
+		if err != nil {
+			// The write would be applied again and, for sign-ups, rejected as
+			// a duplicate
+			w.logger.Error("Failed to remove queued write", "write_id", write.ID, "error", err)
+			return
+		}
+
+		w.backlog.Add(-1)
+		w.metrics.Counter("write_behind_total").Inc("operation", string(write.Operation), "outcome", outcome)
+		if outcome == writeRejected {
+			w.logger.Warn("Queued write rejected", "write_id", write.ID, "operation", write.Operation, "user_id", write.UserID, "reason", write.Rejection)
+		}
+	}
+
+	w.logger.Info("Queued writes applied", "count", len(writes))
+}
+
+// apply performs a queued write in the region it was accepted in
+func (w *WriteBehind) apply(ctx context.Context, write *entities.QueuedWrite) error {
+	if write.Residency != "" {
+		ctx = ports.WithResidency(ctx, write.Residency)
+	}
+
+	switch write.Operation {
+	case entities.QueuedCreateUser:
+		var payload queuedUserCreation
+		if err := json.Unmarshal(write.Payload, &payload); err != nil || payload.User == nil {
+			return domainErrors.ErrMalformedQueuedWrite
+		}
+		payload.User.Password = payload.PasswordHash
+		payload.User.DateOfBirth = payload.DateOfBirth
+		_, err := w.userWriter.createUser(ctx, payload.User, payload.AwaitingConsent, payload.ReferralCode)
+		return err
+
+	case entities.QueuedUpdatePreferences:
+		var request dto.UpdatePreferencesRequestDTO
+		if err := json.Unmarshal(write.Payload, &request); err != nil {
+			return domainErrors.ErrMalformedQueuedWrite
+		}
+		return w.update(ctx, write, func() error {
+			_, err := w.userWriter.UpdatePreferences(ctx, write.UserID, &request)
+			return err
+		})
+
+	case entities.QueuedUpdateProfile:
+		var request dto.UpdateProfileRequestDTO
+		if err := json.Unmarshal(write.Payload, &request); err != nil {
+			return domainErrors.ErrMalformedQueuedWrite
+		}
+		return w.update(ctx, write, func() error {
+			_, err := w.userWriter.UpdateProfile(ctx, write.UserID, &request)
+			return err
+		})
+
+	default:
+		return domainErrors.ErrMalformedQueuedWrite
+	}
+}
+
+// update applies a queued update unless the user changed after it was queued
+// other than by an earlier queued write
+func (w *WriteBehind) update(ctx context.Context, write *entities.QueuedWrite, apply func() error) error {
+	user, err := w.userRepo.GetByID(ctx, write.UserID)
+	if err != nil {
+		return err
+	}
+	if user.UpdatedAt.After(write.QueuedAt) {
+		if last, ok := w.replayed[write.UserID]; !ok || user.UpdatedAt.After(last) {
+			return domainErrors.ErrQueuedWriteConflict
+		}
+	}
+
+	if err := apply(); err != nil {
+		return err
+	}
+	w.replayed[write.UserID] = w.now()
+	return nil
+}
+
+// transient reports whether a write failed for a reason that may pass. The
+// repositories report infrastructure failures as FAILED_TO_* domain errors or
+// as the driver's own errors.
+func transient(err error) bool {
+	var domainErr *domainErrors.DomainError
+	if !errors.As(err, &domainErr) {
+		return true
+	}
+	return strings.HasPrefix(domainErr.Code, "FAILED_TO_")
+}
@@ -0,0 +1,351 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"user-service/internal/application/dto"
+	"user-service/internal/domain/entities"
+	domainErrors "user-service/internal/domain/errors"
+	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryWriteJournal keeps queued writes in memory
+type memoryWriteJournal struct {
+	pending  []*entities.QueuedWrite
+	rejected []*entities.QueuedWrite
+	next     int
+}
+
+func (j *memoryWriteJournal) Append(ctx context.Context, write *entities.QueuedWrite) error {
+	j.next++
+	write.ID = fmt.Sprintf("%020d", j.next)
+	j.pending = append(j.pending, write)
+	return nil
+}
+
+func (j *memoryWriteJournal) Pending(ctx context.Context) ([]*entities.QueuedWrite, error) {
+	return append([]*entities.QueuedWrite(nil), j.pending...), nil
+}
+
+func (j *memoryWriteJournal) Complete(ctx context.Context, id string) error {
+	for i, write := range j.pending {
+		if write.ID == id {
+			j.pending = append(j.pending[:i], j.pending[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (j *memoryWriteJournal) Reject(ctx context.Context, write *entities.QueuedWrite) error {
+	j.rejected = append(j.rejected, write)
+	return j.Complete(ctx, write.ID)
+}
+
+func (j *memoryWriteJournal) Len(ctx context.Context) (int, error) {
+	return len(j.pending), nil
+}
+
+// testProbe stands in for the database health check
+type testProbe struct {
+	err error
+}
+
+func (p *testProbe) check(ctx context.Context) error {
+	return p.err
+}
+
+func setupTestWriteBehind(t *testing.T) (*WriteBehind, *MockUserRepository, *MockEventPublisher, *memoryWriteJournal, *testProbe, *metrics.Registry) {
+	useCases, mockRepo, mockPublisher := setupTestUseCasesWithPublisher()
+	journal := &memoryWriteJournal{}
+	probe := &testProbe{}
+	registry := metrics.NewRegistry()
+
+	writeBehind, err := NewWriteBehind(useCases, mockRepo, journal, probe.check, WriteBehindOptions{
+		Operations:    entities.QueuedWriteOperations,
+		ProbeInterval: time.Second,
+	}, logger.New("test"), registry)
+	require.NoError(t, err)
+	writeBehind.now = func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) }
+	return writeBehind, mockRepo, mockPublisher, journal, probe, registry
+}
+
+func TestWriteBehind_PassesWritesThroughWhileOnline(t *testing.T) {
+	// Given a reachable database and nothing queued
+	writeBehind, mockRepo, _, journal, _, _ := setupTestWriteBehind(t)
+	ctx := context.Background()
+	mockRepo.On("ExistsByEmail", ctx, "ada@example.com").Return(false, nil)
+	mockRepo.On("Create", ctx, mock.Anything).Return(&entities.User{ID: 1, Email: "ada@example.com"}, nil)
+
+	// When
+	result, err := writeBehind.CreateUser(ctx, &dto.CreateUserRequestDTO{
+		Email:     "ada@example.com",
+		Password:  "SecurePass123",
+		FirstName: "Ada",
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), result.ID)
+	assert.Empty(t, journal.pending)
+}
+
+func TestWriteBehind_QueuesSignUpsWhileOffline(t *testing.T) {
+	// Given an unreachable database
+	writeBehind, mockRepo, _, journal, probe, registry := setupTestWriteBehind(t)
+	ctx := context.Background()
+	probe.err = errors.New("connection refused")
+	writeBehind.check(ctx)
+
+	// When
+	_, err := writeBehind.CreateUser(ctx, &dto.CreateUserRequestDTO{
+		Email:     "ada@example.com",
+		Password:  "SecurePass123",
+		FirstName: "Ada",
+	})
+
+	// Then the sign-up is queued with its password hashed
+	var queued *domainErrors.WriteQueuedError
+	require.ErrorAs(t, err, &queued)
+	assert.ErrorIs(t, err, domainErrors.ErrWriteQueued)
+	require.Len(t, journal.pending, 1)
+	assert.Equal(t, queued.ID, journal.pending[0].ID)
+	assert.NotContains(t, string(journal.pending[0].Payload), "SecurePass123")
+	assert.Equal(t, uint64(1), registry.Counter("write_behind_total").Value("operation", "create_user", "outcome", writeQueued))
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestWriteBehind_QueuedSignUpIsValidated(t *testing.T) {
+	// Given an unreachable database
+	writeBehind, _, _, journal, probe, _ := setupTestWriteBehind(t)
+	ctx := context.Background()
+	probe.err = errors.New("connection refused")
+	writeBehind.check(ctx)
+
+	// When the sign-up is invalid
+	_, err := writeBehind.CreateUser(ctx, &dto.CreateUserRequestDTO{
+		Email:     "ada@example.com",
+		Password:  "short",
+		FirstName: "Ada",
+	})
+
+	// Then it is refused rather than queued
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, domainErrors.ErrWriteQueued)
+	assert.Empty(t, journal.pending)
+}
+
+func TestWriteBehind_ReplaysQueuedWritesInOrder(t *testing.T) {
+	// Given a sign-up and a preference change queued while offline
+	writeBehind, mockRepo, mockPublisher, journal, probe, registry := setupTestWriteBehind(t)
+	ctx := context.Background()
+	probe.err = errors.New("connection refused")
+	writeBehind.check(ctx)
+
+	_, err := writeBehind.CreateUser(ctx, &dto.CreateUserRequestDTO{
+		Email:     "ada@example.com",
+		Password:  "SecurePass123",
+		FirstName: "Ada",
+	})
+	require.ErrorIs(t, err, domainErrors.ErrWriteQueued)
+	optOut := true
+	_, err = writeBehind.UpdatePreferences(ctx, 2, &dto.UpdatePreferencesRequestDTO{SecurityDigestOptOut: &optOut})
+	require.ErrorIs(t, err, domainErrors.ErrWriteQueued)
+
+	var order []string
+	mockRepo.On("ExistsByEmail", mock.Anything, "ada@example.com").Return(false, nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(user *entities.User) bool {
+		return user.Email == "ada@example.com" && user.Password != "" && user.Password != "SecurePass123"
+	})).Run(func(mock.Arguments) { order = append(order, "create") }).Return(&entities.User{ID: 1, Email: "ada@example.com"}, nil)
+	mockRepo.On("GetByID", mock.Anything, uint(2)).Return(&entities.User{ID: 2, UpdatedAt: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)}, nil)
+	mockRepo.On("UpdatePreferences", mock.Anything, uint(2), mock.MatchedBy(func(preferences entities.UserPreferences) bool {
+		return preferences.SecurityDigestOptOut
+	})).Run(func(mock.Arguments) { order = append(order, "preferences") }).Return(nil)
+	mockPublisher.On("Publish", mock.Anything, mock.Anything).Return(nil)
+
+	// When the database is back
+	probe.err = nil
+	writeBehind.check(ctx)
+
+	// Then both writes are applied in the order they were queued
+	assert.Equal(t, []string{"create", "preferences"}, order)
+	assert.Empty(t, journal.pending)
+	assert.Empty(t, journal.rejected)
+	assert.Equal(t, uint64(2), registry.Counter("write_behind_total").Value("operation", "create_user", "outcome", writeApplied)+
+		registry.Counter("write_behind_total").Value("operation", "update_preferences", "outcome", writeApplied))
+
+	// And new writes are no longer queued
+	mockRepo.On("ExistsByEmail", ctx, "grace@example.com").Return(false, nil)
+	mockRepo.On("Create", ctx, mock.Anything).Return(&entities.User{ID: 3, Email: "grace@example.com"}, nil)
+	_, err = writeBehind.CreateUser(ctx, &dto.CreateUserRequestDTO{
+		Email:     "grace@example.com",
+		Password:  "SecurePass123",
+		FirstName: "Grace",
+	})
+	assert.NoError(t, err)
+}
+
+func TestWriteBehind_RejectsSignUpsForEmailsRegisteredMeanwhile(t *testing.T) {
+	// Given a sign-up and a preference change queued while offline
+	writeBehind, mockRepo, mockPublisher, journal, probe, registry := setupTestWriteBehind(t)
+	ctx := context.Background()
+	probe.err = errors.New("connection refused")
+	writeBehind.check(ctx)
+
+	_, err := writeBehind.CreateUser(ctx, &dto.CreateUserRequestDTO{
+		Email:     "ada@example.com",
+		Password:  "SecurePass123",
+		FirstName: "Ada",
+	})
+	require.ErrorIs(t, err, domainErrors.ErrWriteQueued)
+	optOut := true
+	_, err = writeBehind.UpdatePreferences(ctx, 2, &dto.UpdatePreferencesRequestDTO{SecurityDigestOptOut: &optOut})
+	require.ErrorIs(t, err, domainErrors.ErrWriteQueued)
+
+	// And the email registered through another instance meanwhile
+	mockRepo.On("ExistsByEmail", mock.Anything, "ada@example.com").Return(true, nil)
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrUserAlreadyExists)
+	mockRepo.On("GetByID", mock.Anything, uint(2)).Return(&entities.User{ID: 2, UpdatedAt: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)}, nil)
+	mockRepo.On("UpdatePreferences", mock.Anything, uint(2), mock.Anything).Return(nil)
+	mockPublisher.On("Publish", mock.Anything, mock.Anything).Return(nil)
+
+	// When the database is back
+	probe.err = nil
+	writeBehind.check(ctx)
+
+	// Then the sign-up is rejected and the writes behind it still applied
+	require.Len(t, journal.rejected, 1)
+	assert.Equal(t, entities.QueuedCreateUser, journal.rejected[0].Operation)
+	assert.Empty(t, journal.pending)
+	assert.Equal(t, uint64(1), registry.Counter("write_behind_total").Value("operation", "update_preferences", "outcome", writeApplied))
+	mockRepo.AssertCalled(t, "UpdatePreferences", mock.Anything, uint(2), mock.Anything)
+
+	// And new writes are no longer queued
+	_, err = writeBehind.UpdatePreferences(ctx, 2, &dto.UpdatePreferencesRequestDTO{SecurityDigestOptOut: &optOut})
+	assert.NoError(t, err)
+}
+
+func TestWriteBehind_KeepsSignUpsQueuedWhenTheExistenceCheckFails(t *testing.T) {
+	// Given a sign-up queued while offline
+	writeBehind, mockRepo, _, journal, probe, registry := setupTestWriteBehind(t)
+	ctx := context.Background()
+	probe.err = errors.New("connection refused")
+	writeBehind.check(ctx)
+
+	_, err := writeBehind.CreateUser(ctx, &dto.CreateUserRequestDTO{
+		Email:     "ada@example.com",
+		Password:  "SecurePass123",
+		FirstName: "Ada",
+	})
+	require.ErrorIs(t, err, domainErrors.ErrWriteQueued)
+
+	// And a database that flaps again during replay
+	mockRepo.On("ExistsByEmail", mock.Anything, "ada@example.com").Return(false, domainErrors.ErrFailedToCheckUserExistance)
+
+	// When the database is reported back
+	probe.err = nil
+	writeBehind.check(ctx)
+
+	// Then the sign-up stays queued for the next attempt instead of being rejected
+	assert.Len(t, journal.pending, 1)
+	assert.Empty(t, journal.rejected)
+	assert.Zero(t, registry.Counter("write_behind_total").Value("operation", "create_user", "outcome", writeRejected))
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestWriteBehind_QueuesBehindBacklog(t *testing.T) {
+	// Given a write left queued when the database came back
+	writeBehind, mockRepo, _, journal, probe, _ := setupTestWriteBehind(t)
+	ctx := context.Background()
+	probe.err = errors.New("connection refused")
+	writeBehind.check(ctx)
+	optOut := true
+	_, err := writeBehind.UpdatePreferences(ctx, 2, &dto.UpdatePreferencesRequestDTO{SecurityDigestOptOut: &optOut})
+	require.ErrorIs(t, err, domainErrors.ErrWriteQueued)
+
+	mockRepo.On("GetByID", mock.Anything, uint(2)).Return(nil, domainErrors.ErrFailedToCheckUserExistance)
+	probe.err = nil
+	writeBehind.check(ctx)
+
+	// When another write to the user arrives
+	_, err = writeBehind.UpdatePreferences(ctx, 2, &dto.UpdatePreferencesRequestDTO{SecurityDigestOptOut: &optOut})
+
+	// Then it queues behind the first instead of overtaking it
+	assert.ErrorIs(t, err, domainErrors.ErrWriteQueued)
+	assert.Len(t, journal.pending, 2)
+}
+
+func TestWriteBehind_TransientFailureKeepsWritesQueued(t *testing.T) {
+	// Given two queued updates
+	writeBehind, mockRepo, _, journal, probe, _ := setupTestWriteBehind(t)
+	ctx := context.Background()
+	probe.err = errors.New("connection refused")
+	writeBehind.check(ctx)
+	optOut := true
+	for _, id := range []uint{2, 3} {
+		_, err := writeBehind.UpdatePreferences(ctx, id, &dto.UpdatePreferencesRequestDTO{SecurityDigestOptOut: &optOut})
+		require.ErrorIs(t, err, domainErrors.ErrWriteQueued)
+	}
+
+	// When the database fails again while the first is applied
+	mockRepo.On("GetByID", mock.Anything, uint(2)).Return(nil, errors.New("connection reset"))
+	probe.err = nil
+	writeBehind.check(ctx)
+
+	// Then both stay queued, in order, for the next attempt
+	require.Len(t, journal.pending, 2)
+	assert.Equal(t, uint(2), journal.pending[0].UserID)
+	assert.Empty(t, journal.rejected)
+	mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, uint(3))
+}
+
+func TestWriteBehind_RejectsStaleUpdates(t *testing.T) {
+	// Given an update queued before the user changed elsewhere
+	writeBehind, mockRepo, _, journal, probe, registry := setupTestWriteBehind(t)
+	ctx := context.Background()
+	probe.err = errors.New("connection refused")
+	writeBehind.check(ctx)
+	name := "Ada"
+	_, err := writeBehind.UpdateProfile(ctx, 2, &dto.UpdateProfileRequestDTO{DisplayName: &name})
+	require.ErrorIs(t, err, domainErrors.ErrWriteQueued)
+
+	mockRepo.On("GetByID", mock.Anything, uint(2)).Return(&entities.User{ID: 2, UpdatedAt: time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)}, nil)
+
+	// When the database is back
+	probe.err = nil
+	writeBehind.check(ctx)
+
+	// Then the update is rejected and kept for review
+	assert.Empty(t, journal.pending)
+	require.Len(t, journal.rejected, 1)
+	assert.Equal(t, domainErrors.ErrQueuedWriteConflict.Error(), journal.rejected[0].Rejection)
+	assert.Equal(t, uint64(1), registry.Counter("write_behind_total").Value("operation", "update_profile", "outcome", writeRejected))
+	mockRepo.AssertNotCalled(t, "UpdateProfile", mock.Anything, mock.Anything)
+}
+
+func TestWriteBehind_OnlyQueuesConfiguredOperations(t *testing.T) {
+	// Given only sign-ups may be queued
+	writeBehind, mockRepo, _, journal, probe, _ := setupTestWriteBehind(t)
+	writeBehind.options.Operations = []entities.QueuedWriteOperation{entities.QueuedCreateUser}
+	ctx := context.Background()
+	probe.err = errors.New("connection refused")
+	writeBehind.check(ctx)
+	mockRepo.On("GetByID", ctx, uint(2)).Return(nil, domainErrors.ErrFailedToCheckUserExistance)
+
+	// When
+	optOut := true
+	_, err := writeBehind.UpdatePreferences(ctx, 2, &dto.UpdatePreferencesRequestDTO{SecurityDigestOptOut: &optOut})
+
+	// Then the update fails as it would without write-behind
+	assert.ErrorIs(t, err, domainErrors.ErrFailedToCheckUserExistance)
+	assert.Empty(t, journal.pending)
+}
@@ -0,0 +1,50 @@
+package config
+
+import "fmt"
+
+// Age gate policies
+const (
+	AgeGatePolicyReject          = "reject"
+	AgeGatePolicyGuardianConsent = "guardian_consent"
+)
+
+// AgeGateConfig controls what happens to users who sign up with a date of
+// birth below a minimum age, as COPPA and GDPR-K require: they are rejected
+// or kept pending until a guardian consents. Regions are residency regions
+// whose law differs from the default.
+type AgeGateConfig struct {
+	// MinimumAge gates users younger than it; 0 disables the gate
+	MinimumAge int    `mapstructure:"minimum_age"`
+	Policy     string `mapstructure:"policy"`
+	// Regions override the gate for users resident in them
+	Regions map[string]RegionAgeGateConfig `mapstructure:"regions"`
+}
+
+// RegionAgeGateConfig is the age gate of one residency region
+type RegionAgeGateConfig struct {
+	MinimumAge int    `mapstructure:"minimum_age"`
+	Policy     string `mapstructure:"policy"`
+}
+
+// Validate checks the default gate and every regional one
+func (c AgeGateConfig) Validate() error {
+	if err := validateAgeGate("profile.age_gate", c.MinimumAge, c.Policy); err != nil {
+		return err
+	}
+	for region, gate := range c.Regions {
+		if err := validateAgeGate("profile.age_gate.regions."+region, gate.MinimumAge, gate.Policy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateAgeGate(key string, minimumAge int, policy string) error {
+	if minimumAge < 0 || minimumAge > 21 {
+		return fmt.Errorf("%s.minimum_age: %d must be between 0 and 21", key, minimumAge)
+	}
+	if policy != AgeGatePolicyReject && policy != AgeGatePolicyGuardianConsent {
+		return fmt.Errorf("%s.policy: %q must be reject or guardian_consent", key, policy)
+	}
+	return nil
+}
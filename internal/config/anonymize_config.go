@@ -0,0 +1,17 @@
+package config
+
+import "github.com/spf13/viper"
+
+// AnonymizeConfig configures the anonymized dump used to seed staging
+type AnonymizeConfig struct {
+	// Salt keys the fake value derivation; keep it secret and stable so the same
+	// email maps to the same fake across dumps. Prefer
+	// USER_SERVICE_ANONYMIZE_SALT_FILE.
+	Salt         string `mapstructure:"salt"`
+	TargetSchema string `mapstructure:"target_schema"`
+}
+
+func AnonymizeDefaults(v *viper.Viper) {
+	v.SetDefault("anonymize.salt", "")
+	v.SetDefault("anonymize.target_schema", "staging")
+}
@@ -0,0 +1,13 @@
+package config
+
+// APIKeyConfig maps a static API key to a named caller and role
+type APIKeyConfig struct {
+	Name string `mapstructure:"name"`
+	Key  string `mapstructure:"key"`
+	Role string `mapstructure:"role"`
+	// Tenant is the customer the key's calls are billed to; it defaults to
+	// the key's name
+	Tenant string `mapstructure:"tenant"`
+	// Attributes describe the caller to authorization policies, e.g. region
+	Attributes map[string]string `mapstructure:"attributes"`
+}
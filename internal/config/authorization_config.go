@@ -0,0 +1,154 @@
+package config
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Authorization modes
+const (
+	// AuthorizationModeRBAC checks routes against their allowed roles only
+	AuthorizationModeRBAC = "rbac"
+	// AuthorizationModeDryRun evaluates policies next to the role checks and
+	// logs where they disagree, while the role checks still decide
+	AuthorizationModeDryRun = "dry_run"
+	// AuthorizationModeEnforce lets policies decide
+	AuthorizationModeEnforce = "enforce"
+)
+
+// Policy condition operators
+const (
+	PolicyOperatorIn    = "in"
+	PolicyOperatorNotIn = "not_in"
+	// PolicyOperatorCIDR matches IP attributes against CIDR ranges
+	PolicyOperatorCIDR = "cidr"
+)
+
+// AuthorizationConfig controls attribute-based access control. Policies are
+// evaluated over the subject (the API key's name, role and attributes), the
+// action a route performs, the resource it acts on and the request context.
+type AuthorizationConfig struct {
+	Mode string `mapstructure:"mode"`
+	// PolicyFile is a YAML or JSON bundle with a top-level policies list,
+	// added to the policies below
+	PolicyFile string         `mapstructure:"policy_file"`
+	Policies   []PolicyConfig `mapstructure:"policies"`
+	// DecisionLog logs every policy decision, outside rbac mode
+	DecisionLog bool `mapstructure:"decision_log"`
+}
+
+// PolicyConfig permits or forbids actions. A request matches a policy when the
+// subject has one of its roles (any role when empty), the action matches one
+// of its actions and every condition holds. A matching forbid policy wins over
+// any permit; without a matching permit the request is denied.
+type PolicyConfig struct {
+	ID     string   `mapstructure:"id"`
+	Effect string   `mapstructure:"effect"` // permit or forbid
+	Roles  []string `mapstructure:"roles"`
+	// Actions are action names such as users.delete; "users.*" matches every
+	// users action and "*" every action
+	Actions    []string                `mapstructure:"actions"`
+	Conditions []PolicyConditionConfig `mapstructure:"when"`
+}
+
+// PolicyConditionConfig tests one attribute, e.g. context.ip or
+// subject.region. Values starting with "$" name another attribute, so
+// "$subject.region" compares with the subject's region.
+type PolicyConditionConfig struct {
+	Attribute string   `mapstructure:"attribute"`
+	Operator  string   `mapstructure:"operator"`
+	Values    []string `mapstructure:"values"`
+}
+
+// policyAttributeScopes are the prefixes condition attributes start with
+var policyAttributeScopes = []string{"subject.", "resource.", "context."}
+
+// Validate checks the mode and the shape of every policy
+func (c AuthorizationConfig) Validate() error {
+	switch c.Mode {
+	case AuthorizationModeRBAC, AuthorizationModeDryRun, AuthorizationModeEnforce:
+	default:
+		return fmt.Errorf("authorization.mode: %q must be rbac, dry_run or enforce", c.Mode)
+	}
+
+	ids := make(map[string]bool, len(c.Policies))
+	for _, policy := range c.Policies {
+		if policy.ID == "" {
+			return fmt.Errorf("authorization.policies: every policy needs an id")
+		}
+		if ids[policy.ID] {
+			return fmt.Errorf("authorization.policies: duplicate policy id %q", policy.ID)
+		}
+		ids[policy.ID] = true
+
+		if policy.Effect != "permit" && policy.Effect != "forbid" {
+			return fmt.Errorf("authorization.policies.%s.effect: %q must be permit or forbid", policy.ID, policy.Effect)
+		}
+		if len(policy.Actions) == 0 {
+			return fmt.Errorf("authorization.policies.%s.actions: at least one action is required", policy.ID)
+		}
+		for _, condition := range policy.Conditions {
+			if err := condition.validate(); err != nil {
+				return fmt.Errorf("authorization.policies.%s.when: %w", policy.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (c PolicyConditionConfig) validate() error {
+	if !slices.ContainsFunc(policyAttributeScopes, func(scope string) bool { return strings.HasPrefix(c.Attribute, scope) }) {
+		return fmt.Errorf("attribute %q must start with subject., resource. or context.", c.Attribute)
+	}
+	if len(c.Values) == 0 {
+		return fmt.Errorf("attribute %q: at least one value is required", c.Attribute)
+	}
+
+	switch c.Operator {
+	case PolicyOperatorIn, PolicyOperatorNotIn:
+	case PolicyOperatorCIDR:
+		for _, value := range c.Values {
+			if _, err := netip.ParsePrefix(value); err != nil {
+				return fmt.Errorf("attribute %q: %q is not a CIDR range", c.Attribute, value)
+			}
+		}
+	default:
+		return fmt.Errorf("attribute %q: operator %q must be in, not_in or cidr", c.Attribute, c.Operator)
+	}
+	return nil
+}
+
+// policyBundle is the layout of authorization.policy_file
+type policyBundle struct {
+	Policies []PolicyConfig `mapstructure:"policies"`
+}
+
+// loadPolicyFile adds the policies of the configured bundle, if any
+func (c *AuthorizationConfig) loadPolicyFile() error {
+	if c.PolicyFile == "" {
+		return nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(c.PolicyFile)
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read authorization.policy_file: %w", err)
+	}
+
+	var bundle policyBundle
+	if err := v.Unmarshal(&bundle); err != nil {
+		return fmt.Errorf("failed to parse authorization.policy_file: %w", err)
+	}
+	c.Policies = append(c.Policies, bundle.Policies...)
+	return nil
+}
+
+func AuthorizationDefaults(v *viper.Viper) {
+	v.SetDefault("authorization.mode", AuthorizationModeRBAC)
+	v.SetDefault("authorization.policy_file", "")
+	v.SetDefault("authorization.decision_log", true)
+}
@@ -0,0 +1,28 @@
+package config
+
+import "github.com/spf13/viper"
+
+// BackupConfig configures snapshot export and restore
+type BackupConfig struct {
+	// EncryptionKey is a base64 encoded 32-byte key; prefer providing it through
+	// USER_SERVICE_BACKUP_ENCRYPTION_KEY_FILE
+	EncryptionKey string         `mapstructure:"encryption_key"`
+	S3            BackupS3Config `mapstructure:"s3"`
+}
+
+// BackupS3Config locates the S3 service. Credentials are read from the
+// standard AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN
+// environment variables.
+type BackupS3Config struct {
+	Region string `mapstructure:"region"`
+	// Endpoint targets an S3 compatible service such as MinIO
+	Endpoint  string `mapstructure:"endpoint"`
+	PathStyle bool   `mapstructure:"path_style"`
+}
+
+func BackupDefaults(v *viper.Viper) {
+	v.SetDefault("backup.encryption_key", "")
+	v.SetDefault("backup.s3.region", "us-east-1")
+	v.SetDefault("backup.s3.endpoint", "")
+	v.SetDefault("backup.s3.path_style", false)
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// BindingConfig controls how strictly request bodies are decoded
+type BindingConfig struct {
+	// RejectUnknownFields fails requests whose JSON body carries fields the
+	// endpoint does not accept, so misspelled fields are not silently dropped
+	RejectUnknownFields bool `mapstructure:"reject_unknown_fields"`
+	// LenientRoutes ignore unknown fields even when RejectUnknownFields is set.
+	// Entries are "METHOD /route", using the route pattern, e.g.
+	// "PUT /api/v1/internal/users/sync".
+	LenientRoutes []string `mapstructure:"lenient_routes"`
+}
+
+// Validate rejects lenient routes that are not "METHOD /route"
+func (c BindingConfig) Validate() error {
+	for _, route := range c.LenientRoutes {
+		method, path, ok := strings.Cut(route, " ")
+		if !ok || method == "" || !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("binding.lenient_routes: %q must be \"METHOD /route\"", route)
+		}
+	}
+	return nil
+}
+
+func BindingDefaults(v *viper.Viper) {
+	v.SetDefault("binding.reject_unknown_fields", true)
+	v.SetDefault("binding.lenient_routes", []string{})
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+type BotDetectionConfig struct {
+	Enabled           bool          `mapstructure:"enabled"`
+	HoneypotFields    []string      `mapstructure:"honeypot_fields"`
+	TimestampField    string        `mapstructure:"timestamp_field"`
+	MinSubmitTime     time.Duration `mapstructure:"min_submit_time"`
+	BlockedUserAgents []string      `mapstructure:"blocked_user_agents"`
+	BlockedIPs        []string      `mapstructure:"blocked_ips"`
+}
+
+func BotDetectionDefaults(v *viper.Viper) {
+	v.SetDefault("security.bot_detection.enabled", false)
+	v.SetDefault("security.bot_detection.honeypot_fields", []string{"website"})
+	v.SetDefault("security.bot_detection.timestamp_field", "form_rendered_at")
+	v.SetDefault("security.bot_detection.min_submit_time", 2*time.Second)
+	v.SetDefault("security.bot_detection.blocked_user_agents", []string{})
+	v.SetDefault("security.bot_detection.blocked_ips", []string{})
+}
@@ -0,0 +1,23 @@
+package config
+
+import "github.com/spf13/viper"
+
+// BulkConfig bounds bulk user imports
+type BulkConfig struct {
+	// MaxItems is the most users accepted in a single request
+	MaxItems int `mapstructure:"max_items"`
+	// Concurrency is the number of workers validating and hashing items
+	Concurrency int `mapstructure:"concurrency"`
+	// BatchSize is the number of rows per INSERT statement
+	BatchSize int `mapstructure:"batch_size"`
+	// MaxInFlight is the number of bulk requests processed at once; further
+	// requests are rejected with a retryable error
+	MaxInFlight int `mapstructure:"max_in_flight"`
+}
+
+func BulkDefaults(v *viper.Viper) {
+	v.SetDefault("bulk.max_items", 500)
+	v.SetDefault("bulk.concurrency", 4)
+	v.SetDefault("bulk.batch_size", 100)
+	v.SetDefault("bulk.max_in_flight", 2)
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// CacheConfig configures the shared Redis cache. While disabled, only
+// in-process caches are used and Redis is never contacted.
+type CacheConfig struct {
+	Enabled        bool          `mapstructure:"enabled"`
+	Address        string        `mapstructure:"address"`
+	ConnectTimeout time.Duration `mapstructure:"connect_timeout"`
+	// Required makes startup fail when the cache is unreachable; otherwise the
+	// service starts and reports itself degraded
+	Required bool `mapstructure:"required"`
+}
+
+func CacheDefaults(v *viper.Viper) {
+	v.SetDefault("cache.enabled", false)
+	v.SetDefault("cache.address", "localhost:6379")
+	v.SetDefault("cache.connect_timeout", 2*time.Second)
+	v.SetDefault("cache.required", false)
+}
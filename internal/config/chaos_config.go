@@ -0,0 +1,31 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// ChaosConfig configures fault injection for resilience testing.
+// It is ignored in production.
+type ChaosConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// AllowHeaders lets callers trigger faults with X-Chaos-* request headers
+	AllowHeaders bool              `mapstructure:"allow_headers"`
+	Rules        []ChaosRuleConfig `mapstructure:"rules"`
+}
+
+// ChaosRuleConfig describes the faults injected on a route
+type ChaosRuleConfig struct {
+	Method      string        `mapstructure:"method"`
+	Route       string        `mapstructure:"route"`
+	Latency     time.Duration `mapstructure:"latency"`
+	ErrorRate   float64       `mapstructure:"error_rate"`
+	ErrorStatus int           `mapstructure:"error_status"`
+	DropDBRate  float64       `mapstructure:"drop_db_rate"`
+}
+
+func ChaosDefaults(v *viper.Viper) {
+	v.SetDefault("chaos.enabled", false)
+	v.SetDefault("chaos.allow_headers", false)
+}
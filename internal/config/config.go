@@ -3,6 +3,10 @@ package config
 import (
 	"errors"
 	"fmt"
+	"io/fs"
+	"net/netip"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -10,13 +14,50 @@ import (
 )
 
 type Config struct {
-	Environment string         `mapstructure:"environment"`
-	LogLevel    string         `mapstructure:"loglevel"`
-	Version     string         `mapstructure:"version"`
-	Server      ServerConfig   `mapstructure:"server"`
-	Database    DatabaseConfig `mapstructure:"database"`
-	Security    SecurityConfig `mapstructure:"security"`
-	Logging     LoggingConfig  `mapstructure:"logging"`
+	Environment      string                 `mapstructure:"environment"`
+	LogLevel         string                 `mapstructure:"loglevel"`
+	Version          string                 `mapstructure:"version"`
+	Server           ServerConfig           `mapstructure:"server"`
+	Database         DatabaseConfig         `mapstructure:"database"`
+	Security         SecurityConfig         `mapstructure:"security"`
+	Logging          LoggingConfig          `mapstructure:"logging"`
+	Health           HealthConfig           `mapstructure:"health"`
+	Chaos            ChaosConfig            `mapstructure:"chaos"`
+	Quota            QuotaConfig            `mapstructure:"quota"`
+	Bulk             BulkConfig             `mapstructure:"bulk"`
+	Deletion         DeletionConfig         `mapstructure:"deletion"`
+	Existence        ExistenceConfig        `mapstructure:"existence"`
+	Time             TimeConfig             `mapstructure:"time"`
+	Messaging        MessagingConfig        `mapstructure:"messaging"`
+	Cache            CacheConfig            `mapstructure:"cache"`
+	Backup           BackupConfig           `mapstructure:"backup"`
+	Anonymize        AnonymizeConfig        `mapstructure:"anonymize"`
+	SearchTokens     SearchTokensConfig     `mapstructure:"search_tokens"`
+	Pagination       PaginationConfig       `mapstructure:"pagination"`
+	Residency        ResidencyConfig        `mapstructure:"residency"`
+	ShadowReads      ShadowReadsConfig      `mapstructure:"shadow_reads"`
+	OfflineWrites    OfflineWritesConfig    `mapstructure:"offline_writes"`
+	Deadline         DeadlineConfig         `mapstructure:"deadline"`
+	Jobs             JobsConfig             `mapstructure:"jobs"`
+	Binding          BindingConfig          `mapstructure:"binding"`
+	ResponseEnvelope ResponseEnvelopeConfig `mapstructure:"response_envelope"`
+	RequestBody      RequestBodyConfig      `mapstructure:"request_body"`
+	Duplicates       DuplicatesConfig       `mapstructure:"duplicates"`
+	SensitiveActions SensitiveActionsConfig `mapstructure:"sensitive_actions"`
+	Verification     VerificationConfig     `mapstructure:"verification"`
+	ErrorSummary     ErrorSummaryConfig     `mapstructure:"error_summary"`
+	Usage            UsageConfig            `mapstructure:"usage"`
+	ResponseCache    ResponseCacheConfig    `mapstructure:"response_cache"`
+	OIDC             OIDCConfig             `mapstructure:"oidc"`
+	Authorization    AuthorizationConfig    `mapstructure:"authorization"`
+	Middleware       MiddlewareConfig       `mapstructure:"middleware"`
+	Profile          ProfileConfig          `mapstructure:"profile"`
+
+	// Sources lists the config files that were read, base file first
+	Sources []string `mapstructure:"-"`
+	// OverriddenKeys lists the keys set with overrides; values are left out as
+	// they may be secrets
+	OverriddenKeys []string `mapstructure:"-"`
 }
 
 type ServerConfig struct {
@@ -26,6 +67,19 @@ type ServerConfig struct {
 	WriteTimeout    time.Duration `mapstructure:"write_timeout"`
 	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
 	CORS            CORSConfig    `mapstructure:"cors"`
+	// TrustedProxies are the CIDR ranges of proxies whose X-Forwarded-For
+	// is believed; without any, the client IP is the connection's address
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+}
+
+// Validate checks the trusted proxy ranges
+func (c ServerConfig) Validate() error {
+	for _, proxy := range c.TrustedProxies {
+		if _, err := netip.ParsePrefix(proxy); err != nil {
+			return fmt.Errorf("server.trusted_proxies: %q is not a CIDR range", proxy)
+		}
+	}
+	return nil
 }
 
 type CORSConfig struct {
@@ -35,11 +89,42 @@ type CORSConfig struct {
 }
 
 type SecurityConfig struct {
-	RateLimitRPS   int `mapstructure:"rate_limit_rps"`
-	RateLimitBurst int `mapstructure:"rate_limit_burst"`
+	RateLimitRPS   int                `mapstructure:"rate_limit_rps"`
+	RateLimitBurst int                `mapstructure:"rate_limit_burst"`
+	BotDetection   BotDetectionConfig `mapstructure:"bot_detection"`
+	APIKeys        []APIKeyConfig     `mapstructure:"api_keys"`
 }
 
-func Load(configFile, env string) (*Config, error) {
+// IsProduction reports whether the service runs in a production environment
+func (c *Config) IsProduction() bool {
+	switch strings.ToLower(c.Environment) {
+	case "production", "prod":
+		return true
+	default:
+		return false
+	}
+}
+
+// Load builds the configuration for env. Sources are applied in increasing
+// order of precedence:
+//
+//  1. built-in defaults
+//  2. the base file (config.yaml, or configFile when given)
+//  3. the environment overlay next to it (config.<env>.yaml), when present
+//  4. USER_SERVICE_* environment variables, or the files their *_FILE
+//     variants point to
+//  5. overrides given as "key=value", e.g. from --set
+//
+// Overlays and overrides only replace the keys they set; nested sections are
+// merged key by key, while lists are replaced as a whole.
+//
+// A key maps to an environment variable by upper-casing it, replacing dots
+// with underscores and adding the prefix: database.password is read from
+// USER_SERVICE_DATABASE_PASSWORD, or from the file named by
+// USER_SERVICE_DATABASE_PASSWORD_FILE. Only keys with a default or present in a
+// config file are looked up, and lists of objects such as security.api_keys
+// can only be set from a file.
+func Load(configFile, env string, overrides []string) (*Config, error) {
 	v := viper.New()
 
 	// Set defaults
@@ -58,8 +143,8 @@ func Load(configFile, env string) (*Config, error) {
 	}
 
 	// Environment variables
-	v.SetEnvPrefix("USER_SERVICE")
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.SetEnvPrefix(envPrefix)
+	v.SetEnvKeyReplacer(envKeyReplacer)
 	v.AutomaticEnv()
 
 	// Read config file
@@ -70,6 +155,28 @@ func Load(configFile, env string) (*Config, error) {
 		}
 	}
 
+	var sources []string
+	if baseFile := v.ConfigFileUsed(); baseFile != "" {
+		sources = append(sources, baseFile)
+	}
+
+	overlay, err := mergeOverlay(v, env)
+	if err != nil {
+		return nil, err
+	}
+	if overlay != "" {
+		sources = append(sources, overlay)
+	}
+
+	if err := applySecretFiles(v); err != nil {
+		return nil, err
+	}
+
+	overriddenKeys, err := applyOverrides(v, overrides)
+	if err != nil {
+		return nil, err
+	}
+
 	version := v.GetString("VERSION")
 
 	// Override environment
@@ -80,10 +187,144 @@ func Load(configFile, env string) (*Config, error) {
 	if err := v.Unmarshal(&config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
+	config.Sources = sources
+	config.OverriddenKeys = overriddenKeys
+
+	if _, err := config.Time.DisplayLocation(); err != nil {
+		return nil, err
+	}
+
+	if err := config.Residency.Validate(config.Database); err != nil {
+		return nil, err
+	}
+
+	if err := config.ShadowReads.Validate(config.Database); err != nil {
+		return nil, err
+	}
+
+	if err := config.Server.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := config.OfflineWrites.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := config.Health.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := config.Binding.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := config.ResponseEnvelope.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := config.SensitiveActions.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := config.Verification.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := config.ErrorSummary.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := config.Usage.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := config.Jobs.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := config.ResponseCache.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := config.SearchTokens.Validate(config.IsProduction()); err != nil {
+		return nil, err
+	}
+
+	if err := config.Pagination.Validate(config.IsProduction()); err != nil {
+		return nil, err
+	}
+
+	if err := config.OIDC.Validate(config.IsProduction()); err != nil {
+		return nil, err
+	}
+
+	if err := config.Profile.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := config.Authorization.loadPolicyFile(); err != nil {
+		return nil, err
+	}
+
+	if err := config.Authorization.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := config.Middleware.Validate(config.IsProduction()); err != nil {
+		return nil, err
+	}
 
 	return &config, nil
 }
 
+// overlayPath returns the environment overlay for a base config file, e.g.
+// configs/config.production.yaml for configs/config.yaml
+func overlayPath(baseFile, env string) string {
+	ext := filepath.Ext(baseFile)
+	return strings.TrimSuffix(baseFile, ext) + "." + strings.ToLower(env) + ext
+}
+
+// mergeOverlay merges the environment overlay over the base file, if one
+// exists, and returns its path
+func mergeOverlay(v *viper.Viper, env string) (string, error) {
+	baseFile := v.ConfigFileUsed()
+	if baseFile == "" || env == "" {
+		return "", nil
+	}
+
+	overlay := overlayPath(baseFile, env)
+	if _, err := os.Stat(overlay); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to stat config overlay %s: %w", overlay, err)
+	}
+
+	v.SetConfigFile(overlay)
+	if err := v.MergeInConfig(); err != nil {
+		return "", fmt.Errorf("failed to merge config overlay %s: %w", overlay, err)
+	}
+	return overlay, nil
+}
+
+// applyOverrides applies "key=value" overrides, which win over every other
+// source. Values are strings and converted like environment variables, so
+// durations ("5s"), numbers and comma-separated lists work as expected.
+// It returns the keys that were set.
+func applyOverrides(v *viper.Viper, overrides []string) ([]string, error) {
+	keys := make([]string, 0, len(overrides))
+	for _, override := range overrides {
+		key, value, ok := strings.Cut(override, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid config override %q: expected key=value", override)
+		}
+		v.Set(key, value)
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
 func setDefaults(v *viper.Viper) {
 	// Server defaults
 	v.SetDefault("server.port", "8080")
@@ -96,11 +337,44 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.cors.allow_origins", []string{"*"})
 	v.SetDefault("server.cors.allow_methods", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"})
 	v.SetDefault("server.cors.allow_headers", []string{"*"})
+	v.SetDefault("server.trusted_proxies", []string{})
 
 	DatabaseDefaults(v)
 
 	v.SetDefault("security.rate_limit_rps", 100)
 	v.SetDefault("security.rate_limit_burst", 200)
+	BotDetectionDefaults(v)
 
 	DefaultLogger(v)
+	HealthDefaults(v)
+	ChaosDefaults(v)
+	QuotaDefaults(v)
+	BulkDefaults(v)
+	DeletionDefaults(v)
+	ExistenceDefaults(v)
+	TimeDefaults(v)
+	MessagingDefaults(v)
+	CacheDefaults(v)
+	BackupDefaults(v)
+	AnonymizeDefaults(v)
+	SearchTokensDefaults(v)
+	PaginationDefaults(v)
+	ResidencyDefaults(v)
+	ShadowReadsDefaults(v)
+	OfflineWritesDefaults(v)
+	DeadlineDefaults(v)
+	JobsDefaults(v)
+	BindingDefaults(v)
+	ResponseEnvelopeDefaults(v)
+	RequestBodyDefaults(v)
+	DuplicatesDefaults(v)
+	SensitiveActionsDefaults(v)
+	VerificationDefaults(v)
+	ErrorSummaryDefaults(v)
+	UsageDefaults(v)
+	ResponseCacheDefaults(v)
+	OIDCDefaults(v)
+	AuthorizationDefaults(v)
+	MiddlewareDefaults(v)
+	ProfileDefaults(v)
 }
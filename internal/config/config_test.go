@@ -0,0 +1,439 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const baseConfigYAML = `
+server:
+  port: "8090"
+  read_timeout: "30s"
+  cors:
+    allow_origins: ["http://localhost:3000", "http://localhost:4000"]
+database:
+  host: "localhost"
+  max_open_conns: 10
+logging:
+  level: "debug"
+  format: "console"
+  components:
+    gorm: "warn"
+    http: "info"
+`
+
+const productionOverlayYAML = `
+database:
+  host: "db.internal"
+logging:
+  level: "info"
+  format: "json"
+  components:
+    gorm: "error"
+server:
+  cors:
+    allow_origins: ["https://app.example.com"]
+search_tokens:
+  key: "production-search-token-key-for-tests"
+pagination:
+  cursor_key: "production-pagination-cursor-key-for-tests"
+`
+
+// writeConfigFiles writes the given files into a temporary directory and
+// returns the path of the base config.yaml
+func writeConfigFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	return filepath.Join(dir, "config.yaml")
+}
+
+func TestLoad_WithoutOverlayUsesBaseFile(t *testing.T) {
+	// Given
+	configFile := writeConfigFiles(t, map[string]string{
+		"config.yaml":            baseConfigYAML,
+		"config.production.yaml": productionOverlayYAML,
+	})
+
+	// When
+	cfg, err := Load(configFile, "development", nil)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "development", cfg.Environment)
+	assert.Equal(t, "localhost", cfg.Database.Host)
+	assert.Equal(t, "debug", cfg.Logging.Level)
+	assert.Equal(t, 30*time.Second, cfg.Server.ReadTimeout)
+}
+
+func TestLoad_OverlayMergesOverBaseFile(t *testing.T) {
+	// Given
+	configFile := writeConfigFiles(t, map[string]string{
+		"config.yaml":            baseConfigYAML,
+		"config.production.yaml": productionOverlayYAML,
+	})
+
+	// When
+	cfg, err := Load(configFile, "production", nil)
+
+	// Then the overlay wins for the keys it sets
+	require.NoError(t, err)
+	assert.Equal(t, "db.internal", cfg.Database.Host)
+	assert.Equal(t, "info", cfg.Logging.Level)
+	assert.Equal(t, "json", cfg.Logging.Format)
+	assert.Equal(t, "error", cfg.Logging.Components["gorm"])
+
+	// And keys it does not set keep the base value, even inside merged sections
+	assert.Equal(t, "8090", cfg.Server.Port)
+	assert.Equal(t, 10, cfg.Database.MaxOpenConns)
+	assert.Equal(t, "info", cfg.Logging.Components["http"])
+
+	// And lists are replaced, not appended to
+	assert.Equal(t, []string{"https://app.example.com"}, cfg.Server.CORS.AllowOrigins)
+
+	// And both files are reported as sources, base file first
+	require.Len(t, cfg.Sources, 2)
+	assert.Equal(t, "config.yaml", filepath.Base(cfg.Sources[0]))
+	assert.Equal(t, "config.production.yaml", filepath.Base(cfg.Sources[1]))
+}
+
+func TestLoad_EnvironmentVariablesWinOverOverlay(t *testing.T) {
+	// Given
+	configFile := writeConfigFiles(t, map[string]string{
+		"config.yaml":            baseConfigYAML,
+		"config.production.yaml": productionOverlayYAML,
+	})
+	t.Setenv("USER_SERVICE_DATABASE_HOST", "db.from-env")
+
+	// When
+	cfg, err := Load(configFile, "production", nil)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "db.from-env", cfg.Database.Host)
+}
+
+func TestLoad_OverridesWinOverEverything(t *testing.T) {
+	// Given
+	configFile := writeConfigFiles(t, map[string]string{
+		"config.yaml":            baseConfigYAML,
+		"config.production.yaml": productionOverlayYAML,
+	})
+	t.Setenv("USER_SERVICE_DATABASE_HOST", "db.from-env")
+
+	// When
+	cfg, err := Load(configFile, "production", []string{
+		"database.host=db.emergency",
+		"database.max_open_conns=50",
+		"server.read_timeout=5s",
+		"logging.components.gorm=debug",
+		"server.cors.allow_origins=https://a.example.com,https://b.example.com",
+	})
+
+	// Then values are converted to the field types
+	require.NoError(t, err)
+	assert.Equal(t, "db.emergency", cfg.Database.Host)
+	assert.Equal(t, 50, cfg.Database.MaxOpenConns)
+	assert.Equal(t, 5*time.Second, cfg.Server.ReadTimeout)
+	assert.Equal(t, "debug", cfg.Logging.Components["gorm"])
+	assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, cfg.Server.CORS.AllowOrigins)
+
+	// And the environment still comes from --env
+	assert.Equal(t, "production", cfg.Environment)
+
+	// And the overridden keys are reported without their values
+	assert.Equal(t, []string{
+		"database.host",
+		"database.max_open_conns",
+		"server.read_timeout",
+		"logging.components.gorm",
+		"server.cors.allow_origins",
+	}, cfg.OverriddenKeys)
+}
+
+func TestLoad_RejectsMalformedOverride(t *testing.T) {
+	// Given
+	configFile := writeConfigFiles(t, map[string]string{"config.yaml": baseConfigYAML})
+
+	// When
+	_, err := Load(configFile, "development", []string{"database.host"})
+
+	// Then
+	assert.ErrorContains(t, err, "expected key=value")
+}
+
+func TestLoad_RejectsInvalidOverlay(t *testing.T) {
+	// Given
+	configFile := writeConfigFiles(t, map[string]string{
+		"config.yaml":         baseConfigYAML,
+		"config.staging.yaml": "database: [not: valid",
+	})
+
+	// When
+	_, err := Load(configFile, "staging", nil)
+
+	// Then
+	assert.ErrorContains(t, err, "config.staging.yaml")
+}
+
+func TestOverlayPath(t *testing.T) {
+	assert.Equal(t, filepath.Join("configs", "config.production.yaml"), overlayPath(filepath.Join("configs", "config.yaml"), "Production"))
+	assert.Equal(t, "/etc/user-service/config-docker.staging.yml", overlayPath("/etc/user-service/config-docker.yml", "staging"))
+}
+
+func TestLoad_ReadsSecretFromFileEnvVar(t *testing.T) {
+	// Given a password mounted as a secret file, with a trailing newline
+	secretFile := filepath.Join(t.TempDir(), "db-password")
+	require.NoError(t, os.WriteFile(secretFile, []byte("s3cr3t\n"), 0o600))
+	configFile := writeConfigFiles(t, map[string]string{"config.yaml": baseConfigYAML})
+	t.Setenv("USER_SERVICE_DATABASE_PASSWORD_FILE", secretFile)
+
+	// When
+	cfg, err := Load(configFile, "development", nil)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", cfg.Database.Password)
+}
+
+func TestLoad_RejectsSecretSetBothWays(t *testing.T) {
+	// Given
+	secretFile := filepath.Join(t.TempDir(), "db-password")
+	require.NoError(t, os.WriteFile(secretFile, []byte("s3cr3t"), 0o600))
+	configFile := writeConfigFiles(t, map[string]string{"config.yaml": baseConfigYAML})
+	t.Setenv("USER_SERVICE_DATABASE_PASSWORD", "plain")
+	t.Setenv("USER_SERVICE_DATABASE_PASSWORD_FILE", secretFile)
+
+	// When
+	_, err := Load(configFile, "development", nil)
+
+	// Then
+	assert.ErrorContains(t, err, "USER_SERVICE_DATABASE_PASSWORD_FILE")
+}
+
+func TestLoad_FailsOnMissingSecretFile(t *testing.T) {
+	// Given
+	configFile := writeConfigFiles(t, map[string]string{"config.yaml": baseConfigYAML})
+	t.Setenv("USER_SERVICE_DATABASE_PASSWORD_FILE", filepath.Join(t.TempDir(), "missing"))
+
+	// When
+	_, err := Load(configFile, "development", nil)
+
+	// Then
+	assert.Error(t, err)
+}
+
+func TestLoad_EnvironmentOnly(t *testing.T) {
+	// Given no config file at all
+	t.Chdir(t.TempDir())
+	t.Setenv("USER_SERVICE_DATABASE_HOST", "db.from-env")
+	t.Setenv("USER_SERVICE_SERVER_PORT", "9000")
+
+	// When
+	cfg, err := Load("", "development", nil)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "db.from-env", cfg.Database.Host)
+	assert.Equal(t, "9000", cfg.Server.Port)
+	assert.Equal(t, 25, cfg.Database.MaxOpenConns)
+}
+
+func TestLoad_RegionDatabaseInheritsPrimarySettings(t *testing.T) {
+	// Given
+	path := writeConfigFiles(t, map[string]string{"config.yaml": baseConfigYAML + `
+residency:
+  enabled: true
+  regions:
+    us:
+      host: "postgres-us"
+`})
+
+	// When
+	cfg, err := Load(path, "development", nil)
+
+	// Then
+	require.NoError(t, err)
+	database, dedicated := cfg.Residency.DatabaseFor("us", cfg.Database)
+	assert.True(t, dedicated)
+	assert.Equal(t, "postgres-us", database.Host)
+	assert.Equal(t, cfg.Database.Database, database.Database)
+	assert.Equal(t, 10, database.MaxOpenConns)
+
+	_, dedicated = cfg.Residency.DatabaseFor("eu", cfg.Database)
+	assert.False(t, dedicated)
+}
+
+func TestLoad_RejectsRegionDatabaseSameAsPrimary(t *testing.T) {
+	path := writeConfigFiles(t, map[string]string{"config.yaml": baseConfigYAML + `
+residency:
+  regions:
+    us:
+      username: "other"
+`})
+
+	_, err := Load(path, "development", nil)
+
+	assert.ErrorContains(t, err, "residency.regions.us")
+}
+
+func TestLoad_RejectsMalformedLenientRoute(t *testing.T) {
+	// Given
+	configFile := writeConfigFiles(t, map[string]string{"config.yaml": baseConfigYAML})
+
+	// When
+	_, err := Load(configFile, "", []string{"binding.lenient_routes=/api/v1/users"})
+
+	// Then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "binding.lenient_routes")
+}
+
+func TestLoad_SensitiveActionLimits(t *testing.T) {
+	// Given
+	configFile := writeConfigFiles(t, map[string]string{"config.yaml": baseConfigYAML})
+
+	// When one limit is overridden
+	cfg, err := Load(configFile, "development", []string{"sensitive_actions.limits.password_reset.max=3"})
+
+	// Then it keeps its default window and the others keep their defaults
+	require.NoError(t, err)
+	assert.Equal(t, ActionLimitConfig{Max: 3, Window: time.Hour}, cfg.SensitiveActions.Limits["password_reset"])
+	assert.Equal(t, ActionLimitConfig{Max: 5, Window: time.Hour}, cfg.SensitiveActions.Limits["email_change"])
+}
+
+func TestLoad_RejectsSensitiveActionLimitWithoutWindow(t *testing.T) {
+	configFile := writeConfigFiles(t, map[string]string{"config.yaml": baseConfigYAML})
+
+	_, err := Load(configFile, "development", []string{"sensitive_actions.limits.email_change.window=0s"})
+
+	assert.ErrorContains(t, err, "sensitive_actions.limits.email_change.window")
+}
+
+func TestLoad_RejectsNonPositiveJobIntervals(t *testing.T) {
+	configFile := writeConfigFiles(t, map[string]string{"config.yaml": baseConfigYAML})
+
+	for key, value := range map[string]string{"jobs.poll_interval": "0s", "jobs.suspension_expiry_interval": "-1m"} {
+		_, err := Load(configFile, "development", []string{key + "=" + value})
+
+		assert.ErrorContains(t, err, key)
+	}
+}
+
+func TestLoad_RejectsFeatureBackedByCriticalComponent(t *testing.T) {
+	configFile := writeConfigFiles(t, map[string]string{"config.yaml": baseConfigYAML})
+
+	_, err := Load(configFile, "development", []string{"health.critical_components=postgres,redis"})
+
+	assert.ErrorContains(t, err, `health.features.cache: component "redis" is critical`)
+}
+
+func TestLoad_AddsPoliciesFromPolicyFile(t *testing.T) {
+	// Given
+	configFile := writeConfigFiles(t, map[string]string{
+		"config.yaml": baseConfigYAML,
+		"policies.yaml": `
+policies:
+  - id: "office-only"
+    effect: "forbid"
+    actions: ["admin.*"]
+    when:
+      - attribute: "context.ip"
+        operator: "cidr"
+        values: ["10.0.0.0/8"]
+`,
+	})
+	policyFile := filepath.Join(filepath.Dir(configFile), "policies.yaml")
+
+	// When
+	cfg, err := Load(configFile, "development", []string{"authorization.policy_file=" + policyFile})
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, cfg.Authorization.Policies, 1)
+	assert.Equal(t, "office-only", cfg.Authorization.Policies[0].ID)
+	assert.Equal(t, []PolicyConditionConfig{{Attribute: "context.ip", Operator: "cidr", Values: []string{"10.0.0.0/8"}}}, cfg.Authorization.Policies[0].Conditions)
+}
+
+func TestAuthorizationConfig_Validate(t *testing.T) {
+	permit := PolicyConfig{ID: "admins", Effect: "permit", Roles: []string{"admin"}, Actions: []string{"*"}}
+
+	tests := []struct {
+		name    string
+		config  AuthorizationConfig
+		wantErr string
+	}{
+		{"valid", AuthorizationConfig{Mode: "enforce", Policies: []PolicyConfig{permit}}, ""},
+		{"unknown mode", AuthorizationConfig{Mode: "strict"}, "authorization.mode"},
+		{"duplicate id", AuthorizationConfig{Mode: "rbac", Policies: []PolicyConfig{permit, permit}}, "duplicate policy id"},
+		{"unknown effect", AuthorizationConfig{Mode: "rbac", Policies: []PolicyConfig{{ID: "p", Effect: "allow", Actions: []string{"*"}}}}, "must be permit or forbid"},
+		{"bad attribute", AuthorizationConfig{Mode: "rbac", Policies: []PolicyConfig{{ID: "p", Effect: "permit", Actions: []string{"*"},
+			Conditions: []PolicyConditionConfig{{Attribute: "ip", Operator: "in", Values: []string{"x"}}}}}}, "must start with subject."},
+		{"bad cidr", AuthorizationConfig{Mode: "rbac", Policies: []PolicyConfig{{ID: "p", Effect: "permit", Actions: []string{"*"},
+			Conditions: []PolicyConditionConfig{{Attribute: "context.ip", Operator: "cidr", Values: []string{"10.0.0.0"}}}}}}, "is not a CIDR range"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestServerConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ServerConfig
+		wantErr string
+	}{
+		{"no proxies", ServerConfig{}, ""},
+		{"valid proxies", ServerConfig{TrustedProxies: []string{"10.0.0.0/8", "2001:db8::/32"}}, ""},
+		{"bare address", ServerConfig{TrustedProxies: []string{"10.0.0.1"}}, "server.trusted_proxies"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestAgeGateConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  AgeGateConfig
+		wantErr string
+	}{
+		{"valid", AgeGateConfig{MinimumAge: 16, Policy: "reject", Regions: map[string]RegionAgeGateConfig{"us": {MinimumAge: 13, Policy: "guardian_consent"}}}, ""},
+		{"disabled", AgeGateConfig{Policy: "reject"}, ""},
+		{"unknown policy", AgeGateConfig{MinimumAge: 16, Policy: "allow"}, "profile.age_gate.policy"},
+		{"bad regional age", AgeGateConfig{MinimumAge: 16, Policy: "reject", Regions: map[string]RegionAgeGateConfig{"eu": {MinimumAge: 30, Policy: "reject"}}}, "profile.age_gate.regions.eu.minimum_age"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
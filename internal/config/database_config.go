@@ -7,15 +7,27 @@ import (
 )
 
 type DatabaseConfig struct {
-	Host         string        `mapstructure:"host"`
-	Port         string        `mapstructure:"port"`
-	Username     string        `mapstructure:"username"`
-	Password     string        `mapstructure:"password"`
-	Database     string        `mapstructure:"database"`
-	SSLMode      string        `mapstructure:"ssl_mode"`
-	MaxOpenConns int           `mapstructure:"max_open_conns"`
-	MaxIdleConns int           `mapstructure:"max_idle_conns"`
-	MaxLifetime  time.Duration `mapstructure:"max_lifetime"`
+	Host         string          `mapstructure:"host"`
+	Port         string          `mapstructure:"port"`
+	Username     string          `mapstructure:"username"`
+	Password     string          `mapstructure:"password"`
+	Database     string          `mapstructure:"database"`
+	SSLMode      string          `mapstructure:"ssl_mode"`
+	MaxOpenConns int             `mapstructure:"max_open_conns"`
+	MaxIdleConns int             `mapstructure:"max_idle_conns"`
+	MaxLifetime  time.Duration   `mapstructure:"max_lifetime"`
+	SlowQuery    SlowQueryConfig `mapstructure:"slow_query"`
+	// QueryMetrics exports per-fingerprint query counts and latency histograms
+	QueryMetrics bool `mapstructure:"query_metrics"`
+}
+
+// SlowQueryConfig controls how queries over the slow threshold are reported
+type SlowQueryConfig struct {
+	Threshold time.Duration `mapstructure:"threshold"`
+	// Explain logs the plan of slow queries; it is ignored in production
+	// unless ExplainInProduction is also set
+	Explain             bool `mapstructure:"explain"`
+	ExplainInProduction bool `mapstructure:"explain_in_production"`
 }
 
 func DatabaseDefaults(v *viper.Viper) {
@@ -29,4 +41,8 @@ func DatabaseDefaults(v *viper.Viper) {
 	v.SetDefault("database.max_open_conns", 25)
 	v.SetDefault("database.max_idle_conns", 25)
 	v.SetDefault("database.max_lifetime", 5*time.Minute)
+	v.SetDefault("database.query_metrics", true)
+	v.SetDefault("database.slow_query.threshold", 200*time.Millisecond)
+	v.SetDefault("database.slow_query.explain", false)
+	v.SetDefault("database.slow_query.explain_in_production", false)
 }
@@ -0,0 +1,21 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// DeadlineConfig controls how caller supplied request budgets are honored
+type DeadlineConfig struct {
+	// Enabled derives the request deadline from X-Request-Timeout or
+	// grpc-timeout when the caller sends one
+	Enabled bool `mapstructure:"enabled"`
+	// MaxTimeout caps caller budgets; longer ones are shortened to it
+	MaxTimeout time.Duration `mapstructure:"max_timeout"`
+}
+
+func DeadlineDefaults(v *viper.Viper) {
+	v.SetDefault("deadline.enabled", true)
+	v.SetDefault("deadline.max_timeout", 30*time.Second)
+}
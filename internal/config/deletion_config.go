@@ -0,0 +1,42 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// DeletionConfig configures the checks dependent services run before a user is deleted
+type DeletionConfig struct {
+	// Timeout bounds each checker unless it sets its own
+	Timeout time.Duration `mapstructure:"timeout"`
+	// FailOpen allows deletions when a checker fails, unless it sets its own policy
+	FailOpen bool                    `mapstructure:"fail_open"`
+	Checkers []DeletionCheckerConfig `mapstructure:"checkers"`
+}
+
+// DeletionCheckerConfig registers a remote deletion checker
+type DeletionCheckerConfig struct {
+	Name    string        `mapstructure:"name"`
+	URL     string        `mapstructure:"url"`
+	Timeout time.Duration `mapstructure:"timeout"`
+	// FailPolicy is "open" or "closed"; empty inherits deletion.fail_open
+	FailPolicy string `mapstructure:"fail_policy"`
+}
+
+// IsFailOpen resolves the checker's failure policy against the global default
+func (c DeletionCheckerConfig) IsFailOpen(defaultFailOpen bool) bool {
+	switch c.FailPolicy {
+	case "open":
+		return true
+	case "closed":
+		return false
+	default:
+		return defaultFailOpen
+	}
+}
+
+func DeletionDefaults(v *viper.Viper) {
+	v.SetDefault("deletion.timeout", 2*time.Second)
+	v.SetDefault("deletion.fail_open", false)
+}
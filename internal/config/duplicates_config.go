@@ -0,0 +1,20 @@
+package config
+
+import "github.com/spf13/viper"
+
+// DuplicatesConfig tunes the duplicate_detection job
+type DuplicatesConfig struct {
+	// MinConfidence is the lowest confidence, between 0 and 1, worth reviewing
+	MinConfidence float64 `mapstructure:"min_confidence"`
+	// ScanPageSize is the number of users loaded per query while scanning
+	ScanPageSize int `mapstructure:"scan_page_size"`
+	// MaxGroupSize skips groups of accounts sharing an email, phone or name
+	// that are too large to be one person
+	MaxGroupSize int `mapstructure:"max_group_size"`
+}
+
+func DuplicatesDefaults(v *viper.Viper) {
+	v.SetDefault("duplicates.min_confidence", 0.6)
+	v.SetDefault("duplicates.scan_page_size", 1000)
+	v.SetDefault("duplicates.max_group_size", 20)
+}
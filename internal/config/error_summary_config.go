@@ -0,0 +1,39 @@
+package config
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// ErrorSummaryConfig sizes the in-memory summary of server errors that
+// on-call reads from the admin API. Each instance keeps its own.
+type ErrorSummaryConfig struct {
+	// RecentErrors is how many of the latest 5xx responses are kept
+	RecentErrors int `mapstructure:"recent_errors"`
+	// Window is how far back the top error codes are counted
+	Window time.Duration `mapstructure:"window"`
+	// TopCodes is how many error codes the summary ranks
+	TopCodes int `mapstructure:"top_codes"`
+}
+
+// Validate rejects an empty summary
+func (c ErrorSummaryConfig) Validate() error {
+	if c.RecentErrors < 1 {
+		return errors.New("error_summary.recent_errors must be at least 1")
+	}
+	if c.Window < time.Minute {
+		return errors.New("error_summary.window must be at least 1m")
+	}
+	if c.TopCodes < 1 {
+		return errors.New("error_summary.top_codes must be at least 1")
+	}
+	return nil
+}
+
+func ErrorSummaryDefaults(v *viper.Viper) {
+	v.SetDefault("error_summary.recent_errors", 50)
+	v.SetDefault("error_summary.window", "1h")
+	v.SetDefault("error_summary.top_codes", 10)
+}
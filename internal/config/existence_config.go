@@ -0,0 +1,21 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// ExistenceConfig tunes the HEAD existence endpoints
+type ExistenceConfig struct {
+	// CacheTTL is how long existence answers are cached, in process and by
+	// clients through Cache-Control
+	CacheTTL time.Duration `mapstructure:"cache_ttl"`
+	// MaxEntries bounds each in-process cache
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+func ExistenceDefaults(v *viper.Viper) {
+	v.SetDefault("existence.cache_ttl", "30s")
+	v.SetDefault("existence.max_entries", 10000)
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+type HealthConfig struct {
+	// CriticalComponents fail readiness when unhealthy; others only degrade it
+	CriticalComponents []string      `mapstructure:"critical_components"`
+	CheckTimeout       time.Duration `mapstructure:"check_timeout"`
+	DegradedLatency    time.Duration `mapstructure:"degraded_latency"`
+	// Features maps each optional feature (search, cache, webhooks) to the
+	// components it needs; the feature runs degraded while any is unhealthy
+	Features map[string][]string `mapstructure:"features"`
+}
+
+// Validate rejects optional features backed by critical components, as those
+// would fail readiness instead of degrading the feature
+func (c HealthConfig) Validate() error {
+	for feature, components := range c.Features {
+		for _, component := range components {
+			if slices.Contains(c.CriticalComponents, component) {
+				return fmt.Errorf("health.features.%s: component %q is critical", feature, component)
+			}
+		}
+	}
+	return nil
+}
+
+func HealthDefaults(v *viper.Viper) {
+	v.SetDefault("health.critical_components", []string{"postgres"})
+	v.SetDefault("health.check_timeout", 5*time.Second)
+	v.SetDefault("health.degraded_latency", 500*time.Millisecond)
+	v.SetDefault("health.features", map[string][]string{"cache": {"redis"}})
+}
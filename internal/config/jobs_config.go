@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// JobsConfig configures the background job scheduler
+type JobsConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// PollInterval is how often the scheduler looks for due jobs
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	// HealthCheckInterval schedules the health_check job
+	HealthCheckInterval time.Duration `mapstructure:"health_check_interval"`
+	// DuplicateDetectionInterval schedules the duplicate_detection job
+	DuplicateDetectionInterval time.Duration `mapstructure:"duplicate_detection_interval"`
+	// ActivityDigestInterval schedules the activity_digest job; each digest
+	// covers at most this long
+	ActivityDigestInterval time.Duration `mapstructure:"activity_digest_interval"`
+	// SuspensionExpiryInterval schedules the suspension_expiry job, which
+	// reactivates users whose suspension reached its reactivation date
+	SuspensionExpiryInterval time.Duration `mapstructure:"suspension_expiry_interval"`
+}
+
+// Validate rejects intervals the scheduler cannot tick at
+func (c JobsConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	for key, interval := range map[string]time.Duration{
+		"poll_interval":                c.PollInterval,
+		"health_check_interval":        c.HealthCheckInterval,
+		"duplicate_detection_interval": c.DuplicateDetectionInterval,
+		"activity_digest_interval":     c.ActivityDigestInterval,
+		"suspension_expiry_interval":   c.SuspensionExpiryInterval,
+	} {
+		if interval <= 0 {
+			return fmt.Errorf("jobs.%s: %v must be positive", key, interval)
+		}
+	}
+	return nil
+}
+
+func JobsDefaults(v *viper.Viper) {
+	v.SetDefault("jobs.enabled", true)
+	v.SetDefault("jobs.poll_interval", 10*time.Second)
+	v.SetDefault("jobs.health_check_interval", 5*time.Minute)
+	v.SetDefault("jobs.duplicate_detection_interval", 24*time.Hour)
+	v.SetDefault("jobs.activity_digest_interval", 7*24*time.Hour)
+	v.SetDefault("jobs.suspension_expiry_interval", 15*time.Minute)
+}
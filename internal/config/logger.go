@@ -5,9 +5,33 @@ import "github.com/spf13/viper"
 type LoggingConfig struct {
 	Level  string `mapstructure:"level"`
 	Format string `mapstructure:"format"`
+	// Components overrides the level per logger component, e.g. gorm: warn
+	Components map[string]string `mapstructure:"components"`
+	Access     AccessLogConfig   `mapstructure:"access"`
+}
+
+// AccessLogConfig routes HTTP access logs to their own output instead of the
+// application log
+type AccessLogConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Output is "stdout", "stderr" or a file path
+	Output string `mapstructure:"output"`
+	// Format defaults to logging.format when empty
+	Format string `mapstructure:"format"`
+	// SampleRate is the fraction of successful requests logged; failures are always logged
+	SampleRate float64 `mapstructure:"sample_rate"`
+	MaxSizeMB  int     `mapstructure:"max_size_mb"`
+	MaxBackups int     `mapstructure:"max_backups"`
+	MaxAgeDays int     `mapstructure:"max_age_days"`
 }
 
 func DefaultLogger(v *viper.Viper) {
 	v.SetDefault("logging.level", "info")
 	v.SetDefault("logging.format", "json")
+	v.SetDefault("logging.access.enabled", false)
+	v.SetDefault("logging.access.output", "stdout")
+	v.SetDefault("logging.access.sample_rate", 1.0)
+	v.SetDefault("logging.access.max_size_mb", 100)
+	v.SetDefault("logging.access.max_backups", 5)
+	v.SetDefault("logging.access.max_age_days", 7)
 }
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Optional middlewares, which route groups may switch off or back on
+const (
+	// MiddlewareAuth is the role or policy check of staff and internal routes
+	MiddlewareAuth = "auth"
+	// MiddlewareRateLimit limits requests per client IP to
+	// security.rate_limit_rps
+	MiddlewareRateLimit = "rate_limit"
+	// MiddlewareQuota caps the page size of list routes
+	MiddlewareQuota = "quota"
+	// MiddlewareCache sets Cache-Control and serves cached GET responses
+	MiddlewareCache = "cache"
+	// MiddlewareBotDetection screens public sign-ups
+	MiddlewareBotDetection = "bot_detection"
+)
+
+// OptionalMiddlewares lists the middlewares route groups may switch
+var OptionalMiddlewares = []string{
+	MiddlewareAuth,
+	MiddlewareRateLimit,
+	MiddlewareQuota,
+	MiddlewareCache,
+	MiddlewareBotDetection,
+}
+
+// MiddlewareConfig switches optional middlewares per route group. A route
+// follows the group with the longest prefix that names a middleware, so a
+// narrower group can enable again what a wider one disables. Middlewares
+// only run where the code attaches them: enabling quota on a route that
+// takes no page size adds nothing.
+type MiddlewareConfig struct {
+	Groups []MiddlewareGroupConfig `mapstructure:"groups"`
+}
+
+// MiddlewareGroupConfig switches middlewares for the routes under a prefix
+type MiddlewareGroupConfig struct {
+	// Prefix selects routes by path, e.g. /api/v1/admin; "/" selects all
+	Prefix  string   `mapstructure:"prefix"`
+	Enable  []string `mapstructure:"enable"`
+	Disable []string `mapstructure:"disable"`
+}
+
+// Enabled reports whether the optional middleware name runs on a route
+func (c MiddlewareConfig) Enabled(name, path string) bool {
+	longest := -1
+	enabled := true
+	for _, group := range c.Groups {
+		if !group.Covers(path) || len(group.Prefix) <= longest {
+			continue
+		}
+		switch {
+		case slices.Contains(group.Disable, name):
+			enabled = false
+		case slices.Contains(group.Enable, name):
+			enabled = true
+		default:
+			continue
+		}
+		longest = len(group.Prefix)
+	}
+	return enabled
+}
+
+// Covers reports whether the route path lies under the group's prefix
+func (g MiddlewareGroupConfig) Covers(path string) bool {
+	prefix := strings.TrimSuffix(g.Prefix, "/")
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// Validate rejects unknown middlewares, malformed or repeated prefixes and,
+// in production, groups that switch authorization off
+func (c MiddlewareConfig) Validate(production bool) error {
+	prefixes := make(map[string]bool, len(c.Groups))
+	for i, group := range c.Groups {
+		if !strings.HasPrefix(group.Prefix, "/") {
+			return fmt.Errorf("middleware.groups[%d].prefix: %q must start with /", i, group.Prefix)
+		}
+		if prefixes[group.Prefix] {
+			return fmt.Errorf("middleware.groups[%d].prefix: duplicate prefix %q", i, group.Prefix)
+		}
+		prefixes[group.Prefix] = true
+
+		for _, name := range append(slices.Clone(group.Enable), group.Disable...) {
+			if !slices.Contains(OptionalMiddlewares, name) {
+				return fmt.Errorf("middleware.groups[%d]: unknown middleware %q, expected one of %s", i, name, strings.Join(OptionalMiddlewares, ", "))
+			}
+		}
+		for _, name := range group.Disable {
+			if slices.Contains(group.Enable, name) {
+				return fmt.Errorf("middleware.groups[%d]: %q is both enabled and disabled", i, name)
+			}
+		}
+		if production && slices.Contains(group.Disable, MiddlewareAuth) {
+			return fmt.Errorf("middleware.groups[%d]: auth must not be disabled in production", i)
+		}
+	}
+	return nil
+}
+
+func MiddlewareDefaults(v *viper.Viper) {
+	v.SetDefault("middleware.groups", []map[string]interface{}{})
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddlewareConfig_Enabled(t *testing.T) {
+	cfg := MiddlewareConfig{Groups: []MiddlewareGroupConfig{
+		{Prefix: "/api/v1", Disable: []string{MiddlewareCache}},
+		{Prefix: "/api/v1/users", Enable: []string{MiddlewareCache}},
+		{Prefix: "/api/v1/health", Disable: []string{MiddlewareRateLimit}},
+	}}
+
+	assert.False(t, cfg.Enabled(MiddlewareCache, "/api/v1/admin/jobs"))
+	assert.True(t, cfg.Enabled(MiddlewareCache, "/api/v1/users/:id"), "the longest prefix wins")
+	assert.True(t, cfg.Enabled(MiddlewareCache, "/.well-known/openid-configuration"))
+	assert.False(t, cfg.Enabled(MiddlewareRateLimit, "/api/v1/health"))
+	assert.True(t, cfg.Enabled(MiddlewareRateLimit, "/api/v1/healthz"), "prefixes match whole segments")
+	assert.False(t, cfg.Enabled(MiddlewareCache, "/api/v1/health"), "groups not naming a middleware leave it alone")
+}
+
+func TestMiddlewareConfig_Validate(t *testing.T) {
+	valid := MiddlewareConfig{Groups: []MiddlewareGroupConfig{{Prefix: "/api/v1/admin", Disable: []string{MiddlewareAuth}}}}
+	assert.NoError(t, valid.Validate(false))
+	assert.ErrorContains(t, valid.Validate(true), "auth must not be disabled in production")
+
+	unknown := MiddlewareConfig{Groups: []MiddlewareGroupConfig{{Prefix: "/", Disable: []string{"idempotency"}}}}
+	assert.ErrorContains(t, unknown.Validate(false), `unknown middleware "idempotency"`)
+
+	relative := MiddlewareConfig{Groups: []MiddlewareGroupConfig{{Prefix: "api/v1"}}}
+	assert.ErrorContains(t, relative.Validate(false), "middleware.groups[0].prefix")
+
+	duplicate := MiddlewareConfig{Groups: []MiddlewareGroupConfig{{Prefix: "/api/v1"}, {Prefix: "/api/v1"}}}
+	assert.ErrorContains(t, duplicate.Validate(false), "duplicate prefix")
+
+	conflicting := MiddlewareConfig{Groups: []MiddlewareGroupConfig{{Prefix: "/", Enable: []string{MiddlewareQuota}, Disable: []string{MiddlewareQuota}}}}
+	assert.ErrorContains(t, conflicting.Validate(false), "both enabled and disabled")
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// OfflineWriteOperations are the writes that can be queued while the
+// database is unreachable
+var OfflineWriteOperations = []string{"create_user", "update_preferences", "update_profile"}
+
+// OfflineWritesConfig lets edge deployments with flaky database connectivity
+// accept writes into a durable local queue while the database is unreachable
+// and apply them once it is back. Queued writes answer 202 Accepted.
+type OfflineWritesConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Path is the directory of the queue; it must be on persistent storage
+	// and used by this instance only
+	Path string `mapstructure:"path"`
+	// Operations are the writes that may be queued; all others keep failing
+	// while the database is unreachable
+	Operations []string `mapstructure:"operations"`
+	// MaxQueued caps the queue; writes beyond it fail
+	MaxQueued int `mapstructure:"max_queued"`
+	// ProbeInterval is how often the database is checked and queued writes
+	// are applied
+	ProbeInterval time.Duration `mapstructure:"probe_interval"`
+}
+
+// Validate rejects unknown operations and missing settings
+func (c OfflineWritesConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Path == "" {
+		return fmt.Errorf("offline_writes.path: required when enabled")
+	}
+	if len(c.Operations) == 0 {
+		return fmt.Errorf("offline_writes.operations: name at least one of %v", OfflineWriteOperations)
+	}
+	for _, operation := range c.Operations {
+		if !slices.Contains(OfflineWriteOperations, operation) {
+			return fmt.Errorf("offline_writes.operations: unknown operation %q, expected one of %v", operation, OfflineWriteOperations)
+		}
+	}
+	if c.MaxQueued <= 0 {
+		return fmt.Errorf("offline_writes.max_queued: must be positive")
+	}
+	if c.ProbeInterval <= 0 {
+		return fmt.Errorf("offline_writes.probe_interval: must be positive")
+	}
+	return nil
+}
+
+func OfflineWritesDefaults(v *viper.Viper) {
+	v.SetDefault("offline_writes.enabled", false)
+	v.SetDefault("offline_writes.path", "./data/offline-writes")
+	v.SetDefault("offline_writes.operations", OfflineWriteOperations)
+	v.SetDefault("offline_writes.max_queued", 10000)
+	v.SetDefault("offline_writes.probe_interval", 5*time.Second)
+}
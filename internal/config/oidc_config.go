@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// OIDCConfig controls the OpenID Connect provider mode, letting first-party
+// apps sign users in through the service
+type OIDCConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Issuer is the public base URL of the service, e.g. https://id.example.com;
+	// it identifies the provider in tokens and the discovery document
+	Issuer string `mapstructure:"issuer"`
+	// SigningKeyFile is a PEM RSA private key tokens are signed with. Without
+	// one, a key is generated on startup outside production.
+	SigningKeyFile string        `mapstructure:"signing_key_file"`
+	CodeTTL        time.Duration `mapstructure:"code_ttl"`
+	AccessTokenTTL time.Duration `mapstructure:"access_token_ttl"`
+	IDTokenTTL     time.Duration `mapstructure:"id_token_ttl"`
+}
+
+// Validate checks the issuer and lifetimes when the provider is enabled
+func (c OIDCConfig) Validate(production bool) error {
+	if !c.Enabled {
+		return nil
+	}
+
+	issuer, err := url.Parse(c.Issuer)
+	if err != nil || issuer.Host == "" || (issuer.Scheme != "https" && issuer.Scheme != "http") ||
+		issuer.RawQuery != "" || issuer.Fragment != "" {
+		return fmt.Errorf("oidc.issuer: %q must be an absolute URL without query or fragment", c.Issuer)
+	}
+	if production && issuer.Scheme != "https" {
+		return fmt.Errorf("oidc.issuer: must use https in production")
+	}
+	if production && c.SigningKeyFile == "" {
+		return fmt.Errorf("oidc.signing_key_file: required in production")
+	}
+	if c.CodeTTL <= 0 || c.AccessTokenTTL <= 0 || c.IDTokenTTL <= 0 {
+		return fmt.Errorf("oidc: code_ttl, access_token_ttl and id_token_ttl must be positive")
+	}
+	return nil
+}
+
+func OIDCDefaults(v *viper.Viper) {
+	v.SetDefault("oidc.enabled", false)
+	v.SetDefault("oidc.issuer", "http://localhost:8080")
+	v.SetDefault("oidc.signing_key_file", "")
+	v.SetDefault("oidc.code_ttl", time.Minute)
+	v.SetDefault("oidc.access_token_ttl", time.Hour)
+	v.SetDefault("oidc.id_token_ttl", time.Hour)
+}
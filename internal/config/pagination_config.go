@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// developmentCursorKey signs pagination cursors outside production when no
+// key is configured
+const developmentCursorKey = "development-only-pagination-cursor-key"
+
+// minCursorKeyLength is the shortest key accepted
+const minCursorKeyLength = 32
+
+// PaginationConfig keys the pagination cursors handed to clients
+type PaginationConfig struct {
+	// CursorKey signs cursors; changing it invalidates the cursors clients
+	// hold. Prefer USER_SERVICE_PAGINATION_CURSOR_KEY_FILE.
+	CursorKey string `mapstructure:"cursor_key"`
+}
+
+// EffectiveCursorKey returns the configured key, or a well-known development
+// key and true when none is configured
+func (c PaginationConfig) EffectiveCursorKey() (string, bool) {
+	if c.CursorKey == "" {
+		return developmentCursorKey, true
+	}
+	return c.CursorKey, false
+}
+
+// Validate requires a key of at least 32 characters in production
+func (c PaginationConfig) Validate(production bool) error {
+	if c.CursorKey == "" && production {
+		return fmt.Errorf("pagination.cursor_key: required in production")
+	}
+	if c.CursorKey != "" && len(c.CursorKey) < minCursorKeyLength {
+		return fmt.Errorf("pagination.cursor_key: must be at least %d characters", minCursorKeyLength)
+	}
+	return nil
+}
+
+func PaginationDefaults(v *viper.Viper) {
+	v.SetDefault("pagination.cursor_key", "")
+}
@@ -0,0 +1,28 @@
+package config
+
+import "github.com/spf13/viper"
+
+// ProfileConfig controls the profile data users sign up with
+type ProfileConfig struct {
+	// RequiredFields are the optional profile fields this deployment makes
+	// mandatory on user creation: last_name and/or phone. First name, email
+	// and password are always required.
+	RequiredFields []string `mapstructure:"required_fields"`
+	// DefaultLocale is the BCP 47 locale of users who choose none. Their
+	// default time zone is time.display_timezone.
+	DefaultLocale string `mapstructure:"default_locale"`
+	// AgeGate holds back users younger than a minimum age
+	AgeGate AgeGateConfig `mapstructure:"age_gate"`
+}
+
+// Validate checks the age gates
+func (c ProfileConfig) Validate() error {
+	return c.AgeGate.Validate()
+}
+
+func ProfileDefaults(v *viper.Viper) {
+	v.SetDefault("profile.required_fields", []string{"last_name"})
+	v.SetDefault("profile.default_locale", "en-US")
+	v.SetDefault("profile.age_gate.minimum_age", 16)
+	v.SetDefault("profile.age_gate.policy", AgeGatePolicyReject)
+}
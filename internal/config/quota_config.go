@@ -0,0 +1,35 @@
+package config
+
+import "github.com/spf13/viper"
+
+// QuotaConfig bounds how much data a single list or export request can pull
+type QuotaConfig struct {
+	// MaxPageSize is the largest page size per caller role ("anonymous" for unauthenticated callers)
+	MaxPageSize map[string]int `mapstructure:"max_page_size"`
+	// MaxExportRows is the largest export per caller role
+	MaxExportRows map[string]int `mapstructure:"max_export_rows"`
+	// OverrideParam is the query parameter admins set to acknowledge an oversized request
+	OverrideParam string `mapstructure:"override_param"`
+	// OverrideMaxPageSize is the hard ceiling even for acknowledged admin requests
+	OverrideMaxPageSize int `mapstructure:"override_max_page_size"`
+	// OverrideMaxExportRows is the hard export ceiling even for acknowledged admin requests
+	OverrideMaxExportRows int `mapstructure:"override_max_export_rows"`
+}
+
+func QuotaDefaults(v *viper.Viper) {
+	v.SetDefault("quota.max_page_size", map[string]int{
+		"anonymous": 100,
+		"internal":  200,
+		"support":   200,
+		"admin":     500,
+	})
+	v.SetDefault("quota.max_export_rows", map[string]int{
+		"anonymous": 0,
+		"internal":  10000,
+		"support":   1000,
+		"admin":     50000,
+	})
+	v.SetDefault("quota.override_param", "acknowledge_large_request")
+	v.SetDefault("quota.override_max_page_size", 5000)
+	v.SetDefault("quota.override_max_export_rows", 1000000)
+}
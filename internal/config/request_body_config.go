@@ -0,0 +1,29 @@
+package config
+
+import "github.com/spf13/viper"
+
+// RequestBodyConfig limits the size and type of request bodies
+type RequestBodyConfig struct {
+	// MaxSizeKB caps bodies on routes without a limit of their own
+	MaxSizeKB int `mapstructure:"max_size_kb"`
+	// RequireJSON answers 415 to POST, PUT and PATCH bodies that are not
+	// UTF-8 application/json
+	RequireJSON bool `mapstructure:"require_json"`
+	// FormRoutes are route patterns that also accept UTF-8
+	// application/x-www-form-urlencoded bodies, e.g. OAuth endpoints
+	FormRoutes []string                 `mapstructure:"form_routes"`
+	Routes     []RequestBodyRouteConfig `mapstructure:"routes"`
+}
+
+// RequestBodyRouteConfig sets the body size limit of a route
+type RequestBodyRouteConfig struct {
+	Method    string `mapstructure:"method"`
+	Route     string `mapstructure:"route"`
+	MaxSizeKB int    `mapstructure:"max_size_kb"`
+}
+
+func RequestBodyDefaults(v *viper.Viper) {
+	v.SetDefault("request_body.max_size_kb", 1024)
+	v.SetDefault("request_body.require_json", true)
+	v.SetDefault("request_body.form_routes", []string{"/oauth/authorize", "/oauth/token"})
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// ResidencyConfig configures per-user data residency. While disabled, users
+// are stored without a region in the primary database.
+type ResidencyConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// HomeRegion serves requests that name no region and owns users stored
+	// before residency was enabled
+	HomeRegion string `mapstructure:"home_region"`
+	// Header carries the caller's region. It is only honored for internal
+	// callers such as the regional ingress; API keys with a region attribute
+	// are bound to that region instead.
+	Header string `mapstructure:"header"`
+	// Regions gives regions their own database; regions without an entry use
+	// the primary database and are only separated row by row
+	Regions map[string]RegionDatabaseConfig `mapstructure:"regions"`
+}
+
+// RegionDatabaseConfig overrides the primary database settings for a region
+// or the shadow database. Empty fields inherit the primary value.
+type RegionDatabaseConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     string `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	Database string `mapstructure:"database"`
+	SSLMode  string `mapstructure:"ssl_mode"`
+}
+
+// DatabaseFor returns the database settings of a region with a dedicated
+// database, and false for regions that share the primary one
+func (c ResidencyConfig) DatabaseFor(region string, primary DatabaseConfig) (DatabaseConfig, bool) {
+	override, ok := c.Regions[region]
+	if !ok {
+		return primary, false
+	}
+	return override.apply(primary), true
+}
+
+// apply returns the primary settings with the override's fields replaced
+func (override RegionDatabaseConfig) apply(primary DatabaseConfig) DatabaseConfig {
+	database := primary
+	for _, field := range []struct{ value, target *string }{
+		{&override.Host, &database.Host},
+		{&override.Port, &database.Port},
+		{&override.Username, &database.Username},
+		{&override.Password, &database.Password},
+		{&override.Database, &database.Database},
+		{&override.SSLMode, &database.SSLMode},
+	} {
+		if *field.value != "" {
+			*field.target = *field.value
+		}
+	}
+	return database
+}
+
+// Validate rejects a dedicated database that is the primary one under another name
+func (c ResidencyConfig) Validate(primary DatabaseConfig) error {
+	for region := range c.Regions {
+		database, _ := c.DatabaseFor(region, primary)
+		if database.Host == primary.Host && database.Port == primary.Port && database.Database == primary.Database {
+			return fmt.Errorf("residency.regions.%s: database is the primary database; remove the entry to share it", region)
+		}
+	}
+	return nil
+}
+
+func ResidencyDefaults(v *viper.Viper) {
+	v.SetDefault("residency.enabled", false)
+	v.SetDefault("residency.home_region", "eu")
+	v.SetDefault("residency.header", "X-Data-Region")
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// ResponseCacheConfig controls HTTP caching of GET responses
+type ResponseCacheConfig struct {
+	// DefaultCacheControl is sent on successful GET responses of routes
+	// without a rule of their own; empty leaves them without the header
+	DefaultCacheControl string `mapstructure:"default_cache_control"`
+	// Routes set caching per route pattern, e.g. "/api/v1/users/:id"
+	Routes []ResponseCacheRouteConfig `mapstructure:"routes"`
+	// RefreshTimeout bounds a background revalidation of a stale response
+	RefreshTimeout time.Duration `mapstructure:"refresh_timeout"`
+}
+
+// ResponseCacheRouteConfig describes how responses of one route are cached
+type ResponseCacheRouteConfig struct {
+	Route string `mapstructure:"route"`
+	// Public lets shared caches store the response; otherwise only the client may
+	Public bool `mapstructure:"public"`
+	// MaxAge is how long a response is fresh
+	MaxAge time.Duration `mapstructure:"max_age"`
+	// StaleWhileRevalidate is how long after MaxAge a stale response may still
+	// be served while a fresh one is fetched in the background
+	StaleWhileRevalidate time.Duration `mapstructure:"stale_while_revalidate"`
+	// ServerSide also keeps responses in the shared Redis cache. Only routes
+	// whose response does not depend on the caller may set it, and it has no
+	// effect while the cache is disabled.
+	ServerSide bool `mapstructure:"server_side"`
+}
+
+// CacheControl renders the route's Cache-Control header
+func (r ResponseCacheRouteConfig) CacheControl() string {
+	directives := []string{"private"}
+	if r.Public {
+		directives[0] = "public"
+	}
+	directives = append(directives, "max-age="+strconv.Itoa(int(r.MaxAge/time.Second)))
+	if r.StaleWhileRevalidate > 0 {
+		directives = append(directives, "stale-while-revalidate="+strconv.Itoa(int(r.StaleWhileRevalidate/time.Second)))
+	}
+	return strings.Join(directives, ", ")
+}
+
+// Validate rejects malformed routes and negative durations
+func (c ResponseCacheConfig) Validate() error {
+	for i, route := range c.Routes {
+		if !strings.HasPrefix(route.Route, "/") {
+			return fmt.Errorf("response_cache.routes[%d].route: %q must be a route pattern", i, route.Route)
+		}
+		if route.MaxAge < 0 || route.StaleWhileRevalidate < 0 {
+			return fmt.Errorf("response_cache.routes[%d]: durations must not be negative", i)
+		}
+		if route.ServerSide && route.MaxAge <= 0 {
+			return fmt.Errorf("response_cache.routes[%d]: server_side requires a positive max_age", i)
+		}
+	}
+	return nil
+}
+
+func ResponseCacheDefaults(v *viper.Viper) {
+	v.SetDefault("response_cache.default_cache_control", "")
+	v.SetDefault("response_cache.routes", []map[string]interface{}{})
+	v.SetDefault("response_cache.refresh_timeout", 5*time.Second)
+}
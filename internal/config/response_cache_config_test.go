@@ -0,0 +1,27 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseCacheRouteConfig_CacheControl(t *testing.T) {
+	route := ResponseCacheRouteConfig{MaxAge: 30 * time.Second, StaleWhileRevalidate: time.Minute}
+	assert.Equal(t, "private, max-age=30, stale-while-revalidate=60", route.CacheControl())
+
+	route = ResponseCacheRouteConfig{Public: true, MaxAge: 5 * time.Minute}
+	assert.Equal(t, "public, max-age=300", route.CacheControl())
+}
+
+func TestResponseCacheConfig_Validate(t *testing.T) {
+	valid := ResponseCacheConfig{Routes: []ResponseCacheRouteConfig{{Route: "/api/v1/users/:id", MaxAge: time.Second, ServerSide: true}}}
+	assert.NoError(t, valid.Validate())
+
+	relative := ResponseCacheConfig{Routes: []ResponseCacheRouteConfig{{Route: "users/:id"}}}
+	assert.ErrorContains(t, relative.Validate(), "response_cache.routes[0].route")
+
+	uncached := ResponseCacheConfig{Routes: []ResponseCacheRouteConfig{{Route: "/api/v1/users/:id", ServerSide: true}}}
+	assert.ErrorContains(t, uncached.Validate(), "server_side requires a positive max_age")
+}
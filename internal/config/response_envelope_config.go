@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// ResponseEnvelopeConfig controls the optional {data, meta, errors} envelope
+// around JSON responses, for consumers that require uniform envelopes
+type ResponseEnvelopeConfig struct {
+	// Enabled lets clients ask for the envelope with Header
+	Enabled bool `mapstructure:"enabled"`
+	// Default wraps responses of clients that do not send Header
+	Default bool `mapstructure:"default"`
+	// Header is the request header choosing the envelope, "true" or "false"
+	Header string `mapstructure:"header"`
+}
+
+// Validate requires a header to negotiate the envelope with
+func (c ResponseEnvelopeConfig) Validate() error {
+	if c.Enabled && c.Header == "" {
+		return fmt.Errorf("response_envelope.header is required when the envelope is enabled")
+	}
+	return nil
+}
+
+func ResponseEnvelopeDefaults(v *viper.Viper) {
+	v.SetDefault("response_envelope.enabled", false)
+	v.SetDefault("response_envelope.default", false)
+	v.SetDefault("response_envelope.header", "X-Response-Envelope")
+}
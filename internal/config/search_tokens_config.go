@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// developmentSearchTokenKey keys search tokens outside production when no key
+// is configured
+const developmentSearchTokenKey = "development-only-search-token-key"
+
+// minSearchTokenKeyLength is the shortest key accepted
+const minSearchTokenKeyLength = 32
+
+// SearchTokensConfig keys the search tokens of fields looked up by equality,
+// such as phone numbers
+type SearchTokensConfig struct {
+	// Key must stay stable: tokens stored under another key stop matching until
+	// the migration command recomputes them. Prefer
+	// USER_SERVICE_SEARCH_TOKENS_KEY_FILE.
+	Key string `mapstructure:"key"`
+}
+
+// EffectiveKey returns the configured key, or a well-known development key and
+// true when none is configured
+func (c SearchTokensConfig) EffectiveKey() (string, bool) {
+	if c.Key == "" {
+		return developmentSearchTokenKey, true
+	}
+	return c.Key, false
+}
+
+// Validate requires a key of at least 32 characters in production
+func (c SearchTokensConfig) Validate(production bool) error {
+	if c.Key == "" && production {
+		return fmt.Errorf("search_tokens.key: required in production")
+	}
+	if c.Key != "" && len(c.Key) < minSearchTokenKeyLength {
+		return fmt.Errorf("search_tokens.key: must be at least %d characters", minSearchTokenKeyLength)
+	}
+	return nil
+}
+
+func SearchTokensDefaults(v *viper.Viper) {
+	v.SetDefault("search_tokens.key", "")
+}
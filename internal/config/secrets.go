@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	envPrefix = "USER_SERVICE"
+	// secretFileSuffix marks environment variables holding the path of a file
+	// with the actual value, as mounted by Docker and Kubernetes secrets
+	secretFileSuffix = "_FILE"
+)
+
+var envKeyReplacer = strings.NewReplacer(".", "_")
+
+// envVarName returns the environment variable a config key is read from
+func envVarName(key string) string {
+	return envPrefix + "_" + strings.ToUpper(envKeyReplacer.Replace(key))
+}
+
+// applySecretFiles sets every key whose <VAR>_FILE environment variable is
+// present to the contents of that file, e.g. database.password from the file
+// named by USER_SERVICE_DATABASE_PASSWORD_FILE. Trailing newlines are trimmed.
+// Setting both <VAR> and <VAR>_FILE is rejected as ambiguous.
+func applySecretFiles(v *viper.Viper) error {
+	for _, key := range v.AllKeys() {
+		envVar := envVarName(key)
+
+		path, ok := os.LookupEnv(envVar + secretFileSuffix)
+		if !ok || path == "" {
+			continue
+		}
+
+		if _, direct := os.LookupEnv(envVar); direct {
+			return fmt.Errorf("both %s and %s%s are set; use only one", envVar, envVar, secretFileSuffix)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s%s: %w", envVar, secretFileSuffix, err)
+		}
+
+		v.Set(key, strings.TrimRight(string(content), "\r\n"))
+	}
+	return nil
+}
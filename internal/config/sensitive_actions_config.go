@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// SensitiveActionsConfig limits how often one account may perform sensitive
+// actions, on top of the IP-based rate limit
+type SensitiveActionsConfig struct {
+	// Limits is keyed by action: password_reset, email_change,
+	// verification_resend or sign_in. A max of 0 disables the limit.
+	Limits map[string]ActionLimitConfig `mapstructure:"limits"`
+}
+
+// ActionLimitConfig allows Max occurrences of an action per Window
+type ActionLimitConfig struct {
+	Max    int           `mapstructure:"max"`
+	Window time.Duration `mapstructure:"window"`
+}
+
+// Validate rejects negative limits and enabled limits without a window
+func (c SensitiveActionsConfig) Validate() error {
+	for action, limit := range c.Limits {
+		if limit.Max < 0 {
+			return fmt.Errorf("sensitive_actions.limits.%s.max must not be negative", action)
+		}
+		if limit.Max > 0 && limit.Window <= 0 {
+			return fmt.Errorf("sensitive_actions.limits.%s.window must be positive", action)
+		}
+	}
+	return nil
+}
+
+func SensitiveActionsDefaults(v *viper.Viper) {
+	for _, action := range []string{"password_reset", "email_change", "verification_resend"} {
+		v.SetDefault("sensitive_actions.limits."+action+".max", 5)
+		v.SetDefault("sensitive_actions.limits."+action+".window", time.Hour)
+	}
+	v.SetDefault("sensitive_actions.limits.sign_in.max", 10)
+	v.SetDefault("sensitive_actions.limits.sign_in.window", 15*time.Minute)
+}
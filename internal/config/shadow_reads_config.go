@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// ShadowReadsConfig mirrors a sample of user reads to a secondary repository
+// and compares its results with the primary's in the background, to try out
+// a storage migration target on live traffic. Responses always come from the
+// primary. While residency is enabled only reads of the home region are
+// mirrored, so the secondary database holds a copy of that region alone.
+type ShadowReadsConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Percentage of reads that are mirrored, from 0 to 100
+	Percentage float64 `mapstructure:"percentage"`
+	// Timeout bounds a mirrored read
+	Timeout time.Duration `mapstructure:"timeout"`
+	// MaxInFlight caps concurrent mirrored reads; reads beyond it are not mirrored
+	MaxInFlight int `mapstructure:"max_in_flight"`
+	// Database is the secondary database; empty fields inherit the primary value
+	Database RegionDatabaseConfig `mapstructure:"database"`
+}
+
+// DatabaseFor returns the settings of the secondary database
+func (c ShadowReadsConfig) DatabaseFor(primary DatabaseConfig) DatabaseConfig {
+	return c.Database.apply(primary)
+}
+
+// Validate rejects out-of-range settings and a secondary database that is the
+// primary one
+func (c ShadowReadsConfig) Validate(primary DatabaseConfig) error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Percentage < 0 || c.Percentage > 100 {
+		return fmt.Errorf("shadow_reads.percentage: %v must be between 0 and 100", c.Percentage)
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("shadow_reads.timeout: must be positive")
+	}
+	if c.MaxInFlight <= 0 {
+		return fmt.Errorf("shadow_reads.max_in_flight: must be positive")
+	}
+
+	database := c.DatabaseFor(primary)
+	if database.Host == primary.Host && database.Port == primary.Port && database.Database == primary.Database {
+		return fmt.Errorf("shadow_reads.database: must not be the primary database")
+	}
+	return nil
+}
+
+func ShadowReadsDefaults(v *viper.Viper) {
+	v.SetDefault("shadow_reads.enabled", false)
+	v.SetDefault("shadow_reads.percentage", 1.0)
+	v.SetDefault("shadow_reads.timeout", 2*time.Second)
+	v.SetDefault("shadow_reads.max_in_flight", 16)
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// TimeConfig controls how timestamps are presented. The API always emits
+// RFC 3339 UTC timestamps; DisplayTimezone is only a hint telling clients which
+// IANA zone to render them in when the user has no preference of their own.
+type TimeConfig struct {
+	DisplayTimezone string `mapstructure:"display_timezone"`
+}
+
+// DisplayLocation resolves DisplayTimezone, e.g. "Europe/Madrid"
+func (c TimeConfig) DisplayLocation() (*time.Location, error) {
+	location, err := time.LoadLocation(c.DisplayTimezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time.display_timezone %q: %w", c.DisplayTimezone, err)
+	}
+	return location, nil
+}
+
+func TimeDefaults(v *viper.Viper) {
+	v.SetDefault("time.display_timezone", "UTC")
+}
@@ -0,0 +1,21 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeConfig_DisplayLocation(t *testing.T) {
+	location, err := TimeConfig{DisplayTimezone: "America/Bogota"}.DisplayLocation()
+
+	require.NoError(t, err)
+	assert.Equal(t, "America/Bogota", location.String())
+}
+
+func TestTimeConfig_DisplayLocation_RejectsUnknownZone(t *testing.T) {
+	_, err := TimeConfig{DisplayTimezone: "Mars/Olympus_Mons"}.DisplayLocation()
+
+	assert.Error(t, err)
+}
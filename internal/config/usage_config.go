@@ -0,0 +1,51 @@
+package config
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// UsageConfig configures per-tenant usage metering for billing. The tenant
+// of a call is the tenant of its API key; anonymous calls are not metered.
+type UsageConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// FlushInterval is how often each instance adds its counts to the hourly
+	// rollups; an instance that crashes loses at most this much usage
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+	// ExportDelay is how long after an hour ends it is exported, so every
+	// instance has flushed it; it must exceed the flush interval
+	ExportDelay time.Duration `mapstructure:"export_delay"`
+	// ExportInterval schedules the usage_export job
+	ExportInterval time.Duration `mapstructure:"export_interval"`
+	// ExportDestination is an S3 prefix (s3://bucket/prefix/) or a local
+	// directory receiving one CSV file per hour; empty disables the export.
+	// S3 is reached with the backup.s3 settings and AWS credentials.
+	ExportDestination string `mapstructure:"export_destination"`
+}
+
+// Validate rejects flushes that could land after their hour was exported
+func (c UsageConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.FlushInterval <= 0 {
+		return errors.New("usage.flush_interval must be positive")
+	}
+	if c.ExportDelay <= c.FlushInterval {
+		return errors.New("usage.export_delay must exceed usage.flush_interval")
+	}
+	if c.ExportDestination != "" && c.ExportInterval <= 0 {
+		return errors.New("usage.export_interval must be positive")
+	}
+	return nil
+}
+
+func UsageDefaults(v *viper.Viper) {
+	v.SetDefault("usage.enabled", false)
+	v.SetDefault("usage.flush_interval", time.Minute)
+	v.SetDefault("usage.export_delay", 15*time.Minute)
+	v.SetDefault("usage.export_interval", 15*time.Minute)
+	v.SetDefault("usage.export_destination", "")
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// VerificationConfig tunes verification email requests
+type VerificationConfig struct {
+	// ResendCooldown is the minimum time between two verification emails for
+	// the same user; requests within it are answered with the time left
+	ResendCooldown time.Duration `mapstructure:"resend_cooldown"`
+}
+
+// Validate rejects a cooldown that would let clients resend back to back
+func (c VerificationConfig) Validate() error {
+	if c.ResendCooldown <= 0 {
+		return errors.New("verification.resend_cooldown must be positive")
+	}
+	return nil
+}
+
+func VerificationDefaults(v *viper.Viper) {
+	v.SetDefault("verification.resend_cooldown", "60s")
+}
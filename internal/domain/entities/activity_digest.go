@@ -0,0 +1,80 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxDeviceNameLength bounds the user agent kept to describe a device
+const maxDeviceNameLength = 200
+
+// Device is a browser or app a user signed in from
+type Device struct {
+	// ID identifies the device across sign-ins without storing the raw user
+	// agent as a key
+	ID string `json:"id"`
+	// Name is the user agent the device presented, shortened
+	Name        string    `json:"name"`
+	FirstSeenAt time.Time `json:"first_seen_at"`
+}
+
+// DeviceFromUserAgent describes the device behind a user agent. Devices are
+// told apart by user agent alone, so a browser update counts as a new device.
+func DeviceFromUserAgent(userAgent string, seenAt time.Time) Device {
+	userAgent = strings.TrimSpace(userAgent)
+	sum := sha256.Sum256([]byte(userAgent))
+
+	name := userAgent
+	if len(name) > maxDeviceNameLength {
+		name = name[:maxDeviceNameLength]
+	}
+	if name == "" {
+		name = "Unknown device"
+	}
+	return Device{ID: hex.EncodeToString(sum[:8]), Name: name, FirstSeenAt: seenAt}
+}
+
+// ActivityDigest summarizes a user's account activity over a period
+type ActivityDigest struct {
+	UserID uint
+	// Residency is the region of the user, as user IDs repeat across regions
+	Residency Residency
+	// AfterSequence is the event store position the digest starts after
+	AfterSequence  uint64
+	PeriodStart    time.Time
+	PeriodEnd      time.Time
+	SignIns        int
+	ProfileChanges int
+	// NewDevices lists devices first signed in from during the period
+	NewDevices []Device
+}
+
+// IsEmpty reports whether nothing happened during the period
+func (d *ActivityDigest) IsEmpty() bool {
+	return d.SignIns == 0 && d.ProfileChanges == 0 && len(d.NewDevices) == 0
+}
+
+// Event returns the event the digest is published as. Its digest_id only
+// depends on where the digest starts, so a digest published again after a
+// failed run can be recognized by consumers.
+func (d *ActivityDigest) Event() *UserEvent {
+	devices := d.NewDevices
+	if devices == nil {
+		devices = []Device{}
+	}
+	digestID := strconv.FormatUint(uint64(d.UserID), 10) + ":" + strconv.FormatUint(d.AfterSequence, 10)
+	if d.Residency != "" {
+		digestID = string(d.Residency) + ":" + digestID
+	}
+	return NewUserEvent(UserEventActivityDigest, d.UserID, map[string]interface{}{
+		"digest_id":       digestID,
+		"period_start":    d.PeriodStart.UTC(),
+		"period_end":      d.PeriodEnd.UTC(),
+		"sign_ins":        d.SignIns,
+		"profile_changes": d.ProfileChanges,
+		"new_devices":     devices,
+	})
+}
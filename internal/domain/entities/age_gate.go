@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"time"
+
+	domainErrors "user-service/internal/domain/errors"
+)
+
+// AgeOfMajority is the age below which events flag a user as a minor
+const AgeOfMajority = 18
+
+// earliestDateOfBirth bounds plausible dates of birth
+var earliestDateOfBirth = time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// AgeGatePolicy is what happens to users younger than an age gate's minimum
+type AgeGatePolicy string
+
+const (
+	// AgeGateReject refuses to create the user
+	AgeGateReject AgeGatePolicy = "reject"
+	// AgeGateGuardianConsent creates the user pending until a guardian
+	// consents
+	AgeGateGuardianConsent AgeGatePolicy = "guardian_consent"
+)
+
+// AgeGate holds back users younger than MinimumAge; a zero MinimumAge lets
+// everyone through
+type AgeGate struct {
+	MinimumAge int
+	Policy     AgeGatePolicy
+}
+
+// Admit applies the gate to a new user as of now. Users who gave no date of
+// birth pass. It reports whether the user was made pending guardian consent.
+func (g AgeGate) Admit(user *User, now time.Time) (bool, error) {
+	age, known := user.AgeAt(now)
+	if !known || age >= g.MinimumAge {
+		return false, nil
+	}
+
+	if g.Policy == AgeGateGuardianConsent {
+		user.Status = UserStatusPending
+		return true, nil
+	}
+	return false, domainErrors.ErrUnderMinimumAge
+}
+
+// AgeGates holds the age gate of each residency region, as regions follow
+// different laws (COPPA, GDPR-K), and the gate of users in no region
+type AgeGates struct {
+	Default AgeGate
+	Regions map[Residency]AgeGate
+}
+
+// For returns the gate of a region
+func (g AgeGates) For(region Residency) AgeGate {
+	if gate, ok := g.Regions[region]; ok {
+		return gate
+	}
+	return g.Default
+}
+
+// SetDateOfBirth sets the date of birth, keeping only the calendar date
+func (u *User) SetDateOfBirth(dateOfBirth, now time.Time) error {
+	date := time.Date(dateOfBirth.Year(), dateOfBirth.Month(), dateOfBirth.Day(), 0, 0, 0, 0, time.UTC)
+	if date.Before(earliestDateOfBirth) || date.After(now) {
+		return domainErrors.ErrInvalidDateOfBirth
+	}
+	u.DateOfBirth = &date
+	return nil
+}
+
+// AgeAt returns the user's age in whole years at t, and false when the date
+// of birth is unknown
+func (u *User) AgeAt(t time.Time) (int, bool) {
+	if u.DateOfBirth == nil {
+		return 0, false
+	}
+
+	birth := *u.DateOfBirth
+	age := t.Year() - birth.Year()
+	if t.Month() < birth.Month() || (t.Month() == birth.Month() && t.Day() < birth.Day()) {
+		age--
+	}
+	return age, true
+}
+
+// IsMinorAt reports whether the user is younger than AgeOfMajority at t, or
+// nil when the date of birth is unknown
+func (u *User) IsMinorAt(t time.Time) *bool {
+	age, known := u.AgeAt(t)
+	if !known {
+		return nil
+	}
+	minor := age < AgeOfMajority
+	return &minor
+}
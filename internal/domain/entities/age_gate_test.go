@@ -0,0 +1,69 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	domainErrors "user-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUser_AgeAt(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	user := &User{}
+
+	_, known := user.AgeAt(now)
+	assert.False(t, known)
+	assert.Nil(t, user.IsMinorAt(now))
+
+	require.NoError(t, user.SetDateOfBirth(time.Date(2006, 6, 11, 0, 0, 0, 0, time.UTC), now))
+	age, known := user.AgeAt(now)
+	assert.True(t, known)
+	assert.Equal(t, 17, age, "the birthday is tomorrow")
+	assert.True(t, *user.IsMinorAt(now))
+	assert.False(t, *user.IsMinorAt(now.Add(24 * time.Hour)))
+}
+
+func TestUser_SetDateOfBirth_Invalid(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	user := &User{}
+
+	assert.Equal(t, domainErrors.ErrInvalidDateOfBirth, user.SetDateOfBirth(now.AddDate(0, 0, 1), now))
+	assert.Equal(t, domainErrors.ErrInvalidDateOfBirth, user.SetDateOfBirth(time.Date(1899, 12, 31, 0, 0, 0, 0, time.UTC), now))
+	assert.Nil(t, user.DateOfBirth)
+}
+
+func TestAgeGate_Admit(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	fourteen := time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		gate            AgeGate
+		dateOfBirth     *time.Time
+		awaitingConsent bool
+		err             error
+	}{
+		{name: "old enough", gate: AgeGate{MinimumAge: 13, Policy: AgeGateReject}, dateOfBirth: &fourteen},
+		{name: "unknown age", gate: AgeGate{MinimumAge: 16, Policy: AgeGateReject}},
+		{name: "disabled", gate: AgeGate{Policy: AgeGateReject}, dateOfBirth: &fourteen},
+		{name: "rejected", gate: AgeGate{MinimumAge: 16, Policy: AgeGateReject}, dateOfBirth: &fourteen, err: domainErrors.ErrUnderMinimumAge},
+		{name: "guardian consent", gate: AgeGate{MinimumAge: 16, Policy: AgeGateGuardianConsent}, dateOfBirth: &fourteen, awaitingConsent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{Status: UserStatusActive, DateOfBirth: tt.dateOfBirth}
+
+			awaitingConsent, err := tt.gate.Admit(user, now)
+
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.awaitingConsent, awaitingConsent)
+			if tt.awaitingConsent {
+				assert.Equal(t, UserStatusPending, user.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package entities
+
+import "time"
+
+// AuditEvent describes a security or administrative action worth keeping a trail of
+type AuditEvent struct {
+	Action       string                 `json:"action"`
+	ActorID      string                 `json:"actor_id,omitempty"`
+	ResourceType string                 `json:"resource_type,omitempty"`
+	ResourceID   string                 `json:"resource_id,omitempty"`
+	RequestID    string                 `json:"request_id,omitempty"`
+	RemoteIP     string                 `json:"remote_ip,omitempty"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	OccurredAt   time.Time              `json:"occurred_at"`
+}
@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"time"
+)
+
+// AuthorizationCode is a single-use grant handed to a client after a user
+// signs in, exchanged for tokens at the token endpoint. Only the hash of the
+// code is stored.
+type AuthorizationCode struct {
+	CodeHash    string
+	ClientID    string
+	UserID      uint
+	RedirectURI string
+	Scopes      []string
+	// Nonce is echoed in the ID token so the client can detect replays
+	Nonce string
+	// CodeChallenge is the S256 PKCE challenge the code verifier must match
+	CodeChallenge string
+	AuthTime      time.Time
+	ExpiresAt     time.Time
+}
+
+// Expired reports whether the code can no longer be exchanged
+func (a *AuthorizationCode) Expired(now time.Time) bool {
+	return !now.Before(a.ExpiresAt)
+}
+
+// VerifyPKCE checks a code verifier against the S256 challenge (RFC 7636)
+func (a *AuthorizationCode) VerifyPKCE(verifier string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(challenge), []byte(a.CodeChallenge)) == 1
+}
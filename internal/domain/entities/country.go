@@ -0,0 +1,88 @@
+package entities
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"strings"
+
+	domainErrors "user-service/internal/domain/errors"
+)
+
+// countriesCSV lists the ISO 3166-1 countries with their calling code and
+// the locale and time zone most of their users expect
+//
+//go:embed countries.csv
+var countriesCSV string
+
+// Country is an ISO 3166-1 country and the defaults derived from it
+type Country struct {
+	// Code is the alpha-2 code, e.g. ES
+	Code string
+	Name string
+	// CallingCode is the international dialing code, without the plus sign
+	CallingCode string
+	// Localization is empty for uninhabited territories
+	Localization Localization
+}
+
+// countries indexes the embedded list by code
+var countries = loadCountries()
+
+func loadCountries() map[string]Country {
+	records, err := csv.NewReader(strings.NewReader(countriesCSV)).ReadAll()
+	if err != nil {
+		panic("invalid embedded country list: " + err.Error())
+	}
+
+	index := make(map[string]Country, len(records))
+	// The first record is the header
+	for _, record := range records[1:] {
+		index[record[0]] = Country{
+			Code:         record[0],
+			Name:         record[1],
+			CallingCode:  record[2],
+			Localization: Localization{Locale: record[3], Timezone: record[4]},
+		}
+	}
+	return index
+}
+
+// LookupCountry returns the country with an alpha-2 code, ignoring case
+func LookupCountry(code string) (Country, error) {
+	country, ok := countries[strings.ToUpper(strings.TrimSpace(code))]
+	if !ok {
+		return Country{}, domainErrors.ErrInvalidCountry
+	}
+	return country, nil
+}
+
+// trunkZeroCountries dial the leading 0 of national numbers internationally
+var trunkZeroCountries = map[string]bool{"IT": true, "SM": true, "VA": true}
+
+// InternationalPhone returns a phone number written in national format as an
+// international one, dropping the trunk prefix 0 where it is not part of the
+// number. Numbers starting with + or 00 are returned unchanged.
+func (c Country) InternationalPhone(phone string) string {
+	phone = strings.TrimSpace(phone)
+	if phone == "" || strings.HasPrefix(phone, "+") || strings.HasPrefix(phone, "00") {
+		return phone
+	}
+	if !trunkZeroCountries[c.Code] {
+		phone = strings.TrimPrefix(phone, "0")
+	}
+	return "+" + c.CallingCode + " " + phone
+}
+
+// SetCountry sets the country the user lives in; an empty code clears it
+func (u *User) SetCountry(code string) error {
+	if strings.TrimSpace(code) == "" {
+		u.Country = ""
+		return nil
+	}
+	country, err := LookupCountry(code)
+	if err != nil {
+		return err
+	}
+	u.Country = country.Code
+	return nil
+}
@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"testing"
+
+	domainErrors "user-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountries_EmbeddedListIsValid(t *testing.T) {
+	// Every ISO 3166-1 country is listed
+	assert.Len(t, countries, 249)
+
+	for code, country := range countries {
+		assert.Regexp(t, `^[A-Z]{2}$`, code)
+		assert.NotEmpty(t, country.Name, code)
+		assert.Regexp(t, `^[0-9]{1,3}$`, country.CallingCode, code)
+
+		// Defaults are canonical, so they are stored as if the user chose them
+		if country.Localization == (Localization{}) {
+			continue
+		}
+		localization, err := NewLocalization(country.Localization.Locale, country.Localization.Timezone)
+		require.NoError(t, err, code)
+		assert.Equal(t, localization, country.Localization, code)
+	}
+}
+
+func TestLookupCountry(t *testing.T) {
+	country, err := LookupCountry(" es ")
+	require.NoError(t, err)
+	assert.Equal(t, "ES", country.Code)
+	assert.Equal(t, "34", country.CallingCode)
+	assert.Equal(t, Localization{Locale: "es-ES", Timezone: "Europe/Madrid"}, country.Localization)
+
+	for _, code := range []string{"", "XX", "ESP", "E"} {
+		_, err := LookupCountry(code)
+		assert.Equal(t, domainErrors.ErrInvalidCountry, err, code)
+	}
+}
+
+func TestCountry_InternationalPhone(t *testing.T) {
+	spain, _ := LookupCountry("ES")
+	unitedKingdom, _ := LookupCountry("GB")
+	italy, _ := LookupCountry("IT")
+
+	assert.Equal(t, "+34 600 12 34 56", spain.InternationalPhone("600 12 34 56"))
+	assert.Equal(t, "+44 7700 900123", unitedKingdom.InternationalPhone("07700 900123"))
+	// Italian numbers keep their leading 0
+	assert.Equal(t, "+39 06 1234 5678", italy.InternationalPhone("06 1234 5678"))
+	// International numbers and empty ones are left alone
+	assert.Equal(t, "+1 555 0100", spain.InternationalPhone("+1 555 0100"))
+	assert.Equal(t, "0044 7700 900123", spain.InternationalPhone("0044 7700 900123"))
+	assert.Equal(t, "", spain.InternationalPhone(""))
+
+	// Normalized, national and international numbers are the same number
+	assert.Equal(t, NormalizePhone("+34 600 12 34 56"), NormalizePhone(spain.InternationalPhone("600123456")))
+}
+
+func TestUser_SetCountry(t *testing.T) {
+	user := &User{}
+
+	require.NoError(t, user.SetCountry("fr"))
+	assert.Equal(t, "FR", user.Country)
+
+	assert.Equal(t, domainErrors.ErrInvalidCountry, user.SetCountry("France"))
+	assert.Equal(t, "FR", user.Country)
+
+	require.NoError(t, user.SetCountry(""))
+	assert.Empty(t, user.Country)
+}
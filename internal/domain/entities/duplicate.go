@@ -0,0 +1,190 @@
+package entities
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"user-service/pkg/textdistance"
+)
+
+// DuplicateSignal names the evidence that two accounts belong to one person
+type DuplicateSignal string
+
+const (
+	DuplicateSignalEmail DuplicateSignal = "canonical_email"
+	DuplicateSignalPhone DuplicateSignal = "phone"
+	DuplicateSignalName  DuplicateSignal = "name"
+)
+
+// duplicateSignalWeights is how strongly each signal alone suggests a
+// duplicate. Signals combine as independent evidence.
+var duplicateSignalWeights = map[DuplicateSignal]float64{
+	DuplicateSignalEmail: 0.9,
+	DuplicateSignalPhone: 0.7,
+	DuplicateSignalName:  0.4,
+}
+
+// nearIdenticalNameSimilarity is the name similarity counted as a signal
+const nearIdenticalNameSimilarity = 0.85
+
+// DuplicateSuggestion proposes merging DuplicateUserID into UserID, the
+// older account
+type DuplicateSuggestion struct {
+	ID              uint
+	UserID          uint
+	DuplicateUserID uint
+	// Confidence is between 0 and 1
+	Confidence float64
+	Signals    []DuplicateSignal
+	DetectedAt time.Time
+}
+
+// NewDuplicateSuggestion scores a pair of users, returning nil when no signal
+// links them
+func NewDuplicateSuggestion(a, b *User, detectedAt time.Time) *DuplicateSuggestion {
+	if a.ID == b.ID {
+		return nil
+	}
+	if b.ID < a.ID {
+		a, b = b, a
+	}
+
+	var signals []DuplicateSignal
+	remaining := 1.0
+	addSignal := func(signal DuplicateSignal, strength float64) {
+		signals = append(signals, signal)
+		remaining *= 1 - duplicateSignalWeights[signal]*strength
+	}
+
+	if CanonicalEmail(a.Email) == CanonicalEmail(b.Email) {
+		addSignal(DuplicateSignalEmail, 1)
+	}
+	if phone := NormalizePhone(a.Phone); phone != "" && phone == NormalizePhone(b.Phone) {
+		addSignal(DuplicateSignalPhone, 1)
+	}
+	if similarity := NameSimilarity(a, b); similarity >= nearIdenticalNameSimilarity {
+		addSignal(DuplicateSignalName, similarity)
+	}
+
+	if len(signals) == 0 {
+		return nil
+	}
+
+	return &DuplicateSuggestion{
+		UserID:          a.ID,
+		DuplicateUserID: b.ID,
+		Confidence:      math.Round((1-remaining)*100) / 100,
+		Signals:         signals,
+		DetectedAt:      detectedAt,
+	}
+}
+
+// CanonicalEmail reduces an email to the mailbox it delivers to: lowercased,
+// without a +tag and, for Gmail, without dots in the local part
+func CanonicalEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return email
+	}
+
+	local, _, _ = strings.Cut(local, "+")
+	if domain == "gmail.com" || domain == "googlemail.com" {
+		local = strings.ReplaceAll(local, ".", "")
+		domain = "gmail.com"
+	}
+	return local + "@" + domain
+}
+
+// NormalizePhone keeps the digits of a phone number, dropping a leading 00
+// international prefix. Numbers too short to identify anyone yield "".
+func NormalizePhone(phone string) string {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+	digits = strings.TrimPrefix(digits, "00")
+
+	if len(digits) < 7 {
+		return ""
+	}
+	return digits
+}
+
+// NormalizedName lowercases the user's full name and sorts its words, so
+// swapped first and last names compare equal
+func NormalizedName(u *User) string {
+	words := strings.FieldsFunc(strings.ToLower(u.FullName()), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	slices.Sort(words)
+	return strings.Join(words, " ")
+}
+
+// PhoneticName encodes each word of the user's name with Soundex and sorts the
+// codes, so names that sound alike, such as Jon and John Smyth, compare equal
+func PhoneticName(u *User) string {
+	words := strings.FieldsFunc(strings.ToLower(u.FullName()), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	codes := make([]string, len(words))
+	for i, word := range words {
+		codes[i] = soundex(word)
+	}
+	slices.Sort(codes)
+	return strings.Join(codes, " ")
+}
+
+// soundexCodes groups the consonants that sound alike; other letters have none
+var soundexCodes = map[rune]rune{
+	'b': '1', 'f': '1', 'p': '1', 'v': '1',
+	'c': '2', 'g': '2', 'j': '2', 'k': '2', 'q': '2', 's': '2', 'x': '2', 'z': '2',
+	'd': '3', 't': '3',
+	'l': '4',
+	'm': '5', 'n': '5',
+	'r': '6',
+}
+
+// soundex returns the American Soundex code of a lowercase word: its first
+// letter followed by the codes of the next three consonants, zero padded.
+// Consonants repeating the previous code are skipped unless a vowel separates
+// them.
+func soundex(word string) string {
+	runes := []rune(word)
+	code := []rune{runes[0]}
+	last := soundexCodes[runes[0]]
+	for _, r := range runes[1:] {
+		if len(code) == 4 {
+			break
+		}
+		digit, ok := soundexCodes[r]
+		switch {
+		case ok && digit != last:
+			code = append(code, digit)
+			last = digit
+		case !ok && r != 'h' && r != 'w':
+			last = 0
+		}
+	}
+	for len(code) < 4 {
+		code = append(code, '0')
+	}
+	return string(code)
+}
+
+// NameSimilarity compares the normalized names of two users, from 0 for
+// unrelated names to 1 for identical ones
+func NameSimilarity(a, b *User) float64 {
+	nameA, nameB := NormalizedName(a), NormalizedName(b)
+	longest := max(utf8.RuneCountInString(nameA), utf8.RuneCountInString(nameB))
+	if longest == 0 {
+		return 0
+	}
+	return 1 - float64(textdistance.Levenshtein(nameA, nameB))/float64(longest)
+}
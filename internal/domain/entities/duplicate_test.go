@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalEmail(t *testing.T) {
+	tests := map[string]string{
+		"John.Doe+news@Example.com":  "john.doe@example.com",
+		"j.o.h.n.doe+spam@gmail.com": "johndoe@gmail.com",
+		"john.doe@googlemail.com":    "johndoe@gmail.com",
+		"  jane@example.com ":        "jane@example.com",
+		"not-an-email":               "not-an-email",
+	}
+
+	for email, expected := range tests {
+		assert.Equal(t, expected, CanonicalEmail(email), email)
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	assert.Equal(t, "34600123456", NormalizePhone("+34 600 12 34 56"))
+	assert.Equal(t, "34600123456", NormalizePhone("0034-600-123-456"))
+	assert.Equal(t, "", NormalizePhone("123"), "too short to identify anyone")
+}
+
+func TestNameSimilarity(t *testing.T) {
+	john := &User{FirstName: "John", LastName: "Doe"}
+
+	assert.Equal(t, 1.0, NameSimilarity(john, &User{FirstName: "Doe", LastName: "JOHN"}), "swapped and differently cased")
+	assert.Greater(t, NameSimilarity(john, &User{FirstName: "Jon", LastName: "Doe"}), nearIdenticalNameSimilarity)
+	assert.Less(t, NameSimilarity(john, &User{FirstName: "Jane", LastName: "Smith"}), nearIdenticalNameSimilarity)
+}
+
+func TestPhoneticName(t *testing.T) {
+	john := &User{FirstName: "John", LastName: "Smith"}
+
+	assert.Equal(t, "j500 s530", PhoneticName(john))
+	assert.Equal(t, PhoneticName(john), PhoneticName(&User{FirstName: "Smyth", LastName: "Jon"}), "swapped and spelled differently")
+	assert.Equal(t, "a261", PhoneticName(&User{FirstName: "Ashcraft"}), "h does not separate repeated codes")
+	assert.Equal(t, "t522", PhoneticName(&User{FirstName: "Tymczak"}), "vowels do")
+	assert.NotEqual(t, PhoneticName(john), PhoneticName(&User{FirstName: "Jane", LastName: "Doe"}))
+}
+
+func TestNewDuplicateSuggestion_CombinesSignals(t *testing.T) {
+	// Given a newer account with the same Gmail mailbox and a near-identical name
+	older := &User{ID: 1, Email: "john.doe@gmail.com", FirstName: "John", LastName: "Doe", Phone: "+1 555 010 9999"}
+	newer := &User{ID: 7, Email: "johndoe+shop@gmail.com", FirstName: "Jon", LastName: "Doe", Phone: "555-0100"}
+	now := time.Now()
+
+	// When
+	suggestion := NewDuplicateSuggestion(newer, older, now)
+
+	// Then the older account is the one to keep
+	require.NotNil(t, suggestion)
+	assert.Equal(t, uint(1), suggestion.UserID)
+	assert.Equal(t, uint(7), suggestion.DuplicateUserID)
+	assert.Equal(t, []DuplicateSignal{DuplicateSignalEmail, DuplicateSignalName}, suggestion.Signals)
+	assert.Greater(t, suggestion.Confidence, duplicateSignalWeights[DuplicateSignalEmail])
+	assert.LessOrEqual(t, suggestion.Confidence, 1.0)
+}
+
+func TestNewDuplicateSuggestion_UnrelatedUsers(t *testing.T) {
+	a := &User{ID: 1, Email: "john@example.com", FirstName: "John", LastName: "Doe"}
+	b := &User{ID: 2, Email: "jane@example.com", FirstName: "Jane", LastName: "Smith"}
+
+	assert.Nil(t, NewDuplicateSuggestion(a, b, time.Now()))
+}
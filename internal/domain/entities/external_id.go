@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"regexp"
+	"strings"
+
+	domainErrors "user-service/internal/domain/errors"
+)
+
+// maxExternalIDLength bounds the IDs source systems may supply
+const maxExternalIDLength = 255
+
+// externalSourceRegex matches the names of the systems external IDs come from
+var externalSourceRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{0,49}$`)
+
+// SetExternalID records the ID a source system knows the user by, so other
+// systems can join on it. A user has at most one ID per source.
+func (u *User) SetExternalID(source, id string) error {
+	source = strings.ToLower(strings.TrimSpace(source))
+	id = strings.TrimSpace(id)
+	if !externalSourceRegex.MatchString(source) || id == "" || len(id) > maxExternalIDLength {
+		return domainErrors.ErrInvalidExternalID
+	}
+
+	if u.ExternalIDs == nil {
+		u.ExternalIDs = make(map[string]string)
+	}
+	u.ExternalIDs[source] = id
+	return nil
+}
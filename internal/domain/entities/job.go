@@ -0,0 +1,52 @@
+package entities
+
+import "time"
+
+type JobStatus string
+
+const (
+	JobStatusNeverRun  JobStatus = "never_run"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusFailed    JobStatus = "failed"
+)
+
+type JobTrigger string
+
+const (
+	JobTriggerSchedule JobTrigger = "schedule"
+	JobTriggerManual   JobTrigger = "manual"
+)
+
+// JobState is the persisted state of a background job, shared by every
+// instance running the scheduler
+type JobState struct {
+	Name           string
+	Paused         bool
+	LastStatus     JobStatus
+	LastTrigger    JobTrigger
+	LastStartedAt  time.Time
+	LastFinishedAt time.Time
+	LastDuration   time.Duration
+	LastError      string
+	// LeaseUntil is when the instance running the job loses its claim on it
+	LeaseUntil time.Time
+}
+
+// NewJobState returns the state of a job that has never run
+func NewJobState(name string) *JobState {
+	return &JobState{Name: name, LastStatus: JobStatusNeverRun}
+}
+
+// Running reports whether an instance holds a live claim on the job
+func (s *JobState) Running(now time.Time) bool {
+	return s.LastStatus == JobStatusRunning && now.Before(s.LeaseUntil)
+}
+
+// DueAt returns when the job is next due given its interval
+func (s *JobState) DueAt(interval time.Duration) time.Time {
+	if s.LastStartedAt.IsZero() {
+		return time.Time{}
+	}
+	return s.LastStartedAt.Add(interval)
+}
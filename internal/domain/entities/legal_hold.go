@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	domainErrors "user-service/internal/domain/errors"
+)
+
+// MaxLegalHoldReasonLength caps the reason recorded with a legal hold
+const MaxLegalHoldReasonLength = 500
+
+// LegalHold preserves a user's data for litigation or an investigation: while
+// it is active the user cannot be erased
+type LegalHold struct {
+	Reason   string    `json:"reason"`
+	PlacedBy string    `json:"placed_by"`
+	PlacedAt time.Time `json:"placed_at"`
+	// ExpiresAt is when the hold lapses; nil means until released
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// NewLegalHold validates a legal hold placed at now
+func NewLegalHold(reason, placedBy string, expiresAt *time.Time, now time.Time) (*LegalHold, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len(reason) > MaxLegalHoldReasonLength {
+		return nil, domainErrors.ErrInvalidLegalHoldReason
+	}
+
+	if expiresAt != nil {
+		if !expiresAt.After(now) {
+			return nil, domainErrors.ErrInvalidLegalHoldExpiry
+		}
+		utc := expiresAt.UTC()
+		expiresAt = &utc
+	}
+
+	return &LegalHold{Reason: reason, PlacedBy: strings.TrimSpace(placedBy), PlacedAt: now.UTC(), ExpiresAt: expiresAt}, nil
+}
+
+// ActiveAt reports whether the hold still applies at t
+func (h *LegalHold) ActiveAt(t time.Time) bool {
+	return h.ExpiresAt == nil || t.Before(*h.ExpiresAt)
+}
+
+// Erasable returns ErrLegalHoldActive while the user is under an active legal
+// hold, which every flow erasing users must respect
+func (u *User) Erasable(now time.Time) error {
+	if u.LegalHold != nil && u.LegalHold.ActiveAt(now) {
+		return domainErrors.ErrLegalHoldActive
+	}
+	return nil
+}
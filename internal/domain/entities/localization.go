@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"strings"
+	"time"
+	// Time zones are validated against the embedded IANA database, so the
+	// result does not depend on the host's zoneinfo
+	_ "time/tzdata"
+
+	domainErrors "user-service/internal/domain/errors"
+
+	"golang.org/x/text/language"
+)
+
+// Localization is the locale and time zone communications are adapted to
+type Localization struct {
+	Locale   string
+	Timezone string
+}
+
+// NewLocalization validates and canonicalizes a locale and time zone
+func NewLocalization(locale, timezone string) (Localization, error) {
+	locale, err := NormalizeLocale(locale)
+	if err != nil {
+		return Localization{}, err
+	}
+	timezone, err = NormalizeTimezone(timezone)
+	if err != nil {
+		return Localization{}, err
+	}
+	return Localization{Locale: locale, Timezone: timezone}, nil
+}
+
+// NormalizeLocale validates a BCP 47 language tag with known subtags and
+// returns its canonical form, e.g. "en-us" becomes "en-US"
+func NormalizeLocale(locale string) (string, error) {
+	tag, err := language.Parse(strings.TrimSpace(locale))
+	if err != nil || tag == language.Und {
+		return "", domainErrors.ErrInvalidLocale
+	}
+	return tag.String(), nil
+}
+
+// NormalizeTimezone validates an IANA time zone name, e.g. Europe/Madrid
+func NormalizeTimezone(timezone string) (string, error) {
+	timezone = strings.TrimSpace(timezone)
+	// LoadLocation maps "" to UTC and "Local" to the host's zone
+	if timezone == "" || timezone == "Local" {
+		return "", domainErrors.ErrInvalidTimezone
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return "", domainErrors.ErrInvalidTimezone
+	}
+	return location.String(), nil
+}
+
+// Localization returns the locale and time zone the user chose
+func (p UserPreferences) Localization() Localization {
+	return Localization{Locale: p.Locale, Timezone: p.Timezone}
+}
+
+// DefaultTo fills in the locale and time zone the user has not chosen
+func (p *UserPreferences) DefaultTo(defaults Localization) {
+	if p.Locale == "" {
+		p.Locale = defaults.Locale
+	}
+	if p.Timezone == "" {
+		p.Timezone = defaults.Timezone
+	}
+}
+
+// Localize sets the locale and time zone, keeping the current ones where
+// empty
+func (p *UserPreferences) Localize(locale, timezone string) error {
+	var err error
+	if locale != "" {
+		if locale, err = NormalizeLocale(locale); err != nil {
+			return err
+		}
+		p.Locale = locale
+	}
+	if timezone != "" {
+		if timezone, err = NormalizeTimezone(timezone); err != nil {
+			return err
+		}
+		p.Timezone = timezone
+	}
+	return nil
+}
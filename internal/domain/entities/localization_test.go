@@ -0,0 +1,83 @@
+package entities
+
+import (
+	"testing"
+
+	domainErrors "user-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeLocale(t *testing.T) {
+	tests := []struct {
+		locale   string
+		expected string
+		valid    bool
+	}{
+		{locale: "en-US", expected: "en-US", valid: true},
+		{locale: "en-us", expected: "en-US", valid: true},
+		{locale: "pt_BR", expected: "pt-BR", valid: true},
+		{locale: "es", expected: "es", valid: true},
+		{locale: "", valid: false},
+		{locale: "und", valid: false},
+		{locale: "english", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			locale, err := NormalizeLocale(tt.locale)
+
+			if !tt.valid {
+				assert.Equal(t, domainErrors.ErrInvalidLocale, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, locale)
+		})
+	}
+}
+
+func TestNormalizeTimezone(t *testing.T) {
+	tests := []struct {
+		timezone string
+		valid    bool
+	}{
+		{timezone: "Europe/Madrid", valid: true},
+		{timezone: "UTC", valid: true},
+		{timezone: "", valid: false},
+		{timezone: "Local", valid: false},
+		{timezone: "Mars/Olympus_Mons", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timezone, func(t *testing.T) {
+			timezone, err := NormalizeTimezone(tt.timezone)
+
+			if !tt.valid {
+				assert.Equal(t, domainErrors.ErrInvalidTimezone, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.timezone, timezone)
+		})
+	}
+}
+
+func TestUserPreferences_Localize(t *testing.T) {
+	// Given preferences with defaults filled in
+	preferences := UserPreferences{}
+	preferences.DefaultTo(Localization{Locale: "en-US", Timezone: "UTC"})
+
+	// When only the locale is chosen
+	err := preferences.Localize("es-es", "")
+
+	// Then the time zone is kept
+	require.NoError(t, err)
+	assert.Equal(t, Localization{Locale: "es-ES", Timezone: "UTC"}, preferences.Localization())
+
+	// And an invalid time zone changes nothing
+	err = preferences.Localize("", "Nowhere/City")
+	assert.Equal(t, domainErrors.ErrInvalidTimezone, err)
+	assert.Equal(t, "UTC", preferences.Timezone)
+}
@@ -0,0 +1,198 @@
+package entities
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	domainErrors "user-service/internal/domain/errors"
+)
+
+// Scopes a first-party app can request when signing users in
+const (
+	ScopeOpenID  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+	ScopePhone   = "phone"
+)
+
+// OIDCScopes lists every supported scope
+var OIDCScopes = []string{ScopeOpenID, ScopeProfile, ScopeEmail, ScopePhone}
+
+// ScopeClaims lists the standard claims each scope releases; openid only
+// releases sub, which is always returned
+var ScopeClaims = map[string][]string{
+	ScopeProfile: {"name", "given_name", "family_name", "updated_at"},
+	ScopeEmail:   {"email"},
+	ScopePhone:   {"phone_number"},
+}
+
+// maxClaimNameLength bounds the names claims can be mapped to
+const maxClaimNameLength = 64
+
+// OIDCClient is an application allowed to sign users in through the service
+// acting as an OpenID Connect provider
+type OIDCClient struct {
+	ID       uint   `json:"id"`
+	ClientID string `json:"client_id"`
+	Name     string `json:"name"`
+	// SecretHash is empty for public clients (e.g. single-page and mobile
+	// apps), which authenticate with PKCE alone
+	SecretHash   string   `json:"-"`
+	RedirectURIs []string `json:"redirect_uris"`
+	Scopes       []string `json:"scopes"`
+	// ClaimMappings renames standard claims in the client's /userinfo
+	// responses, e.g. email to mail, for apps expecting other names
+	ClaimMappings map[string]string `json:"claim_mappings,omitempty"`
+	CreatedBy     string            `json:"created_by"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+// NewOIDCClient creates a validated client. Clients without scopes may request
+// openid, profile and email.
+func NewOIDCClient(clientID, name string, redirectURIs, scopes []string, createdBy string) (*OIDCClient, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 100 {
+		return nil, domainErrors.ErrInvalidClientName
+	}
+
+	if len(redirectURIs) == 0 {
+		return nil, domainErrors.ErrInvalidRedirectURI
+	}
+	for _, uri := range redirectURIs {
+		if !validRedirectURI(uri) {
+			return nil, domainErrors.ErrInvalidRedirectURI
+		}
+	}
+
+	if len(scopes) == 0 {
+		scopes = []string{ScopeOpenID, ScopeProfile, ScopeEmail}
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(OIDCScopes, scope) {
+			return nil, domainErrors.ErrInvalidScope
+		}
+	}
+	if !slices.Contains(scopes, ScopeOpenID) {
+		scopes = append([]string{ScopeOpenID}, scopes...)
+	}
+
+	return &OIDCClient{
+		ClientID:     clientID,
+		Name:         name,
+		RedirectURIs: slices.Compact(slices.Clone(redirectURIs)),
+		Scopes:       slices.Compact(scopes),
+		CreatedBy:    createdBy,
+		CreatedAt:    time.Now(),
+	}, nil
+}
+
+// IsConfidential reports whether the client must authenticate with a secret
+func (c *OIDCClient) IsConfidential() bool {
+	return c.SecretHash != ""
+}
+
+// AllowsRedirectURI reports whether uri is one of the client's registered
+// redirect URIs; matching is exact, as the OAuth security practices require
+func (c *OIDCClient) AllowsRedirectURI(uri string) bool {
+	return slices.Contains(c.RedirectURIs, uri)
+}
+
+// GrantScopes parses a space-separated scope request. It must include openid
+// and only name scopes the client was registered with.
+func (c *OIDCClient) GrantScopes(requested string) ([]string, error) {
+	scopes := strings.Fields(requested)
+	if !slices.Contains(scopes, ScopeOpenID) {
+		return nil, domainErrors.ErrInvalidScope
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(c.Scopes, scope) {
+			return nil, domainErrors.ErrInvalidScope
+		}
+	}
+	slices.Sort(scopes)
+	return slices.Compact(scopes), nil
+}
+
+// GrantConsented narrows granted scopes to those the user consented to.
+// openid is implied by signing in and needs no consent.
+func GrantConsented(granted, consented []string) []string {
+	scopes := make([]string, 0, len(granted))
+	for _, scope := range granted {
+		if scope == ScopeOpenID || slices.Contains(consented, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// SetClaimMappings replaces the client's claim mappings. Only standard claims
+// other than sub can be renamed, and no two claims may end up with the same
+// name.
+func (c *OIDCClient) SetClaimMappings(mappings map[string]string) error {
+	released := map[string]string{"sub": "sub"}
+	for _, claims := range ScopeClaims {
+		for _, claim := range claims {
+			released[claim] = claim
+		}
+	}
+
+	for claim, name := range mappings {
+		if _, ok := released[claim]; !ok || claim == "sub" {
+			return domainErrors.ErrInvalidClaimMapping
+		}
+		name = strings.TrimSpace(name)
+		if name == "" || len(name) > maxClaimNameLength {
+			return domainErrors.ErrInvalidClaimMapping
+		}
+		released[claim] = name
+	}
+
+	seen := make(map[string]bool, len(released))
+	for _, name := range released {
+		if seen[name] {
+			return domainErrors.ErrInvalidClaimMapping
+		}
+		seen[name] = true
+	}
+
+	cleaned := make(map[string]string, len(mappings))
+	for claim, name := range mappings {
+		if name = strings.TrimSpace(name); name != claim {
+			cleaned[claim] = name
+		}
+	}
+	c.ClaimMappings = cleaned
+	return nil
+}
+
+// MapClaims renames standard claims as configured for the client
+func (c *OIDCClient) MapClaims(claims map[string]interface{}) map[string]interface{} {
+	mapped := make(map[string]interface{}, len(claims))
+	for claim, value := range claims {
+		if name, ok := c.ClaimMappings[claim]; ok {
+			claim = name
+		}
+		mapped[claim] = value
+	}
+	return mapped
+}
+
+// validRedirectURI accepts absolute https URIs without a fragment, and plain
+// http for loopback addresses used by native apps and local development
+func validRedirectURI(raw string) bool {
+	uri, err := url.Parse(raw)
+	if err != nil || uri.Host == "" || uri.Fragment != "" {
+		return false
+	}
+	switch uri.Scheme {
+	case "https":
+		return true
+	case "http":
+		host := uri.Hostname()
+		return host == "localhost" || host == "127.0.0.1" || host == "::1"
+	default:
+		return false
+	}
+}
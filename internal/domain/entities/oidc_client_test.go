@@ -0,0 +1,75 @@
+package entities
+
+import (
+	"testing"
+
+	domainErrors "user-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOIDCClient(t *testing.T) {
+	client, err := NewOIDCClient("abc", " Web app ", []string{"https://app.example.com/cb", "http://localhost:3000/cb"}, []string{"email"}, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, "Web app", client.Name)
+	assert.Equal(t, []string{ScopeOpenID, ScopeEmail}, client.Scopes, "openid is always allowed")
+	assert.False(t, client.IsConfidential())
+
+	for _, uri := range []string{"http://app.example.com/cb", "https://app.example.com/cb#frag", "myapp:/cb", "/cb"} {
+		_, err := NewOIDCClient("abc", "Web app", []string{uri}, nil, "admin")
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidRedirectURI, uri)
+	}
+
+	_, err = NewOIDCClient("abc", "Web app", []string{"https://app.example.com/cb"}, []string{"offline_access"}, "admin")
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidScope)
+}
+
+func TestOIDCClient_GrantScopes(t *testing.T) {
+	client := &OIDCClient{Scopes: []string{ScopeOpenID, ScopeEmail}}
+
+	scopes, err := client.GrantScopes("email openid email")
+	require.NoError(t, err)
+	assert.Equal(t, []string{ScopeEmail, ScopeOpenID}, scopes)
+
+	_, err = client.GrantScopes("email")
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidScope, "openid is required")
+
+	_, err = client.GrantScopes("openid phone")
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidScope)
+}
+
+func TestAuthorizationCode_VerifyPKCE(t *testing.T) {
+	// Example from RFC 7636, appendix B
+	code := &AuthorizationCode{CodeChallenge: "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"}
+
+	assert.True(t, code.VerifyPKCE("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+	assert.False(t, code.VerifyPKCE("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXz"))
+	assert.False(t, code.VerifyPKCE("short"))
+}
+
+func TestOIDCClient_SetClaimMappings(t *testing.T) {
+	client := &OIDCClient{}
+
+	require.NoError(t, client.SetClaimMappings(map[string]string{"email": " mail ", "given_name": "given_name"}))
+	assert.Equal(t, map[string]string{"email": "mail"}, client.ClaimMappings, "identity mappings are dropped")
+	assert.Equal(t,
+		map[string]interface{}{"sub": "7", "mail": "jane@example.com"},
+		client.MapClaims(map[string]interface{}{"sub": "7", "email": "jane@example.com"}))
+
+	for name, mappings := range map[string]map[string]string{
+		"sub":           {"sub": "id"},
+		"unknown claim": {"nickname": "nick"},
+		"empty name":    {"email": " "},
+		"collision":     {"email": "name"},
+		"shared name":   {"email": "contact", "phone_number": "contact"},
+	} {
+		assert.ErrorIs(t, client.SetClaimMappings(mappings), domainErrors.ErrInvalidClaimMapping, name)
+	}
+	assert.Equal(t, map[string]string{"email": "mail"}, client.ClaimMappings, "rejected mappings leave the client unchanged")
+}
+
+func TestGrantConsented(t *testing.T) {
+	assert.Equal(t, []string{ScopeEmail, ScopeOpenID}, GrantConsented([]string{ScopeEmail, ScopeOpenID, ScopeProfile}, []string{ScopeEmail, ScopePhone}))
+	assert.Equal(t, []string{ScopeOpenID}, GrantConsented([]string{ScopeOpenID, ScopeProfile}, nil))
+}
@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	domainErrors "user-service/internal/domain/errors"
+)
+
+// Visibility is who may see an optional profile field
+type Visibility string
+
+const (
+	// VisibilityPublic fields are shown to every caller, anonymous ones too
+	VisibilityPublic Visibility = "public"
+	// VisibilityOrg fields are shown to callers with an API key
+	VisibilityOrg Visibility = "org"
+	// VisibilityPrivate fields are shown to staff and internal callers only
+	VisibilityPrivate Visibility = "private"
+)
+
+// Profile field limits
+const (
+	MaxDisplayNameLength = 50
+	MaxPronounsLength    = 40
+)
+
+// visibilityRanks orders visibilities from widest to narrowest
+var visibilityRanks = map[Visibility]int{
+	VisibilityPublic:  0,
+	VisibilityOrg:     1,
+	VisibilityPrivate: 2,
+}
+
+// ParseVisibility validates a visibility, e.g. from a request
+func ParseVisibility(value string) (Visibility, error) {
+	visibility := Visibility(strings.ToLower(strings.TrimSpace(value)))
+	if _, ok := visibilityRanks[visibility]; !ok {
+		return "", domainErrors.ErrInvalidVisibility
+	}
+	return visibility, nil
+}
+
+// VisibleTo reports whether a field with this visibility may be shown to an
+// audience, itself given as the narrowest visibility it may see. Unknown
+// visibilities are treated as private.
+func (v Visibility) VisibleTo(audience Visibility) bool {
+	rank, ok := visibilityRanks[v]
+	if !ok {
+		rank = visibilityRanks[VisibilityPrivate]
+	}
+	return rank <= visibilityRanks[audience]
+}
+
+// ProfileVisibility holds who may see each optional profile field. Fields
+// are private until the user widens them.
+type ProfileVisibility struct {
+	DisplayName Visibility `json:"display_name"`
+	Pronouns    Visibility `json:"pronouns"`
+}
+
+// DefaultProfileVisibility is the visibility of new users' profile fields
+var DefaultProfileVisibility = ProfileVisibility{DisplayName: VisibilityPrivate, Pronouns: VisibilityPrivate}
+
+// SetVisibility changes the visibility of the named fields, keeping the
+// current one where empty
+func (p *ProfileVisibility) SetVisibility(displayName, pronouns string) error {
+	var err error
+	if displayName != "" {
+		if p.DisplayName, err = ParseVisibility(displayName); err != nil {
+			return err
+		}
+	}
+	if pronouns != "" {
+		if p.Pronouns, err = ParseVisibility(pronouns); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetProfile sets the display name and pronouns; empty values clear them
+func (u *User) SetProfile(displayName, pronouns string) error {
+	displayName = strings.TrimSpace(displayName)
+	pronouns = strings.TrimSpace(pronouns)
+	if utf8.RuneCountInString(displayName) > MaxDisplayNameLength {
+		return domainErrors.ErrInvalidDisplayName
+	}
+	if utf8.RuneCountInString(pronouns) > MaxPronounsLength {
+		return domainErrors.ErrInvalidPronouns
+	}
+
+	u.DisplayName = displayName
+	u.Pronouns = pronouns
+	return nil
+}
@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	domainErrors "user-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVisibility_VisibleTo(t *testing.T) {
+	tests := []struct {
+		visibility Visibility
+		audience   Visibility
+		visible    bool
+	}{
+		{visibility: VisibilityPublic, audience: VisibilityPublic, visible: true},
+		{visibility: VisibilityOrg, audience: VisibilityPublic, visible: false},
+		{visibility: VisibilityOrg, audience: VisibilityOrg, visible: true},
+		{visibility: VisibilityPrivate, audience: VisibilityOrg, visible: false},
+		{visibility: VisibilityPrivate, audience: VisibilityPrivate, visible: true},
+		{visibility: "", audience: VisibilityOrg, visible: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.visibility)+" to "+string(tt.audience), func(t *testing.T) {
+			assert.Equal(t, tt.visible, tt.visibility.VisibleTo(tt.audience))
+		})
+	}
+}
+
+func TestProfileVisibility_SetVisibility(t *testing.T) {
+	// Given the defaults
+	visibility := DefaultProfileVisibility
+
+	// When only the display name is widened
+	err := visibility.SetVisibility("Public", "")
+
+	// Then the pronouns stay private
+	require.NoError(t, err)
+	assert.Equal(t, ProfileVisibility{DisplayName: VisibilityPublic, Pronouns: VisibilityPrivate}, visibility)
+	assert.Equal(t, domainErrors.ErrInvalidVisibility, visibility.SetVisibility("", "friends"))
+}
+
+func TestUser_SetProfile(t *testing.T) {
+	user := &User{}
+
+	require.NoError(t, user.SetProfile("  JD ", "they/them"))
+	assert.Equal(t, "JD", user.DisplayName)
+	assert.Equal(t, "they/them", user.Pronouns)
+
+	assert.Equal(t, domainErrors.ErrInvalidDisplayName, user.SetProfile(strings.Repeat("a", MaxDisplayNameLength+1), ""))
+	assert.Equal(t, domainErrors.ErrInvalidPronouns, user.SetProfile("", strings.Repeat("a", MaxPronounsLength+1)))
+	assert.Equal(t, "JD", user.DisplayName, "a rejected profile changes nothing")
+}
@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// QueuedWriteOperation names a write that can be queued while the database
+// is unreachable
+type QueuedWriteOperation string
+
+const (
+	QueuedCreateUser        QueuedWriteOperation = "create_user"
+	QueuedUpdatePreferences QueuedWriteOperation = "update_preferences"
+	QueuedUpdateProfile     QueuedWriteOperation = "update_profile"
+)
+
+// QueuedWriteOperations lists every operation that can be queued
+var QueuedWriteOperations = []QueuedWriteOperation{QueuedCreateUser, QueuedUpdatePreferences, QueuedUpdateProfile}
+
+// QueuedWrite is a write accepted while the database was unreachable, to be
+// applied once it is back
+type QueuedWrite struct {
+	// ID orders writes; it is assigned when the write is queued
+	ID        string               `json:"id"`
+	Operation QueuedWriteOperation `json:"operation"`
+	// UserID is the user an update applies to, zero for sign-ups
+	UserID uint `json:"user_id,omitempty"`
+	// Residency is the region the write was accepted in
+	Residency Residency       `json:"residency,omitempty"`
+	Payload   json.RawMessage `json:"payload"`
+	QueuedAt  time.Time       `json:"queued_at"`
+	// Rejection explains why a write was not applied, set once rejected
+	Rejection string `json:"rejection,omitempty"`
+}
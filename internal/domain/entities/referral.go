@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"crypto/rand"
+	"slices"
+	"strings"
+
+	domainErrors "user-service/internal/domain/errors"
+)
+
+// ReferralCodeLength is the number of characters of a referral code
+const ReferralCodeLength = 8
+
+// MaxReferralDepth bounds how many referrers up a chain loop checks follow
+const MaxReferralDepth = 100
+
+// referralAlphabet leaves out characters easily mistaken for others, such as
+// 0 and O or 1 and I. Its 32 characters map bytes onto it without bias.
+const referralAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// NewReferralCode returns a random code a user shares to refer others
+func NewReferralCode() string {
+	code := make([]byte, ReferralCodeLength)
+	// crypto/rand never returns an error
+	_, _ = rand.Read(code)
+	for i, b := range code {
+		code[i] = referralAlphabet[int(b)%len(referralAlphabet)]
+	}
+	return string(code)
+}
+
+// NormalizeReferralCode uppercases and trims a referral code as typed
+func NormalizeReferralCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// ReferBy records referrer as the user who referred u. ancestors are the IDs
+// of referrer's own referrers, nearest first; a referral making u refer one
+// of them would close a loop and is refused. So are referrals by the same
+// person, told apart by their email address or phone number, and referrals
+// by users who are not active.
+func (u *User) ReferBy(referrer *User, ancestors []uint) error {
+	if u.ReferredBy != nil {
+		return domainErrors.ErrAlreadyReferred
+	}
+	if u.isSamePersonAs(referrer) {
+		return domainErrors.ErrSelfReferral
+	}
+	if !referrer.IsActive() {
+		return domainErrors.ErrInvalidReferralCode
+	}
+	if u.ID != 0 && slices.Contains(ancestors, u.ID) {
+		return domainErrors.ErrReferralLoop
+	}
+
+	referrerID := referrer.ID
+	u.ReferredBy = &referrerID
+	return nil
+}
+
+// isSamePersonAs reports whether other is u or another account of the same
+// person
+func (u *User) isSamePersonAs(other *User) bool {
+	if u.ID != 0 && u.ID == other.ID {
+		return true
+	}
+	// Plus-addressed and dotted variants deliver to the same mailbox
+	if CanonicalEmail(u.Email) == CanonicalEmail(other.Email) {
+		return true
+	}
+	phone := NormalizePhone(u.Phone)
+	return phone != "" && phone == NormalizePhone(other.Phone)
+}
@@ -0,0 +1,99 @@
+package entities
+
+import (
+	"testing"
+
+	domainErrors "user-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReferralCode(t *testing.T) {
+	code := NewReferralCode()
+
+	assert.Len(t, code, ReferralCodeLength)
+	for _, r := range code {
+		assert.Contains(t, referralAlphabet, string(r))
+	}
+	assert.NotEqual(t, code, NewReferralCode())
+}
+
+func TestNormalizeReferralCode(t *testing.T) {
+	assert.Equal(t, "ABCD2345", NormalizeReferralCode(" abcd2345 "))
+}
+
+func TestUser_ReferBy(t *testing.T) {
+	referrer := &User{ID: 7, Email: "ana@example.com", Phone: "+1 555 010 0001", Status: UserStatusActive}
+
+	tests := []struct {
+		name      string
+		user      *User
+		referrer  *User
+		ancestors []uint
+		expected  error
+	}{
+		{
+			name:     "new user",
+			user:     &User{Email: "ben@example.com", Phone: "+1 555 010 0002"},
+			referrer: referrer,
+		},
+		{
+			name:     "same account",
+			user:     &User{ID: 7, Email: "other@example.com"},
+			referrer: referrer,
+			expected: domainErrors.ErrSelfReferral,
+		},
+		{
+			name:     "plus-addressed variant of the email",
+			user:     &User{Email: "Ana+invite@example.com"},
+			referrer: referrer,
+			expected: domainErrors.ErrSelfReferral,
+		},
+		{
+			name:     "dotted variant of a Gmail address",
+			user:     &User{Email: "a.n.a@googlemail.com"},
+			referrer: &User{ID: 9, Email: "ana@gmail.com", Status: UserStatusActive},
+			expected: domainErrors.ErrSelfReferral,
+		},
+		{
+			name:     "same phone number",
+			user:     &User{Email: "ana+2@example.com", Phone: "001 555 010 0001"},
+			referrer: referrer,
+			expected: domainErrors.ErrSelfReferral,
+		},
+		{
+			name:     "inactive referrer",
+			user:     &User{Email: "ben@example.com"},
+			referrer: &User{ID: 8, Email: "cal@example.com", Status: UserStatusSuspended},
+			expected: domainErrors.ErrInvalidReferralCode,
+		},
+		{
+			name:      "referrer referred by the user",
+			user:      &User{ID: 3, Email: "ben@example.com"},
+			referrer:  referrer,
+			ancestors: []uint{5, 3},
+			expected:  domainErrors.ErrReferralLoop,
+		},
+		{
+			name:     "already referred",
+			user:     &User{ID: 3, Email: "ben@example.com", ReferredBy: new(uint)},
+			referrer: referrer,
+			expected: domainErrors.ErrAlreadyReferred,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.user.ReferBy(tt.referrer, tt.ancestors)
+
+			if tt.expected != nil {
+				assert.ErrorIs(t, err, tt.expected)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, tt.user.ReferredBy)
+			assert.Equal(t, tt.referrer.ID, *tt.user.ReferredBy)
+		})
+	}
+}
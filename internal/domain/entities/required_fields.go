@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"strings"
+
+	domainErrors "user-service/internal/domain/errors"
+)
+
+// RequiredProfileFields are the optional profile fields a deployment makes
+// mandatory on user creation, by JSON name: last_name and/or phone
+type RequiredProfileFields []string
+
+// Check fails with a validation error naming the first required field the new
+// user lacks. It holds for every way users are created, whatever rules the
+// request they came from was validated with.
+func (r RequiredProfileFields) Check(user *User) error {
+	for _, field := range r {
+		var value string
+		switch field {
+		case "last_name":
+			value = user.LastName
+		case "phone":
+			value = user.Phone
+		default:
+			continue
+		}
+		if strings.TrimSpace(value) == "" {
+			return domainErrors.NewUserValidationError(field, strings.ReplaceAll(field, "_", " ")+" is required")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"testing"
+
+	domainErrors "user-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredProfileFields_Check(t *testing.T) {
+	required := RequiredProfileFields{"last_name", "phone"}
+
+	assert.NoError(t, required.Check(&User{FirstName: "Jane", LastName: "Doe", Phone: "+15551234567"}))
+	assert.NoError(t, RequiredProfileFields(nil).Check(&User{FirstName: "Jane"}))
+
+	var domainErr *domainErrors.DomainError
+	require.ErrorAs(t, required.Check(&User{FirstName: "Jane", LastName: "Doe", Phone: "  "}), &domainErr)
+	assert.Equal(t, "phone", domainErr.Field)
+	require.ErrorAs(t, required.Check(&User{FirstName: "Jane"}), &domainErr)
+	assert.Equal(t, "last_name", domainErr.Field)
+	assert.Equal(t, "last name is required", domainErr.Message)
+}
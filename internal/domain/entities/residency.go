@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"strings"
+
+	domainErrors "user-service/internal/domain/errors"
+)
+
+// Residency is the region a user's personal data must be stored and read in
+type Residency string
+
+const (
+	ResidencyEU Residency = "eu"
+	ResidencyUS Residency = "us"
+)
+
+// Residencies lists every supported residency region
+var Residencies = []Residency{ResidencyEU, ResidencyUS}
+
+// ParseResidency normalizes and validates a residency region name
+func ParseResidency(value string) (Residency, error) {
+	residency := Residency(strings.ToLower(strings.TrimSpace(value)))
+	switch residency {
+	case ResidencyEU, ResidencyUS:
+		return residency, nil
+	}
+	return "", domainErrors.ErrInvalidResidency
+}
@@ -0,0 +1,41 @@
+package entities
+
+import "time"
+
+// SensitiveAction is an account action attackers probe when trying to take
+// over an account, so its frequency is limited per user
+type SensitiveAction string
+
+const (
+	SensitiveActionPasswordReset      SensitiveAction = "password_reset"
+	SensitiveActionEmailChange        SensitiveAction = "email_change"
+	SensitiveActionVerificationResend SensitiveAction = "verification_resend"
+	SensitiveActionSignIn             SensitiveAction = "sign_in"
+)
+
+// SensitiveActions lists every action limited per user
+var SensitiveActions = []SensitiveAction{
+	SensitiveActionPasswordReset,
+	SensitiveActionEmailChange,
+	SensitiveActionVerificationResend,
+	SensitiveActionSignIn,
+}
+
+// ActionLimit allows Max occurrences of an action per Window
+type ActionLimit struct {
+	Max    int
+	Window time.Duration
+}
+
+// ActionCount is how often a user performed an action in the current window
+type ActionCount struct {
+	UserID      uint
+	Action      SensitiveAction
+	Count       int
+	WindowStart time.Time
+}
+
+// RetryAfter returns how long until the window resets under limit
+func (c *ActionCount) RetryAfter(limit ActionLimit, now time.Time) time.Duration {
+	return max(c.WindowStart.Add(limit.Window).Sub(now), 0)
+}
@@ -0,0 +1,35 @@
+package entities
+
+import "time"
+
+// UserAggregate is the aggregate type of every user event
+const UserAggregate = "user"
+
+// StoredEvent is a domain event as persisted in the event store
+type StoredEvent struct {
+	// Sequence orders events across all aggregates; it only grows
+	Sequence uint64 `json:"sequence"`
+	// AggregateType and AggregateID identify the stream the event belongs to.
+	// Events not tied to a single user, such as bulk imports, use ID 0.
+	AggregateType string `json:"aggregate_type"`
+	AggregateID   uint   `json:"aggregate_id"`
+	// Residency is the region the event was recorded in. User IDs repeat
+	// across regions, so it is part of the stream's identity.
+	Residency Residency `json:"residency,omitempty"`
+	// Version is the position of the event within its aggregate, starting at 1
+	Version    uint64                 `json:"version"`
+	Type       UserEventType          `json:"type"`
+	Data       map[string]interface{} `json:"data,omitempty"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	RecordedAt time.Time              `json:"recorded_at"`
+}
+
+// UserEvent returns the domain event carried by the stored event
+func (e *StoredEvent) UserEvent() *UserEvent {
+	return &UserEvent{
+		Type:       e.Type,
+		UserID:     e.AggregateID,
+		Data:       e.Data,
+		OccurredAt: e.OccurredAt,
+	}
+}
@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	domainErrors "user-service/internal/domain/errors"
+)
+
+// SuppressionReason is why no communications may be sent to an address
+type SuppressionReason string
+
+const (
+	SuppressionReasonBounced      SuppressionReason = "bounced"
+	SuppressionReasonUnsubscribed SuppressionReason = "unsubscribed"
+	SuppressionReasonLegalHold    SuppressionReason = "legal_hold"
+)
+
+// MaxSuppressionNoteLength caps the note kept with a suppression
+const MaxSuppressionNoteLength = 500
+
+// notificationEvents are the events the notification service turns into
+// messages to the user
+var notificationEvents = []UserEventType{
+	UserEventActivityDigest,
+	UserEventVerificationRequested,
+}
+
+// Notifies reports whether events of this type make the notification service
+// contact the user, so suppressions apply to them
+func (t UserEventType) Notifies() bool {
+	return slices.Contains(notificationEvents, t)
+}
+
+// Suppression stops communications to an email address, e.g. after it
+// bounced or its owner unsubscribed. Addresses are kept in canonical form, so
+// variants delivering to the same mailbox are suppressed together.
+type Suppression struct {
+	Email  string            `json:"email"`
+	Reason SuppressionReason `json:"reason"`
+	// Source names who suppressed the address, e.g. the notification service
+	Source string `json:"source"`
+	Note   string `json:"note,omitempty"`
+	// ExpiresAt is when the suppression lapses; nil means never
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ParseSuppressionReason normalizes and validates a suppression reason
+func ParseSuppressionReason(value string) (SuppressionReason, error) {
+	reason := SuppressionReason(strings.ToLower(strings.TrimSpace(value)))
+	switch reason {
+	case SuppressionReasonBounced, SuppressionReasonUnsubscribed, SuppressionReasonLegalHold:
+		return reason, nil
+	}
+	return "", domainErrors.ErrInvalidSuppressionReason
+}
+
+// NewSuppression validates a suppression of email starting at now
+func NewSuppression(email, reason, source, note string, expiresAt *time.Time, now time.Time) (*Suppression, error) {
+	if err := validateEmail(strings.TrimSpace(email)); err != nil {
+		return nil, domainErrors.ErrInvalidUserEmail
+	}
+
+	parsed, err := ParseSuppressionReason(reason)
+	if err != nil {
+		return nil, err
+	}
+
+	note = strings.TrimSpace(note)
+	if len(note) > MaxSuppressionNoteLength {
+		return nil, domainErrors.ErrInvalidSuppressionNote
+	}
+
+	if expiresAt != nil {
+		if !expiresAt.After(now) {
+			return nil, domainErrors.ErrInvalidSuppressionExpiry
+		}
+		utc := expiresAt.UTC()
+		expiresAt = &utc
+	}
+
+	return &Suppression{
+		Email:     CanonicalEmail(email),
+		Reason:    parsed,
+		Source:    strings.TrimSpace(source),
+		Note:      note,
+		ExpiresAt: expiresAt,
+		CreatedAt: now.UTC(),
+	}, nil
+}
+
+// ActiveAt reports whether the suppression still applies at t
+func (s *Suppression) ActiveAt(t time.Time) bool {
+	return s.ExpiresAt == nil || t.Before(*s.ExpiresAt)
+}
@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	domainErrors "user-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSuppression(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(30 * 24 * time.Hour)
+
+	suppression, err := NewSuppression(" Jane.Doe+news@GMAIL.com ", "Bounced", "notifications", "hard bounce", &expiresAt, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, "janedoe@gmail.com", suppression.Email, "addresses are kept in canonical form")
+	assert.Equal(t, SuppressionReasonBounced, suppression.Reason)
+	assert.True(t, suppression.ActiveAt(now))
+	assert.False(t, suppression.ActiveAt(expiresAt), "a suppression lapses at its expiry")
+}
+
+func TestNewSuppression_Invalid(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		email     string
+		reason    string
+		expiresAt *time.Time
+		want      error
+	}{
+		{name: "invalid email", email: "not-an-email", reason: "bounced", want: domainErrors.ErrInvalidUserEmail},
+		{name: "unknown reason", email: "jane@example.com", reason: "spam", want: domainErrors.ErrInvalidSuppressionReason},
+		{name: "expiry in the past", email: "jane@example.com", reason: "unsubscribed", expiresAt: &past, want: domainErrors.ErrInvalidSuppressionExpiry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSuppression(tt.email, tt.reason, "notifications", "", tt.expiresAt, now)
+
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestUserEventType_Notifies(t *testing.T) {
+	assert.True(t, UserEventActivityDigest.Notifies())
+	assert.True(t, UserEventVerificationRequested.Notifies())
+	assert.False(t, UserEventUpdated.Notifies())
+}
@@ -0,0 +1,69 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	domainErrors "user-service/internal/domain/errors"
+)
+
+// SuspensionReason classifies why a user was suspended, so downstream
+// services can tailor what they tell the user
+type SuspensionReason string
+
+const (
+	SuspensionReasonFraud   SuspensionReason = "fraud"
+	SuspensionReasonAbuse   SuspensionReason = "abuse"
+	SuspensionReasonPayment SuspensionReason = "payment"
+)
+
+// Suspension records why a user is suspended and until when
+type Suspension struct {
+	Reason SuspensionReason `json:"reason_code"`
+	// Note is for staff only and must not be shown to the user
+	Note string `json:"note,omitempty"`
+	// ReactivateAt is when the suspension is meant to end; nil means indefinitely
+	ReactivateAt *time.Time `json:"reactivate_at,omitempty"`
+}
+
+// ParseSuspensionReason normalizes and validates a suspension reason code
+func ParseSuspensionReason(value string) (SuspensionReason, error) {
+	reason := SuspensionReason(strings.ToLower(strings.TrimSpace(value)))
+	switch reason {
+	case SuspensionReasonFraud, SuspensionReasonAbuse, SuspensionReasonPayment:
+		return reason, nil
+	case "":
+		return "", domainErrors.ErrSuspensionReasonRequired
+	}
+	return "", domainErrors.ErrInvalidSuspensionReason
+}
+
+// NewSuspension validates the details of a suspension starting at now
+func NewSuspension(reasonCode, note string, reactivateAt *time.Time, now time.Time) (*Suspension, error) {
+	reason, err := ParseSuspensionReason(reasonCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if reactivateAt != nil {
+		if !reactivateAt.After(now) {
+			return nil, domainErrors.ErrInvalidReactivationDate
+		}
+		utc := reactivateAt.UTC()
+		reactivateAt = &utc
+	}
+
+	return &Suspension{Reason: reason, Note: strings.TrimSpace(note), ReactivateAt: reactivateAt}, nil
+}
+
+// EventData describes the suspension for user events
+func (s *Suspension) EventData() map[string]interface{} {
+	data := map[string]interface{}{"reason_code": string(s.Reason)}
+	if s.Note != "" {
+		data["note"] = s.Note
+	}
+	if s.ReactivateAt != nil {
+		data["reactivate_at"] = s.ReactivateAt.UTC().Format(time.RFC3339)
+	}
+	return data
+}
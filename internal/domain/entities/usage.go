@@ -0,0 +1,104 @@
+package entities
+
+import (
+	"cmp"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// UsageHourLayout names an hour of usage in export file names
+const UsageHourLayout = "20060102T15Z"
+
+// UsageHour returns the hour usage at t is billed in. Calls belong to the hour
+// they arrive in, however late they are flushed.
+func UsageHour(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Hour)
+}
+
+// UsageUser is a user addressed by a tenant's calls. User IDs are only unique
+// within a residency region, so the region is part of the user; it is empty
+// while residency is disabled.
+type UsageUser struct {
+	Residency Residency
+	ID        uint
+}
+
+// Compare orders usage users by region and ID
+func (u UsageUser) Compare(other UsageUser) int {
+	return cmp.Or(cmp.Compare(u.Residency, other.Residency), cmp.Compare(u.ID, other.ID))
+}
+
+// UsageDelta is the usage of one tenant in one hour that an instance recorded
+// since its previous flush
+type UsageDelta struct {
+	Tenant   string
+	Hour     time.Time
+	APICalls int64
+	// ActiveUsers are the distinct users the tenant's calls addressed
+	ActiveUsers []UsageUser
+}
+
+// UsageBatch is one flush of an instance's usage. Its ID is unique per
+// instance and flush and stays the same when the flush is retried, so a
+// batch is never counted twice.
+type UsageBatch struct {
+	ID     string
+	Deltas []*UsageDelta
+}
+
+// UsageRollup is the usage of one tenant in one hour across every instance
+type UsageRollup struct {
+	Tenant   string
+	Hour     time.Time
+	APICalls int64
+	// ActiveUsers counts distinct users, so rollups of several hours cannot
+	// be added up into the active users of a longer period
+	ActiveUsers int64
+	// Revision grows with every flush into the rollup, so exports can tell
+	// when an exported hour changed
+	Revision int64
+}
+
+// UsageExport is one hour of rollups for the billing system. An hour that
+// changes after its export, e.g. by a flush delayed past the export delay,
+// is exported again with the next version; billing uses the highest version.
+type UsageExport struct {
+	Hour    time.Time
+	Version int
+	Rollups []*UsageRollup
+}
+
+// FileName names the export, e.g. usage-20240501T12Z-v1.csv
+func (e *UsageExport) FileName() string {
+	return fmt.Sprintf("usage-%s-v%d.csv", e.Hour.UTC().Format(UsageHourLayout), e.Version)
+}
+
+// WriteCSV writes the export as CSV with a header row, one row per tenant
+func (e *UsageExport) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"tenant", "hour_start", "hour_end", "api_calls", "active_users", "version"}); err != nil {
+		return err
+	}
+
+	start := e.Hour.UTC().Format(time.RFC3339)
+	end := e.Hour.UTC().Add(time.Hour).Format(time.RFC3339)
+	for _, rollup := range e.Rollups {
+		err := out.Write([]string{
+			rollup.Tenant,
+			start,
+			end,
+			strconv.FormatInt(rollup.APICalls, 10),
+			strconv.FormatInt(rollup.ActiveUsers, 10),
+			strconv.Itoa(e.Version),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
@@ -3,28 +3,65 @@ package entities
 import (
 	"errors"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
+
+	domainErrors "user-service/internal/domain/errors"
 )
 
 type UserStatus string
 
 const (
+	UserStatusPending   UserStatus = "pending"
 	UserStatusActive    UserStatus = "active"
 	UserStatusInactive  UserStatus = "inactive"
 	UserStatusSuspended UserStatus = "suspended"
+	UserStatusDeleted   UserStatus = "deleted"
 )
 
 type User struct {
-	ID        uint       `json:"id"`
-	Email     string     `json:"email"`
-	Password  string     `json:"-"` // Never expose in JSON
-	FirstName string     `json:"first_name"`
-	LastName  string     `json:"last_name"`
-	Phone     string     `json:"phone"`
-	Status    UserStatus `json:"status"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
+	ID        uint   `json:"id"`
+	Email     string `json:"email"`
+	Password  string `json:"-"` // Never expose in JSON
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Phone     string `json:"phone"`
+	// DisplayName and Pronouns are optional and shown according to
+	// Preferences.Visibility
+	DisplayName string `json:"display_name,omitempty"`
+	Pronouns    string `json:"pronouns,omitempty"`
+	// DateOfBirth is optional and only ever shown to admins
+	DateOfBirth *time.Time `json:"-"`
+	Status      UserStatus `json:"status"`
+	Residency   Residency  `json:"residency,omitempty"`
+	// Country is the ISO 3166-1 alpha-2 code of the country the user lives in
+	Country string   `json:"country,omitempty"`
+	Tags    []string `json:"tags"`
+	// ExternalIDs maps source systems to the ID they know the user by
+	ExternalIDs map[string]string `json:"external_ids,omitempty"`
+	// Suspension is set while the user is suspended
+	Suspension *Suspension `json:"suspension,omitempty"`
+	// LegalHold is set while the user's data must be preserved
+	LegalHold *LegalHold `json:"-"`
+	// VerificationSentAt is when a verification email was last requested
+	VerificationSentAt *time.Time `json:"-"`
+	// ReferralCode is the code the user shares to refer others
+	ReferralCode string `json:"referral_code,omitempty"`
+	// ReferredBy is the ID of the user who referred this one, if any
+	ReferredBy  *uint           `json:"referred_by,omitempty"`
+	Preferences UserPreferences `json:"preferences"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// VerificationResendIn returns how long the user must wait before another
+// verification email may be sent, zero once cooldown has passed
+func (u *User) VerificationResendIn(now time.Time, cooldown time.Duration) time.Duration {
+	if u.VerificationSentAt == nil {
+		return 0
+	}
+	return max(u.VerificationSentAt.Add(cooldown).Sub(now), 0)
 }
 
 // Domain methods for business logic
@@ -36,14 +73,101 @@ func (u *User) IsActive() bool {
 	return u.Status == UserStatusActive
 }
 
-func (u *User) Activate() {
-	u.Status = UserStatusActive
-	u.UpdatedAt = time.Now()
+func (u *User) Activate() error {
+	return u.TransitionTo(UserStatusActive)
+}
+
+// Suspend suspends an active user for the given reason
+func (u *User) Suspend(suspension *Suspension) error {
+	if suspension == nil {
+		return domainErrors.ErrSuspensionReasonRequired
+	}
+	if err := u.TransitionTo(UserStatusSuspended); err != nil {
+		return err
+	}
+	u.Suspension = suspension
+	return nil
+}
+
+// MaxUserTags caps how many tags a single user can carry
+const MaxUserTags = 50
+
+var tagRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_:-]{0,49}$`)
+
+// HasTag reports whether the user carries the given tag
+func (u *User) HasTag(tag string) bool {
+	return slices.Contains(u.Tags, NormalizeTag(tag))
+}
+
+// AddTags adds the given tags and returns the ones that were not already present
+func (u *User) AddTags(tags ...string) ([]string, error) {
+	normalized, err := NormalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	added := make([]string, 0, len(normalized))
+	for _, tag := range normalized {
+		if !slices.Contains(u.Tags, tag) {
+			added = append(added, tag)
+		}
+	}
+
+	if len(u.Tags)+len(added) > MaxUserTags {
+		return nil, domainErrors.ErrTooManyTags
+	}
+
+	if len(added) > 0 {
+		u.Tags = append(u.Tags, added...)
+		slices.Sort(u.Tags)
+		u.UpdatedAt = time.Now()
+	}
+
+	return added, nil
+}
+
+// RemoveTags removes the given tags and returns the ones that were actually present
+func (u *User) RemoveTags(tags ...string) ([]string, error) {
+	normalized, err := NormalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	removed := make([]string, 0, len(normalized))
+	for _, tag := range normalized {
+		if slices.Contains(u.Tags, tag) {
+			removed = append(removed, tag)
+		}
+	}
+
+	if len(removed) > 0 {
+		u.Tags = slices.DeleteFunc(u.Tags, func(tag string) bool {
+			return slices.Contains(removed, tag)
+		})
+		u.UpdatedAt = time.Now()
+	}
+
+	return removed, nil
+}
+
+// NormalizeTag lowercases and trims a tag
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
 }
 
-func (u *User) Suspend() {
-	u.Status = UserStatusSuspended
-	u.UpdatedAt = time.Now()
+// NormalizeTags normalizes, validates and de-duplicates a list of tags
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		if !tagRegex.MatchString(tag) {
+			return nil, domainErrors.ErrInvalidTag
+		}
+		if !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized, nil
 }
 
 // Factory function for creating new users
@@ -69,6 +193,11 @@ func NewUser(email, password, firstName, lastName, phone string) (*User, error)
 		LastName:  strings.TrimSpace(lastName),
 		Phone:     strings.TrimSpace(phone),
 		Status:    UserStatusActive,
+		// Every user gets a code, whether or not they ever refer anyone
+		ReferralCode: NewReferralCode(),
+		Preferences: UserPreferences{
+			Visibility: DefaultProfileVisibility,
+		},
 		CreatedAt: now,
 		UpdatedAt: now,
 	}, nil
@@ -0,0 +1,76 @@
+package entities
+
+import "time"
+
+type UserEventType string
+
+const (
+	UserEventTagsChanged   UserEventType = "user.tags_changed"
+	UserEventBulkCreated   UserEventType = "user.bulk_created"
+	UserEventCreated       UserEventType = "user.created"
+	UserEventUpdated       UserEventType = "user.updated"
+	UserEventDeleted       UserEventType = "user.deleted"
+	UserEventStatusChanged UserEventType = "user.status_changed"
+	UserEventSignedIn      UserEventType = "user.signed_in"
+	// UserEventPreferencesChanged is emitted when a user changes preferences
+	UserEventPreferencesChanged UserEventType = "user.preferences_changed"
+	// UserEventActivityDigest carries a periodic summary of a user's account
+	// activity, for the notification service to email as a security digest
+	UserEventActivityDigest UserEventType = "user.activity_digest"
+	// UserEventGuardianConsentRequired is emitted for users held pending by
+	// an age gate, for the guardian consent flow to start
+	UserEventGuardianConsentRequired UserEventType = "user.guardian_consent_required"
+	// UserEventReferred is emitted when a user is attributed to the user who
+	// referred them, for the rewards service to credit the referrer
+	UserEventReferred UserEventType = "user.referred"
+	// UserEventVerificationRequested is emitted when a user asks for another
+	// verification email, for the notification service to send it
+	UserEventVerificationRequested UserEventType = "user.verification_requested"
+)
+
+// UserEvent is a domain event emitted when something happens to a user
+type UserEvent struct {
+	Type   UserEventType          `json:"type"`
+	UserID uint                   `json:"user_id"`
+	Data   map[string]interface{} `json:"data,omitempty"`
+	// ExternalIDs lets consumers join the event with records of source systems
+	ExternalIDs map[string]string `json:"external_ids,omitempty"`
+	// Locale and Timezone let consumers localize what they send the user
+	Locale   string `json:"locale,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
+	// Country is the user's country, for regional analytics
+	Country string `json:"country,omitempty"`
+	// IsMinor is set for users who gave a date of birth
+	IsMinor    *bool     `json:"is_minor,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+	// Recipient is the email address notifications about the event go to,
+	// checked against the suppression list; it never leaves the service
+	Recipient string `json:"-"`
+}
+
+// NewUserEvent creates a user event stamped with the current time
+func NewUserEvent(eventType UserEventType, userID uint, data map[string]interface{}) *UserEvent {
+	return &UserEvent{
+		Type:       eventType,
+		UserID:     userID,
+		Data:       data,
+		OccurredAt: time.Now().UTC(),
+	}
+}
+
+// About stamps the event with what consumers need to know about its user:
+// their locale, time zone and country and whether they are a minor, and
+// with the address notifications about it go to
+func (e *UserEvent) About(user *User) *UserEvent {
+	e.IsMinor = user.IsMinorAt(e.OccurredAt)
+	e.Country = user.Country
+	e.Recipient = user.Email
+	return e.Localize(user.Preferences.Localization())
+}
+
+// Localize stamps the event with the locale and time zone of its user
+func (e *UserEvent) Localize(localization Localization) *UserEvent {
+	e.Locale = localization.Locale
+	e.Timezone = localization.Timezone
+	return e
+}
@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	domainErrors "user-service/internal/domain/errors"
+)
+
+type NoteVisibility string
+
+const (
+	// NoteVisibilityInternal notes are visible to all support staff
+	NoteVisibilityInternal NoteVisibility = "internal"
+	// NoteVisibilityPrivate notes are only visible to their author
+	NoteVisibilityPrivate NoteVisibility = "private"
+)
+
+// MaxNoteLength caps the size of a single support note
+const MaxNoteLength = 5000
+
+// UserNote is an internal annotation support staff keep on a user
+type UserNote struct {
+	ID         uint           `json:"id"`
+	UserID     uint           `json:"user_id"`
+	Author     string         `json:"author"`
+	Text       string         `json:"text"`
+	Visibility NoteVisibility `json:"visibility"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+// NewUserNote creates a validated note for a user
+func NewUserNote(userID uint, author, text string, visibility NoteVisibility) (*UserNote, error) {
+	if visibility == "" {
+		visibility = NoteVisibilityInternal
+	}
+
+	note := &UserNote{
+		UserID: userID,
+		Author: strings.TrimSpace(author),
+	}
+
+	if err := note.Edit(text, visibility); err != nil {
+		return nil, err
+	}
+
+	note.CreatedAt = note.UpdatedAt
+	return note, nil
+}
+
+// Edit replaces the note text and visibility
+func (n *UserNote) Edit(text string, visibility NoteVisibility) error {
+	text = strings.TrimSpace(text)
+	if text == "" || len(text) > MaxNoteLength {
+		return domainErrors.ErrInvalidNoteText
+	}
+
+	if visibility != NoteVisibilityInternal && visibility != NoteVisibilityPrivate {
+		return domainErrors.ErrInvalidNoteVisibility
+	}
+
+	n.Text = text
+	n.Visibility = visibility
+	n.UpdatedAt = time.Now()
+	return nil
+}
+
+// VisibleTo reports whether the given staff member may read the note
+func (n *UserNote) VisibleTo(viewer string) bool {
+	return n.Visibility != NoteVisibilityPrivate || n.Author == viewer
+}
@@ -0,0 +1,16 @@
+package entities
+
+// UserPreferences holds the choices a user made about how the service treats
+// them. The zero value is the default for every preference but the locale
+// and time zone, which new users get from the deployment's defaults, and the
+// profile visibility, which starts out private.
+type UserPreferences struct {
+	// SecurityDigestOptOut stops the weekly security digest
+	SecurityDigestOptOut bool `json:"security_digest_opt_out"`
+	// Locale is a BCP 47 language tag, e.g. en-US
+	Locale string `json:"locale"`
+	// Timezone is an IANA time zone name, e.g. Europe/Madrid
+	Timezone string `json:"timezone"`
+	// Visibility is who may see the optional profile fields
+	Visibility ProfileVisibility `json:"visibility"`
+}
@@ -0,0 +1,80 @@
+package entities
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	domainErrors "user-service/internal/domain/errors"
+)
+
+// statusTransitions lists the statuses each status may change to. Any status
+// except deleted may also become deleted.
+var statusTransitions = map[UserStatus][]UserStatus{
+	UserStatusPending:   {UserStatusActive},
+	UserStatusActive:    {UserStatusSuspended, UserStatusInactive},
+	UserStatusSuspended: {UserStatusActive},
+	UserStatusInactive:  {UserStatusActive},
+}
+
+// ParseUserStatus validates a status name a client may set. Deleted is not
+// accepted; users are deleted through the deletion flow.
+func ParseUserStatus(status string) (UserStatus, error) {
+	parsed := UserStatus(strings.ToLower(strings.TrimSpace(status)))
+	if _, ok := statusTransitions[parsed]; !ok {
+		return "", domainErrors.ErrInvalidStatus
+	}
+	return parsed, nil
+}
+
+// CanTransitionTo returns a StatusTransitionError explaining why the user may
+// not change to status, or nil when the change is allowed
+func (u *User) CanTransitionTo(status UserStatus) error {
+	reason := transitionGuard(u.Status, status)
+	if reason == "" {
+		return nil
+	}
+	return &domainErrors.StatusTransitionError{From: string(u.Status), To: string(status), Reason: reason}
+}
+
+// TransitionTo changes the user's status if the state machine allows it
+func (u *User) TransitionTo(status UserStatus) error {
+	if err := u.CanTransitionTo(status); err != nil {
+		return err
+	}
+	if u.Status == UserStatusSuspended {
+		u.Suspension = nil
+	}
+	u.Status = status
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// transitionGuard returns why from may not change to to, or ""
+func transitionGuard(from, to UserStatus) string {
+	switch {
+	case from == to:
+		return fmt.Sprintf("user is already %s", to)
+	case from == UserStatusDeleted:
+		return "deleted users cannot change status"
+	case to == UserStatusDeleted:
+		return ""
+	case from == UserStatusPending && to != UserStatusActive:
+		return "pending users must be activated first"
+	case to == UserStatusSuspended && from != UserStatusActive:
+		return "only active users can be suspended"
+	}
+
+	allowed, known := statusTransitions[from]
+	if !known {
+		return fmt.Sprintf("unknown status %q", from)
+	}
+	if _, valid := statusTransitions[to]; !valid {
+		return fmt.Sprintf("unknown status %q", to)
+	}
+	if !slices.Contains(allowed, to) {
+		return fmt.Sprintf("%s users cannot become %s", from, to)
+	}
+	return ""
+}
@@ -0,0 +1,92 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	domainErrors "user-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUser_TransitionTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    UserStatus
+		to      UserStatus
+		allowed bool
+		reason  string
+	}{
+		{name: "pending to active", from: UserStatusPending, to: UserStatusActive, allowed: true},
+		{name: "active to suspended", from: UserStatusActive, to: UserStatusSuspended, allowed: true},
+		{name: "suspended to active", from: UserStatusSuspended, to: UserStatusActive, allowed: true},
+		{name: "active to deleted", from: UserStatusActive, to: UserStatusDeleted, allowed: true},
+		{name: "pending to deleted", from: UserStatusPending, to: UserStatusDeleted, allowed: true},
+		{name: "pending to suspended", from: UserStatusPending, to: UserStatusSuspended, reason: "pending users must be activated first"},
+		{name: "inactive to suspended", from: UserStatusInactive, to: UserStatusSuspended, reason: "only active users can be suspended"},
+		{name: "suspended to inactive", from: UserStatusSuspended, to: UserStatusInactive, reason: "suspended users cannot become inactive"},
+		{name: "same status", from: UserStatusActive, to: UserStatusActive, reason: "user is already active"},
+		{name: "deleted to active", from: UserStatusDeleted, to: UserStatusActive, reason: "deleted users cannot change status"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{Status: tt.from}
+
+			err := user.TransitionTo(tt.to)
+
+			if tt.allowed {
+				require.NoError(t, err)
+				assert.Equal(t, tt.to, user.Status)
+				return
+			}
+
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidStatusTransition)
+			var transitionErr *domainErrors.StatusTransitionError
+			require.ErrorAs(t, err, &transitionErr)
+			assert.Equal(t, tt.reason, transitionErr.Reason)
+			assert.Equal(t, tt.from, user.Status, "a refused transition leaves the status unchanged")
+		})
+	}
+}
+
+func TestParseUserStatus(t *testing.T) {
+	status, err := ParseUserStatus(" Suspended ")
+	require.NoError(t, err)
+	assert.Equal(t, UserStatusSuspended, status)
+
+	_, err = ParseUserStatus("deleted")
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidStatus)
+
+	_, err = ParseUserStatus("banned")
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidStatus)
+}
+
+func TestUser_TransitionTo_ClearsSuspensionOnReactivation(t *testing.T) {
+	user := &User{Status: UserStatusActive}
+	require.NoError(t, user.Suspend(&Suspension{Reason: SuspensionReasonAbuse}))
+
+	require.NoError(t, user.Activate())
+
+	assert.Nil(t, user.Suspension)
+}
+
+func TestNewSuspension(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tomorrow := now.Add(24 * time.Hour)
+	yesterday := now.Add(-24 * time.Hour)
+
+	suspension, err := NewSuspension(" Fraud ", " chargeback ring ", &tomorrow, now)
+	require.NoError(t, err)
+	assert.Equal(t, &Suspension{Reason: SuspensionReasonFraud, Note: "chargeback ring", ReactivateAt: &tomorrow}, suspension)
+
+	_, err = NewSuspension("", "", nil, now)
+	assert.ErrorIs(t, err, domainErrors.ErrSuspensionReasonRequired)
+
+	_, err = NewSuspension("spam", "", nil, now)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidSuspensionReason)
+
+	_, err = NewSuspension("abuse", "", &yesterday, now)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidReactivationDate)
+}
@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"time"
+
+	domainErrors "user-service/internal/domain/errors"
+)
+
+// SyncPolicy decides how data from an external source of record is reconciled
+// with an existing user
+type SyncPolicy string
+
+const (
+	// SyncPreferExisting keeps existing users untouched; only missing users are created
+	SyncPreferExisting SyncPolicy = "prefer-existing"
+	// SyncPreferIncoming overwrites every synced field with the incoming value
+	SyncPreferIncoming SyncPolicy = "prefer-incoming"
+	// SyncMerge overwrites fields for which the incoming record has a value
+	SyncMerge SyncPolicy = "merge"
+)
+
+// ParseSyncPolicy validates a policy name, defaulting to merge when empty
+func ParseSyncPolicy(policy string) (SyncPolicy, error) {
+	switch SyncPolicy(policy) {
+	case "":
+		return SyncMerge, nil
+	case SyncPreferExisting, SyncPreferIncoming, SyncMerge:
+		return SyncPolicy(policy), nil
+	default:
+		return "", domainErrors.ErrInvalidSyncPolicy
+	}
+}
+
+// CheckSyncStatus rejects the statuses a source of record may not set.
+// Suspensions need a reason code, which synced records do not carry.
+func CheckSyncStatus(status UserStatus) error {
+	if status == UserStatusSuspended {
+		return domainErrors.ErrSyncCannotSuspend
+	}
+	return nil
+}
+
+// ApplySync reconciles the user with an incoming record according to the
+// policy and returns the names of the fields that changed. A status change the
+// state machine forbids, or one to suspended, rejects the whole record.
+func (u *User) ApplySync(incoming *User, policy SyncPolicy) ([]string, error) {
+	if policy == SyncPreferExisting {
+		return nil, nil
+	}
+
+	statusChanged := incoming.Status != "" && u.Status != incoming.Status
+	if statusChanged {
+		if err := CheckSyncStatus(incoming.Status); err != nil {
+			return nil, err
+		}
+		if err := u.CanTransitionTo(incoming.Status); err != nil {
+			return nil, err
+		}
+	}
+
+	var changed []string
+	apply := func(field string, current *string, value string) {
+		if value == "" && policy == SyncMerge {
+			return
+		}
+		if *current != value {
+			*current = value
+			changed = append(changed, field)
+		}
+	}
+
+	apply("first_name", &u.FirstName, incoming.FirstName)
+	apply("last_name", &u.LastName, incoming.LastName)
+	apply("phone", &u.Phone, incoming.Phone)
+
+	if statusChanged {
+		if err := u.TransitionTo(incoming.Status); err != nil {
+			return nil, err
+		}
+		changed = append(changed, "status")
+	}
+
+	if len(changed) > 0 {
+		u.UpdatedAt = time.Now()
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,118 @@
+package entities
+
+import (
+	"testing"
+
+	domainErrors "user-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUser_ApplySync(t *testing.T) {
+	incoming := &User{FirstName: "Johnny", LastName: "", Phone: "5550001111"}
+
+	tests := []struct {
+		name     string
+		policy   SyncPolicy
+		expected User
+		changed  []string
+	}{
+		{
+			name:     "prefer existing keeps the user untouched",
+			policy:   SyncPreferExisting,
+			expected: User{FirstName: "John", LastName: "Doe", Phone: "1234567890", Status: UserStatusActive},
+			changed:  nil,
+		},
+		{
+			name:     "prefer incoming overwrites every field",
+			policy:   SyncPreferIncoming,
+			expected: User{FirstName: "Johnny", LastName: "", Phone: "5550001111", Status: UserStatusActive},
+			changed:  []string{"first_name", "last_name", "phone"},
+		},
+		{
+			name:     "merge ignores empty incoming fields",
+			policy:   SyncMerge,
+			expected: User{FirstName: "Johnny", LastName: "Doe", Phone: "5550001111", Status: UserStatusActive},
+			changed:  []string{"first_name", "phone"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{FirstName: "John", LastName: "Doe", Phone: "1234567890", Status: UserStatusActive}
+
+			changed, err := user.ApplySync(incoming, tt.policy)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.changed, changed)
+			assert.Equal(t, tt.expected.FirstName, user.FirstName)
+			assert.Equal(t, tt.expected.LastName, user.LastName)
+			assert.Equal(t, tt.expected.Phone, user.Phone)
+			assert.Equal(t, tt.expected.Status, user.Status)
+		})
+	}
+}
+
+func TestUser_ApplySync_NoChanges(t *testing.T) {
+	user := &User{FirstName: "John", LastName: "Doe", Status: UserStatusActive}
+
+	changed, err := user.ApplySync(&User{FirstName: "John", LastName: "Doe"}, SyncMerge)
+	require.NoError(t, err)
+
+	assert.Empty(t, changed)
+	assert.True(t, user.UpdatedAt.IsZero())
+}
+
+func TestUser_ApplySync_Status(t *testing.T) {
+	user := &User{FirstName: "John", Status: UserStatusActive}
+
+	changed, err := user.ApplySync(&User{FirstName: "John", Status: UserStatusInactive}, SyncMerge)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"status"}, changed)
+	assert.Equal(t, UserStatusInactive, user.Status)
+}
+
+func TestUser_ApplySync_RejectsSuspension(t *testing.T) {
+	// Given an active user
+	user := &User{FirstName: "John", Status: UserStatusActive}
+
+	// When the source sends them as suspended, which takes a reason it cannot give
+	changed, err := user.ApplySync(&User{FirstName: "Johnny", Status: UserStatusSuspended}, SyncMerge)
+
+	// Then nothing is applied
+	assert.ErrorIs(t, err, domainErrors.ErrSyncCannotSuspend)
+	assert.Nil(t, changed)
+	assert.Equal(t, "John", user.FirstName)
+	assert.Equal(t, UserStatusActive, user.Status)
+	assert.Nil(t, user.Suspension)
+}
+
+func TestParseSyncPolicy(t *testing.T) {
+	policy, err := ParseSyncPolicy("")
+	assert.NoError(t, err)
+	assert.Equal(t, SyncMerge, policy)
+
+	policy, err = ParseSyncPolicy("prefer-incoming")
+	assert.NoError(t, err)
+	assert.Equal(t, SyncPreferIncoming, policy)
+
+	_, err = ParseSyncPolicy("last-write-wins")
+	assert.Error(t, err)
+}
+
+func TestUser_ApplySync_RejectsInvalidStatusTransition(t *testing.T) {
+	// Given a suspended user, which must be reactivated before anything else
+	user := &User{FirstName: "John", Status: UserStatusSuspended}
+
+	// When the source sends a status the state machine forbids
+	changed, err := user.ApplySync(&User{FirstName: "Johnny", Status: UserStatusInactive}, SyncMerge)
+
+	// Then nothing is applied
+	var transitionErr *domainErrors.StatusTransitionError
+	require.ErrorAs(t, err, &transitionErr)
+	assert.Nil(t, changed)
+	assert.Equal(t, "John", user.FirstName)
+	assert.Equal(t, UserStatusSuspended, user.Status)
+}
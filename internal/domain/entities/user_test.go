@@ -1,11 +1,13 @@
 package entities
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewUser(t *testing.T) {
@@ -195,7 +197,7 @@ func TestUser_Activate(t *testing.T) {
 	}
 	oldUpdatedAt := user.UpdatedAt
 
-	user.Activate()
+	require.NoError(t, user.Activate())
 
 	assert.Equal(t, UserStatusActive, user.Status)
 	assert.True(t, user.UpdatedAt.After(oldUpdatedAt))
@@ -208,9 +210,10 @@ func TestUser_Suspend(t *testing.T) {
 	}
 	oldUpdatedAt := user.UpdatedAt
 
-	user.Suspend()
+	require.NoError(t, user.Suspend(&Suspension{Reason: SuspensionReasonPayment}))
 
 	assert.Equal(t, UserStatusSuspended, user.Status)
+	assert.Equal(t, SuspensionReasonPayment, user.Suspension.Reason)
 	assert.True(t, user.UpdatedAt.After(oldUpdatedAt))
 }
 
@@ -268,3 +271,89 @@ func TestValidatePassword(t *testing.T) {
 		})
 	}
 }
+
+func TestUser_AddTags(t *testing.T) {
+	user := &User{Tags: []string{"vip"}}
+
+	added, err := user.AddTags("Beta_Tester", "vip", "beta_tester")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"beta_tester"}, added)
+	assert.Equal(t, []string{"beta_tester", "vip"}, user.Tags)
+	assert.True(t, user.HasTag("VIP"))
+}
+
+func TestUser_AddTags_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+	}{
+		{"empty", ""},
+		{"spaces inside", "beta tester"},
+		{"special characters", "vip!"},
+		{"too long", strings.Repeat("a", 51)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{}
+			_, err := user.AddTags(tt.tag)
+			assert.Error(t, err)
+			assert.Empty(t, user.Tags)
+		})
+	}
+}
+
+func TestUser_AddTags_TooMany(t *testing.T) {
+	user := &User{}
+	for i := 0; i < MaxUserTags; i++ {
+		_, err := user.AddTags(fmt.Sprintf("tag_%d", i))
+		assert.NoError(t, err)
+	}
+
+	_, err := user.AddTags("one_more")
+
+	assert.Error(t, err)
+	assert.Len(t, user.Tags, MaxUserTags)
+}
+
+func TestUser_SetExternalID(t *testing.T) {
+	user := &User{}
+
+	err := user.SetExternalID(" CRM ", " C-1001 ")
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"crm": "C-1001"}, user.ExternalIDs)
+}
+
+func TestUser_SetExternalID_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		id     string
+	}{
+		{"empty source", "", "C-1001"},
+		{"source with spaces", "legacy crm", "C-1001"},
+		{"empty id", "crm", "  "},
+		{"id too long", "crm", strings.Repeat("x", maxExternalIDLength+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{}
+			err := user.SetExternalID(tt.source, tt.id)
+			assert.Error(t, err)
+			assert.Empty(t, user.ExternalIDs)
+		})
+	}
+}
+
+func TestUser_RemoveTags(t *testing.T) {
+	user := &User{Tags: []string{"beta_tester", "vip"}}
+
+	removed, err := user.RemoveTags("VIP", "unknown")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"vip"}, removed)
+	assert.Equal(t, []string{"beta_tester"}, user.Tags)
+}
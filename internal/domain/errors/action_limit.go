@@ -0,0 +1,51 @@
+package errors
+
+import (
+	"fmt"
+	"time"
+)
+
+// Sensitive action limit errors
+var (
+	ErrActionRateLimited = &DomainError{
+		Code:    "RATE_LIMITED",
+		Message: "This action was performed too often for this account; try again later",
+	}
+
+	ErrFailedToCountActions = &DomainError{
+		Code:    "FAILED_TO_COUNT_ACTIONS",
+		Message: "Failed to check the account's action limits",
+	}
+)
+
+// ActionRateLimitedError reports which per-account limit was hit and when the
+// action is allowed again. It unwraps to ErrActionRateLimited so it is handled
+// like any other domain error.
+type ActionRateLimitedError struct {
+	Action string
+	Limit  int
+	Window time.Duration
+	Retry  time.Duration
+}
+
+func (e *ActionRateLimitedError) Error() string {
+	return fmt.Sprintf("%s (%s: %d per %s)", ErrActionRateLimited.Error(), e.Action, e.Limit, e.Window)
+}
+
+func (e *ActionRateLimitedError) Unwrap() error {
+	return ErrActionRateLimited
+}
+
+// RetryAfter is how long the client should wait before retrying
+func (e *ActionRateLimitedError) RetryAfter() time.Duration {
+	return e.Retry
+}
+
+// Details exposes the limit in error responses
+func (e *ActionRateLimitedError) Details() map[string]interface{} {
+	return map[string]interface{}{
+		"action":         e.Action,
+		"limit":          e.Limit,
+		"window_seconds": int64(e.Window / time.Second),
+	}
+}
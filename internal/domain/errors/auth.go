@@ -0,0 +1,14 @@
+package errors
+
+// Authentication and authorization errors
+var (
+	ErrUnauthorized = &DomainError{
+		Code:    "UNAUTHORIZED",
+		Message: "A valid API key is required",
+	}
+
+	ErrForbidden = &DomainError{
+		Code:    "FORBIDDEN",
+		Message: "Caller is not allowed to perform this action",
+	}
+)
@@ -0,0 +1,115 @@
+// Code generated by "user-service scaffold error-catalog"; DO NOT EDIT.
+
+package errors
+
+// Catalog lists every domain error, ordered by code
+var Catalog = []*DomainError{
+	ErrAlreadyReferred,
+	ErrBulkCapacityExceeded,
+	ErrCrossRegionAccess,
+	ErrDeadlineExceeded,
+	ErrDeleteBlocked,
+	ErrExternalIDTaken,
+	ErrFailedToCheckSuppressions,
+	ErrFailedToCheckUserExistance,
+	ErrFailedToCountActions,
+	ErrFailedToCreateUser,
+	ErrFailedToDeleteUser,
+	ErrFailedToExportUsage,
+	ErrFailedToIssueTokens,
+	ErrFailedToListDuplicates,
+	ErrFailedToListUsers,
+	ErrFailedToLoadJobState,
+	ErrFailedToLoadOIDCClients,
+	ErrFailedToLookUpPhone,
+	ErrFailedToQueueWrite,
+	ErrFailedToReadEvents,
+	ErrFailedToReadUsage,
+	ErrFailedToRecordUsage,
+	ErrFailedToRegisterOIDCClient,
+	ErrFailedToSaveJobState,
+	ErrFailedToStoreDuplicates,
+	ErrFailedToStoreEvent,
+	ErrFailedToSyncUser,
+	ErrFailedToUpdateLegalHold,
+	ErrFailedToUpdateOIDCClient,
+	ErrFailedToUpdateReferral,
+	ErrFailedToUpdateSuppressions,
+	ErrFailedToUpdateUserPreferences,
+	ErrFailedToUpdateUserProfile,
+	ErrFailedToUpdateUserStatus,
+	ErrFailedToUpdateUserTags,
+	ErrFailedToUpdateVerification,
+	ErrForbidden,
+	ErrInternal,
+	ErrInvalidAccessToken,
+	ErrInvalidAuthorizationCode,
+	ErrInvalidClaimMapping,
+	ErrInvalidClientCredentials,
+	ErrInvalidClientName,
+	ErrInvalidCountry,
+	ErrInvalidCredentials,
+	ErrInvalidCursor,
+	ErrInvalidDateOfBirth,
+	ErrInvalidDisplayName,
+	ErrInvalidUserEmail,
+	ErrInvalidExternalID,
+	ErrInvalidID,
+	ErrInvalidLegalHoldExpiry,
+	ErrInvalidLegalHoldReason,
+	ErrInvalidLocale,
+	ErrInvalidLookupPhone,
+	ErrInvalidNoteText,
+	ErrInvalidNoteVisibility,
+	ErrInvalidUserPassword,
+	ErrInvalidPronouns,
+	ErrInvalidReactivationDate,
+	ErrInvalidRedirectURI,
+	ErrInvalidReferralCode,
+	ErrInvalidResidency,
+	ErrInvalidScope,
+	ErrInvalidSearchQuery,
+	ErrInvalidStatus,
+	ErrInvalidStatusTransition,
+	ErrInvalidSuppressionExpiry,
+	ErrInvalidSuppressionNote,
+	ErrInvalidSuppressionReason,
+	ErrInvalidSuspensionReason,
+	ErrInvalidSyncPolicy,
+	ErrInvalidTag,
+	ErrInvalidTime,
+	ErrInvalidTimezone,
+	ErrInvalidUsageRange,
+	ErrInvalidVisibility,
+	ErrJobAlreadyRunning,
+	ErrJobNotFound,
+	ErrLegalHoldActive,
+	ErrMalformedQueuedWrite,
+	ErrNoteForbidden,
+	ErrNoteNotFound,
+	ErrOIDCClientNotFound,
+	ErrPKCERequired,
+	ErrQueuedWriteConflict,
+	ErrActionRateLimited,
+	ErrReferralLoop,
+	ErrSelfReferral,
+	ErrStatusChanged,
+	ErrSuppressionNotFound,
+	ErrSuspensionReasonRequired,
+	ErrSyncCannotSuspend,
+	ErrTooManyBulkItems,
+	ErrTooManyTags,
+	ErrUnauthorized,
+	ErrUnderMinimumAge,
+	ErrUnexpectedSuspensionDetails,
+	ErrUnsupportedGrantType,
+	ErrUnsupportedResponseType,
+	ErrUserAlreadyExists,
+	ErrUserInactive,
+	ErrUserNotFound,
+	ErrUserSuspended,
+	ErrValidationFailed,
+	ErrVerificationSentChanged,
+	ErrWriteQueued,
+	ErrWriteQueueFull,
+}
@@ -0,0 +1,10 @@
+package errors
+
+// Country errors
+var (
+	ErrInvalidCountry = &DomainError{
+		Code:    "INVALID_COUNTRY",
+		Message: "Country must be an ISO 3166-1 alpha-2 code, e.g. ES",
+		Field:   "country",
+	}
+)
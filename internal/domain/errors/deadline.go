@@ -0,0 +1,9 @@
+package errors
+
+// Request budget errors
+var (
+	ErrDeadlineExceeded = &DomainError{
+		Code:    "DEADLINE_EXCEEDED",
+		Message: "The request deadline was exhausted before the request completed",
+	}
+)
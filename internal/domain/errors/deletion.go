@@ -0,0 +1,47 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrDeleteBlocked = &DomainError{
+		Code:    "DELETE_BLOCKED",
+		Message: "User cannot be deleted while dependent records exist",
+	}
+
+	ErrFailedToDeleteUser = &DomainError{
+		Code:    "FAILED_TO_DELETE_USER",
+		Message: "failed to delete user",
+	}
+)
+
+// DeletionBlocker is one reason a dependent system gave to veto a deletion
+type DeletionBlocker struct {
+	Checker string `json:"checker"`
+	Reason  string `json:"reason"`
+}
+
+// DeletionBlockedError lists every blocker that vetoed a deletion. It unwraps
+// to ErrDeleteBlocked so it is handled like any other domain error.
+type DeletionBlockedError struct {
+	Blockers []DeletionBlocker
+}
+
+func (e *DeletionBlockedError) Error() string {
+	reasons := make([]string, 0, len(e.Blockers))
+	for _, blocker := range e.Blockers {
+		reasons = append(reasons, blocker.Checker+": "+blocker.Reason)
+	}
+	return fmt.Sprintf("%s (%s)", ErrDeleteBlocked.Error(), strings.Join(reasons, "; "))
+}
+
+func (e *DeletionBlockedError) Unwrap() error {
+	return ErrDeleteBlocked
+}
+
+// Details exposes the blockers in error responses
+func (e *DeletionBlockedError) Details() map[string]interface{} {
+	return map[string]interface{}{"blockers": e.Blockers}
+}
@@ -0,0 +1,14 @@
+package errors
+
+// Duplicate detection errors
+var (
+	ErrFailedToStoreDuplicates = &DomainError{
+		Code:    "FAILED_TO_STORE_DUPLICATES",
+		Message: "Failed to store duplicate suggestions",
+	}
+
+	ErrFailedToListDuplicates = &DomainError{
+		Code:    "FAILED_TO_LIST_DUPLICATES",
+		Message: "Failed to list duplicate suggestions",
+	}
+)
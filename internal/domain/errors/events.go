@@ -0,0 +1,14 @@
+package errors
+
+// Event store domain errors
+var (
+	ErrFailedToStoreEvent = &DomainError{
+		Code:    "FAILED_TO_STORE_EVENT",
+		Message: "Failed to store domain event",
+	}
+
+	ErrFailedToReadEvents = &DomainError{
+		Code:    "FAILED_TO_READ_EVENTS",
+		Message: "Failed to read domain events",
+	}
+)
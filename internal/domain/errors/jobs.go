@@ -0,0 +1,24 @@
+package errors
+
+// Background job errors
+var (
+	ErrJobNotFound = &DomainError{
+		Code:    "JOB_NOT_FOUND",
+		Message: "Job not found",
+	}
+
+	ErrJobAlreadyRunning = &DomainError{
+		Code:    "JOB_ALREADY_RUNNING",
+		Message: "Job is already running",
+	}
+
+	ErrFailedToLoadJobState = &DomainError{
+		Code:    "FAILED_TO_LOAD_JOB_STATE",
+		Message: "Failed to load job state",
+	}
+
+	ErrFailedToSaveJobState = &DomainError{
+		Code:    "FAILED_TO_SAVE_JOB_STATE",
+		Message: "Failed to save job state",
+	}
+)
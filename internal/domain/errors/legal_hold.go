@@ -0,0 +1,26 @@
+package errors
+
+// Legal hold errors
+var (
+	ErrLegalHoldActive = &DomainError{
+		Code:    "LEGAL_HOLD_ACTIVE",
+		Message: "The user is under a legal hold and cannot be erased",
+	}
+
+	ErrInvalidLegalHoldReason = &DomainError{
+		Code:    "INVALID_LEGAL_HOLD_REASON",
+		Message: "A legal hold requires a reason of at most 500 characters",
+		Field:   "reason",
+	}
+
+	ErrInvalidLegalHoldExpiry = &DomainError{
+		Code:    "INVALID_LEGAL_HOLD_EXPIRY",
+		Message: "Expiry must be in the future",
+		Field:   "expires_at",
+	}
+
+	ErrFailedToUpdateLegalHold = &DomainError{
+		Code:    "FAILED_TO_UPDATE_LEGAL_HOLD",
+		Message: "Failed to update the legal hold",
+	}
+)
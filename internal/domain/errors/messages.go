@@ -0,0 +1,84 @@
+package errors
+
+import (
+	"embed"
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// messageFiles translate the messages of the domain errors, one file per
+// locale mapping codes to messages. Messages missing from a file fall back to
+// the English Message of the error.
+//
+//go:embed messages/*.json
+var messageFiles embed.FS
+
+var (
+	// translations maps locales to their messages by code
+	translations = loadTranslations()
+	// MessageLocales lists the locales messages are available in, English
+	// first
+	MessageLocales = messageLocales()
+	messageMatcher = language.NewMatcher(MessageLocales)
+)
+
+func loadTranslations() map[language.Tag]map[string]string {
+	entries, err := messageFiles.ReadDir("messages")
+	if err != nil {
+		panic("invalid embedded error messages: " + err.Error())
+	}
+
+	loaded := make(map[language.Tag]map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := messageFiles.ReadFile(path.Join("messages", entry.Name()))
+		if err != nil {
+			panic("invalid embedded error messages: " + err.Error())
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic("invalid embedded error messages " + entry.Name() + ": " + err.Error())
+		}
+		loaded[language.MustParse(strings.TrimSuffix(entry.Name(), ".json"))] = messages
+	}
+	return loaded
+}
+
+func messageLocales() []language.Tag {
+	locales := []language.Tag{language.English}
+	for locale := range translations {
+		locales = append(locales, locale)
+	}
+	sort.Slice(locales[1:], func(i, j int) bool {
+		return locales[i+1].String() < locales[j+1].String()
+	})
+	return locales
+}
+
+// MatchMessageLocale returns the message locale that best matches the
+// preferences, each a language tag or an Accept-Language header, in order of
+// preference. It returns English when nothing matches.
+func MatchMessageLocale(preferences ...string) language.Tag {
+	var wanted []language.Tag
+	for _, preference := range preferences {
+		tags, _, err := language.ParseAcceptLanguage(preference)
+		if err != nil {
+			continue
+		}
+		wanted = append(wanted, tags...)
+	}
+	_, index, _ := messageMatcher.Match(wanted...)
+	return MessageLocales[index]
+}
+
+// LocalizedMessage returns the message of the error in a locale of
+// MessageLocales, falling back to Message
+func (e *DomainError) LocalizedMessage(locale language.Tag) string {
+	if message, ok := translations[locale][e.Code]; ok {
+		return message
+	}
+	return e.Message
+}
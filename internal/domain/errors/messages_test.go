@@ -0,0 +1,53 @@
+package errors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestMessages_TranslateEveryDomainError(t *testing.T) {
+	codes := make(map[string]bool, len(Catalog))
+	for _, err := range Catalog {
+		codes[err.Code] = true
+	}
+
+	for locale, messages := range translations {
+		for _, err := range Catalog {
+			assert.NotEmpty(t, messages[err.Code], "%s has no %s message", err.Code, locale)
+		}
+		for code := range messages {
+			assert.True(t, codes[code], "%s translates %s, which is not a domain error", locale, code)
+		}
+	}
+}
+
+func TestMatchMessageLocale(t *testing.T) {
+	tests := []struct {
+		preferences []string
+		want        language.Tag
+	}{
+		{nil, language.English},
+		{[]string{""}, language.English},
+		{[]string{"es-CO"}, language.Spanish},
+		{[]string{"fr, es;q=0.5"}, language.Spanish},
+		{[]string{"fr", "es"}, language.Spanish},
+		{[]string{"en-GB", "es"}, language.English},
+		{[]string{"not a locale", "es"}, language.Spanish},
+		{[]string{"de"}, language.English},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, MatchMessageLocale(tt.preferences...), tt.preferences)
+	}
+}
+
+func TestDomainError_LocalizedMessage(t *testing.T) {
+	assert.Equal(t, "Usuario no encontrado", ErrUserNotFound.LocalizedMessage(language.Spanish))
+	assert.Equal(t, ErrUserNotFound.Message, ErrUserNotFound.LocalizedMessage(language.English))
+
+	// Errors without a translation fall back to English
+	untranslated := &DomainError{Code: "UNTRANSLATED", Message: "Not translated"}
+	assert.Equal(t, "Not translated", untranslated.LocalizedMessage(language.Spanish))
+}
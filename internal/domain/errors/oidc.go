@@ -0,0 +1,93 @@
+package errors
+
+// OpenID Connect provider errors
+var (
+	ErrOIDCClientNotFound = &DomainError{
+		Code:    "OIDC_CLIENT_NOT_FOUND",
+		Message: "OIDC client not found",
+		Field:   "client_id",
+	}
+
+	ErrInvalidClientCredentials = &DomainError{
+		Code:    "INVALID_CLIENT_CREDENTIALS",
+		Message: "Client authentication failed",
+	}
+
+	ErrInvalidRedirectURI = &DomainError{
+		Code:    "INVALID_REDIRECT_URI",
+		Message: "Redirect URI is not registered for this client",
+		Field:   "redirect_uri",
+	}
+
+	ErrInvalidClientName = &DomainError{
+		Code:    "INVALID_CLIENT_NAME",
+		Message: "Client name must be between 1 and 100 characters",
+		Field:   "name",
+	}
+
+	ErrInvalidScope = &DomainError{
+		Code:    "INVALID_SCOPE",
+		Message: "Requested scope is unknown or not allowed for this client",
+		Field:   "scope",
+	}
+
+	ErrInvalidClaimMapping = &DomainError{
+		Code:    "INVALID_CLAIM_MAPPING",
+		Message: "Claim mappings must rename standard claims other than sub to distinct names of at most 64 characters",
+		Field:   "claim_mappings",
+	}
+
+	ErrUnsupportedResponseType = &DomainError{
+		Code:    "UNSUPPORTED_RESPONSE_TYPE",
+		Message: "Only the authorization code flow (response_type=code) is supported",
+		Field:   "response_type",
+	}
+
+	ErrPKCERequired = &DomainError{
+		Code:    "PKCE_REQUIRED",
+		Message: "A code_challenge using the S256 method is required",
+		Field:   "code_challenge",
+	}
+
+	ErrUnsupportedGrantType = &DomainError{
+		Code:    "UNSUPPORTED_GRANT_TYPE",
+		Message: "Only the authorization_code grant type is supported",
+		Field:   "grant_type",
+	}
+
+	ErrInvalidAuthorizationCode = &DomainError{
+		Code:    "INVALID_AUTHORIZATION_CODE",
+		Message: "Authorization code is invalid, expired or was issued to another client",
+		Field:   "code",
+	}
+
+	ErrInvalidCredentials = &DomainError{
+		Code:    "INVALID_CREDENTIALS",
+		Message: "Email or password is incorrect",
+	}
+
+	ErrInvalidAccessToken = &DomainError{
+		Code:    "INVALID_ACCESS_TOKEN",
+		Message: "Access token is missing, invalid or expired",
+	}
+
+	ErrFailedToRegisterOIDCClient = &DomainError{
+		Code:    "FAILED_TO_REGISTER_OIDC_CLIENT",
+		Message: "Failed to register OIDC client",
+	}
+
+	ErrFailedToUpdateOIDCClient = &DomainError{
+		Code:    "FAILED_TO_UPDATE_OIDC_CLIENT",
+		Message: "Failed to update OIDC client",
+	}
+
+	ErrFailedToLoadOIDCClients = &DomainError{
+		Code:    "FAILED_TO_LOAD_OIDC_CLIENTS",
+		Message: "Failed to load OIDC clients",
+	}
+
+	ErrFailedToIssueTokens = &DomainError{
+		Code:    "FAILED_TO_ISSUE_TOKENS",
+		Message: "Failed to issue tokens",
+	}
+)
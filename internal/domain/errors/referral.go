@@ -0,0 +1,32 @@
+package errors
+
+// Referral errors
+var (
+	ErrInvalidReferralCode = &DomainError{
+		Code:    "INVALID_REFERRAL_CODE",
+		Message: "The referral code is not valid",
+		Field:   "referral_code",
+	}
+
+	ErrSelfReferral = &DomainError{
+		Code:    "SELF_REFERRAL",
+		Message: "Users cannot refer themselves",
+		Field:   "referral_code",
+	}
+
+	ErrReferralLoop = &DomainError{
+		Code:    "REFERRAL_LOOP",
+		Message: "The referral would make the user refer one of their own referrers",
+		Field:   "referral_code",
+	}
+
+	ErrAlreadyReferred = &DomainError{
+		Code:    "ALREADY_REFERRED",
+		Message: "The user was already referred by someone",
+	}
+
+	ErrFailedToUpdateReferral = &DomainError{
+		Code:    "FAILED_TO_UPDATE_REFERRAL",
+		Message: "Failed to update the referral",
+	}
+)
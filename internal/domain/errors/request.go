@@ -0,0 +1,32 @@
+package errors
+
+// Request errors, for path and query parameters rejected before a use case
+// runs, and for requests that fail in ways no other error describes
+var (
+	ErrInvalidID = &DomainError{
+		Code:    "INVALID_ID",
+		Message: "Invalid user ID format",
+		Field:   "id",
+	}
+
+	ErrInvalidCursor = &DomainError{
+		Code:    "INVALID_CURSOR",
+		Message: "Cursor is not valid for this listing",
+		Field:   "cursor",
+	}
+
+	ErrInvalidTime = &DomainError{
+		Code:    "INVALID_TIME",
+		Message: "from and to must be RFC 3339 timestamps, e.g. 2024-05-01T00:00:00Z",
+	}
+
+	ErrValidationFailed = &DomainError{
+		Code:    "VALIDATION_ERROR",
+		Message: "Request validation failed",
+	}
+
+	ErrInternal = &DomainError{
+		Code:    "INTERNAL_ERROR",
+		Message: "An internal error occurred",
+	}
+)
@@ -0,0 +1,15 @@
+package errors
+
+// Data residency errors
+var (
+	ErrInvalidResidency = &DomainError{
+		Code:    "INVALID_RESIDENCY",
+		Message: "Residency must be one of: eu, us",
+		Field:   "residency",
+	}
+
+	ErrCrossRegionAccess = &DomainError{
+		Code:    "CROSS_REGION_ACCESS",
+		Message: "User data is resident in another region and cannot be accessed from this one",
+	}
+)
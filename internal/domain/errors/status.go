@@ -0,0 +1,72 @@
+package errors
+
+import "fmt"
+
+// User status errors
+var (
+	ErrInvalidStatus = &DomainError{
+		Code:    "INVALID_STATUS",
+		Message: "Status must be 'pending', 'active', 'inactive' or 'suspended'",
+		Field:   "status",
+	}
+
+	ErrInvalidStatusTransition = &DomainError{
+		Code:    "INVALID_STATUS_TRANSITION",
+		Message: "User status cannot change this way",
+		Field:   "status",
+	}
+
+	ErrStatusChanged = &DomainError{
+		Code:    "STATUS_CHANGED",
+		Message: "User status changed concurrently; reload the user and retry",
+	}
+
+	ErrSuspensionReasonRequired = &DomainError{
+		Code:    "SUSPENSION_REASON_REQUIRED",
+		Message: "Suspending a user requires a reason code",
+		Field:   "reason_code",
+	}
+
+	ErrInvalidSuspensionReason = &DomainError{
+		Code:    "INVALID_SUSPENSION_REASON",
+		Message: "Reason code must be 'fraud', 'abuse' or 'payment'",
+		Field:   "reason_code",
+	}
+
+	ErrInvalidReactivationDate = &DomainError{
+		Code:    "INVALID_REACTIVATION_DATE",
+		Message: "Reactivation date must be in the future",
+		Field:   "reactivate_at",
+	}
+
+	ErrUnexpectedSuspensionDetails = &DomainError{
+		Code:    "UNEXPECTED_SUSPENSION_DETAILS",
+		Message: "Reason code, note and reactivation date are only accepted when suspending",
+	}
+
+	ErrFailedToUpdateUserStatus = &DomainError{
+		Code:    "FAILED_TO_UPDATE_USER_STATUS",
+		Message: "Failed to update user status",
+	}
+)
+
+// StatusTransitionError explains why a status change was refused. It unwraps
+// to ErrInvalidStatusTransition so it is handled like any other domain error.
+type StatusTransitionError struct {
+	From   string
+	To     string
+	Reason string
+}
+
+func (e *StatusTransitionError) Error() string {
+	return fmt.Sprintf("%s (%s -> %s: %s)", ErrInvalidStatusTransition.Error(), e.From, e.To, e.Reason)
+}
+
+func (e *StatusTransitionError) Unwrap() error {
+	return ErrInvalidStatusTransition
+}
+
+// Details exposes the refused transition in error responses
+func (e *StatusTransitionError) Details() map[string]interface{} {
+	return map[string]interface{}{"from": e.From, "to": e.To, "reason": e.Reason}
+}
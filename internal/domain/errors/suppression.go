@@ -0,0 +1,37 @@
+package errors
+
+// Communication suppression errors
+var (
+	ErrSuppressionNotFound = &DomainError{
+		Code:    "SUPPRESSION_NOT_FOUND",
+		Message: "The email address is not suppressed",
+	}
+
+	ErrInvalidSuppressionReason = &DomainError{
+		Code:    "INVALID_SUPPRESSION_REASON",
+		Message: "Reason must be 'bounced', 'unsubscribed' or 'legal_hold'",
+		Field:   "reason",
+	}
+
+	ErrInvalidSuppressionNote = &DomainError{
+		Code:    "INVALID_SUPPRESSION_NOTE",
+		Message: "Note must be at most 500 characters",
+		Field:   "note",
+	}
+
+	ErrInvalidSuppressionExpiry = &DomainError{
+		Code:    "INVALID_SUPPRESSION_EXPIRY",
+		Message: "Expiry must be in the future",
+		Field:   "expires_at",
+	}
+
+	ErrFailedToCheckSuppressions = &DomainError{
+		Code:    "FAILED_TO_CHECK_SUPPRESSIONS",
+		Message: "Failed to check the suppression list",
+	}
+
+	ErrFailedToUpdateSuppressions = &DomainError{
+		Code:    "FAILED_TO_UPDATE_SUPPRESSIONS",
+		Message: "Failed to update the suppression list",
+	}
+)
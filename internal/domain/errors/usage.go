@@ -0,0 +1,24 @@
+package errors
+
+// Usage metering errors
+var (
+	ErrInvalidUsageRange = &DomainError{
+		Code:    "INVALID_USAGE_RANGE",
+		Message: "The usage range must start before it ends and span at most 31 days",
+	}
+
+	ErrFailedToRecordUsage = &DomainError{
+		Code:    "FAILED_TO_RECORD_USAGE",
+		Message: "Failed to record usage",
+	}
+
+	ErrFailedToReadUsage = &DomainError{
+		Code:    "FAILED_TO_READ_USAGE",
+		Message: "Failed to read usage",
+	}
+
+	ErrFailedToExportUsage = &DomainError{
+		Code:    "FAILED_TO_EXPORT_USAGE",
+		Message: "Failed to export usage",
+	}
+)
@@ -2,6 +2,8 @@ package errors
 
 import "fmt"
 
+//go:generate go run ../../.. scaffold error-catalog --dir ../../..
+
 type DomainError struct {
 	Code    string
 	Message string
@@ -64,12 +66,168 @@ var (
 		Code:    "FAILED_TO_LIST_USERS",
 		Message: "failed to list users",
 	}
+
+	ErrInvalidTag = &DomainError{
+		Code:    "INVALID_TAG",
+		Message: "Tags must be 1-50 characters of lowercase letters, digits, '_', ':' or '-'",
+		Field:   "tags",
+	}
+
+	ErrTooManyTags = &DomainError{
+		Code:    "TOO_MANY_TAGS",
+		Message: "User has reached the maximum number of tags",
+		Field:   "tags",
+	}
+
+	ErrFailedToUpdateUserTags = &DomainError{
+		Code:    "FAILED_TO_UPDATE_USER_TAGS",
+		Message: "failed to update user tags",
+	}
+
+	ErrInvalidExternalID = &DomainError{
+		Code:    "INVALID_EXTERNAL_ID",
+		Message: "External ID sources must be 1-50 lowercase letters, digits, '_', '.' or '-', and IDs 1-255 characters",
+		Field:   "external_id",
+	}
+
+	ErrInvalidLocale = &DomainError{
+		Code:    "INVALID_LOCALE",
+		Message: "Locale must be a known BCP 47 language tag, e.g. en-US",
+		Field:   "locale",
+	}
+
+	ErrInvalidTimezone = &DomainError{
+		Code:    "INVALID_TIMEZONE",
+		Message: "Timezone must be an IANA time zone name, e.g. Europe/Madrid",
+		Field:   "timezone",
+	}
+
+	ErrInvalidVisibility = &DomainError{
+		Code:    "INVALID_VISIBILITY",
+		Message: "Visibility must be 'public', 'org' or 'private'",
+		Field:   "visibility",
+	}
+
+	ErrInvalidDisplayName = &DomainError{
+		Code:    "INVALID_DISPLAY_NAME",
+		Message: "Display name must be at most 50 characters",
+		Field:   "display_name",
+	}
+
+	ErrInvalidPronouns = &DomainError{
+		Code:    "INVALID_PRONOUNS",
+		Message: "Pronouns must be at most 40 characters",
+		Field:   "pronouns",
+	}
+
+	ErrInvalidDateOfBirth = &DomainError{
+		Code:    "INVALID_DATE_OF_BIRTH",
+		Message: "Date of birth must be a past date after 1900",
+		Field:   "date_of_birth",
+	}
+
+	ErrUnderMinimumAge = &DomainError{
+		Code:    "UNDER_MINIMUM_AGE",
+		Message: "User is younger than the minimum age to register",
+		Field:   "date_of_birth",
+	}
+
+	ErrExternalIDTaken = &DomainError{
+		Code:    "EXTERNAL_ID_TAKEN",
+		Message: "Another user already has this external ID for the source",
+		Field:   "external_id",
+	}
+
+	ErrFailedToUpdateUserProfile = &DomainError{
+		Code:    "FAILED_TO_UPDATE_USER_PROFILE",
+		Message: "failed to update user profile",
+	}
+
+	ErrFailedToUpdateUserPreferences = &DomainError{
+		Code:    "FAILED_TO_UPDATE_USER_PREFERENCES",
+		Message: "failed to update user preferences",
+	}
+
+	ErrTooManyBulkItems = &DomainError{
+		Code:    "TOO_MANY_BULK_ITEMS",
+		Message: "Bulk request exceeds the maximum number of users",
+		Field:   "users",
+	}
+
+	ErrInvalidSyncPolicy = &DomainError{
+		Code:    "INVALID_SYNC_POLICY",
+		Message: "Sync policy must be 'prefer-existing', 'prefer-incoming' or 'merge'",
+		Field:   "policy",
+	}
+
+	ErrSyncCannotSuspend = &DomainError{
+		Code:    "SYNC_CANNOT_SUSPEND",
+		Message: "Synced records cannot suspend users, suspend them with a reason code instead",
+		Field:   "status",
+	}
+
+	ErrFailedToSyncUser = &DomainError{
+		Code:    "FAILED_TO_SYNC_USER",
+		Message: "failed to sync user",
+	}
+
+	ErrBulkCapacityExceeded = &DomainError{
+		Code:    "BULK_CAPACITY_EXCEEDED",
+		Message: "Too many bulk imports are in progress, retry later",
+	}
+)
+
+// Phone lookup domain errors
+var (
+	ErrInvalidLookupPhone = &DomainError{
+		Code:    "INVALID_LOOKUP_PHONE",
+		Message: "Phone number must contain between 7 and 15 digits",
+		Field:   "phone",
+	}
+
+	ErrFailedToLookUpPhone = &DomainError{
+		Code:    "FAILED_TO_LOOK_UP_PHONE",
+		Message: "failed to look up phone number",
+	}
+)
+
+// Admin search domain errors
+var (
+	ErrInvalidSearchQuery = &DomainError{
+		Code:    "INVALID_SEARCH_QUERY",
+		Message: "Search needs at least 3 characters of an email address or 4 digits of a phone number",
+	}
+)
+
+// Support note domain errors
+var (
+	ErrNoteNotFound = &DomainError{
+		Code:    "NOTE_NOT_FOUND",
+		Message: "Note not found",
+	}
+
+	ErrNoteForbidden = &DomainError{
+		Code:    "NOTE_FORBIDDEN",
+		Message: "Only the author can modify this note",
+	}
+
+	ErrInvalidNoteText = &DomainError{
+		Code:    "INVALID_NOTE_TEXT",
+		Message: "Note text must be between 1 and 5000 characters",
+		Field:   "text",
+	}
+
+	ErrInvalidNoteVisibility = &DomainError{
+		Code:    "INVALID_NOTE_VISIBILITY",
+		Message: "Note visibility must be 'internal' or 'private'",
+		Field:   "visibility",
+	}
 )
 
 // Helper functions to create specific errors
 func NewUserValidationError(field, message string) *DomainError {
 	return &DomainError{
-		Code:    "VALIDATION_ERROR",
+		Code:    ErrValidationFailed.Code,
 		Message: message,
 		Field:   field,
 	}
@@ -0,0 +1,14 @@
+package errors
+
+// Verification email errors
+var (
+	ErrVerificationSentChanged = &DomainError{
+		Code:    "VERIFICATION_SENT_CHANGED",
+		Message: "A verification email was requested concurrently",
+	}
+
+	ErrFailedToUpdateVerification = &DomainError{
+		Code:    "FAILED_TO_UPDATE_VERIFICATION",
+		Message: "Failed to record the verification request",
+	}
+)
@@ -0,0 +1,51 @@
+package errors
+
+// Offline write errors
+var (
+	ErrWriteQueued = &DomainError{
+		Code:    "WRITE_QUEUED",
+		Message: "The database is unreachable; the change was queued and will be applied once it is back",
+	}
+
+	ErrWriteQueueFull = &DomainError{
+		Code:    "WRITE_QUEUE_FULL",
+		Message: "The database is unreachable and no more changes can be queued",
+	}
+
+	ErrFailedToQueueWrite = &DomainError{
+		Code:    "FAILED_TO_QUEUE_WRITE",
+		Message: "Failed to queue the change",
+	}
+)
+
+// Reasons queued writes are rejected when applied
+var (
+	ErrQueuedWriteConflict = &DomainError{
+		Code:    "QUEUED_WRITE_CONFLICT",
+		Message: "The user was changed elsewhere after the write was queued",
+	}
+
+	ErrMalformedQueuedWrite = &DomainError{
+		Code:    "MALFORMED_QUEUED_WRITE",
+		Message: "The queued write cannot be read",
+	}
+)
+
+// WriteQueuedError identifies a write accepted into the offline queue. It
+// unwraps to ErrWriteQueued so it is handled like any other domain error.
+type WriteQueuedError struct {
+	ID string
+}
+
+func (e *WriteQueuedError) Error() string {
+	return ErrWriteQueued.Error() + " (write " + e.ID + ")"
+}
+
+func (e *WriteQueuedError) Unwrap() error {
+	return ErrWriteQueued
+}
+
+// Details exposes the queued write in responses
+func (e *WriteQueuedError) Details() map[string]interface{} {
+	return map[string]interface{}{"write_id": e.ID}
+}
@@ -6,32 +6,139 @@ import (
 
 	gormConn "user-service/internal/adapters/persistence/postgres"
 	"user-service/internal/config"
+	"user-service/internal/domain/entities"
 	"user-service/pkg/logger"
+	"user-service/pkg/metrics"
 
 	"gorm.io/gorm"
 )
 
 type DatabaseConnections struct {
-	conn   *gormConn.GormDB
-	logger logger.Logger
+	conn *gormConn.GormDB
+	// regions holds the dedicated databases of residency regions
+	regions map[entities.Residency]*gormConn.GormDB
+	// shadow is the secondary database of shadow reads, nil when they are
+	// disabled or it is unreachable
+	shadow       *gormConn.GormDB
+	dependencies []*Dependency
+	logger       logger.Logger
 }
 
-func NewDatabaseConnections(cfg *config.Config, logger logger.Logger) (*DatabaseConnections, error) {
+func NewDatabaseConnections(cfg *config.Config, logger logger.Logger, registry *metrics.Registry) (*DatabaseConnections, error) {
 	log := logger.With("component", "database_connections")
 
 	// PostgreSQL connection
 	log.Info("Connecting to PostgreSQL...")
-	pg, err := gormConn.NewGormConnection(cfg, logger)
+	pg, err := gormConn.NewGormConnection(cfg, logger, registry)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to postgres: %w", err)
 	}
 
+	connections := &DatabaseConnections{
+		conn:    pg,
+		regions: make(map[entities.Residency]*gormConn.GormDB),
+		logger:  log,
+	}
+
+	if err := connections.connectRegions(cfg, logger, registry); err != nil {
+		_ = connections.Close()
+		return nil, err
+	}
+
+	connections.connectShadow(cfg, logger, registry)
+
+	connections.dependencies, err = connectDependencies(cfg, log)
+	if err != nil {
+		_ = connections.Close()
+		return nil, err
+	}
+
 	log.Info("All database connections established successfully")
 
+	return connections, nil
+}
+
+// NewDisconnectedConnections returns connections that are never opened, for
+// commands that build the server without serving requests, such as routes.
+// Their databases are nil and must not be queried.
+func NewDisconnectedConnections(logger logger.Logger) *DatabaseConnections {
 	return &DatabaseConnections{
-		conn:   pg,
-		logger: log,
-	}, nil
+		regions: make(map[entities.Residency]*gormConn.GormDB),
+		logger:  logger.With("component", "database_connections"),
+	}
+}
+
+// connectRegions opens the dedicated database of every residency region that
+// has one configured
+func (d *DatabaseConnections) connectRegions(cfg *config.Config, logger logger.Logger, registry *metrics.Registry) error {
+	if !cfg.Residency.Enabled {
+		return nil
+	}
+
+	for name := range cfg.Residency.Regions {
+		region, err := entities.ParseResidency(name)
+		if err != nil {
+			return fmt.Errorf("residency.regions.%s: %w", name, err)
+		}
+
+		database, _ := cfg.Residency.DatabaseFor(name, cfg.Database)
+		regional := *cfg
+		regional.Database = database
+
+		d.logger.Info("Connecting to regional PostgreSQL...", "region", region, "host", database.Host)
+		pg, err := gormConn.NewGormConnection(&regional, logger.With("region", string(region)), registry)
+		if err != nil {
+			return fmt.Errorf("failed to connect to postgres for region %s: %w", region, err)
+		}
+		d.regions[region] = pg
+	}
+
+	return nil
+}
+
+// connectShadow opens the secondary database of shadow reads. Shadow reads
+// must never keep the service from starting, so they are left off when it is
+// unreachable.
+func (d *DatabaseConnections) connectShadow(cfg *config.Config, logger logger.Logger, registry *metrics.Registry) {
+	if !cfg.ShadowReads.Enabled {
+		return
+	}
+
+	shadowed := *cfg
+	shadowed.Database = cfg.ShadowReads.DatabaseFor(cfg.Database)
+
+	d.logger.Info("Connecting to shadow PostgreSQL...", "host", shadowed.Database.Host)
+	pg, err := gormConn.NewGormConnection(&shadowed, logger.With("database", "shadow"), registry)
+	if err != nil {
+		d.logger.Warn("Shadow database unreachable, shadow reads are off", "error", err)
+		return
+	}
+	d.shadow = pg
+}
+
+// connectDependencies probes every dependency enabled in cfg. Unreachable
+// required dependencies abort startup; optional ones are kept so the health
+// registry reports them until they come up.
+func connectDependencies(cfg *config.Config, log logger.Logger) ([]*Dependency, error) {
+	dependencies, err := EnabledDependencies(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dependency := range dependencies {
+		log.Info("Connecting to dependency...", "dependency", dependency.Name, "address", dependency.Address)
+
+		if err := dependency.Connect(context.Background()); err != nil {
+			if dependency.Required {
+				return nil, err
+			}
+			log.Warn("Optional dependency unreachable, starting degraded",
+				"dependency", dependency.Name,
+				"error", err)
+		}
+	}
+
+	return dependencies, nil
 }
 
 func (d *DatabaseConnections) Close() error {
@@ -42,6 +149,16 @@ func (d *DatabaseConnections) Close() error {
 	if err := d.conn.Close(); err != nil {
 		errs = append(errs, fmt.Errorf("postgres close error: %w", err))
 	}
+	for region, conn := range d.regions {
+		if err := conn.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("postgres %s close error: %w", region, err))
+		}
+	}
+	if d.shadow != nil {
+		if err := d.shadow.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("postgres shadow close error: %w", err))
+		}
+	}
 
 	if len(errs) > 0 {
 		return fmt.Errorf("errors closing connections: %v", errs)
@@ -55,10 +172,95 @@ func (d *DatabaseConnections) HealthCheck(ctx context.Context) map[string]error
 	checks := make(map[string]error)
 
 	checks["postgres"] = d.conn.HealthCheck(ctx)
+	for region, conn := range d.regions {
+		checks["postgres_"+string(region)] = conn.HealthCheck(ctx)
+	}
+	for _, dependency := range d.dependencies {
+		checks[dependency.Name] = dependency.Connect(ctx)
+	}
 
 	return checks
 }
 
+// PingDatabases checks the primary and regional databases, failing when any
+// of them is unreachable
+func (d *DatabaseConnections) PingDatabases(ctx context.Context) error {
+	if err := d.conn.HealthCheck(ctx); err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	for region, conn := range d.regions {
+		if err := conn.HealthCheck(ctx); err != nil {
+			return fmt.Errorf("postgres %s: %w", region, err)
+		}
+	}
+	return nil
+}
+
+// RegisterHealthChecks registers every open connection and enabled dependency
+// with the health registry
+func (d *DatabaseConnections) RegisterHealthChecks(registry *HealthRegistry) {
+	registry.Register("postgres", d.conn)
+	for region, conn := range d.regions {
+		registry.Register("postgres_"+string(region), conn)
+	}
+	for _, dependency := range d.dependencies {
+		registry.Register(dependency.Name, dependency)
+	}
+}
+
 func (d *DatabaseConnections) GetGormDB() *gorm.DB {
+	if d.conn == nil {
+		return nil
+	}
 	return d.conn.DB()
 }
+
+// GetRegionGormDB returns the database a residency region is stored in: its
+// dedicated database when configured, otherwise the primary one
+func (d *DatabaseConnections) GetRegionGormDB(region entities.Residency) *gorm.DB {
+	if conn, ok := d.regions[region]; ok {
+		return conn.DB()
+	}
+	return d.GetGormDB()
+}
+
+// GetShadowGormDB returns the secondary database of shadow reads, nil when
+// there is none
+func (d *DatabaseConnections) GetShadowGormDB() *gorm.DB {
+	if d.shadow == nil {
+		return nil
+	}
+	return d.shadow.DB()
+}
+
+// GetRegionalGormDBs returns the dedicated database of every region that has one
+func (d *DatabaseConnections) GetRegionalGormDBs() map[entities.Residency]*gorm.DB {
+	dbs := make(map[entities.Residency]*gorm.DB, len(d.regions))
+	for region, conn := range d.regions {
+		dbs[region] = conn.DB()
+	}
+	return dbs
+}
+
+// ServerVersions asks every connected database and dependency for its server
+// version; unavailable versions are reported with the reason
+func (d *DatabaseConnections) ServerVersions(ctx context.Context) map[string]string {
+	versions := make(map[string]string)
+
+	versions["postgres"] = versionOrReason(d.conn.ServerVersion(ctx))
+	for region, conn := range d.regions {
+		versions["postgres_"+string(region)] = versionOrReason(conn.ServerVersion(ctx))
+	}
+	for _, dependency := range d.dependencies {
+		versions[dependency.Name] = versionOrReason(dependency.Version(ctx))
+	}
+
+	return versions
+}
+
+func versionOrReason(version string, err error) string {
+	if err != nil {
+		return "unavailable: " + err.Error()
+	}
+	return version
+}
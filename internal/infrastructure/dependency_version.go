@@ -0,0 +1,171 @@
+package infrastructure
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// maxHandshakeBytes bounds how much a version probe reads from a dependency
+const maxHandshakeBytes = 64 << 10
+
+// versionProbe asks a freshly connected dependency for its server version
+type versionProbe func(conn net.Conn) (string, error)
+
+// Version connects to the dependency and asks it for its server version
+func (d *Dependency) Version(ctx context.Context) (string, error) {
+	if d.probe == nil {
+		return "", errors.New("version probe not supported")
+	}
+
+	if d.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.Timeout)
+		defer cancel()
+	}
+
+	conn, err := d.dial(ctx, "tcp", d.Address)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return "", err
+		}
+	}
+
+	return d.probe(conn)
+}
+
+// amqpVersion reads the server properties RabbitMQ sends in Connection.Start
+// and hangs up before authenticating, so no credentials are needed. The broker
+// logs the abandoned handshake.
+func amqpVersion(conn net.Conn) (string, error) {
+	if _, err := conn.Write([]byte("AMQP\x00\x00\x09\x01")); err != nil {
+		return "", err
+	}
+
+	header := make([]byte, 7)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return "", fmt.Errorf("failed to read AMQP frame: %w", err)
+	}
+	size := binary.BigEndian.Uint32(header[3:])
+	if header[0] != 1 || size > maxHandshakeBytes {
+		return "", errors.New("unexpected AMQP frame")
+	}
+
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(conn, payload); err != nil {
+		return "", fmt.Errorf("failed to read AMQP frame: %w", err)
+	}
+	// class 10 (connection), method 10 (start), then the protocol version
+	if len(payload) < 10 || binary.BigEndian.Uint16(payload) != 10 || binary.BigEndian.Uint16(payload[2:]) != 10 {
+		return "", errors.New("expected AMQP Connection.Start")
+	}
+
+	properties, err := amqpStrings(payload[6:])
+	if err != nil {
+		return "", err
+	}
+	if properties["version"] == "" {
+		return "", errors.New("AMQP server did not report a version")
+	}
+	return strings.TrimSpace(properties["product"] + " " + properties["version"]), nil
+}
+
+// amqpFixedSizes gives the size of fixed-width AMQP field values
+var amqpFixedSizes = map[byte]int{
+	't': 1, 'b': 1, 'B': 1, 's': 2, 'u': 2, 'I': 4, 'i': 4, 'f': 4,
+	'l': 8, 'L': 8, 'd': 8, 'T': 8, 'D': 5, 'V': 0,
+}
+
+// amqpStrings returns the string entries of an AMQP field table, skipping
+// the others
+func amqpStrings(data []byte) (map[string]string, error) {
+	errMalformed := errors.New("malformed AMQP field table")
+	if len(data) < 4 {
+		return nil, errMalformed
+	}
+	size := binary.BigEndian.Uint32(data)
+	if uint64(size) > uint64(len(data)-4) {
+		return nil, errMalformed
+	}
+	table := data[4 : 4+size]
+
+	values := make(map[string]string)
+	for len(table) > 0 {
+		nameLen := int(table[0])
+		if len(table) < 1+nameLen+1 {
+			return nil, errMalformed
+		}
+		name := string(table[1 : 1+nameLen])
+		kind := table[1+nameLen]
+		table = table[2+nameLen:]
+
+		if width, ok := amqpFixedSizes[kind]; ok {
+			if len(table) < width {
+				return nil, errMalformed
+			}
+			table = table[width:]
+			continue
+		}
+
+		// 'S' long strings, 'F' nested tables, 'A' arrays and 'x' byte
+		// arrays are all prefixed with their length
+		if !strings.ContainsRune("SFAx", rune(kind)) || len(table) < 4 {
+			return nil, errMalformed
+		}
+		length := binary.BigEndian.Uint32(table)
+		if uint64(length) > uint64(len(table)-4) {
+			return nil, errMalformed
+		}
+		if kind == 'S' {
+			values[name] = string(table[4 : 4+length])
+		}
+		table = table[4+length:]
+	}
+
+	return values, nil
+}
+
+// redisVersion reads redis_version from INFO server. Servers requiring
+// authentication answer with an error, which is reported as is.
+func redisVersion(conn net.Conn) (string, error) {
+	if _, err := conn.Write([]byte("*2\r\n$4\r\nINFO\r\n$6\r\nserver\r\n")); err != nil {
+		return "", err
+	}
+
+	reader := bufio.NewReader(io.LimitReader(conn, maxHandshakeBytes))
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("failed to read redis reply: %w", err)
+	}
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "-") {
+		return "", fmt.Errorf("redis: %s", strings.TrimPrefix(line, "-"))
+	}
+
+	length, err := strconv.Atoi(strings.TrimPrefix(line, "$"))
+	if !strings.HasPrefix(line, "$") || err != nil || length < 0 {
+		return "", fmt.Errorf("unexpected redis reply %q", line)
+	}
+	info := make([]byte, length)
+	if _, err := io.ReadFull(reader, info); err != nil {
+		return "", fmt.Errorf("failed to read redis reply: %w", err)
+	}
+
+	for _, entry := range strings.Split(string(info), "\n") {
+		if version, ok := strings.CutPrefix(strings.TrimSpace(entry), "redis_version:"); ok {
+			return version, nil
+		}
+	}
+	return "", errors.New("redis did not report a version")
+}
@@ -0,0 +1,108 @@
+package infrastructure
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// amqpStartFrame builds the Connection.Start frame a broker sends after the
+// protocol header
+func amqpStartFrame(properties [][3]string) []byte {
+	var table []byte
+	for _, property := range properties {
+		table = append(table, byte(len(property[0])))
+		table = append(table, property[0]...)
+		table = append(table, property[1][0])
+		switch property[1] {
+		case "S":
+			table = binary.BigEndian.AppendUint32(table, uint32(len(property[2])))
+			table = append(table, property[2]...)
+		case "t":
+			table = append(table, 1)
+		}
+	}
+
+	payload := []byte{0, 10, 0, 10, 0, 9}
+	payload = binary.BigEndian.AppendUint32(payload, uint32(len(table)))
+	payload = append(payload, table...)
+
+	frame := []byte{1, 0, 0}
+	frame = binary.BigEndian.AppendUint32(frame, uint32(len(payload)))
+	frame = append(frame, payload...)
+	return append(frame, 0xCE)
+}
+
+// serve answers the probe on the other end of a pipe once it has written
+// requestLen bytes
+func serve(t *testing.T, requestLen int, reply []byte) net.Conn {
+	client, server := net.Pipe()
+	t.Cleanup(func() { client.Close() })
+
+	go func() {
+		defer server.Close()
+		if _, err := io.ReadFull(server, make([]byte, requestLen)); err != nil {
+			return
+		}
+		_, _ = server.Write(reply)
+	}()
+
+	return client
+}
+
+func TestAMQPVersion_ReadsServerProperties(t *testing.T) {
+	// Given a broker announcing its product and version among other properties
+	conn := serve(t, 8, amqpStartFrame([][3]string{
+		{"capabilities", "t", ""},
+		{"product", "S", "RabbitMQ"},
+		{"version", "S", "3.13.7"},
+	}))
+
+	// When
+	version, err := amqpVersion(conn)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "RabbitMQ 3.13.7", version)
+}
+
+func TestAMQPVersion_RejectsUnexpectedFrame(t *testing.T) {
+	conn := serve(t, 8, []byte("HTTP/1.1 400 Bad Request\r\n\r\n"))
+
+	_, err := amqpVersion(conn)
+
+	assert.Error(t, err)
+}
+
+func TestAMQPStrings_RejectsTruncatedTable(t *testing.T) {
+	_, err := amqpStrings([]byte{0, 0, 0, 9, 7, 'v', 'e', 'r', 's'})
+
+	assert.Error(t, err)
+}
+
+func TestRedisVersion_ReadsInfoServer(t *testing.T) {
+	// Given
+	info := "# Server\r\nredis_version:7.2.4\r\nredis_mode:standalone\r\n"
+	conn := serve(t, 26, []byte("$"+strconv.Itoa(len(info))+"\r\n"+info+"\r\n"))
+
+	// When
+	version, err := redisVersion(conn)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "7.2.4", version)
+}
+
+func TestRedisVersion_ReportsServerError(t *testing.T) {
+	conn := serve(t, 26, []byte("-NOAUTH Authentication required.\r\n"))
+
+	_, err := redisVersion(conn)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "NOAUTH")
+}
@@ -0,0 +1,81 @@
+package infrastructure
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"user-service/internal/application/ports"
+)
+
+// FeatureMonitor tracks which optional features are usable from the health
+// reports of the components behind them. It only learns from checks that
+// actually run, i.e. readiness probes and the health check job, and considers
+// every feature usable until then.
+type FeatureMonitor struct {
+	features map[ports.Feature][]string
+
+	mu       sync.RWMutex
+	degraded map[ports.Feature]string
+}
+
+var _ ports.FeatureHealth = (*FeatureMonitor)(nil)
+
+// NewFeatureMonitor creates a monitor for features, mapped to the components
+// each needs
+func NewFeatureMonitor(features map[string][]string) *FeatureMonitor {
+	monitor := &FeatureMonitor{
+		features: make(map[ports.Feature][]string, len(features)),
+		degraded: make(map[ports.Feature]string),
+	}
+	for feature, components := range features {
+		monitor.features[ports.Feature(feature)] = components
+	}
+	return monitor
+}
+
+// Observe updates the features from a health report. A feature is degraded
+// while one of its components is unhealthy; slow components still serve it.
+// Components missing from the report are switched off and ignored.
+func (m *FeatureMonitor) Observe(report HealthReport) {
+	degraded := make(map[ports.Feature]string)
+	for feature, components := range m.features {
+		var reasons []string
+		for _, name := range components {
+			component, ok := report.Components[name]
+			if ok && component.Status == ports.HealthStatusUnhealthy {
+				reasons = append(reasons, fmt.Sprintf("%s is unhealthy: %s", name, component.Reason))
+			}
+		}
+		if len(reasons) > 0 {
+			degraded[feature] = strings.Join(reasons, "; ")
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.degraded = degraded
+}
+
+// Degraded implements ports.FeatureHealth
+func (m *FeatureMonitor) Degraded(feature ports.Feature) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	reason, ok := m.degraded[feature]
+	return reason, ok
+}
+
+// Degradations returns the degraded features, sorted by name
+func (m *FeatureMonitor) Degradations() []ports.Degradation {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	degradations := make([]ports.Degradation, 0, len(m.degraded))
+	for feature, reason := range m.degraded {
+		degradations = append(degradations, ports.Degradation{Feature: feature, Reason: reason})
+	}
+	sort.Slice(degradations, func(i, j int) bool { return degradations[i].Feature < degradations[j].Feature })
+	return degradations
+}
@@ -0,0 +1,52 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/application/ports"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeatureMonitor_FollowsComponentHealth(t *testing.T) {
+	// Given the cache feature backed by redis
+	registry := NewHealthRegistry([]string{"postgres"}, time.Second)
+	registry.Register("postgres", &switchableHealth{status: ports.HealthStatusHealthy})
+	redis := &switchableHealth{status: ports.HealthStatusUnhealthy, reason: "connection refused"}
+	registry.Register("redis", redis)
+	monitor := NewFeatureMonitor(map[string][]string{"cache": {"redis"}, "search": {"opensearch"}})
+	registry.Observe(monitor.Observe)
+
+	_, degraded := monitor.Degraded(ports.FeatureCache)
+	assert.False(t, degraded, "features are usable until a check says otherwise")
+
+	// When redis is down
+	report := registry.Check(context.Background())
+
+	// Then the service stays ready without its cache
+	assert.True(t, report.Ready)
+	reason, degraded := monitor.Degraded(ports.FeatureCache)
+	assert.True(t, degraded)
+	assert.Equal(t, "redis is unhealthy: connection refused", reason)
+	_, degraded = monitor.Degraded(ports.FeatureSearch)
+	assert.False(t, degraded, "components that are not registered are switched off")
+
+	// When redis recovers
+	redis.status = ports.HealthStatusDegraded
+	registry.Check(context.Background())
+
+	// Then a slow cache is still used
+	assert.Empty(t, monitor.Degradations())
+}
+
+// switchableHealth reports whatever health it is set to
+type switchableHealth struct {
+	status ports.HealthStatus
+	reason string
+}
+
+func (s *switchableHealth) CheckHealth(context.Context) ports.ComponentHealth {
+	return ports.ComponentHealth{Status: s.status, Reason: s.reason}
+}
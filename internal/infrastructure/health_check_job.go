@@ -0,0 +1,45 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"user-service/internal/application/ports"
+)
+
+// HealthCheckJob runs the registered health checks in the background, so an
+// unhealthy component shows up in the job history even when nothing polls the
+// health endpoints
+type HealthCheckJob struct {
+	registry *HealthRegistry
+}
+
+// NewHealthCheckJob creates a job checking every component of registry
+func NewHealthCheckJob(registry *HealthRegistry) ports.Job {
+	return &HealthCheckJob{registry: registry}
+}
+
+// Name implements ports.Job
+func (j *HealthCheckJob) Name() string {
+	return "health_check"
+}
+
+// Run implements ports.Job. It fails when any component is not healthy.
+func (j *HealthCheckJob) Run(ctx context.Context) error {
+	report := j.registry.Check(ctx)
+
+	var failing []string
+	for name, component := range report.Components {
+		if component.Status != ports.HealthStatusHealthy {
+			failing = append(failing, fmt.Sprintf("%s is %s: %s", name, component.Status, component.Reason))
+		}
+	}
+	if len(failing) == 0 {
+		return nil
+	}
+
+	sort.Strings(failing)
+	return fmt.Errorf("service is %s (%s)", report.Status, strings.Join(failing, "; "))
+}
@@ -0,0 +1,158 @@
+package infrastructure
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"user-service/internal/application/ports"
+)
+
+// ComponentReport is the health of one registered component
+type ComponentReport struct {
+	Name      string             `json:"-"`
+	Status    ports.HealthStatus `json:"status"`
+	Reason    string             `json:"reason,omitempty"`
+	Critical  bool               `json:"critical"`
+	LatencyMS int64              `json:"latency_ms"`
+}
+
+// HealthReport aggregates the health of every registered component
+type HealthReport struct {
+	Status     ports.HealthStatus         `json:"status"`
+	Score      int                        `json:"score"`
+	Ready      bool                       `json:"ready"`
+	Components map[string]ComponentReport `json:"components"`
+}
+
+type registeredComponent struct {
+	name     string
+	critical bool
+	checker  ports.ComponentHealthChecker
+}
+
+// HealthRegistry keeps track of dependencies and grades overall service health
+type HealthRegistry struct {
+	mu         sync.RWMutex
+	components []registeredComponent
+	critical   []string
+	timeout    time.Duration
+	observers  []func(HealthReport)
+}
+
+// NewHealthRegistry creates a registry; components named in critical fail readiness when unhealthy
+func NewHealthRegistry(critical []string, timeout time.Duration) *HealthRegistry {
+	return &HealthRegistry{
+		critical: critical,
+		timeout:  timeout,
+	}
+}
+
+// Register adds a component to the registry
+func (r *HealthRegistry) Register(name string, checker ports.ComponentHealthChecker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.components = append(r.components, registeredComponent{
+		name:     name,
+		critical: slices.Contains(r.critical, name),
+		checker:  checker,
+	})
+}
+
+// Observe calls fn with the report of every later check
+func (r *HealthRegistry) Observe(fn func(HealthReport)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.observers = append(r.observers, fn)
+}
+
+// Check runs every component check concurrently and grades the result.
+//
+// The overall status is unhealthy when a critical component is unhealthy,
+// degraded when anything else is not fully healthy, and healthy otherwise.
+// The score weights critical components twice as much as optional ones.
+func (r *HealthRegistry) Check(ctx context.Context) HealthReport {
+	r.mu.RLock()
+	components := slices.Clone(r.components)
+	observers := slices.Clone(r.observers)
+	r.mu.RUnlock()
+
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	reports := make([]ComponentReport, len(components))
+	var wg sync.WaitGroup
+	for i, component := range components {
+		wg.Add(1)
+		go func(i int, component registeredComponent) {
+			defer wg.Done()
+			result := component.checker.CheckHealth(ctx)
+			reports[i] = ComponentReport{
+				Name:      component.name,
+				Status:    result.Status,
+				Reason:    result.Reason,
+				Critical:  component.critical,
+				LatencyMS: result.Latency.Milliseconds(),
+			}
+		}(i, component)
+	}
+	wg.Wait()
+
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Name < reports[j].Name })
+
+	report := gradeReports(reports)
+	for _, observe := range observers {
+		observe(report)
+	}
+	return report
+}
+
+func gradeReports(reports []ComponentReport) HealthReport {
+	report := HealthReport{
+		Status:     ports.HealthStatusHealthy,
+		Ready:      true,
+		Components: make(map[string]ComponentReport, len(reports)),
+	}
+
+	var earned, possible float64
+	for _, component := range reports {
+		report.Components[component.Name] = component
+
+		weight := 1.0
+		if component.Critical {
+			weight = 2.0
+		}
+		possible += weight
+
+		switch component.Status {
+		case ports.HealthStatusHealthy:
+			earned += weight
+		case ports.HealthStatusDegraded:
+			earned += weight / 2
+			if report.Status == ports.HealthStatusHealthy {
+				report.Status = ports.HealthStatusDegraded
+			}
+		default:
+			if component.Critical {
+				report.Status = ports.HealthStatusUnhealthy
+				report.Ready = false
+			} else if report.Status == ports.HealthStatusHealthy {
+				report.Status = ports.HealthStatusDegraded
+			}
+		}
+	}
+
+	report.Score = 100
+	if possible > 0 {
+		report.Score = int(earned / possible * 100)
+	}
+
+	return report
+}
@@ -0,0 +1,103 @@
+package infrastructure
+
+import (
+	"context"
+	"net"
+	"sort"
+	"time"
+
+	"user-service/internal/adapters/persistence/schema"
+	"user-service/internal/config"
+	"user-service/pkg/logger"
+)
+
+// StartupReport summarises how the service booted. It is logged as a single
+// entry so incident timelines show exactly what was running.
+type StartupReport struct {
+	Environment    string
+	ServiceVersion string
+	ConfigSources  []string
+	OverriddenKeys []string
+	Features       []string
+	Dependencies   map[string]string
+	// SchemaVersion is the service version that last migrated the database
+	SchemaVersion  string
+	SchemaChecksum string
+	// SchemaCurrent reports whether the database matches this build's models
+	SchemaCurrent bool
+	Addresses     map[string]string
+}
+
+// NewStartupReport gathers the report, asking dependencies for their versions
+func NewStartupReport(ctx context.Context, cfg *config.Config, connections *DatabaseConnections) StartupReport {
+	report := StartupReport{
+		Environment:    cfg.Environment,
+		ServiceVersion: cfg.Version,
+		ConfigSources:  cfg.Sources,
+		OverriddenKeys: cfg.OverriddenKeys,
+		Features:       EnabledFeatures(cfg),
+		Dependencies:   connections.ServerVersions(ctx),
+		SchemaVersion:  "unknown",
+		Addresses: map[string]string{
+			"http": net.JoinHostPort(cfg.Server.Host, cfg.Server.Port),
+		},
+	}
+
+	db := connections.GetGormDB()
+	current, err := schema.Current(ctx, db)
+	switch {
+	case err != nil:
+		report.SchemaVersion = "unavailable: " + err.Error()
+	case current == nil:
+		report.SchemaVersion = "untracked"
+	default:
+		report.SchemaVersion = current.ServiceVersion
+		report.SchemaChecksum = current.Checksum
+		expected, err := schema.Checksum(db, schema.Models())
+		report.SchemaCurrent = err == nil && expected == current.Checksum
+	}
+
+	return report
+}
+
+// EnabledFeatures lists the optional features switched on in cfg, sorted
+func EnabledFeatures(cfg *config.Config) []string {
+	switches := map[string]bool{
+		"access_log":         cfg.Logging.Access.Enabled,
+		"bot_detection":      cfg.Security.BotDetection.Enabled,
+		"cache":              cfg.Cache.Enabled,
+		"chaos":              cfg.Chaos.Enabled && !cfg.IsProduction(),
+		"deadline":           cfg.Deadline.Enabled,
+		"deletion_checkers":  len(cfg.Deletion.Checkers) > 0,
+		"jobs":               cfg.Jobs.Enabled,
+		"messaging":          cfg.Messaging.Enabled,
+		"query_metrics":      cfg.Database.QueryMetrics,
+		"residency":          cfg.Residency.Enabled,
+		"slow_query_explain": cfg.Database.SlowQuery.Explain && (!cfg.IsProduction() || cfg.Database.SlowQuery.ExplainInProduction),
+	}
+
+	features := make([]string, 0, len(switches))
+	for name, enabled := range switches {
+		if enabled {
+			features = append(features, name)
+		}
+	}
+	sort.Strings(features)
+	return features
+}
+
+// Log writes the report as one structured entry
+func (r StartupReport) Log(log logger.Logger) {
+	log.Info("Service started",
+		"environment", r.Environment,
+		"service_version", r.ServiceVersion,
+		"config_sources", r.ConfigSources,
+		"overridden_keys", r.OverriddenKeys,
+		"features", r.Features,
+		"dependencies", r.Dependencies,
+		"schema_version", r.SchemaVersion,
+		"schema_checksum", r.SchemaChecksum,
+		"schema_current", r.SchemaCurrent,
+		"addresses", r.Addresses,
+		"started_at", time.Now().UTC().Format(time.RFC3339))
+}
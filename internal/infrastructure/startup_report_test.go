@@ -0,0 +1,34 @@
+package infrastructure
+
+import (
+	"testing"
+
+	"user-service/internal/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnabledFeatures_SortedAndOnlyEnabled(t *testing.T) {
+	// Given
+	cfg := &config.Config{
+		Environment: "development",
+		Messaging:   config.MessagingConfig{Enabled: true},
+		Jobs:        config.JobsConfig{Enabled: true},
+		Chaos:       config.ChaosConfig{Enabled: true},
+	}
+
+	// When
+	features := EnabledFeatures(cfg)
+
+	// Then
+	assert.Equal(t, []string{"chaos", "jobs", "messaging"}, features)
+}
+
+func TestEnabledFeatures_ChaosNeverReportedInProduction(t *testing.T) {
+	cfg := &config.Config{
+		Environment: "production",
+		Chaos:       config.ChaosConfig{Enabled: true},
+	}
+
+	assert.Empty(t, EnabledFeatures(cfg))
+}
@@ -0,0 +1,152 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// errorsDir holds the domain errors the catalog is generated from
+var errorsDir = filepath.Join("internal", "domain", "errors")
+
+// ErrorCatalogPath is where the error catalog is generated, relative to the
+// repository root
+var ErrorCatalogPath = filepath.Join(errorsDir, "catalog.go")
+
+var catalogTemplate = template.Must(template.New("catalog").Parse(`// Code generated by "user-service scaffold error-catalog"; DO NOT EDIT.
+
+package errors
+
+// Catalog lists every domain error, ordered by code
+var Catalog = []*DomainError{
+{{- range .}}
+	{{.}},
+{{- end}}
+}
+`))
+
+// ErrorCatalog renders the catalog of every DomainError declared in the
+// domain errors package under root. overlay holds files that are not written
+// yet, keyed by their path relative to root; they take precedence over the
+// files on disk.
+func ErrorCatalog(root string, overlay map[string][]byte) ([]byte, error) {
+	sources := make(map[string][]byte)
+	paths, err := filepath.Glob(filepath.Join(root, errorsDir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		relative, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil, err
+		}
+		if sources[relative], err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	for path, source := range overlay {
+		if filepath.Dir(path) == errorsDir && filepath.Ext(path) == ".go" {
+			sources[path] = source
+		}
+	}
+
+	owners := make(map[string]string)
+	for path, source := range sources {
+		if strings.HasSuffix(path, "_test.go") || path == ErrorCatalogPath {
+			continue
+		}
+		declared, err := declaredErrors(path, source)
+		if err != nil {
+			return nil, err
+		}
+		for name, code := range declared {
+			if owner, taken := owners[code]; taken {
+				return nil, fmt.Errorf("%s and %s share the code %s", owner, name, code)
+			}
+			owners[code] = name
+		}
+	}
+
+	codes := make([]string, 0, len(owners))
+	for code := range owners {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	names := make([]string, len(codes))
+	for i, code := range codes {
+		names[i] = owners[code]
+	}
+
+	var out bytes.Buffer
+	if err := catalogTemplate.Execute(&out, names); err != nil {
+		return nil, err
+	}
+	return format.Source(out.Bytes())
+}
+
+// declaredErrors returns the code of every package-level DomainError of a
+// source file by variable name
+func declaredErrors(path string, source []byte) (map[string]string, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), path, source, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	declared := make(map[string]string)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			for i, expr := range value.Values {
+				unary, ok := expr.(*ast.UnaryExpr)
+				if !ok {
+					continue
+				}
+				literal, ok := unary.X.(*ast.CompositeLit)
+				if !ok {
+					continue
+				}
+				if ident, ok := literal.Type.(*ast.Ident); !ok || ident.Name != "DomainError" {
+					continue
+				}
+				code, err := literalCode(literal)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %s: %w", path, value.Names[i].Name, err)
+				}
+				declared[value.Names[i].Name] = code
+			}
+		}
+	}
+	return declared, nil
+}
+
+// literalCode returns the Code field of a DomainError literal
+func literalCode(literal *ast.CompositeLit) (string, error) {
+	for _, elt := range literal.Elts {
+		field, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, ok := field.Key.(*ast.Ident); !ok || key.Name != "Code" {
+			continue
+		}
+		value, ok := field.Value.(*ast.BasicLit)
+		if !ok || value.Kind != token.STRING {
+			return "", fmt.Errorf("domain error codes must be string literals")
+		}
+		return strconv.Unquote(value.Value)
+	}
+	return "", fmt.Errorf("domain error without a code")
+}
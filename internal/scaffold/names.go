@@ -0,0 +1,137 @@
+package scaffold
+
+import (
+	"fmt"
+	"go/token"
+	"regexp"
+	"strings"
+)
+
+// namePattern accepts lower-case words separated by hyphens or underscores
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9]*([_-][a-z0-9]+)*$`)
+
+// reservedNames would clash with existing resources or with the packages the
+// generated code imports
+var reservedNames = map[string]bool{
+	"user": true, "users": true, "entities": true, "errors": true, "ports": true,
+	"dto": true, "usecases": true, "handlers": true, "logger": true, "http": true,
+	"echo": true, "gorm": true, "time": true, "strings": true, "strconv": true,
+	"context": true, "pagination": true, "auth": true, "mock": true,
+}
+
+// Names are the spellings of a resource name the templates need
+type Names struct {
+	// Type is the exported Go name, e.g. ShippingAddress
+	Type string
+	// Var is the unexported Go name, e.g. shippingAddress
+	Var string
+	// Receiver is the method receiver, e.g. s
+	Receiver string
+	// Snake is the name in snake case, e.g. shipping_address
+	Snake string
+	// Plural is the exported plural, e.g. ShippingAddresses
+	Plural string
+	// PluralVar is the unexported plural, e.g. shippingAddresses
+	PluralVar string
+	// PluralSnake is the plural in snake case, also the table name
+	PluralSnake string
+	// Path is the URL segment, e.g. shipping-addresses
+	Path string
+	// Human is the name in prose, e.g. shipping address
+	Human string
+	// PluralHuman is the plural in prose, e.g. shipping addresses
+	PluralHuman string
+	// Title is Human with a capital, e.g. Shipping address
+	Title string
+	// Code is the prefix of error codes, e.g. SHIPPING_ADDRESS
+	Code string
+	// Package is the repository package, e.g. shipping_address_repository
+	Package string
+}
+
+// NewNames derives the spellings of a resource name such as
+// "shipping-address"; plural overrides the derived plural when not empty
+func NewNames(name, plural string) (Names, error) {
+	words, err := splitName(name)
+	if err != nil {
+		return Names{}, err
+	}
+
+	var pluralWords []string
+	if plural != "" {
+		if pluralWords, err = splitName(plural); err != nil {
+			return Names{}, err
+		}
+	} else {
+		pluralWords = append(append([]string(nil), words[:len(words)-1]...), pluralize(words[len(words)-1]))
+	}
+
+	names := Names{
+		Type:        camel(words, true),
+		Var:         camel(words, false),
+		Receiver:    words[0][:1],
+		Snake:       strings.Join(words, "_"),
+		Plural:      camel(pluralWords, true),
+		PluralVar:   camel(pluralWords, false),
+		PluralSnake: strings.Join(pluralWords, "_"),
+		Path:        strings.Join(pluralWords, "-"),
+		Human:       strings.Join(words, " "),
+		PluralHuman: strings.Join(pluralWords, " "),
+		Code:        strings.ToUpper(strings.Join(words, "_")),
+		Package:     strings.Join(words, "_") + "_repository",
+	}
+	names.Title = strings.ToUpper(names.Human[:1]) + names.Human[1:]
+
+	for _, ident := range []string{names.Var, names.PluralVar, names.Snake, names.PluralSnake} {
+		if token.IsKeyword(ident) || reservedNames[ident] {
+			return Names{}, fmt.Errorf("%w: %q is reserved", ErrInvalidName, ident)
+		}
+	}
+	if names.Type == names.Plural {
+		return Names{}, fmt.Errorf("%w: %q has the same singular and plural", ErrInvalidName, name)
+	}
+	return names, nil
+}
+
+// splitName splits a hyphen or underscore separated name into its words
+func splitName(name string) ([]string, error) {
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("%w: %q must be lower-case words separated by '-' or '_'", ErrInvalidName, name)
+	}
+	return strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' }), nil
+}
+
+// pluralize applies the regular English plural rules; irregular plurals are
+// given explicitly
+func pluralize(word string) string {
+	switch {
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsAny(word[len(word)-2:len(word)-1], "aeiou"):
+		return word[:len(word)-1] + "ies"
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	default:
+		return word + "s"
+	}
+}
+
+// camel joins words in camel case, capitalizing the first one when exported
+func camel(words []string, exported bool) string {
+	var b strings.Builder
+	for i, word := range words {
+		if i == 0 && !exported {
+			b.WriteString(word)
+			continue
+		}
+		// Keep the repo's initialisms, e.g. OIDCClient and ExternalID
+		if upper := strings.ToUpper(word); initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// initialisms are written in upper case inside Go names
+var initialisms = map[string]bool{"ID": true, "URL": true, "API": true, "IP": true, "OIDC": true, "SMS": true}
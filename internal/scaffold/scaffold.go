@@ -0,0 +1,266 @@
+// Package scaffold generates the layers of a new user sub-resource, such as
+// addresses or notes, and wires them into the composition root. It also
+// generates the catalog of domain errors.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+var (
+	// ErrInvalidName is returned for resource names that cannot be generated
+	ErrInvalidName = errors.New("invalid resource name")
+	// ErrResourceExists is returned when a generated file or wiring already exists
+	ErrResourceExists = errors.New("resource already exists")
+	// ErrMarkerNotFound is returned when a file lacks the marker wiring is added at
+	ErrMarkerNotFound = errors.New("scaffold marker not found")
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+// generatedFile is a file rendered for each resource
+type generatedFile struct {
+	template string
+	path     string
+}
+
+// generatedFiles lists the templates and where their output goes
+func generatedFiles(n Names) []generatedFile {
+	return []generatedFile{
+		{"entity.go.tmpl", filepath.Join("internal", "domain", "entities", n.Snake+".go")},
+		{"errors.go.tmpl", filepath.Join("internal", "domain", "errors", n.Snake+".go")},
+		{"port.go.tmpl", filepath.Join("internal", "application", "ports", n.Snake+".go")},
+		{"repository.go.tmpl", filepath.Join("internal", "adapters", "persistence", n.Package, "gorm_"+n.Snake+"_repository.go")},
+		{"dto.go.tmpl", filepath.Join("internal", "application", "dto", n.Snake+".go")},
+		{"usecase.go.tmpl", filepath.Join("internal", "application", "usecases", n.Snake+".go")},
+		{"usecase_test.go.tmpl", filepath.Join("internal", "application", "usecases", n.Snake+"_test.go")},
+		{"handler.go.tmpl", filepath.Join("internal", "adapters", "http", "handlers", n.Snake+"_handler.go")},
+	}
+}
+
+// wiring adds generated code after a marker comment of an existing file
+type wiring struct {
+	path   string
+	marker string
+	// snippet is a template rendered with the resource names
+	snippet string
+	// imports are added to the file's import block
+	imports []string
+}
+
+// repositoryImport is the import path of the generated repository package
+func repositoryImport(n Names) string {
+	return "user-service/internal/adapters/persistence/" + n.Package
+}
+
+// wirings lists the changes to the composition root
+func wirings(n Names) []wiring {
+	return []wiring{
+		{
+			path:    filepath.Join("internal", "adapters", "http", "server.go"),
+			marker:  "scaffold:wiring",
+			snippet: "{{.Var}}Repo := {{.Package}}.NewGorm{{.Type}}Repository(s.connections.GetGormDB())\n{{.Var}}UseCases := usecases.New{{.Type}}UseCases(userRepo, {{.Var}}Repo, auditLogger, s.logger)\n{{.Var}}Handler := handlers.New{{.Type}}Handler({{.Var}}UseCases, s.logger)\n",
+			imports: []string{repositoryImport(n)},
+		},
+		{
+			path:    filepath.Join("internal", "adapters", "http", "server.go"),
+			marker:  "scaffold:routes",
+			snippet: "users.GET(\"/:id/{{.Path}}\", {{.Var}}Handler.List{{.Plural}}, pageSizeQuota)\nusers.POST(\"/:id/{{.Path}}\", {{.Var}}Handler.Create{{.Type}})\nusers.PUT(\"/:id/{{.Path}}/:{{.Snake}}_id\", {{.Var}}Handler.Update{{.Type}})\nusers.DELETE(\"/:id/{{.Path}}/:{{.Snake}}_id\", {{.Var}}Handler.Delete{{.Type}})\n",
+		},
+		{
+			path:    filepath.Join("internal", "adapters", "persistence", "schema", "schema.go"),
+			marker:  "scaffold:models",
+			snippet: "&{{.Package}}.{{.Type}}Model{},\n",
+			imports: []string{repositoryImport(n)},
+		},
+		{
+			path:    filepath.Join("internal", "adapters", "http", "handlers", "errors.go"),
+			marker:  "scaffold:errors",
+			snippet: "domainErrors.Err{{.Type}}NotFound.Code: {Status: http.StatusNotFound},\n",
+		},
+		{
+			path:    filepath.Join("internal", "adapters", "http", "handlers", "errors_contract_test.go"),
+			marker:  "scaffold:contract",
+			snippet: "{domainErrors.Err{{.Type}}NotFound, http.StatusNotFound, false},\n{domainErrors.ErrInvalid{{.Type}}Name, http.StatusBadRequest, false},\n",
+		},
+	}
+}
+
+// Generate renders a resource into the tree rooted at root, wires it into
+// the composition root and adds its errors to the error catalog. Nothing is written when any generated file already
+// exists or a marker is missing. It returns the files written or changed.
+func Generate(root string, names Names) ([]string, error) {
+	outputs, err := Plan(root, names)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(outputs))
+	for _, path := range sortedKeys(outputs) {
+		full := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			return paths, err
+		}
+		if err := os.WriteFile(full, outputs[path], 0o644); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// Plan renders the files Generate would write, keyed by their path relative
+// to root, without writing them
+func Plan(root string, names Names) (map[string][]byte, error) {
+	outputs := make(map[string][]byte)
+
+	for _, file := range generatedFiles(names) {
+		if _, err := os.Stat(filepath.Join(root, file.path)); err == nil {
+			return nil, fmt.Errorf("%w: %s", ErrResourceExists, file.path)
+		}
+		source, err := render(file.template, names)
+		if err != nil {
+			return nil, err
+		}
+		outputs[file.path] = source
+	}
+
+	for _, w := range wirings(names) {
+		source, ok := outputs[w.path]
+		if !ok {
+			var err error
+			if source, err = os.ReadFile(filepath.Join(root, w.path)); err != nil {
+				return nil, err
+			}
+		}
+
+		var snippet bytes.Buffer
+		if err := template.Must(template.New(w.marker).Parse(w.snippet)).Execute(&snippet, names); err != nil {
+			return nil, err
+		}
+
+		source, err := insertAfterMarker(source, w.marker, snippet.String())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", w.path, err)
+		}
+		for _, path := range w.imports {
+			source = addImport(source, path)
+		}
+		if source, err = format.Source(source); err != nil {
+			return nil, fmt.Errorf("%s: generated code does not compile: %w", w.path, err)
+		}
+		outputs[w.path] = source
+	}
+
+	// The resource's errors join the error catalog
+	catalog, err := ErrorCatalog(root, outputs)
+	if err != nil {
+		return nil, err
+	}
+	outputs[ErrorCatalogPath] = catalog
+
+	return outputs, nil
+}
+
+// render executes a template and formats the result
+func render(name string, names Names) ([]byte, error) {
+	tmpl, err := template.ParseFS(templates, "templates/"+name)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, names); err != nil {
+		return nil, err
+	}
+
+	source, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%s: generated code does not compile: %w", name, err)
+	}
+	return source, nil
+}
+
+// insertAfterMarker adds snippet to the block that starts with the marker
+// comment: after the lines following it at the same indentation, so repeated
+// resources are appended in order
+func insertAfterMarker(source []byte, marker, snippet string) ([]byte, error) {
+	lines := strings.Split(string(source), "\n")
+
+	at := -1
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "//") && strings.Contains(trimmed, marker) {
+			at = i
+			break
+		}
+	}
+	if at < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMarkerNotFound, marker)
+	}
+
+	indent := lines[at][:len(lines[at])-len(strings.TrimLeft(lines[at], "\t "))]
+	snippetLines := strings.Split(strings.TrimSuffix(snippet, "\n"), "\n")
+
+	end := at + 1
+	for ; end < len(lines); end++ {
+		line := lines[end]
+		if strings.TrimSpace(line) == "" || !strings.HasPrefix(line, indent) || strings.HasPrefix(strings.TrimPrefix(line, indent), "}") {
+			break
+		}
+		if sameCode(line, snippetLines[0]) {
+			return nil, fmt.Errorf("%w: %s is already wired", ErrResourceExists, marker)
+		}
+	}
+
+	inserted := make([]string, 0, len(lines)+len(snippetLines))
+	inserted = append(inserted, lines[:end]...)
+	for _, line := range snippetLines {
+		inserted = append(inserted, indent+line)
+	}
+	inserted = append(inserted, lines[end:]...)
+	return []byte(strings.Join(inserted, "\n")), nil
+}
+
+// sameCode reports whether two lines differ only in spacing, as formatting
+// aligns wired lines with their neighbours
+func sameCode(a, b string) bool {
+	return strings.Join(strings.Fields(a), " ") == strings.Join(strings.Fields(b), " ")
+}
+
+// addImport adds path to the project imports of source; formatting sorts it
+// into place
+func addImport(source []byte, path string) []byte {
+	spec := "\t\"" + path + "\""
+	text := string(source)
+	if strings.Contains(text, spec+"\n") {
+		return source
+	}
+
+	// The first project import starts the group the new one belongs to
+	at := strings.Index(text, "\n\t\"user-service/")
+	if at < 0 {
+		return source
+	}
+	return []byte(text[:at] + "\n" + spec + text[at:])
+}
+
+// sortedKeys returns the paths of outputs in a stable order
+func sortedKeys(outputs map[string][]byte) []string {
+	keys := make([]string, 0, len(outputs))
+	for key := range outputs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
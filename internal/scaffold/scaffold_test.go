@@ -0,0 +1,199 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// repoRoot is the repository this package lives in
+const repoRoot = "../.."
+
+// fixtureRoot holds a minimal composition root with every scaffold marker.
+// Generating into it, rather than into a copy of the repository, keeps the
+// tests working after resources were generated into the repository.
+var fixtureRoot = filepath.Join("testdata", "root")
+
+// copyFixture copies the fixture composition root into a fresh root
+func copyFixture(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	require.NoError(t, os.CopyFS(root, os.DirFS(fixtureRoot)))
+	return root
+}
+
+func readFile(t *testing.T, root, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(root, path))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestNewNames(t *testing.T) {
+	tests := []struct {
+		name, plural string
+		want         Names
+	}{
+		{"shipping-address", "", Names{Type: "ShippingAddress", Var: "shippingAddress", Plural: "ShippingAddresses", PluralSnake: "shipping_addresses", Path: "shipping-addresses", Code: "SHIPPING_ADDRESS", Package: "shipping_address_repository"}},
+		{"company", "", Names{Type: "Company", Var: "company", Plural: "Companies", PluralSnake: "companies", Path: "companies", Code: "COMPANY", Package: "company_repository"}},
+		{"device_key", "", Names{Type: "DeviceKey", Var: "deviceKey", Plural: "DeviceKeys", PluralSnake: "device_keys", Path: "device-keys", Code: "DEVICE_KEY", Package: "device_key_repository"}},
+		{"ip-allowance", "", Names{Type: "IPAllowance", Var: "ipAllowance", Plural: "IPAllowances", PluralSnake: "ip_allowances", Path: "ip-allowances", Code: "IP_ALLOWANCE", Package: "ip_allowance_repository"}},
+		{"person", "people", Names{Type: "Person", Var: "person", Plural: "People", PluralSnake: "people", Path: "people", Code: "PERSON", Package: "person_repository"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names, err := NewNames(tt.name, tt.plural)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Type, names.Type)
+			assert.Equal(t, tt.want.Var, names.Var)
+			assert.Equal(t, tt.want.Plural, names.Plural)
+			assert.Equal(t, tt.want.PluralSnake, names.PluralSnake)
+			assert.Equal(t, tt.want.Path, names.Path)
+			assert.Equal(t, tt.want.Code, names.Code)
+			assert.Equal(t, tt.want.Package, names.Package)
+		})
+	}
+}
+
+func TestNewNames_RejectsInvalidNames(t *testing.T) {
+	for _, name := range []string{"", "Address", "2fa", "shipping address", "address-", "user", "type", "time"} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewNames(name, "")
+
+			assert.ErrorIs(t, err, ErrInvalidName)
+		})
+	}
+}
+
+func TestWirings_MarkersArePresent(t *testing.T) {
+	// Given the composition root of this repository
+	names, err := NewNames("shipping-address", "")
+	require.NoError(t, err)
+
+	// Then every file wiring changes has its marker
+	for _, w := range wirings(names) {
+		assert.Contains(t, readFile(t, repoRoot, w.path), w.marker, w.path)
+	}
+}
+
+func TestGenerate_WritesAndWiresResource(t *testing.T) {
+	// Given a composition root
+	root := copyFixture(t)
+	names, err := NewNames("shipping-address", "")
+	require.NoError(t, err)
+
+	// When
+	paths, err := Generate(root, names)
+
+	// Then every layer is written and wired in
+	require.NoError(t, err)
+	for _, file := range generatedFiles(names) {
+		assert.Contains(t, paths, file.path)
+		assert.FileExists(t, filepath.Join(root, file.path))
+	}
+
+	server := readFile(t, root, filepath.Join("internal", "adapters", "http", "server.go"))
+	assert.Contains(t, server, `"user-service/internal/adapters/persistence/shipping_address_repository"`)
+	assert.Contains(t, server, "shippingAddressHandler := handlers.NewShippingAddressHandler(shippingAddressUseCases, s.logger)")
+	assert.Contains(t, server, `users.DELETE("/:id/shipping-addresses/:shipping_address_id", shippingAddressHandler.DeleteShippingAddress)`)
+
+	schema := readFile(t, root, filepath.Join("internal", "adapters", "persistence", "schema", "schema.go"))
+	assert.Contains(t, schema, "&shipping_address_repository.ShippingAddressModel{},")
+
+	errors := readFile(t, root, filepath.Join("internal", "adapters", "http", "handlers", "errors.go"))
+	assert.Contains(t, errors, "domainErrors.ErrShippingAddressNotFound.Code")
+
+	contract := readFile(t, root, filepath.Join("internal", "adapters", "http", "handlers", "errors_contract_test.go"))
+	assert.Contains(t, contract, "{domainErrors.ErrInvalidShippingAddressName, http.StatusBadRequest, false},")
+
+	handler := readFile(t, root, filepath.Join("internal", "adapters", "http", "handlers", "shipping_address_handler.go"))
+	assert.Contains(t, handler, "// ListShippingAddresses handles GET /api/v1/users/:id/shipping-addresses")
+	catalog := readFile(t, root, ErrorCatalogPath)
+	assert.Contains(t, paths, ErrorCatalogPath)
+	assert.Contains(t, catalog, "\tErrShippingAddressNotFound,\n")
+	assert.Contains(t, catalog, "\tErrUserNotFound,\n")
+}
+
+func TestErrorCatalog_IsUpToDate(t *testing.T) {
+	// When
+	catalog, err := ErrorCatalog(repoRoot, nil)
+
+	// Then the committed catalog matches, see "user-service scaffold error-catalog"
+	require.NoError(t, err)
+	assert.Equal(t, string(catalog), readFile(t, repoRoot, ErrorCatalogPath))
+}
+
+func TestErrorCatalog_RejectsSharedCodes(t *testing.T) {
+	// Given an error reusing the code of another
+	root := copyFixture(t)
+	shared := filepath.Join(errorsDir, "shared_code.go")
+	overlay := map[string][]byte{
+		shared: []byte("package errors\n\nvar ErrAnotherUserNotFound = &DomainError{Code: \"USER_NOT_FOUND\"}\n"),
+	}
+
+	// When
+	_, err := ErrorCatalog(root, overlay)
+
+	// Then
+	assert.ErrorContains(t, err, "share the code USER_NOT_FOUND")
+}
+
+func TestGenerate_AppendsResourcesInOrder(t *testing.T) {
+	// Given a root with one generated resource
+	root := copyFixture(t)
+	first, err := NewNames("address", "")
+	require.NoError(t, err)
+	_, err = Generate(root, first)
+	require.NoError(t, err)
+
+	// When another one is generated
+	second, err := NewNames("device", "")
+	require.NoError(t, err)
+	_, err = Generate(root, second)
+
+	// Then its wiring follows the first one
+	require.NoError(t, err)
+	server := readFile(t, root, filepath.Join("internal", "adapters", "http", "server.go"))
+	assert.Less(t, strings.Index(server, "addressHandler :="), strings.Index(server, "deviceHandler :="))
+	assert.Less(t, strings.Index(server, `"/:id/addresses"`), strings.Index(server, `"/:id/devices"`))
+}
+
+func TestGenerate_RefusesExistingResource(t *testing.T) {
+	// Given a generated resource
+	root := copyFixture(t)
+	names, err := NewNames("address", "")
+	require.NoError(t, err)
+	_, err = Generate(root, names)
+	require.NoError(t, err)
+	before := readFile(t, root, filepath.Join("internal", "adapters", "http", "server.go"))
+
+	// When it is generated again
+	_, err = Generate(root, names)
+
+	// Then nothing changes
+	assert.ErrorIs(t, err, ErrResourceExists)
+	assert.Equal(t, before, readFile(t, root, filepath.Join("internal", "adapters", "http", "server.go")))
+}
+
+func TestGenerate_RequiresMarkers(t *testing.T) {
+	// Given a schema without its marker
+	root := copyFixture(t)
+	schemaPath := filepath.Join(root, "internal", "adapters", "persistence", "schema", "schema.go")
+	schema := readFile(t, root, filepath.Join("internal", "adapters", "persistence", "schema", "schema.go"))
+	require.NoError(t, os.WriteFile(schemaPath, []byte(strings.ReplaceAll(schema, "scaffold:models", "")), 0o644))
+	names, err := NewNames("address", "")
+	require.NoError(t, err)
+
+	// When
+	_, err = Generate(root, names)
+
+	// Then no file is written
+	assert.ErrorIs(t, err, ErrMarkerNotFound)
+	assert.NoFileExists(t, filepath.Join(root, "internal", "domain", "entities", "address.go"))
+}
@@ -0,0 +1,12 @@
+package handlers
+
+import (
+	"net/http"
+
+	domainErrors "user-service/internal/domain/errors"
+)
+
+var domainErrorSpecs = map[string]errorSpec{
+	domainErrors.ErrUserNotFound.Code: {Status: http.StatusNotFound},
+	// Resources added by "user-service scaffold resource" (scaffold:errors)
+}
@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	"net/http"
+
+	domainErrors "user-service/internal/domain/errors"
+)
+
+var errorContract = []struct {
+	err       *domainErrors.DomainError
+	status    int
+	retryable bool
+}{
+	{domainErrors.ErrUserNotFound, http.StatusNotFound, false},
+
+	// Resources added by "user-service scaffold resource" (scaffold:contract)
+}
@@ -0,0 +1,22 @@
+package http
+
+import (
+	"user-service/internal/adapters/http/handlers"
+	"user-service/internal/application/usecases"
+)
+
+func (s *Server) setupRoutes() error {
+	userRepo := s.userRepository()
+	auditLogger := s.auditLogger()
+	userHandler := handlers.NewUserHandler(usecases.NewUserUseCases(userRepo), s.logger)
+
+	// Resources added by "user-service scaffold resource" (scaffold:wiring)
+
+	users := s.routes.Group("/api/v1/users")
+	{
+		users.GET("/:id", userHandler.GetUser)
+		// Resources added by "user-service scaffold resource" (scaffold:routes)
+	}
+
+	return nil
+}
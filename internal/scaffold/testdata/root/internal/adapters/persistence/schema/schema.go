@@ -0,0 +1,13 @@
+package schema
+
+import (
+	"user-service/internal/adapters/persistence/user_repository"
+)
+
+// Models returns every model stored in the primary database
+func Models() []interface{} {
+	return []interface{}{
+		&user_repository.UserModel{},
+		// Resources added by "user-service scaffold resource" (scaffold:models)
+	}
+}
@@ -0,0 +1,20 @@
+package errors
+
+type DomainError struct {
+	Code    string
+	Message string
+	Field   string
+}
+
+var (
+	ErrUserNotFound = &DomainError{
+		Code:    "USER_NOT_FOUND",
+		Message: "User not found",
+	}
+
+	ErrUserAlreadyExists = &DomainError{
+		Code:    "USER_ALREADY_EXISTS",
+		Message: "User with this email already exists",
+		Field:   "email",
+	}
+)
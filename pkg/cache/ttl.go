@@ -0,0 +1,108 @@
+// pkg/cache/ttl.go
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// TTL is a concurrency-safe in-memory cache whose entries expire after a fixed
+// time to live. When full, expired entries are dropped first, then the entry
+// closest to expiry.
+type TTL[K comparable, V any] struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[K]entry[V]
+	now        func() time.Time
+}
+
+// NewTTL creates a cache holding at most maxEntries entries for ttl each
+func NewTTL[K comparable, V any](ttl time.Duration, maxEntries int) *TTL[K, V] {
+	return &TTL[K, V]{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[K]entry[V]),
+		now:        time.Now,
+	}
+}
+
+// Get returns the cached value for key if present and not expired
+func (c *TTL[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	if c.now().After(cached.expiresAt) {
+		delete(c.entries, key)
+		var zero V
+		return zero, false
+	}
+
+	return cached.value, true
+}
+
+// Set stores value under key
+func (c *TTL[K, V]) Set(key K, value V) {
+	if c.ttl <= 0 || c.maxEntries <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= c.maxEntries {
+		c.evict()
+	}
+
+	c.entries[key] = entry[V]{value: value, expiresAt: c.now().Add(c.ttl)}
+}
+
+// Delete removes key from the cache
+func (c *TTL[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// Len returns the number of entries, including expired ones not yet evicted
+func (c *TTL[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
+
+// evict makes room for one entry; callers must hold the lock
+func (c *TTL[K, V]) evict() {
+	now := c.now()
+
+	var oldestKey K
+	var oldest time.Time
+	found := false
+
+	for key, cached := range c.entries {
+		if now.After(cached.expiresAt) {
+			delete(c.entries, key)
+			continue
+		}
+		if !found || cached.expiresAt.Before(oldest) {
+			oldestKey, oldest, found = key, cached.expiresAt, true
+		}
+	}
+
+	if len(c.entries) >= c.maxEntries && found {
+		delete(c.entries, oldestKey)
+	}
+}
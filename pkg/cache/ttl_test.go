@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTTL_GetAndExpire(t *testing.T) {
+	now := time.Now()
+	c := NewTTL[string, bool](time.Minute, 10)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", true)
+	value, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.True(t, value)
+
+	now = now.Add(2 * time.Minute)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestTTL_EvictsEntryClosestToExpiry(t *testing.T) {
+	now := time.Now()
+	c := NewTTL[int, string](time.Minute, 2)
+	c.now = func() time.Time { return now }
+
+	c.Set(1, "first")
+	now = now.Add(time.Second)
+	c.Set(2, "second")
+	c.Set(3, "third")
+
+	_, ok := c.Get(1)
+	assert.False(t, ok)
+	_, ok = c.Get(2)
+	assert.True(t, ok)
+	_, ok = c.Get(3)
+	assert.True(t, ok)
+}
+
+func TestTTL_DisabledWithZeroTTL(t *testing.T) {
+	c := NewTTL[string, bool](0, 10)
+
+	c.Set("a", true)
+
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+}
+
+func TestTTL_Delete(t *testing.T) {
+	c := NewTTL[string, bool](time.Minute, 10)
+
+	c.Set("a", true)
+	c.Delete("a")
+
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+}
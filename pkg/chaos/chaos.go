@@ -0,0 +1,17 @@
+// pkg/chaos/chaos.go
+package chaos
+
+import "context"
+
+type dropDBKey struct{}
+
+// WithDroppedDB marks the context so database calls made with it fail as if the connection dropped
+func WithDroppedDB(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dropDBKey{}, true)
+}
+
+// DroppedDB reports whether database calls made with ctx should fail
+func DroppedDB(ctx context.Context) bool {
+	dropped, _ := ctx.Value(dropDBKey{}).(bool)
+	return dropped
+}
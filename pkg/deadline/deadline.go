@@ -0,0 +1,102 @@
+// Package deadline carries request time budgets across service boundaries
+package deadline
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// HeaderTimeout carries the caller's remaining budget as a Go duration
+	// ("1.5s", "250ms") or a number of milliseconds
+	HeaderTimeout = "X-Request-Timeout"
+	// HeaderGRPCTimeout carries the budget in gRPC wire format ("250m", "2S")
+	HeaderGRPCTimeout = "Grpc-Timeout"
+)
+
+var grpcUnits = map[byte]time.Duration{
+	'H': time.Hour,
+	'M': time.Minute,
+	'S': time.Second,
+	'm': time.Millisecond,
+	'u': time.Microsecond,
+	'n': time.Nanosecond,
+}
+
+// ParseTimeout parses an X-Request-Timeout value
+func ParseTimeout(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if ms < 0 {
+			return 0, fmt.Errorf("negative timeout %q", value)
+		}
+		return time.Duration(ms) * time.Millisecond, nil
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %q", value)
+	}
+	if timeout < 0 {
+		return 0, fmt.Errorf("negative timeout %q", value)
+	}
+	return timeout, nil
+}
+
+// ParseGRPCTimeout parses a grpc-timeout value: at most 8 digits and a unit
+func ParseGRPCTimeout(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 2 || len(value) > 9 {
+		return 0, fmt.Errorf("invalid grpc-timeout %q", value)
+	}
+
+	unit, ok := grpcUnits[value[len(value)-1]]
+	if !ok {
+		return 0, fmt.Errorf("invalid grpc-timeout unit in %q", value)
+	}
+	amount, err := strconv.ParseUint(value[:len(value)-1], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid grpc-timeout %q", value)
+	}
+	return time.Duration(amount) * unit, nil
+}
+
+// FromHeader returns the budget carried by a request, preferring
+// X-Request-Timeout over grpc-timeout
+func FromHeader(header http.Header) (time.Duration, bool, error) {
+	if value := header.Get(HeaderTimeout); value != "" {
+		timeout, err := ParseTimeout(value)
+		return timeout, true, err
+	}
+	if value := header.Get(HeaderGRPCTimeout); value != "" {
+		timeout, err := ParseGRPCTimeout(value)
+		return timeout, true, err
+	}
+	return 0, false, nil
+}
+
+// Remaining returns the budget left before the deadline of ctx
+func Remaining(ctx context.Context) (time.Duration, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	return max(time.Until(deadline), 0), true
+}
+
+// FormatTimeout formats a budget as an X-Request-Timeout value
+func FormatTimeout(timeout time.Duration) string {
+	return strconv.FormatInt(timeout.Milliseconds(), 10) + "ms"
+}
+
+// Propagate stamps an outbound request with the budget left in its context,
+// so the next service gives up when this one would no longer wait for it
+func Propagate(req *http.Request) {
+	if remaining, ok := Remaining(req.Context()); ok {
+		req.Header.Set(HeaderTimeout, FormatTimeout(remaining))
+	}
+}
@@ -0,0 +1,108 @@
+package deadline
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeout(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{value: "250", expected: 250 * time.Millisecond},
+		{value: "1.5s", expected: 1500 * time.Millisecond},
+		{value: "250ms", expected: 250 * time.Millisecond},
+		{value: "0", expected: 0},
+		{value: "-5", wantErr: true},
+		{value: "-1s", wantErr: true},
+		{value: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			timeout, err := ParseTimeout(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, timeout)
+		})
+	}
+}
+
+func TestParseGRPCTimeout(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{value: "100m", expected: 100 * time.Millisecond},
+		{value: "2S", expected: 2 * time.Second},
+		{value: "1M", expected: time.Minute},
+		{value: "5u", expected: 5 * time.Microsecond},
+		{value: "100", wantErr: true},
+		{value: "123456789m", wantErr: true},
+		{value: "10x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			timeout, err := ParseGRPCTimeout(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, timeout)
+		})
+	}
+}
+
+func TestFromHeader_PrefersRequestTimeout(t *testing.T) {
+	header := http.Header{}
+	header.Set(HeaderGRPCTimeout, "5S")
+	header.Set(HeaderTimeout, "250ms")
+
+	timeout, ok, err := FromHeader(header)
+
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 250*time.Millisecond, timeout)
+}
+
+func TestFromHeader_Absent(t *testing.T) {
+	_, ok, err := FromHeader(http.Header{})
+
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestPropagate(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.test", nil)
+	require.NoError(t, err)
+
+	Propagate(req)
+
+	remaining, err := ParseTimeout(req.Header.Get(HeaderTimeout))
+	require.NoError(t, err)
+	assert.InDelta(t, time.Minute, remaining, float64(time.Second))
+}
+
+func TestPropagate_WithoutDeadline(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "http://example.test", nil)
+	require.NoError(t, err)
+
+	Propagate(req)
+
+	assert.Empty(t, req.Header.Get(HeaderTimeout))
+}
@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// AccessOptions configures the dedicated HTTP access log
+type AccessOptions struct {
+	// Output is "stdout", "stderr" or a file path; files are rotated
+	Output string
+	// Format is one of json, console or logfmt; empty keeps the environment default
+	Format string
+	// SampleRate is the fraction of successful requests written; warnings and
+	// errors (4xx/5xx) are always written
+	SampleRate float64
+	// MaxSizeMB, MaxBackups and MaxAgeDays bound the retention of file outputs
+	MaxSizeMB  int
+	MaxBackups int
+	MaxAgeDays int
+}
+
+// NewAccessLogger builds a logger writing to its own output, separate from the
+// application logs, so pipelines can route and retain access logs differently
+func NewAccessLogger(env string, opts AccessOptions) (Logger, error) {
+	var output zapcore.WriteSyncer
+	switch strings.ToLower(opts.Output) {
+	case "", "stdout":
+		output = zapcore.Lock(os.Stdout)
+	case "stderr":
+		output = zapcore.Lock(os.Stderr)
+	default:
+		output = zapcore.AddSync(&lumberjack.Logger{
+			Filename:   opts.Output,
+			MaxSize:    opts.MaxSizeMB,
+			MaxBackups: opts.MaxBackups,
+			MaxAge:     opts.MaxAgeDays,
+		})
+	}
+
+	return newAccessLogger(env, opts, output)
+}
+
+func newAccessLogger(env string, opts AccessOptions, output zapcore.WriteSyncer) (*zapLogger, error) {
+	if opts.SampleRate < 0 || opts.SampleRate > 1 {
+		return nil, fmt.Errorf("invalid access log sample rate %v", opts.SampleRate)
+	}
+
+	settings := getEnvSettings(env)
+	if opts.Format != "" {
+		settings.format = strings.ToLower(opts.Format)
+	}
+
+	encoder, err := newEncoder(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every request is an access log entry regardless of the application level
+	var core zapcore.Core = zapcore.NewCore(encoder, output, zapcore.DebugLevel)
+	if opts.SampleRate < 1 {
+		core = &samplingCore{Core: core, rate: opts.SampleRate}
+	}
+
+	base := zap.New(core, zap.ErrorOutput(output))
+	if len(settings.initialFields) > 0 {
+		base = base.With(settings.initialFields...)
+	}
+
+	return &zapLogger{
+		sugar: base.Sugar(),
+		base:  base,
+	}, nil
+}
+
+// samplingCore keeps a random fraction of entries below warning level
+type samplingCore struct {
+	zapcore.Core
+	rate float64
+}
+
+func (c *samplingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &samplingCore{Core: c.Core.With(fields), rate: c.rate}
+}
+
+func (c *samplingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if entry.Level < zapcore.WarnLevel && rand.Float64() >= c.rate {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
@@ -0,0 +1,119 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+var logfmtPool = buffer.NewPool()
+
+// logfmtEncoder renders entries as key=value pairs. Fields are collected
+// through a map encoder and written in key order, after the entry's own
+// time, level, caller and message keys.
+type logfmtEncoder struct {
+	*zapcore.MapObjectEncoder
+	config zapcore.EncoderConfig
+}
+
+func newLogfmtEncoder(config zapcore.EncoderConfig) zapcore.Encoder {
+	return &logfmtEncoder{
+		MapObjectEncoder: zapcore.NewMapObjectEncoder(),
+		config:           config,
+	}
+}
+
+func (e *logfmtEncoder) Clone() zapcore.Encoder {
+	clone := zapcore.NewMapObjectEncoder()
+	for key, value := range e.Fields {
+		clone.Fields[key] = value
+	}
+	return &logfmtEncoder{MapObjectEncoder: clone, config: e.config}
+}
+
+func (e *logfmtEncoder) EncodeEntry(entry zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	line := logfmtPool.Get()
+
+	if e.config.TimeKey != "" {
+		writeLogfmtPair(line, e.config.TimeKey, entry.Time.Format(time.RFC3339Nano))
+	}
+	if e.config.LevelKey != "" {
+		writeLogfmtPair(line, e.config.LevelKey, entry.Level.String())
+	}
+	if e.config.NameKey != "" && entry.LoggerName != "" {
+		writeLogfmtPair(line, e.config.NameKey, entry.LoggerName)
+	}
+	if e.config.CallerKey != "" && entry.Caller.Defined {
+		writeLogfmtPair(line, e.config.CallerKey, entry.Caller.TrimmedPath())
+	}
+	if e.config.MessageKey != "" {
+		writeLogfmtPair(line, e.config.MessageKey, entry.Message)
+	}
+
+	encoder := zapcore.NewMapObjectEncoder()
+	for key, value := range e.Fields {
+		encoder.Fields[key] = value
+	}
+	for _, field := range fields {
+		field.AddTo(encoder)
+	}
+
+	keys := make([]string, 0, len(encoder.Fields))
+	for key := range encoder.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		writeLogfmtPair(line, key, formatLogfmtValue(encoder.Fields[key]))
+	}
+
+	if e.config.StacktraceKey != "" && entry.Stack != "" {
+		writeLogfmtPair(line, e.config.StacktraceKey, entry.Stack)
+	}
+
+	line.AppendString(e.config.LineEnding)
+	if e.config.LineEnding == "" {
+		line.AppendString(zapcore.DefaultLineEnding)
+	}
+
+	return line, nil
+}
+
+func formatLogfmtValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	case map[string]interface{}, []interface{}:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(encoded)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func writeLogfmtPair(line *buffer.Buffer, key, value string) {
+	if line.Len() > 0 {
+		line.AppendByte(' ')
+	}
+	line.AppendString(key)
+	line.AppendByte('=')
+
+	if value == "" || strings.ContainsAny(value, " =\"\t\r\n") {
+		line.AppendString(fmt.Sprintf("%q", value))
+		return
+	}
+	line.AppendString(value)
+}
@@ -2,12 +2,21 @@
 package logger
 
 import (
+	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+	FormatLogfmt  = "logfmt"
+)
+
 type Logger interface {
 	Debug(msg string, args ...interface{})
 	Info(msg string, args ...interface{})
@@ -19,62 +28,195 @@ type Logger interface {
 	Sync() error
 }
 
+// Options overrides the environment defaults used to build a logger
+type Options struct {
+	// Level is the minimum level logged; empty keeps the environment default
+	Level string
+	// Format is one of json, console or logfmt; empty keeps the environment default
+	Format string
+	// Components overrides the level for loggers tagged with a "component" field,
+	// e.g. {"gorm": "warn", "http": "info"}
+	Components map[string]string
+}
+
 type zapLogger struct {
-	sugar *zap.SugaredLogger
-	base  *zap.Logger
+	sugar      *zap.SugaredLogger
+	base       *zap.Logger
+	components map[string]zapcore.Level
 }
 
 func New(env string) Logger {
-	config := getZapConfig(env)
+	log, err := NewWithOptions(env, Options{})
+	if err != nil {
+		panic("Failed to initialize logging: " + err.Error())
+	}
+	return log
+}
 
-	base, err := config.Build(
+// NewWithOptions builds a logger for the environment, applying the configured
+// format, level and per-component level overrides
+func NewWithOptions(env string, opts Options) (Logger, error) {
+	return newZapLogger(env, opts, zapcore.Lock(os.Stderr))
+}
+
+func newZapLogger(env string, opts Options, output zapcore.WriteSyncer) (*zapLogger, error) {
+	settings := getEnvSettings(env)
+
+	if opts.Level != "" {
+		level, err := zapcore.ParseLevel(opts.Level)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level %q: %w", opts.Level, err)
+		}
+		settings.level = level
+	}
+
+	if opts.Format != "" {
+		settings.format = strings.ToLower(opts.Format)
+	}
+
+	encoder, err := newEncoder(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	components := make(map[string]zapcore.Level, len(opts.Components))
+	minLevel := settings.level
+	for component, levelName := range opts.Components {
+		level, err := zapcore.ParseLevel(levelName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level %q for component %q: %w", levelName, component, err)
+		}
+		components[strings.ToLower(component)] = level
+		minLevel = min(minLevel, level)
+	}
+
+	// The inner core accepts everything any component may log; the level
+	// filter decides per logger what actually gets written
+	var core zapcore.Core = zapcore.NewCore(encoder, output, minLevel)
+	if settings.sampling {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, 100, 100)
+	}
+	core = &levelFilterCore{Core: core, level: settings.level}
+
+	options := []zap.Option{
+		zap.AddCaller(),
 		zap.AddCallerSkip(1), // Skip one level to show the actual caller
 		zap.AddStacktrace(zapcore.ErrorLevel),
-	)
-	if err != nil {
-		panic("Failed to initialize logging: " + err.Error())
+		zap.ErrorOutput(output),
+	}
+	if settings.development {
+		options = append(options, zap.Development())
 	}
 
-	return &zapLogger{
-		sugar: base.Sugar(),
-		base:  base,
+	base := zap.New(core, options...)
+	if len(settings.initialFields) > 0 {
+		base = base.With(settings.initialFields...)
 	}
+
+	return &zapLogger{
+		sugar:      base.Sugar(),
+		base:       base,
+		components: components,
+	}, nil
+}
+
+// envSettings are the logging defaults for an environment
+type envSettings struct {
+	level         zapcore.Level
+	format        string
+	development   bool
+	sampling      bool
+	encoder       zapcore.EncoderConfig
+	initialFields []zap.Field
 }
 
-func getZapConfig(env string) zap.Config {
+func getEnvSettings(env string) envSettings {
 	switch strings.ToLower(env) {
 	case "development", "dev":
-		config := zap.NewDevelopmentConfig()
-		config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
-		config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-		config.EncoderConfig.EncodeCaller = zapcore.ShortCallerEncoder
-		return config
+		encoder := zap.NewDevelopmentEncoderConfig()
+		encoder.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		encoder.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoder.EncodeCaller = zapcore.ShortCallerEncoder
+		return envSettings{
+			level:       zapcore.DebugLevel,
+			format:      FormatConsole,
+			development: true,
+			encoder:     encoder,
+		}
 
 	case "production", "prod":
-		config := zap.NewProductionConfig()
-		config.EncoderConfig.TimeKey = "timestamp"
-		config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-		config.EncoderConfig.LevelKey = "level"
-		config.EncoderConfig.CallerKey = "caller"
-		config.EncoderConfig.MessageKey = "message"
-		config.EncoderConfig.StacktraceKey = "stacktrace"
-
-		// Add service information to all logs
-		config.InitialFields = map[string]interface{}{
-			"service": "user-service",
-			"version": "1.0.0",
+		encoder := zap.NewProductionEncoderConfig()
+		encoder.TimeKey = "timestamp"
+		encoder.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoder.LevelKey = "level"
+		encoder.CallerKey = "caller"
+		encoder.MessageKey = "message"
+		encoder.StacktraceKey = "stacktrace"
+		return envSettings{
+			level:    zapcore.InfoLevel,
+			format:   FormatJSON,
+			sampling: true,
+			encoder:  encoder,
+			// Add service information to all logs
+			initialFields: []zap.Field{
+				zap.String("service", "user-service"),
+				zap.String("version", "1.0.0"),
+			},
 		}
-		return config
 
 	default:
-		config := zap.NewProductionConfig()
-		config.Level = zap.NewAtomicLevelAt(zapcore.InfoLevel)
-		config.EncoderConfig.TimeKey = "timestamp"
-		config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-		return config
+		encoder := zap.NewProductionEncoderConfig()
+		encoder.TimeKey = "timestamp"
+		encoder.EncodeTime = zapcore.ISO8601TimeEncoder
+		return envSettings{
+			level:    zapcore.InfoLevel,
+			format:   FormatJSON,
+			sampling: true,
+			encoder:  encoder,
+		}
 	}
 }
 
+func newEncoder(settings envSettings) (zapcore.Encoder, error) {
+	encoderConfig := settings.encoder
+
+	switch settings.format {
+	case FormatJSON:
+		// Color codes would corrupt machine-readable output
+		encoderConfig.EncodeLevel = zapcore.LowercaseLevelEncoder
+		return zapcore.NewJSONEncoder(encoderConfig), nil
+	case FormatConsole, "text":
+		return zapcore.NewConsoleEncoder(encoderConfig), nil
+	case FormatLogfmt:
+		encoderConfig.EncodeLevel = zapcore.LowercaseLevelEncoder
+		return newLogfmtEncoder(encoderConfig), nil
+	default:
+		return nil, fmt.Errorf("unsupported log format %q", settings.format)
+	}
+}
+
+// levelFilterCore gates a core by a level that can differ per logger, which
+// is how component overrides are applied
+type levelFilterCore struct {
+	zapcore.Core
+	level zapcore.Level
+}
+
+func (c *levelFilterCore) Enabled(level zapcore.Level) bool {
+	return c.level.Enabled(level)
+}
+
+func (c *levelFilterCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelFilterCore{Core: c.Core.With(fields), level: c.level}
+}
+
+func (c *levelFilterCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.level.Enabled(entry.Level) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
+
 func (l *zapLogger) Debug(msg string, args ...interface{}) {
 	l.sugar.Debugw(msg, args...)
 }
@@ -96,12 +238,42 @@ func (l *zapLogger) Fatal(msg string, args ...interface{}) {
 }
 
 func (l *zapLogger) With(fields ...interface{}) Logger {
+	sugar := l.sugar.With(fields...)
+
+	if level, ok := l.componentLevel(fields); ok {
+		sugar = sugar.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			if filter, ok := core.(*levelFilterCore); ok {
+				return &levelFilterCore{Core: filter.Core, level: level}
+			}
+			return core
+		}))
+	}
+
 	return &zapLogger{
-		sugar: l.sugar.With(fields...),
-		base:  l.base,
+		sugar:      sugar,
+		base:       l.base,
+		components: l.components,
 	}
 }
 
+// componentLevel returns the override for a "component" field among the
+// key/value pairs, if one is configured
+func (l *zapLogger) componentLevel(fields []interface{}) (zapcore.Level, bool) {
+	if len(l.components) == 0 {
+		return 0, false
+	}
+
+	for i := 0; i+1 < len(fields); i += 2 {
+		if key, ok := fields[i].(string); ok && key == "component" {
+			component, _ := fields[i+1].(string)
+			level, ok := l.components[strings.ToLower(component)]
+			return level, ok
+		}
+	}
+
+	return 0, false
+}
+
 func (l *zapLogger) Sync() error {
 	return l.sugar.Sync()
 }
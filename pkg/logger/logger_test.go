@@ -0,0 +1,133 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func newTestLogger(t *testing.T, opts Options) (*zapLogger, *bytes.Buffer) {
+	var out bytes.Buffer
+	log, err := newZapLogger("test", opts, zapcore.AddSync(&out))
+	require.NoError(t, err)
+	return log, &out
+}
+
+func TestNewWithOptions_JSONFormat(t *testing.T) {
+	// Given
+	log, out := newTestLogger(t, Options{Format: FormatJSON})
+
+	// When
+	log.Info("user created", "user_id", 42)
+
+	// Then
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &entry))
+	assert.Equal(t, "user created", entry["msg"])
+	assert.Equal(t, "info", entry["level"])
+	assert.Equal(t, float64(42), entry["user_id"])
+}
+
+func TestNewWithOptions_LogfmtFormat(t *testing.T) {
+	// Given
+	log, out := newTestLogger(t, Options{Format: FormatLogfmt})
+
+	// When
+	log.With("component", "http").Info("request completed", "status", 200, "path", "/api/v1/users list")
+
+	// Then
+	line := out.String()
+	assert.Contains(t, line, "level=info")
+	assert.Contains(t, line, `msg="request completed"`)
+	assert.Contains(t, line, "component=http")
+	assert.Contains(t, line, `path="/api/v1/users list"`)
+	assert.Contains(t, line, "status=200")
+	assert.True(t, strings.HasSuffix(line, "\n"))
+}
+
+func TestNewWithOptions_LevelFromOptions(t *testing.T) {
+	// Given
+	log, out := newTestLogger(t, Options{Format: FormatJSON, Level: "warn"})
+
+	// When
+	log.Info("ignored")
+	log.Warn("kept")
+
+	// Then
+	assert.NotContains(t, out.String(), "ignored")
+	assert.Contains(t, out.String(), "kept")
+}
+
+func TestNewWithOptions_ComponentOverrides(t *testing.T) {
+	// Given
+	log, out := newTestLogger(t, Options{
+		Format:     FormatJSON,
+		Level:      "info",
+		Components: map[string]string{"gorm": "warn", "http": "debug"},
+	})
+
+	// When
+	log.With("component", "gorm").Info("gorm info")
+	log.With("component", "gorm").Warn("gorm warn")
+	log.With("component", "http").Debug("http debug")
+	log.With("component", "user_handler").Debug("handler debug")
+	log.With("component", "user_handler").Info("handler info")
+
+	// Then
+	output := out.String()
+	assert.NotContains(t, output, "gorm info")
+	assert.Contains(t, output, "gorm warn")
+	assert.Contains(t, output, "http debug")
+	assert.NotContains(t, output, "handler debug")
+	assert.Contains(t, output, "handler info")
+}
+
+func TestNewWithOptions_RejectsInvalidSettings(t *testing.T) {
+	_, err := NewWithOptions("test", Options{Format: "xml"})
+	assert.Error(t, err)
+
+	_, err = NewWithOptions("test", Options{Level: "loud"})
+	assert.Error(t, err)
+
+	_, err = NewWithOptions("test", Options{Components: map[string]string{"gorm": "loud"}})
+	assert.Error(t, err)
+}
+
+func TestNewAccessLogger_WritesToOwnOutput(t *testing.T) {
+	// Given
+	var out bytes.Buffer
+	access, err := newAccessLogger("production", AccessOptions{Format: FormatLogfmt, SampleRate: 1}, zapcore.AddSync(&out))
+	require.NoError(t, err)
+
+	// When
+	access.Debug("HTTP request completed", "status", 200)
+
+	// Then
+	assert.Contains(t, out.String(), "status=200")
+	assert.Contains(t, out.String(), "service=user-service")
+}
+
+func TestNewAccessLogger_SamplesOnlySuccessfulRequests(t *testing.T) {
+	// Given
+	var out bytes.Buffer
+	access, err := newAccessLogger("test", AccessOptions{Format: FormatJSON, SampleRate: 0}, zapcore.AddSync(&out))
+	require.NoError(t, err)
+
+	// When
+	access.Debug("HTTP request completed", "status", 200)
+	access.Warn("HTTP request completed", "status", 404)
+
+	// Then
+	assert.Equal(t, 1, strings.Count(out.String(), "\n"))
+	assert.Contains(t, out.String(), "404")
+}
+
+func TestNewAccessLogger_RejectsInvalidSampleRate(t *testing.T) {
+	_, err := NewAccessLogger("test", AccessOptions{SampleRate: 1.5})
+	assert.Error(t, err)
+}
@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaskEmail hides the local part of an email address except its first
+// character, e.g. "john.doe@example.com" becomes "j***@example.com", so logs
+// can still be correlated by domain without holding the address
+func MaskEmail(email string) string {
+	if email == "" {
+		return ""
+	}
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return "***"
+	}
+	if local == "" {
+		return "***@" + domain
+	}
+	first, _ := utf8.DecodeRuneInString(local)
+	return string(first) + "***@" + domain
+}
+
+// MaskEmailsInURI masks every path segment and query value of a request URI
+// that holds an email address, whether or not it is percent-encoded. Masked
+// values are left unescaped to keep access logs readable.
+func MaskEmailsInURI(uri string) string {
+	path, rawQuery, hasQuery := strings.Cut(uri, "?")
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if decoded, err := url.PathUnescape(segment); err == nil && strings.Contains(decoded, "@") {
+			segments[i] = MaskEmail(decoded)
+		}
+	}
+	masked := strings.Join(segments, "/")
+
+	if !hasQuery {
+		return masked
+	}
+
+	pairs := strings.Split(rawQuery, "&")
+	for i, pair := range pairs {
+		key, value, _ := strings.Cut(pair, "=")
+		if decoded, err := url.QueryUnescape(value); err == nil && strings.Contains(decoded, "@") {
+			pairs[i] = key + "=" + MaskEmail(decoded)
+		}
+	}
+	return masked + "?" + strings.Join(pairs, "&")
+}
+
+// MaskPhone hides every digit of a phone number but the last four, e.g.
+// "+44 20 7946 0958" becomes "***0958"
+func MaskPhone(phone string) string {
+	var digits []rune
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits = append(digits, r)
+		}
+	}
+	if len(digits) == 0 {
+		return ""
+	}
+	if len(digits) <= 4 {
+		return "***"
+	}
+	return "***" + string(digits[len(digits)-4:])
+}
@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskEmail(t *testing.T) {
+	assert.Equal(t, "j***@example.com", MaskEmail("john.doe@example.com"))
+	assert.Equal(t, "***@example.com", MaskEmail("@example.com"))
+	assert.Equal(t, "***", MaskEmail("not-an-email"))
+	assert.Equal(t, "", MaskEmail(""))
+	assert.Equal(t, "é***@example.com", MaskEmail("élodie@example.com"))
+}
+
+func TestMaskPhone(t *testing.T) {
+	assert.Equal(t, "***0958", MaskPhone("+44 20 7946 0958"))
+	assert.Equal(t, "***", MaskPhone("0958"))
+	assert.Equal(t, "", MaskPhone(""))
+}
+
+func TestMaskEmailsInURI(t *testing.T) {
+	tests := []struct {
+		name     string
+		uri      string
+		expected string
+	}{
+		{
+			name:     "path segment",
+			uri:      "/api/v1/users/email/john@example.com",
+			expected: "/api/v1/users/email/j***@example.com",
+		},
+		{
+			name:     "encoded path segment",
+			uri:      "/api/v1/users/email/john%2Bwork%40example.com",
+			expected: "/api/v1/users/email/j***@example.com",
+		},
+		{
+			name:     "query value",
+			uri:      "/api/v1/users?email=john%2Bwork%40example.com&page=2",
+			expected: "/api/v1/users?email=j***@example.com&page=2",
+		},
+		{
+			name:     "nothing to mask",
+			uri:      "/api/v1/users/42?tag=vip",
+			expected: "/api/v1/users/42?tag=vip",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MaskEmailsInURI(tt.uri))
+		})
+	}
+}
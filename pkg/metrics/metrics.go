@@ -0,0 +1,236 @@
+// pkg/metrics/metrics.go
+package metrics
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Registry holds the in-process counters and histograms exposed by the metrics endpoint
+type Registry struct {
+	mu         sync.RWMutex
+	counters   map[string]*Counter
+	histograms map[string]*Histogram
+}
+
+// NewRegistry creates an empty metrics registry
+func NewRegistry() *Registry {
+	return &Registry{
+		counters:   make(map[string]*Counter),
+		histograms: make(map[string]*Histogram),
+	}
+}
+
+// Counter returns the counter registered under name, creating it if needed
+func (r *Registry) Counter(name string) *Counter {
+	r.mu.RLock()
+	counter, ok := r.counters[name]
+	r.mu.RUnlock()
+	if ok {
+		return counter
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if counter, ok := r.counters[name]; ok {
+		return counter
+	}
+
+	counter = &Counter{values: make(map[string]uint64)}
+	r.counters[name] = counter
+	return counter
+}
+
+// Histogram returns the histogram registered under name, creating it with the
+// given upper bucket bounds if needed
+func (r *Registry) Histogram(name string, buckets []float64) *Histogram {
+	r.mu.RLock()
+	histogram, ok := r.histograms[name]
+	r.mu.RUnlock()
+	if ok {
+		return histogram
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if histogram, ok := r.histograms[name]; ok {
+		return histogram
+	}
+
+	bounds := slices.Clone(buckets)
+	sort.Float64s(bounds)
+
+	histogram = &Histogram{buckets: bounds, series: make(map[string]*histogramSeries)}
+	r.histograms[name] = histogram
+	return histogram
+}
+
+// HistogramSnapshot returns a copy of every histogram keyed by name and label set
+func (r *Registry) HistogramSnapshot() map[string]map[string]HistogramValue {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snapshot := make(map[string]map[string]HistogramValue, len(r.histograms))
+	for name, histogram := range r.histograms {
+		snapshot[name] = histogram.snapshot()
+	}
+	return snapshot
+}
+
+// Snapshot returns a copy of every counter keyed by name and label set
+func (r *Registry) Snapshot() map[string]map[string]uint64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snapshot := make(map[string]map[string]uint64, len(r.counters))
+	for name, counter := range r.counters {
+		snapshot[name] = counter.snapshot()
+	}
+	return snapshot
+}
+
+// Counter is a monotonically increasing value partitioned by labels
+type Counter struct {
+	mu     sync.Mutex
+	values map[string]uint64
+}
+
+// Inc increments the counter for the given key/value label pairs
+func (c *Counter) Inc(labels ...string) {
+	c.Add(1, labels...)
+}
+
+// Add increases the counter by n for the given key/value label pairs
+func (c *Counter) Add(n uint64, labels ...string) {
+	key := labelKey(labels)
+
+	c.mu.Lock()
+	c.values[key] += n
+	c.mu.Unlock()
+}
+
+// Value returns the current value for the given key/value label pairs
+func (c *Counter) Value(labels ...string) uint64 {
+	key := labelKey(labels)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.values[key]
+}
+
+func (c *Counter) snapshot() map[string]uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	values := make(map[string]uint64, len(c.values))
+	for key, value := range c.values {
+		values[key] = value
+	}
+	return values
+}
+
+// DefaultLatencyBuckets are upper bounds in seconds suited to request and query latencies
+var DefaultLatencyBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5}
+
+// Histogram counts observations into buckets, partitioned by labels
+type Histogram struct {
+	mu      sync.Mutex
+	buckets []float64
+	series  map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// HistogramValue is a point-in-time copy of one histogram series. Buckets are
+// cumulative and keyed by their upper bound, with "+Inf" counting everything.
+type HistogramValue struct {
+	Buckets map[string]uint64 `json:"buckets"`
+	Count   uint64            `json:"count"`
+	Sum     float64           `json:"sum"`
+}
+
+// Observe records a value for the given key/value label pairs
+func (h *Histogram) Observe(value float64, labels ...string) {
+	key := labelKey(labels)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	series, ok := h.series[key]
+	if !ok {
+		series = &histogramSeries{counts: make([]uint64, len(h.buckets))}
+		h.series[key] = series
+	}
+
+	for i, bound := range h.buckets {
+		if value <= bound {
+			series.counts[i]++
+		}
+	}
+	series.count++
+	series.sum += value
+}
+
+// Value returns the current state of the series for the given key/value label pairs
+func (h *Histogram) Value(labels ...string) HistogramValue {
+	key := labelKey(labels)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.value(h.series[key])
+}
+
+func (h *Histogram) snapshot() map[string]HistogramValue {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	values := make(map[string]HistogramValue, len(h.series))
+	for key, series := range h.series {
+		values[key] = h.value(series)
+	}
+	return values
+}
+
+func (h *Histogram) value(series *histogramSeries) HistogramValue {
+	value := HistogramValue{Buckets: make(map[string]uint64, len(h.buckets)+1)}
+	if series == nil {
+		series = &histogramSeries{counts: make([]uint64, len(h.buckets))}
+	}
+
+	for i, bound := range h.buckets {
+		value.Buckets[strconv.FormatFloat(bound, 'g', -1, 64)] = series.counts[i]
+	}
+	value.Buckets["+Inf"] = series.count
+	value.Count = series.count
+	value.Sum = series.sum
+	return value
+}
+
+// labelKey renders label pairs as a stable "k1=v1,k2=v2" key
+func labelKey(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, (len(labels)+1)/2)
+	for i := 0; i < len(labels); i += 2 {
+		value := ""
+		if i+1 < len(labels) {
+			value = labels[i+1]
+		}
+		pairs = append(pairs, labels[i]+"="+value)
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
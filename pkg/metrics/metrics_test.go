@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounter_LabelOrderDoesNotMatter(t *testing.T) {
+	registry := NewRegistry()
+	counter := registry.Counter("requests_total")
+
+	counter.Inc("route", "/users", "status", "200")
+	counter.Add(2, "status", "200", "route", "/users")
+
+	assert.Equal(t, uint64(3), counter.Value("route", "/users", "status", "200"))
+	assert.Equal(t, uint64(3), registry.Snapshot()["requests_total"]["route=/users,status=200"])
+}
+
+func TestHistogram_CumulativeBuckets(t *testing.T) {
+	registry := NewRegistry()
+	histogram := registry.Histogram("latency_seconds", []float64{0.5, 0.1})
+
+	histogram.Observe(0.05, "op", "query")
+	histogram.Observe(0.2, "op", "query")
+	histogram.Observe(3, "op", "query")
+
+	value := histogram.Value("op", "query")
+	assert.Equal(t, uint64(1), value.Buckets["0.1"])
+	assert.Equal(t, uint64(2), value.Buckets["0.5"])
+	assert.Equal(t, uint64(3), value.Buckets["+Inf"])
+	assert.Equal(t, uint64(3), value.Count)
+	assert.InDelta(t, 3.25, value.Sum, 1e-9)
+}
+
+func TestHistogram_ReturnsExistingHistogram(t *testing.T) {
+	registry := NewRegistry()
+
+	first := registry.Histogram("latency_seconds", DefaultLatencyBuckets)
+	second := registry.Histogram("latency_seconds", []float64{1})
+
+	assert.Same(t, first, second)
+	assert.Equal(t, uint64(0), second.Value().Count)
+	assert.Contains(t, registry.HistogramSnapshot(), "latency_seconds")
+}
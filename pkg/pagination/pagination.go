@@ -0,0 +1,42 @@
+// Package pagination keeps page sizes and cursors consistent across listings
+package pagination
+
+// Limits bound the pages of a listing. Upper bounds per caller are enforced
+// by the quota middleware before handlers read the page size.
+type Limits struct {
+	// DefaultSize is the page size of requests that ask for none
+	DefaultSize int
+	// MaxSize caps page sizes; 0 leaves them to the quota middleware
+	MaxSize int
+}
+
+// DefaultLimits are the limits of listings without their own
+var DefaultLimits = Limits{DefaultSize: 20}
+
+// Size returns the page size to serve for a requested one
+func (l Limits) Size(requested int) int {
+	if requested < 1 {
+		return l.DefaultSize
+	}
+	if l.MaxSize > 0 && requested > l.MaxSize {
+		return l.MaxSize
+	}
+	return requested
+}
+
+// Page returns the page and page size to serve for requested ones. Pages
+// are numbered from 1.
+func (l Limits) Page(page, size int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	return page, l.Size(size)
+}
+
+// Offset returns the number of items before a page
+func Offset(page, size int) int {
+	if page < 1 {
+		return 0
+	}
+	return (page - 1) * size
+}
@@ -0,0 +1,69 @@
+package pagination
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKey = "pagination-test-key-of-32-characters"
+
+func TestLimits_Page(t *testing.T) {
+	limits := Limits{DefaultSize: 20, MaxSize: 100}
+
+	page, size := limits.Page(0, 0)
+	assert.Equal(t, 1, page)
+	assert.Equal(t, 20, size)
+
+	page, size = limits.Page(3, 500)
+	assert.Equal(t, 3, page)
+	assert.Equal(t, 100, size)
+
+	assert.Equal(t, 40, Offset(3, 20))
+	assert.Equal(t, 0, Offset(0, 20))
+}
+
+func TestCodec_RoundTrip(t *testing.T) {
+	codec, err := NewCodec(testKey)
+	require.NoError(t, err)
+
+	token := codec.Encode(Cursor{Sort: "created_at,id", Keys: []string{"2024-05-01T12:00:00Z", "42"}})
+	cursor, err := codec.Decode(token, "created_at,id")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2024-05-01T12:00:00Z", "42"}, cursor.Keys)
+}
+
+func TestCodec_RejectsInvalidCursors(t *testing.T) {
+	codec, err := NewCodec(testKey)
+	require.NoError(t, err)
+	other, err := NewCodec("another-pagination-key-of-32-chars")
+	require.NoError(t, err)
+
+	token := codec.Encode(Cursor{Sort: "id", Keys: []string{"42"}})
+	encoded, signature, _ := strings.Cut(token, ".")
+	forged := other.Encode(Cursor{Sort: "id", Keys: []string{"1"}})
+	forgedEncoded, _, _ := strings.Cut(forged, ".")
+
+	for name, candidate := range map[string]string{
+		"empty":        "",
+		"not a cursor": "42",
+		"other key":    other.Encode(Cursor{Sort: "id", Keys: []string{"42"}}),
+		"tampered":     forgedEncoded + "." + signature,
+		"truncated":    encoded + "." + signature[:10],
+		"wrong keys":   codec.Encode(Cursor{Sort: "id", Keys: []string{"1", "2"}}),
+	} {
+		_, err := codec.Decode(candidate, "id")
+		assert.ErrorIs(t, err, ErrInvalidCursor, name)
+	}
+
+	_, err = codec.Decode(token, "created_at,id")
+	assert.ErrorIs(t, err, ErrInvalidCursor, "other order")
+}
+
+func TestNewCodec_RejectsShortKeys(t *testing.T) {
+	_, err := NewCodec("short")
+	assert.Error(t, err)
+}
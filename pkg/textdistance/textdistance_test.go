@@ -0,0 +1,28 @@
+package textdistance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "", b: "", expected: 0},
+		{a: "", b: "abc", expected: 3},
+		{a: "kitten", b: "sitting", expected: 3},
+		{a: "jane doe", b: "jane doe", expected: 0},
+		{a: "müller", b: "muller", expected: 1},
+		{a: "firstname", b: "first_name", expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Levenshtein(tt.a, tt.b))
+			assert.Equal(t, tt.expected, Levenshtein(tt.b, tt.a))
+		})
+	}
+}